@@ -0,0 +1,129 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/handlebargh/yatto/internal/helpers"
+	"github.com/handlebargh/yatto/internal/hooks"
+	"github.com/handlebargh/yatto/internal/items"
+	"github.com/handlebargh/yatto/internal/vcs"
+	"github.com/spf13/cobra"
+)
+
+var (
+	addProject  string
+	addTitle    string
+	addPriority string
+	addDue      string
+	addLabels   string
+	addRemind   string
+)
+
+// addCmd represents the add command
+var addCmd = &cobra.Command{
+	Use:   "add",
+	Short: "Add a task without opening the TUI",
+	Example: `  yatto add --project Work --title "Write report" --priority high --due tomorrow
+  yatto add -p Home -t "Buy milk" -l "shopping, errands"
+  yatto add -p Work -t "Submit taxes" -d "2026-05-31 12:00" --remind "1w, 1d"`,
+	RunE: func(_ *cobra.Command, _ []string) error {
+		if strings.TrimSpace(addTitle) == "" {
+			return errors.New("title must not be empty")
+		}
+
+		if !slices.Contains([]string{"low", "medium", "high"}, addPriority) {
+			return fmt.Errorf("invalid priority %q (valid: low, medium, high)", addPriority)
+		}
+
+		if err := setupApp(); err != nil {
+			return err
+		}
+
+		project, err := findProject(appConfig.Viper, addProject)
+		if err != nil {
+			return err
+		}
+
+		task := &items.Task{
+			ID:       uuid.NewString(),
+			Title:    addTitle,
+			Priority: addPriority,
+			Labels:   helpers.LabelsStringToSlice(addLabels),
+		}
+
+		if addDue != "" {
+			dueDate, err := helpers.ParseDueDate(addDue)
+			if err != nil {
+				return fmt.Errorf("invalid due date %q: %w", addDue, err)
+			}
+			task.DueDate = &dueDate
+		}
+
+		reminders, err := items.ParseReminders(addRemind)
+		if err != nil {
+			return err
+		}
+		task.Reminders = reminders
+
+		// Ignore error just like the task form does.
+		task.Author, _ = vcs.User(appConfig.Viper)
+
+		if err := runCmd(vcs.InitCmd(appConfig.Viper)); err != nil {
+			return err
+		}
+
+		if err := runCmd(task.WriteTaskJSON(appConfig.Viper, *project, "create")); err != nil {
+			return err
+		}
+
+		if err := runCmd(vcs.CommitCmd(
+			appConfig.Viper,
+			fmt.Sprintf("create: %s", task.Title),
+			filepath.Join(project.ID, task.ID+".json"),
+		)); err != nil {
+			return err
+		}
+		warnHooks(hooks.RunAll(appConfig.Viper, task.HookCalls(*project, "create")))
+
+		fmt.Printf("Created task %s in project %s\n", task.ID, project.Title)
+
+		return nil
+	},
+}
+
+func init() {
+	addCmd.Flags().StringVarP(&addProject, "project", "p", "", "Project title or UUID to add the task to")
+	addCmd.Flags().StringVarP(&addTitle, "title", "t", "", "Title of the task")
+	addCmd.Flags().StringVarP(&addPriority, "priority", "P", "low", "Priority of the task (low, medium, high)")
+	addCmd.Flags().StringVarP(&addDue, "due", "d", "", "Due date, e.g. \"tomorrow\", \"in 3 days\" or \"2026-02-14 15:04\"")
+	addCmd.Flags().StringVarP(&addLabels, "labels", "l", "", "Comma-separated list of labels")
+	addCmd.Flags().StringVarP(&addRemind, "remind", "r", "", "Lead times before the due date to be reminded at, e.g. \"1d, 1h\"")
+	_ = addCmd.MarkFlagRequired("project")
+	_ = addCmd.MarkFlagRequired("title")
+	rootCmd.AddCommand(addCmd)
+}
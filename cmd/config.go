@@ -26,7 +26,6 @@ import (
 
 	"github.com/handlebargh/yatto/internal/config"
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
 )
 
 // configCmd represents the config command
@@ -34,10 +33,8 @@ var configCmd = &cobra.Command{
 	Use:   "config",
 	Short: "Manage configuration settings",
 	PersistentPreRunE: func(_ *cobra.Command, _ []string) error {
-		appCtx := &AppContext{viper.GetViper()}
-
 		setCfg := config.Settings{
-			Viper:      appCtx.Viper,
+			Viper:      appConfig.Viper,
 			ConfigPath: configPath,
 			Home:       homePath,
 			Input:      os.Stdin,
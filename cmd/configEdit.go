@@ -16,23 +16,19 @@
 package cmd
 
 import (
-	"fmt"
 	"os"
 	"os/exec"
-	"regexp"
 
+	"github.com/handlebargh/yatto/internal/helpers"
 	"github.com/spf13/cobra"
 )
 
 var (
 	// ErrNoEditorSet is returned when the EDITOR environment variable is empty.
-	ErrNoEditorSet = fmt.Errorf("environment variable EDITOR not set")
+	ErrNoEditorSet = helpers.ErrNoEditorSet
 
 	// ErrInvalidEditorSet is returned when the EDITOR environment variable contains illegal characters.
-	ErrInvalidEditorSet = fmt.Errorf("environment variable EDITOR contains illegal characters")
-
-	// editorRegexp validates the executable part of EDITOR.
-	editorRegexp = regexp.MustCompile(`^[a-zA-Z0-9 _/\\.\-:]+$`)
+	ErrInvalidEditorSet = helpers.ErrInvalidEditorSet
 )
 
 // configEditCmd represents the config edit command
@@ -40,11 +36,9 @@ var configEditCmd = &cobra.Command{
 	Use:   "edit",
 	Short: "Edit the configuration file",
 	RunE: func(_ *cobra.Command, _ []string) error {
-		editor := os.Getenv("EDITOR")
-		if editor == "" {
-			return ErrNoEditorSet
-		} else if !editorRegexp.MatchString(editor) {
-			return ErrInvalidEditorSet
+		editor, err := helpers.Editor()
+		if err != nil {
+			return err
 		}
 
 		cmd := exec.Command(editor, configPath) // #nosec G204 Command uses validated variables
@@ -0,0 +1,187 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/huh"
+	"github.com/handlebargh/yatto/internal/doctor"
+	"github.com/handlebargh/yatto/internal/vcs"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var doctorFix bool
+
+// doctorCmd represents the doctor command
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Short: "Check the storage directory for problems",
+	Long: `Check the storage directory for problems, such as files that yatto skips
+because they cannot be read.
+
+The following problems are reported:
+  - project, task and state files that cannot be parsed or do not match
+    their schema, see 'yatto schema'
+  - task files outside of any project
+  - temporary files left behind by interrupted writes
+  - tasks sharing their ID with another task, e.g. after copying task files
+  - tasks assigned to someone who never committed to the repository
+  - a missing repository, unfinished merges or rebases and uncommitted changes
+
+With --fix, temporary files and unparsable state files are removed, copies
+of tasks sharing an ID get a new one and uncommitted changes to valid files
+are committed. All other problems have
+to be resolved manually. The command exits with a non-zero status if any
+problem remains.`,
+	Example: `  yatto doctor
+  yatto doctor --fix`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(_ *cobra.Command, _ []string) error {
+		if err := setupApp(); err != nil {
+			return err
+		}
+
+		problems, err := doctor.Check(appConfig.Viper)
+		if err != nil {
+			return err
+		}
+
+		if len(problems) == 0 {
+			fmt.Println("No problems found")
+			return nil
+		}
+
+		var (
+			remaining int
+			fixed     []string
+			commit    []string
+		)
+
+		for _, problem := range problems {
+			if !doctorFix || problem.Fix == nil {
+				hint := ""
+				if problem.Fix != nil {
+					hint = " (fixable)"
+				}
+				fmt.Printf("✗ %s%s\n", problem, hint)
+				remaining++
+				continue
+			}
+
+			files, err := problem.Fix()
+			if err != nil {
+				fmt.Printf("✗ %s (fix failed: %v)\n", problem, err)
+				remaining++
+				continue
+			}
+
+			fmt.Printf("✓ %s (fixed)\n", problem)
+			fixed = append(fixed, problem.String())
+			commit = append(commit, files...)
+		}
+
+		if len(commit) > 0 {
+			slices.Sort(commit)
+			commit = slices.Compact(commit)
+
+			message := fmt.Sprintf("doctor: commit %d pending change(s)\n\n- %s",
+				len(commit), strings.Join(commit, "\n- "))
+
+			if err := runCmd(vcs.CommitCmd(appConfig.Viper, message, commit...)); err != nil {
+				return err
+			}
+		}
+
+		if remaining > 0 {
+			if doctorFix {
+				return fmt.Errorf("%d problem(s) fixed, %d remaining", len(fixed), remaining)
+			}
+			return fmt.Errorf("%d problem(s) found", remaining)
+		}
+
+		fmt.Printf("%d problem(s) fixed\n", len(fixed))
+
+		return nil
+	},
+}
+
+// resolveDuplicateIDs asks the user whether tasks sharing their ID with
+// another task, see doctor.DuplicateIDs, should get a new ID and commits
+// the changes. Declined duplicates are reported again on the next start.
+func resolveDuplicateIDs(v *viper.Viper) error {
+	problems, err := doctor.DuplicateIDs(v)
+	if err != nil || len(problems) == 0 {
+		return err
+	}
+
+	lines := make([]string, 0, len(problems))
+	for _, problem := range problems {
+		lines = append(lines, problem.String())
+	}
+
+	var reassign bool
+	form := huh.NewForm(
+		huh.NewGroup(
+			huh.NewConfirm().
+				Title(fmt.Sprintf("Give %d duplicate task(s) a new ID?", len(problems))).
+				Description(strings.Join(lines, "\n")).
+				Affirmative("Yes").
+				Negative("No").
+				Value(&reassign),
+		),
+	)
+
+	if err := form.Run(); err != nil {
+		if errors.Is(err, huh.ErrUserAborted) {
+			os.Exit(0)
+		}
+		return err
+	}
+
+	if !reassign {
+		return nil
+	}
+
+	var commit []string
+	for _, problem := range problems {
+		files, err := problem.Fix()
+		commit = append(commit, files...)
+		if err != nil {
+			return fmt.Errorf("%s: %w", problem.Path, err)
+		}
+	}
+
+	return runCmd(vcs.CommitCmd(v,
+		fmt.Sprintf("doctor: reassign %d duplicate task ID(s)", len(problems)),
+		commit...))
+}
+
+func init() {
+	doctorCmd.Flags().BoolVar(&doctorFix, "fix", false, "Repair problems that can be fixed safely")
+	rootCmd.AddCommand(doctorCmd)
+}
@@ -0,0 +1,92 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/handlebargh/yatto/internal/items"
+	"github.com/spf13/cobra"
+)
+
+var (
+	doneProject string
+	doneReopen  bool
+)
+
+// doneCmd represents the done command
+var doneCmd = &cobra.Command{
+	Use:   "done <task>...",
+	Short: "Mark tasks as completed",
+	Long: `Mark tasks as completed without opening the TUI.
+
+Tasks are selected by their UUID or by a unique, case-insensitive prefix of their title.`,
+	Example: `  yatto done 2023255a-1749-4f6c-9877-0c73ab42e5ab
+  yatto done --project Work "write rep"
+  yatto done --reopen "write rep"`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(_ *cobra.Command, args []string) error {
+		if err := setupApp(); err != nil {
+			return err
+		}
+
+		if doneReopen {
+			return changeTaskState(doneProject, args, "completion", "reopen",
+				func(_ *items.Project, t *items.Task) (bool, error) {
+					if !t.Completed {
+						return false, nil
+					}
+					t.Completed = false
+					return true, nil
+				})
+		}
+
+		return changeTaskState(doneProject, args, "completion", "complete",
+			func(p *items.Project, t *items.Task) (bool, error) {
+				if t.Completed {
+					return false, nil
+				}
+
+				tasks, err := p.ReadTasksFromFS(appConfig.Viper)
+				if err := warnSkipped(err); err != nil {
+					return false, err
+				}
+
+				if blockers := t.OpenBlockers(tasks); len(blockers) > 0 {
+					var titles []string
+					for _, b := range blockers {
+						titles = append(titles, fmt.Sprintf("%q", b.Title))
+					}
+					return false, fmt.Errorf("blocked by open task(s) %s", strings.Join(titles, ", "))
+				}
+				t.Completed = true
+				t.InProgress = false
+				return true, nil
+			})
+	},
+}
+
+func init() {
+	doneCmd.Flags().StringVarP(&doneProject, "project", "p", "", "Only consider tasks of this project (title or UUID)")
+	doneCmd.Flags().BoolVarP(&doneReopen, "reopen", "r", false, "Reopen completed tasks instead")
+	rootCmd.AddCommand(doneCmd)
+}
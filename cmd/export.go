@@ -0,0 +1,96 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/handlebargh/yatto/internal/bundle"
+	"github.com/spf13/cobra"
+)
+
+var (
+	exportProjects []string
+	exportOutput   string
+)
+
+// exportCmd represents the export command
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Export projects into a single backup file",
+	Long: `Export projects with their tasks, archived tasks and attachments into a
+single JSON bundle, e.g. as a backup or to move them to another machine
+without sharing the remote. Import it with "yatto import --format yatto".
+
+All projects are exported unless projects are given by title or UUID.
+The bundle is written to standard output unless a file is given.`,
+	Example: `  yatto export > backup.json
+  yatto export --project Work --project Home --output work-and-home.json`,
+	Args: cobra.NoArgs,
+	RunE: func(_ *cobra.Command, _ []string) error {
+		if err := setupApp(); err != nil {
+			return err
+		}
+
+		var ids []string
+		for _, query := range exportProjects {
+			project, err := findProject(appConfig.Viper, query)
+			if err != nil {
+				return err
+			}
+			ids = append(ids, project.ID)
+		}
+
+		b, err := bundle.Export(appConfig.Viper, ids...)
+		if err := warnSkipped(err); err != nil {
+			return err
+		}
+
+		if exportOutput == "" || exportOutput == "-" {
+			return b.Write(os.Stdout)
+		}
+
+		file, err := os.OpenFile(exportOutput, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+		if err != nil {
+			return err
+		}
+
+		if err := errors.Join(b.Write(file), file.Close()); err != nil {
+			return err
+		}
+
+		var tasks int
+		for _, p := range b.Projects {
+			tasks += len(p.Tasks) + len(p.Archived)
+		}
+		fmt.Printf("Exported %d project(s) with %d task(s) to %s\n", len(b.Projects), tasks, exportOutput)
+
+		return nil
+	},
+}
+
+func init() {
+	exportCmd.Flags().StringArrayVarP(&exportProjects, "project", "p", nil, "Project to export (title or UUID), can be repeated")
+	exportCmd.Flags().StringVarP(&exportOutput, "output", "o", "", "File to write the bundle to")
+	rootCmd.AddCommand(exportCmd)
+}
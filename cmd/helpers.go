@@ -0,0 +1,309 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/handlebargh/yatto/internal/helpers"
+	"github.com/handlebargh/yatto/internal/hooks"
+	"github.com/handlebargh/yatto/internal/items"
+	"github.com/handlebargh/yatto/internal/vcs"
+	"github.com/spf13/viper"
+)
+
+// errNoProject is returned by findProject if no project matches.
+var errNoProject = errors.New("no project found")
+
+// findProject returns the project whose UUID or title matches the given
+// query. Titles are compared case-insensitively; if no title is equal to
+// the query, the only title starting with it matches. An error listing
+// the candidates is returned if more than one project matches.
+func findProject(v *viper.Viper, query string) (*items.Project, error) {
+	if strings.TrimSpace(query) == "" {
+		return nil, errors.New("no project given")
+	}
+
+	projects, err := helpers.ReadProjectsFromFS(v)
+	if err := warnSkipped(err); err != nil {
+		return nil, err
+	}
+
+	var matches, prefixMatches []items.Project
+	for _, project := range projects {
+		if project.ID == query {
+			return &project, nil
+		}
+
+		switch {
+		case strings.EqualFold(project.Title, query):
+			matches = append(matches, project)
+		case strings.HasPrefix(strings.ToLower(project.Title), strings.ToLower(query)):
+			prefixMatches = append(prefixMatches, project)
+		}
+	}
+
+	if len(matches) == 0 {
+		matches = prefixMatches
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, fmt.Errorf("%w matching %q", errNoProject, query)
+	case 1:
+		return &matches[0], nil
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d projects found matching %q, use a longer title or the project UUID instead:", len(matches), query)
+	for _, p := range matches {
+		fmt.Fprintf(&b, "\n  %s  %s", p.ID, p.Title)
+	}
+
+	return nil, errors.New(b.String())
+}
+
+// warnSkipped prints a warning to stderr if err reports files that were
+// skipped while reading the storage directory and returns nil in that case.
+// Any other error is returned unchanged.
+func warnSkipped(err error) error {
+	var skipped *items.SkippedFilesError
+	if errors.As(err, &skipped) {
+		_, _ = fmt.Fprintf(os.Stderr, "warning: %v\nRun 'yatto doctor' for details.\n", err)
+		return nil
+	}
+
+	return err
+}
+
+// warnHooks prints a warning to stderr if hooks failed.
+func warnHooks(err error) {
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "warning: %v\n", err)
+	}
+}
+
+// runCmd executes the given command synchronously and returns
+// the error carried by the resulting message, if any. The output
+// of failed vcs commands is appended to the error.
+func runCmd(cmd tea.Cmd) error {
+	if cmd == nil {
+		return nil
+	}
+
+	switch msg := cmd().(type) {
+	case vcs.InitErrorMsg:
+		return backendError(msg.CmdOutput, msg.Err)
+	case vcs.CommitErrorMsg:
+		return backendError(msg.CmdOutput, msg.Err)
+	case vcs.PullErrorMsg:
+		return backendError(msg.CmdOutput, msg.Err)
+	case vcs.PushErrorMsg:
+		return backendError(msg.CmdOutput, msg.Err)
+	case vcs.BranchErrorMsg:
+		return backendError(msg.CmdOutput, msg.Err)
+	case vcs.PushQueuedMsg:
+		// The commit was made, so only warn about the failed push.
+		var reason error = msg.Err
+		if remoteErr := vcs.DiagnoseRemote(msg.CmdOutput, msg.Err); remoteErr != nil {
+			reason = remoteErr
+		}
+		_, _ = fmt.Fprintf(os.Stderr,
+			"warning: could not push: %v\n%d change(s) not yet pushed, run 'yatto sync' to retry.\n",
+			reason, msg.Unpushed)
+		return nil
+	case hooks.ErrorMsg:
+		// The change was committed, so only warn about failed hooks.
+		warnHooks(msg.Err)
+		return nil
+	case tea.BatchMsg:
+		// A commit followed by the commands to run after it, see
+		// vcs.AfterCommitCmd.
+		var errs []error
+		for _, cmd := range msg {
+			errs = append(errs, runCmd(cmd))
+		}
+		return errors.Join(errs...)
+	case error:
+		return msg
+	default:
+		return nil
+	}
+}
+
+// backendError returns the error of a failed vcs command. Known remote
+// problems are explained instead of returning the raw command output.
+func backendError(output string, err error) error {
+	if remoteErr := vcs.DiagnoseRemote(output, err); remoteErr != nil {
+		return fmt.Errorf("%w\nrun 'yatto remote test' to check the connection", remoteErr)
+	}
+
+	return fmt.Errorf("%w\n%s", err, output)
+}
+
+// findTask returns the task whose UUID matches the given query, or the
+// only task whose title starts with it (case-insensitive), together with
+// the project it belongs to. If projectQuery is not empty, only tasks of
+// the matching project are considered.
+func findTask(v *viper.Viper, projectQuery, query string) (*items.Project, *items.Task, error) {
+	if strings.TrimSpace(query) == "" {
+		return nil, nil, errors.New("no task given")
+	}
+
+	var projects []items.Project
+	if projectQuery != "" {
+		project, err := findProject(v, projectQuery)
+		if err != nil {
+			return nil, nil, err
+		}
+		projects = append(projects, *project)
+	} else {
+		var err error
+		projects, err = helpers.ReadProjectsFromFS(v)
+		if err := warnSkipped(err); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	type match struct {
+		project items.Project
+		task    items.Task
+	}
+
+	var matches []match
+	for _, project := range projects {
+		tasks, err := project.ReadTasksFromFS(v)
+		if err := warnSkipped(err); err != nil {
+			return nil, nil, err
+		}
+
+		for _, task := range tasks {
+			if task.ID == query {
+				return &project, &task, nil
+			}
+
+			if strings.HasPrefix(strings.ToLower(task.Title), strings.ToLower(query)) {
+				matches = append(matches, match{project, task})
+			}
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, nil, fmt.Errorf("no task found matching %q", query)
+	case 1:
+		return &matches[0].project, &matches[0].task, nil
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d tasks found matching %q, use the task UUID instead:", len(matches), query)
+	for _, m := range matches {
+		fmt.Fprintf(&b, "\n  %s  %s (%s)", m.task.ID, m.task.Title, m.project.Title)
+	}
+
+	return nil, nil, errors.New(b.String())
+}
+
+// changeTaskState applies change to every task matching one of the queries
+// and commits the result with the same commit message as the TUI toggles.
+// change receives the task along with its project and reports whether the
+// task was modified, or returns an error if the transition is not allowed. kind is the write kind of the changed tasks;
+// completing a recurring task creates its next occurrence.
+func changeTaskState(
+	projectQuery string,
+	queries []string,
+	actionName, kind string,
+	change func(*items.Project, *items.Task) (bool, error),
+) error {
+	v := appConfig.Viper
+
+	var writeCmds []tea.Cmd
+	var taskPaths, taskNames, recurNames []string
+	var hookCalls []hooks.Call
+	seen := make(map[string]bool)
+
+	for _, query := range queries {
+		project, task, err := findTask(v, projectQuery, query)
+		if err != nil {
+			return err
+		}
+
+		if seen[task.ID] {
+			continue
+		}
+		seen[task.ID] = true
+
+		changed, err := change(project, task)
+		if err != nil {
+			return fmt.Errorf("%s: %w", task.Title, err)
+		}
+
+		if !changed {
+			fmt.Printf("Task %q is unchanged\n", task.Title)
+			continue
+		}
+
+		var next *items.Task
+		if kind == "complete" {
+			next = task.Recur()
+		}
+
+		writeCmds = append(writeCmds, task.WriteTaskJSON(v, *project, kind))
+		taskPaths = append(taskPaths, filepath.Join(project.ID, task.ID+".json"))
+		taskNames = append(taskNames, task.Title)
+		hookCalls = append(hookCalls, task.HookCalls(*project, kind)...)
+
+		if next != nil {
+			writeCmds = append(writeCmds, next.WriteTaskJSON(v, *project, "recur"))
+			taskPaths = append(taskPaths, filepath.Join(project.ID, next.ID+".json"))
+			hookCalls = append(hookCalls, next.HookCalls(*project, "recur")...)
+			recurNames = append(recurNames, fmt.Sprintf("%s (due %s)", next.Title, next.DueDateToString()))
+		}
+	}
+
+	if len(taskNames) == 0 {
+		return nil
+	}
+
+	for _, cmd := range writeCmds {
+		if err := runCmd(cmd); err != nil {
+			return err
+		}
+	}
+
+	commitMsg := items.StateChangeCommitMessage(actionName, taskNames, recurNames)
+	if err := runCmd(vcs.CommitCmd(v, commitMsg, taskPaths...)); err != nil {
+		return err
+	}
+	warnHooks(hooks.RunAll(v, hookCalls))
+
+	fmt.Printf("Changed %s state of %d task(s)\n", actionName, len(taskNames))
+	for _, name := range recurNames {
+		fmt.Printf("Created next occurrence: %s\n", name)
+	}
+
+	return nil
+}
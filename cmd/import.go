@@ -0,0 +1,420 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/google/uuid"
+	"github.com/handlebargh/yatto/internal/bundle"
+	"github.com/handlebargh/yatto/internal/helpers"
+	"github.com/handlebargh/yatto/internal/hooks"
+	"github.com/handlebargh/yatto/internal/importer"
+	"github.com/handlebargh/yatto/internal/items"
+	"github.com/handlebargh/yatto/internal/vcs"
+	"github.com/mattn/go-runewidth"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var (
+	importFormat  string
+	importProject string
+	importNewIDs  bool
+	importStdin   bool
+)
+
+// importCmd represents the import command
+var importCmd = &cobra.Command{
+	Use:   "import [file]",
+	Short: "Import tasks exported by another task manager or from standard input",
+	Long: `Import tasks exported by another task manager without opening the TUI.
+
+The export is read from the given file or from standard input.
+Tasks are added to the project of the same title, which is created if it
+does not exist yet. Tasks that already exist are skipped, so an export
+can be imported repeatedly. The tasks of every project are committed at once.
+
+A bundle written by "yatto export" is imported with its projects, archived
+tasks and attachments. Projects and tasks whose ID exists already are
+kept as they are, only their missing tasks are added. Use --new-ids to
+import the bundle as a copy next to the projects it was exported from.
+
+The formats lines and json create new tasks in the project given by
+--project, an ID or title, which is created if no project matches.
+Every line is parsed like a quick capture line of the task list, e.g.
+"Fix login bug !high @alice +auth due:fri"; blank lines and list markers
+are ignored. A JSON array may hold such lines or objects with the keys
+title, description, priority, assignee, labels and due.
+
+Tasks of a todo.txt file are added to the project of their first
++project, or to --project if they have none. Contexts and further
+projects become labels, the due: and t: tags set the due and start date.
+
+Supported formats:
+  taskwarrior  JSON written by "task export"
+  todotxt      todo.txt file
+  yatto        bundle written by "yatto export"
+  lines        one task per line
+  json         array of tasks`,
+	Example: `  task export | yatto import --format taskwarrior
+  yatto import --format taskwarrior --project Inbox tasks.json
+  yatto import --format todotxt ~/todo.txt
+  yatto import --format yatto backup.json
+  cat notes.md | yatto import --stdin --format lines --project Work
+  echo '[{"title": "Book flights", "due": "friday"}]' | yatto import --stdin --format json -p Travel`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(_ *cobra.Command, args []string) error {
+		if !slices.Contains([]string{"taskwarrior", "todotxt", "yatto", "lines", "json"}, importFormat) {
+			return fmt.Errorf("invalid format %q (valid: taskwarrior, todotxt, yatto, lines, json)", importFormat)
+		}
+
+		if importStdin && len(args) == 1 && args[0] != "-" {
+			return errors.New("--stdin cannot be combined with a file")
+		}
+
+		var input io.Reader = os.Stdin
+		if len(args) == 1 && args[0] != "-" {
+			file, err := os.Open(args[0])
+			if err != nil {
+				return err
+			}
+			defer file.Close() //nolint:errcheck
+
+			input = file
+		}
+
+		// Read the export first, standard input may be needed by setupApp.
+		if importFormat == "yatto" {
+			b, err := bundle.Read(input)
+			if err != nil {
+				return err
+			}
+			if importNewIDs {
+				b.RenewIDs()
+			}
+
+			if err := setupApp(); err != nil {
+				return err
+			}
+
+			if err := runCmd(vcs.InitCmd(appConfig.Viper)); err != nil {
+				return err
+			}
+
+			return importBundle(appConfig.Viper, b)
+		}
+
+		projects, err := parseImport(importFormat, input, importProject)
+		if err != nil {
+			return err
+		}
+
+		if err := setupApp(); err != nil {
+			return err
+		}
+
+		if err := runCmd(vcs.InitCmd(appConfig.Viper)); err != nil {
+			return err
+		}
+
+		return importProjects(appConfig.Viper, importFormat, projects)
+	},
+}
+
+// parseImport converts the input of the given format, except yatto
+// bundles, into the tasks to import grouped by project. Tasks without
+// a project are put into the given project.
+func parseImport(format string, input io.Reader, project string) ([]importer.ProjectImport, error) {
+	switch format {
+	case "lines", "json":
+		parse := importer.Lines
+		if format == "json" {
+			parse = importer.JSON
+		}
+
+		p, err := parse(input, project)
+		if err != nil {
+			return nil, err
+		}
+		return []importer.ProjectImport{p}, nil
+	case "todotxt":
+		return importer.TodoTxt(input, project)
+	default:
+		return importer.Taskwarrior(input, project)
+	}
+}
+
+// importProjects writes the tasks imported from the given format into
+// their projects, which are created if they do not exist yet, and commits
+// every project at once.
+func importProjects(v *viper.Viper, format string, projects []importer.ProjectImport) error {
+	existing, err := existingTaskIDs(v)
+	if err != nil {
+		return err
+	}
+
+	// Ignore errors just like the task form does.
+	author, _ := vcs.User(v)
+	contributors, _ := vcs.AllContributors(v)
+
+	for _, p := range projects {
+		project, created, err := importTarget(v, format, p.Title)
+		if err != nil {
+			return err
+		}
+
+		for _, task := range p.Tasks {
+			if task.Assignee != "" {
+				task.Assignee = helpers.MatchContributor(task.Assignee, contributors)
+			}
+		}
+
+		if err := importProjectTasks(v, format, project, created, p.Tasks, existing, author); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// importTarget returns the project to import the tasks into and whether
+// it has to be created. Taskwarrior and todo.txt projects are matched by
+// title only, while the project given for lines and json may also be an
+// ID or the prefix of a title, like in the other commands.
+func importTarget(v *viper.Viper, format, title string) (*items.Project, bool, error) {
+	if format == "taskwarrior" || format == "todotxt" {
+		return findOrCreateProject(v, title)
+	}
+
+	project, err := findProject(v, title)
+	if errors.Is(err, errNoProject) {
+		return findOrCreateProject(v, title)
+	}
+
+	return project, false, err
+}
+
+// importProjectTasks writes the imported tasks of a single project and
+// commits them. The project is written first if it was created. Tasks
+// whose ID is contained in existing are skipped.
+func importProjectTasks(
+	v *viper.Viper,
+	format string,
+	project *items.Project,
+	created bool,
+	tasks []*items.Task,
+	existing map[string]bool,
+	author string,
+) error {
+	var writeCmds []tea.Cmd
+	var paths, titles []string
+	var hookCalls []hooks.Call
+
+	if created {
+		writeCmds = append(writeCmds, project.WriteProjectJSON(v, project.MarshalProject(), "create"))
+		paths = append(paths, filepath.Join(project.ID, "project.json"))
+	}
+
+	for _, task := range tasks {
+		if existing[task.ID] {
+			continue
+		}
+		existing[task.ID] = true
+
+		task.Author = author
+		writeCmds = append(writeCmds, task.WriteTaskJSON(v, *project, "create"))
+		paths = append(paths, task.Path(*project))
+		titles = append(titles, task.Title)
+		hookCalls = append(hookCalls, task.HookCalls(*project, "create")...)
+	}
+
+	skipped := len(tasks) - len(titles)
+
+	if len(titles) == 0 {
+		fmt.Printf("Nothing to import into project %s (%d skipped)\n", project.Title, skipped)
+		return nil
+	}
+
+	for _, cmd := range writeCmds {
+		if err := runCmd(cmd); err != nil {
+			return err
+		}
+	}
+
+	message := fmt.Sprintf("import: %d task(s) from %s\n\n- %s", len(titles), format, strings.Join(titles, "\n- "))
+	if err := runCmd(vcs.CommitCmd(v, message, paths...)); err != nil {
+		return err
+	}
+	warnHooks(hooks.RunAll(v, hookCalls))
+
+	fmt.Printf("Imported %d task(s) into project %s (%d skipped)\n", len(titles), project.Title, skipped)
+
+	return nil
+}
+
+// importBundle writes the projects of the bundle and commits the changes
+// of every project at once. Existing projects are not changed and tasks
+// that already exist are skipped. The tasks are written as they are,
+// without updating their timestamps or running hooks.
+func importBundle(v *viper.Viper, b *bundle.Bundle) error {
+	projects, err := helpers.ReadProjectsFromFS(v)
+	if err := warnSkipped(err); err != nil {
+		return err
+	}
+
+	local := make(map[string]items.Project)
+	for _, project := range projects {
+		local[project.ID] = project
+	}
+
+	existing, err := existingTaskIDs(v)
+	if err != nil {
+		return err
+	}
+
+	for _, p := range b.Projects {
+		project, found := local[p.Project.ID]
+		if !found {
+			project = p.Project
+		}
+
+		var writeCmds []tea.Cmd
+		var paths, titles []string
+
+		if !found {
+			writeCmds = append(writeCmds, project.WriteProjectJSON(v, project.MarshalProject(), "create"))
+			paths = append(paths, filepath.Join(project.ID, "project.json"))
+		}
+
+		for _, task := range slices.Concat(p.Tasks, p.Archived) {
+			if existing[task.ID] {
+				continue
+			}
+			existing[task.ID] = true
+
+			task.Archived = slices.ContainsFunc(p.Archived, func(t items.Task) bool { return t.ID == task.ID })
+			writeCmds = append(writeCmds, task.WriteTaskJSON(v, project, "import"))
+			paths = append(paths, task.Path(project))
+			titles = append(titles, task.Title)
+
+			attachments, err := bundle.WriteAttachments(v, project, task.ID, p.Attachments[task.ID])
+			if err != nil {
+				return err
+			}
+			paths = append(paths, attachments...)
+		}
+
+		skipped := len(p.Tasks) + len(p.Archived) - len(titles)
+
+		if len(writeCmds) == 0 {
+			fmt.Printf("Nothing to import into project %s (%d skipped)\n", project.Title, skipped)
+			continue
+		}
+
+		for _, cmd := range writeCmds {
+			if err := runCmd(cmd); err != nil {
+				return err
+			}
+		}
+
+		message := fmt.Sprintf("import: project %s", project.Title)
+		if len(titles) > 0 {
+			message = fmt.Sprintf("import: %d task(s) from backup\n\n- %s", len(titles), strings.Join(titles, "\n- "))
+		}
+		if err := runCmd(vcs.CommitCmd(v, message, paths...)); err != nil {
+			return err
+		}
+
+		fmt.Printf("Imported %d task(s) into project %s (%d skipped)\n", len(titles), project.Title, skipped)
+	}
+
+	return nil
+}
+
+// findOrCreateProject returns the project of the given title, compared
+// case-insensitively, or a new project if there is none. The new project
+// is not written yet. An error is returned if the title is ambiguous.
+func findOrCreateProject(v *viper.Viper, title string) (*items.Project, bool, error) {
+	projects, err := helpers.ReadProjectsFromFS(v)
+	if err := warnSkipped(err); err != nil {
+		return nil, false, err
+	}
+
+	var matches []items.Project
+	for _, project := range projects {
+		if strings.EqualFold(project.Title, title) {
+			matches = append(matches, project)
+		}
+	}
+
+	switch len(matches) {
+	case 0:
+		return &items.Project{
+			ID:    uuid.NewString(),
+			Title: runewidth.Truncate(title, 32, ""),
+			Color: "blue",
+		}, true, nil
+	case 1:
+		return &matches[0], false, nil
+	default:
+		return nil, false, fmt.Errorf("%d projects found matching %q", len(matches), title)
+	}
+}
+
+// existingTaskIDs returns the IDs of all tasks in storage, including archived ones.
+func existingTaskIDs(v *viper.Viper) (map[string]bool, error) {
+	projects, err := helpers.ReadProjectsFromFS(v)
+	if err := warnSkipped(err); err != nil {
+		return nil, err
+	}
+
+	ids := make(map[string]bool)
+	for _, project := range projects {
+		tasks, err := project.ReadTasksFromFS(v)
+		archived, archivedErr := project.ReadArchivedTasksFromFS(v)
+		if err := warnSkipped(items.CollectSkipped(err, archivedErr)); err != nil {
+			return nil, err
+		}
+
+		for _, task := range append(tasks, archived...) {
+			ids[task.ID] = true
+		}
+	}
+
+	return ids, nil
+}
+
+func init() {
+	importCmd.Flags().StringVarP(&importFormat, "format", "f", "", "Format of the input (taskwarrior, todotxt, yatto, lines, json)")
+	importCmd.Flags().StringVarP(&importProject, "project", "p", "Inbox", "Project for tasks without a project, or for all tasks of lines and json")
+	importCmd.Flags().BoolVar(&importNewIDs, "new-ids", false, "Import a yatto bundle with new project and task IDs")
+	importCmd.Flags().BoolVar(&importStdin, "stdin", false, "Read the tasks from standard input")
+	_ = importCmd.MarkFlagRequired("format")
+	rootCmd.AddCommand(importCmd)
+}
@@ -0,0 +1,101 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cmd
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/handlebargh/yatto/internal/helpers"
+	"github.com/handlebargh/yatto/internal/items"
+	"github.com/spf13/cobra"
+)
+
+var (
+	notifyDesktop bool
+	notifyQuiet   bool
+)
+
+// notifyCmd represents the notify command
+var notifyCmd = &cobra.Command{
+	Use:   "notify",
+	Short: "Remind of tasks whose reminders are due",
+	Long: `Remind of the open tasks whose reminders became due since the last check.
+
+Reminders are printed and sent as desktop notifications. Each reminder is sent once,
+so run the command regularly, e.g. every few minutes from cron, to be reminded while
+the TUI is closed. The task_overdue hooks of tasks that became overdue are run as well.`,
+	Example: `  yatto notify
+  yatto notify --desktop=false
+  */5 * * * * yatto notify --quiet`,
+	Args: cobra.NoArgs,
+	RunE: func(_ *cobra.Command, _ []string) error {
+		if err := setupApp(); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		warnHooks(items.NotifyOverdue(appConfig.Viper, now))
+
+		// Failing to save the reminders sent is reported after sending them.
+		reminders, remindErr := items.DueReminders(appConfig.Viper, now)
+		if len(reminders) == 0 {
+			return remindErr
+		}
+
+		projects, err := helpers.ReadProjectsFromFS(appConfig.Viper)
+		if err := warnSkipped(err); err != nil {
+			return err
+		}
+
+		titles := make(map[string]string)
+		for _, p := range projects {
+			titles[p.ID] = p.Title
+		}
+
+		for _, projectID := range slices.Sorted(maps.Keys(reminders)) {
+			for _, t := range reminders[projectID] {
+				message := fmt.Sprintf("%s, due %s", titles[projectID], t.DueDateToString())
+
+				if !notifyQuiet {
+					fmt.Printf("Reminder: %s (%s)\n", t.Title, message)
+				}
+
+				if notifyDesktop {
+					if err := helpers.Notify("Reminder: "+t.Title, message); err != nil {
+						_, _ = fmt.Fprintf(os.Stderr, "warning: could not send notification: %v\n", err)
+					}
+				}
+			}
+		}
+
+		return remindErr
+	},
+}
+
+func init() {
+	notifyCmd.Flags().BoolVar(&notifyDesktop, "desktop", true, "Send desktop notifications")
+	notifyCmd.Flags().BoolVarP(&notifyQuiet, "quiet", "q", false, "Do not print the reminders")
+	rootCmd.AddCommand(notifyCmd)
+}
@@ -0,0 +1,92 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cmd
+
+import (
+	"cmp"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/handlebargh/yatto/internal/config"
+	"github.com/handlebargh/yatto/internal/vcs"
+	"github.com/spf13/viper"
+)
+
+// finishOnboarding applies the first-run answers that need the storage
+// directory: it creates the first project and imports the chosen file.
+// Tasks of the import without a project are put into the first project.
+// A failed import is only reported, it can be repeated with "yatto import".
+func finishOnboarding(v *viper.Viper, onboarding config.Onboarding) error {
+	if onboarding.Project == "" && onboarding.ImportFormat == "" {
+		return nil
+	}
+
+	if err := runCmd(vcs.InitCmd(v)); err != nil {
+		return err
+	}
+
+	if onboarding.Project != "" {
+		project, created, err := findOrCreateProject(v, onboarding.Project)
+		if err != nil {
+			return err
+		}
+
+		if created {
+			if err := runCmd(project.WriteProjectJSON(v, project.MarshalProject(), "create")); err != nil {
+				return err
+			}
+
+			message := fmt.Sprintf("create: %s", project.Title)
+			if err := runCmd(vcs.CommitCmd(v, message, filepath.Join(project.ID, "project.json"))); err != nil {
+				return err
+			}
+
+			fmt.Printf("Created project %s\n", project.Title)
+		}
+	}
+
+	if onboarding.ImportFormat == "" {
+		return nil
+	}
+
+	if err := importFile(v, onboarding.ImportFormat, onboarding.ImportFile, cmp.Or(onboarding.Project, "Inbox")); err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "warning: import of %s failed: %v\n", onboarding.ImportFile, err)
+	}
+
+	return nil
+}
+
+// importFile imports the tasks of the given file and format.
+func importFile(v *viper.Viper, format, path, project string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close() //nolint:errcheck
+
+	projects, err := parseImport(format, file, project)
+	if err != nil {
+		return err
+	}
+
+	return importProjects(v, format, projects)
+}
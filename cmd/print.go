@@ -22,17 +22,15 @@ package cmd
 
 import (
 	"errors"
-	"os"
-	"os/exec"
+	"fmt"
+	"slices"
 	"strings"
 
-	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
-	"github.com/charmbracelet/lipgloss"
-	"github.com/handlebargh/yatto/internal/config"
+	"github.com/handlebargh/yatto/internal/colors"
 	"github.com/handlebargh/yatto/internal/fetchmodel"
 	"github.com/handlebargh/yatto/internal/staticprinter"
-	"github.com/handlebargh/yatto/internal/storage"
+	"github.com/handlebargh/yatto/internal/vcs"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -41,104 +39,106 @@ var (
 	pullFlag      bool
 	authorFlag    bool
 	assigneeFlag  bool
+	deferredFlag  bool
 	printProjects string
 	printRegex    string
+	printFormat   string
+	plainFlag     bool
 )
 
 var printCmd = &cobra.Command{
 	Use:   "print",
 	Short: "Print tasks to stdout",
-	PreRunE: func(_ *cobra.Command, _ []string) error {
-		_, gitErr := exec.LookPath("git")
-		_, jjErr := exec.LookPath("jj")
-		if gitErr != nil && jjErr != nil {
-			return errors.New("yatto requires either 'git' or 'jj' to be installed")
-		}
-
-		return nil
-	},
 	RunE: func(_ *cobra.Command, _ []string) error {
-		setCfg := config.Settings{
-			Viper:      appConfig.Viper,
-			ConfigPath: configPath,
-			Home:       homePath,
-			Input:      os.Stdin,
-			Output:     os.Stdout,
-			Exit:       os.Exit,
+		if !slices.Contains(staticprinter.Formats, printFormat) {
+			return fmt.Errorf("unknown format %q (valid: %s)",
+				printFormat, strings.Join(staticprinter.Formats, ", "))
 		}
 
-		if err := config.CreateConfigFile(setCfg); err != nil {
-			if errors.Is(err, config.ErrUserAborted) {
-				os.Exit(0)
-			}
+		if err := setupApp(); err != nil {
 			return err
 		}
 
-		err := config.LoadAndValidateConfig(setCfg.Viper)
-		if err != nil {
-			return err
+		if plainFlag {
+			colors.SetPlain(true)
 		}
 
-		setStorage := storage.Settings{
-			Viper:  appConfig.Viper,
-			Input:  os.Stdin,
-			Output: os.Stdout,
-			Exit:   os.Exit,
-		}
-
-		if err := storage.CreateStorageDir(setStorage); err != nil {
-			if errors.Is(err, storage.ErrUserAborted) {
-				os.Exit(0)
+		if pullFlag && vcs.RemoteEnabled(appConfig.Viper) {
+			if err := pullBeforePrint(appConfig.Viper, printFormat); err != nil {
+				return err
 			}
-			return err
 		}
 
-		if pullFlag &&
-			((appConfig.Viper.GetString("vcs.backend") == "git" && appConfig.Viper.GetBool("git.remote.enable")) ||
-				(appConfig.Viper.GetString("vcs.backend") == "jj" && appConfig.Viper.GetBool("jj.remote.enable"))) {
-			s := spinner.New()
-			s.Spinner = spinner.Dot
-			s.Style = s.Style.
-				Foreground(lipgloss.AdaptiveColor{Light: "#FFB733", Dark: "#FFA336"}).
-				Bold(true)
-
-			fetchModel := fetchmodel.FetchModel{
-				Spinner: s,
-			}
+		return printTaskList(appConfig.Viper, printFormat, printProjects, printRegex)
+	},
+}
 
-			if _, err := tea.NewProgram(fetchModel, tea.WithAltScreen()).Run(); err != nil {
-				return err
-			}
+// pullBeforePrint pulls the remote before printing. The table is preceded by a
+// spinner, while JSON and CSV are pulled without any output, so that
+// they can be piped into other programs.
+func pullBeforePrint(v *viper.Viper, format string) error {
+	if format != staticprinter.FormatTable {
+		if err := runCmd(vcs.InitCmd(v)); err != nil {
+			return err
 		}
+		return runCmd(vcs.PullCmd(v))
+	}
 
-		printTaskList(appConfig.Viper, printProjects, printRegex)
-
-		return nil
-	},
+	_, err := tea.NewProgram(fetchmodel.NewFetchModel(v), tea.WithAltScreen()).Run()
+	return err
 }
 
-// printTaskList prints a list of tasks based on the provided project names
+// printTaskList prints a list of tasks based on the provided projects
 // and a regular expression filter.
 //
-// The function takes three arguments as input:
+// The function takes four arguments as input:
 // - v: a viper instance for configuration.
-// - printProjects: a space-separated string of project names.
+// - format: the output format (table, json or csv).
+// - printProjects: a comma or space-separated list of project titles or UUIDs.
 // - printRegex: a regular expression used to filter tasks.
 //
-// It splits the printProjects string into individual project names, then calls
-// printer.PrintTasks with the regex and the list of projects.
-func printTaskList(v *viper.Viper, printProjects, printRegex string) {
-	// Get a slice of strings from user input.
-	projects := strings.Fields(printProjects)
+// It resolves the projects, see findProject, then calls
+// printer.PrintTasks with the format, the regex and the project UUIDs.
+func printTaskList(v *viper.Viper, format, printProjects, printRegex string) error {
+	// Titles may contain spaces, so a list with commas is split at them only.
+	queries := strings.Fields(printProjects)
+	if strings.Contains(printProjects, ",") {
+		queries = nil
+		for query := range strings.SplitSeq(printProjects, ",") {
+			if query = strings.TrimSpace(query); query != "" {
+				queries = append(queries, query)
+			}
+		}
+	}
+
+	var projects []string
+	for _, query := range queries {
+		project, err := findProject(v, query)
+		switch {
+		case errors.Is(err, errNoProject):
+			// Reported as missing by PrintTasks.
+			projects = append(projects, query)
+		case err != nil:
+			return err
+		default:
+			projects = append(projects, project.ID)
+		}
+	}
 
-	staticprinter.PrintTasks(v, printRegex, authorFlag, assigneeFlag, projects...)
+	return staticprinter.PrintTasks(v, format, printRegex, authorFlag, assigneeFlag, deferredFlag, projects...)
 }
 
 func init() {
 	printCmd.Flags().BoolVarP(&pullFlag, "pull", "p", false, "Pull the remote before printing")
 	printCmd.Flags().BoolVarP(&authorFlag, "author", "a", false, "Print tasks only authored by you")
-	printCmd.Flags().BoolVarP(&assigneeFlag, "assignee", "A", false, "Print tasks only assigned to you")
-	printCmd.Flags().StringVarP(&printProjects, "projects", "P", "", "List of project UUIDs to print from")
+	printCmd.Flags().BoolVarP(&assigneeFlag, "assignee", "A", false, "Print only tasks assigned to or watched by you")
+	printCmd.Flags().BoolVarP(&deferredFlag, "deferred", "d", false,
+		"Also print tasks whose start date is in the future")
+	printCmd.Flags().StringVarP(&printProjects, "projects", "P", "", "Projects to print from, by title or UUID, separated by commas or spaces")
 	printCmd.Flags().StringVarP(&printRegex, "regex", "r", "", "Regex to filter task labels")
+	printCmd.Flags().StringVarP(&printFormat, "format", "f", staticprinter.FormatTable,
+		"Output format (table, json, csv)")
+	printCmd.Flags().BoolVar(&plainFlag, "plain", false,
+		"Print without colors and Unicode symbols, for dumb terminals and screen readers")
 	rootCmd.AddCommand(printCmd)
 }
@@ -0,0 +1,204 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/handlebargh/yatto/internal/config"
+	"github.com/handlebargh/yatto/internal/storage"
+	"github.com/handlebargh/yatto/internal/vcs"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var remoteSetURLForce bool
+
+// remoteCmd represents the remote command
+var remoteCmd = &cobra.Command{
+	Use:   "remote",
+	Short: "Show, change and test the remote repository",
+	Long: `Manage the remote repository the storage directory is synced with.
+
+The remote is configured in the git.remote section for the git and gogit
+backends and in the jj.remote section for jj.`,
+	Example: `  yatto remote show
+  yatto remote test
+  yatto remote set-url git@github.com:me/tasks.git`,
+}
+
+// remoteShowCmd represents the remote show command
+var remoteShowCmd = &cobra.Command{
+	Use:   "show",
+	Short: "Show the configured remote and how yatto authenticates with it",
+	Args:  cobra.NoArgs,
+	RunE: func(_ *cobra.Command, _ []string) error {
+		if err := setupApp(); err != nil {
+			return err
+		}
+
+		v := appConfig.Viper
+
+		if _, err := remoteSection(v); err != nil {
+			return err
+		}
+
+		remote := config.Get(v).Remote()
+		url := remote.URL
+
+		status := "disabled"
+		if remote.Enable {
+			status = "enabled"
+		}
+
+		lastSync := "never"
+		if t := storage.LastSync(v); !t.IsZero() {
+			lastSync = t.Format(time.DateTime)
+		}
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		_, _ = fmt.Fprintf(w, "Backend:\t%s\n", config.Get(v).VCSBackend)
+		_, _ = fmt.Fprintf(w, "Remote:\t%s (%s)\n", remote.Name, status)
+		if url == "" {
+			_, _ = fmt.Fprintf(w, "URL:\tnot set\n")
+		} else {
+			_, _ = fmt.Fprintf(w, "URL:\t%s\n", vcs.RedactURL(url))
+			_, _ = fmt.Fprintf(w, "Auth:\t%s\n", vcs.RemoteAuth(v, url))
+		}
+		for _, m := range vcs.Mirrors(v) {
+			_, _ = fmt.Fprintf(w, "Mirror:\t%s %s (%s)\n", m.Name, vcs.RedactURL(m.URL), m.Policy)
+		}
+		_, _ = fmt.Fprintf(w, "Last sync:\t%s\n", lastSync)
+		_, _ = fmt.Fprintf(w, "Unpushed:\t%d change(s)\n", storage.UnpushedCommits(v))
+
+		return w.Flush()
+	},
+}
+
+// remoteTestCmd represents the remote test command
+var remoteTestCmd = &cobra.Command{
+	Use:   "test",
+	Short: "Check that the remote can be reached and read",
+	Long: `Connect to the configured remote repository without changing anything.
+
+If the connection fails, the reason is reported along with what to do
+about it, e.g. adding the SSH key to the ssh-agent.`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(_ *cobra.Command, _ []string) error {
+		if err := setupApp(); err != nil {
+			return err
+		}
+
+		v := appConfig.Viper
+
+		if _, err := remoteSection(v); err != nil {
+			return err
+		}
+
+		remote := config.Get(v).Remote()
+		url := remote.URL
+		if url == "" {
+			return errors.New("no remote URL configured, set one with yatto remote set-url")
+		}
+
+		if err := vcs.TestRemote(v, url); err != nil {
+			return err
+		}
+
+		fmt.Printf("Connected to %s\n", vcs.RedactURL(url))
+		if !remote.Enable {
+			fmt.Println("Syncing with the remote is disabled, enable it with yatto remote set-url")
+		}
+
+		return nil
+	},
+}
+
+// remoteSetURLCmd represents the remote set-url command
+var remoteSetURLCmd = &cobra.Command{
+	Use:   "set-url <url>",
+	Short: "Change the remote URL and enable syncing with it",
+	Long: `Change the URL of the remote repository in the config file and in the
+repository of the storage directory, and enable syncing with it.
+
+The new remote is tested first, the URL is only changed if it can be
+reached. Use --force to change it anyway, e.g. while being offline.`,
+	Example: `  yatto remote set-url git@github.com:me/tasks.git
+  yatto remote set-url --force https://me:<token>@git.example.com/me/tasks.git`,
+	Args:         cobra.ExactArgs(1),
+	SilenceUsage: true,
+	RunE: func(_ *cobra.Command, args []string) error {
+		if err := setupApp(); err != nil {
+			return err
+		}
+
+		v := appConfig.Viper
+		url := args[0]
+
+		section, err := remoteSection(v)
+		if err != nil {
+			return err
+		}
+
+		if !remoteSetURLForce {
+			if err := vcs.TestRemote(v, url); err != nil {
+				return fmt.Errorf("%w\nuse --force to change the URL anyway", err)
+			}
+		}
+
+		if _, _, err := config.Update(v, map[string]any{
+			section + ".remote.url":    url,
+			section + ".remote.enable": true,
+		}); err != nil {
+			return err
+		}
+
+		if err := vcs.SetRemoteURL(v, url); err != nil {
+			return fmt.Errorf("config file updated, but the repository remote could not be changed: %w", err)
+		}
+
+		fmt.Printf("Remote %s set to %s\n", config.Get(v).Remote().Name, vcs.RedactURL(url))
+
+		return nil
+	},
+}
+
+// remoteSection returns the config section of the remote or an error
+// if the vcs backend has none.
+func remoteSection(v *viper.Viper) (string, error) {
+	section := vcs.RemoteSection(v)
+	if section == "" {
+		return "", fmt.Errorf("%w (vcs.backend = %q)", vcs.ErrNoRemoteSection, config.Get(v).VCSBackend)
+	}
+
+	return section, nil
+}
+
+func init() {
+	remoteSetURLCmd.Flags().BoolVar(&remoteSetURLForce, "force", false, "Change the URL without testing the remote")
+	remoteCmd.AddCommand(remoteShowCmd, remoteTestCmd, remoteSetURLCmd)
+	rootCmd.AddCommand(remoteCmd)
+}
@@ -22,23 +22,36 @@
 package cmd
 
 import (
+	"cmp"
+	"context"
 	"errors"
 	"fmt"
 	"os"
 	"os/exec"
+	"strings"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+	"github.com/handlebargh/yatto/internal/colors"
 	"github.com/handlebargh/yatto/internal/config"
 	"github.com/handlebargh/yatto/internal/fetchmodel"
+	"github.com/handlebargh/yatto/internal/logging"
 	"github.com/handlebargh/yatto/internal/models"
 	"github.com/handlebargh/yatto/internal/storage"
+	"github.com/handlebargh/yatto/internal/vcs"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
 
 var (
-	configPath string
-	homePath   string
+	configPath  string
+	homePath    string
+	profileName string
+	logFile     string
+
+	// closeLog closes the file opened for --log-file.
+	closeLog func() error
 )
 
 // AppContext holds shared application dependencies.
@@ -46,73 +59,286 @@ type AppContext struct {
 	Viper *viper.Viper
 }
 
-var appConfig = &AppContext{viper.GetViper()}
+var appConfig = &AppContext{viper.New()}
 
 // rootCmd represents the base command when called without any subcommands
 var rootCmd = &cobra.Command{
 	Use:   "yatto",
 	Short: "Interactive VCS-based todo-list for the command-line",
-	PreRunE: func(_ *cobra.Command, _ []string) error {
-		_, gitErr := exec.LookPath("git")
-		_, jjErr := exec.LookPath("jj")
-		if gitErr != nil && jjErr != nil {
-			return errors.New("yatto requires either 'git' or 'jj' to be installed")
+	RunE: func(_ *cobra.Command, _ []string) error {
+		if err := chooseProfile(); err != nil {
+			return err
 		}
 
-		return nil
-	},
-	RunE: func(_ *cobra.Command, _ []string) error {
-		setCfg := config.Settings{
-			Viper:      appConfig.Viper,
-			ConfigPath: configPath,
-			Home:       homePath,
-			Input:      os.Stdin,
-			Output:     os.Stdout,
-			Exit:       os.Exit,
+		if err := setupApp(); err != nil {
+			return err
 		}
 
-		if err := config.CreateConfigFile(setCfg); err != nil {
-			if errors.Is(err, config.ErrUserAborted) {
-				os.Exit(0)
+		if vcs.RemoteEnabled(appConfig.Viper) {
+			if _, err := tea.NewProgram(fetchmodel.NewFetchModel(appConfig.Viper), tea.WithAltScreen()).
+				Run(); err != nil {
+				return err
 			}
+		}
+
+		// Copied task files, possibly just pulled, share their ID.
+		if err := resolveDuplicateIDs(appConfig.Viper); err != nil {
 			return err
 		}
 
-		err := config.LoadAndValidateConfig(setCfg.Viper)
+		model, err := startupModel(appConfig.Viper)
 		if err != nil {
 			return err
 		}
 
-		setStorage := storage.Settings{
-			Viper:  appConfig.Viper,
-			Input:  os.Stdin,
-			Output: os.Stdout,
-			Exit:   os.Exit,
-		}
+		p := tea.NewProgram(
+			model,
+			tea.WithAltScreen(),
+			tea.WithMouseCellMotion(),
+		)
+
+		// Reload the lists when another process changes the storage directory.
+		// Watching is a convenience only, so errors are ignored.
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go func() {
+			_ = storage.Watch(ctx, appConfig.Viper, func(msg storage.StorageChangedMsg) { p.Send(msg) })
+		}()
 
-		if err := storage.CreateStorageDir(setStorage); err != nil {
-			if errors.Is(err, storage.ErrUserAborted) {
-				os.Exit(0)
+		// Apply changes of the config file without a restart.
+		// The new colors are swapped in here, the models update the rest.
+		_ = config.Watch(appConfig.Viper, func(next *viper.Viper, cfg *config.Config, err error) {
+			if err == nil {
+				colors.Configure(next)
 			}
+			p.Send(models.ConfigChangedMsg{Config: cfg, Err: err})
+		})
+
+		if _, err := p.Run(); err != nil {
 			return err
 		}
 
-		if (appConfig.Viper.GetString("vcs.backend") == "git" && appConfig.Viper.GetBool("git.remote.enable")) ||
-			(appConfig.Viper.GetString("vcs.backend") == "jj" && appConfig.Viper.GetBool("jj.remote.enable")) {
+		// Make the commits still queued when the program was quit.
+		return runCmd(vcs.FlushCmd(appConfig.Viper))
+	},
+	// Merge the branch of the project committed to last, see
+	// git.branch_per_project.
+	PersistentPostRunE: func(_ *cobra.Command, _ []string) error {
+		return runCmd(vcs.LeaveProjectBranchCmd(appConfig.Viper))
+	},
+}
+
+// setupApp makes sure a valid config file and the storage directory exist.
+// The user is asked to create them if they are missing. If the user aborts,
+// the program exits. On the first run, the first project and the import
+// chosen while creating the config file are set up as well.
+func setupApp() error {
+	var onboarding config.Onboarding
 
-			if _, err := tea.NewProgram(fetchmodel.NewFetchModel(appConfig.Viper), tea.WithAltScreen()).
-				Run(); err != nil {
-				return err
-			}
+	setCfg := config.Settings{
+		Viper:      appConfig.Viper,
+		ConfigPath: configPath,
+		Home:       homePath,
+		Input:      os.Stdin,
+		Output:     os.Stdout,
+		Exit:       os.Exit,
+		Onboarding: &onboarding,
+	}
+
+	if err := config.CreateConfigFile(setCfg); err != nil {
+		if errors.Is(err, config.ErrUserAborted) {
+			os.Exit(0)
+		}
+		return err
+	}
+
+	if profileName != "" {
+		if err := config.ApplyProfile(setCfg.Viper, profileName); err != nil {
+			return err
 		}
+	}
 
-		if _, err := tea.NewProgram(models.InitialProjectListModel(appConfig.Viper), tea.WithAltScreen()).
-			Run(); err != nil {
+	err := config.LoadAndValidateConfig(setCfg.Viper)
+	if err != nil {
+		return err
+	}
+
+	colors.Configure(appConfig.Viper)
+
+	if logFile != "" && closeLog == nil {
+		closeLog, err = logging.Setup(logFile, config.Get(appConfig.Viper).LogLevel)
+		if err != nil {
 			return err
 		}
+	}
+
+	// Moving the storage directory is a convenience, so yatto goes on
+	// with the old location if it fails.
+	if moved, err := config.MigrateStorage(appConfig.Viper, homePath); err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "warning: %v\n", err)
+	} else if moved != "" {
+		_, _ = fmt.Fprintf(os.Stderr, "Moved storage directory to %s\n", moved)
+	}
+
+	if err := requireVCSBinary(appConfig.Viper); err != nil {
+		return err
+	}
+
+	// The storage directory was chosen while creating the config file.
+	setStorage := storage.Settings{
+		Viper:     appConfig.Viper,
+		Input:     os.Stdin,
+		Output:    os.Stdout,
+		Exit:      os.Exit,
+		Confirmed: onboarding.FirstRun,
+	}
+
+	if err := storage.CreateStorageDir(setStorage); err != nil {
+		if errors.Is(err, storage.ErrUserAborted) {
+			os.Exit(0)
+		}
+		return err
+	}
+
+	// A project branch is still checked out if yatto was killed.
+	if err := runCmd(vcs.LeaveProjectBranchCmd(appConfig.Viper)); err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "warning: %v\n", err)
+	}
+
+	if onboarding.FirstRun {
+		return finishOnboarding(appConfig.Viper, onboarding)
+	}
+
+	return nil
+}
+
+// chooseProfile asks the user which profile to use if the config file
+// defines profiles and none was chosen with --profile. The settings
+// outside of any profile are offered as "default".
+func chooseProfile() error {
+	if profileName != "" {
+		return nil
+	}
+
+	// A missing config file is created by setupApp.
+	if err := appConfig.Viper.ReadInConfig(); err != nil {
+		return nil
+	}
+
+	profiles := config.Profiles(appConfig.Viper)
+	if len(profiles) == 0 {
+		return nil
+	}
+
+	options := []huh.Option[string]{
+		huh.NewOption(fmt.Sprintf("default (%s)", config.Get(appConfig.Viper).StoragePath), ""),
+	}
+	for _, name := range profiles {
+		storagePath := cmp.Or(
+			appConfig.Viper.GetString("profiles."+name+".storage.path"),
+			config.Get(appConfig.Viper).StoragePath,
+		)
+		options = append(options, huh.NewOption(fmt.Sprintf("%s (%s)", name, storagePath), name))
+	}
+
+	form := huh.NewForm(
+		huh.NewGroup(
+			huh.NewSelect[string]().
+				Title("Choose a profile").
+				Options(options...).
+				Value(&profileName),
+		),
+	)
+
+	if err := form.Run(); err != nil {
+		if errors.Is(err, huh.ErrUserAborted) {
+			os.Exit(0)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// startupModel returns the model the TUI starts with. If the TUI crashed
+// last time, the user is asked whether to restore the saved session
+// instead. The saved session is removed either way.
+func startupModel(v *viper.Viper) (tea.Model, error) {
+	session, err := storage.ReadCrash(v)
+	if err != nil {
+		_, _ = fmt.Fprintf(os.Stderr, "warning: the saved session cannot be restored: %v\nRun 'yatto doctor' for details.\n", err)
+		return models.NewStartupModel(v), nil
+	}
+	if session == nil {
+		return models.NewStartupModel(v), nil
+	}
 
+	restore := true
+	form := huh.NewForm(
+		huh.NewGroup(
+			huh.NewConfirm().
+				Title("yatto quit unexpectedly last time. Restore the session?").
+				Description(sessionDescription(session)).
+				Affirmative("Yes").
+				Negative("No").
+				Value(&restore),
+		),
+	)
+
+	if err := form.Run(); err != nil {
+		if errors.Is(err, huh.ErrUserAborted) {
+			os.Exit(0)
+		}
+		return nil, err
+	}
+
+	if err := storage.RemoveCrash(v); err != nil {
+		return nil, err
+	}
+
+	if !restore {
+		return models.NewStartupModel(v), nil
+	}
+
+	return models.RestoreSession(v, *session), nil
+}
+
+// sessionDescription describes a session saved by a crash.
+func sessionDescription(session *storage.Session) string {
+	reason, _, _ := strings.Cut(session.Panic, "\n")
+	description := fmt.Sprintf("Crashed %s: %s", session.Time.Local().Format(time.DateTime), reason)
+
+	switch {
+	case session.Form == "task" && session.Edit:
+		description += "\nThe task being edited is reopened with the unsaved input."
+	case session.Form == "task":
+		description += "\nThe new task is reopened with the unsaved input."
+	case session.Form == "project" && session.Edit:
+		description += "\nThe project being edited is reopened with the unsaved input."
+	case session.Form == "project":
+		description += "\nThe new project is reopened with the unsaved input."
+	case session.ProjectID != "":
+		description += "\nThe project last open is reopened."
+	}
+
+	return description
+}
+
+// requireVCSBinary returns an error if the executable used by the
+// configured vcs backend cannot be found in PATH. The gogit backend
+// is built into yatto and the none backend uses no vcs at all, so
+// neither needs an executable.
+func requireVCSBinary(v *viper.Viper) error {
+	backend := config.Get(v).VCSBackend
+	if backend == "gogit" || backend == "none" {
 		return nil
-	},
+	}
+
+	if _, err := exec.LookPath(backend); err != nil {
+		return fmt.Errorf("vcs backend %q requires '%s' to be installed", backend, backend)
+	}
+
+	return nil
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
@@ -126,7 +352,15 @@ func Execute() {
 
 	config.InitConfig(appConfig.Viper, homePath, &configPath)
 
-	if err := rootCmd.Execute(); err != nil {
+	err := rootCmd.Execute()
+	if err != nil {
+		logging.Error("yatto failed", "err", err)
+	}
+	if closeLog != nil {
+		_ = closeLog()
+	}
+
+	if err != nil {
 		fmt.Println(err)
 		os.Exit(1)
 	}
@@ -134,4 +368,6 @@ func Execute() {
 
 func init() {
 	rootCmd.PersistentFlags().StringVarP(&configPath, "config", "c", "", "Path to the config file")
+	rootCmd.PersistentFlags().StringVar(&profileName, "profile", "", "Name of the profile of the config file to use")
+	rootCmd.PersistentFlags().StringVar(&logFile, "log-file", "", "Append a debug trace to this file, see log.level")
 }
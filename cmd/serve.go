@@ -0,0 +1,125 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cmd
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/handlebargh/yatto/internal/server"
+	"github.com/handlebargh/yatto/pkg/yatto"
+	"github.com/spf13/cobra"
+)
+
+var (
+	serveAddr  string
+	serveToken string
+)
+
+// serveCmd represents the serve command
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve projects and tasks over a local HTTP API",
+	Long: `Serve the projects and tasks of the storage directory over a JSON HTTP API,
+e.g. for a web or mobile frontend. Changes are committed like in the TUI.
+
+Endpoints:
+
+  GET   /projects                     list all projects
+  GET   /tasks?project=<UUID>         list the tasks of a project
+  POST  /tasks?project=<UUID>         create a task
+  PATCH /tasks/<UUID>?project=<UUID>  change the given fields of a task
+  POST  /sync                         pull from and push to the remote
+
+Every request needs the header "Authorization: Bearer <token>". The token
+is read from --token or the YATTO_TOKEN environment variable. If neither
+is set, a random token is generated and printed on startup.`,
+	Example: `  yatto serve
+  YATTO_TOKEN=secret yatto serve --addr 127.0.0.1:9000`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(_ *cobra.Command, _ []string) error {
+		if err := setupApp(); err != nil {
+			return err
+		}
+
+		host, _, err := net.SplitHostPort(serveAddr)
+		if err != nil {
+			return fmt.Errorf("invalid address %q: %w", serveAddr, err)
+		}
+		if ip := net.ParseIP(host); host != "localhost" && (ip == nil || !ip.IsLoopback()) {
+			_, _ = fmt.Fprintf(os.Stderr, "warning: %s is reachable from other hosts\n", serveAddr)
+		}
+
+		token := serveToken
+		if token == "" {
+			token = os.Getenv("YATTO_TOKEN")
+		}
+		if token == "" {
+			b := make([]byte, 16)
+			_, _ = rand.Read(b)
+			token = hex.EncodeToString(b)
+			fmt.Printf("Token: %s\n", token)
+		}
+
+		client, err := yatto.New(appConfig.Viper)
+		if err != nil {
+			return err
+		}
+
+		srv := &http.Server{
+			Addr:              serveAddr,
+			Handler:           server.New(client, token),
+			ReadHeaderTimeout: 10 * time.Second,
+		}
+
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+		defer stop()
+
+		go func() {
+			<-ctx.Done()
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			_ = srv.Shutdown(shutdownCtx)
+		}()
+
+		fmt.Printf("Serving on http://%s\n", serveAddr)
+		if err := srv.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+			return err
+		}
+
+		return nil
+	},
+}
+
+func init() {
+	serveCmd.Flags().StringVarP(&serveAddr, "addr", "a", "127.0.0.1:8080", "Address to listen on")
+	serveCmd.Flags().StringVarP(&serveToken, "token", "t", "", "Token the clients have to send")
+	rootCmd.AddCommand(serveCmd)
+}
@@ -0,0 +1,80 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cmd
+
+import (
+	"errors"
+
+	"github.com/handlebargh/yatto/internal/items"
+	"github.com/spf13/cobra"
+)
+
+var (
+	startProject string
+	startStop    bool
+)
+
+// startCmd represents the start command
+var startCmd = &cobra.Command{
+	Use:   "start <task>...",
+	Short: "Mark tasks as in progress",
+	Long: `Mark tasks as in progress without opening the TUI.
+
+Tasks are selected by their UUID or by a unique, case-insensitive prefix of their title.`,
+	Example: `  yatto start 2023255a-1749-4f6c-9877-0c73ab42e5ab
+  yatto start --project Work "write rep"
+  yatto start --stop "write rep"`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(_ *cobra.Command, args []string) error {
+		if err := setupApp(); err != nil {
+			return err
+		}
+
+		if startStop {
+			return changeTaskState(startProject, args, "progress", "stop",
+				func(_ *items.Project, t *items.Task) (bool, error) {
+					if !t.InProgress {
+						return false, nil
+					}
+					t.InProgress = false
+					return true, nil
+				})
+		}
+
+		return changeTaskState(startProject, args, "progress", "start",
+			func(_ *items.Project, t *items.Task) (bool, error) {
+				if t.Completed {
+					return false, errors.New("cannot set completed task as in progress")
+				}
+				if t.InProgress {
+					return false, nil
+				}
+				t.InProgress = true
+				return true, nil
+			})
+	},
+}
+
+func init() {
+	startCmd.Flags().StringVarP(&startProject, "project", "p", "", "Only consider tasks of this project (title or UUID)")
+	startCmd.Flags().BoolVarP(&startStop, "stop", "s", false, "Stop tasks that are in progress instead")
+	rootCmd.AddCommand(startCmd)
+}
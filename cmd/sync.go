@@ -0,0 +1,253 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/handlebargh/yatto/internal/config"
+	"github.com/handlebargh/yatto/internal/helpers"
+	"github.com/handlebargh/yatto/internal/hooks"
+	"github.com/handlebargh/yatto/internal/items"
+	"github.com/handlebargh/yatto/internal/storage"
+	"github.com/handlebargh/yatto/internal/sync/github"
+	"github.com/handlebargh/yatto/internal/vcs"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var syncProject string
+
+// syncCmd represents the sync command
+var syncCmd = &cobra.Command{
+	Use:   "sync",
+	Short: "Sync tasks with the remote repository and external services",
+	Long: `Pull from and push to the remote repository of the storage directory.
+
+Changes that could not be pushed when they were made, e.g. because the
+remote was unreachable, are pushed. Use a subcommand to sync with an
+external service instead.`,
+	Example: `  yatto sync
+  yatto sync github`,
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	RunE: func(_ *cobra.Command, _ []string) error {
+		if err := setupApp(); err != nil {
+			return err
+		}
+
+		v := appConfig.Viper
+
+		if !vcs.RemoteEnabled(v) {
+			return errors.New("no remote repository enabled, see the git.remote or jj.remote settings")
+		}
+
+		if err := runCmd(vcs.InitCmd(v)); err != nil {
+			return err
+		}
+
+		unpushed := storage.UnpushedCommits(v)
+
+		if err := runCmd(vcs.PushCmd(v)); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		warnHooks(errors.Join(hooks.RunSyncFinished(v, now), items.NotifyOverdue(v, now)))
+
+		if unpushed > 0 {
+			fmt.Printf("Synced with remote, pushed %d pending change(s)\n", unpushed)
+		} else {
+			fmt.Println("Synced with remote")
+		}
+
+		return nil
+	},
+}
+
+// syncGithubCmd represents the sync github command
+var syncGithubCmd = &cobra.Command{
+	Use:   "github",
+	Short: "Import open GitHub issues as tasks",
+	Long: `Import the open issues of GitHub repositories as tasks.
+
+Every project is synced with the repository configured for it:
+
+  [github.projects.<project UUID>]
+  repo = "owner/name"
+  close_issues = true
+
+Issues that were imported before are skipped. If close_issues is set,
+the issues of completed tasks are closed on GitHub.
+
+For GitHub Enterprise, set github.api_url to the API endpoint of the server.
+
+The token is read from github.projects.<project UUID>.token, github.token
+or the GITHUB_TOKEN environment variable, in this order.`,
+	Example: `  yatto sync github
+  yatto sync github --project Work`,
+	Args: cobra.NoArgs,
+	RunE: func(_ *cobra.Command, _ []string) error {
+		if err := setupApp(); err != nil {
+			return err
+		}
+
+		v := appConfig.Viper
+
+		projectIDs := slices.Sorted(maps.Keys(config.Get(v).GitHubProjects))
+
+		if syncProject != "" {
+			project, err := findProject(v, syncProject)
+			if err != nil {
+				return err
+			}
+			if !slices.Contains(projectIDs, project.ID) {
+				return fmt.Errorf("no github repository configured for project %s", project.Title)
+			}
+			projectIDs = []string{project.ID}
+		}
+
+		if len(projectIDs) == 0 {
+			return fmt.Errorf("no github repository configured, see yatto sync github --help")
+		}
+
+		if err := runCmd(vcs.InitCmd(v)); err != nil {
+			return err
+		}
+
+		for _, id := range projectIDs {
+			if err := syncGithubProject(v, id); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	},
+}
+
+// syncGithubProject imports the open issues of the repository configured
+// for the project with the given ID and commits the new tasks. Afterwards,
+// the issues of completed tasks are closed if the project is configured so.
+func syncGithubProject(v *viper.Viper, projectID string) error {
+	cfg := config.Get(v)
+	settings := cfg.GitHubProjects[projectID]
+
+	repo := settings.Repo
+	if err := github.ValidateRepo(repo); err != nil {
+		return err
+	}
+
+	projects, err := helpers.ReadProjectsFromFS(v)
+	if err := warnSkipped(err); err != nil {
+		return err
+	}
+
+	var project *items.Project
+	for _, p := range projects {
+		if p.ID == projectID {
+			project = &p
+			break
+		}
+	}
+	if project == nil {
+		return fmt.Errorf("project %s configured for github repository %s not found", projectID, repo)
+	}
+
+	token := settings.Token
+	if token == "" {
+		token = cfg.GitHubToken
+	}
+	if token == "" {
+		token = os.Getenv("GITHUB_TOKEN")
+	}
+
+	client := github.NewClient(token)
+	if url := cfg.GitHubAPIURL; url != "" {
+		client.BaseURL = strings.TrimSuffix(url, "/")
+	}
+	ctx := context.Background()
+
+	issues, err := client.OpenIssues(ctx, repo)
+	if err != nil {
+		return err
+	}
+
+	tasks, err := project.ReadTasksFromFS(v)
+	archived, archivedErr := project.ReadArchivedTasksFromFS(v)
+	if err := warnSkipped(items.CollectSkipped(err, archivedErr)); err != nil {
+		return err
+	}
+
+	plan := github.PlanSync(repo, issues, append(tasks, archived...), settings.CloseIssues)
+
+	if len(plan.New) > 0 {
+		// Ignore error just like the task form does.
+		author, _ := vcs.User(v)
+
+		var writeCmds []tea.Cmd
+		var paths, titles []string
+		var hookCalls []hooks.Call
+		for _, task := range plan.New {
+			task.Author = author
+			writeCmds = append(writeCmds, task.WriteTaskJSON(v, *project, "create"))
+			paths = append(paths, task.Path(*project))
+			titles = append(titles, task.Title)
+			hookCalls = append(hookCalls, task.HookCalls(*project, "create")...)
+		}
+
+		for _, cmd := range writeCmds {
+			if err := runCmd(cmd); err != nil {
+				return err
+			}
+		}
+
+		message := fmt.Sprintf("sync: %d task(s) from github %s\n\n- %s", len(titles), repo, strings.Join(titles, "\n- "))
+		if err := runCmd(vcs.CommitCmd(v, message, paths...)); err != nil {
+			return err
+		}
+		warnHooks(hooks.RunAll(v, hookCalls))
+	}
+
+	fmt.Printf("Imported %d issue(s) of %s into project %s\n", len(plan.New), repo, project.Title)
+
+	for _, number := range plan.Close {
+		if err := client.CloseIssue(ctx, repo, number); err != nil {
+			return err
+		}
+		fmt.Printf("Closed issue %s\n", github.IssueID(repo, number))
+	}
+
+	return nil
+}
+
+func init() {
+	syncGithubCmd.Flags().StringVarP(&syncProject, "project", "p", "", "Only sync this project (title or UUID)")
+	syncCmd.AddCommand(syncGithubCmd)
+	rootCmd.AddCommand(syncCmd)
+}
@@ -0,0 +1,260 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package bundle implements the backup format of yatto export and
+// yatto import. A bundle is a single JSON document holding projects
+// with their open and archived tasks and the files attached to them,
+// so that it can be moved to another machine without a shared remote.
+package bundle
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/handlebargh/yatto/internal/config"
+	"github.com/handlebargh/yatto/internal/helpers"
+	"github.com/handlebargh/yatto/internal/items"
+	"github.com/handlebargh/yatto/internal/storage"
+	"github.com/spf13/viper"
+)
+
+// Version is the version of the bundle format written by Export.
+const Version = 1
+
+// Bundle is an export of projects.
+type Bundle struct {
+	Version    int       `json:"version"`
+	ExportedAt time.Time `json:"exported_at"`
+	Projects   []Project `json:"projects"`
+}
+
+// Project is an exported project with its tasks.
+type Project struct {
+	Project  items.Project `json:"project"`
+	Tasks    []items.Task  `json:"tasks,omitempty"`
+	Archived []items.Task  `json:"archived,omitempty"`
+
+	// Attachments maps task IDs to the names and contents of their attachments.
+	Attachments map[string]map[string][]byte `json:"attachments,omitempty"`
+}
+
+// Export returns a bundle of the projects with the given IDs,
+// or of all projects if no IDs are given. Files that cannot be read
+// are skipped and reported in an items.SkippedFilesError, which is
+// returned along with the bundle.
+func Export(v *viper.Viper, projectIDs ...string) (*Bundle, error) {
+	var skippedFiles *items.SkippedFilesError
+
+	projects, err := helpers.ReadProjectsFromFS(v)
+	if err != nil && !errors.As(err, &skippedFiles) {
+		return nil, err
+	}
+	skipped := []error{err}
+	items.SortProjects(projects)
+
+	b := &Bundle{Version: Version, ExportedAt: time.Now()}
+
+	for _, project := range projects {
+		if len(projectIDs) > 0 && !slices.Contains(projectIDs, project.ID) {
+			continue
+		}
+
+		p, err := exportProject(v, project)
+		if err != nil && !errors.As(err, &skippedFiles) {
+			return nil, fmt.Errorf("project %s: %w", project.Title, err)
+		}
+		skipped = append(skipped, err)
+		b.Projects = append(b.Projects, p)
+	}
+
+	return b, items.CollectSkipped(skipped...)
+}
+
+// exportProject reads the tasks and attachments of the project. Task
+// files that cannot be read are reported in an items.SkippedFilesError,
+// which is returned along with the project.
+func exportProject(v *viper.Viper, project items.Project) (Project, error) {
+	tasks, err := project.ReadTasksFromFS(v)
+	archived, archivedErr := project.ReadArchivedTasksFromFS(v)
+	skipped := items.CollectSkipped(err, archivedErr)
+
+	var skippedFiles *items.SkippedFilesError
+	if skipped != nil && !errors.As(skipped, &skippedFiles) {
+		return Project{}, skipped
+	}
+
+	p := Project{Project: project, Tasks: tasks, Archived: archived}
+
+	root, err := os.OpenRoot(config.Get(v).StoragePath)
+	if err != nil {
+		return Project{}, fmt.Errorf("could not open storage directory: %w", err)
+	}
+	defer root.Close() //nolint:errcheck
+
+	for _, task := range slices.Concat(tasks, archived) {
+		attachments, err := task.Attachments(v, project)
+		if err != nil {
+			return Project{}, err
+		}
+
+		for _, a := range attachments {
+			data, err := root.ReadFile(filepath.Join(task.AttachmentsDir(project), a.Name))
+			if err != nil {
+				return Project{}, fmt.Errorf("could not read attachment %s: %w", a.Name, err)
+			}
+
+			if p.Attachments == nil {
+				p.Attachments = make(map[string]map[string][]byte)
+			}
+			if p.Attachments[task.ID] == nil {
+				p.Attachments[task.ID] = make(map[string][]byte)
+			}
+			p.Attachments[task.ID][a.Name] = data
+		}
+	}
+
+	return p, skipped
+}
+
+// Write writes the bundle as JSON to w.
+func (b *Bundle) Write(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(b)
+}
+
+// Read reads a bundle from r. An error is returned if the bundle
+// has an unknown version or contains IDs or file names that cannot
+// be used in the storage directory.
+func Read(r io.Reader) (*Bundle, error) {
+	var b Bundle
+	if err := json.NewDecoder(r).Decode(&b); err != nil {
+		return nil, fmt.Errorf("invalid bundle: %w", err)
+	}
+
+	if b.Version != Version {
+		return nil, fmt.Errorf("unsupported bundle version %d", b.Version)
+	}
+
+	for _, p := range b.Projects {
+		if !validName(p.Project.ID) {
+			return nil, fmt.Errorf("invalid project ID %q", p.Project.ID)
+		}
+
+		for _, task := range slices.Concat(p.Tasks, p.Archived) {
+			if !items.UUIDRegex.MatchString(task.ID + ".json") {
+				return nil, fmt.Errorf("invalid task ID %q", task.ID)
+			}
+		}
+
+		for _, files := range p.Attachments {
+			for name := range files {
+				if !validName(name) {
+					return nil, fmt.Errorf("invalid attachment name %q", name)
+				}
+			}
+		}
+	}
+
+	return &b, nil
+}
+
+// validName reports whether name can be used as a file name in a
+// directory without leaving it or being taken for a hidden file.
+func validName(name string) bool {
+	return name != "" && !strings.HasPrefix(name, ".") && !strings.ContainsAny(name, `/\`)
+}
+
+// RenewIDs gives all projects and tasks of the bundle new IDs, so that
+// it can be imported next to the projects it was exported from.
+// Blockers are changed to the new IDs of their tasks.
+func (b *Bundle) RenewIDs() {
+	for i := range b.Projects {
+		p := &b.Projects[i]
+		p.Project.ID = uuid.NewString()
+
+		ids := make(map[string]string)
+		for _, tasks := range [][]items.Task{p.Tasks, p.Archived} {
+			for j := range tasks {
+				ids[tasks[j].ID] = uuid.NewString()
+				tasks[j].ID = ids[tasks[j].ID]
+			}
+		}
+
+		for _, tasks := range [][]items.Task{p.Tasks, p.Archived} {
+			for j := range tasks {
+				for k, id := range tasks[j].BlockedBy {
+					if newID, ok := ids[id]; ok {
+						tasks[j].BlockedBy[k] = newID
+					}
+				}
+			}
+		}
+
+		attachments := make(map[string]map[string][]byte)
+		for id, files := range p.Attachments {
+			if newID, ok := ids[id]; ok {
+				attachments[newID] = files
+			}
+		}
+		p.Attachments = attachments
+	}
+}
+
+// WriteAttachments writes the attachments of the task with the given ID
+// in the project and returns their paths relative to the storage path.
+func WriteAttachments(v *viper.Viper, project items.Project, taskID string, files map[string][]byte) ([]string, error) {
+	if len(files) == 0 {
+		return nil, nil
+	}
+
+	root, err := os.OpenRoot(config.Get(v).StoragePath)
+	if err != nil {
+		return nil, fmt.Errorf("could not open storage directory: %w", err)
+	}
+	defer root.Close() //nolint:errcheck
+
+	task := items.Task{ID: taskID}
+	dir := task.AttachmentsDir(project)
+	if err := root.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+
+	var paths []string
+	for _, name := range slices.Sorted(maps.Keys(files)) {
+		path := filepath.Join(dir, name)
+		if err := storage.AtomicWrite(root, path, files[name], 0o600); err != nil {
+			return nil, fmt.Errorf("could not write attachment %s: %w", name, err)
+		}
+		paths = append(paths, path)
+	}
+
+	return paths, nil
+}
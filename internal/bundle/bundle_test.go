@@ -0,0 +1,130 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package bundle
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/handlebargh/yatto/internal/items"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportRead(t *testing.T) {
+	tempDir := t.TempDir()
+	v := viper.New()
+	v.Set("storage.path", tempDir)
+
+	project := items.Project{ID: uuid.NewString(), Title: "Test Project"}
+	projectDir := filepath.Join(tempDir, project.ID)
+	require.NoError(t, os.MkdirAll(filepath.Join(projectDir, items.ArchiveDir), 0o750))
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, "project.json"), project.MarshalProject(), 0o600))
+
+	open := items.Task{ID: uuid.NewString(), Title: "Open"}
+	archived := items.Task{ID: uuid.NewString(), Title: "Archived", Completed: true}
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, open.ID+".json"), open.MarshalTask(), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(projectDir, items.ArchiveDir, archived.ID+".json"), archived.MarshalTask(), 0o600))
+
+	attachments := filepath.Join(projectDir, open.ID+items.AttachmentsSuffix)
+	require.NoError(t, os.Mkdir(attachments, 0o750))
+	require.NoError(t, os.WriteFile(filepath.Join(attachments, "notes.txt"), []byte("notes"), 0o600))
+
+	b, err := Export(v)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, b.Write(&buf))
+
+	read, err := Read(&buf)
+	require.NoError(t, err)
+	require.Len(t, read.Projects, 1)
+
+	p := read.Projects[0]
+	assert.Equal(t, project.Title, p.Project.Title)
+	assert.Equal(t, []string{"Open"}, []string{p.Tasks[0].Title})
+	assert.Equal(t, []string{"Archived"}, []string{p.Archived[0].Title})
+	assert.Equal(t, []byte("notes"), p.Attachments[open.ID]["notes.txt"])
+
+	t.Run("writes attachments", func(t *testing.T) {
+		paths, err := WriteAttachments(v, project, archived.ID, map[string][]byte{"a.txt": []byte("a")})
+		require.NoError(t, err)
+		assert.Equal(t, []string{filepath.Join(project.ID, archived.ID+items.AttachmentsSuffix, "a.txt")}, paths)
+
+		data, err := os.ReadFile(filepath.Join(tempDir, paths[0]))
+		require.NoError(t, err)
+		assert.Equal(t, "a", string(data))
+	})
+
+	t.Run("exports the given projects only", func(t *testing.T) {
+		b, err := Export(v, "other")
+		require.NoError(t, err)
+		assert.Empty(t, b.Projects)
+	})
+}
+
+func TestRead(t *testing.T) {
+	tests := []struct {
+		name, input, err string
+	}{
+		{"unknown version", `{"version": 2}`, "unsupported bundle version"},
+		{"invalid project ID", `{"version": 1, "projects": [{"project": {"id": "../x"}}]}`, "invalid project ID"},
+		{"invalid task ID", `{"version": 1, "projects": [{"project": {"id": "p"}, "tasks": [{"id": "x"}]}]}`, "invalid task ID"},
+		{
+			"invalid attachment name",
+			`{"version": 1, "projects": [{"project": {"id": "p"}, "attachments": {"x": {"../a": ""}}}]}`,
+			"invalid attachment name",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Read(strings.NewReader(tt.input))
+			assert.ErrorContains(t, err, tt.err)
+		})
+	}
+}
+
+func TestRenewIDs(t *testing.T) {
+	blocker := items.Task{ID: uuid.NewString(), Title: "Blocker"}
+	blocked := items.Task{ID: uuid.NewString(), Title: "Blocked", BlockedBy: []string{blocker.ID, "elsewhere"}}
+
+	b := &Bundle{Version: Version, Projects: []Project{{
+		Project:     items.Project{ID: "project"},
+		Tasks:       []items.Task{blocker},
+		Archived:    []items.Task{blocked},
+		Attachments: map[string]map[string][]byte{blocker.ID: {"a.txt": nil}},
+	}}}
+
+	b.RenewIDs()
+
+	p := b.Projects[0]
+	assert.NotEqual(t, "project", p.Project.ID)
+	assert.NotEqual(t, blocker.ID, p.Tasks[0].ID)
+	assert.NotEqual(t, blocked.ID, p.Archived[0].ID)
+	assert.Equal(t, []string{p.Tasks[0].ID, "elsewhere"}, p.Archived[0].BlockedBy)
+	assert.Contains(t, p.Attachments, p.Tasks[0].ID)
+}
@@ -23,135 +23,114 @@
 package colors
 
 import (
+	"cmp"
+	"sync/atomic"
+
 	"github.com/charmbracelet/huh"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/spf13/viper"
 )
 
-// Red returns an AdaptiveColor configured for light and dark themes.
-//
-// The color values are loaded from Viper configuration keys:
-//   - "colors.red_light" for the light theme
-//   - "colors.red_dark" for the dark theme
-//
-// These should be set via Viper's defaults or loaded from a config file
-// before calling this function. If not set, the returned color will use empty strings.
-func Red() lipgloss.AdaptiveColor {
-	return lipgloss.AdaptiveColor{
-		Light: viper.GetString("colors.red_light"),
-		Dark:  viper.GetString("colors.red_dark"),
-	}
+// Palette holds the colors and form theme used by the application.
+type Palette struct {
+	Red       lipgloss.AdaptiveColor
+	VividRed  lipgloss.AdaptiveColor
+	Indigo    lipgloss.AdaptiveColor
+	Green     lipgloss.AdaptiveColor
+	Orange    lipgloss.AdaptiveColor
+	Blue      lipgloss.AdaptiveColor
+	Yellow    lipgloss.AdaptiveColor
+	BadgeText lipgloss.AdaptiveColor
+	FormTheme string
 }
 
-// VividRed returns an AdaptiveColor configured for light and dark themes.
-//
-// The color values are loaded from Viper configuration keys:
-//   - "colors.vividRed_light" for the light theme
-//   - "colors.vividRed_dark" for the dark theme
-//
-// These should be set via Viper's defaults or loaded from a config file
-// before calling this function. If not set, the returned color will use empty strings.
-func VividRed() lipgloss.AdaptiveColor {
-	return lipgloss.AdaptiveColor{
-		Light: viper.GetString("colors.vividRed_light"),
-		Dark:  viper.GetString("colors.vividRed_dark"),
+// NewPalette returns the palette configured in v.
+//
+// Each color is loaded from a pair of configuration keys for the light and
+// the dark theme, e.g. "colors.red_light" and "colors.red_dark". The form
+// theme is loaded from "colors.form.theme". If a theme is selected with
+// "colors.theme", its colors and form theme are used instead, see LoadTheme.
+// Keys that are not set result in empty values.
+func NewPalette(v *viper.Viper) *Palette {
+	// An invalid theme is reported by the config validation.
+	theme := &Theme{}
+	if name := v.GetString("colors.theme"); name != "" {
+		if loaded, err := LoadTheme(ThemeDir(v), name); err == nil {
+			theme = loaded
+		}
 	}
-}
 
-// Indigo returns an AdaptiveColor configured for light and dark themes.
-//
-// The color values are loaded from Viper configuration keys:
-//   - "colors.indigo_light" for the light theme
-//   - "colors.indigo_dark" for the dark theme
-//
-// These should be set via Viper's defaults or loaded from a config file
-// before calling this function. If not set, the returned color will use empty strings.
-func Indigo() lipgloss.AdaptiveColor {
-	return lipgloss.AdaptiveColor{
-		Light: viper.GetString("colors.indigo_light"),
-		Dark:  viper.GetString("colors.indigo_dark"),
+	value := func(key string) string {
+		if value, ok := theme.Colors[key]; ok {
+			return value
+		}
+		return v.GetString("colors." + key)
 	}
-}
 
-// Green returns an AdaptiveColor configured for light and dark themes.
-//
-// The color values are loaded from Viper configuration keys:
-//   - "colors.green_light" for the light theme
-//   - "colors.green_dark" for the dark theme
-//
-// These should be set via Viper's defaults or loaded from a config file
-// before calling this function. If not set, the returned color will use empty strings.
-func Green() lipgloss.AdaptiveColor {
-	return lipgloss.AdaptiveColor{
-		Light: viper.GetString("colors.green_light"),
-		Dark:  viper.GetString("colors.green_dark"),
+	adaptive := func(name string) lipgloss.AdaptiveColor {
+		return lipgloss.AdaptiveColor{
+			Light: value(name + "_light"),
+			Dark:  value(name + "_dark"),
+		}
 	}
-}
 
-// Orange returns an AdaptiveColor configured for light and dark themes.
-//
-// The color values are loaded from Viper configuration keys:
-//   - "colors.orange_light" for the light theme
-//   - "colors.orange_dark" for the dark theme
-//
-// These should be set via Viper's defaults or loaded from a config file
-// before calling this function. If not set, the returned color will use empty strings.
-func Orange() lipgloss.AdaptiveColor {
-	return lipgloss.AdaptiveColor{
-		Light: viper.GetString("colors.orange_light"),
-		Dark:  viper.GetString("colors.orange_dark"),
+	return &Palette{
+		Red:       adaptive("red"),
+		VividRed:  adaptive("vividred"),
+		Indigo:    adaptive("indigo"),
+		Green:     adaptive("green"),
+		Orange:    adaptive("orange"),
+		Blue:      adaptive("blue"),
+		Yellow:    adaptive("yellow"),
+		BadgeText: adaptive("badge_text"),
+		FormTheme: cmp.Or(theme.FormTheme, v.GetString("colors.form.theme")),
 	}
 }
 
-// Blue returns an AdaptiveColor configured for light and dark themes.
-//
-// The color values are loaded from Viper configuration keys:
-//   - "colors.blue_light" for the light theme
-//   - "colors.blue_dark" for the dark theme
-//
-// These should be set via Viper's defaults or loaded from a config file
-// before calling this function. If not set, the returned color will use empty strings.
-func Blue() lipgloss.AdaptiveColor {
-	return lipgloss.AdaptiveColor{
-		Light: viper.GetString("colors.blue_light"),
-		Dark:  viper.GetString("colors.blue_dark"),
-	}
-}
+// current is the palette returned by the functions of this package.
+// It is replaced as a whole by Configure, so it can be read concurrently.
+var current atomic.Pointer[Palette]
 
-// Yellow returns an AdaptiveColor configured for light and dark themes.
-//
-// The color values are loaded from Viper configuration keys:
-//   - "colors.yellow_light" for the light theme
-//   - "colors.yellow_dark" for the dark theme
-//
-// These should be set via Viper's defaults or loaded from a config file
-// before calling this function. If not set, the returned color will use empty strings.
-func Yellow() lipgloss.AdaptiveColor {
-	return lipgloss.AdaptiveColor{
-		Light: viper.GetString("colors.yellow_light"),
-		Dark:  viper.GetString("colors.yellow_dark"),
-	}
+func init() {
+	current.Store(&Palette{})
 }
 
-// BadgeText returns an AdaptiveColor configured for light and dark themes.
-//
-// The color values are loaded from Viper configuration keys:
-//   - "colors.badge_text_light" for the light theme
-//   - "colors.Badge_text_dark" for the dark theme
-//
-// These should be set via Viper's defaults or loaded from a config file
-// before calling this function. If not set, the returned color will use empty strings.
-func BadgeText() lipgloss.AdaptiveColor {
-	return lipgloss.AdaptiveColor{
-		Light: viper.GetString("colors.badge_text_light"),
-		Dark:  viper.GetString("colors.Badge_text_dark"),
-	}
+// Configure makes the palette configured in v the one used by the
+// functions of this package and enables the plain output mode if
+// "ui.plain" is set, see SetPlain. It must be called once the
+// configuration is loaded; until then all colors are empty.
+func Configure(v *viper.Viper) {
+	current.Store(NewPalette(v))
+	SetPlain(v.GetBool("ui.plain"))
 }
 
+// Red returns the configured red for light and dark themes.
+func Red() lipgloss.AdaptiveColor { return current.Load().Red }
+
+// VividRed returns the configured vivid red for light and dark themes.
+func VividRed() lipgloss.AdaptiveColor { return current.Load().VividRed }
+
+// Indigo returns the configured indigo for light and dark themes.
+func Indigo() lipgloss.AdaptiveColor { return current.Load().Indigo }
+
+// Green returns the configured green for light and dark themes.
+func Green() lipgloss.AdaptiveColor { return current.Load().Green }
+
+// Orange returns the configured orange for light and dark themes.
+func Orange() lipgloss.AdaptiveColor { return current.Load().Orange }
+
+// Blue returns the configured blue for light and dark themes.
+func Blue() lipgloss.AdaptiveColor { return current.Load().Blue }
+
+// Yellow returns the configured yellow for light and dark themes.
+func Yellow() lipgloss.AdaptiveColor { return current.Load().Yellow }
+
+// BadgeText returns the configured badge text color for light and dark themes.
+func BadgeText() lipgloss.AdaptiveColor { return current.Load().BadgeText }
+
 // FormTheme returns a pointer to a huh.Theme based on the configured theme name.
 //
-// It reads the configuration key "colors.form.theme" using Viper and returns the
-// corresponding predefined theme from the huh package. Supported theme values are:
+// Supported theme values are:
 //
 //   - "Charm"
 //   - "Dracula"
@@ -159,10 +138,10 @@ func BadgeText() lipgloss.AdaptiveColor {
 //   - "Base16"
 //   - "Base"
 //
-// If the configuration key is unset or does not match any of the supported values,
+// If the theme is unset or does not match any of the supported values,
 // the function defaults to returning ThemeBase16.
 func FormTheme() *huh.Theme {
-	switch viper.GetString("colors.form.theme") {
+	switch current.Load().FormTheme {
 	case "Charm":
 		return huh.ThemeCharm()
 	case "Dracula":
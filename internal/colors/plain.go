@@ -0,0 +1,160 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package colors
+
+import (
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+// plain reports whether the plain output mode is enabled, see SetPlain.
+var plain atomic.Bool
+
+var (
+	// profileMu guards profile.
+	profileMu sync.Mutex
+
+	// profile is the color profile in use before the plain
+	// output mode was enabled.
+	profile termenv.Profile
+)
+
+// asciiGlyphs maps the Unicode glyphs used by the application and the
+// widgets it is built with to ASCII characters. Each replacement has the
+// width of the glyph, so that the layout of a view is kept.
+var asciiGlyphs = map[rune]rune{
+	// Status messages and badges.
+	'🗸': '+',
+	'🗑': 'x',
+	'✘': 'x',
+	'✓': '+',
+	'✗': 'x',
+	'⚠': '!',
+	'⚙': '*',
+	'🗘': '*',
+	'⟳': '*',
+	'↶': '<',
+	'↻': '@',
+	'⏱': '~',
+	'⇡': '^',
+	'⋯': '.',
+	'★': '*',
+	'●': '*',
+	'◆': '*',
+	'◇': 'o',
+	'⟹': '>',
+	'❯': '>',
+	'›': '>',
+
+	// Punctuation.
+	'·': '-',
+	'•': '*',
+	'・': '-',
+	'―': '-',
+	'—': '-',
+	'…': '~',
+
+	// Arrows used in the help.
+	'←': '<',
+	'→': '>',
+	'↑': '^',
+	'↓': 'v',
+
+	// Borders and charts.
+	'─': '-',
+	'━': '-',
+	'│': '|',
+	'┃': '|',
+	'╭': '+',
+	'╮': '+',
+	'╰': '+',
+	'╯': '+',
+	'┌': '+',
+	'┐': '+',
+	'└': '+',
+	'┘': '+',
+	'├': '+',
+	'┤': '+',
+	'┬': '+',
+	'┴': '+',
+	'┼': '+',
+	'▁': '1',
+	'▂': '2',
+	'▃': '3',
+	'▄': '4',
+	'▅': '5',
+	'▆': '6',
+	'▇': '7',
+	'█': '8',
+}
+
+// SetPlain enables or disables the plain output mode for dumb terminals
+// and screen readers. In plain mode no colors are used and ASCII replaces
+// the Unicode glyphs of the output, see ASCII.
+func SetPlain(enable bool) {
+	profileMu.Lock()
+	defer profileMu.Unlock()
+
+	if plain.Swap(enable) == enable {
+		return
+	}
+
+	if enable {
+		profile = lipgloss.ColorProfile()
+		lipgloss.SetColorProfile(termenv.Ascii)
+	} else {
+		lipgloss.SetColorProfile(profile)
+	}
+}
+
+// Plain reports whether the plain output mode is enabled.
+func Plain() bool { return plain.Load() }
+
+// NoColor reports whether colors are disabled, either by the plain
+// output mode or by setting the NO_COLOR environment variable,
+// see https://no-color.org.
+func NoColor() bool {
+	return Plain() || os.Getenv("NO_COLOR") != ""
+}
+
+// ASCII returns s with its Unicode glyphs replaced by ASCII characters
+// if the plain output mode is enabled. Otherwise, s is returned as is.
+func ASCII(s string) string {
+	if !Plain() {
+		return s
+	}
+
+	return strings.Map(func(r rune) rune {
+		if ascii, ok := asciiGlyphs[r]; ok {
+			return ascii
+		}
+		// The frames of spinners.
+		if r >= '⠀' && r <= '⣿' {
+			return '*'
+		}
+		return r
+	}, s)
+}
@@ -0,0 +1,60 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package colors
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlain(t *testing.T) {
+	lipgloss.SetColorProfile(termenv.TrueColor)
+	t.Cleanup(func() { SetPlain(false) })
+
+	const status = "🗸  Task created ― committing changes • ⟹  ✘ 🗑 ü"
+	assert.Equal(t, status, ASCII(status))
+	assert.Equal(t, "\x1b[38;2;255;0;0mred\x1b[0m",
+		lipgloss.NewStyle().Foreground(lipgloss.Color("#FF0000")).Render("red"))
+
+	SetPlain(true)
+	assert.True(t, Plain())
+	assert.True(t, NoColor())
+	assert.Equal(t, "+  Task created - committing changes * >  x x ü", ASCII(status))
+	assert.Equal(t, "red", lipgloss.NewStyle().Foreground(lipgloss.Color("#FF0000")).Render("red"))
+
+	// The color profile in use before is restored.
+	SetPlain(false)
+	assert.False(t, Plain())
+	assert.Equal(t, termenv.TrueColor, lipgloss.ColorProfile())
+	assert.Equal(t, status, ASCII(status))
+}
+
+func TestNoColor(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	assert.False(t, NoColor())
+
+	t.Setenv("NO_COLOR", "1")
+	assert.True(t, NoColor())
+	assert.False(t, Plain())
+}
@@ -0,0 +1,159 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package colors
+
+import (
+	"bytes"
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// builtinThemes holds the themes shipped with the application.
+//
+//go:embed themes/*.toml
+var builtinThemes embed.FS
+
+// Names lists the colors of the palette by their name in the
+// configuration, e.g. "red" for "colors.red_light" and "colors.red_dark".
+var Names = []string{"red", "vividred", "indigo", "green", "orange", "blue", "yellow", "badge_text"}
+
+// ThemesDir is the name of the directory next to the config file
+// that holds the theme files of the user.
+const ThemesDir = "themes"
+
+// ErrUnknownTheme is returned by LoadTheme for themes that are
+// neither built in nor found in the themes directory.
+var ErrUnknownTheme = errors.New("unknown theme")
+
+// Theme is a color theme loaded from a TOML file. Theme files use the
+// keys of the colors section of the config file, e.g. "red_light" and
+// "red_dark", and may name a form theme with "form_theme".
+type Theme struct {
+	// Colors maps the keys of the colors set by the theme to their values.
+	Colors map[string]string
+
+	// FormTheme is the form theme used with the theme, if any.
+	FormTheme string
+}
+
+// ThemeDir returns the directory holding the theme files of the user,
+// next to the config file used by v, or an empty string if there is none.
+func ThemeDir(v *viper.Viper) string {
+	file := v.ConfigFileUsed()
+	if file == "" {
+		return ""
+	}
+
+	return filepath.Join(filepath.Dir(file), ThemesDir)
+}
+
+// LoadTheme loads the theme with the given name. A file named after the
+// theme in dir, e.g. "gruvbox.toml", takes precedence over a built-in theme.
+func LoadTheme(dir, name string) (*Theme, error) {
+	if name == "" || strings.ContainsAny(name, `/\`) || name == "." || name == ".." {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownTheme, name)
+	}
+
+	if dir != "" {
+		data, err := os.ReadFile(filepath.Join(dir, name+".toml"))
+		if err == nil {
+			return parseTheme(name, data)
+		}
+		if !errors.Is(err, fs.ErrNotExist) {
+			return nil, fmt.Errorf("could not read theme %q: %w", name, err)
+		}
+	}
+
+	data, err := builtinThemes.ReadFile("themes/" + name + ".toml")
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownTheme, name)
+	}
+
+	return parseTheme(name, data)
+}
+
+// parseTheme parses the theme file of the named theme.
+// Keys other than the colors and the form theme are rejected.
+func parseTheme(name string, data []byte) (*Theme, error) {
+	file := viper.New()
+	file.SetConfigType("toml")
+	if err := file.ReadConfig(bytes.NewReader(data)); err != nil {
+		return nil, fmt.Errorf("invalid theme %q: %w", name, err)
+	}
+
+	theme := &Theme{Colors: make(map[string]string)}
+
+	for _, key := range file.AllKeys() {
+		switch {
+		case key == "form_theme":
+			theme.FormTheme = file.GetString(key)
+		case isColorKey(key):
+			theme.Colors[key] = file.GetString(key)
+		default:
+			return nil, fmt.Errorf("invalid theme %q: unknown key %q", name, key)
+		}
+	}
+
+	return theme, nil
+}
+
+// isColorKey reports whether key is the light or dark value
+// of one of the colors, e.g. "red_light".
+func isColorKey(key string) bool {
+	for _, suffix := range []string{"_light", "_dark"} {
+		if color, ok := strings.CutSuffix(key, suffix); ok && slices.Contains(Names, color) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ThemeNames returns the sorted names of the built-in themes
+// and of the theme files in dir.
+func ThemeNames(dir string) []string {
+	var names []string
+
+	builtin, _ := fs.Glob(builtinThemes, "themes/*.toml")
+	for _, file := range builtin {
+		names = append(names, strings.TrimSuffix(path.Base(file), ".toml"))
+	}
+
+	if dir != "" {
+		files, _ := filepath.Glob(filepath.Join(dir, "*.toml"))
+		for _, file := range files {
+			names = append(names, strings.TrimSuffix(filepath.Base(file), ".toml"))
+		}
+	}
+
+	slices.Sort(names)
+
+	return slices.Compact(names)
+}
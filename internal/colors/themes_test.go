@@ -0,0 +1,106 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package colors
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadTheme(t *testing.T) {
+	dir := t.TempDir()
+
+	t.Run("built-in themes set all colors", func(t *testing.T) {
+		for _, name := range ThemeNames("") {
+			theme, err := LoadTheme("", name)
+			assert.NoError(t, err)
+			assert.Len(t, theme.Colors, 2*len(Names), name)
+			assert.NotEmpty(t, theme.FormTheme, name)
+		}
+	})
+
+	t.Run("theme files take precedence", func(t *testing.T) {
+		data := []byte("form_theme = \"Dracula\"\nred_dark = \"#FF0000\"\n")
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, "gruvbox.toml"), data, 0o600))
+
+		theme, err := LoadTheme(dir, "gruvbox")
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{"red_dark": "#FF0000"}, theme.Colors)
+		assert.Equal(t, "Dracula", theme.FormTheme)
+	})
+
+	t.Run("unknown keys are rejected", func(t *testing.T) {
+		data := []byte("purple_dark = \"#800080\"\n")
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, "purple.toml"), data, 0o600))
+
+		_, err := LoadTheme(dir, "purple")
+		assert.ErrorContains(t, err, `unknown key "purple_dark"`)
+	})
+
+	t.Run("unknown themes", func(t *testing.T) {
+		for _, name := range []string{"", "neon", "../gruvbox", ".."} {
+			_, err := LoadTheme(dir, name)
+			assert.ErrorIs(t, err, ErrUnknownTheme, name)
+		}
+	})
+
+	t.Run("names", func(t *testing.T) {
+		assert.Equal(t, []string{"catppuccin", "gruvbox", "purple", "solarized"}, ThemeNames(dir))
+	})
+}
+
+func TestNewPalette(t *testing.T) {
+	dir := t.TempDir()
+	configPath := filepath.Join(dir, "config.toml")
+	assert.NoError(t, os.Mkdir(filepath.Join(dir, ThemesDir), 0o700))
+	assert.NoError(t, os.WriteFile(
+		filepath.Join(dir, ThemesDir, "custom.toml"),
+		[]byte("red_light = \"#111111\"\nred_dark = \"#222222\"\n"),
+		0o600,
+	))
+
+	v := viper.New()
+	v.SetConfigFile(configPath)
+	v.Set("colors.red_light", "#AAAAAA")
+	v.Set("colors.blue_dark", "#BBBBBB")
+	v.Set("colors.form.theme", "Base")
+
+	p := NewPalette(v)
+	assert.Equal(t, "#AAAAAA", p.Red.Light)
+	assert.Equal(t, "Base", p.FormTheme)
+
+	// The colors of the theme replace the configured ones,
+	// the others are kept.
+	v.Set("colors.theme", "custom")
+	p = NewPalette(v)
+	assert.Equal(t, "#111111", p.Red.Light)
+	assert.Equal(t, "#222222", p.Red.Dark)
+	assert.Equal(t, "#BBBBBB", p.Blue.Dark)
+	assert.Equal(t, "Base", p.FormTheme)
+
+	v.Set("colors.theme", "catppuccin")
+	p = NewPalette(v)
+	assert.Equal(t, "Catppuccin", p.FormTheme)
+}
@@ -22,14 +22,24 @@
 package config
 
 import (
+	"cmp"
 	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"os"
 	"path/filepath"
 	"regexp"
+	"runtime"
+	"slices"
+	"strings"
+	"time"
 
 	"github.com/charmbracelet/huh"
+	"github.com/fsnotify/fsnotify"
+	"github.com/handlebargh/yatto/internal/colors"
+	"github.com/handlebargh/yatto/internal/hooks"
+	"github.com/handlebargh/yatto/internal/logging"
 	"github.com/spf13/viper"
 )
 
@@ -45,6 +55,29 @@ var (
 
 	// Validates color codes
 	colorRegexp = regexp.MustCompile(`^#?[a-fA-F0-9]+$`)
+
+	// ErrUnknownProfile is returned by ApplyProfile for profiles
+	// not defined in the config file.
+	ErrUnknownProfile = errors.New("unknown profile")
+)
+
+const (
+	// ProfileKey holds the name of the profile applied by ApplyProfile.
+	ProfileKey = "profile"
+
+	// baseStoragePathKey holds the storage path configured outside of
+	// the profile applied by ApplyProfile.
+	baseStoragePathKey = "profile_base_storage_path"
+
+	// loadedKey holds the Config loaded by Load, see Get.
+	loadedKey = "loaded_config"
+
+	// MirrorRequired is the policy of mirrors that must be pushed to.
+	MirrorRequired = "required"
+
+	// MirrorOptional is the policy of mirrors whose failed pushes are
+	// ignored. Mirrors without a policy are optional.
+	MirrorOptional = "optional"
 )
 
 // config is used to load all values from the configuration file
@@ -57,6 +90,7 @@ type config struct {
 	gitRemoteEnable     bool
 	jjRemoteEnable      bool
 	jjRemoteColocate    bool
+	gitBranchPerProject bool
 	storagePath         string
 	vcsBackend          string
 	gitDefaultBranch    string
@@ -64,21 +98,158 @@ type config struct {
 	jjDefaultBranch     string
 	jjRemoteName        string
 	colorsFormTheme     string
+	colorsTheme         string
+	theme               *colors.Theme
 	colorValues         map[string]string
+	pomodoroWork        string
+	pomodoroBreak       string
+	syncInterval        string
+	dueSoon             string
+	dueAllDay           bool
+	startupView         string
+	listCompact         bool
+	logLevel            string
+	mirrors             map[string]mirror
+	hooks               map[string][]string
+}
+
+// mirror holds the settings of an additional remote
+// the storage repository is pushed to.
+type mirror struct {
+	url    string
+	policy string
 }
 
 // InitConfig sets default values for application configuration and
-// attempts to load configuration from a file.
+// attempts to load configuration from a file. The config file is looked
+// for in ConfigDir, or in ~/.config/yatto if it only exists there.
+// The default storage path is DataDir, or the legacy ~/.yatto as long
+// as it was not moved there, see MigrateStorage.
 func InitConfig(v *viper.Viper, home string, configPath *string) {
-	v.SetDefault("storage.path", filepath.Join(home, ".yatto"))
+	setDefaults(v, home)
+
+	legacy := legacyStoragePath(home)
+	if exists(legacy) && !exists(DataDir(home)) {
+		v.SetDefault("storage.path", legacy)
+	}
+
+	if *configPath != "" {
+		v.SetConfigFile(*configPath)
+	} else {
+		dir := ConfigDir(home)
+		if legacyDir := filepath.Join(home, ".config", "yatto"); !exists(filepath.Join(dir, "config.toml")) &&
+			exists(filepath.Join(legacyDir, "config.toml")) {
+			dir = legacyDir
+		}
+
+		v.SetConfigName("config")
+		v.SetConfigType("toml")
+		v.AddConfigPath(dir)
+		*configPath = filepath.Join(dir, "config.toml")
+	}
+}
+
+// ConfigDir returns the directory of the config file and themes,
+// $XDG_CONFIG_HOME/yatto or ~/.config/yatto if XDG_CONFIG_HOME is unset.
+func ConfigDir(home string) string {
+	return filepath.Join(xdgDir("XDG_CONFIG_HOME", filepath.Join(home, ".config")), "yatto")
+}
+
+// DataDir returns the default storage directory, $XDG_DATA_HOME/yatto or
+// ~/.local/share/yatto if XDG_DATA_HOME is unset. On Windows, where
+// there is no such convention, it is ~/.yatto unless XDG_DATA_HOME is set.
+func DataDir(home string) string {
+	fallback := filepath.Join(home, ".local", "share")
+	if runtime.GOOS == "windows" {
+		fallback = ""
+	}
+
+	if dir := xdgDir("XDG_DATA_HOME", fallback); dir != "" {
+		return filepath.Join(dir, "yatto")
+	}
+
+	return legacyStoragePath(home)
+}
+
+// xdgDir returns the directory in the XDG environment variable env,
+// or fallback if it is unset. Relative paths are ignored as demanded
+// by the XDG Base Directory Specification.
+func xdgDir(env, fallback string) string {
+	if dir := os.Getenv(env); filepath.IsAbs(dir) {
+		return dir
+	}
+
+	return fallback
+}
+
+// legacyStoragePath returns the default storage path of earlier
+// versions of yatto.
+func legacyStoragePath(home string) string {
+	return filepath.Join(home, ".yatto")
+}
+
+// exists reports whether a file or directory exists at path.
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
+
+// MigrateStorage moves the storage directory of earlier versions of
+// yatto, ~/.yatto, to DataDir, if v uses it and DataDir does not exist
+// yet. If the config file names ~/.yatto as storage path, which earlier
+// versions wrote there by default, it is changed to DataDir. A config
+// file without storage path used ~/.yatto by default as well, whatever
+// default v has. Nothing is moved for profiles, as they name their
+// storage path on purpose.
+// The new storage path is returned, or an empty string if nothing was
+// moved.
+func MigrateStorage(v *viper.Viper, home string) (string, error) {
+	legacy, target := legacyStoragePath(home), DataDir(home)
+
+	path := filepath.Clean(v.GetString("storage.path"))
+	if !v.InConfig("storage.path") && path == target {
+		path = legacy
+	}
+
+	if v.GetString(ProfileKey) != "" || legacy == target ||
+		path != legacy || !exists(legacy) || exists(target) {
+		return "", nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(target), 0o700); err != nil {
+		return "", fmt.Errorf("could not move storage directory to %s: %w", target, err)
+	}
+	if err := os.Rename(legacy, target); err != nil {
+		return "", fmt.Errorf("could not move storage directory to %s: %w", target, err)
+	}
+
+	if v.InConfig("storage.path") {
+		if _, _, err := Update(v, map[string]any{"storage.path": target}); err != nil {
+			return target, fmt.Errorf("moved storage directory to %s, but could not update the config file: %w", target, err)
+		}
+	}
+	v.SetDefault("storage.path", target)
+	v.Set("storage.path", target)
+	if _, ok := v.Get(loadedKey).(*Config); ok {
+		v.Set(loadedKey, read(v))
+	}
+
+	return target, nil
+}
+
+// setDefaults sets the default values of all settings.
+func setDefaults(v *viper.Viper, home string) {
+	v.SetDefault("storage.path", DataDir(home))
 
 	// assignee
 	v.SetDefault("assignee.show", false)
 	v.SetDefault("assignee.show_printer", false)
+	v.SetDefault("assignee.roster", "")
 
 	// author
 	v.SetDefault("author.show", false)
 	v.SetDefault("author.show_printer", false)
+	v.SetDefault("author.protect_delete", false)
 
 	// vcs
 	v.SetDefault("vcs.backend", "git")
@@ -87,6 +258,7 @@ func InitConfig(v *viper.Viper, home string, configPath *string) {
 	v.SetDefault("git.default_branch", "main")
 	v.SetDefault("git.remote.enable", false)
 	v.SetDefault("git.remote.name", "origin")
+	v.SetDefault("git.branch_per_project", false)
 
 	// jj
 	v.SetDefault("jj.default_branch", "main")
@@ -112,25 +284,75 @@ func InitConfig(v *viper.Viper, home string, configPath *string) {
 	v.SetDefault("colors.badge_text_light", "#000000")
 	v.SetDefault("colors.badge_text_dark", "#000000")
 
+	// Color themes; empty uses the colors above.
+	v.SetDefault("colors.theme", "")
+
 	// Form themes
 	v.SetDefault("colors.form.theme", "Base16")
 
-	if *configPath != "" {
-		v.SetConfigFile(*configPath)
-	} else {
-		v.SetConfigName("config")
-		v.SetConfigType("toml")
-		v.AddConfigPath(filepath.Join(home, ".config", "yatto"))
-		*configPath = filepath.Join(home, ".config", "yatto", "config.toml")
+	// pomodoro
+	v.SetDefault("pomodoro.work", "25m")
+	v.SetDefault("pomodoro.break", "5m")
+	v.SetDefault("pomodoro.notify", false)
+
+	// sync
+	v.SetDefault("sync.interval", "0")
+
+	// due dates
+	v.SetDefault("due.soon", "0")
+	v.SetDefault("due.all_day", false)
+
+	// startup
+	v.SetDefault("startup.view", "projects")
+
+	// list
+	v.SetDefault("list.compact", false)
+
+	// ui
+	v.SetDefault("ui.plain", false)
+
+	// log
+	v.SetDefault("log.level", "info")
+}
+
+// Profiles returns the names of the profiles defined in the config file
+// as tables like [profiles.work], sorted by name.
+func Profiles(v *viper.Viper) []string {
+	return slices.Sorted(maps.Keys(v.GetStringMap("profiles")))
+}
+
+// ApplyProfile overrides the settings of v with the ones of the profile
+// with the given name. Profiles hold any setting of the config file,
+// e.g. "storage.path" or "vcs.backend", in their table. Settings not
+// set by the profile keep their value. The name of the profile is kept
+// as ProfileKey, so that Watch and Update apply it again.
+func ApplyProfile(v *viper.Viper, name string) error {
+	if !slices.Contains(Profiles(v), name) {
+		return fmt.Errorf("%w %q (available: %s)", ErrUnknownProfile, name, strings.Join(Profiles(v), ", "))
+	}
+
+	if v.GetString(ProfileKey) == "" {
+		v.Set(baseStoragePathKey, v.GetString("storage.path"))
 	}
+
+	// Sub returns nil for an empty profile.
+	if profile := v.Sub("profiles." + name); profile != nil {
+		for _, key := range profile.AllKeys() {
+			v.Set(key, profile.Get(key))
+		}
+	}
+	v.Set(ProfileKey, name)
+
+	return nil
 }
 
 // Settings defines the runtime settings used by CreateConfigFile.
 //
 // Fields:
-//   - Viper:  The viper instance to use for configuration.
-//   - Input:  Input stream to read user responses (e.g., os.Stdin).
-//   - Output: Output stream to print prompts and messages (e.g., os.Stdout).
+//   - Viper:      The viper instance to use for configuration.
+//   - Input:      Input stream to read user responses (e.g., os.Stdin).
+//   - Output:     Output stream to print prompts and messages (e.g., os.Stdout).
+//   - Onboarding: If set, the first project and an import are asked for too.
 type Settings struct {
 	Viper      *viper.Viper
 	ConfigPath string
@@ -138,17 +360,36 @@ type Settings struct {
 	Input      io.Reader
 	Output     io.Writer
 	Exit       func(int)
+	Onboarding *Onboarding
+}
+
+// Onboarding holds the answers of the first-run steps that go beyond the
+// config file. They are applied by the caller once the storage directory
+// exists.
+//
+// Fields:
+//   - FirstRun:     Whether the config file was created.
+//   - Project:      Title of the first project, empty to skip.
+//   - ImportFormat: Format of the file to import ("todotxt" or "taskwarrior"), empty to skip.
+//   - ImportFile:   Absolute path of the file to import.
+type Onboarding struct {
+	FirstRun     bool
+	Project      string
+	ImportFormat string
+	ImportFile   string
 }
 
 // CreateConfigFile ensures that a configuration file exists for the application.
 // It first attempts to read an existing config using Viper. If no config file
 // is found, the user is prompted to confirm creation of a new one at the default
-// location ($HOME/.config/config.toml) or at set.ConfigPath if specified.
+// location (config.toml in ConfigDir) or at set.ConfigPath if specified.
 //
 // The function then asks the user to choose some configuration values. These
-// values are stored in the config file.
+// values are stored in the config file. If a remote repository is given, it
+// is cloned into the storage directory later on. With settings.Onboarding
+// set, the user is also asked for a first project and a file to import.
 //
-// If necessary, the configuration directory ($HOME/.config/yatto) is created
+// If necessary, the directory of the config file is created
 // with permissions 0750, and Viper writes the config file safely using
 // viper.SafeWriteConfig.
 //
@@ -161,7 +402,7 @@ func CreateConfigFile(settings Settings) error {
 			return fmt.Errorf("fatal error getting config: %w", err)
 		}
 
-		path := filepath.Join(settings.Home, ".config", "yatto", "config.toml")
+		path := filepath.Join(ConfigDir(settings.Home), "config.toml")
 		if settings.ConfigPath != "" {
 			path = settings.ConfigPath
 		}
@@ -171,6 +412,7 @@ func CreateConfigFile(settings Settings) error {
 			choiceVCS    string
 			colocateJJ   bool
 			remoteURL    string
+			storagePath  = settings.Viper.GetString("storage.path")
 		)
 
 		form := huh.NewForm(
@@ -198,7 +440,9 @@ func CreateConfigFile(settings Settings) error {
 					Title("Choose your version control system").
 					Options(
 						huh.NewOption("Git", "git"),
+						huh.NewOption("Git (built-in, no git binary required)", "gogit"),
 						huh.NewOption("Jujutsu", "jj"),
+						huh.NewOption("None (plain files, no history or sync)", "none"),
 					).
 					Value(&choiceVCS),
 			),
@@ -228,12 +472,46 @@ func CreateConfigFile(settings Settings) error {
 			settings.Viper.Set("jj.colocate", colocateJJ)
 		}
 
+		// Without a vcs there is no remote to sync with.
+		if choiceVCS != "none" {
+			form = huh.NewForm(
+				huh.NewGroup(
+					huh.NewInput().
+						Title("Remote repository URL").
+						Description("e.g. git@github.com:<username>/<repo>.git\n" +
+							"An existing repository is cloned, leave empty to skip").
+						Value(&remoteURL),
+				),
+			)
+
+			if err := form.Run(); err != nil {
+				return err
+			}
+
+			if remoteURL != "" {
+				switch choiceVCS {
+				case "git", "gogit":
+					settings.Viper.Set("git.remote.enable", true)
+					settings.Viper.Set("git.remote.url", remoteURL)
+				case "jj":
+					settings.Viper.Set("jj.remote.enable", true)
+					settings.Viper.Set("jj.remote.url", remoteURL)
+				}
+			}
+		}
+
 		form = huh.NewForm(
 			huh.NewGroup(
 				huh.NewInput().
-					Title("Remote repository URL").
-					Description("e.g. git@github.com:<username>/<repo>.git\nLeave empty to skip").
-					Value(&remoteURL),
+					Title("Storage directory").
+					Description("Where your projects and tasks are stored").
+					Validate(func(s string) error {
+						if strings.TrimSpace(s) == "" {
+							return errors.New("storage directory must not be empty")
+						}
+						return nil
+					}).
+					Value(&storagePath),
 			),
 		)
 
@@ -241,19 +519,20 @@ func CreateConfigFile(settings Settings) error {
 			return err
 		}
 
-		if remoteURL != "" {
-			switch choiceVCS {
-			case "git":
-				settings.Viper.Set("git.remote.enable", true)
-				settings.Viper.Set("git.remote.url", remoteURL)
-			case "jj":
-				settings.Viper.Set("jj.remote.enable", true)
-				settings.Viper.Set("jj.remote.url", remoteURL)
+		// Keep the default out of the config file, so the storage
+		// directory follows the data directory.
+		if storagePath = strings.TrimSpace(storagePath); storagePath != settings.Viper.GetString("storage.path") {
+			settings.Viper.Set("storage.path", storagePath)
+		}
+
+		if settings.Onboarding != nil {
+			if err := askOnboarding(settings.Onboarding); err != nil {
+				return err
 			}
 		}
 
 		// Create config dir
-		if err := os.MkdirAll(filepath.Join(settings.Home, ".config", "yatto"), 0o750); err != nil {
+		if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
 			return fmt.Errorf("error creating config directory: %w", err)
 		}
 
@@ -266,10 +545,281 @@ func CreateConfigFile(settings Settings) error {
 	return nil
 }
 
+// askOnboarding asks for the first project and a file to import into it.
+func askOnboarding(onboarding *Onboarding) error {
+	onboarding.FirstRun = true
+
+	form := huh.NewForm(
+		huh.NewGroup(
+			huh.NewInput().
+				Title("First project").
+				Description("e.g. Personal or Work\nLeave empty to skip").
+				CharLimit(32).
+				Value(&onboarding.Project),
+			huh.NewSelect[string]().
+				Title("Import tasks from another task manager?").
+				Options(
+					huh.NewOption("No", ""),
+					huh.NewOption("todo.txt", "todotxt"),
+					huh.NewOption("Taskwarrior (JSON written by \"task export\")", "taskwarrior"),
+				).
+				Value(&onboarding.ImportFormat),
+		),
+	)
+
+	if err := form.Run(); err != nil {
+		return err
+	}
+
+	onboarding.Project = strings.TrimSpace(onboarding.Project)
+
+	if onboarding.ImportFormat == "" {
+		return nil
+	}
+
+	var importFile string
+
+	form = huh.NewForm(
+		huh.NewGroup(
+			huh.NewInput().
+				Title("File to import").
+				Validate(func(s string) error {
+					path, err := normalizeStoragePath(strings.TrimSpace(s))
+					if err != nil {
+						return err
+					}
+					if path == "" {
+						return errors.New("file must not be empty")
+					}
+					_, err = os.Stat(path)
+					return err
+				}).
+				Value(&importFile),
+		),
+	)
+
+	if err := form.Run(); err != nil {
+		return err
+	}
+
+	path, err := normalizeStoragePath(strings.TrimSpace(importFile))
+	onboarding.ImportFile = path
+
+	return err
+}
+
+// Config holds all settings in typed form. It is loaded and validated
+// once at startup by Load and read with Get afterwards. When the config
+// file changes, the settings of the list display are applied to the
+// running program, see Watch; all others take effect on the next start.
+// The colors and hooks packages, which this package depends on, read
+// their settings from viper.
+type Config struct {
+	StoragePath string
+	VCSBackend  string
+
+	GitDefaultBranch    string
+	GitRemoteEnable     bool
+	GitRemoteName       string
+	GitRemoteURL        string
+	GitBranchPerProject bool
+
+	JJDefaultBranch  string
+	JJColocate       bool
+	JJRemoteEnable   bool
+	JJRemoteName     string
+	JJRemoteURL      string
+	JJRemoteColocate bool
+
+	// Mirrors are the mirrors of the section of the vcs backend,
+	// sorted by name.
+	Mirrors []Mirror
+
+	AuthorShow          bool
+	AuthorShowPrinter   bool
+	AuthorProtectDelete bool
+
+	AssigneeShow        bool
+	AssigneeShowPrinter bool
+	AssigneeRoster      string
+
+	// Colors maps the names of the colors with their variant,
+	// e.g. "red_light", to their values.
+	Colors          map[string]string
+	ColorsTheme     string
+	ColorsFormTheme string
+
+	PomodoroWork   time.Duration
+	PomodoroBreak  time.Duration
+	PomodoroNotify bool
+
+	SyncInterval time.Duration
+
+	DueSoon   time.Duration
+	DueAllDay bool
+
+	StartupView string
+	ListCompact bool
+	UIPlain     bool
+	LogLevel    string
+
+	// Hooks maps hook events to the commands and URLs run for them.
+	Hooks map[string][]string
+
+	GitHubToken    string
+	GitHubAPIURL   string
+	GitHubProjects map[string]GitHubProject
+}
+
+// Mirror is an additional remote the storage repository is pushed to,
+// e.g. a backup, configured in a [git.mirrors.<name>] or
+// [jj.mirrors.<name>] section.
+type Mirror struct {
+	Name   string
+	URL    string
+	Policy string
+}
+
+// GitHubProject holds the settings of a project synced with the issues
+// of a GitHub repository, configured in a [github.projects.<id>] section.
+type GitHubProject struct {
+	Repo        string
+	Token       string
+	CloseIssues bool
+}
+
+// Remote holds the settings of the remote of a vcs backend.
+type Remote struct {
+	Enable        bool
+	Name          string
+	URL           string
+	DefaultBranch string
+}
+
+// Remote returns the settings of the remote of the configured vcs
+// backend. The gogit backend shares the git configuration section.
+// Backends without a remote get the zero value.
+func (c *Config) Remote() Remote {
+	switch c.VCSBackend {
+	case "git", "gogit":
+		return Remote{c.GitRemoteEnable, c.GitRemoteName, c.GitRemoteURL, c.GitDefaultBranch}
+	case "jj":
+		return Remote{c.JJRemoteEnable, c.JJRemoteName, c.JJRemoteURL, c.JJDefaultBranch}
+	default:
+		return Remote{}
+	}
+}
+
+// Get returns the settings of v loaded by Load. If they were not loaded,
+// e.g. in tests, they are read from v without validation, and invalid
+// durations result in zero.
+func Get(v *viper.Viper) *Config {
+	if cfg, ok := v.Get(loadedKey).(*Config); ok {
+		return cfg
+	}
+
+	return read(v)
+}
+
+// read returns the settings of v in typed form.
+func read(v *viper.Viper) *Config {
+	// The gogit backend shares the git configuration section.
+	mirrorSection := "git"
+	if v.GetString("vcs.backend") == "jj" {
+		mirrorSection = "jj"
+	}
+
+	duration := func(key string) time.Duration {
+		d, _ := time.ParseDuration(v.GetString(key))
+		return d
+	}
+
+	cfg := &Config{
+		StoragePath:         v.GetString("storage.path"),
+		VCSBackend:          v.GetString("vcs.backend"),
+		GitDefaultBranch:    v.GetString("git.default_branch"),
+		GitRemoteEnable:     v.GetBool("git.remote.enable"),
+		GitRemoteName:       v.GetString("git.remote.name"),
+		GitRemoteURL:        v.GetString("git.remote.url"),
+		GitBranchPerProject: v.GetBool("git.branch_per_project"),
+		JJDefaultBranch:     v.GetString("jj.default_branch"),
+		JJColocate:          v.GetBool("jj.colocate"),
+		JJRemoteEnable:      v.GetBool("jj.remote.enable"),
+		JJRemoteName:        v.GetString("jj.remote.name"),
+		JJRemoteURL:         v.GetString("jj.remote.url"),
+		JJRemoteColocate:    v.GetBool("jj.remote.colocate"),
+		AuthorShow:          v.GetBool("author.show"),
+		AuthorShowPrinter:   v.GetBool("author.show_printer"),
+		AuthorProtectDelete: v.GetBool("author.protect_delete"),
+		AssigneeShow:        v.GetBool("assignee.show"),
+		AssigneeShowPrinter: v.GetBool("assignee.show_printer"),
+		AssigneeRoster:      v.GetString("assignee.roster"),
+		Colors:              make(map[string]string, 2*len(colors.Names)),
+		ColorsTheme:         v.GetString("colors.theme"),
+		ColorsFormTheme:     v.GetString("colors.form.theme"),
+		PomodoroWork:        duration("pomodoro.work"),
+		PomodoroBreak:       duration("pomodoro.break"),
+		PomodoroNotify:      v.GetBool("pomodoro.notify"),
+		SyncInterval:        duration("sync.interval"),
+		DueSoon:             duration("due.soon"),
+		DueAllDay:           v.GetBool("due.all_day"),
+		StartupView:         v.GetString("startup.view"),
+		ListCompact:         v.GetBool("list.compact"),
+		UIPlain:             v.GetBool("ui.plain"),
+		LogLevel:            v.GetString("log.level"),
+		Hooks:               make(map[string][]string),
+		GitHubToken:         v.GetString("github.token"),
+		GitHubAPIURL:        v.GetString("github.api_url"),
+		GitHubProjects:      make(map[string]GitHubProject),
+	}
+
+	for _, name := range colors.Names {
+		cfg.Colors[name+"_light"] = v.GetString("colors." + name + "_light")
+		cfg.Colors[name+"_dark"] = v.GetString("colors." + name + "_dark")
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(v.GetStringMap(mirrorSection + ".mirrors"))) {
+		key := mirrorSection + ".mirrors." + name
+		cfg.Mirrors = append(cfg.Mirrors, Mirror{
+			Name:   name,
+			URL:    v.GetString(key + ".url"),
+			Policy: cmp.Or(v.GetString(key+".policy"), MirrorOptional),
+		})
+	}
+
+	for event := range v.GetStringMap("hooks") {
+		cfg.Hooks[event] = v.GetStringSlice("hooks." + event)
+	}
+
+	for id := range v.GetStringMap("github.projects") {
+		key := "github.projects." + id
+		cfg.GitHubProjects[id] = GitHubProject{
+			Repo:        v.GetString(key + ".repo"),
+			Token:       v.GetString(key + ".token"),
+			CloseIssues: v.GetBool(key + ".close_issues"),
+		}
+	}
+
+	return cfg
+}
+
 // LoadAndValidateConfig loads configuration values from viper and validates them.
 // It returns an error if any configuration value is invalid or missing required fields.
 // This function should be called at application startup after viper has been initialized.
 func LoadAndValidateConfig(v *viper.Viper) error {
+	_, err := Load(v)
+	return err
+}
+
+// Load validates the configuration values from viper like LoadAndValidateConfig
+// and returns them as a Config.
+func Load(v *viper.Viper) (*Config, error) {
+	storagePath, err := normalizeStoragePath(v.GetString("storage.path"))
+	if err != nil {
+		return nil, err
+	}
+	v.Set("storage.path", storagePath)
+
 	cfg := &config{
 		assigneeShow:        v.GetBool("assignee.show"),
 		assigneeShowPrinter: v.GetBool("assignee.show_printer"),
@@ -278,6 +828,7 @@ func LoadAndValidateConfig(v *viper.Viper) error {
 		gitRemoteEnable:     v.GetBool("git.remote.enable"),
 		jjRemoteEnable:      v.GetBool("jj.remote.enable"),
 		jjRemoteColocate:    v.GetBool("jj.remote.colocate"),
+		gitBranchPerProject: v.GetBool("git.branch_per_project"),
 		storagePath:         v.GetString("storage.path"),
 		vcsBackend:          v.GetString("vcs.backend"),
 		gitDefaultBranch:    v.GetString("git.default_branch"),
@@ -285,6 +836,7 @@ func LoadAndValidateConfig(v *viper.Viper) error {
 		jjDefaultBranch:     v.GetString("jj.default_branch"),
 		jjRemoteName:        v.GetString("jj.remote.name"),
 		colorsFormTheme:     v.GetString("colors.form.theme"),
+		colorsTheme:         v.GetString("colors.theme"),
 		colorValues: map[string]string{
 			"colors.red_light":        v.GetString("colors.red_light"),
 			"colors.red_dark":         v.GetString("colors.red_dark"),
@@ -303,18 +855,189 @@ func LoadAndValidateConfig(v *viper.Viper) error {
 			"colors.badge_text_light": v.GetString("colors.badge_text_light"),
 			"colors.badge_text_dark":  v.GetString("colors.badge_text_dark"),
 		},
+		pomodoroWork:  v.GetString("pomodoro.work"),
+		pomodoroBreak: v.GetString("pomodoro.break"),
+		syncInterval:  v.GetString("sync.interval"),
+		dueSoon:       v.GetString("due.soon"),
+		dueAllDay:     v.GetBool("due.all_day"),
+		startupView:   v.GetString("startup.view"),
+		listCompact:   v.GetBool("list.compact"),
+		logLevel:      v.GetString("log.level"),
+	}
+
+	// The gogit backend shares the git configuration section.
+	mirrorSection := "git"
+	if cfg.vcsBackend == "jj" {
+		mirrorSection = "jj"
+	}
+
+	cfg.mirrors = make(map[string]mirror)
+	for name := range v.GetStringMap(mirrorSection + ".mirrors") {
+		key := mirrorSection + ".mirrors." + name
+		cfg.mirrors[name] = mirror{
+			url:    v.GetString(key + ".url"),
+			policy: v.GetString(key + ".policy"),
+		}
+	}
+
+	cfg.hooks = make(map[string][]string)
+	for event := range v.GetStringMap("hooks") {
+		cfg.hooks[event] = v.GetStringSlice("hooks." + event)
+	}
+
+	if cfg.colorsTheme != "" {
+		theme, err := colors.LoadTheme(colors.ThemeDir(v), cfg.colorsTheme)
+		if err != nil {
+			return nil, fmt.Errorf("invalid colors.theme: %w", err)
+		}
+		cfg.theme = theme
 	}
 
 	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	loaded := read(v)
+	v.Set(loadedKey, loaded)
+
+	return loaded, nil
+}
+
+// Watch watches the config file used by v and calls onChange whenever
+// it changes. The file is read into a separate viper instance, so that
+// v is never modified while the program is running. onChange receives
+// that instance along with the validated configuration, or the error
+// if the new configuration is invalid. It is called from the goroutine
+// watching the file.
+func Watch(v *viper.Viper, onChange func(next *viper.Viper, cfg *Config, err error)) error {
+	next, err := reread(v)
+	if err != nil {
 		return err
 	}
 
+	profile := v.GetString(ProfileKey)
+	if profile != "" {
+		if err := ApplyProfile(next, profile); err != nil {
+			return err
+		}
+	}
+
+	next.OnConfigChange(func(_ fsnotify.Event) {
+		if profile != "" {
+			if err := ApplyProfile(next, profile); err != nil {
+				onChange(next, nil, err)
+				return
+			}
+		}
+
+		cfg, err := Load(next)
+		onChange(next, cfg, err)
+	})
+	next.WatchConfig()
+
 	return nil
 }
 
+// Update sets the given values on top of the config file used by v and
+// writes the file, if the result is valid. Like Watch, it works on a
+// separate viper instance and leaves v untouched. The instance holding
+// the new configuration is returned along with the validated values.
+// With a profile applied to v, values of settings set by the profile
+// are written to the profile's table.
+func Update(v *viper.Viper, values map[string]any) (*viper.Viper, *Config, error) {
+	file, err := reread(v)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	profile := v.GetString(ProfileKey)
+	for key, value := range values {
+		if profile != "" && file.IsSet("profiles."+profile+"."+key) {
+			key = "profiles." + profile + "." + key
+		}
+		file.Set(key, value)
+	}
+
+	// Load keeps the loaded values in the instance it validates and the
+	// file must not get the settings of the profile, so both go into
+	// a copy.
+	next := viper.New()
+	next.SetConfigFile(file.ConfigFileUsed())
+	if err := next.MergeConfigMap(file.AllSettings()); err != nil {
+		return nil, nil, err
+	}
+	if profile != "" {
+		if err := ApplyProfile(next, profile); err != nil {
+			return nil, nil, err
+		}
+		next.Set(baseStoragePathKey, v.GetString(baseStoragePathKey))
+	}
+
+	cfg, err := Load(next)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := file.WriteConfig(); err != nil {
+		return nil, nil, fmt.Errorf("error writing config file: %w", err)
+	}
+
+	return next, cfg, nil
+}
+
+// reread reads the config file used by v into a new viper instance
+// with the same defaults.
+func reread(v *viper.Viper) (*viper.Viper, error) {
+	file := v.ConfigFileUsed()
+	if file == "" {
+		return nil, errors.New("no config file in use")
+	}
+
+	next := viper.New()
+	setDefaults(next, "")
+	next.SetDefault("storage.path", cmp.Or(v.GetString(baseStoragePathKey), v.GetString("storage.path")))
+	next.SetConfigFile(file)
+
+	if err := next.ReadInConfig(); err != nil {
+		return nil, err
+	}
+
+	return next, nil
+}
+
+// normalizeStoragePath turns the configured storage path into a clean
+// absolute path using the separators of the operating system.
+// A leading "~" is replaced by the user's home directory and relative
+// paths are resolved against the current working directory.
+// An empty path is returned unchanged and rejected by Validate.
+func normalizeStoragePath(storagePath string) (string, error) {
+	if storagePath == "" {
+		return "", nil
+	}
+
+	storagePath = filepath.FromSlash(storagePath)
+
+	if storagePath == "~" || strings.HasPrefix(storagePath, "~"+string(filepath.Separator)) {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("cannot expand storage path %q: %w", storagePath, err)
+		}
+		storagePath = filepath.Join(home, storagePath[1:])
+	}
+
+	absPath, err := filepath.Abs(storagePath)
+	if err != nil {
+		return "", fmt.Errorf("invalid storage path %q: %w", storagePath, err)
+	}
+
+	return absPath, nil
+}
+
 // Validate checks that all configuration values are valid and consistent.
-// It validates storage paths, VCS backend settings (git/jj), branch and remote names
-// to prevent command injection, form theme names, and color codes.
+// It validates storage paths, VCS backend settings (git/gogit/jj), branch and remote names
+// to prevent command injection, mirrors, hook events, form theme names, color codes, the color
+// theme, pomodoro durations, the sync interval, the due soon duration, the startup view
+// and the log level.
 // Returns an error describing the first validation failure encountered.
 func (c *config) Validate() error {
 	// Storage path validation
@@ -327,7 +1050,7 @@ func (c *config) Validate() error {
 
 	// VCS backend validation
 	switch c.vcsBackend {
-	case "git":
+	case "git", "gogit":
 		if !branchNameRegexp.MatchString(c.gitDefaultBranch) {
 			return fmt.Errorf("invalid branch name: %q", c.gitDefaultBranch)
 		}
@@ -341,10 +1064,45 @@ func (c *config) Validate() error {
 		if !remoteNameRegexp.MatchString(c.jjRemoteName) {
 			return fmt.Errorf("invalid remote name: %q", c.jjRemoteName)
 		}
+	case "none":
 	default:
 		return fmt.Errorf("unknown vcs backend: %s", c.vcsBackend)
 	}
 
+	if c.gitBranchPerProject && c.vcsBackend != "git" {
+		return fmt.Errorf("git.branch_per_project requires the git backend, not %s", c.vcsBackend)
+	}
+
+	// Mirror validation
+	remoteName := c.gitRemoteName
+	if c.vcsBackend == "jj" {
+		remoteName = c.jjRemoteName
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(c.mirrors)) {
+		m := c.mirrors[name]
+
+		if !remoteNameRegexp.MatchString(name) || name == remoteName {
+			return fmt.Errorf("invalid mirror name: %q", name)
+		}
+		if m.url == "" || strings.HasPrefix(m.url, "-") {
+			return fmt.Errorf("invalid url for mirror %q: %q", name, m.url)
+		}
+		if !slices.Contains([]string{"", MirrorRequired, MirrorOptional}, m.policy) {
+			return fmt.Errorf("invalid policy for mirror %q: %q (valid: required, optional)", name, m.policy)
+		}
+	}
+
+	// Hook validation
+	for _, event := range slices.Sorted(maps.Keys(c.hooks)) {
+		if !slices.Contains(hooks.Events, hooks.Event(event)) {
+			return fmt.Errorf("unknown hook event: %q", event)
+		}
+		if slices.Contains(c.hooks[event], "") {
+			return fmt.Errorf("empty hook for event %q", event)
+		}
+	}
+
 	// Form theme validation
 	validThemes := map[string]bool{
 		"Charm":      true,
@@ -368,5 +1126,49 @@ func (c *config) Validate() error {
 		}
 	}
 
+	// Theme validation; the theme file was read by Load.
+	if c.theme != nil {
+		if c.theme.FormTheme != "" && !validThemes[c.theme.FormTheme] {
+			return fmt.Errorf("unknown form_theme in theme %q: %s", c.colorsTheme, c.theme.FormTheme)
+		}
+
+		for _, k := range slices.Sorted(maps.Keys(c.theme.Colors)) {
+			if v := c.theme.Colors[k]; !colorRegexp.MatchString(v) {
+				return fmt.Errorf("invalid color value for '%s' in theme %q: %q", k, c.colorsTheme, v)
+			}
+		}
+	}
+
+	// Pomodoro durations validation
+	for k, v := range map[string]string{"pomodoro.work": c.pomodoroWork, "pomodoro.break": c.pomodoroBreak} {
+		if d, err := time.ParseDuration(v); err != nil || d <= 0 {
+			return fmt.Errorf("invalid duration for '%s': %q", k, v)
+		}
+	}
+
+	// Sync interval validation; empty or zero disables background sync.
+	if c.syncInterval != "" {
+		if d, err := time.ParseDuration(c.syncInterval); err != nil || d < 0 {
+			return fmt.Errorf("invalid duration for 'sync.interval': %q", c.syncInterval)
+		}
+	}
+
+	// Due soon validation; empty or zero only warns of tasks due today.
+	if c.dueSoon != "" {
+		if d, err := time.ParseDuration(c.dueSoon); err != nil || d < 0 {
+			return fmt.Errorf("invalid duration for 'due.soon': %q", c.dueSoon)
+		}
+	}
+
+	// Startup view validation; empty shows the project list.
+	if !slices.Contains([]string{"", "projects", "dashboard"}, c.startupView) {
+		return fmt.Errorf("invalid startup.view: %q (valid: projects, dashboard)", c.startupView)
+	}
+
+	// Log level validation; empty logs at the info level.
+	if _, err := logging.ParseLevel(c.logLevel); err != nil {
+		return fmt.Errorf("invalid log.level: %w", err)
+	}
+
 	return nil
 }
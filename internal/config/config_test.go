@@ -21,8 +21,11 @@
 package config
 
 import (
+	"os"
 	"path/filepath"
+	"runtime"
 	"testing"
+	"time"
 
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
@@ -44,6 +47,9 @@ func TestValidateConfig(t *testing.T) {
 			colorValues: map[string]string{
 				"colors.red_light": "#ff0000",
 			},
+			pomodoroWork:  "25m",
+			pomodoroBreak: "5m",
+			syncInterval:  "0",
 		}
 	}
 
@@ -62,6 +68,20 @@ func TestValidateConfig(t *testing.T) {
 		assert.NoError(t, err)
 	})
 
+	t.Run("valid gogit config", func(t *testing.T) {
+		cfg := baseValidConfig()
+		cfg.vcsBackend = "gogit"
+		err := cfg.Validate()
+		assert.NoError(t, err)
+	})
+
+	t.Run("valid none config", func(t *testing.T) {
+		cfg := baseValidConfig()
+		cfg.vcsBackend = "none"
+		err := cfg.Validate()
+		assert.NoError(t, err)
+	})
+
 	t.Run("invalid storage path - empty", func(t *testing.T) {
 		cfg := baseValidConfig()
 		cfg.storagePath = ""
@@ -83,6 +103,15 @@ func TestValidateConfig(t *testing.T) {
 		assert.ErrorContains(t, err, "unknown vcs backend: svn")
 	})
 
+	t.Run("branch per project", func(t *testing.T) {
+		cfg := baseValidConfig()
+		cfg.gitBranchPerProject = true
+		assert.NoError(t, cfg.Validate())
+
+		cfg.vcsBackend = "gogit"
+		assert.ErrorContains(t, cfg.Validate(), "git.branch_per_project requires the git backend")
+	})
+
 	t.Run("invalid git branch name", func(t *testing.T) {
 		cfg := baseValidConfig()
 		cfg.gitDefaultBranch = "invalid branch"
@@ -111,16 +140,141 @@ func TestValidateConfig(t *testing.T) {
 		err := cfg.Validate()
 		assert.ErrorContains(t, err, "invalid color value")
 	})
+
+	t.Run("invalid pomodoro duration", func(t *testing.T) {
+		cfg := baseValidConfig()
+		cfg.pomodoroWork = "25"
+		err := cfg.Validate()
+		assert.ErrorContains(t, err, "invalid duration for 'pomodoro.work'")
+	})
+
+	t.Run("negative pomodoro duration", func(t *testing.T) {
+		cfg := baseValidConfig()
+		cfg.pomodoroBreak = "-5m"
+		err := cfg.Validate()
+		assert.ErrorContains(t, err, "invalid duration for 'pomodoro.break'")
+	})
+
+	t.Run("valid mirrors", func(t *testing.T) {
+		cfg := baseValidConfig()
+		cfg.mirrors = map[string]mirror{
+			"backup": {url: "git@example.com:me/backup.git", policy: "required"},
+			"usb":    {url: "/media/usb/yatto.git"},
+		}
+		err := cfg.Validate()
+		assert.NoError(t, err)
+	})
+
+	t.Run("invalid mirror name", func(t *testing.T) {
+		cfg := baseValidConfig()
+		cfg.mirrors = map[string]mirror{"origin": {url: "/media/usb/yatto.git"}}
+		err := cfg.Validate()
+		assert.ErrorContains(t, err, "invalid mirror name")
+	})
+
+	t.Run("invalid mirror url", func(t *testing.T) {
+		cfg := baseValidConfig()
+		cfg.mirrors = map[string]mirror{"backup": {url: "--upload-pack=evil"}}
+		err := cfg.Validate()
+		assert.ErrorContains(t, err, "invalid url for mirror")
+	})
+
+	t.Run("invalid mirror policy", func(t *testing.T) {
+		cfg := baseValidConfig()
+		cfg.mirrors = map[string]mirror{"backup": {url: "/media/usb/yatto.git", policy: "sometimes"}}
+		err := cfg.Validate()
+		assert.ErrorContains(t, err, "invalid policy for mirror")
+	})
+
+	t.Run("valid hooks", func(t *testing.T) {
+		cfg := baseValidConfig()
+		cfg.hooks = map[string][]string{"task_created": {"notify-send created", "https://example.com/hook"}}
+		err := cfg.Validate()
+		assert.NoError(t, err)
+	})
+
+	t.Run("unknown hook event", func(t *testing.T) {
+		cfg := baseValidConfig()
+		cfg.hooks = map[string][]string{"task_deleted": {"true"}}
+		err := cfg.Validate()
+		assert.ErrorContains(t, err, "unknown hook event")
+	})
+
+	t.Run("empty hook", func(t *testing.T) {
+		cfg := baseValidConfig()
+		cfg.hooks = map[string][]string{"task_completed": {""}}
+		err := cfg.Validate()
+		assert.ErrorContains(t, err, "empty hook")
+	})
+
+	t.Run("valid sync interval", func(t *testing.T) {
+		cfg := baseValidConfig()
+		cfg.syncInterval = "5m"
+		err := cfg.Validate()
+		assert.NoError(t, err)
+	})
+
+	t.Run("invalid sync interval", func(t *testing.T) {
+		cfg := baseValidConfig()
+		cfg.syncInterval = "-1m"
+		err := cfg.Validate()
+		assert.ErrorContains(t, err, "invalid duration for 'sync.interval'")
+	})
+
+	t.Run("valid due soon", func(t *testing.T) {
+		cfg := baseValidConfig()
+		cfg.dueSoon = "48h"
+		err := cfg.Validate()
+		assert.NoError(t, err)
+	})
+
+	t.Run("invalid due soon", func(t *testing.T) {
+		cfg := baseValidConfig()
+		cfg.dueSoon = "two days"
+		err := cfg.Validate()
+		assert.ErrorContains(t, err, "invalid duration for 'due.soon'")
+	})
+
+	t.Run("valid startup view", func(t *testing.T) {
+		cfg := baseValidConfig()
+		cfg.startupView = "dashboard"
+		err := cfg.Validate()
+		assert.NoError(t, err)
+	})
+
+	t.Run("invalid startup view", func(t *testing.T) {
+		cfg := baseValidConfig()
+		cfg.startupView = "agenda"
+		err := cfg.Validate()
+		assert.ErrorContains(t, err, "invalid startup.view")
+	})
+
+	t.Run("valid log level", func(t *testing.T) {
+		cfg := baseValidConfig()
+		cfg.logLevel = "debug"
+		err := cfg.Validate()
+		assert.NoError(t, err)
+	})
+
+	t.Run("invalid log level", func(t *testing.T) {
+		cfg := baseValidConfig()
+		cfg.logLevel = "trace"
+		err := cfg.Validate()
+		assert.ErrorContains(t, err, "invalid log.level")
+	})
 }
 
 func TestInitConfig(t *testing.T) {
+	t.Setenv("XDG_CONFIG_HOME", "")
+	t.Setenv("XDG_DATA_HOME", "")
+
 	v := viper.New()
 	homeDir := "/fake/home"
 	configPath := ""
 
 	InitConfig(v, homeDir, &configPath)
 
-	assert.Equal(t, filepath.Join(homeDir, ".yatto"), v.GetString("storage.path"))
+	assert.Equal(t, DataDir(homeDir), v.GetString("storage.path"))
 	assert.Equal(t, "git", v.GetString("vcs.backend"))
 	assert.Equal(t, "main", v.GetString("git.default_branch"))
 	assert.Equal(t, "Base16", v.GetString("colors.form.theme"))
@@ -132,3 +286,429 @@ func TestInitConfig(t *testing.T) {
 	InitConfig(v, homeDir, &explicitPath)
 	assert.Equal(t, explicitPath, v.ConfigFileUsed())
 }
+
+func TestXDGDirs(t *testing.T) {
+	home := t.TempDir()
+
+	t.Setenv("XDG_CONFIG_HOME", "")
+	t.Setenv("XDG_DATA_HOME", "")
+	assert.Equal(t, filepath.Join(home, ".config", "yatto"), ConfigDir(home))
+	if runtime.GOOS != "windows" {
+		assert.Equal(t, filepath.Join(home, ".local", "share", "yatto"), DataDir(home))
+	}
+
+	xdg := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(xdg, "config"))
+	t.Setenv("XDG_DATA_HOME", filepath.Join(xdg, "data"))
+	assert.Equal(t, filepath.Join(xdg, "config", "yatto"), ConfigDir(home))
+	assert.Equal(t, filepath.Join(xdg, "data", "yatto"), DataDir(home))
+
+	t.Setenv("XDG_DATA_HOME", "relative")
+	assert.NotContains(t, DataDir(home), "relative", "relative paths must be ignored")
+
+	t.Run("falls back to the legacy locations", func(t *testing.T) {
+		t.Setenv("XDG_DATA_HOME", filepath.Join(xdg, "data"))
+		assert.NoError(t, os.MkdirAll(filepath.Join(home, ".config", "yatto"), 0o700))
+		assert.NoError(t, os.WriteFile(filepath.Join(home, ".config", "yatto", "config.toml"), nil, 0o600))
+		assert.NoError(t, os.Mkdir(filepath.Join(home, ".yatto"), 0o700))
+
+		v := viper.New()
+		configPath := ""
+		InitConfig(v, home, &configPath)
+		assert.Equal(t, filepath.Join(home, ".config", "yatto", "config.toml"), configPath)
+		assert.Equal(t, filepath.Join(home, ".yatto"), v.GetString("storage.path"))
+	})
+}
+
+func TestMigrateStorage(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("XDG_DATA_HOME", filepath.Join(home, "data"))
+	t.Setenv("XDG_CONFIG_HOME", "")
+
+	legacy := filepath.Join(home, ".yatto")
+	assert.NoError(t, os.MkdirAll(filepath.Join(legacy, "project"), 0o700))
+
+	configPath := filepath.Join(home, "config.toml")
+	content := "[storage]\npath = \"" + filepath.ToSlash(legacy) + "\"\n"
+	assert.NoError(t, os.WriteFile(configPath, []byte(content), 0o600))
+
+	v := viper.New()
+	InitConfig(v, home, &configPath)
+	assert.NoError(t, v.ReadInConfig())
+	assert.NoError(t, LoadAndValidateConfig(v))
+
+	moved, err := MigrateStorage(v, home)
+	assert.NoError(t, err)
+	assert.Equal(t, DataDir(home), moved)
+	assert.Equal(t, moved, v.GetString("storage.path"))
+	assert.Equal(t, moved, Get(v).StoragePath)
+	assert.DirExists(t, filepath.Join(moved, "project"))
+	assert.NoDirExists(t, legacy)
+
+	reread := viper.New()
+	reread.SetConfigFile(configPath)
+	assert.NoError(t, reread.ReadInConfig())
+	assert.Equal(t, moved, reread.GetString("storage.path"))
+
+	// Nothing is left to move.
+	moved, err = MigrateStorage(v, home)
+	assert.NoError(t, err)
+	assert.Empty(t, moved)
+
+	t.Run("moves the default storage path of config files without it", func(t *testing.T) {
+		assert.NoError(t, os.RemoveAll(DataDir(home)))
+		assert.NoError(t, os.MkdirAll(filepath.Join(legacy, "project"), 0o700))
+
+		configPath := filepath.Join(home, "nostorage.toml")
+		assert.NoError(t, os.WriteFile(configPath, []byte("[author]\nshow = true\n"), 0o600))
+
+		// The default is DataDir, as when the legacy directory was
+		// not found while the defaults were set.
+		v := viper.New()
+		setDefaults(v, home)
+		v.SetConfigFile(configPath)
+		assert.NoError(t, v.ReadInConfig())
+
+		moved, err := MigrateStorage(v, home)
+		assert.NoError(t, err)
+		assert.Equal(t, DataDir(home), moved)
+		assert.Equal(t, moved, v.GetString("storage.path"))
+		assert.DirExists(t, filepath.Join(moved, "project"))
+		assert.NoDirExists(t, legacy)
+
+		reread := viper.New()
+		reread.SetConfigFile(configPath)
+		assert.NoError(t, reread.ReadInConfig())
+		assert.False(t, reread.InConfig("storage.path"))
+	})
+
+	t.Run("keeps other storage paths", func(t *testing.T) {
+		assert.NoError(t, os.Mkdir(legacy, 0o700))
+		assert.NoError(t, os.RemoveAll(DataDir(home)))
+
+		v := viper.New()
+		v.Set("storage.path", filepath.Join(home, "tasks"))
+
+		moved, err := MigrateStorage(v, home)
+		assert.NoError(t, err)
+		assert.Empty(t, moved)
+		assert.DirExists(t, legacy)
+	})
+}
+
+func TestNormalizeStoragePath(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("USERPROFILE", home)
+
+	cwd, err := os.Getwd()
+	assert.NoError(t, err)
+
+	type testCase struct {
+		name     string
+		input    string
+		expected string
+	}
+
+	testCases := []testCase{
+		{
+			name:     "empty path",
+			input:    "",
+			expected: "",
+		},
+		{
+			name:     "absolute path",
+			input:    filepath.Join(home, "yatto"),
+			expected: filepath.Join(home, "yatto"),
+		},
+		{
+			name:     "unclean absolute path",
+			input:    filepath.Join(home, "a", "..", "yatto") + string(filepath.Separator),
+			expected: filepath.Join(home, "yatto"),
+		},
+		{
+			name:     "relative path",
+			input:    "yatto",
+			expected: filepath.Join(cwd, "yatto"),
+		},
+		{
+			name:     "home directory",
+			input:    "~",
+			expected: home,
+		},
+		{
+			name:     "path below home directory",
+			input:    "~/.yatto",
+			expected: filepath.Join(home, ".yatto"),
+		},
+		{
+			name:     "forward slashes",
+			input:    filepath.ToSlash(filepath.Join(home, "a", "yatto")),
+			expected: filepath.Join(home, "a", "yatto"),
+		},
+	}
+
+	if runtime.GOOS == "windows" {
+		testCases = append(testCases,
+			testCase{
+				name:     "drive letter with forward slashes",
+				input:    "C:/Users/me/.yatto",
+				expected: `C:\Users\me\.yatto`,
+			},
+			testCase{
+				name:     "path below home directory with backslash",
+				input:    `~\.yatto`,
+				expected: filepath.Join(home, ".yatto"),
+			},
+		)
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := normalizeStoragePath(tc.input)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
+
+func TestLoadAndValidateConfigNormalizesStoragePath(t *testing.T) {
+	v := viper.New()
+	InitConfig(v, t.TempDir(), new(string))
+	v.Set("storage.path", "relative/yatto")
+
+	assert.NoError(t, LoadAndValidateConfig(v))
+
+	cwd, err := os.Getwd()
+	assert.NoError(t, err)
+	assert.Equal(t, filepath.Join(cwd, "relative", "yatto"), v.GetString("storage.path"))
+}
+
+func TestLoad(t *testing.T) {
+	v := viper.New()
+	InitConfig(v, t.TempDir(), new(string))
+	v.Set("author.show", true)
+	v.Set("pomodoro.work", "50m")
+	v.Set("due.soon", "48h")
+
+	v.Set("git.mirrors.backup.url", "https://example.com/backup.git")
+	v.Set("github.projects.p1.repo", "owner/repo")
+
+	cfg, err := Load(v)
+	assert.NoError(t, err)
+	assert.True(t, cfg.AuthorShow)
+	assert.False(t, cfg.AssigneeShow)
+	assert.Equal(t, 48*time.Hour, cfg.DueSoon)
+	assert.Equal(t, 50*time.Minute, cfg.PomodoroWork)
+	assert.False(t, cfg.DueAllDay)
+	assert.False(t, cfg.ListCompact)
+	assert.Equal(t, "git", cfg.VCSBackend)
+	assert.Equal(t, Remote{false, "origin", "", "main"}, cfg.Remote())
+	assert.Equal(t, []Mirror{{"backup", "https://example.com/backup.git", MirrorOptional}}, cfg.Mirrors)
+	assert.Equal(t, "owner/repo", cfg.GitHubProjects["p1"].Repo)
+	assert.Same(t, cfg, Get(v), "Get returns the loaded settings")
+
+	// Settings are read again as long as they were not loaded.
+	unloaded := viper.New()
+	InitConfig(unloaded, t.TempDir(), new(string))
+	unloaded.Set("list.compact", true)
+	assert.True(t, Get(unloaded).ListCompact)
+	unloaded.Set("list.compact", false)
+	assert.False(t, Get(unloaded).ListCompact)
+
+	v.Set("pomodoro.work", "soon")
+	cfg, err = Load(v)
+	assert.Error(t, err)
+	assert.Nil(t, cfg)
+}
+
+func TestLoadTheme(t *testing.T) {
+	home := t.TempDir()
+	configPath := filepath.Join(home, "config.toml")
+	assert.NoError(t, os.WriteFile(configPath, []byte("[colors]\n"), 0o600))
+	assert.NoError(t, os.Mkdir(filepath.Join(home, "themes"), 0o700))
+
+	v := viper.New()
+	InitConfig(v, home, &configPath)
+	assert.NoError(t, v.ReadInConfig())
+
+	t.Run("built-in theme", func(t *testing.T) {
+		v.Set("colors.theme", "gruvbox")
+		_, err := Load(v)
+		assert.NoError(t, err)
+	})
+
+	t.Run("unknown theme", func(t *testing.T) {
+		v.Set("colors.theme", "neon")
+		_, err := Load(v)
+		assert.ErrorContains(t, err, "invalid colors.theme")
+	})
+
+	t.Run("invalid color in theme file", func(t *testing.T) {
+		theme := filepath.Join(home, "themes", "broken.toml")
+		assert.NoError(t, os.WriteFile(theme, []byte(`red_dark = "red"`), 0o600))
+
+		v.Set("colors.theme", "broken")
+		_, err := Load(v)
+		assert.ErrorContains(t, err, "invalid color value for 'red_dark' in theme")
+	})
+
+	t.Run("invalid form theme in theme file", func(t *testing.T) {
+		theme := filepath.Join(home, "themes", "broken.toml")
+		assert.NoError(t, os.WriteFile(theme, []byte(`form_theme = "Neon"`), 0o600))
+
+		v.Set("colors.theme", "broken")
+		_, err := Load(v)
+		assert.ErrorContains(t, err, "unknown form_theme")
+	})
+}
+
+func TestWatch(t *testing.T) {
+	home := t.TempDir()
+	configPath := filepath.Join(home, "config.toml")
+	assert.NoError(t, os.WriteFile(configPath, []byte("[author]\nshow = false\n"), 0o600))
+
+	v := viper.New()
+	InitConfig(v, home, &configPath)
+	assert.NoError(t, v.ReadInConfig())
+
+	type change struct {
+		next *viper.Viper
+		cfg  *Config
+		err  error
+	}
+	changes := make(chan change, 10)
+
+	err := Watch(v, func(next *viper.Viper, cfg *Config, err error) {
+		changes <- change{next, cfg, err}
+	})
+	assert.NoError(t, err)
+
+	// waitFor returns the first change that satisfies ok.
+	waitFor := func(ok func(change) bool) change {
+		t.Helper()
+
+		timeout := time.After(5 * time.Second)
+		for {
+			select {
+			case c := <-changes:
+				if ok(c) {
+					return c
+				}
+			case <-timeout:
+				t.Fatal("config change was not reported")
+				return change{}
+			}
+		}
+	}
+
+	assert.NoError(t, os.WriteFile(configPath, []byte("[author]\nshow = true\n"), 0o600))
+	c := waitFor(func(c change) bool { return c.err == nil && c.cfg.AuthorShow })
+	assert.True(t, c.next.GetBool("author.show"))
+	assert.False(t, v.GetBool("author.show"), "the running configuration must not be modified")
+
+	assert.NoError(t, os.WriteFile(configPath, []byte("[colors.form]\ntheme = \"Unknown\"\n"), 0o600))
+	c = waitFor(func(c change) bool { return c.err != nil })
+	assert.ErrorContains(t, c.err, "unknown colors.form.theme")
+}
+
+func TestUpdate(t *testing.T) {
+	home := t.TempDir()
+	configPath := filepath.Join(home, "config.toml")
+	assert.NoError(t, os.WriteFile(configPath, []byte("[author]\nshow = false\n"), 0o600))
+
+	v := viper.New()
+	InitConfig(v, home, &configPath)
+	assert.NoError(t, v.ReadInConfig())
+
+	next, cfg, err := Update(v, map[string]any{"author.show": true, "colors.form.theme": "Dracula"})
+	assert.NoError(t, err)
+	assert.True(t, cfg.AuthorShow)
+	assert.Equal(t, "Dracula", next.GetString("colors.form.theme"))
+	assert.False(t, v.GetBool("author.show"), "the running configuration must not be modified")
+
+	reread := viper.New()
+	reread.SetConfigFile(configPath)
+	assert.NoError(t, reread.ReadInConfig())
+	assert.True(t, reread.GetBool("author.show"))
+	assert.Equal(t, "Dracula", reread.GetString("colors.form.theme"))
+	assert.False(t, reread.IsSet(loadedKey), "the loaded settings must not be written")
+	assert.Same(t, cfg, Get(next))
+
+	written, err := os.ReadFile(configPath)
+	assert.NoError(t, err)
+
+	_, _, err = Update(v, map[string]any{"git.default_branch": "main; rm -rf /"})
+	assert.ErrorContains(t, err, "invalid branch name")
+
+	unchanged, err := os.ReadFile(configPath)
+	assert.NoError(t, err)
+	assert.Equal(t, string(written), string(unchanged), "an invalid config must not be written")
+}
+
+func TestApplyProfile(t *testing.T) {
+	home := t.TempDir()
+	configPath := filepath.Join(home, "config.toml")
+	content := `[storage]
+path = "/tasks/personal"
+
+[author]
+show = true
+
+[profiles.work]
+storage.path = "/tasks/work"
+vcs.backend = "none"
+
+[profiles.empty]
+`
+	assert.NoError(t, os.WriteFile(configPath, []byte(content), 0o600))
+
+	v := viper.New()
+	InitConfig(v, home, &configPath)
+	assert.NoError(t, v.ReadInConfig())
+	assert.Equal(t, []string{"empty", "work"}, Profiles(v))
+
+	err := ApplyProfile(v, "home")
+	assert.ErrorIs(t, err, ErrUnknownProfile)
+	assert.ErrorContains(t, err, "available: empty, work")
+
+	assert.NoError(t, ApplyProfile(v, "empty"))
+	assert.Equal(t, "/tasks/personal", v.GetString("storage.path"))
+
+	assert.NoError(t, ApplyProfile(v, "work"))
+	assert.Equal(t, "work", v.GetString(ProfileKey))
+	assert.Equal(t, "/tasks/work", v.GetString("storage.path"))
+	assert.Equal(t, "none", v.GetString("vcs.backend"))
+	assert.True(t, v.GetBool("author.show"), "settings not set by the profile are kept")
+}
+
+func TestUpdateWithProfile(t *testing.T) {
+	home := t.TempDir()
+	configPath := filepath.Join(home, "config.toml")
+	content := `[profiles.work]
+storage.path = "/tasks/work"
+author.show = false
+`
+	assert.NoError(t, os.WriteFile(configPath, []byte(content), 0o600))
+
+	v := viper.New()
+	InitConfig(v, home, &configPath)
+	assert.NoError(t, v.ReadInConfig())
+	assert.NoError(t, ApplyProfile(v, "work"))
+
+	next, cfg, err := Update(v, map[string]any{"author.show": true, "colors.form.theme": "Dracula"})
+	assert.NoError(t, err)
+	assert.True(t, cfg.AuthorShow)
+	assert.Equal(t, filepath.FromSlash("/tasks/work"), next.GetString("storage.path"))
+	assert.Equal(t, "work", next.GetString(ProfileKey))
+
+	reread := viper.New()
+	reread.SetConfigFile(configPath)
+	assert.NoError(t, reread.ReadInConfig())
+	assert.True(t, reread.GetBool("profiles.work.author.show"), "settings of the profile are written to the profile")
+	assert.False(t, reread.GetBool("author.show"))
+	assert.Equal(t, "Dracula", reread.GetString("colors.form.theme"))
+	assert.Equal(t, DataDir(home), reread.GetString("storage.path"),
+		"the storage path of the profile must not become the default")
+	assert.False(t, reread.IsSet(ProfileKey))
+}
@@ -0,0 +1,455 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package doctor checks the storage directory for problems, such as
+// unparsable files that yatto has to skip, and repairs the ones that
+// can be fixed without losing data.
+package doctor
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/handlebargh/yatto/internal/config"
+	"github.com/handlebargh/yatto/internal/helpers"
+	"github.com/handlebargh/yatto/internal/items"
+	"github.com/handlebargh/yatto/internal/storage"
+	"github.com/handlebargh/yatto/internal/vcs"
+	"github.com/spf13/viper"
+)
+
+// projectFile is the name of the metadata file inside a project's directory.
+const projectFile = "project.json"
+
+// Problem describes a single issue found in the storage directory.
+type Problem struct {
+	// Path is the affected file or directory relative to the storage path.
+	Path string
+	// Message describes the issue.
+	Message string
+	// Fix repairs the issue and returns the files that have to be
+	// committed afterwards. It is nil if the issue cannot be repaired
+	// automatically.
+	Fix func() ([]string, error)
+}
+
+// String returns the problem in the form "path: message".
+func (p Problem) String() string {
+	if p.Path == "" {
+		return p.Message
+	}
+
+	return p.Path + ": " + p.Message
+}
+
+// checkedTask is a task that was read successfully, along with its path.
+type checkedTask struct {
+	path string
+	task items.Task
+}
+
+// Check inspects the configured storage directory and returns all problems
+// found. It reports unparsable project, task and state files, task files
+// outside of any project, temporary files left behind by interrupted writes,
+// tasks sharing their ID with another task, tasks assigned to unknown
+// contributors and problems with the repository of the configured vcs
+// backend.
+// An error is only returned if the storage directory cannot be read.
+func Check(v *viper.Viper) (problems []Problem, err error) {
+	root, err := os.OpenRoot(config.Get(v).StoragePath)
+	if err != nil {
+		return nil, fmt.Errorf("could not open storage directory: %w", err)
+	}
+	defer helpers.CloseWithErr(root, &err)
+
+	entries, err := fs.ReadDir(root.FS(), ".")
+	if err != nil {
+		return nil, fmt.Errorf("could not read storage directory: %w", err)
+	}
+
+	var tasks []checkedTask
+	for _, entry := range entries {
+		name := entry.Name()
+
+		switch {
+		case name == ".git" || name == ".jj" || name == storage.TemplatesDir || name == storage.IndexFile:
+			continue
+		case storage.IsTempFile(name):
+			problems = append(problems, tempFileProblem(root, name))
+		case entry.IsDir():
+			found, dirTasks := checkProject(root, name)
+			problems = append(problems, found...)
+			tasks = append(tasks, dirTasks...)
+		case name == storage.StateFile:
+			if _, err := storage.ReadState(v); err != nil {
+				problems = append(problems, Problem{
+					Path:    name,
+					Message: err.Error(),
+					Fix:     removeFix(root.Name(), name),
+				})
+			}
+		case name == storage.CrashFile:
+			if _, err := storage.ReadCrash(v); err != nil {
+				problems = append(problems, Problem{
+					Path:    name,
+					Message: err.Error(),
+					Fix:     removeFix(root.Name(), name),
+				})
+			}
+		case path.Ext(name) == ".json":
+			problems = append(problems, Problem{Path: name, Message: "task file outside of any project"})
+		}
+	}
+
+	problems = append(problems, checkDuplicateIDs(root, tasks)...)
+	problems = append(problems, checkAssignees(v, tasks)...)
+	problems = append(problems, checkVCS(v, root, problems)...)
+
+	return problems, nil
+}
+
+// checkProject checks the project directory dir and its archive. It returns
+// the problems found and all tasks that could be read.
+func checkProject(root *os.Root, dir string) ([]Problem, []checkedTask) {
+	var problems []Problem
+
+	data, err := root.ReadFile(path.Join(dir, projectFile))
+	switch {
+	case errors.Is(err, fs.ErrNotExist):
+		problems = append(problems, Problem{
+			Path:    dir,
+			Message: "directory has no " + projectFile + ", its task files are outside of any project",
+		})
+	case err != nil:
+		problems = append(problems, Problem{Path: path.Join(dir, projectFile), Message: err.Error()})
+	default:
+		if _, err := items.ParseProject(data); err != nil {
+			problems = append(problems, Problem{
+				Path:    path.Join(dir, projectFile),
+				Message: fmt.Sprintf("invalid project file: %v", err),
+			})
+		}
+	}
+
+	found, tasks := checkTaskDir(root, dir)
+	problems = append(problems, found...)
+
+	archiveDir := path.Join(dir, items.ArchiveDir)
+	if info, err := root.Stat(archiveDir); err == nil && info.IsDir() {
+		found, archived := checkTaskDir(root, archiveDir)
+		problems = append(problems, found...)
+		tasks = append(tasks, archived...)
+	}
+
+	return problems, tasks
+}
+
+// checkTaskDir parses all task files in dir and reports files that
+// cannot be read as well as leftover temporary files.
+func checkTaskDir(root *os.Root, dir string) ([]Problem, []checkedTask) {
+	entries, err := fs.ReadDir(root.FS(), dir)
+	if err != nil {
+		return []Problem{{Path: dir, Message: err.Error()}}, nil
+	}
+
+	var (
+		problems []Problem
+		tasks    []checkedTask
+	)
+
+	for _, entry := range entries {
+		name := path.Join(dir, entry.Name())
+
+		if storage.IsTempFile(name) {
+			problems = append(problems, tempFileProblem(root, name))
+			continue
+		}
+
+		if entry.IsDir() || !items.UUIDRegex.MatchString(entry.Name()) {
+			continue
+		}
+
+		data, err := root.ReadFile(name)
+		if err != nil {
+			problems = append(problems, Problem{Path: name, Message: err.Error()})
+			continue
+		}
+
+		task, err := items.ParseTask(data)
+		if err != nil {
+			problems = append(problems, Problem{
+				Path:    name,
+				Message: fmt.Sprintf("invalid task file: %v", err),
+			})
+			continue
+		}
+
+		tasks = append(tasks, checkedTask{path: name, task: task})
+	}
+
+	return problems, tasks
+}
+
+// DuplicateIDs returns the problems of tasks sharing their ID with another
+// task, see checkDuplicateIDs. Unlike Check, it only reads the task files,
+// so it is cheap enough to run on every start.
+// An error is only returned if the storage directory cannot be read.
+func DuplicateIDs(v *viper.Viper) (problems []Problem, err error) {
+	root, err := os.OpenRoot(config.Get(v).StoragePath)
+	if err != nil {
+		return nil, fmt.Errorf("could not open storage directory: %w", err)
+	}
+	defer helpers.CloseWithErr(root, &err)
+
+	entries, err := fs.ReadDir(root.FS(), ".")
+	if err != nil {
+		return nil, fmt.Errorf("could not read storage directory: %w", err)
+	}
+
+	var tasks []checkedTask
+	for _, entry := range entries {
+		name := entry.Name()
+		if !entry.IsDir() || name == ".git" || name == ".jj" || name == storage.TemplatesDir {
+			continue
+		}
+
+		// Unreadable files are reported by Check.
+		_, dirTasks := checkProject(root, name)
+		tasks = append(tasks, dirTasks...)
+	}
+
+	return checkDuplicateIDs(root, tasks), nil
+}
+
+// checkDuplicateIDs reports tasks sharing their ID with another task,
+// which happens when task files are copied manually. The copy with the
+// oldest file keeps the ID, the others can be fixed by giving them a
+// new one, see reassignFix.
+func checkDuplicateIDs(root *os.Root, tasks []checkedTask) []Problem {
+	var ids []string
+	byID := make(map[string][]checkedTask)
+	for _, t := range tasks {
+		if _, ok := byID[t.task.ID]; !ok {
+			ids = append(ids, t.task.ID)
+		}
+		byID[t.task.ID] = append(byID[t.task.ID], t)
+	}
+
+	var problems []Problem
+	for _, id := range ids {
+		copies := byID[id]
+		if len(copies) < 2 {
+			continue
+		}
+
+		modTime := func(c checkedTask) time.Time {
+			if info, err := root.Stat(c.path); err == nil {
+				return info.ModTime()
+			}
+			return time.Time{}
+		}
+		slices.SortStableFunc(copies, func(a, b checkedTask) int {
+			return cmp.Or(modTime(a).Compare(modTime(b)), strings.Compare(a.path, b.path))
+		})
+
+		for _, c := range copies[1:] {
+			problems = append(problems, Problem{
+				Path:    c.path,
+				Message: fmt.Sprintf("task ID %s is also used by %s", c.task.ID, copies[0].path),
+				Fix:     reassignFix(root.Name(), c, copies[0]),
+			})
+		}
+	}
+
+	return problems
+}
+
+// reassignFix returns a fix that gives the duplicate dup of the task kept
+// a new ID. The task file and attachments directory are renamed and the
+// tasks of dup's project blocked by the old ID are now blocked by dup,
+// unless kept is in the same project.
+func reassignFix(storagePath string, dup, kept checkedTask) func() ([]string, error) {
+	return func() (_ []string, err error) {
+		root, err := os.OpenRoot(storagePath)
+		if err != nil {
+			return nil, err
+		}
+		defer helpers.CloseWithErr(root, &err)
+
+		oldID, newID := dup.task.ID, uuid.NewString()
+		projectDir := strings.Split(dup.path, "/")[0]
+
+		task := dup.task
+		task.ID = newID
+		newPath := path.Join(path.Dir(dup.path), newID+".json")
+		if err := storage.AtomicWrite(root, newPath, task.MarshalTask(), 0o600); err != nil {
+			return nil, err
+		}
+		if err := root.Remove(dup.path); err != nil {
+			return nil, err
+		}
+		changed := []string{dup.path, newPath}
+
+		oldDir := path.Join(projectDir, oldID+items.AttachmentsSuffix)
+		newDir := path.Join(projectDir, newID+items.AttachmentsSuffix)
+		if strings.Split(kept.path, "/")[0] == projectDir {
+			// Both copies share the project and thus the attachments.
+			return changed, nil
+		}
+		if err := root.Rename(oldDir, newDir); err == nil {
+			changed = append(changed, oldDir, newDir)
+		} else if !errors.Is(err, fs.ErrNotExist) {
+			return changed, err
+		}
+
+		_, tasks := checkProject(root, projectDir)
+		for _, t := range tasks {
+			i := slices.Index(t.task.BlockedBy, oldID)
+			if i < 0 {
+				continue
+			}
+
+			t.task.BlockedBy[i] = newID
+			if err := storage.AtomicWrite(root, t.path, t.task.MarshalTask(), 0o600); err != nil {
+				return changed, err
+			}
+			changed = append(changed, t.path)
+		}
+
+		return changed, nil
+	}
+}
+
+// checkAssignees reports tasks assigned to someone who never committed
+// to the repository. The check is skipped if the contributors cannot
+// be determined, e.g. because the repository has no commits yet.
+func checkAssignees(v *viper.Viper, tasks []checkedTask) []Problem {
+	contributors, err := vcs.AllContributors(v)
+	if err != nil || len(contributors) == 0 {
+		return nil
+	}
+
+	var problems []Problem
+	for _, t := range tasks {
+		if t.task.Assignee == "" {
+			continue
+		}
+
+		if !slices.Contains(contributors, helpers.AddAngleBracketsToEmail(t.task.Assignee)) {
+			problems = append(problems, Problem{
+				Path:    t.path,
+				Message: fmt.Sprintf("assignee %q is not a contributor of the repository", t.task.Assignee),
+			})
+		}
+	}
+
+	return problems
+}
+
+// checkVCS reports a missing repository, unfinished merges and rebases,
+// and uncommitted changes. Uncommitted changes to files that have no
+// other problem can be fixed by committing them.
+func checkVCS(v *viper.Viper, root *os.Root, found []Problem) []Problem {
+	backend := config.Get(v).VCSBackend
+	if backend == "none" {
+		return nil
+	}
+
+	repoDir := ".git"
+	if backend == "jj" {
+		repoDir = ".jj"
+	}
+
+	if _, err := root.Stat(repoDir); err != nil {
+		return []Problem{{
+			Path:    repoDir,
+			Message: fmt.Sprintf("storage directory is not a %s repository, start yatto once to initialize it", backend),
+		}}
+	}
+
+	var problems []Problem
+
+	if backend != "jj" {
+		for _, marker := range []string{"MERGE_HEAD", "rebase-merge", "rebase-apply"} {
+			if _, err := root.Stat(path.Join(repoDir, marker)); err == nil {
+				problems = append(problems, Problem{
+					Path:    repoDir,
+					Message: "unfinished merge or rebase, resolve it with git before using yatto",
+				})
+				break
+			}
+		}
+	}
+
+	changed, err := vcs.Status(v)
+	if err != nil {
+		return append(problems, Problem{Message: fmt.Sprintf("cannot read repository status: %v", err)})
+	}
+
+	for _, file := range changed {
+		if file == storage.StateFile || file == storage.IndexFile || file == storage.CrashFile ||
+			storage.IsTempFile(file) {
+			continue
+		}
+
+		problem := Problem{Path: file, Message: "uncommitted change"}
+		if !slices.ContainsFunc(found, func(p Problem) bool {
+			return p.Path == file || strings.HasPrefix(file, p.Path+"/")
+		}) {
+			problem.Fix = func() ([]string, error) { return []string{file}, nil }
+		}
+
+		problems = append(problems, problem)
+	}
+
+	return problems
+}
+
+// tempFileProblem reports a temporary file left behind by an interrupted
+// write. Such files are never committed and can be removed safely.
+func tempFileProblem(root *os.Root, name string) Problem {
+	return Problem{
+		Path:    name,
+		Message: "temporary file left behind by an interrupted write",
+		Fix:     removeFix(root.Name(), name),
+	}
+}
+
+// removeFix returns a fix that removes the file name within storagePath.
+// It is only used for files that are not under version control.
+func removeFix(storagePath, name string) func() ([]string, error) {
+	return func() (_ []string, err error) {
+		root, err := os.OpenRoot(storagePath)
+		if err != nil {
+			return nil, err
+		}
+		defer helpers.CloseWithErr(root, &err)
+
+		return nil, root.Remove(name)
+	}
+}
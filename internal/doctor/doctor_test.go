@@ -0,0 +1,293 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package doctor
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/handlebargh/yatto/internal/items"
+	"github.com/handlebargh/yatto/internal/storage"
+	"github.com/handlebargh/yatto/internal/vcs"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	projectID = "project"
+	taskID    = "c0ffee00-0000-4000-8000-000000000001"
+)
+
+// setupStorage creates a repository containing a valid project with
+// a single committed task and returns the viper instance pointing to it.
+func setupStorage(t *testing.T) *viper.Viper {
+	t.Helper()
+
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	tempDir := t.TempDir()
+	v := viper.New()
+	v.Set("storage.path", tempDir)
+	v.Set("vcs.backend", "gogit")
+
+	repo, err := git.PlainInit(tempDir, false)
+	assert.NoError(t, err)
+
+	cfg, err := repo.Config()
+	assert.NoError(t, err)
+	cfg.User.Name = "Test User"
+	cfg.User.Email = "test@example.com"
+	assert.NoError(t, repo.SetConfig(cfg))
+
+	project := items.Project{ID: projectID, Title: "Project"}
+	task := items.Task{ID: taskID, Title: "Task", Priority: "low", Assignee: "Test User <test@example.com>"}
+
+	assert.NoError(t, os.Mkdir(filepath.Join(tempDir, projectID), 0o700))
+	writeFile(t, v, filepath.Join(projectID, "project.json"), string(project.MarshalProject()))
+	writeFile(t, v, filepath.Join(projectID, taskID+".json"), string(task.MarshalTask()))
+
+	msg := vcs.CommitCmd(v, "init", projectID)()
+	_, failed := msg.(error)
+	assert.False(t, failed, "commit failed: %v", msg)
+
+	return v
+}
+
+// writeFile writes content to the file name relative to the storage path.
+func writeFile(t *testing.T, v *viper.Viper, name, content string) {
+	t.Helper()
+	assert.NoError(t, os.WriteFile(filepath.Join(v.GetString("storage.path"), name), []byte(content), 0o600))
+}
+
+// paths returns the paths of all problems.
+func paths(problems []Problem) []string {
+	var result []string
+	for _, p := range problems {
+		result = append(result, p.Path)
+	}
+	return result
+}
+
+func TestCheck(t *testing.T) {
+	t.Run("reports nothing for valid storage", func(t *testing.T) {
+		v := setupStorage(t)
+
+		problems, err := Check(v)
+		assert.NoError(t, err)
+		assert.Empty(t, problems)
+	})
+
+	t.Run("skips repository checks without a vcs", func(t *testing.T) {
+		v := setupStorage(t)
+		v.Set("vcs.backend", "none")
+		assert.NoError(t, os.RemoveAll(filepath.Join(v.GetString("storage.path"), ".git")))
+
+		task := items.Task{ID: taskID, Title: "Changed", Priority: "low"}
+		writeFile(t, v, filepath.Join(projectID, taskID+".json"), string(task.MarshalTask()))
+
+		problems, err := Check(v)
+		assert.NoError(t, err)
+		assert.Empty(t, problems)
+	})
+
+	t.Run("reports unparsable files", func(t *testing.T) {
+		v := setupStorage(t)
+		writeFile(t, v, filepath.Join(projectID, taskID+".json"), "{")
+		writeFile(t, v, filepath.Join(projectID, "project.json"), "[]")
+
+		problems, err := Check(v)
+		assert.NoError(t, err)
+		assert.Contains(t, paths(problems), projectID+"/"+taskID+".json")
+		assert.Contains(t, paths(problems), projectID+"/project.json")
+
+		for _, p := range problems {
+			assert.Nil(t, p.Fix, "broken files must not be committed: %s", p)
+		}
+	})
+
+	t.Run("reports files not matching the schema", func(t *testing.T) {
+		v := setupStorage(t)
+		writeFile(t, v, filepath.Join(projectID, taskID+".json"),
+			`{"id":"`+taskID+`","title":"Task","priority":"urgent","subtasks":[{"title":"x","done":1}]}`)
+
+		problems, err := Check(v)
+		assert.NoError(t, err)
+		assert.Contains(t, paths(problems), projectID+"/"+taskID+".json")
+		assert.Contains(t, problems[0].Message, "/priority")
+		assert.Contains(t, problems[0].Message, "/subtasks/0/done")
+	})
+
+	t.Run("reports task files outside of any project", func(t *testing.T) {
+		v := setupStorage(t)
+		writeFile(t, v, taskID+".json", "{}")
+		assert.NoError(t, os.Mkdir(filepath.Join(v.GetString("storage.path"), "orphan"), 0o700))
+
+		problems, err := Check(v)
+		assert.NoError(t, err)
+		assert.Contains(t, paths(problems), taskID+".json")
+		assert.Contains(t, paths(problems), "orphan")
+	})
+
+	t.Run("reports unknown assignees", func(t *testing.T) {
+		v := setupStorage(t)
+
+		task := items.Task{ID: taskID, Title: "Task", Priority: "low", Assignee: "Someone <someone@example.com>"}
+		writeFile(t, v, filepath.Join(projectID, taskID+".json"), string(task.MarshalTask()))
+
+		problems, err := Check(v)
+		assert.NoError(t, err)
+		assert.Contains(t, paths(problems), projectID+"/"+taskID+".json")
+	})
+
+	t.Run("removes temporary files", func(t *testing.T) {
+		v := setupStorage(t)
+		name := filepath.Join(projectID, "."+taskID+".json.tmp-ABC")
+		writeFile(t, v, name, "{")
+
+		problems, err := Check(v)
+		assert.NoError(t, err)
+		assert.Len(t, problems, 1)
+		assert.NotNil(t, problems[0].Fix)
+
+		files, err := problems[0].Fix()
+		assert.NoError(t, err)
+		assert.Empty(t, files)
+		assert.NoFileExists(t, filepath.Join(v.GetString("storage.path"), name))
+	})
+
+	t.Run("commits uncommitted changes", func(t *testing.T) {
+		v := setupStorage(t)
+		other := items.Task{ID: "c0ffee00-0000-4000-8000-000000000002", Title: "Other", Priority: "low"}
+		writeFile(t, v, filepath.Join(projectID, other.ID+".json"), string(other.MarshalTask()))
+
+		problems, err := Check(v)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{projectID + "/" + other.ID + ".json"}, paths(problems))
+		assert.NotNil(t, problems[0].Fix)
+
+		files, err := problems[0].Fix()
+		assert.NoError(t, err)
+		assert.Equal(t, []string{projectID + "/" + other.ID + ".json"}, files)
+	})
+
+	t.Run("ignores the state file", func(t *testing.T) {
+		v := setupStorage(t)
+		writeFile(t, v, ".yatto-state.json", `{"project_sorts":{}}`)
+
+		problems, err := Check(v)
+		assert.NoError(t, err)
+		assert.Empty(t, problems)
+	})
+
+	t.Run("does not treat the templates directory as a project", func(t *testing.T) {
+		v := setupStorage(t)
+		assert.NoError(t, os.Mkdir(filepath.Join(v.GetString("storage.path"), storage.TemplatesDir), 0o700))
+		writeFile(t, v, filepath.Join(storage.TemplatesDir, taskID+".json"), `{"name":"template"}`)
+
+		// The template is only reported as not yet committed.
+		problems, err := Check(v)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{storage.TemplatesDir + "/" + taskID + ".json"}, paths(problems))
+	})
+
+	t.Run("reports a missing repository", func(t *testing.T) {
+		v := setupStorage(t)
+		assert.NoError(t, os.RemoveAll(filepath.Join(v.GetString("storage.path"), ".git")))
+
+		problems, err := Check(v)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{".git"}, paths(problems))
+	})
+
+	t.Run("fails for missing storage directory", func(t *testing.T) {
+		v := viper.New()
+		v.Set("storage.path", filepath.Join(t.TempDir(), "missing"))
+
+		_, err := Check(v)
+		assert.Error(t, err)
+	})
+}
+
+func TestDuplicateIDs(t *testing.T) {
+	t.Run("reports nothing without duplicates", func(t *testing.T) {
+		v := setupStorage(t)
+
+		problems, err := DuplicateIDs(v)
+		assert.NoError(t, err)
+		assert.Empty(t, problems)
+	})
+
+	t.Run("gives the copy a new ID", func(t *testing.T) {
+		v := setupStorage(t)
+		storagePath := v.GetString("storage.path")
+
+		copied := items.Project{ID: "copied", Title: "Copied"}
+		task := items.Task{ID: taskID, Title: "Task", Priority: "low"}
+		blocked := items.Task{
+			ID: "c0ffee00-0000-4000-8000-000000000002", Title: "Blocked", Priority: "low",
+			BlockedBy: []string{taskID},
+		}
+
+		assert.NoError(t, os.MkdirAll(filepath.Join(storagePath, copied.ID, taskID+items.AttachmentsSuffix), 0o700))
+		writeFile(t, v, filepath.Join(copied.ID, "project.json"), string(copied.MarshalProject()))
+		writeFile(t, v, filepath.Join(copied.ID, taskID+".json"), string(task.MarshalTask()))
+		writeFile(t, v, filepath.Join(copied.ID, blocked.ID+".json"), string(blocked.MarshalTask()))
+		writeFile(t, v, filepath.Join(copied.ID, taskID+items.AttachmentsSuffix, "note.txt"), "note")
+
+		// The original is older than the copy.
+		old := time.Now().Add(-time.Hour)
+		assert.NoError(t, os.Chtimes(filepath.Join(storagePath, projectID, taskID+".json"), old, old))
+
+		problems, err := DuplicateIDs(v)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{copied.ID + "/" + taskID + ".json"}, paths(problems))
+		assert.NotNil(t, problems[0].Fix)
+
+		checked, err := Check(v)
+		assert.NoError(t, err)
+		assert.Contains(t, paths(checked), copied.ID+"/"+taskID+".json")
+
+		files, err := problems[0].Fix()
+		assert.NoError(t, err)
+		assert.Contains(t, files, copied.ID+"/"+taskID+".json")
+		assert.Contains(t, files, copied.ID+"/"+blocked.ID+".json")
+		assert.FileExists(t, filepath.Join(storagePath, projectID, taskID+".json"))
+		assert.NoFileExists(t, filepath.Join(storagePath, copied.ID, taskID+".json"))
+
+		problems, err = DuplicateIDs(v)
+		assert.NoError(t, err)
+		assert.Empty(t, problems)
+
+		data, err := os.ReadFile(filepath.Join(storagePath, copied.ID, blocked.ID+".json"))
+		assert.NoError(t, err)
+		var rewritten items.Task
+		assert.NoError(t, json.Unmarshal(data, &rewritten))
+		assert.Len(t, rewritten.BlockedBy, 1)
+		assert.NotEqual(t, taskID, rewritten.BlockedBy[0])
+		assert.FileExists(t, filepath.Join(storagePath, copied.ID, rewritten.BlockedBy[0]+".json"))
+		assert.FileExists(t, filepath.Join(storagePath, copied.ID, rewritten.BlockedBy[0]+items.AttachmentsSuffix, "note.txt"))
+	})
+}
@@ -28,6 +28,7 @@ import (
 	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/handlebargh/yatto/internal/storage"
 	"github.com/handlebargh/yatto/internal/vcs"
 	"github.com/spf13/viper"
 )
@@ -38,6 +39,7 @@ type FetchModel struct {
 	Spinner   spinner.Model
 	CmdOutput string
 	Err       error
+	Pushing   bool
 	Width     int
 	Height    int
 }
@@ -89,9 +91,23 @@ func (m FetchModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case vcs.PullDoneMsg:
+		// Retry pushing commits that could not be pushed before.
+		if storage.UnpushedCommits(m.Config) > 0 {
+			m.Pushing = true
+			return m, vcs.PushCmd(m.Config)
+		}
+		return m, tea.Quit
+
+	case vcs.PushDoneMsg, vcs.PushErrorMsg:
+		// A failed push is retried later and must not block the start.
 		return m, tea.Quit
 
 	case vcs.PullErrorMsg:
+		// Conflicts are resolved in the project list.
+		if vcs.ConflictInProgress(m.Config) {
+			return m, tea.Quit
+		}
+		m.CmdOutput = msg.CmdOutput
 		m.Err = msg.Err
 		return m, nil
 
@@ -119,6 +135,8 @@ func (m FetchModel) View() string {
 	var content string
 	if m.Err != nil {
 		content = m.CmdOutput
+	} else if m.Pushing {
+		content = fmt.Sprintf("%s Pushing pending changes to remote…", m.Spinner.View())
 	} else {
 		content = fmt.Sprintf("%s Fetching data from remote…", m.Spinner.View())
 	}
@@ -24,54 +24,94 @@ package helpers
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"io/fs"
+	"net/mail"
 	"os"
+	"os/exec"
+	"path"
 	"path/filepath"
+	"regexp"
+	"runtime"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/atotto/clipboard"
+	"github.com/aymanbagabas/go-osc52/v2"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/handlebargh/yatto/internal/colors"
+	"github.com/handlebargh/yatto/internal/config"
 	"github.com/handlebargh/yatto/internal/items"
+	"github.com/handlebargh/yatto/internal/storage"
 	"github.com/spf13/viper"
 )
 
+var (
+	// ErrNoEditorSet is returned when the EDITOR environment variable is empty.
+	ErrNoEditorSet = fmt.Errorf("environment variable EDITOR not set")
+
+	// ErrInvalidEditorSet is returned when the EDITOR environment variable contains illegal characters.
+	ErrInvalidEditorSet = fmt.Errorf("environment variable EDITOR contains illegal characters")
+
+	// editorRegexp validates the executable part of EDITOR.
+	editorRegexp = regexp.MustCompile(`^[a-zA-Z0-9 _/\\.\-:]+$`)
+
+	// hexColorRegexp matches project colors given as "#rgb" or "#rrggbb".
+	hexColorRegexp = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+)
+
+// ProjectColors are the named project colors. They are taken from
+// the configured color palette, see GetColorCode.
+var ProjectColors = []string{"green", "orange", "red", "blue", "indigo", "yellow"}
+
 // ReadProjectsFromFS reads all project directories from the configured storage path.
-// It deserializes each project's `project.json` file into an items.Project object.
-// Returns a slice of all successfully read projects.
-// Panics if the storage directory can't be read or if project files are invalid.
-func ReadProjectsFromFS(v *viper.Viper) []items.Project {
-	root, err := os.OpenRoot(v.GetString("storage.path"))
+// It deserializes each project's `project.json` file into an items.Project object,
+// reading the files concurrently, see storage.ScanFiles.
+// Projects whose `project.json` cannot be read or parsed are skipped and reported
+// in an items.SkippedFilesError, which is returned along with the remaining projects.
+// The projects are sorted as in the project list, see items.SortProjects.
+// Any other error means that the storage directory could not be read.
+func ReadProjectsFromFS(v *viper.Viper) (projects []items.Project, err error) {
+	root, err := os.OpenRoot(config.Get(v).StoragePath)
 	if err != nil {
-		panic(fmt.Errorf("could not open storage directory: %w", err))
+		return nil, fmt.Errorf("could not open storage directory: %w", err)
 	}
 	defer CloseWithErr(root, &err)
 
 	entries, err := fs.ReadDir(root.FS(), ".")
 	if err != nil {
-		panic(fmt.Errorf("fatal error reading storage directory: %w", err))
+		return nil, fmt.Errorf("could not read storage directory: %w", err)
 	}
 
-	var projects []items.Project
+	var projectPaths []string
 	for _, entry := range entries {
-		if !entry.IsDir() || entry.Name() == ".git" || entry.Name() == ".jj" {
+		if !entry.IsDir() || entry.Name() == ".git" || entry.Name() == ".jj" || entry.Name() == storage.TemplatesDir {
 			continue
 		}
+		projectPaths = append(projectPaths, path.Join(entry.Name(), "project.json"))
+	}
 
-		projectFile, err := root.ReadFile(filepath.Join(entry.Name(), "project.json"))
-		if err != nil {
-			panic(err)
-		}
+	results := storage.ScanFiles(root, projectPaths, items.ParseProject)
 
-		var project items.Project
-		if err := json.Unmarshal(projectFile, &project); err != nil {
-			panic(err)
+	var skipped []items.CorruptFileError
+	for _, r := range results {
+		if r.Err != nil {
+			skipped = append(skipped, items.CorruptFileError{Path: r.Path, Err: r.Err})
+			continue
 		}
-		projects = append(projects, project)
+		projects = append(projects, r.Value)
 	}
 
-	return projects
+	items.SortProjects(projects)
+
+	if len(skipped) > 0 {
+		return projects, &items.SkippedFilesError{Files: skipped}
+	}
+
+	return projects, nil
 }
 
 // AllLabels walks the task storage directory (as configured by the
@@ -85,23 +125,41 @@ func ReadProjectsFromFS(v *viper.Viper) []items.Project {
 // Task files are expected to contain a "labels" field as a comma-separated
 // string or a string array.
 //
-// It is assumed that all matching files are readable and contain valid JSON.
-// If this invariant is violated (e.g., a file is unreadable or cannot be
-// parsed), the function will panic immediately rather than attempting to
-// recover.
-func AllLabels(v *viper.Viper) map[string]int {
-	root, err := os.OpenRoot(v.GetString("storage.path"))
+// Files that cannot be read or parsed are skipped, as the labels are only
+// used for suggestions. An error is returned if the storage directory
+// cannot be walked.
+func AllLabels(v *viper.Viper) (map[string]int, error) {
+	return countLabels(v, ".")
+}
+
+// ProjectLabels works like AllLabels but only counts the labels of tasks
+// in the given project, including its archived tasks.
+func ProjectLabels(v *viper.Viper, p items.Project) (map[string]int, error) {
+	return countLabels(v, p.ID)
+}
+
+// countLabels counts the labels of all task files below dir, which is
+// relative to the storage directory.
+func countLabels(v *viper.Viper, dir string) (_ map[string]int, err error) {
+	storagePath := config.Get(v).StoragePath
+
+	root, err := os.OpenRoot(storagePath)
 	if err != nil {
-		panic(fmt.Errorf("could not open storage directory: %w", err))
+		return nil, fmt.Errorf("could not open storage directory: %w", err)
 	}
 	defer CloseWithErr(root, &err)
 
 	// Store labels in a map and track their frequency.
 	labelCount := make(map[string]int)
 
-	err = fs.WalkDir(root.FS(), ".", func(path string, d fs.DirEntry, walkErr error) error {
+	err = fs.WalkDir(root.FS(), dir, func(path string, d fs.DirEntry, walkErr error) error {
 		if walkErr != nil {
-			panic(fmt.Sprintf("unexpected FS walk error at %s: %v", path, walkErr))
+			return walkErr
+		}
+
+		// Template labels are no labels in use.
+		if d.IsDir() && path == storage.TemplatesDir {
+			return fs.SkipDir
 		}
 
 		if d.IsDir() || !items.UUIDRegex.MatchString(filepath.Base(path)) {
@@ -110,14 +168,14 @@ func AllLabels(v *viper.Viper) map[string]int {
 
 		data, err := root.ReadFile(path)
 		if err != nil {
-			panic(fmt.Sprintf("unexpected read error for %s: %v", path, err))
+			return nil
 		}
 
 		var task struct {
 			Labels items.Labels `json:"labels"`
 		}
 		if err := json.Unmarshal(data, &task); err != nil {
-			panic(fmt.Sprintf("unexpected JSON parse error for %s: %v", path, err))
+			return nil
 		}
 
 		for _, label := range task.Labels {
@@ -127,10 +185,10 @@ func AllLabels(v *viper.Viper) map[string]int {
 		return nil
 	})
 	if err != nil {
-		panic(fmt.Sprintf("unexpected error walking storage dir %s: %v", v.GetString("storage.path"), err))
+		return nil, fmt.Errorf("could not read labels from %s: %w", storagePath, err)
 	}
 
-	return labelCount
+	return labelCount, nil
 }
 
 // LabelsStringToSlice splits a comma-separated labels string into a slice of
@@ -154,10 +212,15 @@ func LabelsStringToSlice(labels string) []string {
 	return result
 }
 
-// GetColorCode maps a project color name to its corresponding lipgloss.AdaptiveColor.
-// Supported colors include: green, orange, red, blue, indigo.
+// GetColorCode maps a project color to its corresponding lipgloss.AdaptiveColor.
+// Supported colors are the ProjectColors, which follow the configured palette,
+// and hex colors like "#ff8800", which are used for light and dark themes alike.
 // Defaults to blue if the color is unrecognized.
 func GetColorCode(color string) lipgloss.AdaptiveColor {
+	if IsHexColor(color) {
+		return lipgloss.AdaptiveColor{Light: color, Dark: color}
+	}
+
 	switch color {
 	case "green":
 		return colors.Green()
@@ -169,11 +232,18 @@ func GetColorCode(color string) lipgloss.AdaptiveColor {
 		return colors.Blue()
 	case "indigo":
 		return colors.Indigo()
+	case "yellow":
+		return colors.Yellow()
 	default:
 		return colors.Blue()
 	}
 }
 
+// IsHexColor reports whether color is a hex color like "#ff8800" or "#f80".
+func IsHexColor(color string) bool {
+	return hexColorRegexp.MatchString(color)
+}
+
 // UniqueNonEmptyStrings splits the input string by newlines, trims whitespace from each line,
 // and returns a slice of unique, non-empty strings in arbitrary order.
 func UniqueNonEmptyStrings(slice []string) []string {
@@ -224,3 +294,373 @@ func CloseWithErr(c io.Closer, err *error) {
 		*err = cErr
 	}
 }
+
+// ParseDueDate parses a due date given either as a natural language
+// shortcut (see ParseShortcut) or in one of the formats accepted by
+// ParseFlexibleDate. The result is interpreted in the local time zone,
+// just like due dates entered in the task form.
+func ParseDueDate(str string) (time.Time, error) {
+	if t, err := ParseShortcut(str); err == nil {
+		return t, nil
+	}
+
+	t, err := ParseFlexibleDate(str)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return time.ParseInLocation(time.DateTime, t.Format(time.DateTime), time.Local)
+}
+
+// ParseFlexibleDate parses a string into a time.Time value, supporting a variety of common date and time formats.
+// It handles ISO 8601, localized formats (e.g., "DD.MM.YYYY", "MM/DD/YYYY"), time-only inputs (assumed for today),
+// and RFC3339. If the input does not match any supported format, it returns an error.
+//
+// Examples of valid inputs:
+//   - "2026-02-14"
+//   - "14.02.2026 15:04"
+//   - "02/14/2026"
+//   - "15:04" (assumes today's date)
+//   - "2006-01-02T15:04:05Z07:00"
+func ParseFlexibleDate(str string) (time.Time, error) {
+	now := time.Now()
+	layouts := []string{
+		time.DateTime,      // "2006-01-02 15:04:05"
+		"2006-01-02",       // "2006-02-14"
+		"02.01.2006 15:04", // "14.02.2026 15:04"
+		"02.01.2006",       // "14.02.2026"
+		"02/01/2006 15:04", // "02/14/2026 15:04"
+		"02/01/2006",       // "02/14/2026"
+		"15:04",            // "15:04" (assume today)
+		time.RFC3339,       // "2006-01-02T15:04:05Z07:00"
+	}
+
+	if len(str) <= 5 {
+		if t, err := time.Parse("15:04", str); err == nil {
+			return time.Date(now.Year(), now.Month(), now.Day(), t.Hour(), t.Minute(), 0, 0, now.Location()), nil
+		}
+	}
+
+	for _, layout := range layouts {
+		t, err := time.Parse(layout, str)
+		if err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("unsupported date format")
+}
+
+// ParseShortcut parses natural language date shortcuts into a time.Time value.
+// It supports expressions like "tomorrow", "in 3 days", "in 2 weeks", "next monday", etc.
+// All returned times are set to midnight (00:00:00) in the local timezone.
+// If the input does not match any supported shortcut, it returns an error.
+//
+// Examples of valid inputs:
+//   - "tomorrow"
+//   - "in 3 days"
+//   - "in 2 weeks"
+//   - "next monday"
+func ParseShortcut(str string) (time.Time, error) {
+	now := time.Now()
+	todayAtMidnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	re := regexp.MustCompile(`^in (\d+) (days|weeks)$`)
+	matches := re.FindStringSubmatch(strings.ToLower(str))
+	if len(matches) == 3 {
+		amount, _ := strconv.Atoi(matches[1])
+		unit := matches[2]
+		switch unit {
+		case "days":
+			return todayAtMidnight.AddDate(0, 0, amount), nil
+		case "weeks":
+			return todayAtMidnight.AddDate(0, 0, amount*7), nil
+		}
+	}
+
+	switch strings.ToLower(str) {
+	case "tomorrow":
+		return todayAtMidnight.AddDate(0, 0, 1), nil
+	case "in a week":
+		return todayAtMidnight.AddDate(0, 0, 7), nil
+	case "in a month":
+		return todayAtMidnight.AddDate(0, 1, 0), nil
+	case "next monday":
+		return NextWeekday(todayAtMidnight, time.Monday), nil
+	case "next tuesday":
+		return NextWeekday(todayAtMidnight, time.Tuesday), nil
+	case "next wednesday":
+		return NextWeekday(todayAtMidnight, time.Wednesday), nil
+	case "next thursday":
+		return NextWeekday(todayAtMidnight, time.Thursday), nil
+	case "next friday":
+		return NextWeekday(todayAtMidnight, time.Friday), nil
+	case "next saturday":
+		return NextWeekday(todayAtMidnight, time.Saturday), nil
+	case "next sunday":
+		return NextWeekday(todayAtMidnight, time.Sunday), nil
+	default:
+		return time.Time{}, fmt.Errorf("unknown shortcut")
+	}
+}
+
+// NextWeekday returns the next occurrence of a specific weekday (e.g., time.Monday)
+// after the given time t. If t's weekday is already the target day,
+// it returns the time for the same day in the following week.
+//
+// Example:
+//
+//	NextWeekday(time.Now(), time.Monday) // Next Monday at the same time as t.
+func NextWeekday(t time.Time, day time.Weekday) time.Time {
+	diff := (day - t.Weekday() + 7) % 7
+	return t.AddDate(0, 0, int(diff))
+}
+
+// Notify sends a desktop notification with the given title and message.
+// It uses osascript on macOS and notify-send on all other systems.
+func Notify(title, message string) error {
+	var cmd *exec.Cmd
+
+	switch runtime.GOOS {
+	case "darwin":
+		script := fmt.Sprintf("display notification %q with title %q", message, title)
+		cmd = exec.Command("osascript", "-e", script) // #nosec G204 Script only contains quoted strings
+	default:
+		cmd = exec.Command("notify-send", title, message) // #nosec G204 Arguments are not interpreted by a shell
+	}
+
+	return cmd.Run()
+}
+
+// Open opens the file at path with the platform's default application.
+// It uses open on macOS, the URL protocol handler on Windows and xdg-open
+// on all other systems.
+func Open(path string) error {
+	var cmd *exec.Cmd
+
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", path) // #nosec G204 Arguments are not interpreted by a shell
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", path) // #nosec G204 Arguments are not interpreted by a shell
+	default:
+		cmd = exec.Command("xdg-open", path) // #nosec G204 Arguments are not interpreted by a shell
+	}
+
+	return cmd.Run()
+}
+
+// Editor returns the editor set in the EDITOR environment variable.
+// Returns ErrNoEditorSet if EDITOR is empty and ErrInvalidEditorSet
+// if it contains characters that are not allowed in a path.
+func Editor() (string, error) {
+	editor := os.Getenv("EDITOR")
+	if editor == "" {
+		return "", ErrNoEditorSet
+	} else if !editorRegexp.MatchString(editor) {
+		return "", ErrInvalidEditorSet
+	}
+
+	return editor, nil
+}
+
+// CopyToClipboard copies text to the system clipboard. In SSH sessions, or
+// if no clipboard utility is available, the text is sent to the terminal as
+// an OSC 52 escape sequence instead, which most terminals forward to the
+// clipboard of the local machine.
+func CopyToClipboard(text string) error {
+	if os.Getenv("SSH_TTY") == "" && os.Getenv("SSH_CONNECTION") == "" {
+		if err := clipboard.WriteAll(text); err == nil {
+			return nil
+		}
+	}
+
+	return writeOSC52(os.Stdout, text)
+}
+
+// writeOSC52 writes text to w as an OSC 52 sequence setting the clipboard.
+// The sequence is wrapped for tmux and screen if the program runs in them.
+func writeOSC52(w io.Writer, text string) error {
+	seq := osc52.New(text)
+
+	switch {
+	case os.Getenv("TMUX") != "":
+		seq = seq.Tmux()
+	case strings.HasPrefix(os.Getenv("TERM"), "screen"):
+		seq = seq.Screen()
+	}
+
+	_, err := seq.WriteTo(w)
+	return err
+}
+
+// QuickAdd holds the fields of a task parsed from a
+// quick capture line, see ParseQuickAdd.
+type QuickAdd struct {
+	Title    string
+	Priority string
+	Assignee string
+	Labels   []string
+	DueDate  *time.Time
+}
+
+// ParseQuickAdd parses a single line describing a task, e.g.
+// "Fix login bug !high @alice +auth due:fri". Words starting with "!"
+// set the priority (high, medium, low or their first letter), "@" the
+// assignee and "+" add a label. "due:" sets the due date, given as a
+// weekday ("fri", "friday"), "today" or in any format accepted by
+// ParseDueDate that contains no spaces. All other words form the title.
+// The priority defaults to low.
+func ParseQuickAdd(line string) (QuickAdd, error) {
+	result := QuickAdd{Priority: "low"}
+
+	var title []string
+	for word := range strings.FieldsSeq(line) {
+		lower := strings.ToLower(word)
+
+		switch {
+		case strings.HasPrefix(word, "!") && len(word) > 1:
+			priority, ok := map[string]string{
+				"h": "high", "high": "high",
+				"m": "medium", "medium": "medium",
+				"l": "low", "low": "low",
+			}[lower[1:]]
+			if !ok {
+				return QuickAdd{}, fmt.Errorf("invalid priority %q (valid: high, medium, low)", word[1:])
+			}
+			result.Priority = priority
+
+		case strings.HasPrefix(word, "@") && len(word) > 1:
+			result.Assignee = word[1:]
+
+		case strings.HasPrefix(word, "+") && len(word) > 1:
+			result.Labels = append(result.Labels, word[1:])
+
+		case strings.HasPrefix(lower, "due:") && len(word) > 4:
+			dueDate, err := parseQuickAddDate(word[4:])
+			if err != nil {
+				return QuickAdd{}, fmt.Errorf("invalid due date %q: %w", word[4:], err)
+			}
+			result.DueDate = &dueDate
+
+		default:
+			title = append(title, word)
+		}
+	}
+
+	result.Title = strings.Join(title, " ")
+	if result.Title == "" {
+		return QuickAdd{}, errors.New("title must not be empty")
+	}
+
+	if len(result.Labels) > 0 {
+		result.Labels = UniqueNonEmptyStrings(result.Labels)
+	}
+
+	return result, nil
+}
+
+// parseQuickAddDate parses the due date of a quick capture line.
+// Weekdays refer to their next occurrence, today included.
+func parseQuickAddDate(str string) (time.Time, error) {
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	lower := strings.ToLower(str)
+	if lower == "today" {
+		return today, nil
+	}
+
+	for day := time.Sunday; day <= time.Saturday; day++ {
+		name := strings.ToLower(day.String())
+		if lower == name || lower == name[:3] {
+			return NextWeekday(today, day), nil
+		}
+	}
+
+	return ParseDueDate(str)
+}
+
+// MatchContributor returns the first of the given contributors, as
+// returned by vcs.AllContributors, with a word starting with name,
+// ignoring case. E.g. "alice" matches "Alice Doe alice@example.com".
+// Returns name itself if no contributor matches.
+func MatchContributor(name string, contributors []string) string {
+	lower := strings.ToLower(name)
+
+	for _, contributor := range contributors {
+		for word := range strings.FieldsSeq(strings.ToLower(contributor)) {
+			if strings.HasPrefix(word, lower) {
+				return contributor
+			}
+		}
+	}
+
+	return name
+}
+
+// NormalizeAssignee returns the assignee in the "Name email" format of the
+// contributors returned by vcs.AllContributors. It accepts a bare email
+// address, "Name email" and "Name <email>". An error is returned if str
+// does not end with a valid email address.
+func NormalizeAssignee(str string) (string, error) {
+	str = strings.TrimSpace(str)
+
+	if addr, err := mail.ParseAddress(str); err == nil {
+		return strings.TrimSpace(addr.Name + " " + addr.Address), nil
+	}
+
+	fields := strings.Fields(str)
+	if len(fields) > 0 {
+		email := fields[len(fields)-1]
+		if addr, err := mail.ParseAddress(email); err == nil && addr.Address == email {
+			return strings.Join(fields, " "), nil
+		}
+	}
+
+	return "", fmt.Errorf("invalid email address: %q", str)
+}
+
+// Roster returns the assignees listed in the team roster file set by
+// "assignee.roster", in the order of the file. A relative path is taken
+// relative to the storage directory, so that the roster can be committed
+// along with the tasks. The file holds one assignee per line, see
+// NormalizeAssignee; empty lines, lines starting with "#" and invalid
+// entries are skipped. Returns nil if no roster file is set.
+func Roster(v *viper.Viper) ([]string, error) {
+	cfg := config.Get(v)
+
+	file := cfg.AssigneeRoster
+	if file == "" {
+		return nil, nil
+	}
+
+	if file == "~" || strings.HasPrefix(file, "~"+string(filepath.Separator)) {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, err
+		}
+		file = filepath.Join(home, file[1:])
+	} else if !filepath.IsAbs(file) {
+		file = filepath.Join(cfg.StoragePath, file)
+	}
+
+	data, err := os.ReadFile(filepath.Clean(file))
+	if err != nil {
+		return nil, fmt.Errorf("could not read assignee roster: %w", err)
+	}
+
+	var roster []string
+	for line := range strings.Lines(string(data)) {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if assignee, err := NormalizeAssignee(line); err == nil {
+			roster = append(roster, assignee)
+		}
+	}
+
+	return roster, nil
+}
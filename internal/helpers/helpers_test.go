@@ -21,10 +21,17 @@
 package helpers
 
 import (
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/handlebargh/yatto/internal/colors"
+	"github.com/handlebargh/yatto/internal/items"
+	"github.com/handlebargh/yatto/internal/storage"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -80,6 +87,10 @@ func TestGetColorCode(t *testing.T) {
 		{"red", "red", colors.Red()},
 		{"blue", "blue", colors.Blue()},
 		{"indigo", "indigo", colors.Indigo()},
+		{"yellow", "yellow", colors.Yellow()},
+		{"hex", "#ff8800", lipgloss.AdaptiveColor{Light: "#ff8800", Dark: "#ff8800"}},
+		{"short hex", "#f80", lipgloss.AdaptiveColor{Light: "#f80", Dark: "#f80"}},
+		{"invalid hex", "#ff880", colors.Blue()},
 		{"unknown", "unknown", colors.Blue()},
 		{"empty", "", colors.Blue()},
 	}
@@ -173,3 +184,247 @@ func TestAddAngleBracketsToEmail(t *testing.T) {
 		})
 	}
 }
+
+func TestParseDueDate(t *testing.T) {
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+
+	testCases := []struct {
+		name     string
+		input    string
+		expected time.Time
+		wantErr  bool
+	}{
+		{
+			name:     "shortcut",
+			input:    "tomorrow",
+			expected: today.AddDate(0, 0, 1),
+		},
+		{
+			name:     "relative days",
+			input:    "in 3 days",
+			expected: today.AddDate(0, 0, 3),
+		},
+		{
+			name:     "iso date",
+			input:    "2030-02-14",
+			expected: time.Date(2030, 2, 14, 0, 0, 0, 0, time.Local),
+		},
+		{
+			name:     "localized date and time",
+			input:    "14.02.2030 15:04",
+			expected: time.Date(2030, 2, 14, 15, 4, 0, 0, time.Local),
+		},
+		{
+			name:    "invalid",
+			input:   "someday",
+			wantErr: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result, err := ParseDueDate(tc.input)
+			if tc.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.True(t, tc.expected.Equal(result), "expected %v, got %v", tc.expected, result)
+		})
+	}
+}
+
+func TestReadProjectsFromFS(t *testing.T) {
+	tempDir := t.TempDir()
+	v := viper.New()
+	v.Set("storage.path", tempDir)
+
+	project := items.Project{ID: "valid", Title: "Valid"}
+	assert.NoError(t, os.Mkdir(filepath.Join(tempDir, "valid"), 0o700))
+	assert.NoError(t, os.WriteFile(filepath.Join(tempDir, "valid", "project.json"), project.MarshalProject(), 0o600))
+
+	assert.NoError(t, os.Mkdir(filepath.Join(tempDir, "corrupt"), 0o700))
+	assert.NoError(t, os.WriteFile(filepath.Join(tempDir, "corrupt", "project.json"), []byte("{"), 0o600))
+
+	assert.NoError(t, os.Mkdir(filepath.Join(tempDir, "empty"), 0o700))
+	assert.NoError(t, os.Mkdir(filepath.Join(tempDir, ".git"), 0o700))
+	assert.NoError(t, os.Mkdir(filepath.Join(tempDir, storage.TemplatesDir), 0o700))
+
+	projects, err := ReadProjectsFromFS(v)
+	assert.Len(t, projects, 1)
+	assert.Equal(t, "Valid", projects[0].Title)
+
+	var skipped *items.SkippedFilesError
+	assert.ErrorAs(t, err, &skipped)
+
+	var paths []string
+	for _, f := range skipped.Files {
+		paths = append(paths, f.Path)
+	}
+	assert.ElementsMatch(t, []string{"corrupt/project.json", "empty/project.json"}, paths)
+
+	v.Set("storage.path", filepath.Join(tempDir, "missing"))
+	projects, err = ReadProjectsFromFS(v)
+	assert.Nil(t, projects)
+	assert.Error(t, err)
+	assert.NotErrorAs(t, err, &skipped)
+}
+
+func TestProjectLabels(t *testing.T) {
+	tempDir := t.TempDir()
+	v := viper.New()
+	v.Set("storage.path", tempDir)
+
+	write := func(dir, id, labels string) {
+		assert.NoError(t, os.MkdirAll(filepath.Join(tempDir, dir), 0o700))
+		data := []byte(`{"labels":"` + labels + `"}`)
+		assert.NoError(t, os.WriteFile(filepath.Join(tempDir, dir, id+".json"), data, 0o600))
+	}
+
+	write("work", "0b9f4f2e-7a8c-4c3e-9d8e-1f2a3b4c5d6e", "bug,ui")
+	write(filepath.Join("work", items.ArchiveDir), "1c8e5e3f-6b9d-4d2f-8e7f-2a3b4c5d6e7f", "bug")
+	write("home", "2d7f6d4a-5c8e-4e1a-9f6a-3b4c5d6e7f8a", "garden")
+	write(storage.TemplatesDir, "3e6a7c5b-4d9f-4f2b-8a5b-4c5d6e7f8a9b", "template")
+
+	labels, err := ProjectLabels(v, items.Project{ID: "work"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int{"bug": 2, "ui": 1}, labels)
+
+	labels, err = AllLabels(v)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int{"bug": 2, "ui": 1, "garden": 1}, labels)
+
+	labels, err = ProjectLabels(v, items.Project{ID: "missing"})
+	assert.Error(t, err)
+	assert.Nil(t, labels)
+
+	v.Set("storage.path", filepath.Join(tempDir, "missing"))
+	labels, err = AllLabels(v)
+	assert.Error(t, err)
+	assert.Nil(t, labels)
+}
+
+func TestEditor(t *testing.T) {
+	testCases := []struct {
+		name    string
+		editor  string
+		want    string
+		wantErr error
+	}{
+		{name: "unset", editor: "", wantErr: ErrNoEditorSet},
+		{name: "command", editor: "vim", want: "vim"},
+		{name: "path", editor: "/usr/bin/nvim", want: "/usr/bin/nvim"},
+		{name: "shell characters", editor: "vim; rm -rf /", wantErr: ErrInvalidEditorSet},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv("EDITOR", tc.editor)
+
+			got, err := Editor()
+			assert.ErrorIs(t, err, tc.wantErr)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
+
+func TestWriteOSC52(t *testing.T) {
+	t.Setenv("TMUX", "")
+	t.Setenv("TERM", "xterm-256color")
+
+	var b strings.Builder
+	assert.NoError(t, writeOSC52(&b, "hello"))
+	assert.Equal(t, "\x1b]52;c;aGVsbG8=\x07", b.String())
+
+	t.Setenv("TMUX", "/tmp/tmux-1000/default,1,0")
+	b.Reset()
+	assert.NoError(t, writeOSC52(&b, "hello"))
+	assert.True(t, strings.HasPrefix(b.String(), "\x1bPtmux;"), "expected tmux passthrough, got %q", b.String())
+}
+
+func TestParseQuickAdd(t *testing.T) {
+	now := time.Now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+
+	got, err := ParseQuickAdd("Fix login bug !high @alice +auth due:fri +auth")
+	assert.NoError(t, err)
+	assert.Equal(t, "Fix login bug", got.Title)
+	assert.Equal(t, "high", got.Priority)
+	assert.Equal(t, "alice", got.Assignee)
+	assert.Equal(t, []string{"auth"}, got.Labels)
+	if assert.NotNil(t, got.DueDate) {
+		assert.Equal(t, NextWeekday(today, time.Friday), *got.DueDate)
+	}
+
+	got, err = ParseQuickAdd("Buy milk !M due:Today")
+	assert.NoError(t, err)
+	assert.Equal(t, "Buy milk", got.Title)
+	assert.Equal(t, "medium", got.Priority)
+	if assert.NotNil(t, got.DueDate) {
+		assert.Equal(t, today, *got.DueDate)
+	}
+
+	got, err = ParseQuickAdd("Call Bob")
+	assert.NoError(t, err)
+	assert.Equal(t, QuickAdd{Title: "Call Bob", Priority: "low"}, got)
+
+	_, err = ParseQuickAdd("Call Bob !urgent")
+	assert.Error(t, err)
+
+	_, err = ParseQuickAdd("Call Bob due:someday")
+	assert.Error(t, err)
+
+	_, err = ParseQuickAdd("!high +auth")
+	assert.Error(t, err)
+}
+
+func TestMatchContributor(t *testing.T) {
+	contributors := []string{"Bob Smith bob@example.com", "Alice Doe alice@example.com"}
+
+	assert.Equal(t, "Alice Doe alice@example.com", MatchContributor("alice", contributors))
+	assert.Equal(t, "Bob Smith bob@example.com", MatchContributor("Smi", contributors))
+	assert.Equal(t, "carol", MatchContributor("carol", contributors))
+}
+
+func TestNormalizeAssignee(t *testing.T) {
+	for input, want := range map[string]string{
+		"alice@example.com":                  "alice@example.com",
+		" Alice Doe alice@example.com ":      "Alice Doe alice@example.com",
+		"Alice Doe <alice@example.com>":      "Alice Doe alice@example.com",
+		"\"Doe, Alice\" <alice@example.com>": "Doe, Alice alice@example.com",
+	} {
+		got, err := NormalizeAssignee(input)
+		assert.NoError(t, err, input)
+		assert.Equal(t, want, got, input)
+	}
+
+	for _, input := range []string{"", "alice", "Alice Doe", "alice@example.com Alice"} {
+		_, err := NormalizeAssignee(input)
+		assert.Error(t, err, input)
+	}
+}
+
+func TestRoster(t *testing.T) {
+	tempDir := t.TempDir()
+	v := viper.New()
+	v.Set("storage.path", tempDir)
+
+	roster, err := Roster(v)
+	assert.NoError(t, err)
+	assert.Nil(t, roster)
+
+	data := "# The team\nAlice Doe <alice@example.com>\n\nbob@example.com\nnot an address\n"
+	assert.NoError(t, os.WriteFile(filepath.Join(tempDir, "team.txt"), []byte(data), 0o600))
+
+	// Relative paths are taken relative to the storage directory.
+	v.Set("assignee.roster", "team.txt")
+	roster, err = Roster(v)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Alice Doe alice@example.com", "bob@example.com"}, roster)
+
+	v.Set("assignee.roster", filepath.Join(tempDir, "missing.txt"))
+	_, err = Roster(v)
+	assert.Error(t, err)
+}
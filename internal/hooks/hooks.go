@@ -0,0 +1,211 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package hooks runs the user-defined hooks configured in the [hooks]
+// section for events like a created or completed task. A hook is either
+// a shell command, which receives the event payload on stdin, or an
+// http(s) URL the payload is POSTed to.
+package hooks
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"os/exec"
+	"runtime"
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/viper"
+)
+
+// Event is the name of an event hooks can be configured for.
+type Event string
+
+// The events hooks can be configured for.
+const (
+	// TaskCreated is sent with the task JSON when a task is created.
+	TaskCreated Event = "task_created"
+
+	// TaskCompleted is sent with the task JSON when a task is completed.
+	TaskCompleted Event = "task_completed"
+
+	// TaskOverdue is sent with the task JSON once when an open task
+	// is found to be overdue.
+	TaskOverdue Event = "task_overdue"
+
+	// SyncFinished is sent after a successful sync with the remote.
+	SyncFinished Event = "sync_finished"
+)
+
+// Events are all events hooks can be configured for.
+var Events = []Event{TaskCreated, TaskCompleted, TaskOverdue, SyncFinished}
+
+// timeout is how long a single hook may run.
+var timeout = 10 * time.Second
+
+// Configured reports whether any hooks are configured for the event.
+func Configured(v *viper.Viper, event Event) bool {
+	return len(v.GetStringSlice("hooks."+string(event))) > 0
+}
+
+// Run runs all hooks configured for the event one after another and
+// passes them the payload. projectID is the ID of the project the event
+// belongs to, if any. Commands get the event and project ID in the
+// YATTO_EVENT and YATTO_PROJECT_ID environment variables, URLs in the
+// X-Yatto-Event and X-Yatto-Project-Id headers.
+//
+// A failing hook does not keep the others from running. The errors of
+// all failed hooks are returned together.
+func Run(v *viper.Viper, event Event, projectID string, payload []byte) error {
+	var errs []error
+
+	for _, hook := range v.GetStringSlice("hooks." + string(event)) {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+
+		var err error
+		if strings.HasPrefix(hook, "http://") || strings.HasPrefix(hook, "https://") {
+			err = post(ctx, hook, event, projectID, payload)
+		} else {
+			err = execute(ctx, hook, event, projectID, payload)
+		}
+		cancel()
+
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s hook %q: %w", event, hook, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// execute runs the command with the platform's shell.
+func execute(ctx context.Context, command string, event Event, projectID string, payload []byte) error {
+	var cmd *exec.Cmd
+
+	switch runtime.GOOS {
+	case "windows":
+		cmd = exec.CommandContext(ctx, "cmd", "/C", command) // #nosec G204 The command is configured by the user
+	default:
+		cmd = exec.CommandContext(ctx, "sh", "-c", command) // #nosec G204 The command is configured by the user
+	}
+
+	cmd.Stdin = bytes.NewReader(payload)
+	cmd.Env = append(os.Environ(), "YATTO_EVENT="+string(event), "YATTO_PROJECT_ID="+projectID)
+
+	if output, err := cmd.CombinedOutput(); err != nil {
+		if len(output) > 0 {
+			return fmt.Errorf("%w: %s", err, strings.TrimSpace(string(output)))
+		}
+		return err
+	}
+
+	return nil
+}
+
+// post sends the payload to the URL.
+func post(ctx context.Context, url string, event Event, projectID string, payload []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Yatto-Event", string(event))
+	if projectID != "" {
+		req.Header.Set("X-Yatto-Project-Id", projectID)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	_ = resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	return nil
+}
+
+// RunSyncFinished runs the hooks of the SyncFinished event. The payload
+// holds the time the sync finished.
+func RunSyncFinished(v *viper.Viper, now time.Time) error {
+	if !Configured(v, SyncFinished) {
+		return nil
+	}
+
+	payload, err := json.Marshal(struct {
+		Time time.Time `json:"time"`
+	}{now})
+	if err != nil {
+		return err
+	}
+
+	return Run(v, SyncFinished, "", payload)
+}
+
+// Call is a run of the hooks of an event, see Run.
+type Call struct {
+	Event     Event
+	ProjectID string
+	Payload   []byte
+}
+
+// ErrorMsg carries the errors of hooks run in the background.
+type ErrorMsg struct {
+	Err error
+}
+
+// Error implements the error interface for ErrorMsg.
+func (e ErrorMsg) Error() string { return e.Err.Error() }
+
+// RunAll runs the hooks of all calls one after another and returns
+// their joined errors.
+func RunAll(v *viper.Viper, calls []Call) error {
+	var errs []error
+	for _, call := range calls {
+		errs = append(errs, Run(v, call.Event, call.ProjectID, call.Payload))
+	}
+
+	return errors.Join(errs...)
+}
+
+// RunCmd returns a command that runs the hooks of all calls, see RunAll.
+// It returns an ErrorMsg if any of them failed. If no hooks are configured
+// for the calls, nil is returned, so that nothing is run at all.
+func RunCmd(v *viper.Viper, calls []Call) tea.Cmd {
+	if !slices.ContainsFunc(calls, func(c Call) bool { return Configured(v, c.Event) }) {
+		return nil
+	}
+
+	return func() tea.Msg {
+		if err := RunAll(v, calls); err != nil {
+			return ErrorMsg{err}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,103 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package hooks
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRun(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the hooks use sh")
+	}
+
+	t.Run("passes the payload to commands", func(t *testing.T) {
+		out := filepath.Join(t.TempDir(), "out")
+		v := viper.New()
+		v.Set("hooks.task_created", []string{`cat > "` + out + `"; echo "$YATTO_EVENT $YATTO_PROJECT_ID" >> "` + out + `"`})
+
+		assert.True(t, Configured(v, TaskCreated))
+		assert.False(t, Configured(v, TaskCompleted))
+
+		err := Run(v, TaskCreated, "project", []byte("{}\n"))
+		assert.NoError(t, err)
+
+		data, _ := os.ReadFile(out)
+		assert.Equal(t, "{}\ntask_created project\n", string(data))
+	})
+
+	t.Run("posts the payload to URLs", func(t *testing.T) {
+		var body, event string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			data, _ := io.ReadAll(r.Body)
+			body = string(data)
+			event = r.Header.Get("X-Yatto-Event")
+		}))
+		defer server.Close()
+
+		v := viper.New()
+		v.Set("hooks.task_completed", []string{server.URL})
+
+		err := Run(v, TaskCompleted, "project", []byte(`{"id":"1"}`))
+		assert.NoError(t, err)
+		assert.Equal(t, `{"id":"1"}`, body)
+		assert.Equal(t, "task_completed", event)
+	})
+
+	t.Run("runs all hooks and reports failures", func(t *testing.T) {
+		out := filepath.Join(t.TempDir(), "out")
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+		}))
+		defer server.Close()
+
+		v := viper.New()
+		v.Set("hooks.sync_finished", []string{"echo broken >&2; exit 1", server.URL, `touch "` + out + `"`})
+
+		err := RunSyncFinished(v, time.Now())
+		assert.ErrorContains(t, err, "broken")
+		assert.ErrorContains(t, err, "unexpected status 500")
+		assert.FileExists(t, out)
+	})
+	t.Run("runs the hooks of calls in the background", func(t *testing.T) {
+		v := viper.New()
+		calls := []Call{{Event: TaskCreated, ProjectID: "project", Payload: []byte("{}")}}
+		assert.Nil(t, RunCmd(v, calls))
+
+		v.Set("hooks.task_created", []string{"exit 1"})
+		cmd := RunCmd(v, calls)
+		if assert.NotNil(t, cmd) {
+			msg, ok := cmd().(ErrorMsg)
+			assert.True(t, ok)
+			assert.ErrorContains(t, msg, "task_created hook")
+		}
+	})
+}
@@ -0,0 +1,167 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package importer
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/handlebargh/yatto/internal/helpers"
+	"github.com/handlebargh/yatto/internal/items"
+)
+
+// Lines reads one task per line, e.g. a brainstorm or meeting notes, and
+// puts them into the given project. Each line is parsed like a quick
+// capture line, see helpers.ParseQuickAdd. Blank lines are skipped and
+// leading list markers ("-", "*" and "- [ ]") are removed.
+func Lines(r io.Reader, project string) (ProjectImport, error) {
+	result := ProjectImport{Title: project}
+
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := trimListMarker(strings.TrimSpace(scanner.Text()))
+		if line == "" {
+			continue
+		}
+
+		task, err := parseLine(line)
+		if err != nil {
+			return ProjectImport{}, fmt.Errorf("line %d: %w", n, err)
+		}
+		result.Tasks = append(result.Tasks, task)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return ProjectImport{}, err
+	}
+
+	return result, nil
+}
+
+// jsonTask is a single task of a JSON import.
+type jsonTask struct {
+	Title       string   `json:"title"`
+	Description string   `json:"description"`
+	Priority    string   `json:"priority"`
+	Assignee    string   `json:"assignee"`
+	Labels      []string `json:"labels"`
+	Due         string   `json:"due"`
+}
+
+// JSON reads an array of tasks and puts them into the given project.
+// An element is either a string, parsed like a line of Lines, or an
+// object with title, description, priority, assignee, labels and due.
+// The due date accepts any format of helpers.ParseDueDate.
+func JSON(r io.Reader, project string) (ProjectImport, error) {
+	var elements []json.RawMessage
+	if err := json.NewDecoder(r).Decode(&elements); err != nil {
+		return ProjectImport{}, fmt.Errorf("invalid JSON import: %w", err)
+	}
+
+	result := ProjectImport{Title: project}
+
+	for i, raw := range elements {
+		task, err := parseJSONTask(raw)
+		if err != nil {
+			return ProjectImport{}, fmt.Errorf("element %d: %w", i+1, err)
+		}
+		result.Tasks = append(result.Tasks, task)
+	}
+
+	return result, nil
+}
+
+// parseJSONTask converts a single element of a JSON import into a task.
+func parseJSONTask(raw json.RawMessage) (*items.Task, error) {
+	var line string
+	if err := json.Unmarshal(raw, &line); err == nil {
+		return parseLine(line)
+	}
+
+	var jt jsonTask
+	if err := json.Unmarshal(raw, &jt); err != nil {
+		return nil, err
+	}
+
+	if strings.TrimSpace(jt.Title) == "" {
+		return nil, errors.New("title must not be empty")
+	}
+
+	if jt.Priority == "" {
+		jt.Priority = "low"
+	}
+	if !slices.Contains([]string{"low", "medium", "high"}, jt.Priority) {
+		return nil, fmt.Errorf("invalid priority %q (valid: low, medium, high)", jt.Priority)
+	}
+
+	task := &items.Task{
+		ID:          uuid.NewString(),
+		Title:       strings.TrimSpace(jt.Title),
+		Description: jt.Description,
+		Priority:    jt.Priority,
+		Assignee:    jt.Assignee,
+		Labels:      helpers.UniqueNonEmptyStrings(jt.Labels),
+	}
+
+	if jt.Due != "" {
+		dueDate, err := helpers.ParseDueDate(jt.Due)
+		if err != nil {
+			return nil, fmt.Errorf("invalid due date %q: %w", jt.Due, err)
+		}
+		task.DueDate = &dueDate
+	}
+
+	return task, nil
+}
+
+// parseLine converts a quick capture line into a new task.
+func parseLine(line string) (*items.Task, error) {
+	parsed, err := helpers.ParseQuickAdd(line)
+	if err != nil {
+		return nil, err
+	}
+
+	return &items.Task{
+		ID:       uuid.NewString(),
+		Title:    parsed.Title,
+		Priority: parsed.Priority,
+		Assignee: parsed.Assignee,
+		Labels:   parsed.Labels,
+		DueDate:  parsed.DueDate,
+	}, nil
+}
+
+// trimListMarker removes a leading Markdown list marker or checkbox.
+func trimListMarker(line string) string {
+	for _, marker := range []string{"- [ ] ", "* [ ] ", "- ", "* "} {
+		if rest, ok := strings.CutPrefix(line, marker); ok {
+			return strings.TrimSpace(rest)
+		}
+	}
+
+	return line
+}
@@ -0,0 +1,87 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package importer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLines(t *testing.T) {
+	input := "- Fix login bug !high @alice +auth\n\n* [ ] Write notes\n  Plain line  \n"
+
+	project, err := Lines(strings.NewReader(input), "Work")
+	require.NoError(t, err)
+	assert.Equal(t, "Work", project.Title)
+	require.Len(t, project.Tasks, 3)
+
+	fix := project.Tasks[0]
+	assert.Equal(t, "Fix login bug", fix.Title)
+	assert.Equal(t, "high", fix.Priority)
+	assert.Equal(t, "alice", fix.Assignee)
+	assert.Equal(t, []string{"auth"}, []string(fix.Labels))
+	assert.NotEmpty(t, fix.ID)
+
+	assert.Equal(t, "Write notes", project.Tasks[1].Title)
+	assert.Equal(t, "Plain line", project.Tasks[2].Title)
+	assert.Equal(t, "low", project.Tasks[2].Priority)
+	assert.NotEqual(t, project.Tasks[1].ID, project.Tasks[2].ID)
+
+	_, err = Lines(strings.NewReader("ok\nbroken !urgent"), "Work")
+	assert.ErrorContains(t, err, "line 2")
+}
+
+func TestJSON(t *testing.T) {
+	input := `["Call Bob +phone", {"title": "Book flights", "description": "Window seat",
+		"priority": "medium", "labels": ["trip", "trip"], "due": "2026-02-14"}]`
+
+	project, err := JSON(strings.NewReader(input), "Travel")
+	require.NoError(t, err)
+	require.Len(t, project.Tasks, 2)
+
+	call := project.Tasks[0]
+	assert.Equal(t, "Call Bob", call.Title)
+	assert.Equal(t, []string{"phone"}, []string(call.Labels))
+
+	flights := project.Tasks[1]
+	assert.Equal(t, "Book flights", flights.Title)
+	assert.Equal(t, "Window seat", flights.Description)
+	assert.Equal(t, "medium", flights.Priority)
+	assert.Equal(t, []string{"trip"}, []string(flights.Labels))
+	require.NotNil(t, flights.DueDate)
+	assert.Equal(t, "2026-02-14", flights.DueDate.Format("2006-01-02"))
+
+	tests := map[string]string{
+		`{"title": "x"}`:                         "invalid JSON import",
+		`[{"title": " "}]`:                       "title must not be empty",
+		`[{"title": "x", "priority": "urgent"}]`: "invalid priority",
+		`[{"title": "x", "due": "someday"}]`:     "invalid due date",
+		`[42]`:                                   "element 1",
+	}
+
+	for input, want := range tests {
+		_, err := JSON(strings.NewReader(input), "Travel")
+		assert.ErrorContains(t, err, want, input)
+	}
+}
@@ -0,0 +1,191 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package importer converts tasks exported by other task managers
+// into yatto tasks.
+package importer
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/handlebargh/yatto/internal/items"
+)
+
+// taskwarriorTimeLayout is the format of all dates in a Taskwarrior export.
+const taskwarriorTimeLayout = "20060102T150405Z"
+
+// ProjectImport holds the converted tasks of a single project.
+type ProjectImport struct {
+	Title string
+	Tasks []*items.Task
+}
+
+// taskwarriorTask is a single task as written by `task export`.
+type taskwarriorTask struct {
+	UUID        string                  `json:"uuid"`
+	Description string                  `json:"description"`
+	Status      string                  `json:"status"`
+	Project     string                  `json:"project"`
+	Priority    string                  `json:"priority"`
+	Tags        []string                `json:"tags"`
+	Due         string                  `json:"due"`
+	Start       string                  `json:"start"`
+	Entry       string                  `json:"entry"`
+	End         string                  `json:"end"`
+	Annotations []taskwarriorAnnotation `json:"annotations"`
+	Depends     json.RawMessage         `json:"depends"`
+}
+
+// taskwarriorAnnotation is a timestamped note attached to a Taskwarrior task.
+type taskwarriorAnnotation struct {
+	Entry       string `json:"entry"`
+	Description string `json:"description"`
+}
+
+// Taskwarrior reads the JSON written by `task export` and converts the tasks
+// into yatto tasks grouped by project, in order of first appearance.
+// Tasks without a project are put into defaultProject. Deleted tasks and
+// recurring task templates are skipped; their instances are imported instead.
+func Taskwarrior(r io.Reader, defaultProject string) ([]ProjectImport, error) {
+	var exported []taskwarriorTask
+	if err := json.NewDecoder(r).Decode(&exported); err != nil {
+		return nil, fmt.Errorf("invalid taskwarrior export: %w", err)
+	}
+
+	var projects []ProjectImport
+	index := make(map[string]int)
+
+	for _, tw := range exported {
+		if tw.Status == "deleted" || tw.Status == "recurring" {
+			continue
+		}
+
+		task, err := tw.toTask()
+		if err != nil {
+			return nil, err
+		}
+
+		title := tw.Project
+		if title == "" {
+			title = defaultProject
+		}
+
+		i, ok := index[title]
+		if !ok {
+			i = len(projects)
+			index[title] = i
+			projects = append(projects, ProjectImport{Title: title})
+		}
+		projects[i].Tasks = append(projects[i].Tasks, task)
+	}
+
+	return projects, nil
+}
+
+// toTask converts a Taskwarrior task into a yatto task with the same UUID.
+func (tw taskwarriorTask) toTask() (*items.Task, error) {
+	if !items.UUIDRegex.MatchString(tw.UUID + ".json") {
+		return nil, fmt.Errorf("invalid task uuid %q", tw.UUID)
+	}
+
+	task := &items.Task{
+		ID:         strings.ToLower(tw.UUID),
+		Title:      tw.Description,
+		Priority:   "low",
+		Labels:     tw.Tags,
+		Completed:  tw.Status == "completed",
+		InProgress: tw.Status != "completed" && tw.Start != "",
+	}
+
+	switch tw.Priority {
+	case "H":
+		task.Priority = "high"
+	case "M":
+		task.Priority = "medium"
+	}
+
+	if tw.Due != "" {
+		due, err := time.Parse(taskwarriorTimeLayout, tw.Due)
+		if err != nil {
+			return nil, fmt.Errorf("invalid due date of task %q: %w", tw.Description, err)
+		}
+		due = due.Local()
+		task.DueDate = &due
+	}
+
+	// The timestamps are informational, so invalid ones are left out.
+	if entry, err := time.Parse(taskwarriorTimeLayout, tw.Entry); err == nil {
+		entry = entry.Local()
+		task.CreatedAt = &entry
+	}
+	if end, err := time.Parse(taskwarriorTimeLayout, tw.End); err == nil && task.Completed {
+		end = end.Local()
+		task.CompletedAt = &end
+	}
+
+	var notes []string
+	for _, annotation := range tw.Annotations {
+		entry, err := time.Parse(taskwarriorTimeLayout, annotation.Entry)
+		if err != nil {
+			notes = append(notes, "- "+annotation.Description)
+			continue
+		}
+		notes = append(notes, fmt.Sprintf("- %s: %s", entry.Local().Format(time.DateOnly), annotation.Description))
+	}
+	task.Description = strings.Join(notes, "\n")
+
+	depends, err := parseDepends(tw.Depends)
+	if err != nil {
+		return nil, fmt.Errorf("invalid dependencies of task %q: %w", tw.Description, err)
+	}
+	task.BlockedBy = depends
+
+	return task, nil
+}
+
+// parseDepends parses the dependencies of a Taskwarrior task. Older versions
+// of Taskwarrior export them as a comma-separated string instead of an array.
+func parseDepends(raw json.RawMessage) ([]string, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+
+	var depends []string
+	if err := json.Unmarshal(raw, &depends); err == nil {
+		return depends, nil
+	}
+
+	var list string
+	if err := json.Unmarshal(raw, &list); err != nil {
+		return nil, err
+	}
+
+	for uuid := range strings.SplitSeq(list, ",") {
+		if uuid = strings.TrimSpace(uuid); uuid != "" {
+			depends = append(depends, uuid)
+		}
+	}
+
+	return depends, nil
+}
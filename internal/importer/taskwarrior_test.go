@@ -0,0 +1,93 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package importer
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const taskwarriorExport = `[
+{"id":1,"description":"Write report","entry":"20260101T090000Z","modified":"20260102T090000Z",
+ "project":"Work","status":"pending","uuid":"5f0c4e1a-5b8e-4d2a-9c1e-0c9a7f3f8a11","priority":"H",
+ "tags":["finance","q1"],"due":"20260214T150400Z","start":"20260102T090000Z",
+ "annotations":[{"entry":"20260103T100000Z","description":"Ask for numbers"}],
+ "depends":["0b7e0c42-3d3c-4b7e-8f0e-3d0f1e2a4b55"]},
+{"id":0,"description":"Collect numbers","entry":"20260101T090000Z","end":"20260103T090000Z",
+ "project":"Work","status":"completed","uuid":"0b7e0c42-3d3c-4b7e-8f0e-3d0f1e2a4b55","start":"20260102T090000Z"},
+{"id":2,"description":"Buy milk","entry":"20260101T090000Z","status":"pending",
+ "uuid":"8a1d2c3b-4e5f-4a6b-8c7d-9e0f1a2b3c4d","priority":"L","depends":"5f0c4e1a-5b8e-4d2a-9c1e-0c9a7f3f8a11, "},
+{"id":0,"description":"Old task","entry":"20260101T090000Z","status":"deleted",
+ "project":"Work","uuid":"1c2d3e4f-5a6b-4c7d-8e9f-0a1b2c3d4e5f"},
+{"id":3,"description":"Water plants","entry":"20260101T090000Z","status":"recurring","recur":"weekly",
+ "project":"Home","uuid":"2d3e4f5a-6b7c-4d8e-9f0a-1b2c3d4e5f6a","due":"20260105T080000Z"}
+]`
+
+func TestTaskwarrior(t *testing.T) {
+	projects, err := Taskwarrior(strings.NewReader(taskwarriorExport), "Inbox")
+	assert.NoError(t, err)
+	assert.Len(t, projects, 2)
+
+	assert.Equal(t, "Work", projects[0].Title)
+	assert.Len(t, projects[0].Tasks, 2)
+	assert.Equal(t, "Inbox", projects[1].Title)
+	assert.Len(t, projects[1].Tasks, 1)
+
+	report := projects[0].Tasks[0]
+	assert.Equal(t, "5f0c4e1a-5b8e-4d2a-9c1e-0c9a7f3f8a11", report.ID)
+	assert.Equal(t, "Write report", report.Title)
+	assert.Equal(t, "high", report.Priority)
+	assert.Equal(t, []string{"finance", "q1"}, []string(report.Labels))
+	assert.True(t, report.InProgress)
+	assert.False(t, report.Completed)
+	assert.Equal(t, time.Date(2026, time.February, 14, 15, 4, 0, 0, time.UTC), report.DueDate.UTC())
+	assert.Contains(t, report.Description, "Ask for numbers")
+	assert.Equal(t, []string{"0b7e0c42-3d3c-4b7e-8f0e-3d0f1e2a4b55"}, report.BlockedBy)
+	assert.Equal(t, time.Date(2026, time.January, 1, 9, 0, 0, 0, time.UTC), report.CreatedAt.UTC())
+	assert.Nil(t, report.CompletedAt)
+
+	collect := projects[0].Tasks[1]
+	assert.True(t, collect.Completed)
+	assert.False(t, collect.InProgress)
+	assert.Equal(t, "low", collect.Priority)
+	assert.Nil(t, collect.DueDate)
+	assert.Equal(t, time.Date(2026, time.January, 3, 9, 0, 0, 0, time.UTC), collect.CompletedAt.UTC())
+
+	milk := projects[1].Tasks[0]
+	assert.Equal(t, "low", milk.Priority)
+	assert.Equal(t, []string{"5f0c4e1a-5b8e-4d2a-9c1e-0c9a7f3f8a11"}, milk.BlockedBy)
+}
+
+func TestTaskwarrior_Invalid(t *testing.T) {
+	_, err := Taskwarrior(strings.NewReader("{"), "Inbox")
+	assert.ErrorContains(t, err, "invalid taskwarrior export")
+
+	_, err = Taskwarrior(strings.NewReader(`[{"uuid":"nope","status":"pending"}]`), "Inbox")
+	assert.ErrorContains(t, err, "invalid task uuid")
+
+	_, err = Taskwarrior(strings.NewReader(
+		`[{"uuid":"5f0c4e1a-5b8e-4d2a-9c1e-0c9a7f3f8a11","status":"pending","due":"tomorrow"}]`,
+	), "Inbox")
+	assert.ErrorContains(t, err, "invalid due date")
+}
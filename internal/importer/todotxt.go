@@ -0,0 +1,176 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package importer
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/handlebargh/yatto/internal/helpers"
+	"github.com/handlebargh/yatto/internal/items"
+)
+
+// todoTxtNamespace derives the IDs of imported todo.txt tasks from their
+// lines, so importing the same file again skips the known tasks.
+var todoTxtNamespace = uuid.MustParse("6f1c3b0e-4d2a-4e8b-9a57-2f0d8c1e7b43")
+
+// TodoTxt reads a todo.txt file and converts its tasks into yatto tasks
+// grouped by project, in order of first appearance. The first +project of
+// a task selects its project, tasks without one are put into
+// defaultProject. Contexts and further projects become labels.
+//
+// Priorities A and B are mapped to high and medium, all others to low.
+// The due: and t: tags set the due and start date, unknown tags are kept
+// in the title. A task whose line changes is imported again as a new task.
+func TodoTxt(r io.Reader, defaultProject string) ([]ProjectImport, error) {
+	var projects []ProjectImport
+	index := make(map[string]int)
+
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		task, title, err := parseTodoTxt(line)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", n, err)
+		}
+
+		if title == "" {
+			title = defaultProject
+		}
+
+		i, ok := index[title]
+		if !ok {
+			i = len(projects)
+			index[title] = i
+			projects = append(projects, ProjectImport{Title: title})
+		}
+		projects[i].Tasks = append(projects[i].Tasks, task)
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return projects, nil
+}
+
+// parseTodoTxt converts a single todo.txt line into a task and returns
+// the title of its project, which is empty if the line has none.
+func parseTodoTxt(line string) (*items.Task, string, error) {
+	task := &items.Task{
+		ID:       uuid.NewSHA1(todoTxtNamespace, []byte(line)).String(),
+		Priority: "low",
+	}
+
+	fields := strings.Fields(line)
+
+	if fields[0] == "x" {
+		task.Completed = true
+		fields = fields[1:]
+
+		if len(fields) > 0 {
+			if completed, ok := parseTodoTxtDate(fields[0]); ok {
+				task.CompletedAt = &completed
+				fields = fields[1:]
+			}
+		}
+	}
+
+	if len(fields) > 0 && len(fields[0]) == 3 && fields[0][0] == '(' && fields[0][2] == ')' &&
+		fields[0][1] >= 'A' && fields[0][1] <= 'Z' {
+		task.Priority = todoTxtPriority(fields[0][1])
+		fields = fields[1:]
+	}
+
+	if len(fields) > 0 {
+		if created, ok := parseTodoTxtDate(fields[0]); ok {
+			task.CreatedAt = &created
+			fields = fields[1:]
+		}
+	}
+
+	var project string
+	var words, labels []string
+
+	for _, field := range fields {
+		switch {
+		case len(field) > 1 && field[0] == '+':
+			if project == "" {
+				project = field[1:]
+			} else {
+				labels = append(labels, field[1:])
+			}
+		case len(field) > 1 && field[0] == '@':
+			labels = append(labels, field[1:])
+		case strings.HasPrefix(field, "due:"):
+			due, ok := parseTodoTxtDate(strings.TrimPrefix(field, "due:"))
+			if !ok {
+				return nil, "", fmt.Errorf("invalid due date %q", field)
+			}
+			task.DueDate = &due
+		case strings.HasPrefix(field, "t:"):
+			start, ok := parseTodoTxtDate(strings.TrimPrefix(field, "t:"))
+			if !ok {
+				return nil, "", fmt.Errorf("invalid threshold date %q", field)
+			}
+			task.StartDate = &start
+		case strings.HasPrefix(field, "pri:") && len(field) == 5:
+			// Completed tasks keep their priority in a tag.
+			task.Priority = todoTxtPriority(field[4])
+		default:
+			words = append(words, field)
+		}
+	}
+
+	task.Title = strings.Join(words, " ")
+	if task.Title == "" {
+		return nil, "", fmt.Errorf("task %q has no title", line)
+	}
+	task.Labels = helpers.UniqueNonEmptyStrings(labels)
+
+	return task, project, nil
+}
+
+// todoTxtPriority maps a todo.txt priority letter to a yatto priority.
+func todoTxtPriority(letter byte) string {
+	switch letter {
+	case 'A':
+		return "high"
+	case 'B':
+		return "medium"
+	default:
+		return "low"
+	}
+}
+
+// parseTodoTxtDate parses a todo.txt date in the local time zone.
+func parseTodoTxtDate(str string) (time.Time, bool) {
+	t, err := time.ParseInLocation(time.DateOnly, str, time.Local)
+	return t, err == nil
+}
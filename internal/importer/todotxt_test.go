@@ -0,0 +1,81 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package importer
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTodoTxt(t *testing.T) {
+	input := `(A) 2026-01-10 Call Mom +Family @phone due:2026-01-12
+x 2026-01-11 2026-01-05 Pay rent +Home pri:B
+(C) Water plants +Home +Garden t:2026-02-01 url:example.org
+
+Read a book`
+
+	projects, err := TodoTxt(strings.NewReader(input), "Inbox")
+	require.NoError(t, err)
+	require.Len(t, projects, 3)
+
+	assert.Equal(t, "Family", projects[0].Title)
+	assert.Equal(t, "Home", projects[1].Title)
+	assert.Equal(t, "Inbox", projects[2].Title)
+
+	call := projects[0].Tasks[0]
+	assert.Equal(t, "Call Mom", call.Title)
+	assert.Equal(t, "high", call.Priority)
+	assert.Equal(t, []string{"phone"}, []string(call.Labels))
+	require.NotNil(t, call.CreatedAt)
+	assert.Equal(t, "2026-01-10", call.CreatedAt.Format(time.DateOnly))
+	require.NotNil(t, call.DueDate)
+	assert.Equal(t, "2026-01-12", call.DueDate.Format(time.DateOnly))
+	assert.False(t, call.Completed)
+
+	require.Len(t, projects[1].Tasks, 2)
+	rent := projects[1].Tasks[0]
+	assert.True(t, rent.Completed)
+	assert.Equal(t, "medium", rent.Priority)
+	require.NotNil(t, rent.CompletedAt)
+	assert.Equal(t, "2026-01-11", rent.CompletedAt.Format(time.DateOnly))
+	assert.Equal(t, "2026-01-05", rent.CreatedAt.Format(time.DateOnly))
+
+	plants := projects[1].Tasks[1]
+	assert.Equal(t, "Water plants url:example.org", plants.Title)
+	assert.Equal(t, "low", plants.Priority)
+	assert.Equal(t, []string{"Garden"}, []string(plants.Labels))
+	require.NotNil(t, plants.StartDate)
+	assert.Equal(t, "2026-02-01", plants.StartDate.Format(time.DateOnly))
+
+	assert.Equal(t, "Read a book", projects[2].Tasks[0].Title)
+
+	again, err := TodoTxt(strings.NewReader(input), "Inbox")
+	require.NoError(t, err)
+	assert.Equal(t, call.ID, again[0].Tasks[0].ID, "IDs are derived from the line")
+	assert.NotEqual(t, call.ID, rent.ID)
+
+	_, err = TodoTxt(strings.NewReader("ok\nBroken due:someday"), "Inbox")
+	assert.ErrorContains(t, err, "line 2")
+}
@@ -0,0 +1,204 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package items
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/handlebargh/yatto/internal/config"
+	"github.com/spf13/viper"
+)
+
+// AttachmentsSuffix is appended to a task's ID to form the name of
+// the directory that holds the task's attachments.
+const AttachmentsSuffix = ".attachments"
+
+type (
+	// AttachFileDoneMsg indicates that a file was copied into the
+	// attachments directory of Task. Path is relative to the storage path.
+	AttachFileDoneMsg struct {
+		Task Task
+		Path string
+	}
+
+	// AttachFileErrorMsg is returned when a file cannot be attached to a Task.
+	AttachFileErrorMsg struct{ Err error }
+)
+
+// Error implements the error interface for AttachFileErrorMsg.
+func (e AttachFileErrorMsg) Error() string { return e.Err.Error() }
+
+// Attachment is a file attached to a task.
+type Attachment struct {
+	Name string
+	Size int64
+}
+
+// String returns the attachment's name followed by its size.
+func (a Attachment) String() string {
+	return fmt.Sprintf("%s (%s)", a.Name, FormatSize(a.Size))
+}
+
+// FormatSize returns a human-readable representation of size bytes
+// using binary units, e.g. "1.5 KiB".
+func FormatSize(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+
+	return fmt.Sprintf("%.1f %ciB", float64(size)/float64(div), "KMGTPE"[exp])
+}
+
+// AttachmentsDir returns the path of the directory holding the task's
+// attachments relative to the storage path. The directory stays in the
+// project directory when the task is archived.
+func (t *Task) AttachmentsDir(p Project) string {
+	return filepath.Join(p.ID, t.ID+AttachmentsSuffix)
+}
+
+// Attachments returns the files attached to the task sorted by name.
+// A task without an attachments directory has no attachments.
+func (t *Task) Attachments(v *viper.Viper, p Project) ([]Attachment, error) {
+	root, err := os.OpenRoot(config.Get(v).StoragePath)
+	if err != nil {
+		return nil, fmt.Errorf("could not open storage directory: %w", err)
+	}
+	defer root.Close() //nolint:errcheck
+
+	entries, err := fs.ReadDir(root.FS(), filepath.ToSlash(t.AttachmentsDir(p)))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not read attachments: %w", err)
+	}
+
+	var attachments []Attachment
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() {
+			continue
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return nil, fmt.Errorf("could not read attachment %s: %w", entry.Name(), err)
+		}
+
+		attachments = append(attachments, Attachment{Name: entry.Name(), Size: info.Size()})
+	}
+
+	return attachments, nil
+}
+
+// AttachFile copies the file at src into the task's attachments directory.
+// If an attachment of the same name exists, a number is added to the name.
+// Returns a Tea message on success or failure.
+func (t *Task) AttachFile(v *viper.Viper, p Project, src string) tea.Cmd {
+	return func() tea.Msg {
+		root, err := os.OpenRoot(config.Get(v).StoragePath)
+		if err != nil {
+			return AttachFileErrorMsg{fmt.Errorf("could not open storage directory: %w", err)}
+		}
+		defer root.Close() //nolint:errcheck
+
+		in, err := os.Open(filepath.Clean(src))
+		if err != nil {
+			return AttachFileErrorMsg{err}
+		}
+		defer in.Close() //nolint:errcheck
+
+		dir := t.AttachmentsDir(p)
+		if err := root.MkdirAll(dir, 0o700); err != nil {
+			return AttachFileErrorMsg{err}
+		}
+
+		name, out, err := createAttachment(root, dir, filepath.Base(src))
+		if err != nil {
+			return AttachFileErrorMsg{err}
+		}
+
+		_, copyErr := io.Copy(out, in)
+		if err := errors.Join(copyErr, out.Close()); err != nil {
+			_ = root.Remove(name)
+			return AttachFileErrorMsg{fmt.Errorf("could not copy %s: %w", src, err)}
+		}
+
+		return AttachFileDoneMsg{Task: *t, Path: name}
+	}
+}
+
+// createAttachment creates a new file for an attachment called base in dir.
+// Existing files are never overwritten, instead a number is appended to
+// the name, e.g. "notes-1.txt". Returns the path of the created file.
+func createAttachment(root *os.Root, dir, base string) (string, *os.File, error) {
+	ext := filepath.Ext(base)
+	stem := strings.TrimSuffix(base, ext)
+
+	for i := 0; ; i++ {
+		name := base
+		if i > 0 {
+			name = fmt.Sprintf("%s-%d%s", stem, i, ext)
+		}
+
+		path := filepath.Join(dir, name)
+		f, err := root.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o600)
+		if errors.Is(err, fs.ErrExist) {
+			continue
+		}
+		if err != nil {
+			return "", nil, err
+		}
+
+		return path, f, nil
+	}
+}
+
+// AttachmentsToMarkdown returns a Markdown section listing the given
+// attachments along with their sizes, or an empty string if there are none.
+func AttachmentsToMarkdown(attachments []Attachment) string {
+	if len(attachments) == 0 {
+		return ""
+	}
+
+	var content strings.Builder
+
+	content.WriteString("### Attachments\n\n")
+	for _, a := range attachments {
+		fmt.Fprintf(&content, "- %s\n", a.String())
+	}
+	content.WriteString("\n")
+
+	return content.String()
+}
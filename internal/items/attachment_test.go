@@ -0,0 +1,116 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package items
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/spf13/viper"
+)
+
+func TestFormatSize(t *testing.T) {
+	tests := []struct {
+		size int64
+		want string
+	}{
+		{0, "0 B"},
+		{1023, "1023 B"},
+		{1024, "1.0 KiB"},
+		{1536, "1.5 KiB"},
+		{5 * 1024 * 1024, "5.0 MiB"},
+	}
+
+	for _, tt := range tests {
+		if got := FormatSize(tt.size); got != tt.want {
+			t.Errorf("FormatSize(%d) = %q, want %q", tt.size, got, tt.want)
+		}
+	}
+}
+
+func TestTask_AttachFile(t *testing.T) {
+	tempDir := t.TempDir()
+	v := viper.New()
+	v.Set("storage.path", tempDir)
+
+	project := Project{ID: "test-project"}
+	if err := os.Mkdir(filepath.Join(tempDir, project.ID), 0o750); err != nil {
+		t.Fatal(err)
+	}
+
+	src := filepath.Join(t.TempDir(), "notes.txt")
+	if err := os.WriteFile(src, []byte("hello"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	task := &Task{ID: uuid.NewString(), Title: "Test Task"}
+
+	attachments, err := task.Attachments(v, project)
+	if err != nil || len(attachments) != 0 {
+		t.Fatalf("Expected no attachments, got %v (%v)", attachments, err)
+	}
+
+	for _, want := range []string{"notes.txt", "notes-1.txt"} {
+		msg := task.AttachFile(v, project, src)()
+		done, ok := msg.(AttachFileDoneMsg)
+		if !ok {
+			t.Fatalf("Expected AttachFileDoneMsg, got %T: %v", msg, msg)
+		}
+
+		if done.Path != filepath.Join(task.AttachmentsDir(project), want) {
+			t.Errorf("Unexpected attachment path %q", done.Path)
+		}
+
+		data, err := os.ReadFile(filepath.Join(tempDir, done.Path))
+		if err != nil || string(data) != "hello" {
+			t.Errorf("Unexpected attachment content %q (%v)", data, err)
+		}
+	}
+
+	attachments, err = task.Attachments(v, project)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Attachment{{Name: "notes-1.txt", Size: 5}, {Name: "notes.txt", Size: 5}}
+	if len(attachments) != len(want) {
+		t.Fatalf("Expected %v, got %v", want, attachments)
+	}
+	for i := range want {
+		if attachments[i] != want[i] {
+			t.Errorf("Expected %v, got %v", want[i], attachments[i])
+		}
+	}
+
+	markdown := AttachmentsToMarkdown(attachments)
+	if !strings.Contains(markdown, "### Attachments") || !strings.Contains(markdown, "- notes.txt (5 B)") {
+		t.Errorf("Unexpected markdown:\n%s", markdown)
+	}
+
+	if msg := task.AttachFile(v, project, filepath.Join(tempDir, "missing"))(); msg == nil {
+		t.Error("Expected an error message for a missing file")
+	} else if _, ok := msg.(AttachFileErrorMsg); !ok {
+		t.Errorf("Expected AttachFileErrorMsg, got %T", msg)
+	}
+}
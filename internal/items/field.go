@@ -0,0 +1,142 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package items
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Types of custom fields.
+const (
+	FieldText   = "text"
+	FieldNumber = "number"
+	FieldEnum   = "enum"
+	FieldDate   = "date"
+)
+
+// FieldDef defines a custom field of the tasks in a project.
+type FieldDef struct {
+	Name string `json:"name"`
+	// Type is one of FieldText, FieldNumber, FieldEnum and FieldDate.
+	Type string `json:"type"`
+	// Options lists the allowed values of an enum field.
+	Options []string `json:"options,omitempty"`
+}
+
+// FieldDefs is the list of custom fields of a project.
+type FieldDefs []FieldDef
+
+// Validate returns an error if the value is not valid for the field.
+// Empty values are always valid, as custom fields are optional.
+// Dates are expected in time.DateOnly format.
+func (f FieldDef) Validate(value string) error {
+	if value == "" {
+		return nil
+	}
+
+	switch f.Type {
+	case FieldNumber:
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			return fmt.Errorf("%s must be a number", f.Name)
+		}
+	case FieldEnum:
+		if !slices.Contains(f.Options, value) {
+			return fmt.Errorf("%s must be one of %s", f.Name, strings.Join(f.Options, ", "))
+		}
+	case FieldDate:
+		if _, err := time.Parse(time.DateOnly, value); err != nil {
+			return fmt.Errorf("%s must be a date like 2026-02-14", f.Name)
+		}
+	}
+
+	return nil
+}
+
+// FieldFilterKey returns the name of a custom field as used in
+// "name:value" filter terms, lowercased and with spaces replaced
+// by underscores.
+func FieldFilterKey(name string) string {
+	return strings.ReplaceAll(strings.ToLower(name), " ", "_")
+}
+
+// ParseFieldDefs parses custom field definitions, one per line in the
+// form "name: type", e.g. "Points: number". Enum fields list their
+// options after the type, e.g. "Env: enum dev, staging, prod".
+// Empty lines are skipped.
+func ParseFieldDefs(str string) (FieldDefs, error) {
+	var defs FieldDefs
+	for line := range strings.SplitSeq(str, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		name, spec, found := strings.Cut(line, ":")
+		name = strings.TrimSpace(name)
+		if !found || name == "" {
+			return nil, fmt.Errorf("invalid field definition: %q", line)
+		}
+
+		if slices.ContainsFunc(defs, func(d FieldDef) bool { return strings.EqualFold(d.Name, name) }) {
+			return nil, fmt.Errorf("duplicate field: %q", name)
+		}
+
+		fieldType, options, _ := strings.Cut(strings.TrimSpace(spec), " ")
+		def := FieldDef{Name: name, Type: strings.ToLower(fieldType)}
+
+		switch def.Type {
+		case FieldText, FieldNumber, FieldDate:
+		case FieldEnum:
+			for option := range strings.SplitSeq(options, ",") {
+				if option = strings.TrimSpace(option); option != "" && !slices.Contains(def.Options, option) {
+					def.Options = append(def.Options, option)
+				}
+			}
+			if len(def.Options) == 0 {
+				return nil, fmt.Errorf("enum field %q needs options", name)
+			}
+		default:
+			return nil, fmt.Errorf("unknown field type %q (valid: text, number, enum, date)", fieldType)
+		}
+
+		defs = append(defs, def)
+	}
+
+	return defs, nil
+}
+
+// String returns the field definitions in the format read by ParseFieldDefs.
+func (d FieldDefs) String() string {
+	lines := make([]string, 0, len(d))
+	for _, def := range d {
+		line := def.Name + ": " + def.Type
+		if def.Type == FieldEnum {
+			line += " " + strings.Join(def.Options, ", ")
+		}
+		lines = append(lines, line)
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,90 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package items
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseFieldDefs(t *testing.T) {
+	input := "Points: number\n\nEnv: enum dev, prod, dev\nReview date: DATE\nNotes: text"
+
+	defs, err := ParseFieldDefs(input)
+	if err != nil {
+		t.Fatalf("ParseFieldDefs returned an error: %v", err)
+	}
+
+	want := FieldDefs{
+		{Name: "Points", Type: FieldNumber},
+		{Name: "Env", Type: FieldEnum, Options: []string{"dev", "prod"}},
+		{Name: "Review date", Type: FieldDate},
+		{Name: "Notes", Type: FieldText},
+	}
+	if defs.String() != want.String() {
+		t.Errorf("Expected %q, but got %q", want.String(), defs.String())
+	}
+
+	again, err := ParseFieldDefs(defs.String())
+	if err != nil || again.String() != defs.String() {
+		t.Errorf("Expected String to round trip, but got %q (%v)", again.String(), err)
+	}
+
+	for _, invalid := range []string{"Points", ": text", "Points: integer", "Env: enum", "a: text\nA: number"} {
+		if _, err := ParseFieldDefs(invalid); err == nil {
+			t.Errorf("Expected ParseFieldDefs(%q) to fail", invalid)
+		}
+	}
+}
+
+func TestFieldDef_Validate(t *testing.T) {
+	tests := []struct {
+		def   FieldDef
+		value string
+		valid bool
+	}{
+		{FieldDef{Name: "Notes", Type: FieldText}, "anything", true},
+		{FieldDef{Name: "Points", Type: FieldNumber}, "2.5", true},
+		{FieldDef{Name: "Points", Type: FieldNumber}, "two", false},
+		{FieldDef{Name: "Points", Type: FieldNumber}, "", true},
+		{FieldDef{Name: "Env", Type: FieldEnum, Options: []string{"dev", "prod"}}, "prod", true},
+		{FieldDef{Name: "Env", Type: FieldEnum, Options: []string{"dev", "prod"}}, "test", false},
+		{FieldDef{Name: "Review", Type: FieldDate}, "2026-02-14", true},
+		{FieldDef{Name: "Review", Type: FieldDate}, "14.02.2026", false},
+	}
+
+	for _, tt := range tests {
+		if err := tt.def.Validate(tt.value); (err == nil) != tt.valid {
+			t.Errorf("Validate(%q) of %s field: expected valid %t, got error %v", tt.value, tt.def.Type, tt.valid, err)
+		}
+	}
+}
+
+func TestTask_FilterValueFields(t *testing.T) {
+	task := &Task{Title: "Deploy", Fields: map[string]string{"Env": "prod", "Review date": "2026-02-14"}}
+	value := task.FilterValue()
+
+	for _, term := range []string{"env:prod", "review_date:2026-02-14"} {
+		if !strings.Contains(value, term) {
+			t.Errorf("Expected filter value %q to contain %q", value, term)
+		}
+	}
+}
@@ -26,6 +26,8 @@ import (
 	"time"
 
 	"github.com/charmbracelet/bubbles/list"
+	"github.com/handlebargh/yatto/internal/config"
+	"github.com/spf13/viper"
 )
 
 const ellipses = "..."
@@ -49,6 +51,197 @@ func IsToday(t *time.Time) bool {
 	return y1 == y2 && m1 == m2 && d1 == d2
 }
 
+// AgendaBucket groups open tasks by the urgency of their due date.
+type AgendaBucket int
+
+const (
+	// AgendaOverdue holds tasks whose due date has passed.
+	AgendaOverdue AgendaBucket = iota
+
+	// AgendaToday holds tasks due later today.
+	AgendaToday
+
+	// AgendaThisWeek holds tasks due within the next seven days.
+	AgendaThisWeek
+
+	// AgendaLater holds tasks due after the next seven days or without a due date.
+	AgendaLater
+)
+
+// String returns the display name of the agenda bucket.
+func (b AgendaBucket) String() string {
+	switch b {
+	case AgendaOverdue:
+		return "Overdue"
+	case AgendaToday:
+		return "Today"
+	case AgendaThisWeek:
+		return "This Week"
+	default:
+		return "Later"
+	}
+}
+
+// AgendaBucketOf returns the agenda bucket of a task relative to now.
+func AgendaBucketOf(t *Task, now time.Time) AgendaBucket {
+	if t.DueDate == nil {
+		return AgendaLater
+	}
+
+	due := *t.DueDate
+	endOfToday := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()).AddDate(0, 0, 1)
+
+	switch {
+	case due.Before(now):
+		return AgendaOverdue
+	case due.Before(endOfToday):
+		return AgendaToday
+	case due.Before(endOfToday.AddDate(0, 0, 7)):
+		return AgendaThisWeek
+	default:
+		return AgendaLater
+	}
+}
+
+// Urgency tells how pressing the due date of a task is.
+type Urgency int
+
+const (
+	// UrgencyNone applies to tasks without a due date.
+	UrgencyNone Urgency = iota
+
+	// UrgencyLater applies to tasks due after the warning period.
+	UrgencyLater
+
+	// UrgencySoon applies to tasks due today or within the warning period.
+	UrgencySoon
+
+	// UrgencyOverdue applies to tasks whose due date has passed.
+	UrgencyOverdue
+)
+
+// DueSettings controls when tasks count as due soon or overdue,
+// as set by the "due.soon" and "due.all_day" settings.
+type DueSettings struct {
+	// Soon is how long ahead of their due date tasks are due soon,
+	// in addition to tasks due today.
+	Soon time.Duration
+
+	// AllDay treats due dates without a time of day, i.e. at midnight,
+	// as due by the end of that day.
+	AllDay bool
+}
+
+// DueSettingsFromConfig returns the due settings of the configuration.
+func DueSettingsFromConfig(v *viper.Viper) DueSettings {
+	cfg := config.Get(v)
+
+	return DueSettings{
+		Soon:   cfg.DueSoon,
+		AllDay: cfg.DueAllDay,
+	}
+}
+
+// Deadline returns the time the task is due by, honoring AllDay.
+// It returns the zero time if the task has no due date.
+func (s DueSettings) Deadline(t *Task) time.Time {
+	if t.DueDate == nil {
+		return time.Time{}
+	}
+
+	due := *t.DueDate
+	if s.AllDay && due.Hour() == 0 && due.Minute() == 0 && due.Second() == 0 {
+		return due.AddDate(0, 0, 1)
+	}
+
+	return due
+}
+
+// Urgency returns the urgency of the task relative to now.
+func (s DueSettings) Urgency(t *Task, now time.Time) Urgency {
+	if t.DueDate == nil {
+		return UrgencyNone
+	}
+
+	deadline := s.Deadline(t)
+	y1, m1, d1 := t.DueDate.Date()
+	y2, m2, d2 := now.Date()
+	dueToday := y1 == y2 && m1 == m2 && d1 == d2
+
+	switch {
+	case deadline.Before(now):
+		return UrgencyOverdue
+	case dueToday || deadline.Sub(now) <= s.Soon:
+		return UrgencySoon
+	default:
+		return UrgencyLater
+	}
+}
+
+// DueFilter restricts a task list to tasks with due dates in a range.
+type DueFilter int
+
+const (
+	// DueAny matches every task.
+	DueAny DueFilter = iota
+
+	// DueOverdue matches open tasks whose due date has passed.
+	DueOverdue
+
+	// DueToday matches tasks due on the current day.
+	DueToday
+
+	// DueThisWeek matches tasks due today or within the next seven days.
+	DueThisWeek
+
+	// DueNone matches tasks without a due date.
+	DueNone
+)
+
+// DueFilters lists all due filters in menu order.
+var DueFilters = []DueFilter{DueAny, DueOverdue, DueToday, DueThisWeek, DueNone}
+
+// String returns the display name of the due filter.
+func (f DueFilter) String() string {
+	switch f {
+	case DueOverdue:
+		return "overdue"
+	case DueToday:
+		return "due today"
+	case DueThisWeek:
+		return "due this week"
+	case DueNone:
+		return "no due date"
+	default:
+		return "any due date"
+	}
+}
+
+// Match reports whether the task passes the due filter relative to now.
+func (f DueFilter) Match(t *Task, now time.Time) bool {
+	if f == DueAny {
+		return true
+	}
+	if t.DueDate == nil {
+		return f == DueNone
+	}
+
+	due := *t.DueDate
+	startOfToday := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	endOfToday := startOfToday.AddDate(0, 0, 1)
+
+	switch f {
+	case DueOverdue:
+		return !t.Completed && due.Before(now)
+	case DueToday:
+		return !due.Before(startOfToday) && due.Before(endOfToday)
+	case DueThisWeek:
+		return !due.Before(startOfToday) && due.Before(endOfToday.AddDate(0, 0, 7))
+	default:
+		return false
+	}
+}
+
 // TaskFilterFunc filters tasks based on a search term using AND logic.
 // It returns a slice of list.Rank containing only items where ALL space-separated
 // tokens in the search term are found (case-insensitive substring match).
@@ -94,3 +287,43 @@ func TaskFilterFunc(term string, targets []string) []list.Rank {
 
 	return ranks
 }
+
+// SearchScore ranks a task against a search term using AND logic.
+// Every space-separated token must be found (case-insensitive) in the
+// task's title, labels or description, otherwise the score is 0.
+// Title matches weigh more than label matches, which weigh more than
+// description matches. Titles starting with a token score extra.
+func SearchScore(t *Task, term string) int {
+	searchTokens := strings.Fields(strings.ToLower(term))
+	if len(searchTokens) == 0 {
+		return 0
+	}
+
+	title := strings.ToLower(t.Title)
+	labels := strings.ToLower(t.Labels.String())
+	description := strings.ToLower(t.Description)
+
+	score := 0
+	for _, token := range searchTokens {
+		tokenScore := 0
+		if strings.Contains(title, token) {
+			tokenScore += 4
+			if strings.HasPrefix(title, token) {
+				tokenScore += 2
+			}
+		}
+		if strings.Contains(labels, token) {
+			tokenScore += 2
+		}
+		if strings.Contains(description, token) {
+			tokenScore++
+		}
+
+		if tokenScore == 0 {
+			return 0
+		}
+		score += tokenScore
+	}
+
+	return score
+}
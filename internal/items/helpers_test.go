@@ -21,6 +21,7 @@
 package items
 
 import (
+	"slices"
 	"testing"
 	"time"
 )
@@ -88,3 +89,137 @@ func TestTaskFilterFunc(t *testing.T) {
 		}
 	})
 }
+
+func TestAgendaBucketOf(t *testing.T) {
+	now := time.Date(2026, time.March, 10, 12, 0, 0, 0, time.Local)
+	at := func(d time.Duration) *Task {
+		due := now.Add(d)
+		return &Task{DueDate: &due}
+	}
+
+	cases := []struct {
+		name     string
+		task     *Task
+		expected AgendaBucket
+	}{
+		{"no due date", &Task{}, AgendaLater},
+		{"past due date", at(-time.Hour), AgendaOverdue},
+		{"later today", at(time.Hour), AgendaToday},
+		{"tomorrow", at(24 * time.Hour), AgendaThisWeek},
+		{"in six days", at(6 * 24 * time.Hour), AgendaThisWeek},
+		{"in two weeks", at(14 * 24 * time.Hour), AgendaLater},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := AgendaBucketOf(tc.task, now); got != tc.expected {
+				t.Errorf("expected %s, but got %s", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestDueSettings_Urgency(t *testing.T) {
+	now := time.Date(2026, time.March, 10, 12, 0, 0, 0, time.Local)
+	at := func(d time.Duration) *Task {
+		due := now.Add(d)
+		return &Task{DueDate: &due}
+	}
+	midnight := func(days int) *Task {
+		due := time.Date(2026, time.March, 10+days, 0, 0, 0, 0, time.Local)
+		return &Task{DueDate: &due}
+	}
+
+	cases := []struct {
+		name     string
+		settings DueSettings
+		task     *Task
+		expected Urgency
+	}{
+		{"no due date", DueSettings{}, &Task{}, UrgencyNone},
+		{"past due date", DueSettings{}, at(-time.Hour), UrgencyOverdue},
+		{"later today", DueSettings{}, at(time.Hour), UrgencySoon},
+		{"tomorrow", DueSettings{}, at(24 * time.Hour), UrgencyLater},
+		{"tomorrow within warning", DueSettings{Soon: 48 * time.Hour}, at(24 * time.Hour), UrgencySoon},
+		{"after warning", DueSettings{Soon: 48 * time.Hour}, at(72 * time.Hour), UrgencyLater},
+		{"midnight today", DueSettings{}, midnight(0), UrgencyOverdue},
+		{"midnight today all day", DueSettings{AllDay: true}, midnight(0), UrgencySoon},
+		{"midnight yesterday all day", DueSettings{AllDay: true}, midnight(-1), UrgencyOverdue},
+		{"midnight tomorrow all day", DueSettings{AllDay: true}, midnight(1), UrgencyLater},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.settings.Urgency(tc.task, now); got != tc.expected {
+				t.Errorf("expected %d, but got %d", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestDueFilter_Match(t *testing.T) {
+	now := time.Date(2026, time.March, 10, 12, 0, 0, 0, time.Local)
+	at := func(d time.Duration) *Task {
+		due := now.Add(d)
+		return &Task{DueDate: &due}
+	}
+	completed := at(-time.Hour)
+	completed.Completed = true
+
+	cases := []struct {
+		name     string
+		task     *Task
+		expected []DueFilter
+	}{
+		{"no due date", &Task{}, []DueFilter{DueAny, DueNone}},
+		{"yesterday", at(-24 * time.Hour), []DueFilter{DueAny, DueOverdue}},
+		{"earlier today", at(-time.Hour), []DueFilter{DueAny, DueOverdue, DueToday, DueThisWeek}},
+		{"completed earlier today", completed, []DueFilter{DueAny, DueToday, DueThisWeek}},
+		{"later today", at(time.Hour), []DueFilter{DueAny, DueToday, DueThisWeek}},
+		{"in six days", at(6 * 24 * time.Hour), []DueFilter{DueAny, DueThisWeek}},
+		{"in two weeks", at(14 * 24 * time.Hour), []DueFilter{DueAny}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			for _, f := range DueFilters {
+				expected := slices.Contains(tc.expected, f)
+				if got := f.Match(tc.task, now); got != expected {
+					t.Errorf("%s: expected %t, but got %t", f, expected, got)
+				}
+			}
+		})
+	}
+}
+
+func TestSearchScore(t *testing.T) {
+	task := &Task{
+		Title:       "Write report",
+		Description: "Quarterly numbers for the board",
+		Labels:      Labels{"work", "finance"},
+	}
+
+	cases := []struct {
+		name     string
+		term     string
+		expected int
+	}{
+		{"empty term", "", 0},
+		{"no match", "groceries", 0},
+		{"title prefix", "write", 6},
+		{"title", "report", 4},
+		{"label", "finance", 2},
+		{"description", "quarterly", 1},
+		{"case insensitive", "REPORT", 4},
+		{"all tokens", "report work board", 7},
+		{"missing token", "report groceries", 0},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := SearchScore(task, tc.term); got != tc.expected {
+				t.Errorf("expected %d, but got %d", tc.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,271 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package items
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/handlebargh/yatto/internal/config"
+	"github.com/handlebargh/yatto/internal/storage"
+	"github.com/spf13/viper"
+)
+
+// indexVersion is the version of the format of the task index.
+// Indexes of other versions are rebuilt.
+const indexVersion = 3
+
+// indexFile is the content of storage.IndexFile.
+type indexFile struct {
+	Version int                   `json:"version"`
+	Files   map[string]indexEntry `json:"files"`
+}
+
+// indexEntry summarizes a task file. The task is parsed again
+// if the modification time or the size of the file changed.
+type indexEntry struct {
+	ModTime time.Time `json:"mtime"`
+	Size    int64     `json:"size"`
+	Task    Task      `json:"task"`
+}
+
+// taskIndex holds the task index of the storage directory at path
+// once it was loaded. Task files are keyed by their slash-separated
+// path relative to the storage directory. Archived tasks are not indexed.
+var taskIndex = struct {
+	sync.Mutex
+	path  string
+	files map[string]indexEntry
+	dirty bool
+}{}
+
+// indexSummary returns the part of the task kept in the task index.
+func (t *Task) indexSummary() Task {
+	return Task{
+		ID:          t.ID,
+		Title:       t.Title,
+		Description: t.Description,
+		Priority:    t.Priority,
+		Labels:      t.Labels,
+		InProgress:  t.InProgress,
+		Completed:   t.Completed,
+		DueDate:     t.DueDate,
+		Estimate:    t.Estimate,
+		Reminders:   t.Reminders,
+		UpdatedAt:   t.UpdatedAt,
+	}
+}
+
+// IndexedTasks returns summaries of the tasks of all projects by project
+// ID, ordered by file name. They hold the ID, title, description, labels,
+// priority, state, due date, estimate, reminders and time of the last
+// change of each task.
+//
+// The summaries are taken from the task index, which is reconciled with
+// the storage directory first: task files that were added or changed
+// since they were indexed, e.g. by a pull, are parsed again, and removed
+// ones are dropped. The index is saved as storage.IndexFile if it changed.
+//
+// Task files that cannot be read or parsed are skipped and reported in a
+// SkippedFilesError, which is returned along with the remaining tasks.
+// Any other error means that the storage directory could not be read.
+func IndexedTasks(v *viper.Viper) (map[string][]Task, error) {
+	taskIndex.Lock()
+	defer taskIndex.Unlock()
+
+	storagePath := config.Get(v).StoragePath
+	root, err := os.OpenRoot(storagePath)
+	if err != nil {
+		return nil, fmt.Errorf("could not open storage directory: %w", err)
+	}
+	defer root.Close() //nolint:errcheck
+
+	if taskIndex.files == nil || taskIndex.path != storagePath {
+		taskIndex.path = storagePath
+		taskIndex.files = readIndexFile(root)
+	}
+
+	skipped, err := reconcileIndex(root)
+	if err != nil {
+		return nil, err
+	}
+
+	if taskIndex.dirty {
+		// The index is only a cache, so failing to save it is not an error.
+		if data, err := json.Marshal(indexFile{Version: indexVersion, Files: taskIndex.files}); err == nil {
+			if storage.AtomicWrite(root, storage.IndexFile, data, 0o600) == nil {
+				taskIndex.dirty = false
+			}
+		}
+	}
+
+	names := make([]string, 0, len(taskIndex.files))
+	for name := range taskIndex.files {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	tasks := make(map[string][]Task)
+	for _, name := range names {
+		projectID := path.Dir(name)
+		tasks[projectID] = append(tasks[projectID], taskIndex.files[name].Task)
+	}
+
+	if len(skipped) > 0 {
+		return tasks, &SkippedFilesError{Files: skipped}
+	}
+
+	return tasks, nil
+}
+
+// readIndexFile reads the task index saved in the storage directory.
+// A missing, unreadable or outdated index results in an empty one.
+func readIndexFile(root *os.Root) map[string]indexEntry {
+	data, err := root.ReadFile(storage.IndexFile)
+	if err != nil {
+		return make(map[string]indexEntry)
+	}
+
+	var index indexFile
+	if err := json.Unmarshal(data, &index); err != nil || index.Version != indexVersion || index.Files == nil {
+		return make(map[string]indexEntry)
+	}
+
+	return index.Files
+}
+
+// reconcileIndex brings the loaded task index up to date with the task
+// files of all project directories and returns the files that could not
+// be read or parsed.
+func reconcileIndex(root *os.Root) ([]CorruptFileError, error) {
+	dirs, err := fs.ReadDir(root.FS(), ".")
+	if err != nil {
+		return nil, fmt.Errorf("could not read storage directory: %w", err)
+	}
+
+	var (
+		skipped []CorruptFileError
+		stale   []string
+		infos   = make(map[string]fs.FileInfo)
+		found   = make(map[string]bool)
+	)
+
+	for _, dir := range dirs {
+		name := dir.Name()
+		if !dir.IsDir() || name == ".git" || name == ".jj" || name == storage.TemplatesDir {
+			continue
+		}
+
+		files, err := fs.ReadDir(root.FS(), name)
+		if err != nil {
+			skipped = append(skipped, CorruptFileError{Path: name, Err: err})
+			continue
+		}
+
+		for _, file := range files {
+			if file.IsDir() || !UUIDRegex.MatchString(file.Name()) {
+				continue
+			}
+
+			filePath := path.Join(name, file.Name())
+			info, err := file.Info()
+			if err != nil {
+				skipped = append(skipped, CorruptFileError{Path: filePath, Err: err})
+				continue
+			}
+			found[filePath] = true
+
+			entry, ok := taskIndex.files[filePath]
+			if !ok || !entry.ModTime.Equal(info.ModTime()) || entry.Size != info.Size() {
+				stale = append(stale, filePath)
+				infos[filePath] = info
+			}
+		}
+	}
+
+	for name := range taskIndex.files {
+		if !found[name] {
+			delete(taskIndex.files, name)
+			taskIndex.dirty = true
+		}
+	}
+
+	results := storage.ScanFiles(root, stale, ParseTask)
+
+	for _, r := range results {
+		taskIndex.dirty = true
+
+		if r.Err != nil {
+			delete(taskIndex.files, r.Path)
+			skipped = append(skipped, CorruptFileError{Path: r.Path, Err: r.Err})
+			continue
+		}
+
+		info := infos[r.Path]
+		taskIndex.files[r.Path] = indexEntry{
+			ModTime: info.ModTime(),
+			Size:    info.Size(),
+			Task:    r.Value.indexSummary(),
+		}
+	}
+
+	return skipped, nil
+}
+
+// updateIndex updates the entry of the task file at name, relative to
+// root, in the loaded task index after the file was written. If t is nil,
+// the file was removed. Archived tasks are not indexed.
+func updateIndex(root *os.Root, name string, t *Task) {
+	taskIndex.Lock()
+	defer taskIndex.Unlock()
+
+	if taskIndex.files == nil || taskIndex.path != root.Name() {
+		return
+	}
+
+	name = filepath.ToSlash(name)
+
+	info, err := root.Stat(name)
+	if t == nil || t.Archived || errors.Is(err, fs.ErrNotExist) {
+		delete(taskIndex.files, name)
+		taskIndex.dirty = true
+		return
+	}
+	if err != nil {
+		// The entry is updated on the next reconciliation.
+		return
+	}
+
+	taskIndex.dirty = true
+	taskIndex.files[name] = indexEntry{
+		ModTime: info.ModTime(),
+		Size:    info.Size(),
+		Task:    t.indexSummary(),
+	}
+}
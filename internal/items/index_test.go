@@ -0,0 +1,127 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package items
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/handlebargh/yatto/internal/storage"
+	"github.com/spf13/viper"
+)
+
+func TestIndexedTasks(t *testing.T) {
+	tempDir := t.TempDir()
+	v := viper.New()
+	v.Set("storage.path", tempDir)
+
+	project := Project{ID: "test-project", Title: "Test Project"}
+	projectDir := filepath.Join(tempDir, project.ID)
+	_ = os.Mkdir(projectDir, 0o750)
+
+	task1 := &Task{ID: uuid.NewString(), Title: "Task 1", Description: "Some details"}
+	task2 := &Task{ID: uuid.NewString(), Title: "Task 2", Comments: []Comment{{Body: "Not indexed"}}}
+	path1 := filepath.Join(projectDir, task1.ID+".json")
+	_ = os.WriteFile(path1, task1.MarshalTask(), 0o600)
+	_ = os.WriteFile(filepath.Join(projectDir, task2.ID+".json"), task2.MarshalTask(), 0o600)
+
+	indexed, err := IndexedTasks(v)
+	if err != nil {
+		t.Fatalf("IndexedTasks returned an error: %v", err)
+	}
+	if got := len(indexed[project.ID]); got != 2 {
+		t.Fatalf("Expected 2 indexed tasks, but got %d", got)
+	}
+	for _, task := range indexed[project.ID] {
+		if task.ID == task1.ID && task.Description != task1.Description {
+			t.Errorf("Expected the description to be indexed, but got %q", task.Description)
+		}
+		if task.Comments != nil {
+			t.Errorf("Expected comments not to be indexed, but got %v", task.Comments)
+		}
+	}
+
+	if _, err := os.Stat(filepath.Join(tempDir, storage.IndexFile)); err != nil {
+		t.Errorf("Expected the index to be saved, but got %v", err)
+	}
+
+	t.Run("reconciles changed and removed files", func(t *testing.T) {
+		task1.Title = "Task 1 changed"
+		_ = os.WriteFile(path1, task1.MarshalTask(), 0o600)
+		later := time.Now().Add(time.Minute)
+		_ = os.Chtimes(path1, later, later)
+		_ = os.Remove(filepath.Join(projectDir, task2.ID+".json"))
+
+		indexed, err := IndexedTasks(v)
+		if err != nil {
+			t.Fatalf("IndexedTasks returned an error: %v", err)
+		}
+		if len(indexed[project.ID]) != 1 || indexed[project.ID][0].Title != task1.Title {
+			t.Errorf("Expected only the changed task, but got %v", indexed[project.ID])
+		}
+	})
+
+	t.Run("is updated on writes", func(t *testing.T) {
+		task3 := &Task{ID: uuid.NewString(), Title: "Task 3"}
+		if msg, ok := task3.WriteTaskJSON(v, project, "create")().(WriteTaskJSONDoneMsg); !ok {
+			t.Fatalf("Expected the task to be written, but got %v", msg)
+		}
+
+		_, ok := taskIndex.files[filepath.ToSlash(task3.Path(project))]
+		if !ok {
+			t.Errorf("Expected the written task to be indexed")
+		}
+
+		_ = task3.DeleteTaskFromFS(v, project)()
+		if _, ok := taskIndex.files[filepath.ToSlash(task3.Path(project))]; ok {
+			t.Errorf("Expected the deleted task to be removed from the index")
+		}
+	})
+
+	t.Run("reports corrupt files", func(t *testing.T) {
+		corruptPath := filepath.Join(project.ID, uuid.NewString()+".json")
+		_ = os.WriteFile(filepath.Join(tempDir, corruptPath), []byte("{"), 0o600)
+
+		indexed, err := IndexedTasks(v)
+		var skipped *SkippedFilesError
+		if !errors.As(err, &skipped) || len(skipped.Files) != 1 {
+			t.Errorf("Expected the corrupt task to be reported, but got %v", err)
+		}
+		if len(indexed[project.ID]) != 1 {
+			t.Errorf("Expected the other tasks to be returned, but got %v", indexed[project.ID])
+		}
+	})
+
+	t.Run("loads the saved index", func(t *testing.T) {
+		taskIndex.Lock()
+		taskIndex.files = nil
+		taskIndex.Unlock()
+
+		indexed, _ := IndexedTasks(v)
+		if len(indexed[project.ID]) != 1 || indexed[project.ID][0].Title != task1.Title {
+			t.Errorf("Expected the task of the saved index, but got %v", indexed[project.ID])
+		}
+	})
+}
@@ -0,0 +1,88 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package items
+
+import (
+	"errors"
+	"maps"
+	"path"
+	"slices"
+	"time"
+
+	"github.com/handlebargh/yatto/internal/hooks"
+	"github.com/handlebargh/yatto/internal/storage"
+	"github.com/spf13/viper"
+)
+
+// NotifyOverdue runs the task_overdue hooks for the open tasks that are
+// overdue at the given time. The hooks are run once per task and due
+// date; the tasks they were run for are kept in the storage state, so
+// that moving the due date of a task arms its hooks again.
+func NotifyOverdue(v *viper.Viper, now time.Time) error {
+	if !hooks.Configured(v, hooks.TaskOverdue) {
+		return nil
+	}
+
+	indexed, err := IndexedTasks(v)
+	var skipped *SkippedFilesError
+	if err != nil && !errors.As(err, &skipped) {
+		return err
+	}
+
+	state, err := storage.ReadState(v)
+	if err != nil {
+		return err
+	}
+
+	due := DueSettingsFromConfig(v)
+	notified := make(map[string]time.Time)
+	var errs []error
+
+	for _, projectID := range slices.Sorted(maps.Keys(indexed)) {
+		for _, t := range indexed[projectID] {
+			if t.Completed || due.Urgency(&t, now) != UrgencyOverdue {
+				continue
+			}
+
+			if at, ok := state.OverdueNotified[t.ID]; ok && at.Equal(*t.DueDate) {
+				notified[t.ID] = at
+				continue
+			}
+
+			// The index only holds a summary, so pass the full task if possible.
+			payload := t.MarshalTask()
+			if full, err := ReadTaskFiles(v, []string{path.Join(projectID, t.ID+".json")}); err == nil && len(full) == 1 {
+				payload = full[0].MarshalTask()
+			}
+
+			// Failed hooks are not retried, as they would fail on every check.
+			errs = append(errs, hooks.Run(v, hooks.TaskOverdue, projectID, payload))
+			notified[t.ID] = *t.DueDate
+		}
+	}
+
+	if !maps.EqualFunc(notified, state.OverdueNotified, time.Time.Equal) {
+		state.OverdueNotified = notified
+		errs = append(errs, storage.WriteState(v, state))
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,92 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package items
+
+import (
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/spf13/viper"
+)
+
+func TestNotifyOverdue(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the hooks use sh")
+	}
+
+	tempDir := t.TempDir()
+	out := filepath.Join(t.TempDir(), "out")
+	v := viper.New()
+	v.Set("storage.path", tempDir)
+	v.Set("hooks.task_overdue", []string{`tr -d '[:space:]' >> "` + out + `"; echo >> "` + out + `"`})
+
+	projectDir := filepath.Join(tempDir, "test-project")
+	_ = os.Mkdir(projectDir, 0o750)
+
+	now := time.Now()
+	past := now.Add(-time.Hour)
+	future := now.Add(time.Hour)
+	overdue := &Task{ID: uuid.NewString(), Title: "Overdue", DueDate: &past}
+	done := &Task{ID: uuid.NewString(), Title: "Done", DueDate: &past, Completed: true}
+	later := &Task{ID: uuid.NewString(), Title: "Later", DueDate: &future}
+	for _, task := range []*Task{overdue, done, later} {
+		_ = os.WriteFile(filepath.Join(projectDir, task.ID+".json"), task.MarshalTask(), 0o600)
+	}
+
+	lines := func() []string {
+		data, _ := os.ReadFile(out)
+		return strings.Fields(string(data))
+	}
+
+	if err := NotifyOverdue(v, now); err != nil {
+		t.Fatalf("NotifyOverdue returned an error: %v", err)
+	}
+	if got := lines(); len(got) != 1 || !strings.Contains(got[0], overdue.ID) {
+		t.Fatalf("Expected the hook to run for the overdue task, but got %v", got)
+	}
+
+	t.Run("runs the hooks once per due date", func(t *testing.T) {
+		if err := NotifyOverdue(v, now); err != nil {
+			t.Fatalf("NotifyOverdue returned an error: %v", err)
+		}
+		if got := len(lines()); got != 1 {
+			t.Errorf("Expected no further hook runs, but got %d", got)
+		}
+
+		earlier := past.Add(-time.Hour)
+		overdue.DueDate = &earlier
+		path := filepath.Join(projectDir, overdue.ID+".json")
+		_ = os.WriteFile(path, overdue.MarshalTask(), 0o600)
+		_ = os.Chtimes(path, now.Add(time.Minute), now.Add(time.Minute))
+
+		if err := NotifyOverdue(v, now); err != nil {
+			t.Fatalf("NotifyOverdue returned an error: %v", err)
+		}
+		if got := len(lines()); got != 2 {
+			t.Errorf("Expected the hook to run again for the new due date, but got %d runs", got)
+		}
+	})
+}
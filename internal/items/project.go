@@ -21,16 +21,24 @@
 package items
 
 import (
+	"cmp"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/fs"
 	"os"
 	"path"
 	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/handlebargh/yatto/internal/config"
+	"github.com/handlebargh/yatto/internal/logging"
+	"github.com/handlebargh/yatto/internal/storage"
 	"github.com/mattn/go-runewidth"
 	"github.com/spf13/viper"
 )
@@ -46,7 +54,7 @@ type (
 	WriteProjectJSONErrorMsg struct{ Err error }
 
 	// ProjectDeleteDoneMsg indicates successful deletion of a project directory.
-	ProjectDeleteDoneMsg struct{}
+	ProjectDeleteDoneMsg struct{ Project Project }
 
 	// ProjectDeleteErrorMsg is returned when a project fails to delete from disk.
 	ProjectDeleteErrorMsg struct{ Err error }
@@ -71,6 +79,8 @@ type TaskStats struct {
 	Total     int
 	Completed int
 	Due       int
+	// Estimate is the summed estimate of the open tasks.
+	Estimate time.Duration
 }
 
 // Project represents a collection of tasks, identified by an ID, title, description,
@@ -81,11 +91,46 @@ type Project struct {
 	Title       string `json:"title"`
 	Description string `json:"description,omitempty"`
 	Color       string `json:"color"`
+	// Icon is an optional emoji or Nerd Font icon shown before the title.
+	Icon string `json:"icon,omitempty"`
+	// Order is the position of the project in the project list.
+	// Projects that were never moved have an Order of 0 and go last.
+	Order int `json:"order,omitempty"`
+	// Pinned projects are listed before all other projects.
+	Pinned bool `json:"pinned,omitempty"`
+	// Settings override the global configuration in the project's task list.
+	Settings *ProjectSettings `json:"settings,omitempty"`
+	// Fields are the custom fields of the project's tasks.
+	Fields FieldDefs `json:"fields,omitempty"`
+}
+
+// ProjectSettings holds settings of a single project. Unset values fall
+// back to the global configuration.
+type ProjectSettings struct {
+	// Sort is the sort mode of the task list used as long as no other
+	// sort was chosen for the project, e.g. "dueDate" or "manual".
+	Sort string `json:"sort,omitempty"`
+	// ItemHeight is the minimum number of lines of each task in the list.
+	ItemHeight   int   `json:"item_height,omitempty"`
+	ShowAuthor   *bool `json:"show_author,omitempty"`
+	ShowAssignee *bool `json:"show_assignee,omitempty"`
+	// WIPLimit is the maximum number of tasks in progress at the same time.
+	// Zero means no limit.
+	WIPLimit int `json:"wip_limit,omitempty"`
 }
 
 // FilterValue returns a string used for filtering/search, based on project title.
 func (p *Project) FilterValue() string { return p.Title }
 
+// DisplayTitle returns the title prefixed with the project's icon, if any.
+func (p *Project) DisplayTitle() string {
+	if p.Icon == "" {
+		return p.Title
+	}
+
+	return p.Icon + " " + p.Title
+}
+
 // CropDescription returns the project's description cropped to fit
 // length with a concatenated ellipses.
 func (p *Project) CropDescription(length int) string {
@@ -100,55 +145,219 @@ func (p *Project) CropDescription(length int) string {
 	return p.Description
 }
 
+// SortProjects sorts the projects for the project list: pinned projects
+// first, then by their Order. Projects without an Order keep their
+// relative position after the ordered ones.
+func SortProjects(projects []Project) {
+	slices.SortStableFunc(projects, func(x, y Project) int {
+		switch {
+		case x.Pinned && !y.Pinned:
+			return -1
+		case !x.Pinned && y.Pinned:
+			return 1
+		case x.Order == 0 && y.Order != 0:
+			return 1
+		case x.Order != 0 && y.Order == 0:
+			return -1
+		default:
+			return cmp.Compare(x.Order, y.Order)
+		}
+	})
+}
+
+// RenumberProjects sets the Order of the given projects to their position
+// in the slice, starting at 1. It returns the projects whose Order was
+// changed and therefore need to be written.
+func RenumberProjects(projects []*Project) []*Project {
+	var changed []*Project
+	for i, p := range projects {
+		if p.Order != i+1 {
+			p.Order = i + 1
+			changed = append(changed, p)
+		}
+	}
+
+	return changed
+}
+
+// ArchiveDir is the name of the directory inside a project's directory
+// that holds archived tasks.
+const ArchiveDir = "archive"
+
+// CorruptFileError describes a file in the storage directory that
+// could not be read or parsed.
+type CorruptFileError struct {
+	// Path is the path of the file relative to the storage path.
+	Path string
+	Err  error
+}
+
+func (e CorruptFileError) Error() string { return fmt.Sprintf("%s: %v", e.Path, e.Err) }
+
+func (e CorruptFileError) Unwrap() error { return e.Err }
+
+// SkippedFilesError is returned by the storage readers along with the
+// items that were read successfully if corrupt files had to be skipped.
+type SkippedFilesError struct {
+	Files []CorruptFileError
+}
+
+func (e *SkippedFilesError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "skipped %d unreadable file(s):", len(e.Files))
+	for _, f := range e.Files {
+		b.WriteString("\n- ")
+		b.WriteString(f.Error())
+	}
+
+	return b.String()
+}
+
+// CollectSkipped combines the errors returned by several storage reads.
+// The first error that is not a SkippedFilesError is returned as is.
+// Otherwise the skipped files of all errors are merged into a single
+// SkippedFilesError. It returns nil if no error occurred.
+func CollectSkipped(errs ...error) error {
+	var files []CorruptFileError
+	for _, err := range errs {
+		if err == nil {
+			continue
+		}
+
+		var skipped *SkippedFilesError
+		if !errors.As(err, &skipped) {
+			return err
+		}
+		files = append(files, skipped.Files...)
+	}
+
+	if len(files) == 0 {
+		return nil
+	}
+
+	return &SkippedFilesError{Files: files}
+}
+
 // ReadTasksFromFS reads all task files from the project's directory
-// and returns them as a slice of Task. It panics if the directory
-// or any task file cannot be read or parsed.
-func (p *Project) ReadTasksFromFS(v *viper.Viper) []Task {
-	root, err := os.OpenRoot(v.GetString("storage.path"))
+// and returns them as a slice of Task. Archived tasks are not included.
+// Task files that cannot be read or parsed are skipped and reported in a
+// SkippedFilesError, which is returned along with the remaining tasks.
+// Any other error means that the project directory could not be read.
+func (p *Project) ReadTasksFromFS(v *viper.Viper) ([]Task, error) {
+	return readTasksFromDir(v, p.ID)
+}
+
+// ReadArchivedTasksFromFS reads all task files from the project's archive
+// directory and returns them as a slice of Task marked as archived.
+// A missing archive directory results in an empty slice. Errors are
+// reported like in ReadTasksFromFS.
+func (p *Project) ReadArchivedTasksFromFS(v *viper.Viper) ([]Task, error) {
+	dir := path.Join(p.ID, ArchiveDir)
+	if !storage.FileExists(v, dir) {
+		return nil, nil
+	}
+
+	tasks, err := readTasksFromDir(v, dir)
+	for i := range tasks {
+		tasks[i].Archived = true
+	}
+
+	return tasks, err
+}
+
+// TaskFiles returns the paths of the task files in the project's
+// directory relative to the storage path without reading them, so that
+// the tasks of large projects can be read in batches with ReadTaskFiles.
+func (p *Project) TaskFiles(v *viper.Viper) ([]string, error) {
+	return taskFilesInDir(v, p.ID)
+}
+
+// readTasksFromDir reads all task files found directly in the given
+// directory relative to the storage path. Files that cannot be read
+// or parsed are skipped and reported in a SkippedFilesError.
+func readTasksFromDir(v *viper.Viper, dir string) ([]Task, error) {
+	files, err := taskFilesInDir(v, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	return ReadTaskFiles(v, files)
+}
+
+// taskFilesInDir returns the paths of the task files found directly
+// in the given directory relative to the storage path.
+func taskFilesInDir(v *viper.Viper, dir string) ([]string, error) {
+	root, err := os.OpenRoot(config.Get(v).StoragePath)
 	if err != nil {
-		panic(fmt.Errorf("could not open storage directory: %w", err))
+		return nil, fmt.Errorf("could not open storage directory: %w", err)
 	}
 	defer root.Close() //nolint:errcheck
 
-	taskFiles, err := fs.ReadDir(root.FS(), p.ID)
+	entries, err := fs.ReadDir(root.FS(), dir)
 	if err != nil {
-		panic(fmt.Errorf("could not read project directory: %w", err))
+		return nil, fmt.Errorf("could not read project directory: %w", err)
 	}
 
-	var tasks []Task
-	for _, entry := range taskFiles {
+	var files []string
+	for _, entry := range entries {
 		if entry.IsDir() || !UUIDRegex.MatchString(entry.Name()) {
 			continue
 		}
+		files = append(files, path.Join(dir, entry.Name()))
+	}
 
-		filePath := path.Join(p.ID, entry.Name())
-		fileContent, err := fs.ReadFile(root.FS(), filePath)
-		if err != nil {
-			panic(err)
-		}
+	return files, nil
+}
 
-		var task Task
-		if err := json.Unmarshal(fileContent, &task); err != nil {
-			panic(err)
+// ReadTaskFiles reads the given task files, with paths relative to the
+// storage path, and returns them as a slice of Task in the same order.
+// The files are read concurrently, see storage.ScanFiles. Files that
+// cannot be read or parsed are skipped and reported in a SkippedFilesError.
+func ReadTaskFiles(v *viper.Viper, files []string) ([]Task, error) {
+	root, err := os.OpenRoot(config.Get(v).StoragePath)
+	if err != nil {
+		return nil, fmt.Errorf("could not open storage directory: %w", err)
+	}
+	defer root.Close() //nolint:errcheck
+
+	var (
+		tasks   []Task
+		skipped []CorruptFileError
+	)
+
+	results := storage.ScanFiles(root, files, ParseTask)
+
+	for _, r := range results {
+		if r.Err != nil {
+			skipped = append(skipped, CorruptFileError{Path: r.Path, Err: r.Err})
+			continue
 		}
-		tasks = append(tasks, task)
+		tasks = append(tasks, r.Value)
 	}
 
-	return tasks
+	if len(skipped) > 0 {
+		return tasks, &SkippedFilesError{Files: skipped}
+	}
+
+	return tasks, nil
 }
 
 // DeleteProjectFromFS deletes the entire project directory and all its contents
 // from disk. Returns a Tea message indicating success or failure.
 func (p *Project) DeleteProjectFromFS(v *viper.Viper) tea.Cmd {
 	return func() tea.Msg {
-		dir := filepath.Join(v.GetString("storage.path"), p.ID)
-
-		err := os.RemoveAll(dir)
+		root, err := os.OpenRoot(config.Get(v).StoragePath)
 		if err != nil {
+			return ProjectDeleteErrorMsg{fmt.Errorf("could not open storage directory: %w", err)}
+		}
+		defer root.Close() //nolint:errcheck
+
+		if err := root.RemoveAll(p.ID); err != nil {
 			return ProjectDeleteErrorMsg{err}
 		}
+		logging.Debug("deleted project", "dir", p.ID)
 
-		return ProjectDeleteDoneMsg{}
+		return ProjectDeleteDoneMsg{*p}
 	}
 }
 
@@ -165,10 +374,16 @@ func (p *Project) MarshalProject() []byte {
 
 // WriteProjectJSON writes the given project JSON to disk as project.json
 // inside the project's directory. Ensures the directory exists.
+// JSON not matching the project schema is not written, see ValidateJSON.
 // Returns a Tea message indicating success or error.
 func (p *Project) WriteProjectJSON(v *viper.Viper, json []byte, kind string) tea.Cmd {
 	return func() tea.Msg {
-		root, err := os.OpenRoot(v.GetString("storage.path"))
+		// Invalid projects would be skipped by everyone reading the storage.
+		if err := ValidateJSON(ProjectSchema, json); err != nil {
+			return WriteProjectJSONErrorMsg{fmt.Errorf("refusing to write project %q: %w", p.Title, err)}
+		}
+
+		root, err := os.OpenRoot(config.Get(v).StoragePath)
 		if err != nil {
 			panic(fmt.Errorf("could not open storage directory: %w", err))
 		}
@@ -180,7 +395,7 @@ func (p *Project) WriteProjectJSON(v *viper.Viper, json []byte, kind string) tea
 		}
 
 		file := filepath.Join(p.ID, "project.json")
-		if err := root.WriteFile(file, json, 0o600); err != nil {
+		if err := storage.AtomicWrite(root, file, json, 0o600); err != nil {
 			return WriteProjectJSONErrorMsg{err}
 		}
 
@@ -188,55 +403,106 @@ func (p *Project) WriteProjectJSON(v *viper.Viper, json []byte, kind string) tea
 	}
 }
 
-// NumOfTasks returns counts for all tasks in the project directory:
-// - total number of tasks
-// - number of completed tasks
-// - number of tasks due today
-//
-// Returns an error if the directory cannot be read or if a task cannot be parsed.
-func (p *Project) NumOfTasks(v *viper.Viper) (int, int, int, error) {
-	root, err := os.OpenRoot(v.GetString("storage.path"))
-	if err != nil {
-		panic(fmt.Errorf("could not open storage directory: %w", err))
+// add counts the task in the stats.
+func (s *TaskStats) add(t *Task) {
+	s.Total++
+
+	if t.Completed {
+		s.Completed++
+		return
 	}
-	defer root.Close() //nolint:errcheck
 
-	entries, err := fs.ReadDir(root.FS(), p.ID)
-	if err != nil {
-		return 0, 0, 0, err
+	if IsToday(t.DueDate) {
+		s.Due++
 	}
+	s.Estimate += time.Duration(t.Estimate)
+}
 
-	total, completed, due := 0, 0, 0
-	for _, entry := range entries {
-		if entry.IsDir() || entry.Name() == "project.json" {
-			continue
-		}
+// statsCache holds the task stats of each project by project ID, so
+// that the project list does not re-read all task files whenever it
+// is shown. Entries are invalidated when tasks are written, deleted
+// or pulled, and expire at midnight as the number of tasks due today
+// depends on the day they were computed.
+var statsCache = struct {
+	sync.Mutex
+	entries map[string]statsCacheEntry
+}{entries: make(map[string]statsCacheEntry)}
+
+// statsCacheEntry is a cached TaskStats along with the day it was computed.
+type statsCacheEntry struct {
+	stats TaskStats
+	day   string
+}
 
-		filePath := filepath.Join(p.ID, entry.Name())
-		data, err := root.ReadFile(filePath)
-		if err != nil {
-			continue
-		}
+// CachedStats returns the task stats of the project: the number of
+// tasks, of completed tasks and of tasks due today, and the summed
+// estimate of the open tasks. They are served from the cache unless
+// they were invalidated by InvalidateTaskStats or computed on an
+// earlier day. Otherwise they are computed from the task index, see
+// IndexedTasks, skipping task files that cannot be parsed.
+func (p *Project) CachedStats(v *viper.Viper) (TaskStats, error) {
+	stats, err := cachedStats(v, []*Project{p})
+	return stats[p.ID], err
+}
 
-		var t struct {
-			DueDate   *time.Time `json:"due_date"`
-			Completed bool       `json:"completed"`
-		}
-		if err := json.Unmarshal(data, &t); err != nil {
-			return 0, 0, 0, err
+// cachedStats returns the task stats of the given projects by project ID,
+// see CachedStats. The task index is reconciled at most once.
+func cachedStats(v *viper.Viper, projects []*Project) (map[string]TaskStats, error) {
+	day := time.Now().Format(time.DateOnly)
+	stats := make(map[string]TaskStats, len(projects))
+
+	var missing []*Project
+
+	statsCache.Lock()
+	for _, p := range projects {
+		if entry, ok := statsCache.entries[p.ID]; ok && entry.day == day {
+			stats[p.ID] = entry.stats
+		} else {
+			missing = append(missing, p)
 		}
+	}
+	statsCache.Unlock()
+
+	if len(missing) == 0 {
+		return stats, nil
+	}
 
-		total++
+	tasks, err := IndexedTasks(v)
+	var skipped *SkippedFilesError
+	if err != nil && !errors.As(err, &skipped) {
+		return nil, err
+	}
+
+	statsCache.Lock()
+	defer statsCache.Unlock()
 
-		if t.Completed {
-			completed++
-		} else if IsToday(t.DueDate) {
-			due++
+	for _, p := range missing {
+		var s TaskStats
+		for _, t := range tasks[p.ID] {
+			s.add(&t)
 		}
 
+		stats[p.ID] = s
+		statsCache.entries[p.ID] = statsCacheEntry{stats: s, day: day}
+	}
+
+	return stats, nil
+}
+
+// InvalidateTaskStats drops the cached task stats of the given projects.
+// Without any project IDs, the whole cache is dropped.
+func InvalidateTaskStats(projectIDs ...string) {
+	statsCache.Lock()
+	defer statsCache.Unlock()
+
+	if len(projectIDs) == 0 {
+		clear(statsCache.entries)
+		return
 	}
 
-	return total, completed, due, nil
+	for _, id := range projectIDs {
+		delete(statsCache.entries, id)
+	}
 }
 
 // FindListIndexByID returns the index of the project in the given slice of list.Item,
@@ -253,19 +519,13 @@ func (p *Project) FindListIndexByID(items []list.Item) int {
 }
 
 // LoadAllTaskStatsCmd loads task stats for all given projects asynchronously.
+// Stats of projects that did not change since they were last loaded are
+// taken from the cache, the others from the task index, see CachedStats.
 func LoadAllTaskStatsCmd(v *viper.Viper, projects []*Project) tea.Cmd {
 	return func() tea.Msg {
-		stats := make(map[string]TaskStats, len(projects))
-		for _, p := range projects {
-			total, completed, due, err := p.NumOfTasks(v)
-			if err != nil {
-				return TaskStatsErrorMsg{Err: err}
-			}
-			stats[p.ID] = TaskStats{
-				Total:     total,
-				Completed: completed,
-				Due:       due,
-			}
+		stats, err := cachedStats(v, projects)
+		if err != nil {
+			return TaskStatsErrorMsg{Err: err}
 		}
 		return TaskStatsDoneMsg{Stats: stats}
 	}
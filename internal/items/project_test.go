@@ -21,7 +21,11 @@
 package items
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
 	"os"
+	"path"
 	"path/filepath"
 	"strings"
 	"testing"
@@ -39,6 +43,18 @@ func TestProject_CropDescription(t *testing.T) {
 	}
 }
 
+func TestProject_DisplayTitle(t *testing.T) {
+	project := &Project{Title: "Garden"}
+	if got := project.DisplayTitle(); got != "Garden" {
+		t.Errorf("Expected the title without an icon, but got %q", got)
+	}
+
+	project.Icon = "🌱"
+	if got := project.DisplayTitle(); got != "🌱 Garden" {
+		t.Errorf("Expected the title prefixed with the icon, but got %q", got)
+	}
+}
+
 func TestProject_WriteProjectJSON(t *testing.T) {
 	tempDir := t.TempDir()
 	v := viper.New()
@@ -99,14 +115,92 @@ func TestProject_ReadTasksFromFS(t *testing.T) {
 	_ = os.WriteFile(filepath.Join(projectDir, task1.ID+".json"), task1.MarshalTask(), 0o600)
 	_ = os.WriteFile(filepath.Join(projectDir, task2.ID+".json"), task2.MarshalTask(), 0o600)
 
-	tasks := project.ReadTasksFromFS(v)
+	tasks, err := project.ReadTasksFromFS(v)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
 
 	if len(tasks) != 2 {
 		t.Errorf("Expected to read 2 tasks, but got %d", len(tasks))
 	}
+
+	corruptPath := filepath.Join(project.ID, uuid.NewString()+".json")
+	_ = os.WriteFile(filepath.Join(tempDir, corruptPath), []byte("{"), 0o600)
+
+	tasks, err = project.ReadTasksFromFS(v)
+	if len(tasks) != 2 {
+		t.Errorf("Expected to skip the corrupt task, but got %d tasks", len(tasks))
+	}
+
+	var skipped *SkippedFilesError
+	if !errors.As(err, &skipped) || len(skipped.Files) != 1 || skipped.Files[0].Path != filepath.ToSlash(corruptPath) {
+		t.Errorf("Expected the corrupt task to be reported, but got %v", err)
+	}
+
+	missing := &Project{ID: "missing"}
+	if tasks, err := missing.ReadTasksFromFS(v); err == nil || errors.As(err, &skipped) || tasks != nil {
+		t.Errorf("Expected an error for a missing project directory, but got %v", err)
+	}
 }
 
-func TestProject_NumOfTasks(t *testing.T) {
+func TestProject_TaskFiles(t *testing.T) {
+	tempDir := t.TempDir()
+	v := viper.New()
+	v.Set("storage.path", tempDir)
+
+	project := &Project{ID: "test-project", Title: "Test Project"}
+	projectDir := filepath.Join(tempDir, project.ID)
+	_ = os.MkdirAll(filepath.Join(projectDir, ArchiveDir), 0o750)
+	_ = os.WriteFile(filepath.Join(projectDir, "project.json"), project.MarshalProject(), 0o600)
+
+	for i := range 3 {
+		task := &Task{ID: uuid.NewString(), Title: fmt.Sprintf("Task %d", i)}
+		_ = os.WriteFile(filepath.Join(projectDir, task.ID+".json"), task.MarshalTask(), 0o600)
+	}
+
+	files, err := project.TaskFiles(v)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if len(files) != 3 {
+		t.Fatalf("Expected 3 task files, but got %v", files)
+	}
+
+	tasks, err := ReadTaskFiles(v, files[:2])
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if len(tasks) != 2 {
+		t.Errorf("Expected to read 2 tasks, but got %d", len(tasks))
+	}
+
+	tasks, err = ReadTaskFiles(v, []string{path.Join(project.ID, uuid.NewString()+".json")})
+	var skipped *SkippedFilesError
+	if len(tasks) != 0 || !errors.As(err, &skipped) {
+		t.Errorf("Expected a missing task file to be skipped, but got %v", err)
+	}
+}
+
+func TestCollectSkipped(t *testing.T) {
+	if err := CollectSkipped(nil, nil); err != nil {
+		t.Errorf("Expected nil, but got %v", err)
+	}
+
+	a := &SkippedFilesError{Files: []CorruptFileError{{Path: "a.json", Err: errors.New("a")}}}
+	b := &SkippedFilesError{Files: []CorruptFileError{{Path: "b.json", Err: errors.New("b")}}}
+
+	var skipped *SkippedFilesError
+	if err := CollectSkipped(a, nil, b); !errors.As(err, &skipped) || len(skipped.Files) != 2 {
+		t.Errorf("Expected both skipped files, but got %v", err)
+	}
+
+	fatal := errors.New("fatal")
+	if err := CollectSkipped(a, fatal); !errors.Is(err, fatal) {
+		t.Errorf("Expected the fatal error, but got %v", err)
+	}
+}
+
+func TestProject_ReadArchivedTasksFromFS(t *testing.T) {
 	tempDir := t.TempDir()
 	v := viper.New()
 	v.Set("storage.path", tempDir)
@@ -115,27 +209,171 @@ func TestProject_NumOfTasks(t *testing.T) {
 	projectDir := filepath.Join(tempDir, project.ID)
 	_ = os.Mkdir(projectDir, 0o750)
 
+	if tasks, err := project.ReadArchivedTasksFromFS(v); err != nil || len(tasks) != 0 {
+		t.Errorf("Expected no archived tasks without archive directory, but got %d", len(tasks))
+	}
+
+	_ = os.Mkdir(filepath.Join(projectDir, ArchiveDir), 0o750)
+
+	active := &Task{ID: uuid.NewString(), Title: "Active"}
+	archived := &Task{ID: uuid.NewString(), Title: "Archived", Completed: true}
+
+	_ = os.WriteFile(filepath.Join(projectDir, active.ID+".json"), active.MarshalTask(), 0o600)
+	_ = os.WriteFile(filepath.Join(projectDir, ArchiveDir, archived.ID+".json"), archived.MarshalTask(), 0o600)
+
+	if tasks, _ := project.ReadTasksFromFS(v); len(tasks) != 1 || tasks[0].ID != active.ID {
+		t.Errorf("Expected only the active task, but got %v", tasks)
+	}
+
+	tasks, err := project.ReadArchivedTasksFromFS(v)
+	if err != nil {
+		t.Fatalf("Expected no error, but got %v", err)
+	}
+	if len(tasks) != 1 || tasks[0].ID != archived.ID {
+		t.Fatalf("Expected only the archived task, but got %v", tasks)
+	}
+	if !tasks[0].Archived {
+		t.Errorf("Expected archived task to be marked as archived")
+	}
+}
+
+func TestProject_Stats(t *testing.T) {
+	tempDir := t.TempDir()
+	v := viper.New()
+	v.Set("storage.path", tempDir)
+
+	project := &Project{ID: uuid.NewString(), Title: "Test Project"}
+	projectDir := filepath.Join(tempDir, project.ID)
+	_ = os.Mkdir(projectDir, 0o750)
+	t.Cleanup(func() { InvalidateTaskStats(project.ID) })
+
 	now := time.Now()
-	task1 := &Task{ID: uuid.NewString(), Title: "Task 1", Completed: true}
-	task2 := &Task{ID: uuid.NewString(), Title: "Task 2", DueDate: &now}
-	task3 := &Task{ID: uuid.NewString(), Title: "Task 3"}
+	task1 := &Task{ID: uuid.NewString(), Title: "Task 1", Completed: true, Estimate: Estimate(time.Hour)}
+	task2 := &Task{ID: uuid.NewString(), Title: "Task 2", DueDate: &now, Estimate: Estimate(90 * time.Minute)}
+	task3 := &Task{ID: uuid.NewString(), Title: "Task 3", Estimate: Estimate(30 * time.Minute)}
 
 	_ = os.WriteFile(filepath.Join(projectDir, task1.ID+".json"), task1.MarshalTask(), 0o600)
 	_ = os.WriteFile(filepath.Join(projectDir, task2.ID+".json"), task2.MarshalTask(), 0o600)
 	_ = os.WriteFile(filepath.Join(projectDir, task3.ID+".json"), task3.MarshalTask(), 0o600)
 
-	total, completed, due, err := project.NumOfTasks(v)
+	// Corrupt task files are skipped.
+	_ = os.WriteFile(filepath.Join(projectDir, uuid.NewString()+".json"), []byte("{"), 0o600)
+
+	stats, err := project.CachedStats(v)
 	if err != nil {
-		t.Fatalf("NumOfTasks returned an error: %v", err)
+		t.Fatalf("CachedStats returned an error: %v", err)
+	}
+
+	if stats.Total != 3 {
+		t.Errorf("Expected total tasks to be 3, but got %d", stats.Total)
+	}
+	if stats.Completed != 1 {
+		t.Errorf("Expected completed tasks to be 1, but got %d", stats.Completed)
+	}
+	if stats.Due != 1 {
+		t.Errorf("Expected due tasks to be 1, but got %d", stats.Due)
+	}
+	if stats.Estimate != 2*time.Hour {
+		t.Errorf("Expected remaining estimate to be 2h, but got %s", stats.Estimate)
+	}
+}
+
+func TestProject_CachedStats(t *testing.T) {
+	tempDir := t.TempDir()
+	v := viper.New()
+	v.Set("storage.path", tempDir)
+
+	project := &Project{ID: uuid.NewString(), Title: "Test Project"}
+	projectDir := filepath.Join(tempDir, project.ID)
+	_ = os.Mkdir(projectDir, 0o750)
+	t.Cleanup(func() { InvalidateTaskStats(project.ID) })
+
+	writeTask := func() {
+		task := &Task{ID: uuid.NewString(), Title: "Task"}
+		_ = os.WriteFile(filepath.Join(projectDir, task.ID+".json"), task.MarshalTask(), 0o600)
+	}
+
+	total := func() int {
+		stats, err := project.CachedStats(v)
+		if err != nil {
+			t.Fatalf("CachedStats returned an error: %v", err)
+		}
+		return stats.Total
+	}
+
+	writeTask()
+	if got := total(); got != 1 {
+		t.Errorf("Expected total tasks to be 1, but got %d", got)
+	}
+
+	writeTask()
+	if got := total(); got != 1 {
+		t.Errorf("Expected cached total tasks to be 1, but got %d", got)
+	}
+
+	InvalidateTaskStats(project.ID)
+	if got := total(); got != 2 {
+		t.Errorf("Expected total tasks to be 2 after invalidation, but got %d", got)
 	}
 
-	if total != 3 {
-		t.Errorf("Expected total tasks to be 3, but got %d", total)
+	writeTask()
+	InvalidateTaskStats()
+	if got := total(); got != 3 {
+		t.Errorf("Expected total tasks to be 3 after clearing the cache, but got %d", got)
 	}
-	if completed != 1 {
-		t.Errorf("Expected completed tasks to be 1, but got %d", completed)
+}
+
+func TestSortProjects(t *testing.T) {
+	projects := []Project{
+		{ID: "a"},
+		{ID: "b", Order: 2},
+		{ID: "c", Pinned: true, Order: 3},
+		{ID: "d"},
+		{ID: "e", Order: 1},
+		{ID: "f", Pinned: true},
+	}
+
+	SortProjects(projects)
+
+	var ids []string
+	for _, p := range projects {
+		ids = append(ids, p.ID)
+	}
+
+	if got := strings.Join(ids, ""); got != "cfebad" {
+		t.Errorf("Expected order cfebad, but got %s", got)
 	}
-	if due != 1 {
-		t.Errorf("Expected due tasks to be 1, but got %d", due)
+}
+
+func TestRenumberProjects(t *testing.T) {
+	a := &Project{ID: "a", Order: 1}
+	b := &Project{ID: "b", Order: 3}
+	c := &Project{ID: "c"}
+
+	changed := RenumberProjects([]*Project{a, b, c})
+
+	if a.Order != 1 || b.Order != 2 || c.Order != 3 {
+		t.Errorf("Expected orders 1, 2, 3, but got %d, %d, %d", a.Order, b.Order, c.Order)
+	}
+
+	if len(changed) != 2 || changed[0].ID != "b" || changed[1].ID != "c" {
+		t.Errorf("Expected projects b and c to be changed, but got %v", changed)
+	}
+}
+
+func TestProject_Settings(t *testing.T) {
+	project := &Project{ID: "p", Title: "Project"}
+	if strings.Contains(string(project.MarshalProject()), "settings") {
+		t.Error("Expected no settings in JSON of project without settings")
+	}
+
+	var read Project
+	if err := json.Unmarshal([]byte(`{"id":"p","settings":{"sort":"manual","show_author":false,"wip_limit":2}}`), &read); err != nil {
+		t.Fatal(err)
+	}
+
+	s := read.Settings
+	if s == nil || s.Sort != "manual" || s.WIPLimit != 2 || s.ShowAuthor == nil || *s.ShowAuthor || s.ShowAssignee != nil {
+		t.Errorf("Unexpected settings %+v", s)
 	}
 }
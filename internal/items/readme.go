@@ -0,0 +1,148 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package items
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/handlebargh/yatto/internal/config"
+	"github.com/handlebargh/yatto/internal/storage"
+	"github.com/spf13/viper"
+)
+
+// ReadmeFile is the name of the optional markdown file in a project
+// directory holding notes about the project, e.g. working agreements.
+const ReadmeFile = "README.md"
+
+type (
+	// WriteReadmeDoneMsg indicates that the README of Project was written
+	// or, if it was emptied, removed.
+	WriteReadmeDoneMsg struct{ Project Project }
+
+	// WriteReadmeErrorMsg is returned when the README of a project
+	// cannot be written.
+	WriteReadmeErrorMsg struct{ Err error }
+)
+
+// Error implements the error interface for WriteReadmeErrorMsg.
+func (e WriteReadmeErrorMsg) Error() string { return e.Err.Error() }
+
+// ReadmePath returns the path of the project's README relative
+// to the storage path.
+func (p *Project) ReadmePath() string {
+	return filepath.Join(p.ID, ReadmeFile)
+}
+
+// Readme returns the content of the project's README.
+// A project without a README has an empty one.
+func (p *Project) Readme(v *viper.Viper) (string, error) {
+	root, err := os.OpenRoot(config.Get(v).StoragePath)
+	if err != nil {
+		return "", fmt.Errorf("could not open storage directory: %w", err)
+	}
+	defer root.Close() //nolint:errcheck
+
+	data, err := fs.ReadFile(root.FS(), filepath.ToSlash(p.ReadmePath()))
+	if errors.Is(err, fs.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("could not read README: %w", err)
+	}
+
+	return strings.TrimRight(string(data), "\n"), nil
+}
+
+// WriteReadme writes content to the project's README. An empty
+// content removes the README.
+// Returns a Tea message indicating success or error.
+func (p *Project) WriteReadme(v *viper.Viper, content string) tea.Cmd {
+	return func() tea.Msg {
+		root, err := os.OpenRoot(config.Get(v).StoragePath)
+		if err != nil {
+			return WriteReadmeErrorMsg{fmt.Errorf("could not open storage directory: %w", err)}
+		}
+		defer root.Close() //nolint:errcheck
+
+		if strings.TrimSpace(content) == "" {
+			if err := root.Remove(p.ReadmePath()); err != nil && !errors.Is(err, fs.ErrNotExist) {
+				return WriteReadmeErrorMsg{err}
+			}
+			return WriteReadmeDoneMsg{Project: *p}
+		}
+
+		if err := storage.AtomicWrite(root, p.ReadmePath(), []byte(content+"\n"), 0o600); err != nil {
+			return WriteReadmeErrorMsg{err}
+		}
+
+		return WriteReadmeDoneMsg{Project: *p}
+	}
+}
+
+// ProjectToMarkdown returns a markdown overview of the project: its
+// description, the task stats and custom fields, followed by the README.
+func (p *Project) ProjectToMarkdown(stats TaskStats, readme string) string {
+	var content strings.Builder
+
+	// Title
+	fmt.Fprintf(&content, "# %s\n\n", p.DisplayTitle())
+
+	// Description
+	if p.Description != "" {
+		fmt.Fprintf(&content, "%s\n\n", p.Description)
+	} else {
+		content.WriteString("*No description provided.*\n\n")
+	}
+
+	// Tasks
+	content.WriteString("### Tasks\n\n")
+	content.WriteString("| Property | Value |\n")
+	content.WriteString("| :--- | :--- |\n")
+	fmt.Fprintf(&content, "| **Open** | %d |\n", stats.Total-stats.Completed)
+	fmt.Fprintf(&content, "| **Completed** | %d |\n", stats.Completed)
+	fmt.Fprintf(&content, "| **Due today** | %d |\n", stats.Due)
+	if stats.Estimate > 0 {
+		fmt.Fprintf(&content, "| **Estimate left** | %s |\n", Estimate(stats.Estimate))
+	}
+	if len(p.Fields) > 0 {
+		names := make([]string, 0, len(p.Fields))
+		for _, field := range p.Fields {
+			names = append(names, field.Name)
+		}
+		fmt.Fprintf(&content, "| **Custom fields** | %s |\n", strings.Join(names, ", "))
+	}
+	content.WriteString("\n---\n\n")
+
+	// README
+	if readme != "" {
+		fmt.Fprintf(&content, "%s\n", readme)
+	} else {
+		content.WriteString("*No README provided.*\n")
+	}
+
+	return content.String()
+}
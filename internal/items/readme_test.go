@@ -0,0 +1,114 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package items
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestProject_Readme(t *testing.T) {
+	tempDir := t.TempDir()
+	v := viper.New()
+	v.Set("storage.path", tempDir)
+
+	project := &Project{ID: "test-project", Title: "Test Project"}
+	if err := os.Mkdir(filepath.Join(tempDir, project.ID), 0o750); err != nil {
+		t.Fatal(err)
+	}
+
+	readme, err := project.Readme(v)
+	if err != nil || readme != "" {
+		t.Fatalf("Expected an empty README, got %q (%v)", readme, err)
+	}
+
+	if msg := project.WriteReadme(v, "# Notes\n\nBe nice.")(); !isReadmeDone(msg) {
+		t.Fatalf("Expected WriteReadmeDoneMsg, got %T: %v", msg, msg)
+	}
+
+	data, err := os.ReadFile(filepath.Join(tempDir, project.ReadmePath()))
+	if err != nil || string(data) != "# Notes\n\nBe nice.\n" {
+		t.Errorf("Unexpected README content %q (%v)", data, err)
+	}
+
+	readme, err = project.Readme(v)
+	if err != nil || readme != "# Notes\n\nBe nice." {
+		t.Errorf("Unexpected README %q (%v)", readme, err)
+	}
+
+	// Emptying the README removes it.
+	if msg := project.WriteReadme(v, "\n")(); !isReadmeDone(msg) {
+		t.Fatalf("Expected WriteReadmeDoneMsg, got %T: %v", msg, msg)
+	}
+
+	if _, err := os.Stat(filepath.Join(tempDir, project.ReadmePath())); !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Expected README to be removed, got %v", err)
+	}
+
+	// Removing a missing README is fine.
+	if msg := project.WriteReadme(v, "")(); !isReadmeDone(msg) {
+		t.Errorf("Expected WriteReadmeDoneMsg, got %T: %v", msg, msg)
+	}
+}
+
+func TestProject_ProjectToMarkdown(t *testing.T) {
+	project := &Project{
+		Title:  "Test Project",
+		Icon:   "🚀",
+		Fields: FieldDefs{{Name: "Customer", Type: FieldText}},
+	}
+	stats := TaskStats{Total: 5, Completed: 2, Due: 1}
+
+	markdown := project.ProjectToMarkdown(stats, "Working agreements")
+	for _, want := range []string{
+		"# 🚀 Test Project",
+		"*No description provided.*",
+		"| **Open** | 3 |",
+		"| **Completed** | 2 |",
+		"| **Due today** | 1 |",
+		"| **Custom fields** | Customer |",
+		"Working agreements",
+	} {
+		if !strings.Contains(markdown, want) {
+			t.Errorf("Expected %q in markdown:\n%s", want, markdown)
+		}
+	}
+
+	if strings.Contains(markdown, "Estimate") {
+		t.Errorf("Expected no estimate without open estimates:\n%s", markdown)
+	}
+
+	if markdown := project.ProjectToMarkdown(stats, ""); !strings.Contains(markdown, "*No README provided.*") {
+		t.Errorf("Expected a missing README to be noted:\n%s", markdown)
+	}
+}
+
+// isReadmeDone reports whether msg is a WriteReadmeDoneMsg.
+func isReadmeDone(msg any) bool {
+	_, ok := msg.(WriteReadmeDoneMsg)
+	return ok
+}
@@ -0,0 +1,225 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package items
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/handlebargh/yatto/internal/storage"
+	"github.com/spf13/viper"
+)
+
+// Reminders are the lead times before the due date of a task at which
+// the task is to be reminded of, e.g. one day and one hour before.
+// They are stored in JSON as duration strings like "1d" or "1h30m".
+type Reminders []time.Duration
+
+// reminderDaysRegex matches lead times starting with days or weeks, e.g.
+// "2d" or "1w12h", which are not understood by time.ParseDuration.
+var reminderDaysRegex = regexp.MustCompile(`^(\d+)([dw])(.*)$`)
+
+// parseReminder parses a single lead time like "1d", "2w", "1h30m" or "1d12h".
+func parseReminder(str string) (time.Duration, error) {
+	var d time.Duration
+	rest := str
+
+	if matches := reminderDaysRegex.FindStringSubmatch(str); matches != nil {
+		n, err := strconv.Atoi(matches[1])
+		if err != nil {
+			return 0, fmt.Errorf("invalid reminder: %q", str)
+		}
+
+		days := n
+		if matches[2] == "w" {
+			days = 7 * n
+		}
+
+		d = time.Duration(days) * 24 * time.Hour
+		rest = matches[3]
+	}
+
+	if rest != "" {
+		r, err := time.ParseDuration(rest)
+		if err != nil || r < 0 {
+			return 0, fmt.Errorf("invalid reminder: %q", str)
+		}
+		d += r
+	}
+
+	if d <= 0 {
+		return 0, fmt.Errorf("invalid reminder: %q", str)
+	}
+
+	return d, nil
+}
+
+// ParseReminders parses a list of lead times separated by commas or
+// spaces, e.g. "1d, 1h", into Reminders. Lead times accept the units of
+// time.ParseDuration as well as "d" for days and "w" for weeks. The
+// reminders are sorted from the longest lead time to the shortest and
+// duplicates are dropped. An empty string yields no reminders.
+func ParseReminders(str string) (Reminders, error) {
+	var reminders Reminders
+
+	for field := range strings.FieldsSeq(strings.ReplaceAll(str, ",", " ")) {
+		d, err := parseReminder(strings.ToLower(field))
+		if err != nil {
+			return nil, err
+		}
+		reminders = append(reminders, d)
+	}
+
+	slices.Sort(reminders)
+	slices.Reverse(reminders)
+
+	return slices.Compact(reminders), nil
+}
+
+// formatReminder returns the lead time with whole days in days,
+// e.g. "1d12h" for 36 hours.
+func formatReminder(d time.Duration) string {
+	const day = 24 * time.Hour
+
+	var s string
+	if days := d / day; days > 0 {
+		s = fmt.Sprintf("%dd", days)
+	}
+
+	return s + Estimate(d%day).String()
+}
+
+// String returns the lead times separated by commas, e.g. "1d, 1h".
+// It returns an empty string for no reminders.
+func (r Reminders) String() string {
+	lead := make([]string, len(r))
+	for i, d := range r {
+		lead[i] = formatReminder(d)
+	}
+
+	return strings.Join(lead, ", ")
+}
+
+// MarshalJSON implements the json.Marshaler interface for Reminders.
+func (r Reminders) MarshalJSON() ([]byte, error) {
+	lead := make([]string, len(r))
+	for i, d := range r {
+		lead[i] = formatReminder(d)
+	}
+
+	return json.Marshal(lead)
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for Reminders.
+func (r *Reminders) UnmarshalJSON(data []byte) error {
+	var lead []string
+	if err := json.Unmarshal(data, &lead); err != nil {
+		return err
+	}
+
+	reminders, err := ParseReminders(strings.Join(lead, ","))
+	if err != nil {
+		return err
+	}
+
+	*r = reminders
+	return nil
+}
+
+// Reminder returns the time the last reminder of the task was due at
+// before now. Reminders are due their lead time before the deadline of
+// the task, see Deadline. The zero time is returned if no reminder is
+// due yet, if the task is completed or if its deadline has passed, as
+// it is overdue then.
+func (s DueSettings) Reminder(t *Task, now time.Time) time.Time {
+	if t.Completed || t.DueDate == nil || len(t.Reminders) == 0 {
+		return time.Time{}
+	}
+
+	deadline := s.Deadline(t)
+	if deadline.Before(now) {
+		return time.Time{}
+	}
+
+	var last time.Time
+	for _, d := range t.Reminders {
+		if at := deadline.Add(-d); !at.After(now) && at.After(last) {
+			last = at
+		}
+	}
+
+	return last
+}
+
+// DueReminders returns the open tasks with a reminder that became due
+// since the last check, see DueSettings.Reminder, by project ID and
+// ordered by file name. Each reminder is returned once; the time of the last
+// reminder returned for a task is kept in the storage state, so that
+// moving the due date of a task arms its reminders again.
+//
+// The tasks are summaries taken from the task index, see IndexedTasks.
+// Task files that cannot be read or parsed are skipped.
+func DueReminders(v *viper.Viper, now time.Time) (map[string][]Task, error) {
+	indexed, err := IndexedTasks(v)
+	var skipped *SkippedFilesError
+	if err != nil && !errors.As(err, &skipped) {
+		return nil, err
+	}
+
+	state, err := storage.ReadState(v)
+	if err != nil {
+		return nil, err
+	}
+
+	due := DueSettingsFromConfig(v)
+	reminded := make(map[string]time.Time)
+	tasks := make(map[string][]Task)
+
+	for projectID := range indexed {
+		for _, t := range indexed[projectID] {
+			at := due.Reminder(&t, now)
+			if at.IsZero() {
+				continue
+			}
+
+			reminded[t.ID] = at
+			if last, ok := state.RemindersNotified[t.ID]; !ok || !last.Equal(at) {
+				tasks[projectID] = append(tasks[projectID], t)
+			}
+		}
+	}
+
+	if !maps.EqualFunc(reminded, state.RemindersNotified, time.Time.Equal) {
+		state.RemindersNotified = reminded
+		if err := storage.WriteState(v, state); err != nil {
+			return tasks, err
+		}
+	}
+
+	return tasks, nil
+}
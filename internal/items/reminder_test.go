@@ -0,0 +1,177 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package items
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/spf13/viper"
+)
+
+func TestParseReminders(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"", ""},
+		{"1h", "1h"},
+		{"1h, 1d", "1d, 1h"},
+		{"1d 1h 1d", "1d, 1h"},
+		{"2W", "14d"},
+		{"1d12h, 90m", "1d12h, 1h30m"},
+		{"36h", "1d12h"},
+	}
+
+	for _, tt := range tests {
+		r, err := ParseReminders(tt.input)
+		if err != nil {
+			t.Errorf("ParseReminders(%q) returned an error: %v", tt.input, err)
+		}
+		if r.String() != tt.want {
+			t.Errorf("ParseReminders(%q) = %q, want %q", tt.input, r, tt.want)
+		}
+	}
+
+	for _, input := range []string{"soon", "-1h", "0m", "1d-1h", "d"} {
+		if _, err := ParseReminders(input); err == nil {
+			t.Errorf("Expected ParseReminders(%q) to fail", input)
+		}
+	}
+
+	task := &Task{Reminders: Reminders{24 * time.Hour, 90 * time.Minute}}
+	data := task.MarshalTask()
+	if !strings.Contains(string(data), `"1d"`) || !strings.Contains(string(data), `"1h30m"`) {
+		t.Errorf("Expected the reminders as duration strings, but got %s", data)
+	}
+
+	var decoded Task
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal returned an error: %v", err)
+	}
+	if !slices.Equal(decoded.Reminders, task.Reminders) {
+		t.Errorf("Expected reminders %s after round trip, but got %s", task.Reminders, decoded.Reminders)
+	}
+	if strings.Contains(string((&Task{}).MarshalTask()), "reminders") {
+		t.Errorf("Expected no reminders in JSON of a task without reminders")
+	}
+}
+
+func TestDueSettings_Reminder(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.Local)
+	due := now.Add(90 * time.Minute)
+	task := &Task{DueDate: &due, Reminders: Reminders{24 * time.Hour, time.Hour}}
+
+	if got, want := (DueSettings{}).Reminder(task, now), due.Add(-24*time.Hour); !got.Equal(want) {
+		t.Errorf("Expected the reminder a day before to be due at %s, but got %s", want, got)
+	}
+	if got, want := (DueSettings{}).Reminder(task, now.Add(time.Hour)), due.Add(-time.Hour); !got.Equal(want) {
+		t.Errorf("Expected the reminder an hour before to be due at %s, but got %s", want, got)
+	}
+	if got := (DueSettings{}).Reminder(task, now.Add(2*time.Hour)); !got.IsZero() {
+		t.Errorf("Expected no reminder for an overdue task, but got %s", got)
+	}
+
+	early := &Task{DueDate: &due, Reminders: Reminders{time.Hour}}
+	if got := (DueSettings{}).Reminder(early, now); !got.IsZero() {
+		t.Errorf("Expected no reminder before its lead time, but got %s", got)
+	}
+
+	done := &Task{DueDate: &due, Reminders: task.Reminders, Completed: true}
+	if got := (DueSettings{}).Reminder(done, now); !got.IsZero() {
+		t.Errorf("Expected no reminder for a completed task, but got %s", got)
+	}
+
+	// All-day tasks are due by the end of the day.
+	midnight := time.Date(2026, 3, 11, 0, 0, 0, 0, time.Local)
+	allDay := &Task{DueDate: &midnight, Reminders: Reminders{time.Hour}}
+	if got := (DueSettings{}).Reminder(allDay, midnight.Add(12*time.Hour)); !got.IsZero() {
+		t.Errorf("Expected no reminder for an overdue task, but got %s", got)
+	}
+	if got, want := (DueSettings{AllDay: true}).Reminder(allDay, midnight.Add(23*time.Hour)), midnight.Add(23*time.Hour); !got.Equal(want) {
+		t.Errorf("Expected the reminder to be due at %s, but got %s", want, got)
+	}
+}
+
+func TestDueReminders(t *testing.T) {
+	tempDir := t.TempDir()
+	v := viper.New()
+	v.Set("storage.path", tempDir)
+
+	projectDir := filepath.Join(tempDir, "test-project")
+	_ = os.Mkdir(projectDir, 0o750)
+
+	now := time.Now()
+	soon := now.Add(30 * time.Minute)
+	later := now.Add(48 * time.Hour)
+	reminded := &Task{ID: uuid.NewString(), Title: "Reminded", DueDate: &soon, Reminders: Reminders{time.Hour}}
+	notYet := &Task{ID: uuid.NewString(), Title: "Not yet", DueDate: &later, Reminders: Reminders{time.Hour}}
+	none := &Task{ID: uuid.NewString(), Title: "None", DueDate: &soon}
+	for _, task := range []*Task{reminded, notYet, none} {
+		_ = os.WriteFile(filepath.Join(projectDir, task.ID+".json"), task.MarshalTask(), 0o600)
+	}
+
+	ids := func(tasks map[string][]Task) []string {
+		var ids []string
+		for _, task := range tasks["test-project"] {
+			ids = append(ids, task.ID)
+		}
+		return ids
+	}
+
+	tasks, err := DueReminders(v, now)
+	if err != nil {
+		t.Fatalf("DueReminders returned an error: %v", err)
+	}
+	if got := ids(tasks); !slices.Equal(got, []string{reminded.ID}) {
+		t.Fatalf("Expected a reminder for %s, but got %v", reminded.ID, got)
+	}
+
+	t.Run("returns each reminder once", func(t *testing.T) {
+		tasks, err := DueReminders(v, now)
+		if err != nil {
+			t.Fatalf("DueReminders returned an error: %v", err)
+		}
+		if got := ids(tasks); len(got) != 0 {
+			t.Errorf("Expected no further reminders, but got %v", got)
+		}
+	})
+
+	t.Run("moving the due date arms the reminders again", func(t *testing.T) {
+		moved := now.Add(45 * time.Minute)
+		reminded.DueDate = &moved
+		_ = os.WriteFile(filepath.Join(projectDir, reminded.ID+".json"), reminded.MarshalTask(), 0o600)
+
+		tasks, err := DueReminders(v, now)
+		if err != nil {
+			t.Fatalf("DueReminders returned an error: %v", err)
+		}
+		if got := ids(tasks); !slices.Equal(got, []string{reminded.ID}) {
+			t.Errorf("Expected a reminder for %s, but got %v", reminded.ID, got)
+		}
+	})
+}
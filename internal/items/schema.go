@@ -0,0 +1,360 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package items
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+// schemaFiles holds the JSON Schemas of the task and project files.
+//
+//go:embed schema/*.json
+var schemaFiles embed.FS
+
+// Names of the schemas, see Schema.
+const (
+	TaskSchema    = "task"
+	ProjectSchema = "project"
+)
+
+// schemas holds the parsed schemas by their name.
+var schemas = map[string]*schemaNode{
+	TaskSchema:    mustParseSchema(TaskSchema),
+	ProjectSchema: mustParseSchema(ProjectSchema),
+}
+
+// schemaNode is the subset of JSON Schema used by the schemas of yatto.
+// Formats are "date-time" (RFC 3339), "duration" (see ParseEstimate)
+// and "reminder" (see ParseReminders).
+type schemaNode struct {
+	Type                 schemaTypes            `json:"type"`
+	Enum                 []string               `json:"enum"`
+	Format               string                 `json:"format"`
+	MinLength            int                    `json:"minLength"`
+	Minimum              *float64               `json:"minimum"`
+	Required             []string               `json:"required"`
+	Properties           map[string]*schemaNode `json:"properties"`
+	AdditionalProperties *schemaNode            `json:"additionalProperties"`
+	Items                *schemaNode            `json:"items"`
+}
+
+// schemaTypes holds the allowed types of a value, given either as
+// a single type or as a list of types.
+type schemaTypes []string
+
+// UnmarshalJSON implements the json.Unmarshaler interface for schemaTypes.
+func (s *schemaTypes) UnmarshalJSON(data []byte) error {
+	var list []string
+	if err := json.Unmarshal(data, &list); err == nil {
+		*s = list
+		return nil
+	}
+
+	var single string
+	if err := json.Unmarshal(data, &single); err != nil {
+		return err
+	}
+
+	*s = schemaTypes{single}
+	return nil
+}
+
+// mustParseSchema parses the embedded schema with the given name.
+// Panics if the schema is missing or invalid.
+func mustParseSchema(name string) *schemaNode {
+	var node schemaNode
+	if err := json.Unmarshal(Schema(name), &node); err != nil {
+		panic(fmt.Errorf("invalid %s schema: %w", name, err))
+	}
+
+	return &node
+}
+
+// Schema returns the JSON Schema with the given name, TaskSchema or
+// ProjectSchema. It returns nil for unknown names.
+func Schema(name string) []byte {
+	data, err := schemaFiles.ReadFile("schema/" + name + ".json")
+	if err != nil {
+		return nil
+	}
+
+	return data
+}
+
+// SchemaViolation is a value that does not match its schema.
+type SchemaViolation struct {
+	// Pointer locates the value as a JSON Pointer, e.g. "/subtasks/0/done".
+	Pointer string
+	// Message describes the violation.
+	Message string
+}
+
+// String returns the violation in the form "pointer: message".
+func (v SchemaViolation) String() string {
+	pointer := v.Pointer
+	if pointer == "" {
+		pointer = "/"
+	}
+
+	return pointer + ": " + v.Message
+}
+
+// SchemaError is returned for JSON that is not valid or does not
+// match the schema of a task or project file.
+type SchemaError struct {
+	// Schema is the name of the schema, TaskSchema or ProjectSchema.
+	Schema string
+	// Violations lists the values not matching the schema. It is
+	// empty if the JSON itself is not valid.
+	Violations []SchemaViolation
+	// Line and Column locate a syntax error of invalid JSON.
+	Line, Column int
+	// Err is the syntax error of invalid JSON.
+	Err error
+}
+
+// Error implements the error interface for SchemaError.
+func (e *SchemaError) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("line %d, column %d: %v", e.Line, e.Column, e.Err)
+	}
+
+	messages := make([]string, len(e.Violations))
+	for i, v := range e.Violations {
+		messages[i] = v.String()
+	}
+
+	return fmt.Sprintf("does not match the %s schema: %s", e.Schema, strings.Join(messages, "; "))
+}
+
+// Unwrap returns the syntax error of invalid JSON.
+func (e *SchemaError) Unwrap() error { return e.Err }
+
+// ValidateJSON validates data against the schema with the given name,
+// TaskSchema or ProjectSchema. It returns a *SchemaError locating the
+// syntax error of invalid JSON or all values not matching the schema.
+// Properties not described by the schema are allowed, so that files
+// written by newer versions of yatto remain readable.
+func ValidateJSON(name string, data []byte) error {
+	schema, ok := schemas[name]
+	if !ok {
+		return fmt.Errorf("unknown schema %q", name)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		line, column := jsonPosition(data, decoder.InputOffset())
+		var syntaxErr *json.SyntaxError
+		if errors.As(err, &syntaxErr) {
+			// The offset is after the byte causing the error.
+			line, column = jsonPosition(data, syntaxErr.Offset-1)
+		}
+
+		return &SchemaError{Schema: name, Line: line, Column: column, Err: err}
+	}
+
+	var violations []SchemaViolation
+	schema.validate("", value, &violations)
+	if len(violations) > 0 {
+		return &SchemaError{Schema: name, Violations: violations}
+	}
+
+	return nil
+}
+
+// ParseTask validates data against the task schema, see ValidateJSON,
+// and returns the task it holds.
+func ParseTask(data []byte) (Task, error) {
+	var task Task
+	if err := ValidateJSON(TaskSchema, data); err != nil {
+		return task, err
+	}
+
+	err := json.Unmarshal(data, &task)
+	return task, err
+}
+
+// ParseProject validates data against the project schema, see
+// ValidateJSON, and returns the project it holds.
+func ParseProject(data []byte) (Project, error) {
+	var project Project
+	if err := ValidateJSON(ProjectSchema, data); err != nil {
+		return project, err
+	}
+
+	err := json.Unmarshal(data, &project)
+	return project, err
+}
+
+// jsonPosition returns the line and column of the byte at offset,
+// both counted from 1.
+func jsonPosition(data []byte, offset int64) (int, int) {
+	offset = min(max(offset, 0), int64(len(data)))
+	before := data[:offset]
+	line := bytes.Count(before, []byte("\n")) + 1
+	column := len(before) - bytes.LastIndexByte(before, '\n')
+
+	return line, column
+}
+
+// validate appends the violations of value, located at pointer,
+// to violations.
+func (s *schemaNode) validate(pointer string, value any, violations *[]SchemaViolation) {
+	report := func(format string, args ...any) {
+		*violations = append(*violations, SchemaViolation{Pointer: pointer, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if len(s.Type) > 0 && !slices.ContainsFunc(s.Type, func(t string) bool { return hasJSONType(value, t) }) {
+		report("expected %s, got %s", strings.Join(s.Type, " or "), jsonType(value))
+		return
+	}
+
+	switch v := value.(type) {
+	case string:
+		if len(s.Enum) > 0 && !slices.Contains(s.Enum, v) {
+			report("must be one of %s", quoteAll(s.Enum))
+		}
+		if len([]rune(v)) < s.MinLength {
+			report("must not be empty")
+		}
+		if err := checkFormat(s.Format, v); err != nil {
+			report("%v", err)
+		}
+	case json.Number:
+		if n, err := v.Float64(); err == nil && s.Minimum != nil && n < *s.Minimum {
+			report("must be at least %v", *s.Minimum)
+		}
+	case []any:
+		if s.Items != nil {
+			for i, item := range v {
+				s.Items.validate(fmt.Sprintf("%s/%d", pointer, i), item, violations)
+			}
+		}
+	case map[string]any:
+		for _, name := range s.Required {
+			if _, ok := v[name]; !ok {
+				report("missing property %q", name)
+			}
+		}
+
+		names := make([]string, 0, len(v))
+		for name := range v {
+			names = append(names, name)
+		}
+		slices.Sort(names)
+
+		for _, name := range names {
+			property := pointer + "/" + escapePointer(name)
+			if schema, ok := s.Properties[name]; ok {
+				schema.validate(property, v[name], violations)
+			} else if s.AdditionalProperties != nil {
+				s.AdditionalProperties.validate(property, v[name], violations)
+			}
+		}
+	default:
+		if len(s.Enum) > 0 {
+			report("must be one of %s", quoteAll(s.Enum))
+		}
+	}
+}
+
+// hasJSONType reports whether value is of the JSON Schema type t.
+func hasJSONType(value any, t string) bool {
+	if t == "integer" {
+		n, ok := value.(json.Number)
+		if !ok {
+			return false
+		}
+		_, err := n.Int64()
+		return err == nil
+	}
+
+	return jsonType(value) == t
+}
+
+// jsonType returns the JSON Schema type of a decoded value.
+func jsonType(value any) string {
+	switch value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case json.Number:
+		return "number"
+	case string:
+		return "string"
+	case []any:
+		return "array"
+	default:
+		return "object"
+	}
+}
+
+// checkFormat returns an error if the value does not have the given
+// format. Empty durations and reminders stand for none. Unknown formats
+// are not checked.
+func checkFormat(format, value string) error {
+	if value == "" && format != "date-time" {
+		return nil
+	}
+
+	switch format {
+	case "date-time":
+		if _, err := time.Parse(time.RFC3339Nano, value); err != nil {
+			return fmt.Errorf("invalid date and time %q, expected a time like 2026-02-14T09:00:00Z", value)
+		}
+	case "duration":
+		if _, err := ParseEstimate(value); err != nil {
+			return err
+		}
+	case "reminder":
+		if _, err := parseReminder(value); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// quoteAll returns the values quoted and separated by commas.
+func quoteAll(values []string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = fmt.Sprintf("%q", v)
+	}
+
+	return strings.Join(quoted, ", ")
+}
+
+// escapePointer escapes a property name for use in a JSON Pointer.
+func escapePointer(name string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(name)
+}
@@ -0,0 +1,125 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package items
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestValidateJSON_MarshalledItems(t *testing.T) {
+	due := time.Date(2026, 2, 14, 9, 0, 0, 0, time.UTC)
+	task := Task{
+		ID: "c0ffee00-0000-4000-8000-000000000001", Title: "Task", Priority: "high",
+		Labels: Labels{"work"}, DueDate: &due, Estimate: Estimate(90 * time.Minute),
+		Reminders: Reminders{36 * time.Hour}, Subtasks: Subtasks{{Title: "Step"}},
+		Recurrence: &Recurrence{Frequency: "weekly", Interval: 2},
+		Comments:   []Comment{{Time: due, Body: "Done soon"}},
+		Fields:     map[string]string{"Points": "3"},
+	}
+	if err := ValidateJSON(TaskSchema, task.MarshalTask()); err != nil {
+		t.Errorf("Expected the marshalled task to be valid, but got %v", err)
+	}
+
+	empty := Task{ID: "c0ffee00-0000-4000-8000-000000000002"}
+	if err := ValidateJSON(TaskSchema, empty.MarshalTask()); err != nil {
+		t.Errorf("Expected a task without priority to be valid, but got %v", err)
+	}
+
+	project := Project{
+		ID: "project", Title: "Project", Color: "blue",
+		Settings: &ProjectSettings{WIPLimit: 3},
+		Fields:   FieldDefs{{Name: "Env", Type: FieldEnum, Options: []string{"dev"}}},
+	}
+	if err := ValidateJSON(ProjectSchema, project.MarshalProject()); err != nil {
+		t.Errorf("Expected the marshalled project to be valid, but got %v", err)
+	}
+}
+
+func TestValidateJSON_Violations(t *testing.T) {
+	tests := []struct {
+		schema string
+		data   string
+		want   []string
+	}{
+		{TaskSchema, `{"id":"a","title":"T","priority":"urgent"}`, []string{"/priority"}},
+		{TaskSchema, `{"title":"T"}`, []string{"/: missing property \"id\""}},
+		{TaskSchema, `{"id":"a","title":"T","subtasks":[{"title":"x"},{"title":"y","done":"yes"}]}`, []string{"/subtasks/1/done"}},
+		{TaskSchema, `{"id":"a","title":"T","due_date":"tomorrow","order":1.5}`, []string{"/due_date", "/order"}},
+		{TaskSchema, `{"id":"a","title":"T","reminders":["1d","soon"],"estimate":"2h"}`, []string{"/reminders/1"}},
+		{TaskSchema, `{"id":"a","title":"T","fields":{"a/b":3}}`, []string{"/fields/a~1b"}},
+		{TaskSchema, `{"id":"a","title":"T","labels":"a,b","new_field":true}`, nil},
+		{ProjectSchema, `{"id":"","title":"P","fields":[{"name":"x","type":"bool"}]}`, []string{"/id", "/fields/0/type"}},
+		{ProjectSchema, `[]`, []string{"/: expected object"}},
+	}
+
+	for _, tt := range tests {
+		err := ValidateJSON(tt.schema, []byte(tt.data))
+		if tt.want == nil {
+			if err != nil {
+				t.Errorf("Expected %s to be valid, but got %v", tt.data, err)
+			}
+			continue
+		}
+
+		var schemaErr *SchemaError
+		if !errors.As(err, &schemaErr) {
+			t.Errorf("Expected a SchemaError for %s, but got %v", tt.data, err)
+			continue
+		}
+		if len(schemaErr.Violations) != len(tt.want) {
+			t.Errorf("Expected %d violation(s) for %s, but got %v", len(tt.want), tt.data, err)
+		}
+		for _, want := range tt.want {
+			if !strings.Contains(err.Error(), want) {
+				t.Errorf("Expected %q in the error for %s, but got %v", want, tt.data, err)
+			}
+		}
+	}
+}
+
+func TestValidateJSON_SyntaxError(t *testing.T) {
+	err := ValidateJSON(TaskSchema, []byte("{\n\t\"id\": \"a\",\n\t\"title\": \"T\"\n\t\"priority\": \"low\"\n}"))
+
+	var schemaErr *SchemaError
+	if !errors.As(err, &schemaErr) {
+		t.Fatalf("Expected a SchemaError, but got %v", err)
+	}
+	if schemaErr.Line != 4 || schemaErr.Column != 2 {
+		t.Errorf("Expected the error at line 4, column 2, but got line %d, column %d", schemaErr.Line, schemaErr.Column)
+	}
+}
+
+func TestParseTask(t *testing.T) {
+	task, err := ParseTask([]byte(`{"id":"a","title":"T","priority":"low","labels":"x, y"}`))
+	if err != nil {
+		t.Fatalf("ParseTask returned an error: %v", err)
+	}
+	if task.Title != "T" || len(task.Labels) != 2 {
+		t.Errorf("Expected the task to be parsed, but got %+v", task)
+	}
+
+	if _, err := ParseTask([]byte(`{"id":"a","title":"T","completed":"no"}`)); err == nil {
+		t.Error("Expected ParseTask to reject an invalid task")
+	}
+}
@@ -27,16 +27,28 @@ package items
 
 import (
 	"bytes"
+	"cmp"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io/fs"
+	"maps"
 	"math"
 	"os"
 	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/charmbracelet/bubbles/list"
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/google/uuid"
+	"github.com/handlebargh/yatto/internal/config"
+	"github.com/handlebargh/yatto/internal/hooks"
+	"github.com/handlebargh/yatto/internal/logging"
+	"github.com/handlebargh/yatto/internal/storage"
 	"github.com/mattn/go-runewidth"
 	"github.com/spf13/viper"
 )
@@ -56,6 +68,25 @@ type (
 
 	// TaskDeleteErrorMsg is returned when a Task fails to delete from disk.
 	TaskDeleteErrorMsg struct{ Err error }
+
+	// TaskArchiveDoneMsg indicates that a Task was successfully moved into
+	// or out of the project's archive directory.
+	TaskArchiveDoneMsg struct{ Task Task }
+
+	// TaskArchiveErrorMsg is returned when a Task fails to move into
+	// or out of the project's archive directory.
+	TaskArchiveErrorMsg struct{ Err error }
+
+	// TaskMoveDoneMsg indicates that a Task was successfully moved
+	// into the directory of another project.
+	TaskMoveDoneMsg struct {
+		Task    Task
+		Project Project
+	}
+
+	// TaskMoveErrorMsg is returned when a Task fails to move
+	// into the directory of another project.
+	TaskMoveErrorMsg struct{ Err error }
 )
 
 // Error implements the error interface for WriteTaskJSONErrorMsg.
@@ -64,19 +95,582 @@ func (e WriteTaskJSONErrorMsg) Error() string { return e.Err.Error() }
 // Error implements the error interface for TaskDeleteErrorMsg.
 func (e TaskDeleteErrorMsg) Error() string { return e.Err.Error() }
 
+// Error implements the error interface for TaskArchiveErrorMsg.
+func (e TaskArchiveErrorMsg) Error() string { return e.Err.Error() }
+
 // Task represents a to-do item with metadata like title, due date, priority,
 // and labels. Tasks are serialized to and from JSON files in storage.
+// Archived is not serialized but derived from the task file's location.
 type Task struct {
-	ID          string     `json:"id"`
-	Title       string     `json:"title"`
-	Description string     `json:"description,omitempty"`
-	Priority    string     `json:"priority"`
-	Labels      Labels     `json:"labels,omitempty"`
-	Author      string     `json:"author,omitempty"`
-	Assignee    string     `json:"assignee,omitempty"`
-	InProgress  bool       `json:"in_progress"`
-	Completed   bool       `json:"completed"`
-	DueDate     *time.Time `json:"due_date,omitempty"`
+	ID          string      `json:"id"`
+	Title       string      `json:"title"`
+	Description string      `json:"description,omitempty"`
+	Priority    string      `json:"priority"`
+	Labels      Labels      `json:"labels,omitempty"`
+	Author      string      `json:"author,omitempty"`
+	Assignee    string      `json:"assignee,omitempty"`
+	Watchers    []string    `json:"watchers,omitempty"`
+	InProgress  bool        `json:"in_progress"`
+	Completed   bool        `json:"completed"`
+	DueDate     *time.Time  `json:"due_date,omitempty"`
+	StartDate   *time.Time  `json:"start_date,omitempty"`
+	Estimate    Estimate    `json:"estimate,omitempty"`
+	Reminders   Reminders   `json:"reminders,omitempty"`
+	Subtasks    Subtasks    `json:"subtasks,omitempty"`
+	Recurrence  *Recurrence `json:"recurrence,omitempty"`
+	BlockedBy   []string    `json:"blocked_by,omitempty"`
+	Order       int         `json:"order,omitempty"`
+	Pomodoros   []time.Time `json:"pomodoros,omitempty"`
+	External    *External   `json:"external,omitempty"`
+	CreatedAt   *time.Time  `json:"created_at,omitempty"`
+	UpdatedAt   *time.Time  `json:"updated_at,omitempty"`
+	CompletedAt *time.Time  `json:"completed_at,omitempty"`
+	Comments    []Comment   `json:"comments,omitempty"`
+	DueChanges  []DueChange `json:"due_changes,omitempty"`
+	// Successor is the ID of the next occurrence that was created when
+	// the recurring task was completed.
+	Successor string `json:"successor,omitempty"`
+	// Fields holds the values of the project's custom fields by name.
+	Fields   map[string]string `json:"fields,omitempty"`
+	Archived bool              `json:"-"`
+}
+
+// Comment is a note on a task, e.g. about progress or a decision.
+// Body is Markdown.
+type Comment struct {
+	Author string    `json:"author,omitempty"`
+	Time   time.Time `json:"time"`
+	Body   string    `json:"body"`
+}
+
+// authorName returns the comment's author without the email address.
+func (c Comment) authorName() string {
+	if i := strings.LastIndex(c.Author, " <"); i >= 0 {
+		return c.Author[:i]
+	}
+
+	return cmp.Or(c.Author, "Unknown")
+}
+
+// DueChange records a change of the due date of a task.
+// From or To is nil if the task had no due date before or after.
+type DueChange struct {
+	Time time.Time  `json:"time"`
+	From *time.Time `json:"from,omitempty"`
+	To   *time.Time `json:"to,omitempty"`
+}
+
+// RecordDueChange adds a DueChange at the given time to the task's
+// due date history if its due date differs from old.
+func (t *Task) RecordDueChange(old *time.Time, now time.Time) {
+	switch {
+	case old == nil && t.DueDate == nil:
+		return
+	case old != nil && t.DueDate != nil && old.Equal(*t.DueDate):
+		return
+	}
+
+	change := DueChange{Time: now}
+	if old != nil {
+		from := *old
+		change.From = &from
+	}
+	if t.DueDate != nil {
+		to := *t.DueDate
+		change.To = &to
+	}
+
+	t.DueChanges = append(t.DueChanges, change)
+}
+
+// Postponed returns how often the due date of the task was moved
+// to a later date.
+func (t *Task) Postponed() int {
+	n := 0
+	for _, change := range t.DueChanges {
+		if change.From != nil && change.To != nil && change.To.After(*change.From) {
+			n++
+		}
+	}
+
+	return n
+}
+
+// External links a task to the item of another service it was imported from.
+type External struct {
+	// Source names the service, e.g. "github".
+	Source string `json:"source"`
+	// ID identifies the item within the service, e.g. "owner/repo#12".
+	ID string `json:"id"`
+	// URL points to the item in the service's web interface.
+	URL string `json:"url,omitempty"`
+}
+
+// Estimate is the expected effort of a task. It is stored in JSON
+// as a duration string like "1h30m".
+type Estimate time.Duration
+
+// ParseEstimate parses a duration like "45m" or "1h30m" into an Estimate.
+// An empty string yields no estimate.
+func ParseEstimate(str string) (Estimate, error) {
+	str = strings.TrimSpace(str)
+	if str == "" {
+		return 0, nil
+	}
+
+	d, err := time.ParseDuration(str)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid estimate: %q", str)
+	}
+
+	return Estimate(d), nil
+}
+
+// String returns the estimate without zero trailing units, e.g. "2h"
+// instead of "2h0m0s". It returns an empty string for no estimate.
+func (e Estimate) String() string {
+	if e == 0 {
+		return ""
+	}
+
+	s := time.Duration(e).String()
+	if strings.HasSuffix(s, "m0s") {
+		s = strings.TrimSuffix(s, "0s")
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+
+	return s
+}
+
+// MarshalJSON implements the json.Marshaler interface for Estimate.
+func (e Estimate) MarshalJSON() ([]byte, error) {
+	return json.Marshal(e.String())
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface for Estimate.
+func (e *Estimate) UnmarshalJSON(data []byte) error {
+	var str string
+	if err := json.Unmarshal(data, &str); err != nil {
+		return err
+	}
+
+	estimate, err := ParseEstimate(str)
+	if err != nil {
+		return err
+	}
+
+	*e = estimate
+	return nil
+}
+
+// Recurrence describes the schedule of a repeating task.
+// The next occurrence is due Interval units of Frequency after the current one.
+type Recurrence struct {
+	Frequency string `json:"frequency"`
+	Interval  int    `json:"interval,omitempty"`
+}
+
+// recurrenceRegex matches custom recurrence intervals like "every 3 days".
+var recurrenceRegex = regexp.MustCompile(`^every (\d+) (day|week|month|year)s?$`)
+
+// ParseRecurrence parses a recurrence description into a Recurrence.
+// Valid inputs are "daily", "weekly", "monthly", "yearly" and
+// "every <n> days|weeks|months|years". An empty string returns nil.
+func ParseRecurrence(str string) (*Recurrence, error) {
+	str = strings.ToLower(strings.TrimSpace(str))
+
+	switch str {
+	case "":
+		return nil, nil
+	case "daily", "weekly", "monthly", "yearly":
+		return &Recurrence{Frequency: str, Interval: 1}, nil
+	}
+
+	matches := recurrenceRegex.FindStringSubmatch(str)
+	if len(matches) != 3 {
+		return nil, fmt.Errorf("unknown recurrence: %q", str)
+	}
+
+	interval, err := strconv.Atoi(matches[1])
+	if err != nil || interval < 1 {
+		return nil, fmt.Errorf("invalid recurrence interval: %q", matches[1])
+	}
+
+	frequencies := map[string]string{
+		"day":   "daily",
+		"week":  "weekly",
+		"month": "monthly",
+		"year":  "yearly",
+	}
+
+	return &Recurrence{Frequency: frequencies[matches[2]], Interval: interval}, nil
+}
+
+// String returns a human-readable representation of the recurrence
+// that can be parsed again by ParseRecurrence.
+func (r *Recurrence) String() string {
+	if r == nil {
+		return ""
+	}
+
+	if r.Interval <= 1 {
+		return r.Frequency
+	}
+
+	units := map[string]string{
+		"daily":   "days",
+		"weekly":  "weeks",
+		"monthly": "months",
+		"yearly":  "years",
+	}
+
+	return fmt.Sprintf("every %d %s", r.Interval, units[r.Frequency])
+}
+
+// Next returns the given time shifted by the recurrence interval.
+func (r *Recurrence) Next(t time.Time) time.Time {
+	interval := max(r.Interval, 1)
+
+	switch r.Frequency {
+	case "daily":
+		return t.AddDate(0, 0, interval)
+	case "weekly":
+		return t.AddDate(0, 0, 7*interval)
+	case "monthly":
+		return t.AddDate(0, interval, 0)
+	case "yearly":
+		return t.AddDate(interval, 0, 0)
+	default:
+		return t
+	}
+}
+
+// NextOccurrence returns a new open task with a fresh ID that follows
+// the task according to its recurrence. The due date is shifted by the
+// recurrence interval, starting from today if the task has no due date.
+// All subtasks of the new task are reset to open.
+// Returns nil if the task does not recur.
+func (t *Task) NextOccurrence() *Task {
+	if t.Recurrence == nil {
+		return nil
+	}
+
+	base := time.Now()
+	base = time.Date(base.Year(), base.Month(), base.Day(), 0, 0, 0, 0, base.Location())
+	if t.DueDate != nil {
+		base = *t.DueDate
+	}
+	dueDate := t.Recurrence.Next(base)
+
+	// Keep the distance between start and due date.
+	var startDate *time.Time
+	if t.StartDate != nil {
+		s := t.Recurrence.Next(*t.StartDate)
+		if t.DueDate != nil {
+			s = dueDate.Add(t.StartDate.Sub(*t.DueDate))
+		}
+		startDate = &s
+	}
+
+	subtasks := make(Subtasks, 0, len(t.Subtasks))
+	for _, subtask := range t.Subtasks {
+		subtasks = append(subtasks, Subtask{Title: subtask.Title})
+	}
+
+	recurrence := *t.Recurrence
+
+	return &Task{
+		ID:          uuid.NewString(),
+		Title:       t.Title,
+		Description: t.Description,
+		Priority:    t.Priority,
+		Labels:      append(Labels{}, t.Labels...),
+		Author:      t.Author,
+		Assignee:    t.Assignee,
+		Watchers:    slices.Clone(t.Watchers),
+		DueDate:     &dueDate,
+		StartDate:   startDate,
+		Estimate:    t.Estimate,
+		Reminders:   slices.Clone(t.Reminders),
+		Fields:      maps.Clone(t.Fields),
+		Subtasks:    subtasks,
+		Recurrence:  &recurrence,
+	}
+}
+
+// Recur returns the next occurrence to be created when the recurring task
+// is completed and records it as the task's successor. Returns nil if the
+// task does not recur or its successor was created before, e.g. when the
+// task is completed again after being reopened.
+func (t *Task) Recur() *Task {
+	if t.Successor != "" {
+		return nil
+	}
+
+	next := t.NextOccurrence()
+	if next != nil {
+		t.Successor = next.ID
+	}
+
+	return next
+}
+
+// Duplicate returns an open copy of the task with a fresh ID, to be used
+// as the starting point of a similar task. Subtasks are reset to open,
+// pomodoros and the link to an external issue are not copied.
+// The due date of a recurring task is shifted to its next occurrence,
+// so that the copy does not duplicate the current one.
+func (t *Task) Duplicate() *Task {
+	if next := t.NextOccurrence(); next != nil {
+		next.BlockedBy = slices.Clone(t.BlockedBy)
+		return next
+	}
+
+	subtasks := make(Subtasks, 0, len(t.Subtasks))
+	for _, subtask := range t.Subtasks {
+		subtasks = append(subtasks, Subtask{Title: subtask.Title})
+	}
+
+	var dueDate *time.Time
+	if t.DueDate != nil {
+		d := *t.DueDate
+		dueDate = &d
+	}
+
+	var startDate *time.Time
+	if t.StartDate != nil {
+		s := *t.StartDate
+		startDate = &s
+	}
+
+	return &Task{
+		ID:          uuid.NewString(),
+		Title:       t.Title,
+		Description: t.Description,
+		Priority:    t.Priority,
+		Labels:      append(Labels{}, t.Labels...),
+		Author:      t.Author,
+		Assignee:    t.Assignee,
+		Watchers:    slices.Clone(t.Watchers),
+		DueDate:     dueDate,
+		StartDate:   startDate,
+		Estimate:    t.Estimate,
+		Reminders:   slices.Clone(t.Reminders),
+		Fields:      maps.Clone(t.Fields),
+		Subtasks:    subtasks,
+		BlockedBy:   slices.Clone(t.BlockedBy),
+	}
+}
+
+// CopyOpenTasks duplicates the open tasks among the given ones, e.g.
+// to take them over into a copy of their project. Blockers are changed
+// to the copies of the blocking tasks and dropped if the blocking task
+// is not copied. The manual order of the tasks is kept.
+func CopyOpenTasks(tasks []Task) []*Task {
+	var copies []*Task
+	ids := make(map[string]string)
+
+	for _, task := range tasks {
+		if task.Completed {
+			continue
+		}
+
+		c := task.Duplicate()
+		c.Order = task.Order
+		ids[task.ID] = c.ID
+		copies = append(copies, c)
+	}
+
+	for _, c := range copies {
+		var blockedBy []string
+		for _, id := range c.BlockedBy {
+			if newID, ok := ids[id]; ok {
+				blockedBy = append(blockedBy, newID)
+			}
+		}
+		c.BlockedBy = blockedBy
+	}
+
+	return copies
+}
+
+// StateChangeCommitMessage returns the commit message used when the
+// action state (e.g. "progress" or "completion") of the named tasks changes.
+// Next occurrences of completed recurring tasks are listed separately.
+func StateChangeCommitMessage(action string, taskNames, recurNames []string) string {
+	msg := fmt.Sprintf("Change %s state of %d task(s)\n\n- %s",
+		action, len(taskNames), strings.Join(taskNames, "\n- "))
+
+	if len(recurNames) > 0 {
+		msg += fmt.Sprintf("\n\nCreate %d next occurrence(s)\n\n- %s",
+			len(recurNames), strings.Join(recurNames, "\n- "))
+	}
+
+	return msg
+}
+
+// ArchiveCommitMessage returns the commit message used when the named
+// tasks are moved into or restored from a project's archive.
+func ArchiveCommitMessage(archivedNames, restoredNames []string) string {
+	var parts []string
+
+	if len(archivedNames) > 0 {
+		parts = append(parts, fmt.Sprintf("Archive %d task(s)\n\n- %s",
+			len(archivedNames), strings.Join(archivedNames, "\n- ")))
+	}
+
+	if len(restoredNames) > 0 {
+		parts = append(parts, fmt.Sprintf("Restore %d task(s) from archive\n\n- %s",
+			len(restoredNames), strings.Join(restoredNames, "\n- ")))
+	}
+
+	return strings.Join(parts, "\n\n")
+}
+
+// Subtask represents a single checklist entry of a task.
+type Subtask struct {
+	Title string `json:"title"`
+	Done  bool   `json:"done"`
+}
+
+// Subtasks is a custom type for a task's checklist.
+type Subtasks []Subtask
+
+// Progress returns the number of done subtasks and the total number of subtasks.
+func (s Subtasks) Progress() (int, int) {
+	done := 0
+	for _, subtask := range s {
+		if subtask.Done {
+			done++
+		}
+	}
+
+	return done, len(s)
+}
+
+// String returns the subtasks as a newline-separated checklist.
+// Done subtasks are prefixed with "[x] ", open ones with "[ ] ".
+func (s Subtasks) String() string {
+	lines := make([]string, 0, len(s))
+	for _, subtask := range s {
+		if subtask.Done {
+			lines = append(lines, "[x] "+subtask.Title)
+		} else {
+			lines = append(lines, "[ ] "+subtask.Title)
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// ParseSubtasks parses a newline-separated checklist into Subtasks.
+// Each non-empty line becomes a subtask. Lines may optionally start with
+// a markdown list marker ("- " or "* ") and a checkbox ("[ ]" or "[x]").
+// A checked box marks the subtask as done.
+func ParseSubtasks(str string) Subtasks {
+	var result Subtasks
+
+	for line := range strings.SplitSeq(str, "\n") {
+		line = strings.TrimSpace(line)
+		line = strings.TrimPrefix(line, "- ")
+		line = strings.TrimPrefix(line, "* ")
+
+		done := false
+		switch {
+		case strings.HasPrefix(line, "[x]"), strings.HasPrefix(line, "[X]"):
+			done = true
+			line = line[3:]
+		case strings.HasPrefix(line, "[ ]"):
+			line = line[3:]
+		}
+
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		result = append(result, Subtask{Title: line, Done: done})
+	}
+
+	return result
+}
+
+// checkboxRegex matches a markdown task list item like "- [ ] Call Bob"
+// or "1. [x] Send report". The second group holds the state of the box.
+var checkboxRegex = regexp.MustCompile(`^(\s*(?:[-*+]|\d+[.)])\s+\[)([ xX])(\]\s+(\S.*))$`)
+
+// checkboxLines returns the indices of the lines holding a task list
+// item. Lines inside fenced code blocks are skipped.
+func checkboxLines(lines []string) []int {
+	var result []int
+
+	fenced := false
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			fenced = !fenced
+			continue
+		}
+
+		if !fenced && checkboxRegex.MatchString(line) {
+			result = append(result, i)
+		}
+	}
+
+	return result
+}
+
+// DescriptionCheckboxes returns the markdown task list items
+// ("- [ ]" and "- [x]") found in the task's description.
+func (t *Task) DescriptionCheckboxes() Subtasks {
+	lines := strings.Split(t.Description, "\n")
+
+	var result Subtasks
+	for _, i := range checkboxLines(lines) {
+		match := checkboxRegex.FindStringSubmatch(lines[i])
+		result = append(result, Subtask{
+			Title: strings.TrimSpace(match[4]),
+			Done:  match[2] != " ",
+		})
+	}
+
+	return result
+}
+
+// ToggleDescriptionCheckbox checks or unchecks the idx-th checkbox
+// returned by DescriptionCheckboxes. The rest of the description
+// is left untouched. Reports whether the checkbox exists.
+func (t *Task) ToggleDescriptionCheckbox(idx int) bool {
+	lines := strings.Split(t.Description, "\n")
+
+	indices := checkboxLines(lines)
+	if idx < 0 || idx >= len(indices) {
+		return false
+	}
+
+	line := lines[indices[idx]]
+	match := checkboxRegex.FindStringSubmatch(line)
+
+	state := "x"
+	if match[2] != " " {
+		state = " "
+	}
+
+	lines[indices[idx]] = match[1] + state + match[3]
+	t.Description = strings.Join(lines, "\n")
+
+	return true
+}
+
+// ChecklistProgress returns the number of done items and the total
+// number of items of the task's subtasks and description checkboxes.
+func (t *Task) ChecklistProgress() (int, int) {
+	done, total := t.Subtasks.Progress()
+	checkedBoxes, boxes := t.DescriptionCheckboxes().Progress()
+
+	return done + checkedBoxes, total + boxes
 }
 
 // Labels is a custom type for task labels to handle both string and array formats in JSON.
@@ -124,8 +718,54 @@ func (t *Task) LabelsList() []string {
 	return t.Labels
 }
 
-// FilterValue returns a string used for filtering/search, combining title and labels.
-func (t *Task) FilterValue() string { return fmt.Sprintf("%s %s", t.Title, t.Labels.String()) }
+// HasLabels reports whether the task carries the given labels, compared
+// case-insensitively. If all is true, every label must be present,
+// otherwise any one of them suffices. An empty list matches every task.
+func (t *Task) HasLabels(labels []string, all bool) bool {
+	if len(labels) == 0 {
+		return true
+	}
+
+	for _, label := range labels {
+		found := slices.ContainsFunc(t.Labels, func(l string) bool {
+			return strings.EqualFold(l, label)
+		})
+		if found && !all {
+			return true
+		}
+		if !found && all {
+			return false
+		}
+	}
+
+	return all
+}
+
+// Watching reports whether the user watches the task.
+func (t *Task) Watching(user string) bool {
+	return user != "" && slices.Contains(t.Watchers, user)
+}
+
+// OwnedBy reports whether the user created the task or is its assignee.
+func (t *Task) OwnedBy(user string) bool {
+	return user != "" && (t.Author == user || t.Assignee == user)
+}
+
+// Involves reports whether the task is assigned to or watched by the user.
+func (t *Task) Involves(user string) bool {
+	return user != "" && (t.Assignee == user || t.Watching(user))
+}
+
+// FilterValue returns a string used for filtering/search, combining title, labels
+// and custom fields as "name:value" terms, see FieldFilterKey.
+func (t *Task) FilterValue() string {
+	value := fmt.Sprintf("%s %s", t.Title, t.Labels.String())
+	for _, name := range slices.Sorted(maps.Keys(t.Fields)) {
+		value += " " + FieldFilterKey(name) + ":" + t.Fields[name]
+	}
+
+	return value
+}
 
 // CropTaskTitle returns the task's title cropped to fit
 // length with a concatenated ellipses.
@@ -170,6 +810,22 @@ func (t *Task) DueDateToString() string {
 	return ""
 }
 
+// StartDateToString formats the task's start date as a string using time.DateTime.
+// Returns an empty string if no start date is set.
+func (t *Task) StartDateToString() string {
+	if t.StartDate != nil {
+		return t.StartDate.Format(time.DateTime)
+	}
+
+	return ""
+}
+
+// Deferred reports whether the task has a start date after now.
+// Deferred tasks are hidden until their start date arrives.
+func (t *Task) Deferred(now time.Time) bool {
+	return t.StartDate != nil && t.StartDate.After(now)
+}
+
 // DaysUntilToString returns a string containing the full days from now until the due date.
 // If the date is in the past, it returns a negative value.
 // Returns "no due date" if executed on a task with missing due date.
@@ -198,6 +854,19 @@ func (t *Task) DaysUntilToString() string {
 	return "no due date"
 }
 
+// DueText describes when the task is due for badges, "due today" or
+// "due in N day(s)". Returns an empty string if no due date is set.
+func (t *Task) DueText() string {
+	switch {
+	case t.DueDate == nil:
+		return ""
+	case IsToday(t.DueDate):
+		return "due today"
+	default:
+		return "due in " + t.DaysUntilToString() + " day(s)"
+	}
+}
+
 // PriorityValue returns a numeric value for the task's priority.
 // Useful for sorting tasks by urgency.
 func (t *Task) PriorityValue() int {
@@ -228,41 +897,303 @@ func (t *Task) MarshalTask() []byte {
 	return bytes.TrimSuffix(buf.Bytes(), []byte("\n"))
 }
 
-// WriteTaskJSON writes the given task JSON to disk under the project directory,
-// using the task's ID as the filename. Returns a Tea message on success or error.
-func (t *Task) WriteTaskJSON(v *viper.Viper, json []byte, p Project, kind string) tea.Cmd {
+// Path returns the path of the task's JSON file relative to the storage path.
+// Archived tasks are located in the project's archive directory.
+func (t *Task) Path(p Project) string {
+	if t.Archived {
+		return filepath.Join(p.ID, ArchiveDir, t.ID+".json")
+	}
+
+	return filepath.Join(p.ID, t.ID+".json")
+}
+
+// WriteTaskJSON writes the task as JSON to disk under the project directory,
+// using the task's ID as the filename. The task's timestamps are updated
+// first, see Stamp, except when only its order changed ("reorder") or
+// it is imported from a backup ("import"). Afterwards, created ("create",
+// "recur") and completed ("complete") tasks are counted for the day, see
+// storage.CountTasks. Their hooks are run once the change is committed,
+// see HookCalls.
+// Tasks not matching the task schema are not written, see ValidateJSON.
+// Returns a Tea message on success or error.
+func (t *Task) WriteTaskJSON(v *viper.Viper, p Project, kind string) tea.Cmd {
+	if kind != "reorder" && kind != "import" {
+		t.Stamp(time.Now())
+	}
+	json := t.MarshalTask()
+
 	return func() tea.Msg {
-		root, err := os.OpenRoot(v.GetString("storage.path"))
+		// Invalid tasks would be skipped by everyone reading the storage.
+		if err := ValidateJSON(TaskSchema, json); err != nil {
+			return WriteTaskJSONErrorMsg{fmt.Errorf("refusing to write task %q: %w", t.Title, err)}
+		}
+
+		root, err := os.OpenRoot(config.Get(v).StoragePath)
 		if err != nil {
 			panic(fmt.Errorf("could not open storage directory: %w", err))
 		}
 		defer root.Close() //nolint:errcheck
 
-		file := filepath.Join(p.ID, t.ID+".json")
+		// The archive directory does not exist before the first task is archived.
+		if err := root.MkdirAll(filepath.Dir(t.Path(p)), 0o700); err != nil {
+			return WriteTaskJSONErrorMsg{err}
+		}
 
-		if err := root.WriteFile(file, json, 0o600); err != nil {
+		if err := storage.AtomicWrite(root, t.Path(p), json, 0o600); err != nil {
 			return WriteTaskJSONErrorMsg{err}
 		}
+		updateIndex(root, t.Path(p), t)
+
+		// Own changes are not reported as unseen, see Task.Unseen. Reordering
+		// does not change the task and may not mark other changes as seen.
+		if kind != "reorder" && t.UpdatedAt != nil {
+			_ = storage.MarkSeen(v, t.ID, *t.UpdatedAt)
+		}
+
+		// The counts are a convenience for the burndown chart.
+		switch kind {
+		case "create", "recur":
+			_ = storage.CountTasks(v, p.ID, time.Now(), storage.DayStats{Created: 1})
+		case "complete":
+			_ = storage.CountTasks(v, p.ID, time.Now(), storage.DayStats{Completed: 1})
+		}
 
 		return WriteTaskJSONDoneMsg{Task: *t, Kind: kind}
 	}
 }
 
-// DeleteTaskFromFS deletes the task's JSON file from the given project directory.
-// Returns a Tea message on success or failure.
+// HookCalls returns the hooks to run once a change of the given kind,
+// see WriteTaskJSON, is committed. Created tasks ("create", "recur") are
+// sent to the task_created hooks and completed ones ("complete") to the
+// task_completed hooks. The task is sent as it is now, so HookCalls is
+// called right after WriteTaskJSON.
+func (t *Task) HookCalls(p Project, kind string) []hooks.Call {
+	var event hooks.Event
+	switch kind {
+	case "create", "recur":
+		event = hooks.TaskCreated
+	case "complete":
+		event = hooks.TaskCompleted
+	default:
+		return nil
+	}
+
+	return []hooks.Call{{Event: event, ProjectID: p.ID, Payload: t.MarshalTask()}}
+}
+
+// Stamp maintains the task's timestamps for a change made at the given
+// time. CreatedAt is only set once, CompletedAt is set when the task is
+// completed and cleared when it is reopened.
+func (t *Task) Stamp(now time.Time) {
+	if t.CreatedAt == nil {
+		created := now
+		t.CreatedAt = &created
+	}
+
+	t.UpdatedAt = &now
+
+	switch {
+	case !t.Completed:
+		t.CompletedAt = nil
+	case t.CompletedAt == nil:
+		completed := now
+		t.CompletedAt = &completed
+	}
+}
+
+// Unseen reports whether the task was changed since the user last saw
+// it, see storage.SeenTasks for seen and since.
+func (t *Task) Unseen(seen map[string]time.Time, since time.Time) bool {
+	if t.UpdatedAt == nil {
+		return false
+	}
+
+	if last, ok := seen[t.ID]; ok {
+		since = last
+	}
+
+	return t.UpdatedAt.After(since)
+}
+
+// DeleteTaskFromFS deletes the task's JSON file from the given project directory
+// along with the task's attachments. Returns a Tea message on success or failure.
 func (t *Task) DeleteTaskFromFS(v *viper.Viper, p Project) tea.Cmd {
 	return func() tea.Msg {
-		file := filepath.Join(v.GetString("storage.path"), p.ID, t.ID+".json")
-
-		err := os.Remove(file)
+		root, err := os.OpenRoot(config.Get(v).StoragePath)
 		if err != nil {
+			return TaskDeleteErrorMsg{fmt.Errorf("could not open storage directory: %w", err)}
+		}
+		defer root.Close() //nolint:errcheck
+
+		if err := root.Remove(t.Path(p)); err != nil {
 			return TaskDeleteErrorMsg{err}
 		}
+		updateIndex(root, t.Path(p), nil)
+
+		if err := root.RemoveAll(t.AttachmentsDir(p)); err != nil {
+			return TaskDeleteErrorMsg{err}
+		}
+		logging.Debug("deleted task", "file", t.Path(p))
 
 		return TaskDeleteDoneMsg{*t}
 	}
 }
 
+// ArchiveTaskOnFS moves the task's JSON file into the project's archive
+// directory if archive is true, or back into the project directory otherwise.
+// The returned message holds a copy of the task with Archived updated.
+// Returns a Tea message on success or failure.
+func (t *Task) ArchiveTaskOnFS(v *viper.Viper, p Project, archive bool) tea.Cmd {
+	return func() tea.Msg {
+		root, err := os.OpenRoot(config.Get(v).StoragePath)
+		if err != nil {
+			return TaskArchiveErrorMsg{fmt.Errorf("could not open storage directory: %w", err)}
+		}
+		defer root.Close() //nolint:errcheck
+
+		if err := root.MkdirAll(filepath.Join(p.ID, ArchiveDir), 0o700); err != nil {
+			return TaskArchiveErrorMsg{err}
+		}
+
+		task := *t
+		oldPath := task.Path(p)
+		task.Archived = archive
+
+		if err := root.Rename(oldPath, task.Path(p)); err != nil {
+			return TaskArchiveErrorMsg{err}
+		}
+		updateIndex(root, oldPath, nil)
+		updateIndex(root, task.Path(p), &task)
+		logging.Debug("moved task", "from", oldPath, "to", task.Path(p))
+
+		return TaskArchiveDoneMsg{task}
+	}
+}
+
+// MoveTaskOnFS moves the task's JSON file and attachments from project
+// from into project to. Archived tasks stay archived.
+// Returns a Tea message on success or failure.
+func (t *Task) MoveTaskOnFS(v *viper.Viper, from, to Project) tea.Cmd {
+	return func() tea.Msg {
+		root, err := os.OpenRoot(config.Get(v).StoragePath)
+		if err != nil {
+			return TaskMoveErrorMsg{fmt.Errorf("could not open storage directory: %w", err)}
+		}
+		defer root.Close() //nolint:errcheck
+
+		if err := root.MkdirAll(filepath.Dir(t.Path(to)), 0o700); err != nil {
+			return TaskMoveErrorMsg{err}
+		}
+
+		if err := root.Rename(t.Path(from), t.Path(to)); err != nil {
+			return TaskMoveErrorMsg{err}
+		}
+		updateIndex(root, t.Path(from), nil)
+		updateIndex(root, t.Path(to), t)
+		logging.Debug("moved task", "from", t.Path(from), "to", t.Path(to))
+
+		err = root.Rename(t.AttachmentsDir(from), t.AttachmentsDir(to))
+		if err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return TaskMoveErrorMsg{err}
+		}
+
+		return TaskMoveDoneMsg{Task: *t, Project: to}
+	}
+}
+
+// BulkEdit describes changes made to several tasks at once.
+// Empty fields leave the corresponding values of the tasks unchanged.
+type BulkEdit struct {
+	Priority      string
+	DueDate       *time.Time
+	ClearDueDate  bool
+	Assignee      string
+	ClearAssignee bool
+	AddLabel      string
+	RemoveLabel   string
+}
+
+// Apply makes the changes described by e to t and reports whether t
+// changed. Labels are compared case-insensitively, as in the task form.
+// Changes of the due date are recorded, see RecordDueChange.
+func (e BulkEdit) Apply(t *Task) bool {
+	changed := false
+
+	if e.Priority != "" && e.Priority != t.Priority {
+		t.Priority = e.Priority
+		changed = true
+	}
+
+	switch oldDueDate := t.DueDate; {
+	case e.ClearDueDate && t.DueDate != nil:
+		t.DueDate = nil
+		t.RecordDueChange(oldDueDate, time.Now())
+		changed = true
+	case e.DueDate != nil && (t.DueDate == nil || !t.DueDate.Equal(*e.DueDate)):
+		dueDate := *e.DueDate
+		t.DueDate = &dueDate
+		t.RecordDueChange(oldDueDate, time.Now())
+		changed = true
+	}
+
+	switch {
+	case e.ClearAssignee && t.Assignee != "":
+		t.Assignee = ""
+		changed = true
+	case e.Assignee != "" && e.Assignee != t.Assignee:
+		t.Assignee = e.Assignee
+		changed = true
+	}
+
+	if label := strings.TrimSpace(e.RemoveLabel); label != "" {
+		labels := slices.DeleteFunc(slices.Clone(t.Labels), func(l string) bool {
+			return strings.EqualFold(l, label)
+		})
+		if len(labels) != len(t.Labels) {
+			t.Labels = labels
+			changed = true
+		}
+	}
+
+	if label := strings.TrimSpace(e.AddLabel); label != "" {
+		if !slices.ContainsFunc(t.Labels, func(l string) bool { return strings.EqualFold(l, label) }) {
+			t.Labels = append(t.Labels, label)
+			changed = true
+		}
+	}
+
+	return changed
+}
+
+// OpenBlockers returns the tasks among the given ones that block
+// this task and are not completed yet. Blockers that cannot be found,
+// e.g. because they were deleted, are ignored.
+func (t *Task) OpenBlockers(tasks []Task) []Task {
+	var open []Task
+	for _, task := range tasks {
+		if !task.Completed && slices.Contains(t.BlockedBy, task.ID) {
+			open = append(open, task)
+		}
+	}
+
+	return open
+}
+
+// Renumber sets the Order of the given tasks to their position in the
+// slice, starting at 1. It returns the tasks whose Order was changed
+// and therefore need to be written.
+func Renumber(tasks []*Task) []*Task {
+	var changed []*Task
+	for i, t := range tasks {
+		if t.Order != i+1 {
+			t.Order = i + 1
+			changed = append(changed, t)
+		}
+	}
+
+	return changed
+}
+
 // FindListIndexByID returns the index of the task in the given slice of list.Item,
 // or -1 if not found.
 func (t *Task) FindListIndexByID(items []list.Item) int {
@@ -291,6 +1222,15 @@ func (t *Task) TaskToMarkdown() string {
 		content.WriteString("*No description provided.*\n\n")
 	}
 
+	// Comments
+	if len(t.Comments) > 0 {
+		content.WriteString("### Comments\n\n")
+		for _, comment := range t.Comments {
+			fmt.Fprintf(&content, "**%s** · %s\n\n%s\n\n",
+				comment.authorName(), comment.Time.Local().Format("Mon, 02 Jan 2006 15:04"), comment.Body)
+		}
+	}
+
 	content.WriteString("---\n\n")
 
 	// Metadata
@@ -307,8 +1247,32 @@ func (t *Task) TaskToMarkdown() string {
 	fmt.Fprintf(&content, "| **Status** | %s |\n", status)
 	fmt.Fprintf(&content, "| **Priority** | %s |\n", strings.ToUpper(t.Priority))
 
+	if t.StartDate != nil {
+		fmt.Fprintf(&content, "| **Start Date** | %s |\n", t.StartDate.Format(time.RFC1123))
+	}
+
 	if t.DueDate != nil {
-		fmt.Fprintf(&content, "| **Due Date** | %s |\n", t.DueDate.Format(time.RFC1123))
+		postponed := ""
+		switch n := t.Postponed(); n {
+		case 0:
+		case 1:
+			postponed = " (postponed once)"
+		default:
+			postponed = fmt.Sprintf(" (postponed %d times)", n)
+		}
+		fmt.Fprintf(&content, "| **Due Date** | %s%s |\n", t.DueDate.Format(time.RFC1123), postponed)
+	}
+
+	if t.Estimate != 0 {
+		fmt.Fprintf(&content, "| **Estimate** | %s |\n", t.Estimate)
+	}
+
+	if len(t.Reminders) > 0 {
+		fmt.Fprintf(&content, "| **Reminders** | %s before due |\n", t.Reminders)
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(t.Fields)) {
+		fmt.Fprintf(&content, "| **%s** | %s |\n", name, t.Fields[name])
 	}
 
 	if t.Author != "" {
@@ -319,11 +1283,56 @@ func (t *Task) TaskToMarkdown() string {
 		fmt.Fprintf(&content, "| **Assignee** | %s |\n", t.Assignee)
 	}
 
+	if len(t.Watchers) > 0 {
+		fmt.Fprintf(&content, "| **Watchers** | %s |\n", strings.Join(t.Watchers, ", "))
+	}
+
 	if len(t.Labels) > 0 {
 		fmt.Fprintf(&content, "| **Labels** | %s |\n", strings.Join(t.Labels, ", "))
 	}
 
+	if t.Recurrence != nil {
+		fmt.Fprintf(&content, "| **Repeats** | %s |\n", t.Recurrence.String())
+	}
+
+	if len(t.Subtasks) > 0 {
+		done, total := t.Subtasks.Progress()
+		fmt.Fprintf(&content, "| **Subtasks** | %d/%d done |\n", done, total)
+	}
+
+	if len(t.Pomodoros) > 0 {
+		fmt.Fprintf(&content, "| **Pomodoros** | %d completed |\n", len(t.Pomodoros))
+	}
+
+	if t.CreatedAt != nil {
+		fmt.Fprintf(&content, "| **Created** | %s |\n", t.CreatedAt.Local().Format(time.RFC1123))
+	}
+
+	if t.UpdatedAt != nil {
+		fmt.Fprintf(&content, "| **Updated** | %s |\n", t.UpdatedAt.Local().Format(time.RFC1123))
+	}
+
+	if t.CompletedAt != nil {
+		fmt.Fprintf(&content, "| **Completed** | %s |\n", t.CompletedAt.Local().Format(time.RFC1123))
+	}
+
+	if t.External != nil {
+		fmt.Fprintf(&content, "| **Source** | [%s %s](%s) |\n", t.External.Source, t.External.ID, t.External.URL)
+	}
+
 	fmt.Fprintf(&content, "| **ID** | %s |\n", t.ID)
 
+	// Subtasks
+	if len(t.Subtasks) > 0 {
+		content.WriteString("\n### Subtasks\n\n")
+		for _, subtask := range t.Subtasks {
+			if subtask.Done {
+				fmt.Fprintf(&content, "- [x] %s\n", subtask.Title)
+			} else {
+				fmt.Fprintf(&content, "- [ ] %s\n", subtask.Title)
+			}
+		}
+	}
+
 	return content.String()
 }
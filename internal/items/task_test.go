@@ -21,13 +21,17 @@
 package items
 
 import (
+	"encoding/json"
 	"os"
 	"path/filepath"
+	"reflect"
+	"slices"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/handlebargh/yatto/internal/storage"
 	"github.com/spf13/viper"
 )
 
@@ -47,6 +51,100 @@ func TestTask_CropTaskLabels(t *testing.T) {
 	}
 }
 
+func TestTask_HasLabels(t *testing.T) {
+	task := &Task{Labels: Labels{"Bug", "ui"}}
+
+	tests := []struct {
+		labels []string
+		all    bool
+		want   bool
+	}{
+		{nil, false, true},
+		{nil, true, true},
+		{[]string{"bug"}, false, true},
+		{[]string{"bug", "docs"}, false, true},
+		{[]string{"bug", "docs"}, true, false},
+		{[]string{"bug", "UI"}, true, true},
+		{[]string{"docs"}, false, false},
+	}
+
+	for _, tt := range tests {
+		if got := task.HasLabels(tt.labels, tt.all); got != tt.want {
+			t.Errorf("HasLabels(%v, %t) = %t, want %t", tt.labels, tt.all, got, tt.want)
+		}
+	}
+}
+
+func TestTask_Involves(t *testing.T) {
+	jane := "Jane Doe jane@example.com"
+	john := "John Doe john@example.com"
+	task := &Task{Assignee: jane, Watchers: []string{john}}
+
+	if !task.Involves(jane) || task.Watching(jane) {
+		t.Errorf("Expected the assignee to be involved but not watching")
+	}
+	if !task.Involves(john) || !task.Watching(john) {
+		t.Errorf("Expected the watcher to be involved and watching")
+	}
+	if task.Involves("Max Doe max@example.com") || task.Involves("") {
+		t.Errorf("Expected other users not to be involved")
+	}
+}
+
+func TestTask_OwnedBy(t *testing.T) {
+	jane := "Jane Doe jane@example.com"
+	john := "John Doe john@example.com"
+	task := &Task{Author: jane, Assignee: john, Watchers: []string{"Max Doe max@example.com"}}
+
+	if !task.OwnedBy(jane) || !task.OwnedBy(john) {
+		t.Errorf("Expected the task to be owned by its author and assignee")
+	}
+	if task.OwnedBy("Max Doe max@example.com") || (&Task{}).OwnedBy("") {
+		t.Errorf("Expected watchers and unknown users not to own the task")
+	}
+}
+
+func TestEstimate(t *testing.T) {
+	tests := []struct {
+		input string
+		want  string
+	}{
+		{"", ""},
+		{"45m", "45m"},
+		{"2h", "2h"},
+		{" 1h30m ", "1h30m"},
+		{"90m", "1h30m"},
+	}
+
+	for _, tt := range tests {
+		e, err := ParseEstimate(tt.input)
+		if err != nil {
+			t.Errorf("ParseEstimate(%q) returned an error: %v", tt.input, err)
+		}
+		if e.String() != tt.want {
+			t.Errorf("ParseEstimate(%q) = %q, want %q", tt.input, e, tt.want)
+		}
+	}
+
+	for _, input := range []string{"soon", "-1h"} {
+		if _, err := ParseEstimate(input); err == nil {
+			t.Errorf("Expected ParseEstimate(%q) to fail", input)
+		}
+	}
+
+	task := &Task{Estimate: Estimate(90 * time.Minute)}
+	var decoded Task
+	if err := json.Unmarshal(task.MarshalTask(), &decoded); err != nil {
+		t.Fatalf("Unmarshal returned an error: %v", err)
+	}
+	if decoded.Estimate != task.Estimate {
+		t.Errorf("Expected estimate %s after round trip, but got %s", task.Estimate, decoded.Estimate)
+	}
+	if strings.Contains(string((&Task{}).MarshalTask()), "estimate") {
+		t.Errorf("Expected no estimate in JSON of a task without estimate")
+	}
+}
+
 func TestTask_DueDateToString(t *testing.T) {
 	now := time.Now()
 	task := &Task{DueDate: &now}
@@ -105,6 +203,10 @@ func TestTask_TaskToMarkdown(t *testing.T) {
 		InProgress:  true,
 		Completed:   false,
 		DueDate:     &dueDate,
+		Pomodoros:   []time.Time{dueDate, dueDate},
+		Comments: []Comment{
+			{Author: "Test User <test.user@example.com>", Time: dueDate, Body: "First *comment*"},
+		},
 	}
 	markdown := task.TaskToMarkdown()
 	if !strings.Contains(markdown, "# Test Task") {
@@ -119,6 +221,309 @@ func TestTask_TaskToMarkdown(t *testing.T) {
 	if !strings.Contains(markdown, "| **Priority** | HIGH |") {
 		t.Errorf("Expected markdown to contain the task priority, but it didn't")
 	}
+	if !strings.Contains(markdown, "| **Pomodoros** | 2 completed |") {
+		t.Errorf("Expected markdown to contain the number of pomodoros, but it didn't")
+	}
+	if !strings.Contains(markdown, "### Comments\n\n**Test User** · ") || !strings.Contains(markdown, "First *comment*") {
+		t.Errorf("Expected markdown to contain the comment thread, but it didn't")
+	}
+}
+
+func TestParseSubtasks(t *testing.T) {
+	subtasks := ParseSubtasks("[x] first\n- [ ] second\n\nthird\n* [X] fourth")
+	if len(subtasks) != 4 {
+		t.Fatalf("Expected 4 subtasks, but got %d", len(subtasks))
+	}
+
+	expected := Subtasks{
+		{Title: "first", Done: true},
+		{Title: "second", Done: false},
+		{Title: "third", Done: false},
+		{Title: "fourth", Done: true},
+	}
+	for i, subtask := range subtasks {
+		if subtask != expected[i] {
+			t.Errorf("Expected subtask %d to be %+v, but got %+v", i, expected[i], subtask)
+		}
+	}
+
+	if ParseSubtasks(subtasks.String()).String() != subtasks.String() {
+		t.Errorf("Expected subtasks to survive a string round trip")
+	}
+}
+
+func TestSubtasks_Progress(t *testing.T) {
+	subtasks := Subtasks{{Title: "a", Done: true}, {Title: "b"}, {Title: "c", Done: true}}
+	done, total := subtasks.Progress()
+	if done != 2 || total != 3 {
+		t.Errorf("Expected 2/3 subtasks done, but got %d/%d", done, total)
+	}
+}
+
+func TestTask_DescriptionCheckboxes(t *testing.T) {
+	task := &Task{
+		Description: "Intro\n\n" +
+			"- [ ] Call Bob\n" +
+			"  * [x] Send report\n" +
+			"1. [X] Book room\n" +
+			"- [ ]\n" +
+			"```\n" +
+			"- [ ] not a checkbox\n" +
+			"```\n" +
+			"- plain item",
+		Subtasks: Subtasks{{Title: "a", Done: true}},
+	}
+
+	checkboxes := task.DescriptionCheckboxes()
+	expected := Subtasks{
+		{Title: "Call Bob"},
+		{Title: "Send report", Done: true},
+		{Title: "Book room", Done: true},
+	}
+	if !reflect.DeepEqual(checkboxes, expected) {
+		t.Fatalf("Expected %v, but got %v", expected, checkboxes)
+	}
+
+	if done, total := task.ChecklistProgress(); done != 3 || total != 4 {
+		t.Errorf("Expected 3/4 checklist items done, but got %d/%d", done, total)
+	}
+
+	if !task.ToggleDescriptionCheckbox(0) || !task.ToggleDescriptionCheckbox(1) {
+		t.Fatal("Expected checkboxes to be toggled")
+	}
+	if task.ToggleDescriptionCheckbox(3) {
+		t.Error("Expected toggling a missing checkbox to fail")
+	}
+
+	if !strings.Contains(task.Description, "\n- [x] Call Bob\n  * [ ] Send report\n1. [X] Book room\n") {
+		t.Errorf("Unexpected description after toggling:\n%s", task.Description)
+	}
+	if !strings.Contains(task.Description, "```\n- [ ] not a checkbox\n```") {
+		t.Errorf("Expected code block to be left untouched:\n%s", task.Description)
+	}
+}
+
+func TestParseRecurrence(t *testing.T) {
+	cases := []struct {
+		input    string
+		expected *Recurrence
+		wantErr  bool
+	}{
+		{"", nil, false},
+		{"weekly", &Recurrence{Frequency: "weekly", Interval: 1}, false},
+		{"Every 3 Days", &Recurrence{Frequency: "daily", Interval: 3}, false},
+		{"every 1 month", &Recurrence{Frequency: "monthly", Interval: 1}, false},
+		{"every 0 days", nil, true},
+		{"fortnightly", nil, true},
+	}
+
+	for _, tc := range cases {
+		r, err := ParseRecurrence(tc.input)
+		if tc.wantErr {
+			if err == nil {
+				t.Errorf("Expected an error for %q, but got none", tc.input)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Unexpected error for %q: %v", tc.input, err)
+			continue
+		}
+		if (r == nil) != (tc.expected == nil) || (r != nil && *r != *tc.expected) {
+			t.Errorf("Expected %+v for %q, but got %+v", tc.expected, tc.input, r)
+		}
+	}
+
+	r := &Recurrence{Frequency: "weekly", Interval: 2}
+	if r.String() != "every 2 weeks" {
+		t.Errorf("Expected \"every 2 weeks\", but got %q", r.String())
+	}
+}
+
+func TestTask_NextOccurrence(t *testing.T) {
+	task := &Task{ID: uuid.NewString(), Title: "Chore"}
+	if task.NextOccurrence() != nil {
+		t.Fatalf("Expected no next occurrence for a non-recurring task")
+	}
+
+	dueDate := time.Date(2026, time.January, 31, 9, 0, 0, 0, time.Local)
+	task.DueDate = &dueDate
+	task.Completed = true
+	task.Subtasks = Subtasks{{Title: "step", Done: true}}
+	task.Recurrence = &Recurrence{Frequency: "weekly", Interval: 1}
+
+	next := task.NextOccurrence()
+	if next == nil {
+		t.Fatalf("Expected a next occurrence for a recurring task")
+	}
+	if next.ID == task.ID {
+		t.Errorf("Expected the next occurrence to have a new ID")
+	}
+	if next.Completed {
+		t.Errorf("Expected the next occurrence to be open")
+	}
+	if !next.DueDate.Equal(dueDate.AddDate(0, 0, 7)) {
+		t.Errorf("Expected due date %s, but got %s", dueDate.AddDate(0, 0, 7), next.DueDate)
+	}
+	if next.Subtasks[0].Done {
+		t.Errorf("Expected subtasks of the next occurrence to be reset")
+	}
+	if next.StartDate != nil {
+		t.Errorf("Expected no start date, but got %s", next.StartDate)
+	}
+
+	startDate := dueDate.AddDate(0, 0, -2)
+	task.StartDate = &startDate
+	next = task.NextOccurrence()
+	if next.StartDate == nil || !next.StartDate.Equal(startDate.AddDate(0, 0, 7)) {
+		t.Errorf("Expected start date %s, but got %v", startDate.AddDate(0, 0, 7), next.StartDate)
+	}
+}
+
+func TestTask_Recur(t *testing.T) {
+	task := &Task{ID: uuid.NewString(), Title: "Chore"}
+	if task.Recur() != nil {
+		t.Fatalf("Expected no next occurrence for a non-recurring task")
+	}
+
+	task.Recurrence = &Recurrence{Frequency: "daily", Interval: 1}
+
+	task.Completed = true
+	next := task.Recur()
+	if next == nil {
+		t.Fatalf("Expected a next occurrence on first completion")
+	}
+	if task.Successor != next.ID {
+		t.Errorf("Expected successor %s, but got %s", next.ID, task.Successor)
+	}
+	if next.Successor != "" {
+		t.Errorf("Expected the next occurrence to have no successor, but got %s", next.Successor)
+	}
+
+	// Reopen and complete the task again.
+	if again := task.Recur(); again != nil {
+		t.Errorf("Expected no further occurrence after completing the task again, but got %s", again.ID)
+	}
+}
+
+func TestTask_Deferred(t *testing.T) {
+	now := time.Date(2026, time.March, 10, 12, 0, 0, 0, time.Local)
+	task := &Task{}
+	if task.Deferred(now) {
+		t.Errorf("Expected a task without start date not to be deferred")
+	}
+
+	past := now.Add(-time.Minute)
+	task.StartDate = &past
+	if task.Deferred(now) {
+		t.Errorf("Expected a task with a past start date not to be deferred")
+	}
+
+	future := now.Add(time.Minute)
+	task.StartDate = &future
+	if !task.Deferred(now) {
+		t.Errorf("Expected a task with a future start date to be deferred")
+	}
+}
+
+func TestTask_Duplicate(t *testing.T) {
+	dueDate := time.Date(2026, time.January, 31, 9, 0, 0, 0, time.Local)
+	task := &Task{
+		ID:         uuid.NewString(),
+		Title:      "Report",
+		Priority:   "high",
+		Labels:     Labels{"work"},
+		InProgress: true,
+		Completed:  true,
+		DueDate:    &dueDate,
+		Reminders:  Reminders{time.Hour},
+		Watchers:   []string{"Jane Doe jane@example.com"},
+		Subtasks:   Subtasks{{Title: "step", Done: true}},
+		BlockedBy:  []string{"blocker"},
+		Order:      3,
+		Pomodoros:  []time.Time{dueDate},
+		External:   &External{},
+	}
+
+	dup := task.Duplicate()
+	if dup.ID == task.ID {
+		t.Errorf("Expected the duplicate to have a new ID")
+	}
+	if dup.Title != task.Title || dup.Priority != task.Priority || dup.Labels.String() != task.Labels.String() {
+		t.Errorf("Expected title, priority and labels to be copied, got %+v", dup)
+	}
+	if dup.Completed || dup.InProgress {
+		t.Errorf("Expected the duplicate to be open")
+	}
+	if !dup.DueDate.Equal(dueDate) || dup.DueDate == task.DueDate {
+		t.Errorf("Expected a copy of due date %s, but got %s", dueDate, dup.DueDate)
+	}
+	if dup.Reminders.String() != task.Reminders.String() {
+		t.Errorf("Expected reminders %s to be copied, but got %s", task.Reminders, dup.Reminders)
+	}
+	if !slices.Equal(dup.Watchers, task.Watchers) {
+		t.Errorf("Expected watchers %v to be copied, but got %v", task.Watchers, dup.Watchers)
+	}
+	if dup.Subtasks[0].Done {
+		t.Errorf("Expected subtasks of the duplicate to be reset")
+	}
+	if len(dup.BlockedBy) != 1 || dup.Order != 0 || dup.Pomodoros != nil || dup.External != nil {
+		t.Errorf("Expected only the blockers to be copied, got %+v", dup)
+	}
+
+	task.Recurrence = &Recurrence{Frequency: "daily", Interval: 1}
+	dup = task.Duplicate()
+	if !dup.DueDate.Equal(dueDate.AddDate(0, 0, 1)) {
+		t.Errorf("Expected due date %s, but got %s", dueDate.AddDate(0, 0, 1), dup.DueDate)
+	}
+	if len(dup.BlockedBy) != 1 {
+		t.Errorf("Expected the blockers of a recurring task to be copied")
+	}
+}
+
+func TestCopyOpenTasks(t *testing.T) {
+	open := Task{ID: uuid.NewString(), Title: "open", Order: 2}
+	done := Task{ID: uuid.NewString(), Title: "done", Completed: true}
+	blocked := Task{ID: uuid.NewString(), Title: "blocked", BlockedBy: []string{open.ID, done.ID}, Order: 1}
+
+	copies := CopyOpenTasks([]Task{open, done, blocked})
+	if len(copies) != 2 {
+		t.Fatalf("Expected 2 copies, but got %d", len(copies))
+	}
+	if copies[0].ID == open.ID || copies[0].Order != 2 {
+		t.Errorf("Expected a new ID and the same order, got %+v", copies[0])
+	}
+	if len(copies[1].BlockedBy) != 1 || copies[1].BlockedBy[0] != copies[0].ID {
+		t.Errorf("Expected the blocker to point to the copy, got %v", copies[1].BlockedBy)
+	}
+}
+
+func TestStateChangeCommitMessage(t *testing.T) {
+	msg := StateChangeCommitMessage("completion", []string{"one", "two"}, nil)
+	expected := "Change completion state of 2 task(s)\n\n- one\n- two"
+	if msg != expected {
+		t.Errorf("Expected %q, but got %q", expected, msg)
+	}
+
+	msg = StateChangeCommitMessage("completion", []string{"one"}, []string{"one (due tomorrow)"})
+	expected = "Change completion state of 1 task(s)\n\n- one\n\nCreate 1 next occurrence(s)\n\n- one (due tomorrow)"
+	if msg != expected {
+		t.Errorf("Expected %q, but got %q", expected, msg)
+	}
+}
+
+func TestArchiveCommitMessage(t *testing.T) {
+	msg := ArchiveCommitMessage([]string{"one", "two"}, nil)
+	expected := "Archive 2 task(s)\n\n- one\n- two"
+	if msg != expected {
+		t.Errorf("Expected %q, but got %q", expected, msg)
+	}
+
+	msg = ArchiveCommitMessage([]string{"one"}, []string{"two"})
+	expected = "Archive 1 task(s)\n\n- one\n\nRestore 1 task(s) from archive\n\n- two"
+	if msg != expected {
+		t.Errorf("Expected %q, but got %q", expected, msg)
+	}
 }
 
 func TestTask_WriteTaskJSON(t *testing.T) {
@@ -131,7 +536,7 @@ func TestTask_WriteTaskJSON(t *testing.T) {
 	_ = os.Mkdir(projectDir, 0o750)
 
 	task := &Task{ID: uuid.NewString(), Title: "Test Task"}
-	cmd := task.WriteTaskJSON(v, task.MarshalTask(), project, "create")
+	cmd := task.WriteTaskJSON(v, project, "create")
 	msg := cmd()
 
 	if _, ok := msg.(WriteTaskJSONDoneMsg); !ok {
@@ -142,6 +547,89 @@ func TestTask_WriteTaskJSON(t *testing.T) {
 	if _, err := os.Stat(taskFile); os.IsNotExist(err) {
 		t.Errorf("Expected task file to be created, but it wasn't")
 	}
+
+	if task.CreatedAt == nil || task.UpdatedAt == nil {
+		t.Errorf("Expected the task to be stamped on write, but got %v, %v", task.CreatedAt, task.UpdatedAt)
+	}
+
+	updated := *task.UpdatedAt
+	task.Order = 1
+	task.WriteTaskJSON(v, project, "reorder")()
+	if !task.UpdatedAt.Equal(updated) {
+		t.Errorf("Expected a reorder not to change UpdatedAt, but got %v", task.UpdatedAt)
+	}
+
+	seen, since := storage.SeenTasks(v)
+	if task.Unseen(seen, since) {
+		t.Errorf("Expected written tasks to be seen")
+	}
+
+	task.Completed = true
+	task.WriteTaskJSON(v, project, "complete")()
+	stats, _, err := storage.ProjectDayStats(v, project.ID)
+	if err != nil {
+		t.Fatalf("Expected the day stats to be readable, but got %v", err)
+	}
+	if want := (storage.DayStats{Created: 1, Completed: 1}); stats[time.Now().Format(time.DateOnly)] != want {
+		t.Errorf("Expected today's stats to be %v, but got %v", want, stats)
+	}
+}
+
+func TestTask_Unseen(t *testing.T) {
+	since := time.Date(2026, time.March, 1, 9, 0, 0, 0, time.UTC)
+	before := since.Add(-time.Hour)
+	after := since.Add(time.Hour)
+
+	tests := []struct {
+		name    string
+		updated *time.Time
+		seen    map[string]time.Time
+		want    bool
+	}{
+		{"never updated", nil, nil, false},
+		{"updated before tracking", &before, nil, false},
+		{"updated after tracking", &after, nil, true},
+		{"seen", &after, map[string]time.Time{"task": after}, false},
+		{"updated after seen", &after, map[string]time.Time{"task": since}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			task := &Task{ID: "task", UpdatedAt: tt.updated}
+			if got := task.Unseen(tt.seen, since); got != tt.want {
+				t.Errorf("Unseen() = %t, want %t", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTask_Stamp(t *testing.T) {
+	created := time.Date(2026, time.March, 1, 9, 0, 0, 0, time.UTC)
+	later := created.Add(time.Hour)
+
+	task := &Task{}
+	task.Stamp(created)
+	if !task.CreatedAt.Equal(created) || !task.UpdatedAt.Equal(created) || task.CompletedAt != nil {
+		t.Errorf("Unexpected timestamps after creation: %v, %v, %v", task.CreatedAt, task.UpdatedAt, task.CompletedAt)
+	}
+
+	task.Completed = true
+	task.Stamp(later)
+	if !task.CreatedAt.Equal(created) || !task.UpdatedAt.Equal(later) || !task.CompletedAt.Equal(later) {
+		t.Errorf("Unexpected timestamps after completion: %v, %v, %v", task.CreatedAt, task.UpdatedAt, task.CompletedAt)
+	}
+
+	// Further changes keep the completion time.
+	task.Stamp(later.Add(time.Hour))
+	if !task.CompletedAt.Equal(later) {
+		t.Errorf("Expected CompletedAt to stay %v, but got %v", later, task.CompletedAt)
+	}
+
+	task.Completed = false
+	task.Stamp(later.Add(2 * time.Hour))
+	if task.CompletedAt != nil {
+		t.Errorf("Expected CompletedAt to be cleared on reopen, but got %v", task.CompletedAt)
+	}
 }
 
 func TestTask_DeleteTaskFromFS(t *testing.T) {
@@ -157,6 +645,10 @@ func TestTask_DeleteTaskFromFS(t *testing.T) {
 	taskFile := filepath.Join(projectDir, task.ID+".json")
 	_ = os.WriteFile(taskFile, task.MarshalTask(), 0o600)
 
+	attachmentsDir := filepath.Join(tempDir, task.AttachmentsDir(project))
+	_ = os.Mkdir(attachmentsDir, 0o750)
+	_ = os.WriteFile(filepath.Join(attachmentsDir, "notes.txt"), []byte("notes"), 0o600)
+
 	cmd := task.DeleteTaskFromFS(v, project)
 	msg := cmd()
 
@@ -167,4 +659,224 @@ func TestTask_DeleteTaskFromFS(t *testing.T) {
 	if _, err := os.Stat(taskFile); !os.IsNotExist(err) {
 		t.Errorf("Expected task file to be deleted, but it wasn't")
 	}
+
+	if _, err := os.Stat(attachmentsDir); !os.IsNotExist(err) {
+		t.Errorf("Expected attachments to be deleted, but they weren't")
+	}
+}
+
+func TestTask_Path(t *testing.T) {
+	project := Project{ID: "test-project"}
+	task := &Task{ID: "test-task"}
+
+	if got := task.Path(project); got != filepath.Join("test-project", "test-task.json") {
+		t.Errorf("Unexpected path %q", got)
+	}
+
+	task.Archived = true
+	if got := task.Path(project); got != filepath.Join("test-project", ArchiveDir, "test-task.json") {
+		t.Errorf("Unexpected archived path %q", got)
+	}
+}
+
+func TestTask_ArchiveTaskOnFS(t *testing.T) {
+	tempDir := t.TempDir()
+	v := viper.New()
+	v.Set("storage.path", tempDir)
+
+	project := Project{ID: "test-project"}
+	projectDir := filepath.Join(tempDir, project.ID)
+	_ = os.Mkdir(projectDir, 0o750)
+
+	task := &Task{ID: uuid.NewString(), Title: "Test Task", Completed: true}
+	taskFile := filepath.Join(projectDir, task.ID+".json")
+	archivedFile := filepath.Join(projectDir, ArchiveDir, task.ID+".json")
+	_ = os.WriteFile(taskFile, task.MarshalTask(), 0o600)
+
+	msg := task.ArchiveTaskOnFS(v, project, true)()
+
+	done, ok := msg.(TaskArchiveDoneMsg)
+	if !ok {
+		t.Fatalf("Expected TaskArchiveDoneMsg, but got %T", msg)
+	}
+	if !done.Task.Archived {
+		t.Errorf("Expected task to be marked as archived")
+	}
+	if _, err := os.Stat(archivedFile); err != nil {
+		t.Errorf("Expected task file to be moved to archive: %v", err)
+	}
+	if _, err := os.Stat(taskFile); !os.IsNotExist(err) {
+		t.Errorf("Expected task file to be removed from project directory")
+	}
+
+	msg = done.Task.ArchiveTaskOnFS(v, project, false)()
+
+	done, ok = msg.(TaskArchiveDoneMsg)
+	if !ok {
+		t.Fatalf("Expected TaskArchiveDoneMsg, but got %T", msg)
+	}
+	if done.Task.Archived {
+		t.Errorf("Expected task not to be marked as archived")
+	}
+	if _, err := os.Stat(taskFile); err != nil {
+		t.Errorf("Expected task file to be moved back: %v", err)
+	}
+}
+
+func TestBulkEdit_Apply(t *testing.T) {
+	due := time.Date(2026, 3, 1, 0, 0, 0, 0, time.Local)
+
+	task := &Task{Priority: "low", Assignee: "alice", Labels: Labels{"Work", "home"}}
+	edit := BulkEdit{
+		Priority:    "high",
+		DueDate:     &due,
+		Assignee:    "bob",
+		AddLabel:    "urgent",
+		RemoveLabel: "work",
+	}
+
+	if !edit.Apply(task) {
+		t.Fatal("Expected task to change")
+	}
+	if task.Priority != "high" || task.Assignee != "bob" || task.DueDate == nil || !task.DueDate.Equal(due) {
+		t.Errorf("Unexpected task after edit: %+v", task)
+	}
+	if !reflect.DeepEqual(task.Labels, Labels{"home", "urgent"}) {
+		t.Errorf("Unexpected labels %v", task.Labels)
+	}
+
+	if edit.Apply(task) {
+		t.Error("Expected applying the same edit twice to change nothing")
+	}
+
+	if !(BulkEdit{ClearDueDate: true, ClearAssignee: true, AddLabel: "URGENT"}).Apply(task) {
+		t.Fatal("Expected task to change")
+	}
+	if task.DueDate != nil || task.Assignee != "" || len(task.Labels) != 2 {
+		t.Errorf("Unexpected task after clearing: %+v", task)
+	}
+
+	if (BulkEdit{}).Apply(task) {
+		t.Error("Expected empty edit to change nothing")
+	}
+
+	if len(task.DueChanges) != 2 || task.DueChanges[0].From != nil || task.DueChanges[1].To != nil {
+		t.Errorf("Expected the due date to be set and cleared in the history, but got %+v", task.DueChanges)
+	}
+}
+
+func TestTask_RecordDueChange(t *testing.T) {
+	now := time.Date(2026, 3, 1, 9, 0, 0, 0, time.Local)
+	first := now.AddDate(0, 0, 1)
+	later := now.AddDate(0, 0, 3)
+	earlier := now.AddDate(0, 0, 2)
+
+	task := &Task{}
+	task.RecordDueChange(nil, now)
+	if len(task.DueChanges) != 0 {
+		t.Errorf("Expected no change without due dates, but got %+v", task.DueChanges)
+	}
+
+	for _, due := range []*time.Time{&first, &later, &later, &earlier, nil, &first, &later} {
+		old := task.DueDate
+		task.DueDate = due
+		task.RecordDueChange(old, now)
+	}
+
+	if got := len(task.DueChanges); got != 6 {
+		t.Errorf("Expected 6 changes, but got %d: %+v", got, task.DueChanges)
+	}
+	if change := task.DueChanges[1]; !change.Time.Equal(now) || !change.From.Equal(first) || !change.To.Equal(later) {
+		t.Errorf("Unexpected change %+v", change)
+	}
+	if got := task.Postponed(); got != 2 {
+		t.Errorf("Expected the task to be postponed twice, but got %d", got)
+	}
+
+	task.DueChanges[1].From = &later
+	if !task.DueChanges[2].From.Equal(later) || task.DueChanges[1].To == task.DueChanges[2].From {
+		t.Errorf("Expected the recorded due dates to be copies")
+	}
+	if !strings.Contains(task.TaskToMarkdown(), "(postponed once) |") {
+		t.Errorf("Expected markdown to contain the number of postponements, but it didn't")
+	}
+}
+
+func TestTask_OpenBlockers(t *testing.T) {
+	open := Task{ID: "open"}
+	done := Task{ID: "done", Completed: true}
+	other := Task{ID: "other"}
+
+	task := &Task{ID: "task", BlockedBy: []string{"open", "done", "deleted"}}
+
+	blockers := task.OpenBlockers([]Task{open, done, other, *task})
+	if len(blockers) != 1 || blockers[0].ID != "open" {
+		t.Errorf("Expected only the open blocker, but got %v", blockers)
+	}
+
+	task.BlockedBy = nil
+	if blockers := task.OpenBlockers([]Task{open, done, other}); len(blockers) != 0 {
+		t.Errorf("Expected no blockers, but got %v", blockers)
+	}
+}
+
+func TestRenumber(t *testing.T) {
+	a := &Task{ID: "a", Order: 1}
+	b := &Task{ID: "b", Order: 3}
+	c := &Task{ID: "c"}
+
+	changed := Renumber([]*Task{a, b, c})
+
+	if a.Order != 1 || b.Order != 2 || c.Order != 3 {
+		t.Errorf("Expected orders 1, 2, 3, but got %d, %d, %d", a.Order, b.Order, c.Order)
+	}
+
+	if len(changed) != 2 || changed[0].ID != "b" || changed[1].ID != "c" {
+		t.Errorf("Expected tasks b and c to be changed, but got %v", changed)
+	}
+
+	if changed := Renumber([]*Task{a, b, c}); len(changed) != 0 {
+		t.Errorf("Expected no changes, but got %v", changed)
+	}
+}
+
+func TestTask_MoveTaskOnFS(t *testing.T) {
+	tempDir := t.TempDir()
+	v := viper.New()
+	v.Set("storage.path", tempDir)
+
+	from := Project{ID: "from-project"}
+	to := Project{ID: "to-project"}
+	_ = os.Mkdir(filepath.Join(tempDir, from.ID), 0o750)
+
+	task := &Task{ID: uuid.NewString(), Title: "Test Task"}
+	_ = os.WriteFile(filepath.Join(tempDir, task.Path(from)), task.MarshalTask(), 0o600)
+	_ = os.Mkdir(filepath.Join(tempDir, task.AttachmentsDir(from)), 0o750)
+	_ = os.WriteFile(filepath.Join(tempDir, task.AttachmentsDir(from), "a.txt"), []byte("a"), 0o600)
+
+	msg := task.MoveTaskOnFS(v, from, to)()
+
+	done, ok := msg.(TaskMoveDoneMsg)
+	if !ok {
+		t.Fatalf("Expected TaskMoveDoneMsg, but got %T", msg)
+	}
+	if done.Project.ID != to.ID {
+		t.Errorf("Expected project %s, got %s", to.ID, done.Project.ID)
+	}
+	if _, err := os.Stat(filepath.Join(tempDir, task.Path(to))); err != nil {
+		t.Errorf("Expected task file to be moved: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(tempDir, task.AttachmentsDir(to), "a.txt")); err != nil {
+		t.Errorf("Expected attachments to be moved: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(tempDir, task.Path(from))); !os.IsNotExist(err) {
+		t.Errorf("Expected task file to be removed from the old project")
+	}
+
+	// Tasks without attachments are moved as well.
+	_ = os.RemoveAll(filepath.Join(tempDir, task.AttachmentsDir(to)))
+	msg = task.MoveTaskOnFS(v, to, from)()
+	if _, ok := msg.(TaskMoveDoneMsg); !ok {
+		t.Fatalf("Expected TaskMoveDoneMsg, but got %T", msg)
+	}
 }
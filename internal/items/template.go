@@ -0,0 +1,205 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package items
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/google/uuid"
+	"github.com/handlebargh/yatto/internal/config"
+	"github.com/handlebargh/yatto/internal/logging"
+	"github.com/handlebargh/yatto/internal/storage"
+	"github.com/spf13/viper"
+)
+
+type (
+	// WriteTemplateJSONDoneMsg indicates successful write of a Template JSON file.
+	WriteTemplateJSONDoneMsg struct {
+		Template Template
+		Kind     string
+	}
+
+	// WriteTemplateJSONErrorMsg is returned when a Template fails to write to disk.
+	WriteTemplateJSONErrorMsg struct{ Err error }
+
+	// TemplateDeleteDoneMsg indicates successful deletion of a Template from disk.
+	TemplateDeleteDoneMsg struct{ Template Template }
+
+	// TemplateDeleteErrorMsg is returned when a Template fails to delete from disk.
+	TemplateDeleteErrorMsg struct{ Err error }
+)
+
+// Error implements the error interface for WriteTemplateJSONErrorMsg.
+func (e WriteTemplateJSONErrorMsg) Error() string { return e.Err.Error() }
+
+// Error implements the error interface for TemplateDeleteErrorMsg.
+func (e TemplateDeleteErrorMsg) Error() string { return e.Err.Error() }
+
+// Template holds the values a new task is pre-filled with.
+// The title may contain the placeholder {date}, which is replaced
+// with the current date when a task is created from the template.
+type Template struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	Priority    string `json:"priority,omitempty"`
+	Labels      Labels `json:"labels,omitempty"`
+}
+
+// Path returns the path of the template's JSON file relative to the storage path.
+func (t *Template) Path() string {
+	return filepath.Join(storage.TemplatesDir, t.ID+".json")
+}
+
+// Task returns a new task with a fresh ID pre-filled from the template.
+func (t *Template) Task(now time.Time) *Task {
+	return &Task{
+		ID:          uuid.NewString(),
+		Title:       strings.ReplaceAll(t.Title, "{date}", now.Format(time.DateOnly)),
+		Description: t.Description,
+		Priority:    cmp.Or(t.Priority, "low"),
+		Labels:      append(Labels{}, t.Labels...),
+	}
+}
+
+// MarshalTemplate returns a pretty-printed JSON representation of the template.
+// Panics if serialization fails.
+func (t *Template) MarshalTemplate() []byte {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetIndent("", "\t")
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(t); err != nil {
+		panic(err)
+	}
+
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n"))
+}
+
+// WriteTemplateJSON writes the given template JSON to disk inside the
+// templates directory. Ensures the directory exists.
+// Returns a Tea message indicating success or error.
+func (t *Template) WriteTemplateJSON(v *viper.Viper, json []byte, kind string) tea.Cmd {
+	return func() tea.Msg {
+		root, err := os.OpenRoot(config.Get(v).StoragePath)
+		if err != nil {
+			return WriteTemplateJSONErrorMsg{fmt.Errorf("could not open storage directory: %w", err)}
+		}
+		defer root.Close() //nolint:errcheck
+
+		if err := root.MkdirAll(storage.TemplatesDir, 0o700); err != nil {
+			return WriteTemplateJSONErrorMsg{err}
+		}
+
+		if err := storage.AtomicWrite(root, t.Path(), json, 0o600); err != nil {
+			return WriteTemplateJSONErrorMsg{err}
+		}
+
+		return WriteTemplateJSONDoneMsg{Template: *t, Kind: kind}
+	}
+}
+
+// DeleteTemplateFromFS deletes the template's JSON file.
+// Returns a Tea message on success or failure.
+func (t *Template) DeleteTemplateFromFS(v *viper.Viper) tea.Cmd {
+	return func() tea.Msg {
+		root, err := os.OpenRoot(config.Get(v).StoragePath)
+		if err != nil {
+			return TemplateDeleteErrorMsg{fmt.Errorf("could not open storage directory: %w", err)}
+		}
+		defer root.Close() //nolint:errcheck
+
+		if err := root.Remove(t.Path()); err != nil {
+			return TemplateDeleteErrorMsg{err}
+		}
+		logging.Debug("deleted template", "file", t.Path())
+
+		return TemplateDeleteDoneMsg{*t}
+	}
+}
+
+// ReadTemplatesFromFS reads all templates from the templates directory,
+// sorted by name. A missing directory means that there are no templates.
+// Files that cannot be read or parsed are skipped and reported
+// in a SkippedFilesError, which is returned along with the remaining templates.
+func ReadTemplatesFromFS(v *viper.Viper) ([]Template, error) {
+	root, err := os.OpenRoot(config.Get(v).StoragePath)
+	if err != nil {
+		return nil, fmt.Errorf("could not open storage directory: %w", err)
+	}
+	defer root.Close() //nolint:errcheck
+
+	entries, err := fs.ReadDir(root.FS(), storage.TemplatesDir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not read templates directory: %w", err)
+	}
+
+	var (
+		templates []Template
+		skipped   []CorruptFileError
+	)
+
+	for _, entry := range entries {
+		if entry.IsDir() || !UUIDRegex.MatchString(entry.Name()) {
+			continue
+		}
+
+		filePath := path.Join(storage.TemplatesDir, entry.Name())
+		data, err := fs.ReadFile(root.FS(), filePath)
+		if err != nil {
+			skipped = append(skipped, CorruptFileError{Path: filePath, Err: err})
+			continue
+		}
+
+		var template Template
+		if err := json.Unmarshal(data, &template); err != nil {
+			skipped = append(skipped, CorruptFileError{Path: filePath, Err: err})
+			continue
+		}
+		templates = append(templates, template)
+	}
+
+	slices.SortFunc(templates, func(a, b Template) int {
+		return cmp.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name))
+	})
+
+	if len(skipped) > 0 {
+		return templates, &SkippedFilesError{Files: skipped}
+	}
+
+	return templates, nil
+}
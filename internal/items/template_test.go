@@ -0,0 +1,99 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package items
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/handlebargh/yatto/internal/storage"
+	"github.com/spf13/viper"
+)
+
+func TestTemplate_Task(t *testing.T) {
+	template := &Template{
+		ID:          uuid.NewString(),
+		Name:        "Weekly report",
+		Title:       "Report {date}",
+		Description: "## Done\n\n## Next",
+		Labels:      Labels{"work"},
+	}
+
+	now := time.Date(2026, time.March, 2, 10, 0, 0, 0, time.Local)
+	task := template.Task(now)
+
+	if task.ID == template.ID || task.ID == "" {
+		t.Errorf("Expected the task to have a new ID")
+	}
+	if task.Title != "Report 2026-03-02" {
+		t.Errorf("Expected the date placeholder to be replaced, but got %q", task.Title)
+	}
+	if task.Description != template.Description || task.Labels.String() != "work" {
+		t.Errorf("Expected description and labels to be copied, got %+v", task)
+	}
+	if task.Priority != "low" {
+		t.Errorf("Expected the default priority, but got %q", task.Priority)
+	}
+}
+
+func TestTemplate_WriteReadDelete(t *testing.T) {
+	tempDir := t.TempDir()
+	v := viper.New()
+	v.Set("storage.path", tempDir)
+
+	templates, err := ReadTemplatesFromFS(v)
+	if err != nil || len(templates) != 0 {
+		t.Fatalf("Expected no templates without a templates directory, got %v, %v", templates, err)
+	}
+
+	b := &Template{ID: uuid.NewString(), Name: "b", Priority: "high"}
+	a := &Template{ID: uuid.NewString(), Name: "A"}
+	for _, template := range []*Template{b, a} {
+		msg := template.WriteTemplateJSON(v, template.MarshalTemplate(), "create")()
+		if _, ok := msg.(WriteTemplateJSONDoneMsg); !ok {
+			t.Fatalf("Expected WriteTemplateJSONDoneMsg, but got %T", msg)
+		}
+	}
+
+	corruptPath := filepath.Join(storage.TemplatesDir, uuid.NewString()+".json")
+	_ = os.WriteFile(filepath.Join(tempDir, corruptPath), []byte("{"), 0o600)
+
+	templates, err = ReadTemplatesFromFS(v)
+	var skipped *SkippedFilesError
+	if !errors.As(err, &skipped) || len(skipped.Files) != 1 {
+		t.Errorf("Expected the corrupt template to be reported, but got %v", err)
+	}
+	if len(templates) != 2 || templates[0].Name != "A" || templates[1].Priority != "high" {
+		t.Fatalf("Expected both templates sorted by name, but got %+v", templates)
+	}
+
+	msg := a.DeleteTemplateFromFS(v)()
+	if _, ok := msg.(TemplateDeleteDoneMsg); !ok {
+		t.Errorf("Expected TemplateDeleteDoneMsg, but got %T", msg)
+	}
+	if _, err := os.Stat(filepath.Join(tempDir, a.Path())); !os.IsNotExist(err) {
+		t.Errorf("Expected the template file to be deleted")
+	}
+}
@@ -0,0 +1,125 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package logging writes the structured debug trace enabled with
+// --log-file. Without it, everything logged is discarded, so the
+// other packages log unconditionally.
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// Levels lists the valid values of the log.level setting.
+var Levels = []string{"debug", "info", "warn", "error"}
+
+var logger atomic.Pointer[slog.Logger]
+
+func init() {
+	logger.Store(slog.New(slog.DiscardHandler))
+}
+
+// ParseLevel returns the slog level named by level, one of Levels.
+// An empty level is info.
+func ParseLevel(level string) (slog.Level, error) {
+	switch strings.ToLower(level) {
+	case "debug":
+		return slog.LevelDebug, nil
+	case "", "info":
+		return slog.LevelInfo, nil
+	case "warn":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	}
+
+	return 0, fmt.Errorf("unknown log level %q (valid: %s)", level, strings.Join(Levels, ", "))
+}
+
+// Setup appends the log to the file at path, creating it if needed,
+// and logs records at level and above. The returned function closes
+// the file; logging is discarded again from then on.
+func Setup(path, level string) (func() error, error) {
+	l, err := ParseLevel(level)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open log file: %w", err)
+	}
+
+	SetOutput(f, l)
+	Logger().Info("yatto started", "pid", os.Getpid(), "args", os.Args[1:])
+
+	return func() error {
+		logger.Store(slog.New(slog.DiscardHandler))
+		return f.Close()
+	}, nil
+}
+
+// SetOutput writes the log to w, logging records at level and above.
+func SetOutput(w io.Writer, level slog.Level) {
+	logger.Store(slog.New(slog.NewTextHandler(w, &slog.HandlerOptions{Level: level})))
+}
+
+// Logger returns the logger in use.
+func Logger() *slog.Logger {
+	return logger.Load()
+}
+
+// Debug logs at the debug level, see slog.Logger.Debug.
+func Debug(msg string, args ...any) {
+	Logger().Debug(msg, args...)
+}
+
+// Info logs at the info level, see slog.Logger.Info.
+func Info(msg string, args ...any) {
+	Logger().Info(msg, args...)
+}
+
+// Warn logs at the warn level, see slog.Logger.Warn.
+func Warn(msg string, args ...any) {
+	Logger().Warn(msg, args...)
+}
+
+// Error logs at the error level, see slog.Logger.Error.
+func Error(msg string, args ...any) {
+	Logger().Error(msg, args...)
+}
+
+// Enabled reports whether records at level are logged. Callers use it
+// to skip building expensive attributes.
+func Enabled(level slog.Level) bool {
+	return Logger().Enabled(context.Background(), level)
+}
+
+// Since returns the time elapsed since start, rounded for the log.
+func Since(start time.Time) time.Duration {
+	return time.Since(start).Round(time.Microsecond)
+}
@@ -0,0 +1,90 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package logging
+
+import (
+	"bytes"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLevel(t *testing.T) {
+	for level, want := range map[string]slog.Level{
+		"":      slog.LevelInfo,
+		"debug": slog.LevelDebug,
+		"INFO":  slog.LevelInfo,
+		"warn":  slog.LevelWarn,
+		"error": slog.LevelError,
+	} {
+		got, err := ParseLevel(level)
+		require.NoError(t, err, level)
+		assert.Equal(t, want, got, level)
+	}
+
+	_, err := ParseLevel("trace")
+	assert.ErrorContains(t, err, `unknown log level "trace"`)
+}
+
+func TestSetOutput(t *testing.T) {
+	t.Cleanup(func() { logger.Store(slog.New(slog.DiscardHandler)) })
+
+	var buf bytes.Buffer
+	SetOutput(&buf, slog.LevelInfo)
+
+	Debug("hidden")
+	Info("shown", "key", "value")
+
+	assert.NotContains(t, buf.String(), "hidden")
+	assert.Contains(t, buf.String(), "msg=shown key=value")
+	assert.False(t, Enabled(slog.LevelDebug))
+	assert.True(t, Enabled(slog.LevelWarn))
+}
+
+func TestSetup(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "yatto.log")
+	require.NoError(t, os.WriteFile(path, []byte("previous run\n"), 0o600))
+
+	closeLog, err := Setup(path, "debug")
+	require.NoError(t, err)
+	Debug("traced", "n", 1)
+	require.NoError(t, closeLog())
+
+	// Nothing is written once the file is closed.
+	Error("discarded")
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "previous run\n")
+	assert.Contains(t, string(data), "msg=\"yatto started\"")
+	assert.Contains(t, string(data), "msg=traced n=1")
+	assert.NotContains(t, string(data), "discarded")
+
+	_, err = Setup(path, "verbose")
+	assert.Error(t, err)
+
+	_, err = Setup(filepath.Join(t.TempDir(), "missing", "yatto.log"), "info")
+	assert.ErrorContains(t, err, "failed to open log file")
+}
@@ -0,0 +1,171 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package mcp
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/handlebargh/yatto/pkg/yatto"
+)
+
+func newTestServer(t *testing.T) (*Server, yatto.Project) {
+	t.Helper()
+
+	tempDir := t.TempDir()
+	config := filepath.Join(tempDir, "config.toml")
+	data := fmt.Sprintf("[storage]\npath = %q\n\n[vcs]\nbackend = \"none\"\n", tempDir)
+	if err := os.WriteFile(config, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	project := yatto.Project{ID: "test-project", Title: "Test Project"}
+	if err := os.Mkdir(filepath.Join(tempDir, project.ID), 0o750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(tempDir, project.ID, "project.json"), project.MarshalProject(), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	client, err := yatto.Open(config)
+	if err != nil {
+		t.Fatalf("Open returned an error: %v", err)
+	}
+
+	return New(client, "test"), project
+}
+
+// exchange sends the requests to the server and returns the responses.
+func exchange(t *testing.T, s *Server, requests ...string) []response {
+	t.Helper()
+
+	var out bytes.Buffer
+	if err := s.Serve(strings.NewReader(strings.Join(requests, "\n")), &out); err != nil {
+		t.Fatalf("Serve returned an error: %v", err)
+	}
+
+	var responses []response
+	dec := json.NewDecoder(&out)
+	for dec.More() {
+		var resp response
+		if err := dec.Decode(&resp); err != nil {
+			t.Fatal(err)
+		}
+		responses = append(responses, resp)
+	}
+
+	return responses
+}
+
+// toolText returns the text of the result of a tool call.
+func toolText(t *testing.T, resp response) (string, bool) {
+	t.Helper()
+
+	if resp.Error != nil {
+		t.Fatalf("Expected a result, but got error %v", resp.Error)
+	}
+
+	data, _ := json.Marshal(resp.Result)
+	var result toolResult
+	if err := json.Unmarshal(data, &result); err != nil || len(result.Content) != 1 {
+		t.Fatalf("Expected a tool result, but got %s", data)
+	}
+
+	return result.Content[0].Text, result.IsError
+}
+
+func TestServer(t *testing.T) {
+	s, project := newTestServer(t)
+
+	responses := exchange(t, s,
+		`{"jsonrpc": "2.0", "id": 1, "method": "initialize", "params": {}}`,
+		`{"jsonrpc": "2.0", "method": "notifications/initialized"}`,
+		`{"jsonrpc": "2.0", "id": 2, "method": "tools/list"}`,
+		`{"jsonrpc": "2.0", "id": 3, "method": "unknown"}`,
+		`not json`,
+	)
+
+	if len(responses) != 4 {
+		t.Fatalf("Expected 4 responses, but got %d", len(responses))
+	}
+	if string(responses[0].ID) != "1" || responses[0].Error != nil {
+		t.Errorf("Expected a result for initialize, but got %+v", responses[0])
+	}
+	if data, _ := json.Marshal(responses[1].Result); !strings.Contains(string(data), `"complete_task"`) {
+		t.Errorf("Expected the tools to be listed, but got %s", data)
+	}
+	if responses[2].Error == nil || responses[2].Error.Code != codeMethodNotFound {
+		t.Errorf("Expected method not found, but got %+v", responses[2])
+	}
+	if responses[3].Error == nil || responses[3].Error.Code != codeParseError {
+		t.Errorf("Expected a parse error, but got %+v", responses[3])
+	}
+
+	t.Run("calls tools", func(t *testing.T) {
+		call := func(id int, name string, args string) string {
+			return fmt.Sprintf(`{"jsonrpc": "2.0", "id": %d, "method": "tools/call", "params": {"name": %q, "arguments": %s}}`,
+				id, name, args)
+		}
+
+		responses := exchange(t, s,
+			call(1, "create_task", fmt.Sprintf(`{"project_id": %q, "task": {"title": "Write report"}}`, project.ID)),
+		)
+		text, isError := toolText(t, responses[0])
+		if isError {
+			t.Fatalf("create_task failed: %s", text)
+		}
+
+		var created yatto.Task
+		if err := json.Unmarshal([]byte(text), &created); err != nil {
+			t.Fatal(err)
+		}
+
+		responses = exchange(t, s,
+			call(2, "update_task", fmt.Sprintf(`{"project_id": %q, "task_id": %q, "fields": {"priority": "high"}}`,
+				project.ID, created.ID)),
+			call(3, "complete_task", fmt.Sprintf(`{"project_id": %q, "task_id": %q}`, project.ID, created.ID)),
+			call(4, "list_tasks", fmt.Sprintf(`{"project_id": %q}`, project.ID)),
+			call(5, "list_tasks", `{"project_id": "missing"}`),
+			call(6, "unknown", `{}`),
+		)
+
+		var tasks []yatto.Task
+		text, _ = toolText(t, responses[2])
+		if err := json.Unmarshal([]byte(text), &tasks); err != nil {
+			t.Fatal(err)
+		}
+		if len(tasks) != 1 || tasks[0].Priority != "high" || !tasks[0].Completed {
+			t.Errorf("Expected the task to be updated and completed, but got %+v", tasks)
+		}
+
+		if _, isError := toolText(t, responses[3]); !isError {
+			t.Error("Expected an error result for a missing project")
+		}
+		if responses[4].Error == nil || responses[4].Error.Code != codeInvalidParams {
+			t.Errorf("Expected invalid params for an unknown tool, but got %+v", responses[4])
+		}
+	})
+}
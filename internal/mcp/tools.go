@@ -0,0 +1,197 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package mcp
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/handlebargh/yatto/pkg/yatto"
+)
+
+// errUnknownTool is returned by call for tools that do not exist.
+var errUnknownTool = errors.New("unknown tool")
+
+// tool describes a tool and the JSON schema of its arguments.
+type tool struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	InputSchema map[string]any `json:"inputSchema"`
+}
+
+// schema returns the JSON schema of an object with the given properties.
+func schema(properties map[string]any, required ...string) map[string]any {
+	s := map[string]any{"type": "object", "properties": properties}
+	if len(required) > 0 {
+		s["required"] = required
+	}
+
+	return s
+}
+
+// str returns the JSON schema of a string property.
+func str(description string) map[string]any {
+	return map[string]any{"type": "string", "description": description}
+}
+
+// taskProperties are the properties of a task that can be set by tools.
+var taskProperties = map[string]any{
+	"title":       str("Title of the task"),
+	"description": str("Description in markdown"),
+	"priority":    map[string]any{"type": "string", "enum": []string{"low", "medium", "high"}},
+	"labels":      map[string]any{"type": "array", "items": map[string]any{"type": "string"}},
+	"due_date":    str("Due date in RFC 3339 format"),
+	"assignee":    str("Email address of the assignee"),
+	"in_progress": map[string]any{"type": "boolean"},
+	"completed":   map[string]any{"type": "boolean"},
+}
+
+// tools are the tools offered to the client.
+var tools = []tool{
+	{
+		Name:        "list_projects",
+		Description: "List all projects with their IDs.",
+		InputSchema: schema(map[string]any{}),
+	},
+	{
+		Name:        "list_tasks",
+		Description: "List the tasks of a project. Archived tasks are not included.",
+		InputSchema: schema(map[string]any{"project_id": str("ID of the project")}, "project_id"),
+	},
+	{
+		Name:        "create_task",
+		Description: "Create a task in a project and commit it. Returns the created task.",
+		InputSchema: schema(map[string]any{
+			"project_id": str("ID of the project"),
+			"task":       schema(taskProperties, "title"),
+		}, "project_id", "task"),
+	},
+	{
+		Name:        "update_task",
+		Description: "Change the given fields of a task and commit it. Fields that are not given are kept.",
+		InputSchema: schema(map[string]any{
+			"project_id": str("ID of the project"),
+			"task_id":    str("ID of the task"),
+			"fields":     schema(taskProperties),
+		}, "project_id", "task_id", "fields"),
+	},
+	{
+		Name: "complete_task",
+		Description: "Mark a task as completed and commit it. Fails if the task is blocked by open tasks. " +
+			"Returns the next occurrence of a recurring task.",
+		InputSchema: schema(map[string]any{
+			"project_id": str("ID of the project"),
+			"task_id":    str("ID of the task"),
+		}, "project_id", "task_id"),
+	},
+	{
+		Name:        "sync",
+		Description: "Pull from and push to the remote repository.",
+		InputSchema: schema(map[string]any{}),
+	},
+}
+
+// arguments are the arguments of all tools.
+type arguments struct {
+	ProjectID string          `json:"project_id"`
+	TaskID    string          `json:"task_id"`
+	Task      json.RawMessage `json:"task"`
+	Fields    json.RawMessage `json:"fields"`
+}
+
+// call runs the tool with the given name and returns its result.
+func (s *Server) call(name string, raw json.RawMessage) (any, error) {
+	var args arguments
+	if err := json.Unmarshal(raw, &args); err != nil {
+		return nil, fmt.Errorf("invalid arguments: %w", err)
+	}
+
+	switch name {
+	case "list_projects":
+		projects, err := s.client.ListProjects()
+		return projects, skippedOnly(err)
+
+	case "list_tasks":
+		tasks, err := s.client.ListTasks(args.ProjectID)
+		return tasks, skippedOnly(err)
+
+	case "create_task":
+		var task yatto.Task
+		if err := decodeTask(args.Task, &task); err != nil {
+			return nil, err
+		}
+		return s.client.CreateTask(args.ProjectID, task)
+
+	case "update_task":
+		tasks, err := s.client.ListTasks(args.ProjectID)
+		if err := skippedOnly(err); err != nil {
+			return nil, err
+		}
+
+		for _, task := range tasks {
+			if task.ID != args.TaskID {
+				continue
+			}
+			if err := decodeTask(args.Fields, &task); err != nil {
+				return nil, err
+			}
+			task.ID = args.TaskID
+			return s.client.UpdateTask(args.ProjectID, task)
+		}
+		return nil, fmt.Errorf("task %s: %w", args.TaskID, yatto.ErrNotFound)
+
+	case "complete_task":
+		return s.client.CompleteTask(args.ProjectID, args.TaskID)
+
+	case "sync":
+		if err := s.client.Sync(); err != nil {
+			return nil, err
+		}
+		return "synced", nil
+
+	default:
+		return nil, fmt.Errorf("%w %q", errUnknownTool, name)
+	}
+}
+
+// decodeTask applies the task fields in raw to task.
+func decodeTask(raw json.RawMessage, task *yatto.Task) error {
+	if len(raw) == 0 {
+		return errors.New("no task given")
+	}
+	if err := json.Unmarshal(raw, task); err != nil {
+		return fmt.Errorf("invalid task: %w", err)
+	}
+
+	return nil
+}
+
+// skippedOnly returns nil if err only reports skipped files, so that the
+// items that could be read are returned. Other errors are returned as is.
+func skippedOnly(err error) error {
+	var skipped *yatto.SkippedFilesError
+	if errors.As(err, &skipped) {
+		return nil
+	}
+
+	return err
+}
@@ -0,0 +1,334 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package models
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/handlebargh/yatto/internal/colors"
+	"github.com/handlebargh/yatto/internal/helpers"
+	"github.com/handlebargh/yatto/internal/items"
+)
+
+// agendaKeyMap defines the key bindings used in the agenda view.
+type agendaKeyMap struct {
+	quit        key.Binding
+	up          key.Binding
+	down        key.Binding
+	openProject key.Binding
+}
+
+// newAgendaKeyMap initializes and returns a new key map for agenda actions.
+func newAgendaKeyMap() *agendaKeyMap {
+	return &agendaKeyMap{
+		quit: key.NewBinding(
+			key.WithKeys("q", "esc", "h"),
+			key.WithHelp("q/esc", "go back"),
+		),
+		up: key.NewBinding(
+			key.WithKeys("up", "k"),
+			key.WithHelp("↑/k", "up"),
+		),
+		down: key.NewBinding(
+			key.WithKeys("down", "j"),
+			key.WithHelp("↓/j", "down"),
+		),
+		openProject: key.NewBinding(
+			key.WithKeys("enter", "l"),
+			key.WithHelp("enter/l", "open in project"),
+		),
+	}
+}
+
+// agendaEntry is a single open task in the agenda along with its project.
+type agendaEntry struct {
+	project items.Project
+	task    items.Task
+	bucket  items.AgendaBucket
+}
+
+// agendaModel represents the Bubble Tea model for the cross-project agenda.
+// It lists all open tasks of all projects grouped by due date.
+type agendaModel struct {
+	projectModel  *ProjectListModel
+	keys          *agendaKeyMap
+	help          help.Model
+	entries       []agendaEntry
+	warning       string
+	cursor        int
+	width, height int
+}
+
+// newAgendaModel creates a new agendaModel containing the open tasks
+// of all projects found in storage, leaving out deferred tasks.
+func newAgendaModel(projectModel *ProjectListModel, width, height int) agendaModel {
+	now := time.Now()
+
+	var entries []agendaEntry
+	err := readAllTasks(projectModel.config, func(project items.Project, task items.Task) {
+		if task.Completed || task.Deferred(now) {
+			return
+		}
+
+		entries = append(entries, agendaEntry{
+			project: project,
+			task:    task,
+			bucket:  items.AgendaBucketOf(&task, now),
+		})
+	})
+
+	slices.SortStableFunc(entries, func(x, y agendaEntry) int {
+		if c := cmp.Compare(x.bucket, y.bucket); c != 0 {
+			return c
+		}
+
+		dx, dy := x.task.DueDate, y.task.DueDate
+		switch {
+		case dx == nil && dy != nil:
+			return 1
+		case dx != nil && dy == nil:
+			return -1
+		case dx != nil && dy != nil:
+			if c := dx.Compare(*dy); c != 0 {
+				return c
+			}
+		}
+
+		return cmp.Compare(y.task.PriorityValue(), x.task.PriorityValue())
+	})
+
+	h, v := appStyle.GetFrameSize()
+
+	return agendaModel{
+		projectModel: projectModel,
+		keys:         newAgendaKeyMap(),
+		help:         help.New(),
+		entries:      entries,
+		warning:      storageWarning(err),
+		width:        width - h,
+		height:       height - v,
+	}
+}
+
+// Init initializes the agendaModel and returns an initial command.
+func (m agendaModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles incoming messages and updates the agendaModel accordingly.
+func (m agendaModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		h, v := appStyle.GetFrameSize()
+		m.width = msg.Width - h
+		m.height = msg.Height - v
+
+	case tea.KeyMsg:
+		if msg.Type == tea.KeyCtrlC {
+			return m, tea.Quit
+		}
+
+		switch {
+		case key.Matches(msg, m.keys.quit):
+			return m.projectModel, func() tea.Msg { return returnedToProjectListMsg{} }
+
+		case key.Matches(msg, m.keys.up):
+			if m.cursor > 0 {
+				m.cursor--
+			}
+
+		case key.Matches(msg, m.keys.down):
+			if m.cursor < len(m.entries)-1 {
+				m.cursor++
+			}
+
+		case key.Matches(msg, m.keys.openProject):
+			return m.openProject()
+		}
+	}
+
+	return m, nil
+}
+
+// openProject switches to the task list of the project owning the
+// selected task and selects the task there.
+func (m agendaModel) openProject() (tea.Model, tea.Cmd) {
+	if len(m.entries) == 0 {
+		return m, nil
+	}
+
+	entry := m.entries[m.cursor]
+
+	idx := entry.project.FindListIndexByID(m.projectModel.list.Items())
+	if idx < 0 {
+		return m, nil
+	}
+	m.projectModel.list.Select(idx)
+
+	project, ok := m.projectModel.list.Items()[idx].(*items.Project)
+	if !ok {
+		return m, nil
+	}
+
+	h, v := appStyle.GetFrameSize()
+	listModel := newTaskListModel(project, m.projectModel, m.width+h, m.height+v)
+	cmd := listModel.selectTask(entry.task)
+
+	return listModel, tea.Batch(cmd, tea.WindowSize())
+}
+
+// View returns the string representation of the agenda view.
+func (m agendaModel) View() string {
+	title := lipgloss.NewStyle().
+		Foreground(colors.BadgeText()).
+		Background(colors.Green()).
+		Padding(0, 1).
+		Render("Agenda")
+
+	if m.warning != "" {
+		title += "  " + m.warning
+	}
+
+	helpView := m.help.ShortHelpView([]key.Binding{
+		m.keys.up,
+		m.keys.down,
+		m.keys.openProject,
+		m.keys.quit,
+	})
+
+	var lines []string
+	cursorLine := 0
+
+	if len(m.entries) == 0 {
+		lines = append(lines, lipgloss.NewStyle().
+			Foreground(colors.Green()).
+			Render("No open tasks"))
+	}
+
+	for i, entry := range m.entries {
+		if i == 0 || entry.bucket != m.entries[i-1].bucket {
+			if i > 0 {
+				lines = append(lines, "")
+			}
+
+			headerColor := colors.Blue()
+			switch entry.bucket {
+			case items.AgendaOverdue:
+				headerColor = colors.VividRed()
+			case items.AgendaToday:
+				headerColor = colors.Orange()
+			}
+
+			lines = append(lines, lipgloss.NewStyle().
+				Bold(true).
+				Foreground(headerColor).
+				Render(entry.bucket.String()))
+		}
+
+		if i == m.cursor {
+			cursorLine = len(lines)
+		}
+		lines = append(lines, m.entryView(entry, i == m.cursor))
+	}
+
+	// Scroll so that the cursor stays visible.
+	visible := max(m.height-lipgloss.Height(title)-lipgloss.Height(helpView)-2, 1)
+	offset := max(0, cursorLine-visible+1)
+	end := min(len(lines), offset+visible)
+
+	var b strings.Builder
+
+	b.WriteString(title)
+	b.WriteString("\n\n")
+	b.WriteString(lipgloss.NewStyle().
+		Height(visible).
+		Render(strings.Join(lines[offset:end], "\n")))
+	b.WriteString("\n")
+	b.WriteString(helpView)
+
+	return appStyle.Render(b.String())
+}
+
+// entryView renders a single agenda entry with its project, priority
+// and due date.
+func (m agendaModel) entryView(entry agendaEntry, selected bool) string {
+	projectColor := helpers.GetColorCode(entry.project.Color)
+
+	titleStyle := lipgloss.NewStyle().
+		Width(max(m.width-60, 20)).
+		PaddingLeft(1)
+
+	if selected {
+		titleStyle = titleStyle.
+			Border(lipgloss.NormalBorder(), false, false, false, true).
+			BorderForeground(projectColor).
+			Bold(true)
+	} else {
+		titleStyle = titleStyle.MarginLeft(1)
+	}
+
+	priorityStyle := lipgloss.NewStyle().
+		Foreground(colors.BadgeText()).
+		Padding(0, 1)
+
+	switch entry.task.Priority {
+	case "low":
+		priorityStyle = priorityStyle.Background(colors.Indigo())
+	case "medium":
+		priorityStyle = priorityStyle.Background(colors.Orange())
+	case "high":
+		priorityStyle = priorityStyle.Background(colors.Red())
+	}
+
+	due := "no due date"
+	if entry.task.DueDate != nil {
+		due = entry.task.DueDate.Format("Mon, 02 Jan 15:04")
+	}
+
+	state := ""
+	if entry.task.InProgress {
+		state = lipgloss.NewStyle().
+			Padding(0, 1).
+			Background(colors.Blue()).
+			Foreground(colors.BadgeText()).
+			Render("in progress")
+	}
+
+	return lipgloss.JoinHorizontal(lipgloss.Top,
+		titleStyle.Render(entry.task.CropTaskTitle(taskEntryLength)),
+		lipgloss.NewStyle().
+			Width(24).
+			Foreground(projectColor).
+			Render(entry.project.Title),
+		lipgloss.NewStyle().
+			Width(20).
+			Render(due),
+		priorityStyle.Render(entry.task.Priority),
+		state,
+	)
+}
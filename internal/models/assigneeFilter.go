@@ -0,0 +1,141 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package models
+
+import (
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/handlebargh/yatto/internal/colors"
+	"github.com/handlebargh/yatto/internal/vcs"
+)
+
+// assigneeFilterModel defines the Bubble Tea model for the picker used
+// to restrict the task list to the tasks of one assignee.
+type assigneeFilterModel struct {
+	form          *huh.Form
+	listModel     *taskListModel
+	assignee      *string
+	width, height int
+	lg            *lipgloss.Renderer
+	styles        *Styles
+}
+
+// newAssigneeFilterModel returns an assigneeFilterModel offering the
+// contributors of the repository. The current filter of the task list
+// is preselected.
+func newAssigneeFilterModel(listModel *taskListModel) assigneeFilterModel {
+	assignee := listModel.filterAssignee
+
+	m := assigneeFilterModel{
+		listModel: listModel,
+		assignee:  &assignee,
+		lg:        lipgloss.DefaultRenderer(),
+	}
+	m.styles = NewStyles(m.lg)
+
+	// Ignore error just like the task form does.
+	contributors, _ := vcs.AllContributors(listModel.projectModel.config)
+	if assignee != "" && !slices.Contains(contributors, assignee) {
+		contributors = append(contributors, assignee)
+	}
+	options := []huh.Option[string]{huh.NewOption("(anyone)", "")}
+	for _, c := range contributors {
+		options = append(options, huh.NewOption(c, c))
+	}
+
+	m.form = huh.NewForm(
+		huh.NewGroup(
+			huh.NewSelect[string]().
+				Title("Show tasks assigned to or watched by:").
+				Height(15).
+				Options(options...).
+				Value(m.assignee),
+		)).
+		WithWidth(80).
+		WithShowHelp(false).
+		WithShowErrors(false).
+		WithTheme(colors.FormTheme())
+
+	return m
+}
+
+// Init initializes the assignee filter model and returns the initial command to run.
+func (m assigneeFilterModel) Init() tea.Cmd {
+	return m.form.Init()
+}
+
+// Update processes incoming messages and updates the model state accordingly.
+// Once an assignee is chosen, the filter is applied to the task list.
+func (m assigneeFilterModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c":
+			return m, tea.Quit
+		case "esc":
+			return m.listModel, nil
+		}
+
+	case tea.WindowSizeMsg:
+		h, v := appStyle.GetFrameSize()
+		m.width = msg.Width - h
+		m.height = msg.Height - v
+	}
+
+	var cmds []tea.Cmd
+
+	form, cmd := m.form.Update(msg)
+	if f, ok := form.(*huh.Form); ok {
+		m.form = f
+		cmds = append(cmds, cmd)
+	}
+
+	if m.form.State == huh.StateCompleted {
+		m.listModel.filterAssignee = *m.assignee
+		cmds = append(cmds, m.listModel.reloadTasks())
+
+		status := "Showing tasks of all assignees"
+		if *m.assignee != "" {
+			status = "Showing tasks assigned to " + *m.assignee
+		}
+		cmds = append(cmds, m.listModel.list.NewStatusMessage(status))
+
+		return m.listModel, tea.Batch(cmds...)
+	}
+
+	return m, tea.Batch(cmds...)
+}
+
+// View renders the assignee picker.
+func (m assigneeFilterModel) View() string {
+	v := strings.TrimSuffix(m.form.View(), "\n\n")
+	form := m.lg.NewStyle().Margin(1, 0).Render(v)
+
+	header := formBoundaryView(m.styles, m.width, "Filter "+m.listModel.project.Title+" by assignee", colors.Blue())
+	footer := formBoundaryView(m.styles, m.width,
+		m.form.Help().ShortHelpView(m.form.KeyBinds())+" • esc cancel", colors.Blue())
+
+	return m.styles.Base.Render(header + "\n" + form + "\n\n" + footer)
+}
@@ -0,0 +1,318 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package models
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/handlebargh/yatto/internal/colors"
+	"github.com/handlebargh/yatto/internal/config"
+	"github.com/handlebargh/yatto/internal/helpers"
+	"github.com/handlebargh/yatto/internal/items"
+	"github.com/handlebargh/yatto/internal/vcs"
+)
+
+// openAttachmentErrorMsg is returned when an attachment
+// cannot be opened with the platform's opener.
+type openAttachmentErrorMsg struct{ err error }
+
+// attachFormModel defines the Bubble Tea model for the form used to
+// attach a file to the task shown in the task pager.
+type attachFormModel struct {
+	form          *huh.Form
+	task          *items.Task
+	pager         taskPagerModel
+	path          *string
+	attaching     bool
+	width, height int
+	lg            *lipgloss.Renderer
+	styles        *Styles
+}
+
+// newAttachFormModel returns an attachFormModel for the given task.
+// The pager is shown again when the form is left.
+func newAttachFormModel(task *items.Task, pager taskPagerModel) attachFormModel {
+	path := ""
+
+	m := attachFormModel{
+		task:  task,
+		pager: pager,
+		path:  &path,
+		lg:    lipgloss.DefaultRenderer(),
+	}
+	m.styles = NewStyles(m.lg)
+
+	m.form = huh.NewForm(
+		huh.NewGroup(
+			huh.NewInput().
+				Title("File:").
+				Description("The file is copied into the project.").
+				Value(m.path).
+				Validate(func(str string) error {
+					info, err := os.Stat(expandPath(str))
+					if err != nil {
+						return errors.New("file does not exist")
+					}
+					if !info.Mode().IsRegular() {
+						return errors.New("not a regular file")
+					}
+					return nil
+				}),
+		)).
+		WithWidth(80).
+		WithShowHelp(false).
+		WithShowErrors(false).
+		WithTheme(colors.FormTheme())
+
+	return m
+}
+
+// expandPath replaces a leading "~" in path by the user's home directory.
+func expandPath(path string) string {
+	path = strings.TrimSpace(path)
+
+	if path == "~" || strings.HasPrefix(path, "~"+string(filepath.Separator)) {
+		if home, err := os.UserHomeDir(); err == nil {
+			return filepath.Join(home, path[1:])
+		}
+	}
+
+	return path
+}
+
+// Init initializes the form model and returns the initial command to run.
+func (m attachFormModel) Init() tea.Cmd {
+	return m.form.Init()
+}
+
+// Update processes incoming messages and updates the model state accordingly.
+// Once the file is copied, the task is written and committed together
+// with the attachment.
+func (m attachFormModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	listModel := m.pager.listModel
+	config := listModel.projectModel.config
+	project := *listModel.project
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c":
+			return m, tea.Quit
+		case "esc":
+			if !m.attaching {
+				return m.pager, nil
+			}
+		}
+
+	case tea.WindowSizeMsg:
+		h, v := appStyle.GetFrameSize()
+		m.width = msg.Width - h
+		m.height = msg.Height - v
+
+	case items.AttachFileDoneMsg:
+		cmds := []tea.Cmd{
+			tea.Sequence(
+				m.task.WriteTaskJSON(config, project, "attach"),
+				vcs.CommitCmd(config,
+					fmt.Sprintf("attach: %s\n\n- %s", m.task.Title, filepath.Base(msg.Path)),
+					m.task.Path(project), msg.Path),
+			),
+			tea.WindowSize(),
+		}
+
+		// Show the task again, including the new attachment.
+		pagerModel := newTaskPagerModel(m.task.TaskToMarkdown(), listModel)
+		return pagerModel, tea.Batch(cmds...)
+
+	case items.AttachFileErrorMsg:
+		pagerModel := newTaskPagerModel(m.task.TaskToMarkdown(), listModel)
+		pagerModel.status = lipgloss.NewStyle().
+			Foreground(colors.Red()).
+			Render(fmt.Sprintf("Could not attach file: %s", msg.Error()))
+		return pagerModel, tea.WindowSize()
+	}
+
+	if m.attaching {
+		return m, nil
+	}
+
+	var cmds []tea.Cmd
+
+	form, cmd := m.form.Update(msg)
+	if f, ok := form.(*huh.Form); ok {
+		m.form = f
+		cmds = append(cmds, cmd)
+	}
+
+	if m.form.State == huh.StateCompleted {
+		m.attaching = true
+		cmds = append(cmds, m.task.AttachFile(config, project, expandPath(*m.path)))
+	}
+
+	return m, tea.Batch(cmds...)
+}
+
+// View renders the attach form UI.
+func (m attachFormModel) View() string {
+	v := strings.TrimSuffix(m.form.View(), "\n\n")
+	form := m.lg.NewStyle().Margin(1, 0).Render(v)
+
+	header := formBoundaryView(m.styles, m.width, "Attach file to "+m.task.Title, colors.Indigo())
+	if errs := m.form.Errors(); len(errs) > 0 {
+		header = formBoundaryView(m.styles, m.width, errs[0].Error(), colors.Red())
+	}
+
+	footer := formBoundaryView(m.styles, m.width,
+		m.form.Help().ShortHelpView(m.form.KeyBinds())+" • esc cancel", colors.Indigo())
+	if m.attaching {
+		footer = formBoundaryView(m.styles, m.width, "Copying file...", colors.Indigo())
+	}
+
+	return m.styles.Base.Render(header + "\n" + form + "\n\n" + footer)
+}
+
+// openAttachmentFormModel defines the Bubble Tea model for the form used
+// to choose which attachment of the task shown in the task pager is opened.
+type openAttachmentFormModel struct {
+	form          *huh.Form
+	pager         taskPagerModel
+	name          *string
+	width, height int
+	lg            *lipgloss.Renderer
+	styles        *Styles
+}
+
+// newOpenAttachmentFormModel returns an openAttachmentFormModel
+// listing the attachments known to the pager.
+func newOpenAttachmentFormModel(pager taskPagerModel) openAttachmentFormModel {
+	name := ""
+
+	m := openAttachmentFormModel{
+		pager: pager,
+		name:  &name,
+		lg:    lipgloss.DefaultRenderer(),
+	}
+	m.styles = NewStyles(m.lg)
+
+	options := make([]huh.Option[string], 0, len(pager.attachments))
+	for _, a := range pager.attachments {
+		options = append(options, huh.NewOption(a.String(), a.Name))
+	}
+
+	m.form = huh.NewForm(
+		huh.NewGroup(
+			huh.NewSelect[string]().
+				Title("Attachment:").
+				Options(options...).
+				Value(m.name),
+		)).
+		WithWidth(80).
+		WithShowHelp(false).
+		WithShowErrors(false).
+		WithTheme(colors.FormTheme())
+
+	return m
+}
+
+// Init initializes the form model and returns the initial command to run.
+func (m openAttachmentFormModel) Init() tea.Cmd {
+	return m.form.Init()
+}
+
+// Update processes incoming messages and updates the model state accordingly.
+func (m openAttachmentFormModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c":
+			return m, tea.Quit
+		case "esc":
+			return m.pager, nil
+		}
+
+	case tea.WindowSizeMsg:
+		h, v := appStyle.GetFrameSize()
+		m.width = msg.Width - h
+		m.height = msg.Height - v
+	}
+
+	var cmds []tea.Cmd
+
+	form, cmd := m.form.Update(msg)
+	if f, ok := form.(*huh.Form); ok {
+		m.form = f
+		cmds = append(cmds, cmd)
+	}
+
+	if m.form.State == huh.StateCompleted {
+		for _, a := range m.pager.attachments {
+			if a.Name == *m.name {
+				cmds = append(cmds, m.pager.openAttachmentCmd(a))
+			}
+		}
+
+		return m.pager, tea.Batch(cmds...)
+	}
+
+	return m, tea.Batch(cmds...)
+}
+
+// View renders the attachment selection UI.
+func (m openAttachmentFormModel) View() string {
+	v := strings.TrimSuffix(m.form.View(), "\n\n")
+	form := m.lg.NewStyle().Margin(1, 0).Render(v)
+
+	header := formBoundaryView(m.styles, m.width, "Open attachment", colors.Indigo())
+	footer := formBoundaryView(m.styles, m.width,
+		m.form.Help().ShortHelpView(m.form.KeyBinds())+" • esc cancel", colors.Indigo())
+
+	return m.styles.Base.Render(header + "\n" + form + "\n\n" + footer)
+}
+
+// openAttachmentCmd opens the given attachment of the selected task
+// with the platform's opener.
+func (m taskPagerModel) openAttachmentCmd(a items.Attachment) tea.Cmd {
+	t := m.selectedTask()
+	if t == nil {
+		return nil
+	}
+
+	path := filepath.Join(
+		config.Get(m.listModel.projectModel.config).StoragePath,
+		t.AttachmentsDir(*m.listModel.project),
+		a.Name,
+	)
+
+	return func() tea.Msg {
+		if err := helpers.Open(path); err != nil {
+			return openAttachmentErrorMsg{err}
+		}
+
+		return nil
+	}
+}
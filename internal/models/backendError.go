@@ -0,0 +1,327 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package models
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/handlebargh/yatto/internal/colors"
+	"github.com/handlebargh/yatto/internal/config"
+	"github.com/handlebargh/yatto/internal/storage"
+	"github.com/handlebargh/yatto/internal/vcs"
+	"github.com/spf13/viper"
+)
+
+// backendErrorKind classifies a failed backend operation, so that the
+// error screen can explain it and offer the matching actions.
+type backendErrorKind int
+
+const (
+	// backendErrorOther is any failure not classified below.
+	backendErrorOther backendErrorKind = iota
+
+	// backendErrorAuth means the remote refused access.
+	backendErrorAuth
+
+	// backendErrorNetwork means the remote could not be reached.
+	backendErrorNetwork
+
+	// backendErrorConflict means a pull stopped because of conflicts.
+	backendErrorConflict
+
+	// backendErrorMissingBinary means the vcs program is not installed.
+	backendErrorMissingBinary
+
+	// backendErrorMirror means only the push to a mirror failed.
+	backendErrorMirror
+
+	// backendErrorDiverged means the gogit backend could not pull
+	// because local and remote history have diverged.
+	backendErrorDiverged
+)
+
+// backendErrorLabels name the kinds of backend errors in the error screen.
+var backendErrorLabels = map[backendErrorKind]string{
+	backendErrorOther:         "Backend error",
+	backendErrorAuth:          "Authentication error",
+	backendErrorNetwork:       "Network error",
+	backendErrorConflict:      "Conflict",
+	backendErrorMissingBinary: "Missing program",
+	backendErrorMirror:        "Mirror error",
+	backendErrorDiverged:      "Diverged history",
+}
+
+// backendOp is the operation that failed, so that it can be retried.
+type backendOp int
+
+const (
+	// backendOpOther cannot be retried, e.g. a commit or writing a file.
+	backendOpOther backendOp = iota
+
+	// backendOpInit is the initialization of the storage repository.
+	backendOpInit
+
+	// backendOpPull is a pull, possibly as part of a commit.
+	backendOpPull
+
+	// backendOpPush is a push, possibly as part of a commit.
+	backendOpPush
+)
+
+// backendAction is what the user chose to do in the error screen.
+type backendAction int
+
+const (
+	backendActionNone backendAction = iota
+	backendActionClose
+	backendActionRetry
+	backendActionSkip
+	backendActionResolve
+	backendActionCopy
+)
+
+// backendErrorOutputLines is the number of lines of the command output
+// shown when it is expanded. Earlier lines are left out.
+const backendErrorOutputLines = 15
+
+// backendError is a failed backend operation as shown by the error
+// screen of the project and task lists.
+type backendError struct {
+	kind     backendErrorKind
+	op       backendOp
+	err      error
+	output   string
+	problem  string
+	hint     string
+	notice   string
+	expanded bool
+}
+
+// newBackendError classifies a failed operation by its error, its
+// command output and the state of the storage repository.
+func newBackendError(v *viper.Viper, op backendOp, output string, err error) *backendError {
+	e := &backendError{
+		op:      op,
+		err:     err,
+		output:  strings.TrimSpace(output),
+		problem: "An error occurred during a backend operation",
+		hint:    "Please commit manually!",
+	}
+
+	var mirrorErr *vcs.MirrorError
+	var execErr *exec.Error
+
+	switch {
+	case err == nil:
+		e.err = errors.New("unknown error")
+	case errors.As(err, &mirrorErr):
+		e.kind = backendErrorMirror
+		e.problem = "Changes were committed, but some remotes could not be pushed to"
+		e.hint = "Retry, or skip the push to try again with the next sync."
+	case errors.As(err, &execErr) && errors.Is(err, exec.ErrNotFound):
+		e.kind = backendErrorMissingBinary
+		e.problem = fmt.Sprintf("%s is not installed", execErr.Name)
+		e.hint = "Install it, or choose another vcs backend in the config file."
+	case errors.Is(err, vcs.ErrDiverged):
+		e.kind = backendErrorDiverged
+		e.problem = "Local and remote history have diverged, which the gogit backend cannot merge"
+		e.hint = fmt.Sprintf("Run 'git pull --rebase' in %s and retry, or switch to the git backend.", config.Get(v).StoragePath)
+	case vcs.ConflictInProgress(v):
+		e.kind = backendErrorConflict
+		e.problem = "The pulled changes conflict with local changes"
+		e.hint = "Keep the local or the remote version of each file. Nothing can be synced until then."
+	default:
+		if remoteErr := vcs.DiagnoseRemote(output, err); remoteErr != nil {
+			e.kind = backendErrorAuth
+			if remoteErr.Network {
+				e.kind = backendErrorNetwork
+			}
+			e.problem = remoteErr.Problem
+			e.hint = strings.ToUpper(remoteErr.Hint[:1]) + remoteErr.Hint[1:] + "."
+		} else if op != backendOpOther {
+			e.hint = "Check the command output, then retry."
+		}
+	}
+
+	return e
+}
+
+// canRetry reports whether the failed operation can be run again.
+func (e *backendError) canRetry() bool {
+	return e.op != backendOpOther && e.kind != backendErrorConflict
+}
+
+// canSkip reports whether the push can be left to the next sync.
+func (e *backendError) canSkip() bool {
+	return (e.op == backendOpPull || e.op == backendOpPush || e.kind == backendErrorMirror) &&
+		e.kind != backendErrorConflict
+}
+
+// action returns the action chosen by key. Toggling the command
+// output is handled right away.
+func (e *backendError) action(key string) backendAction {
+	switch key {
+	case "esc", "q":
+		return backendActionClose
+	case "o":
+		e.expanded = !e.expanded
+	case "y":
+		return backendActionCopy
+	case "r":
+		if e.canRetry() {
+			return backendActionRetry
+		}
+	case "s":
+		if e.canSkip() {
+			return backendActionSkip
+		}
+	case "c":
+		if e.kind == backendErrorConflict {
+			return backendActionResolve
+		}
+	}
+
+	return backendActionNone
+}
+
+// retryCmd returns the command running the failed operation again.
+func (e *backendError) retryCmd(v *viper.Viper) tea.Cmd {
+	switch e.op {
+	case backendOpInit:
+		return vcs.InitCmd(v)
+	case backendOpPull:
+		return vcs.PullCmd(v)
+	default:
+		return vcs.PushCmd(v)
+	}
+}
+
+// skip leaves the push to the next sync and returns the status to show.
+// The local commits are counted as unpushed, so the sync pushes them.
+func (e *backendError) skip(v *viper.Viper) string {
+	if e.kind == backendErrorMirror {
+		return "⇡  Push to mirrors skipped"
+	}
+
+	n := storage.UnpushedCommits(v)
+	if n == 0 {
+		n, _ = storage.AddUnpushedCommit(v)
+	}
+
+	return unpushedStatus(n)
+}
+
+// report returns the error with the full command output for copying.
+func (e *backendError) report() string {
+	var b strings.Builder
+
+	b.WriteString(backendErrorLabels[e.kind] + ": " + e.problem + "\n")
+	b.WriteString(e.err.Error() + "\n")
+	if e.output != "" {
+		b.WriteString("\n" + e.output + "\n")
+	}
+
+	return b.String()
+}
+
+// relevantLine returns the line of the command output that most likely
+// tells what went wrong.
+func (e *backendError) relevantLine() string {
+	lines := strings.Split(e.output, "\n")
+
+	for _, line := range lines {
+		lower := strings.ToLower(line)
+		for _, prefix := range []string{"fatal:", "error:", "conflict", "remote: error"} {
+			if strings.HasPrefix(lower, prefix) {
+				return strings.TrimSpace(line)
+			}
+		}
+	}
+
+	return strings.TrimSpace(lines[len(lines)-1])
+}
+
+// view renders the error screen for the given width.
+func (e *backendError) view(width int) string {
+	width = max(min(width-8, 100), 20)
+
+	title := lipgloss.NewStyle().Bold(true).Foreground(colors.Red())
+	faint := lipgloss.NewStyle().Faint(true)
+
+	var b strings.Builder
+
+	b.WriteString(title.Render(backendErrorLabels[e.kind] + ": " + e.problem))
+	b.WriteString("\n\n")
+	b.WriteString(e.hint)
+	b.WriteString("\n\n")
+
+	switch {
+	case e.output == "":
+		b.WriteString(e.err.Error())
+	case e.expanded:
+		lines := strings.Split(e.output, "\n")
+		if len(lines) > backendErrorOutputLines {
+			b.WriteString(faint.Render(fmt.Sprintf("… %d earlier line(s)", len(lines)-backendErrorOutputLines)))
+			b.WriteString("\n")
+			lines = lines[len(lines)-backendErrorOutputLines:]
+		}
+		b.WriteString(strings.Join(lines, "\n"))
+	default:
+		b.WriteString(e.relevantLine())
+		b.WriteString("\n")
+		b.WriteString(faint.Render(fmt.Sprintf("▸ %d line(s) of command output", strings.Count(e.output, "\n")+1)))
+	}
+
+	var actions []string
+	if e.canRetry() {
+		actions = append(actions, "[r] Retry")
+	}
+	if e.canSkip() {
+		actions = append(actions, "[s] Skip push")
+	}
+	if e.kind == backendErrorConflict {
+		actions = append(actions, "[c] Resolve conflicts")
+	}
+	if e.output != "" {
+		if e.expanded {
+			actions = append(actions, "[o] Hide output")
+		} else {
+			actions = append(actions, "[o] Show output")
+		}
+	}
+	actions = append(actions, "[y] Copy error", "[esc] Close")
+
+	b.WriteString("\n\n")
+	b.WriteString(strings.Join(actions, "   "))
+
+	if e.notice != "" {
+		b.WriteString("\n\n")
+		b.WriteString(faint.Render(e.notice))
+	}
+
+	// Lines are wrapped and aligned left within the centered screen.
+	return lipgloss.NewStyle().Width(width).Render(b.String())
+}
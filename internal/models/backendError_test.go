@@ -0,0 +1,115 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package models
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+	"testing"
+
+	"github.com/handlebargh/yatto/internal/vcs"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewBackendError(t *testing.T) {
+	v := viper.New()
+	v.Set("vcs.backend", "git")
+	v.Set("storage.path", t.TempDir())
+
+	exitErr := errors.New("exit status 128")
+	_, notFound := exec.LookPath("yatto-missing-binary")
+
+	tests := []struct {
+		name      string
+		op        backendOp
+		output    string
+		err       error
+		kind      backendErrorKind
+		canRetry  bool
+		canSkip   bool
+		wantFirst string
+	}{
+		{
+			"auth", backendOpPush,
+			"git@github.com: Permission denied (publickey).\nfatal: Could not read from remote repository.",
+			exitErr, backendErrorAuth, true, true, "fatal: Could not read from remote repository.",
+		},
+		{
+			"network", backendOpPull,
+			"ssh: Could not resolve hostname gitlab.example: Name or service not known",
+			exitErr, backendErrorNetwork, true, true, "ssh: Could not resolve hostname gitlab.example: Name or service not known",
+		},
+		{
+			"missing binary", backendOpInit, "", notFound,
+			backendErrorMissingBinary, true, false, "",
+		},
+		{
+			"mirror", backendOpOther, "backup: rejected",
+			&vcs.MirrorError{Failed: []string{"backup"}}, backendErrorMirror, false, true, "backup: rejected",
+		},
+		{
+			"diverged", backendOpPull, "local and remote history have diverged and cannot be fast-forwarded",
+			fmt.Errorf("%w: %w", vcs.ErrDiverged, errors.New("non-fast-forward update")),
+			backendErrorDiverged, true, true, "local and remote history have diverged and cannot be fast-forwarded",
+		},
+		{
+			"other", backendOpOther, "error: cannot lock ref\nhint: try again",
+			exitErr, backendErrorOther, false, false, "error: cannot lock ref",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newBackendError(v, tt.op, tt.output, tt.err)
+
+			assert.Equal(t, tt.kind, e.kind)
+			assert.Equal(t, tt.canRetry, e.canRetry())
+			assert.Equal(t, tt.canSkip, e.canSkip())
+			if tt.output != "" {
+				assert.Equal(t, tt.wantFirst, e.relevantLine())
+			}
+		})
+	}
+}
+
+func TestBackendErrorAction(t *testing.T) {
+	v := viper.New()
+	v.Set("vcs.backend", "git")
+	v.Set("storage.path", t.TempDir())
+
+	e := newBackendError(v, backendOpOther, "fatal: bad object", errors.New("exit status 128"))
+
+	// Actions that are not offered are ignored.
+	assert.Equal(t, backendActionNone, e.action("r"))
+	assert.Equal(t, backendActionNone, e.action("s"))
+	assert.Equal(t, backendActionNone, e.action("c"))
+	assert.Equal(t, backendActionCopy, e.action("y"))
+	assert.Equal(t, backendActionClose, e.action("esc"))
+
+	assert.NotContains(t, e.view(80), "[r] Retry")
+	assert.Contains(t, e.view(80), "fatal: bad object")
+
+	assert.Equal(t, backendActionNone, e.action("o"))
+	assert.True(t, e.expanded)
+	assert.Contains(t, e.report(), "fatal: bad object")
+}
@@ -0,0 +1,193 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package models
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/handlebargh/yatto/internal/colors"
+	"github.com/handlebargh/yatto/internal/config"
+	"github.com/handlebargh/yatto/internal/hooks"
+	"github.com/handlebargh/yatto/internal/items"
+	"github.com/handlebargh/yatto/internal/storage"
+	"github.com/handlebargh/yatto/internal/vcs"
+	"github.com/spf13/viper"
+)
+
+// syncTickMsg is sent when the configured sync interval has elapsed.
+type syncTickMsg struct{}
+
+// syncDoneMsg carries the result of a background pull or push,
+// i.e. the message returned by vcs.PullCmd or vcs.PushCmd, the
+// error of the hooks run after a successful sync and the changes
+// made by a pull.
+type syncDoneMsg struct {
+	result  tea.Msg
+	hookErr error
+	feed    []feedEntry
+}
+
+// syncInterval returns the configured background sync interval.
+// It returns 0 if background sync is disabled or no remote is enabled.
+func syncInterval(v *viper.Viper) time.Duration {
+	if !vcs.RemoteEnabled(v) {
+		return 0
+	}
+
+	return config.Get(v).SyncInterval
+}
+
+// scheduleSync returns a command that sends a syncTickMsg
+// once the sync interval has elapsed.
+func (m *ProjectListModel) scheduleSync() tea.Cmd {
+	d := syncInterval(m.config)
+	if d <= 0 {
+		return nil
+	}
+
+	m.state.nextSync = time.Now().Add(d)
+
+	return tea.Tick(d, func(time.Time) tea.Msg {
+		return syncTickMsg{}
+	})
+}
+
+// startSync pulls from the remote in the background. Commits that could
+// not be pushed before are pushed as well. The changes made by a pull
+// are collected for the change feed, see diffSnapshots.
+func (m *ProjectListModel) startSync() tea.Cmd {
+	m.state.syncing = true
+	v := m.config
+
+	return func() tea.Msg {
+		if storage.UnpushedCommits(v) > 0 {
+			result := vcs.PushCmd(v)()
+			if _, ok := result.(vcs.PushDoneMsg); ok {
+				return syncDoneMsg{result: result, hookErr: runSyncHooks(v)}
+			}
+
+			return syncDoneMsg{result: result}
+		}
+
+		before := takeSyncSnapshot(v)
+		result := vcs.PullCmd(v)()
+		if _, ok := result.(vcs.PullDoneMsg); !ok {
+			return syncDoneMsg{result: result}
+		}
+
+		feed := diffSnapshots(before, takeSyncSnapshot(v), pulledAuthors(v, before.head))
+
+		return syncDoneMsg{result: result, hookErr: runSyncHooks(v), feed: feed}
+	}
+}
+
+// handleSyncTick starts a background sync unless one is already running.
+// While a foreground backend operation is running or commits are queued,
+// the sync is postponed to the next interval.
+func (m *ProjectListModel) handleSyncTick(busy bool) tea.Cmd {
+	switch {
+	case m.state.syncing:
+		return nil
+	case busy, vcs.CommitPending():
+		return m.scheduleSync()
+	default:
+		return m.startSync()
+	}
+}
+
+// resumeSync restarts background syncing if a syncTickMsg got lost
+// because it arrived while another view was active.
+func (m *ProjectListModel) resumeSync() tea.Cmd {
+	if m.state.syncing || m.state.nextSync.IsZero() || time.Now().Before(m.state.nextSync) {
+		return nil
+	}
+
+	return m.startSync()
+}
+
+// finishSync schedules the next sync and returns the status message
+// to display, which sums up the pulled changes. After a successful sync,
+// a storage.StorageChangedMsg is sent so that the open lists pick up the
+// pulled changes. To show the change feed instead, see showChangeFeed.
+func (m *ProjectListModel) finishSync(msg syncDoneMsg) (string, tea.Cmd) {
+	m.state.syncing = false
+	m.state.loadSyncStatus(m.config)
+	next := m.scheduleSync()
+
+	failed := lipgloss.NewStyle().Foreground(colors.Red())
+
+	switch result := msg.result.(type) {
+	case vcs.PullDoneMsg, vcs.PushDoneMsg:
+		status := "⟳  Synced"
+		if len(msg.feed) > 0 {
+			status += ": " + summarizeFeed(msg.feed)
+		}
+		if msg.hookErr != nil {
+			reason, _, _ := strings.Cut(msg.hookErr.Error(), "\n")
+			status = failed.Render("⟳  Synced, but " + reason)
+		}
+
+		return status, tea.Batch(next, m.pulledChanges())
+
+	case vcs.PullErrorMsg:
+		return failed.Render("⟳  Sync failed: " + syncFailure(result.CmdOutput, result.Err)), next
+
+	case vcs.PushErrorMsg:
+		return failed.Render("⟳  Sync failed: " + syncFailure(result.CmdOutput, result.Err)), next
+
+	default:
+		return failed.Render("⟳  Sync failed: repository not initialized"), next
+	}
+}
+
+// syncFailure returns the reason of a failed background sync for the
+// status bar, the problem if it is a known remote problem.
+func syncFailure(output string, err error) string {
+	if remoteErr := vcs.DiagnoseRemote(output, err); remoteErr != nil {
+		return remoteErr.Problem
+	}
+
+	reason, _, _ := strings.Cut(err.Error(), "\n")
+	return reason
+}
+
+// runSyncHooks runs the hooks after a successful sync. The pulled
+// changes may have made tasks overdue, so these are checked as well.
+func runSyncHooks(v *viper.Viper) error {
+	now := time.Now()
+
+	return errors.Join(hooks.RunSyncFinished(v, now), items.NotifyOverdue(v, now))
+}
+
+// showChangeFeed returns the change feed for the changes of a finished
+// sync on top of current, or nil if nothing was pulled. The lists pick
+// up the changes when the feed is left.
+func (m *ProjectListModel) showChangeFeed(msg syncDoneMsg, current tea.Model) tea.Model {
+	if len(msg.feed) == 0 {
+		return nil
+	}
+
+	return newChangeFeedModel(m, current, m.pulledChanges(), msg.feed)
+}
@@ -0,0 +1,276 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package models
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/handlebargh/yatto/internal/colors"
+	"github.com/handlebargh/yatto/internal/helpers"
+	"github.com/handlebargh/yatto/internal/items"
+	"github.com/handlebargh/yatto/internal/vcs"
+)
+
+// bulkClear is the form value that clears a field of all tasks.
+// Empty values keep the fields unchanged.
+const bulkClear = "-"
+
+// bulkEditVars holds the values entered in the bulk edit form.
+type bulkEditVars struct {
+	confirm     bool
+	priority    string
+	dueDate     string
+	assignee    string
+	addLabel    string
+	removeLabel string
+}
+
+// bulkEditModel defines the Bubble Tea model for the condensed form
+// used to change several selected tasks at once.
+type bulkEditModel struct {
+	form          *huh.Form
+	listModel     *taskListModel
+	tasks         []*items.Task
+	vars          *bulkEditVars
+	width, height int
+	lg            *lipgloss.Renderer
+	styles        *Styles
+}
+
+// newBulkEditModel returns a bulkEditModel for the tasks selected in
+// the given task list, in list order.
+func newBulkEditModel(listModel *taskListModel) bulkEditModel {
+	var tasks []*items.Task
+	var labels []string
+	for _, item := range listModel.list.Items() {
+		if t, ok := item.(*items.Task); ok {
+			if _, selected := listModel.selectedItems[t.ID]; selected {
+				tasks = append(tasks, t)
+				labels = append(labels, t.Labels...)
+			}
+		}
+	}
+
+	m := bulkEditModel{
+		listModel: listModel,
+		tasks:     tasks,
+		vars:      &bulkEditVars{confirm: true},
+		lg:        lipgloss.DefaultRenderer(),
+	}
+	m.styles = NewStyles(m.lg)
+
+	// Ignore error just like the task form does.
+	contributors, _ := vcs.AllContributors(listModel.projectModel.config)
+	assignees := []huh.Option[string]{huh.NewOption("(keep)", ""), huh.NewOption("(nobody)", bulkClear)}
+	for _, c := range contributors {
+		assignees = append(assignees, huh.NewOption(c, c))
+	}
+
+	labels = helpers.UniqueNonEmptyStrings(labels)
+	slices.SortFunc(labels, func(a, b string) int {
+		return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+	})
+	removeLabels := []huh.Option[string]{huh.NewOption("(none)", "")}
+	for _, l := range labels {
+		removeLabels = append(removeLabels, huh.NewOption(l, l))
+	}
+
+	m.form = huh.NewForm(
+		huh.NewGroup(
+			huh.NewSelect[string]().
+				Title("Priority:").
+				Options(
+					huh.NewOption("(keep)", ""),
+					huh.NewOption("low", "low"),
+					huh.NewOption("medium", "medium"),
+					huh.NewOption("high", "high"),
+				).
+				Value(&m.vars.priority),
+
+			huh.NewInput().
+				Title("Due date:").
+				Description("e.g. \"tomorrow\" or \"2026-02-14\".\n"+
+					"Leave empty to keep, enter - to clear.").
+				Value(&m.vars.dueDate).
+				Validate(func(str string) error {
+					str = strings.TrimSpace(str)
+					if str == "" || str == bulkClear {
+						return nil
+					}
+					if _, err := helpers.ParseDueDate(str); err != nil {
+						return errors.New("invalid due date")
+					}
+					return nil
+				}),
+
+			huh.NewSelect[string]().
+				Title("Assignee:").
+				Height(8).
+				Options(assignees...).
+				Value(&m.vars.assignee),
+
+			huh.NewInput().
+				Title("Add label:").
+				Value(&m.vars.addLabel),
+
+			huh.NewSelect[string]().
+				Title("Remove label:").
+				Height(8).
+				Options(removeLabels...).
+				Value(&m.vars.removeLabel),
+
+			huh.NewConfirm().
+				Title(fmt.Sprintf("Apply to %d task(s)?", len(tasks))).
+				Affirmative("Yes").
+				Negative("No").
+				Value(&m.vars.confirm),
+		)).
+		WithWidth(80).
+		WithShowHelp(false).
+		WithShowErrors(false).
+		WithTheme(colors.FormTheme())
+
+	return m
+}
+
+// Init initializes the form model and returns the initial command to run.
+func (m bulkEditModel) Init() tea.Cmd {
+	return m.form.Init()
+}
+
+// Update processes incoming messages and updates the model state accordingly.
+func (m bulkEditModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c":
+			return m, tea.Quit
+		case "esc":
+			return m.listModel, nil
+		}
+
+	case tea.WindowSizeMsg:
+		h, v := appStyle.GetFrameSize()
+		m.width = msg.Width - h
+		m.height = msg.Height - v
+	}
+
+	var cmds []tea.Cmd
+
+	form, cmd := m.form.Update(msg)
+	if f, ok := form.(*huh.Form); ok {
+		m.form = f
+		cmds = append(cmds, cmd)
+	}
+
+	if m.form.State == huh.StateCompleted {
+		if !m.vars.confirm {
+			return m.listModel, nil
+		}
+
+		return m.listModel, tea.Batch(append(cmds, m.apply()...)...)
+	}
+
+	return m, tea.Batch(cmds...)
+}
+
+// apply makes the entered changes to the tasks and returns the commands
+// writing the changed tasks and committing them in a single commit.
+func (m bulkEditModel) apply() []tea.Cmd {
+	edit := items.BulkEdit{
+		Priority:    m.vars.priority,
+		AddLabel:    m.vars.addLabel,
+		RemoveLabel: m.vars.removeLabel,
+	}
+
+	switch dueDate := strings.TrimSpace(m.vars.dueDate); dueDate {
+	case "":
+	case bulkClear:
+		edit.ClearDueDate = true
+	default:
+		// The due date was validated by the form.
+		date, _ := helpers.ParseDueDate(dueDate)
+		edit.DueDate = &date
+	}
+
+	switch m.vars.assignee {
+	case "":
+	case bulkClear:
+		edit.ClearAssignee = true
+	default:
+		edit.Assignee = m.vars.assignee
+	}
+
+	listModel := m.listModel
+	config := listModel.projectModel.config
+	project := *listModel.project
+
+	var writeCmds []tea.Cmd
+	var taskPaths, taskNames []string
+	for _, t := range m.tasks {
+		if edit.Apply(t) {
+			writeCmds = append(writeCmds, t.WriteTaskJSON(config, project, "update"))
+			taskPaths = append(taskPaths, t.Path(project))
+			taskNames = append(taskNames, t.Title)
+		}
+	}
+
+	for k := range listModel.selectedItems {
+		delete(listModel.selectedItems, k)
+	}
+
+	if len(writeCmds) == 0 {
+		return []tea.Cmd{listModel.list.NewStatusMessage("Nothing changed")}
+	}
+
+	message := fmt.Sprintf("bulk edit: %d task(s)\n\n- %s", len(taskNames), strings.Join(taskNames, "\n- "))
+
+	listModel.spinning = true
+	listModel.status = ""
+
+	return []tea.Cmd{
+		listModel.spinner.Tick,
+		tea.Sequence(append(writeCmds, vcs.CommitCmd(config, message, taskPaths...))...),
+	}
+}
+
+// View renders the bulk edit form UI.
+func (m bulkEditModel) View() string {
+	v := strings.TrimSuffix(m.form.View(), "\n\n")
+	form := m.lg.NewStyle().Margin(1, 0).Render(v)
+
+	header := formBoundaryView(m.styles, m.width,
+		fmt.Sprintf("Edit %d selected task(s)", len(m.tasks)), colors.Orange())
+	if errs := m.form.Errors(); len(errs) > 0 {
+		header = formBoundaryView(m.styles, m.width, errs[0].Error(), colors.Red())
+	}
+
+	footer := formBoundaryView(m.styles, m.width,
+		m.form.Help().ShortHelpView(m.form.KeyBinds())+" • esc cancel", colors.Orange())
+
+	return m.styles.Base.Render(header + "\n" + form + "\n\n" + footer)
+}
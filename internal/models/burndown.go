@@ -0,0 +1,554 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package models
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/handlebargh/yatto/internal/colors"
+	"github.com/handlebargh/yatto/internal/config"
+	"github.com/handlebargh/yatto/internal/helpers"
+	"github.com/handlebargh/yatto/internal/items"
+	"github.com/handlebargh/yatto/internal/storage"
+	"github.com/handlebargh/yatto/internal/vcs"
+)
+
+// burndownLogLimit is the maximum number of commits read to find the
+// changes of a project. Older changes don't show up in the chart.
+const burndownLogLimit = 5000
+
+// burndownWindows are the time windows in days the chart cycles through.
+var burndownWindows = []int{14, 30, 90, 7}
+
+// burndownBlocks are the characters used to draw fractions of a chart cell.
+var burndownBlocks = []rune(" ▁▂▃▄▅▆▇█")
+
+// The sources the task counts of the burndown chart are computed from.
+const (
+	// burndownLocal uses the counts of tasks created and completed
+	// on this computer, see storage.CountTasks.
+	burndownLocal = "local counts"
+
+	// burndownHistory uses the snapshots of the repository history.
+	burndownHistory = "history"
+)
+
+// burndownKeyMap defines the key bindings used in the burndown view.
+type burndownKeyMap struct {
+	quit   key.Binding
+	window key.Binding
+	source key.Binding
+}
+
+// newBurndownKeyMap initializes and returns a new key map for burndown actions.
+func newBurndownKeyMap() *burndownKeyMap {
+	return &burndownKeyMap{
+		quit: key.NewBinding(
+			key.WithKeys("q", "esc", "h"),
+			key.WithHelp("q/esc", "go back"),
+		),
+		window: key.NewBinding(
+			key.WithKeys("w"),
+			key.WithHelp("w", "change time window"),
+		),
+		source: key.NewBinding(
+			key.WithKeys("s"),
+			key.WithHelp("s", "switch local counts/history"),
+		),
+	}
+}
+
+// burndownDay holds the task counts of a project at the end of a day.
+type burndownDay struct {
+	date      time.Time
+	open      int
+	completed int // tasks completed on that day
+	created   int // tasks created on that day
+}
+
+// burndownCountsMsg is sent when the local task counts of
+// the project were loaded, see loadCounts.
+type burndownCountsMsg struct {
+	counts map[string]storage.DayStats
+	since  time.Time
+	open   int
+	err    error
+}
+
+// burndownModel represents the Bubble Tea model for the burndown chart of
+// a project. With the history as source, the state of the project at the
+// end of each day is read from the snapshot of the last commit of that
+// day that touched the project. Reading the snapshots is slow, so the
+// local task counts are used instead if they cover the time window or
+// there is no history, as with the none backend. They miss the changes
+// made on other computers though.
+type burndownModel struct {
+	projectModel  *ProjectListModel
+	project       *items.Project
+	keys          *burndownKeyMap
+	help          help.Model
+	window        int
+	source        string
+	entries       []vcs.LogEntry
+	states        map[string]map[string]bool
+	pending       string
+	loading       bool
+	logLoaded     bool
+	cmdOutput     string
+	err           error
+	counts        map[string]storage.DayStats
+	countedSince  time.Time
+	openNow       int
+	countsLoaded  bool
+	countsErr     error
+	width, height int
+}
+
+// newBurndownModel creates a new burndownModel for the given project.
+// The history is loaded asynchronously by the command returned from Init.
+func newBurndownModel(project *items.Project, projectModel *ProjectListModel, width, height int) burndownModel {
+	h, v := appStyle.GetFrameSize()
+
+	m := burndownModel{
+		projectModel: projectModel,
+		project:      project,
+		keys:         newBurndownKeyMap(),
+		help:         help.New(),
+		window:       burndownWindows[0],
+		source:       burndownHistory,
+		states:       make(map[string]map[string]bool),
+		loading:      true,
+		width:        width - h,
+		height:       height - v,
+	}
+
+	noHistory := config.Get(projectModel.config).VCSBackend == "none"
+	m.keys.source.SetEnabled(!noHistory)
+
+	// Unreadable counts are reported once loaded.
+	_, since, _ := storage.ProjectDayStats(projectModel.config, project.ID)
+	if noHistory || (!since.IsZero() && since.Before(m.dayEnds()[0])) {
+		m.source = burndownLocal
+		m.loading = false
+	}
+
+	return m
+}
+
+// Init initializes the burndownModel and starts loading the local task
+// counts and, with the history as source, the commits.
+func (m burndownModel) Init() tea.Cmd {
+	if m.source == burndownLocal {
+		return m.loadCounts()
+	}
+
+	return tea.Batch(m.loadCounts(), vcs.LogCmd(m.projectModel.config, burndownLogLimit))
+}
+
+// loadCounts returns a command that loads the local task counts of the
+// project along with the number of its tasks open now.
+func (m burndownModel) loadCounts() tea.Cmd {
+	v := m.projectModel.config
+	project := m.project
+
+	return func() tea.Msg {
+		counts, since, err := storage.ProjectDayStats(v, project.ID)
+		if err != nil {
+			return burndownCountsMsg{err: err}
+		}
+
+		stats, err := project.CachedStats(v)
+		if err != nil {
+			return burndownCountsMsg{err: err}
+		}
+
+		return burndownCountsMsg{counts: counts, since: since, open: stats.Total - stats.Completed}
+	}
+}
+
+// Update handles incoming messages and updates the burndownModel accordingly.
+func (m burndownModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		h, v := appStyle.GetFrameSize()
+		m.width = msg.Width - h
+		m.height = msg.Height - v
+
+	case burndownCountsMsg:
+		m.countsLoaded = true
+		m.counts = msg.counts
+		m.countedSince = msg.since
+		m.openNow = msg.open
+		m.countsErr = msg.err
+
+	case vcs.LogDoneMsg:
+		m.logLoaded = true
+		prefix := m.project.ID + "/"
+		for _, entry := range msg.Entries {
+			if slices.ContainsFunc(entry.Files, func(f string) bool { return strings.HasPrefix(f, prefix) }) {
+				m.entries = append(m.entries, entry)
+			}
+		}
+
+		return m.loadNext()
+
+	case vcs.LogErrorMsg:
+		m.loading = false
+		m.cmdOutput = msg.CmdOutput
+		m.err = msg.Err
+
+	case vcs.SnapshotDoneMsg:
+		if msg.Hash != m.pending {
+			return m, nil
+		}
+
+		m.states[msg.Hash] = m.taskStates(msg.Files)
+		return m.loadNext()
+
+	case vcs.SnapshotErrorMsg:
+		m.loading = false
+		m.pending = ""
+		m.cmdOutput = msg.CmdOutput
+		m.err = msg.Err
+
+	case tea.KeyMsg:
+		if msg.Type == tea.KeyCtrlC {
+			return m, tea.Quit
+		}
+
+		switch {
+		case key.Matches(msg, m.keys.quit):
+			return m.projectModel, func() tea.Msg { return returnedToProjectListMsg{} }
+
+		case key.Matches(msg, m.keys.window):
+			i := slices.Index(burndownWindows, m.window)
+			m.window = burndownWindows[(i+1)%len(burndownWindows)]
+			if m.source == burndownLocal || m.err != nil {
+				return m, nil
+			}
+			if m.pending != "" {
+				// The snapshot being loaded continues loading the rest.
+				return m, nil
+			}
+
+			return m.loadNext()
+
+		case key.Matches(msg, m.keys.source):
+			if m.source == burndownHistory {
+				m.source = burndownLocal
+				return m, nil
+			}
+
+			m.source = burndownHistory
+			if !m.logLoaded && m.err == nil {
+				m.loading = true
+				return m, vcs.LogCmd(m.projectModel.config, burndownLogLimit)
+			}
+			if m.pending != "" || m.err != nil {
+				return m, nil
+			}
+
+			return m.loadNext()
+		}
+	}
+
+	return m, nil
+}
+
+// dayEnds returns the end of each day of the time window plus the day
+// before, which the changes of the first day are compared against.
+func (m burndownModel) dayEnds() []time.Time {
+	now := time.Now()
+	tomorrow := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, now.Location())
+
+	ends := make([]time.Time, m.window+1)
+	for i := range ends {
+		ends[i] = tomorrow.AddDate(0, 0, i-m.window)
+	}
+
+	return ends
+}
+
+// commitAt returns the hash of the last commit that touched the project
+// before the given time, or an empty string if there is none.
+func (m burndownModel) commitAt(t time.Time) string {
+	// Entries are ordered from newest to oldest.
+	for _, entry := range m.entries {
+		if entry.Date.Before(t) {
+			return entry.Hash
+		}
+	}
+
+	return ""
+}
+
+// loadNext requests the next snapshot needed for the time window,
+// or finishes loading if all of them are available.
+func (m burndownModel) loadNext() (tea.Model, tea.Cmd) {
+	for _, end := range m.dayEnds() {
+		hash := m.commitAt(end)
+		if _, ok := m.states[hash]; hash != "" && !ok {
+			m.loading = true
+			m.pending = hash
+			return m, vcs.SnapshotCmd(m.projectModel.config, hash)
+		}
+	}
+
+	m.loading = false
+	m.pending = ""
+
+	return m, nil
+}
+
+// taskStates returns whether each task of the project in the given
+// snapshot is completed, keyed by task ID. Archived tasks are included.
+func (m burndownModel) taskStates(files map[string][]byte) map[string]bool {
+	states := make(map[string]bool)
+
+	projects, _ := parseSnapshot(files)
+	for _, sp := range projects {
+		if sp.project.ID != m.project.ID {
+			continue
+		}
+
+		for _, t := range sp.tasks {
+			states[t.ID] = t.Completed
+		}
+	}
+
+	return states
+}
+
+// days computes the task counts of each day in the time window
+// from the source in use.
+func (m burndownModel) days() []burndownDay {
+	if m.source == burndownLocal {
+		return m.countedDays()
+	}
+
+	return m.historyDays()
+}
+
+// countedDays computes the task counts of each day in the time window
+// from the local task counts. The tasks open at the end of each day are
+// worked out backwards from the tasks open now, so tasks deleted or
+// reopened since are not taken into account.
+func (m burndownModel) countedDays() []burndownDay {
+	ends := m.dayEnds()
+	days := make([]burndownDay, m.window)
+
+	open := m.openNow
+	for i := m.window - 1; i >= 0; i-- {
+		date := ends[i+1].AddDate(0, 0, -1)
+		counts := m.counts[date.Format(time.DateOnly)]
+
+		days[i] = burndownDay{date: date, open: open, completed: counts.Completed, created: counts.Created}
+		open = max(open-counts.Created+counts.Completed, 0)
+	}
+
+	return days
+}
+
+// historyDays computes the task counts of each day in the time window
+// from the snapshots of the repository history.
+func (m burndownModel) historyDays() []burndownDay {
+	ends := m.dayEnds()
+	days := make([]burndownDay, 0, m.window)
+
+	prev := m.states[m.commitAt(ends[0])]
+	for _, end := range ends[1:] {
+		cur := m.states[m.commitAt(end)]
+
+		day := burndownDay{date: end.AddDate(0, 0, -1)}
+		for id, completed := range cur {
+			wasCompleted, existed := prev[id]
+			if !completed {
+				day.open++
+			}
+			if !existed {
+				day.created++
+			}
+			if completed && !wasCompleted {
+				day.completed++
+			}
+		}
+
+		days = append(days, day)
+		prev = cur
+	}
+
+	return days
+}
+
+// View returns the string representation of the burndown view.
+func (m burndownModel) View() string {
+	title := lipgloss.NewStyle().
+		Foreground(colors.BadgeText()).
+		Background(helpers.GetColorCode(m.project.Color)).
+		Padding(0, 1).
+		Render("Burndown · " + m.project.Title)
+	title += fmt.Sprintf("  last %d days · %s", m.window, m.source)
+
+	helpView := m.help.ShortHelpView([]key.Binding{
+		m.keys.window,
+		m.keys.source,
+		m.keys.quit,
+	})
+
+	var body string
+	switch {
+	case m.source == burndownLocal && m.countsErr != nil:
+		body = lipgloss.NewStyle().
+			Foreground(colors.Red()).
+			Render(fmt.Sprintf("Cannot read task counts: %s", m.countsErr))
+
+	case m.source == burndownLocal && !m.countsLoaded:
+		body = "Loading task counts..."
+
+	case m.source == burndownLocal:
+		body = m.chartView(m.days())
+		body += fmt.Sprintf("\n\nCounted on this computer since %s.", m.countedSince.Format("Jan 02 2006"))
+
+	case m.err != nil:
+		body = lipgloss.NewStyle().
+			Foreground(colors.Red()).
+			Render(fmt.Sprintf("Cannot read history: %s\n\n%s", m.err, m.cmdOutput))
+
+	case m.loading:
+		body = "Loading history..."
+
+	default:
+		body = m.chartView(m.days())
+	}
+
+	visible := max(m.height-lipgloss.Height(title)-lipgloss.Height(helpView)-2, 1)
+
+	var b strings.Builder
+
+	b.WriteString(title)
+	b.WriteString("\n\n")
+	b.WriteString(lipgloss.NewStyle().Height(visible).MaxHeight(visible).Render(body))
+	b.WriteString("\n")
+	b.WriteString(helpView)
+
+	return appStyle.Render(b.String())
+}
+
+// chartView renders the open tasks of each day as a bar chart followed
+// by the number of tasks completed and created per day.
+func (m burndownModel) chartView(days []burndownDay) string {
+	const labelWidth = 10
+
+	maxOpen := 1
+	var totalCompleted, totalCreated int
+	completed := make([]int, len(days))
+	created := make([]int, len(days))
+	for i, day := range days {
+		maxOpen = max(maxOpen, day.open)
+		totalCompleted += day.completed
+		totalCreated += day.created
+		completed[i] = day.completed
+		created[i] = day.created
+	}
+
+	// Each day is a column, with a gap between the columns if there is room.
+	colWidth := max(1, min(4, (m.width-labelWidth-2)/len(days)))
+	barWidth := max(1, colWidth-1)
+	chartHeight := max(4, min(15, m.height-14))
+
+	barStyle := lipgloss.NewStyle().Foreground(helpers.GetColorCode(m.project.Color))
+	axisStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+
+	var b strings.Builder
+
+	b.WriteString(lipgloss.NewStyle().Bold(true).Render("Open tasks"))
+	b.WriteString("\n")
+
+	for row := chartHeight - 1; row >= 0; row-- {
+		label := ""
+		switch row {
+		case chartHeight - 1:
+			label = fmt.Sprint(maxOpen)
+		case 0:
+			label = "0"
+		}
+		b.WriteString(axisStyle.Render(fmt.Sprintf("%*s │", labelWidth, label)))
+
+		for _, day := range days {
+			// The height of the bar in eighths of a cell.
+			eighths := day.open * chartHeight * 8 / maxOpen
+			fill := min(max(eighths-row*8, 0), 8)
+			b.WriteString(barStyle.Render(strings.Repeat(string(burndownBlocks[fill]), barWidth)))
+			b.WriteString(strings.Repeat(" ", colWidth-barWidth))
+		}
+		b.WriteString("\n")
+	}
+
+	b.WriteString(axisStyle.Render(strings.Repeat(" ", labelWidth+1) + "└" + strings.Repeat("─", colWidth*len(days))))
+	b.WriteString("\n")
+
+	first := days[0].date.Format("Jan 02")
+	last := days[len(days)-1].date.Format("Jan 02")
+	gap := max(1, colWidth*len(days)-len(first)-len(last))
+	b.WriteString(axisStyle.Render(strings.Repeat(" ", labelWidth+2) + first + strings.Repeat(" ", gap) + last))
+	b.WriteString("\n\n")
+
+	b.WriteString(fmt.Sprintf("%*s │", labelWidth, "completed"))
+	b.WriteString(lipgloss.NewStyle().Foreground(colors.Green()).Render(sparkline(completed, colWidth, barWidth)))
+	b.WriteString("\n")
+	b.WriteString(fmt.Sprintf("%*s │", labelWidth, "created"))
+	b.WriteString(lipgloss.NewStyle().Foreground(colors.Blue()).Render(sparkline(created, colWidth, barWidth)))
+	b.WriteString("\n\n")
+
+	b.WriteString(fmt.Sprintf("%d open now · %d completed and %d created in %d days · %.1f completed per day",
+		days[len(days)-1].open, totalCompleted, totalCreated, len(days),
+		float64(totalCompleted)/float64(len(days))))
+
+	return b.String()
+}
+
+// sparkline renders the values as a single row of blocks scaled to the
+// largest value, each value taking colWidth cells of which barWidth are drawn.
+func sparkline(values []int, colWidth, barWidth int) string {
+	maxValue := 1
+	for _, v := range values {
+		maxValue = max(maxValue, v)
+	}
+
+	var b strings.Builder
+	for _, v := range values {
+		block := burndownBlocks[v*8/maxValue]
+		if v > 0 && block == ' ' {
+			block = burndownBlocks[1]
+		}
+		b.WriteString(strings.Repeat(string(block), barWidth))
+		b.WriteString(strings.Repeat(" ", colWidth-barWidth))
+	}
+
+	return b.String()
+}
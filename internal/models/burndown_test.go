@@ -0,0 +1,62 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/handlebargh/yatto/internal/storage"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBurndownCountedDays(t *testing.T) {
+	now := time.Now()
+	day := func(offset int) string {
+		return time.Date(now.Year(), now.Month(), now.Day()+offset, 0, 0, 0, 0, now.Location()).Format(time.DateOnly)
+	}
+
+	m := burndownModel{
+		window:  7,
+		source:  burndownLocal,
+		openNow: 4,
+		counts: map[string]storage.DayStats{
+			day(0):  {Created: 2, Completed: 1},
+			day(-2): {Completed: 3},
+			day(-8): {Created: 5},
+		},
+	}
+
+	days := m.days()
+	assert.Len(t, days, 7)
+
+	var open, created, completed []int
+	for _, d := range days {
+		open = append(open, d.open)
+		created = append(created, d.created)
+		completed = append(completed, d.completed)
+	}
+
+	assert.Equal(t, day(0), days[6].date.Format(time.DateOnly))
+	assert.Equal(t, []int{6, 6, 6, 6, 3, 3, 4}, open)
+	assert.Equal(t, []int{0, 0, 0, 0, 0, 0, 2}, created)
+	assert.Equal(t, []int{0, 0, 0, 0, 3, 0, 1}, completed)
+}
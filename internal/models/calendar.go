@@ -0,0 +1,524 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package models
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/handlebargh/yatto/internal/helpers"
+)
+
+// calendarKeyMap defines the key bindings used in the calendar field.
+type calendarKeyMap struct {
+	prevDay   key.Binding
+	nextDay   key.Binding
+	prevWeek  key.Binding
+	nextWeek  key.Binding
+	prevMonth key.Binding
+	nextMonth key.Binding
+	today     key.Binding
+	clear     key.Binding
+	editTime  key.Binding
+	doneTime  key.Binding
+	prev      key.Binding
+	next      key.Binding
+	submit    key.Binding
+}
+
+// newCalendarKeyMap initializes and returns a new key map for the calendar field.
+func newCalendarKeyMap() *calendarKeyMap {
+	return &calendarKeyMap{
+		prevDay: key.NewBinding(
+			key.WithKeys("left", "h"),
+			key.WithHelp("←/h", "prev day"),
+		),
+		nextDay: key.NewBinding(
+			key.WithKeys("right", "l"),
+			key.WithHelp("→/l", "next day"),
+		),
+		prevWeek: key.NewBinding(
+			key.WithKeys("up", "k"),
+			key.WithHelp("↑/k", "prev week"),
+		),
+		nextWeek: key.NewBinding(
+			key.WithKeys("down", "j"),
+			key.WithHelp("↓/j", "next week"),
+		),
+		prevMonth: key.NewBinding(
+			key.WithKeys("[", "<"),
+			key.WithHelp("[", "prev month"),
+		),
+		nextMonth: key.NewBinding(
+			key.WithKeys("]", ">"),
+			key.WithHelp("]", "next month"),
+		),
+		today: key.NewBinding(
+			key.WithKeys("."),
+			key.WithHelp(".", "today"),
+		),
+		clear: key.NewBinding(
+			key.WithKeys("x", "backspace", "delete"),
+			key.WithHelp("x", "no due date"),
+		),
+		editTime: key.NewBinding(
+			key.WithKeys("t"),
+			key.WithHelp("t", "set time"),
+		),
+		doneTime: key.NewBinding(
+			key.WithKeys("enter", "tab"),
+			key.WithHelp("enter", "set time"),
+		),
+		prev: key.NewBinding(
+			key.WithKeys("shift+tab"),
+			key.WithHelp("shift+tab", "back"),
+		),
+		next: key.NewBinding(
+			key.WithKeys("enter", "tab"),
+			key.WithHelp("enter", "next"),
+		),
+		submit: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "submit"),
+		),
+	}
+}
+
+// calendarField is a huh form field that lets the user pick a due date
+// from a month view and optionally enter a time of day.
+// The selected value is written to the bound string formatted as
+// time.DateTime, or as an empty string if no date is selected.
+type calendarField struct {
+	key         string
+	title       string
+	value       *string
+	cursor      time.Time
+	hasDate     bool
+	editingTime bool
+	timeInput   textinput.Model
+	validate    func(time.Time) error
+	err         error
+	focused     bool
+	keys        *calendarKeyMap
+	theme       *huh.Theme
+	width       int
+	height      int
+}
+
+// newCalendarField creates a calendar field bound to value.
+// If value holds a date in time.DateTime format, it is preselected.
+// Otherwise, the calendar opens at today without a selected date.
+func newCalendarField(value *string) *calendarField {
+	ti := textinput.New()
+	ti.Placeholder = "HH:MM"
+	ti.CharLimit = 5
+	ti.Width = 6
+	ti.Prompt = "Time: "
+
+	now := time.Now()
+
+	c := &calendarField{
+		value:     value,
+		cursor:    time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local),
+		timeInput: ti,
+		validate:  func(time.Time) error { return nil },
+		keys:      newCalendarKeyMap(),
+	}
+
+	if t, err := time.ParseInLocation(time.DateTime, *value, time.Local); err == nil {
+		c.cursor = t
+		c.hasDate = true
+		if t.Hour() != 0 || t.Minute() != 0 {
+			c.timeInput.SetValue(t.Format("15:04"))
+		}
+	}
+
+	return c
+}
+
+// Key sets the key of the calendar field.
+func (c *calendarField) Key(key string) *calendarField {
+	c.key = key
+	return c
+}
+
+// Title sets the title of the calendar field.
+func (c *calendarField) Title(title string) *calendarField {
+	c.title = title
+	return c
+}
+
+// Validate sets the validation function of the calendar field.
+// It is only called if a date is selected.
+func (c *calendarField) Validate(validate func(time.Time) error) *calendarField {
+	c.validate = validate
+	return c
+}
+
+// selected returns the selected date combined with the entered time of day.
+func (c *calendarField) selected() (time.Time, error) {
+	date := time.Date(c.cursor.Year(), c.cursor.Month(), c.cursor.Day(), 0, 0, 0, 0, time.Local)
+
+	clock := strings.TrimSpace(c.timeInput.Value())
+	if clock == "" {
+		return date, nil
+	}
+
+	t, err := time.Parse("15:04", clock)
+	if err != nil {
+		return date, errors.New("invalid time, use HH:MM")
+	}
+
+	return date.Add(time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute), nil
+}
+
+// updateValue writes the current selection to the bound value.
+func (c *calendarField) updateValue() {
+	if !c.hasDate {
+		*c.value = ""
+		return
+	}
+
+	if t, err := c.selected(); err == nil {
+		*c.value = t.Format(time.DateTime)
+	}
+}
+
+// checkValue validates the current selection.
+func (c *calendarField) checkValue() error {
+	if !c.hasDate {
+		return nil
+	}
+
+	t, err := c.selected()
+	if err != nil {
+		return err
+	}
+
+	return c.validate(t)
+}
+
+// moveCursor moves the cursor by the given number of months and days
+// and marks the cursor date as selected.
+func (c *calendarField) moveCursor(months, days int) {
+	if c.hasDate {
+		c.cursor = c.cursor.AddDate(0, months, days)
+	}
+	c.hasDate = true
+	c.updateValue()
+}
+
+// Init initializes the calendar field.
+func (c *calendarField) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles key presses to move the cursor, edit the time
+// and move between form fields.
+func (c *calendarField) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		var cmd tea.Cmd
+		c.timeInput, cmd = c.timeInput.Update(msg)
+		return c, cmd
+	}
+
+	c.err = nil
+
+	if c.editingTime {
+		if key.Matches(keyMsg, c.keys.doneTime) {
+			if _, err := c.selected(); err != nil {
+				c.err = err
+				return c, nil
+			}
+
+			c.editingTime = false
+			c.timeInput.Blur()
+			c.updateValue()
+			return c, nil
+		}
+
+		var cmd tea.Cmd
+		c.timeInput, cmd = c.timeInput.Update(msg)
+		c.updateValue()
+		return c, cmd
+	}
+
+	switch {
+	case key.Matches(keyMsg, c.keys.prev):
+		return c, huh.PrevField
+
+	case key.Matches(keyMsg, c.keys.next, c.keys.submit):
+		if err := c.checkValue(); err != nil {
+			c.err = err
+			return c, nil
+		}
+		c.updateValue()
+		return c, huh.NextField
+
+	case key.Matches(keyMsg, c.keys.prevDay):
+		c.moveCursor(0, -1)
+	case key.Matches(keyMsg, c.keys.nextDay):
+		c.moveCursor(0, 1)
+	case key.Matches(keyMsg, c.keys.prevWeek):
+		c.moveCursor(0, -7)
+	case key.Matches(keyMsg, c.keys.nextWeek):
+		c.moveCursor(0, 7)
+	case key.Matches(keyMsg, c.keys.prevMonth):
+		c.moveCursor(-1, 0)
+	case key.Matches(keyMsg, c.keys.nextMonth):
+		c.moveCursor(1, 0)
+
+	case key.Matches(keyMsg, c.keys.today):
+		now := time.Now()
+		c.cursor = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.Local)
+		c.hasDate = true
+		c.updateValue()
+
+	case key.Matches(keyMsg, c.keys.clear):
+		c.hasDate = false
+		c.timeInput.SetValue("")
+		c.updateValue()
+
+	case key.Matches(keyMsg, c.keys.editTime):
+		c.hasDate = true
+		c.editingTime = true
+		return c, c.timeInput.Focus()
+	}
+
+	return c, nil
+}
+
+// activeStyles returns the theme styles matching the focus state.
+func (c *calendarField) activeStyles() *huh.FieldStyles {
+	theme := c.theme
+	if theme == nil {
+		theme = huh.ThemeCharm()
+	}
+	if c.focused {
+		return &theme.Focused
+	}
+	return &theme.Blurred
+}
+
+// View renders the month of the cursor with the selected day highlighted,
+// followed by the selected due date and the time input.
+func (c *calendarField) View() string {
+	styles := c.activeStyles()
+
+	var b strings.Builder
+
+	if c.title != "" {
+		b.WriteString(styles.Title.Render(c.title))
+		b.WriteString("\n")
+	}
+
+	b.WriteString(styles.Description.Render(
+		"←→ day  ↑↓ week  [ ] month  . today  t time  x no due date"))
+	b.WriteString("\n\n")
+
+	b.WriteString(lipgloss.PlaceHorizontal(20, lipgloss.Center, c.cursor.Format("January 2006")))
+	b.WriteString("\n")
+	b.WriteString(styles.Description.Render("Mo Tu We Th Fr Sa Su"))
+	b.WriteString("\n")
+
+	now := time.Now()
+	first := time.Date(c.cursor.Year(), c.cursor.Month(), 1, 0, 0, 0, 0, time.Local)
+	daysInMonth := first.AddDate(0, 1, -1).Day()
+
+	// Weeks start on Monday.
+	offset := (int(first.Weekday()) + 6) % 7
+	b.WriteString(strings.Repeat("   ", offset))
+
+	for day := 1; day <= daysInMonth; day++ {
+		cell := fmt.Sprintf("%2d", day)
+
+		switch {
+		case c.hasDate && day == c.cursor.Day():
+			cell = styles.SelectedOption.Reverse(true).Render(cell)
+		case first.Year() == now.Year() && first.Month() == now.Month() && day == now.Day():
+			cell = lipgloss.NewStyle().Underline(true).Render(cell)
+		}
+
+		b.WriteString(cell)
+
+		if (offset+day)%7 == 0 {
+			b.WriteString("\n")
+		} else if day < daysInMonth {
+			b.WriteString(" ")
+		}
+	}
+
+	b.WriteString("\n\n")
+
+	if c.hasDate {
+		t, _ := c.selected()
+		b.WriteString("Due: " + t.Format("Mon, 02 Jan 2006 15:04"))
+	} else {
+		b.WriteString("No due date")
+	}
+
+	if c.editingTime || c.timeInput.Value() != "" {
+		b.WriteString("\n")
+		b.WriteString(c.timeInput.View())
+	}
+
+	if c.err != nil {
+		b.WriteString("\n")
+		b.WriteString(styles.ErrorMessage.Render(c.err.Error()))
+	}
+
+	return styles.Base.
+		Width(c.width).
+		Render(b.String())
+}
+
+// Focus focuses the calendar field.
+func (c *calendarField) Focus() tea.Cmd {
+	c.focused = true
+	return nil
+}
+
+// Blur blurs the calendar field and validates its value.
+func (c *calendarField) Blur() tea.Cmd {
+	c.focused = false
+	c.editingTime = false
+	c.timeInput.Blur()
+	c.err = c.checkValue()
+	c.updateValue()
+	return nil
+}
+
+// Error returns the validation error of the calendar field.
+func (c *calendarField) Error() error { return c.err }
+
+// Run runs the calendar field on its own.
+func (c *calendarField) Run() error {
+	return huh.Run(c)
+}
+
+// RunAccessible asks for the due date as text, accepting the same
+// formats as helpers.ParseDueDate.
+func (c *calendarField) RunAccessible(w io.Writer, r io.Reader) error {
+	scanner := bufio.NewScanner(r)
+
+	for {
+		_, _ = fmt.Fprintf(w, "%s (leave empty for no due date): ", c.title)
+
+		if !scanner.Scan() {
+			return scanner.Err()
+		}
+
+		input := strings.TrimSpace(scanner.Text())
+		if input == "" {
+			*c.value = ""
+			return nil
+		}
+
+		t, err := helpers.ParseDueDate(input)
+		if err == nil {
+			err = c.validate(t)
+		}
+		if err != nil {
+			_, _ = fmt.Fprintln(w, err)
+			continue
+		}
+
+		*c.value = t.Format(time.DateTime)
+		return nil
+	}
+}
+
+// Skip returns whether the calendar field should be skipped.
+func (*calendarField) Skip() bool { return false }
+
+// Zoom returns whether the calendar field should be zoomed.
+func (*calendarField) Zoom() bool { return false }
+
+// KeyBinds returns the help key bindings of the calendar field.
+func (c *calendarField) KeyBinds() []key.Binding {
+	if c.editingTime {
+		return []key.Binding{c.keys.doneTime}
+	}
+
+	return []key.Binding{
+		c.keys.prevDay, c.keys.nextDay, c.keys.prevWeek, c.keys.nextWeek,
+		c.keys.prevMonth, c.keys.nextMonth, c.keys.today, c.keys.editTime,
+		c.keys.clear, c.keys.prev, c.keys.next, c.keys.submit,
+	}
+}
+
+// WithTheme sets the theme of the calendar field.
+func (c *calendarField) WithTheme(theme *huh.Theme) huh.Field {
+	if c.theme == nil {
+		c.theme = theme
+	}
+	return c
+}
+
+// WithAccessible is a no-op, use RunAccessible instead.
+func (c *calendarField) WithAccessible(bool) huh.Field {
+	return c
+}
+
+// WithKeyMap adopts the form's navigation keys for the calendar field.
+func (c *calendarField) WithKeyMap(k *huh.KeyMap) huh.Field {
+	c.keys.prev = k.Input.Prev
+	c.keys.next = k.Input.Next
+	c.keys.submit = k.Input.Submit
+	return c
+}
+
+// WithWidth sets the width of the calendar field.
+func (c *calendarField) WithWidth(width int) huh.Field {
+	c.width = width
+	return c
+}
+
+// WithHeight sets the height of the calendar field.
+func (c *calendarField) WithHeight(height int) huh.Field {
+	c.height = height
+	return c
+}
+
+// WithPosition enables the navigation keys depending on the
+// position of the calendar field within the form.
+func (c *calendarField) WithPosition(p huh.FieldPosition) huh.Field {
+	c.keys.prev.SetEnabled(!p.IsFirst())
+	c.keys.next.SetEnabled(!p.IsLast())
+	c.keys.submit.SetEnabled(p.IsLast())
+	return c
+}
+
+// GetKey returns the key of the calendar field.
+func (c *calendarField) GetKey() string { return c.key }
+
+// GetValue returns the value of the calendar field.
+func (c *calendarField) GetValue() any { return *c.value }
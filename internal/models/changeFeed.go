@@ -0,0 +1,495 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package models
+
+import (
+	"cmp"
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/handlebargh/yatto/internal/colors"
+	"github.com/handlebargh/yatto/internal/helpers"
+	"github.com/handlebargh/yatto/internal/items"
+	"github.com/handlebargh/yatto/internal/storage"
+	"github.com/handlebargh/yatto/internal/vcs"
+	"github.com/spf13/viper"
+)
+
+// maxFeedCommits is the number of commits read after a pull to find out
+// who made the pulled changes.
+const maxFeedCommits = 200
+
+// The kinds of changes listed in the change feed.
+const (
+	changeAdded     = "added"
+	changeCompleted = "completed"
+	changeReopened  = "reopened"
+	changeUpdated   = "updated"
+	changeMoved     = "moved"
+	changeArchived  = "archived"
+	changeDeleted   = "deleted"
+)
+
+// changeKinds lists the kinds of changes in the order they are summed up.
+var changeKinds = []string{
+	changeAdded, changeCompleted, changeReopened, changeUpdated,
+	changeMoved, changeArchived, changeDeleted,
+}
+
+// feedEntry is a change of a task or, if task is nil, of a project
+// made by the pulled commits. The task and project are the ones after
+// the change, or before it if they were removed.
+type feedEntry struct {
+	kind    string
+	project items.Project
+	task    *items.Task
+	author  string
+}
+
+// syncSnapshot holds the projects and task summaries found in storage
+// along with the current commit, taken before a pull to find out what
+// the pull changed.
+type syncSnapshot struct {
+	head     string
+	projects map[string]items.Project
+	tasks    map[string]snapshotTask
+}
+
+// snapshotTask is a task summary along with the ID of its project.
+type snapshotTask struct {
+	projectID string
+	task      items.Task
+}
+
+// takeSyncSnapshot reads the projects and task summaries of the storage
+// directory, see items.IndexedTasks, and the current commit. Unreadable
+// files are left out.
+func takeSyncSnapshot(v *viper.Viper) syncSnapshot {
+	s := syncSnapshot{
+		projects: make(map[string]items.Project),
+		tasks:    make(map[string]snapshotTask),
+	}
+
+	if msg, ok := vcs.LogCmd(v, 1)().(vcs.LogDoneMsg); ok && len(msg.Entries) > 0 {
+		s.head = msg.Entries[0].Hash
+	}
+
+	projects, _ := helpers.ReadProjectsFromFS(v)
+	for _, p := range projects {
+		s.projects[p.ID] = p
+	}
+
+	indexed, _ := items.IndexedTasks(v)
+	for projectID, tasks := range indexed {
+		for _, t := range tasks {
+			s.tasks[t.ID] = snapshotTask{projectID: projectID, task: t}
+		}
+	}
+
+	return s
+}
+
+// pulledAuthors returns the authors of the commits made since the given
+// commit, keyed by the slash separated paths of the files they changed.
+// The most recent author of a file is kept.
+func pulledAuthors(v *viper.Viper, since string) map[string]string {
+	authors := make(map[string]string)
+
+	msg, ok := vcs.LogCmd(v, maxFeedCommits)().(vcs.LogDoneMsg)
+	if !ok {
+		return authors
+	}
+
+	for _, entry := range msg.Entries {
+		if entry.Hash == since {
+			break
+		}
+		for _, file := range entry.Files {
+			if _, ok := authors[file]; !ok {
+				authors[file] = entry.Author
+			}
+		}
+	}
+
+	return authors
+}
+
+// diffSnapshots returns the changes of projects and tasks from before to
+// after, attributed to the authors of the changed files. Projects come
+// first, then tasks, each ordered by title.
+func diffSnapshots(before, after syncSnapshot, authors map[string]string) []feedEntry {
+	var projects, tasks []feedEntry
+
+	for id, p := range after.projects {
+		if _, ok := before.projects[id]; !ok {
+			projects = append(projects, feedEntry{
+				kind: changeAdded, project: p, author: authors[path.Join(id, "project.json")],
+			})
+		}
+	}
+	for id, p := range before.projects {
+		if _, ok := after.projects[id]; !ok {
+			projects = append(projects, feedEntry{
+				kind: changeDeleted, project: p, author: authors[path.Join(id, "project.json")],
+			})
+		}
+	}
+
+	for id, a := range after.tasks {
+		file := path.Join(a.projectID, id+".json")
+		entry := feedEntry{project: after.projects[a.projectID], task: &a.task, author: authors[file]}
+
+		b, ok := before.tasks[id]
+		switch {
+		case !ok:
+			entry.kind = changeAdded
+		case b.projectID != a.projectID:
+			entry.kind = changeMoved
+		case !b.task.Completed && a.task.Completed:
+			entry.kind = changeCompleted
+		case b.task.Completed && !a.task.Completed:
+			entry.kind = changeReopened
+		case !equalTime(b.task.UpdatedAt, a.task.UpdatedAt):
+			entry.kind = changeUpdated
+		default:
+			continue
+		}
+
+		tasks = append(tasks, entry)
+	}
+
+	for id, b := range before.tasks {
+		if _, ok := after.tasks[id]; ok {
+			continue
+		}
+
+		entry := feedEntry{kind: changeDeleted, project: before.projects[b.projectID], task: &b.task}
+		entry.author = authors[path.Join(b.projectID, id+".json")]
+		if author, ok := authors[path.Join(b.projectID, "archive", id+".json")]; ok {
+			entry.kind = changeArchived
+			entry.author = author
+		}
+		// Tasks of deleted projects are covered by the project.
+		if _, ok := after.projects[b.projectID]; !ok {
+			continue
+		}
+
+		tasks = append(tasks, entry)
+	}
+
+	byTitle := func(x, y feedEntry) int {
+		return strings.Compare(strings.ToLower(x.title()), strings.ToLower(y.title()))
+	}
+	slices.SortFunc(projects, byTitle)
+	slices.SortFunc(tasks, byTitle)
+
+	return append(projects, tasks...)
+}
+
+// equalTime reports whether both times are nil or equal.
+func equalTime(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return a.Equal(*b)
+}
+
+// title returns the title of the changed task or project.
+func (e feedEntry) title() string {
+	if e.task != nil {
+		return e.task.Title
+	}
+
+	return e.project.Title
+}
+
+// authorName returns the name of the author without the email address.
+func (e feedEntry) authorName() string {
+	name, _, _ := strings.Cut(e.author, " <")
+	return strings.TrimSpace(name)
+}
+
+// summarizeFeed sums up the changes by kind, e.g. "3 tasks added,
+// 2 tasks completed by Alice, 1 project deleted". The author is named
+// if all changes of a kind were made by the same one.
+func summarizeFeed(entries []feedEntry) string {
+	var parts []string
+
+	for _, isProject := range []bool{true, false} {
+		for _, kind := range changeKinds {
+			var n int
+			authors := make(map[string]bool)
+			for _, e := range entries {
+				if e.kind == kind && (e.task == nil) == isProject {
+					n++
+					authors[e.authorName()] = true
+				}
+			}
+			if n == 0 {
+				continue
+			}
+
+			noun := "task"
+			if isProject {
+				noun = "project"
+			}
+			if n > 1 {
+				noun += "s"
+			}
+
+			part := fmt.Sprintf("%d %s %s", n, noun, kind)
+			if len(authors) == 1 {
+				for author := range authors {
+					if author != "" {
+						part += " by " + author
+					}
+				}
+			}
+			parts = append(parts, part)
+		}
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// changeFeedKeyMap defines the key bindings of the change feed.
+type changeFeedKeyMap struct {
+	quit     key.Binding
+	up       key.Binding
+	down     key.Binding
+	openTask key.Binding
+}
+
+// newChangeFeedKeyMap initializes and returns a new key map for the change feed.
+func newChangeFeedKeyMap() *changeFeedKeyMap {
+	return &changeFeedKeyMap{
+		quit: key.NewBinding(
+			key.WithKeys("q", "esc"),
+			key.WithHelp("q/esc", "go back"),
+		),
+		up: key.NewBinding(
+			key.WithKeys("up", "k"),
+			key.WithHelp("↑/k", "up"),
+		),
+		down: key.NewBinding(
+			key.WithKeys("down", "j"),
+			key.WithHelp("↓/j", "down"),
+		),
+		openTask: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "show task"),
+		),
+	}
+}
+
+// changeFeedModel represents the Bubble Tea model listing the changes
+// made by a pull. Leaving it returns to the view it was opened from,
+// which then picks up the pulled changes.
+type changeFeedModel struct {
+	projectModel  *ProjectListModel
+	back          tea.Model
+	changed       tea.Cmd
+	keys          *changeFeedKeyMap
+	help          help.Model
+	entries       []feedEntry
+	cursor        int
+	width, height int
+}
+
+// newChangeFeedModel creates a new changeFeedModel for the given changes.
+// changed is run when returning to back, e.g. to reload its lists.
+func newChangeFeedModel(projectModel *ProjectListModel, back tea.Model, changed tea.Cmd, entries []feedEntry) changeFeedModel {
+	return changeFeedModel{
+		projectModel: projectModel,
+		back:         back,
+		changed:      changed,
+		keys:         newChangeFeedKeyMap(),
+		help:         help.New(),
+		entries:      entries,
+	}
+}
+
+// Init initializes the changeFeedModel and returns an initial command.
+func (m changeFeedModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles incoming messages and updates the changeFeedModel accordingly.
+// Storage changes are left to the view returned to.
+func (m changeFeedModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case tea.KeyMsg:
+		if msg.Type == tea.KeyCtrlC {
+			return m, tea.Quit
+		}
+
+		switch {
+		case key.Matches(msg, m.keys.quit):
+			return m.back, tea.Batch(m.changed, tea.WindowSize())
+
+		case key.Matches(msg, m.keys.up):
+			if m.cursor > 0 {
+				m.cursor--
+			}
+
+		case key.Matches(msg, m.keys.down):
+			if m.cursor < len(m.entries)-1 {
+				m.cursor++
+			}
+
+		case key.Matches(msg, m.keys.openTask):
+			entry := m.entries[m.cursor]
+			if entry.task == nil || entry.kind == changeArchived || entry.kind == changeDeleted {
+				return m, nil
+			}
+
+			// The task list is read from storage, but the project list
+			// has to pick up the pulled projects first.
+			reload := m.projectModel.reloadProjects()
+			if pagerModel, cmd, ok := openTaskPager(m.projectModel, entry.project, *entry.task, m.width, m.height); ok {
+				return pagerModel, tea.Batch(reload, cmd)
+			}
+
+			return m, reload
+		}
+	}
+
+	return m, nil
+}
+
+// View renders the changes, one per line, below their summary.
+func (m changeFeedModel) View() string {
+	h, v := appStyle.GetFrameSize()
+	width, height := m.width-h, m.height-v
+
+	title := lipgloss.NewStyle().
+		Foreground(colors.BadgeText()).
+		Background(colors.Blue()).
+		Padding(0, 1).
+		Render("Pulled changes")
+
+	helpView := m.help.ShortHelpView([]key.Binding{
+		m.keys.up,
+		m.keys.down,
+		m.keys.openTask,
+		m.keys.quit,
+	})
+
+	var lines []string
+	for i, entry := range m.entries {
+		lines = append(lines, m.entryView(entry, i == m.cursor, width))
+	}
+
+	// Scroll so that the cursor stays visible.
+	visible := max(height-lipgloss.Height(title)-lipgloss.Height(helpView)-4, 1)
+	offset := max(0, m.cursor-visible+1)
+	end := min(len(lines), offset+visible)
+
+	var b strings.Builder
+
+	b.WriteString(title)
+	b.WriteString("\n\n")
+	b.WriteString(lipgloss.NewStyle().
+		Foreground(colors.Blue()).
+		Width(width).
+		Render(summarizeFeed(m.entries)))
+	b.WriteString("\n\n")
+	b.WriteString(lipgloss.NewStyle().
+		Height(visible).
+		Render(strings.Join(lines[offset:end], "\n")))
+	b.WriteString("\n")
+	b.WriteString(helpView)
+
+	return appStyle.Render(b.String())
+}
+
+// entryView renders a single change with its kind, project and author.
+func (m changeFeedModel) entryView(entry feedEntry, selected bool, width int) string {
+	projectColor := helpers.GetColorCode(entry.project.Color)
+
+	titleStyle := lipgloss.NewStyle().
+		Width(max(width-56, 20)).
+		PaddingLeft(1)
+
+	if selected {
+		titleStyle = titleStyle.
+			Border(lipgloss.NormalBorder(), false, false, false, true).
+			BorderForeground(projectColor).
+			Bold(true)
+	} else {
+		titleStyle = titleStyle.MarginLeft(1)
+	}
+
+	title := entry.title()
+	if entry.task == nil {
+		title = "Project " + title
+	} else {
+		title = entry.task.CropTaskTitle(taskEntryLength)
+	}
+
+	kindStyle := lipgloss.NewStyle().Width(11)
+	switch entry.kind {
+	case changeAdded, changeCompleted:
+		kindStyle = kindStyle.Foreground(colors.Green())
+	case changeArchived, changeDeleted:
+		kindStyle = kindStyle.Foreground(colors.Red())
+	default:
+		kindStyle = kindStyle.Foreground(colors.Orange())
+	}
+
+	return lipgloss.JoinHorizontal(lipgloss.Top,
+		titleStyle.Render(title),
+		kindStyle.Render(entry.kind),
+		lipgloss.NewStyle().
+			Width(24).
+			Foreground(projectColor).
+			Render(entry.project.Title),
+		lipgloss.NewStyle().
+			Foreground(colors.Indigo()).
+			Render(cmp.Or(entry.authorName(), "unknown")),
+	)
+}
+
+// pulledChanges returns a command that sends a storage.StorageChangedMsg
+// for all projects, so that the open lists pick up the pulled changes.
+func (m *ProjectListModel) pulledChanges() tea.Cmd {
+	var ids []string
+	for _, p := range m.allProjects() {
+		ids = append(ids, p.ID)
+	}
+
+	return func() tea.Msg {
+		return storage.StorageChangedMsg{Projects: ids}
+	}
+}
@@ -0,0 +1,98 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/handlebargh/yatto/internal/items"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffSnapshots(t *testing.T) {
+	earlier := time.Date(2026, time.March, 1, 9, 0, 0, 0, time.UTC)
+	later := earlier.Add(time.Hour)
+
+	work := items.Project{ID: "work", Title: "Work"}
+	home := items.Project{ID: "home", Title: "Home"}
+	old := items.Project{ID: "old", Title: "Old"}
+
+	before := syncSnapshot{
+		projects: map[string]items.Project{"work": work, "old": old},
+		tasks: map[string]snapshotTask{
+			"report":  {"work", items.Task{ID: "report", Title: "Report", UpdatedAt: &earlier}},
+			"slides":  {"work", items.Task{ID: "slides", Title: "Slides", UpdatedAt: &earlier}},
+			"notes":   {"work", items.Task{ID: "notes", Title: "Notes", UpdatedAt: &earlier}},
+			"invoice": {"work", items.Task{ID: "invoice", Title: "Invoice"}},
+			"review":  {"work", items.Task{ID: "review", Title: "Review"}},
+			"legacy":  {"old", items.Task{ID: "legacy", Title: "Legacy"}},
+		},
+	}
+	after := syncSnapshot{
+		projects: map[string]items.Project{"work": work, "home": home},
+		tasks: map[string]snapshotTask{
+			"report": {"work", items.Task{ID: "report", Title: "Report", Completed: true, UpdatedAt: &later}},
+			"slides": {"work", items.Task{ID: "slides", Title: "Slides", UpdatedAt: &later}},
+			"notes":  {"work", items.Task{ID: "notes", Title: "Notes", UpdatedAt: &earlier}},
+			"milk":   {"home", items.Task{ID: "milk", Title: "Milk"}},
+		},
+	}
+	authors := map[string]string{
+		"home/project.json":         "Bob <bob@example.com>",
+		"old/project.json":          "Bob <bob@example.com>",
+		"home/milk.json":            "Bob <bob@example.com>",
+		"work/report.json":          "Alice <alice@example.com>",
+		"work/slides.json":          "Alice <alice@example.com>",
+		"work/archive/invoice.json": "Alice <alice@example.com>",
+		"work/review.json":          "Bob <bob@example.com>",
+	}
+
+	var got []string
+	for _, e := range diffSnapshots(before, after, authors) {
+		got = append(got, e.kind+" "+e.title()+" "+e.authorName())
+	}
+
+	assert.Equal(t, []string{
+		"added Home Bob",
+		"deleted Old Bob",
+		"archived Invoice Alice",
+		"added Milk Bob",
+		"completed Report Alice",
+		"deleted Review Bob",
+		"updated Slides Alice",
+	}, got)
+}
+
+func TestSummarizeFeed(t *testing.T) {
+	task := &items.Task{}
+	entries := []feedEntry{
+		{kind: changeDeleted, author: "Bob <bob@example.com>"},
+		{kind: changeAdded, task: task, author: "Alice <alice@example.com>"},
+		{kind: changeAdded, task: task, author: "Bob <bob@example.com>"},
+		{kind: changeAdded, task: task, author: "Bob <bob@example.com>"},
+		{kind: changeCompleted, task: task, author: "Alice <alice@example.com>"},
+		{kind: changeCompleted, task: task, author: "Alice <alice@example.com>"},
+	}
+
+	assert.Equal(t, "1 project deleted by Bob, 3 tasks added, 2 tasks completed by Alice", summarizeFeed(entries))
+	assert.Empty(t, summarizeFeed(nil))
+}
@@ -0,0 +1,153 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/handlebargh/yatto/internal/colors"
+	"github.com/handlebargh/yatto/internal/items"
+	"github.com/handlebargh/yatto/internal/vcs"
+)
+
+// commentFormModel defines the Bubble Tea model for the form used
+// to add a comment to the task shown in the task pager.
+type commentFormModel struct {
+	form          *huh.Form
+	task          *items.Task
+	pager         taskPagerModel
+	body          *string
+	width, height int
+	lg            *lipgloss.Renderer
+	styles        *Styles
+}
+
+// newCommentFormModel returns a commentFormModel for the given task.
+// The pager is shown again when the form is left.
+func newCommentFormModel(task *items.Task, pager taskPagerModel) commentFormModel {
+	body := ""
+
+	m := commentFormModel{
+		task:  task,
+		pager: pager,
+		body:  &body,
+		lg:    lipgloss.DefaultRenderer(),
+	}
+	m.styles = NewStyles(m.lg)
+
+	m.form = huh.NewForm(
+		huh.NewGroup(
+			huh.NewText().
+				Title("Comment:").
+				Description("Markdown is supported.").
+				Value(m.body).
+				Validate(func(str string) error {
+					if strings.TrimSpace(str) == "" {
+						return errors.New("comment must not be empty")
+					}
+					return nil
+				}),
+		)).
+		WithWidth(80).
+		WithShowHelp(false).
+		WithShowErrors(false).
+		WithTheme(colors.FormTheme())
+
+	return m
+}
+
+// Init initializes the form model and returns the initial command to run.
+func (m commentFormModel) Init() tea.Cmd {
+	return m.form.Init()
+}
+
+// Update processes incoming messages and updates the model state accordingly.
+func (m commentFormModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c":
+			return m, tea.Quit
+		case "esc":
+			return m.pager, nil
+		}
+
+	case tea.WindowSizeMsg:
+		h, v := appStyle.GetFrameSize()
+		m.width = msg.Width - h
+		m.height = msg.Height - v
+	}
+
+	var cmds []tea.Cmd
+
+	form, cmd := m.form.Update(msg)
+	if f, ok := form.(*huh.Form); ok {
+		m.form = f
+		cmds = append(cmds, cmd)
+	}
+
+	if m.form.State == huh.StateCompleted {
+		listModel := m.pager.listModel
+		config := listModel.projectModel.config
+		project := *listModel.project
+		body := strings.TrimSpace(*m.body)
+
+		author := listModel.projectModel.currentUser()
+		m.task.Comments = append(m.task.Comments, items.Comment{
+			Author: author,
+			Time:   time.Now(),
+			Body:   body,
+		})
+
+		cmds = append(cmds, tea.Sequence(
+			m.task.WriteTaskJSON(config, project, "comment"),
+			vcs.CommitCmd(config, fmt.Sprintf("comment: %s\n\n%s", m.task.Title, body), m.task.Path(project)),
+		))
+
+		// Show the task again, including the new comment.
+		pagerModel := newTaskPagerModel(m.task.TaskToMarkdown(), listModel)
+		return pagerModel, tea.Batch(append(cmds, tea.WindowSize())...)
+	}
+
+	return m, tea.Batch(cmds...)
+}
+
+// View renders the comment form UI.
+func (m commentFormModel) View() string {
+	v := strings.TrimSuffix(m.form.View(), "\n\n")
+	form := m.lg.NewStyle().Margin(1, 0).Render(v)
+
+	header := formBoundaryView(m.styles, m.width, "Comment on "+m.task.Title, colors.Indigo())
+	if errs := m.form.Errors(); len(errs) > 0 {
+		header = formBoundaryView(m.styles, m.width, errs[0].Error(), colors.Red())
+	}
+
+	footer := formBoundaryView(m.styles, m.width,
+		m.form.Help().ShortHelpView(m.form.KeyBinds())+" • esc cancel", colors.Indigo())
+
+	return m.styles.Base.Render(header + "\n" + form + "\n\n" + footer)
+}
@@ -0,0 +1,370 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package models
+
+import (
+	"cmp"
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/handlebargh/yatto/internal/colors"
+	"github.com/handlebargh/yatto/internal/vcs"
+	"github.com/spf13/viper"
+)
+
+// conflictChoice is the version of a conflicting file to keep.
+type conflictChoice int
+
+const (
+	conflictUnresolved conflictChoice = iota
+	conflictKeepLocal
+	conflictKeepRemote
+)
+
+// conflictEntry is a conflicting file and the version chosen for it.
+type conflictEntry struct {
+	vcs.Conflict
+	choice conflictChoice
+}
+
+// conflictsLoadedMsg carries the conflicts of a stopped pull.
+type conflictsLoadedMsg struct {
+	conflicts []vcs.Conflict
+	err       error
+}
+
+// conflictResolveErrorMsg reports that a conflict could not be resolved.
+type conflictResolveErrorMsg struct {
+	err error
+}
+
+// loadConflictsCmd reads the conflicts of a stopped pull.
+func loadConflictsCmd(v *viper.Viper) tea.Cmd {
+	return func() tea.Msg {
+		conflicts, err := vcs.Conflicts(v)
+		return conflictsLoadedMsg{conflicts, err}
+	}
+}
+
+// resolveConflictsCmd keeps the chosen version of each file, then
+// finishes the pull and pushes like vcs.ContinueConflictCmd.
+func resolveConflictsCmd(v *viper.Viper, entries []conflictEntry) tea.Cmd {
+	return func() tea.Msg {
+		for _, entry := range entries {
+			if err := vcs.ResolveConflict(v, entry.Path, entry.choice == conflictKeepLocal); err != nil {
+				return conflictResolveErrorMsg{err}
+			}
+		}
+
+		return vcs.ContinueConflictCmd(v)()
+	}
+}
+
+// conflictResolverKeyMap defines the key bindings of the conflict resolver.
+type conflictResolverKeyMap struct {
+	quit       key.Binding
+	up         key.Binding
+	down       key.Binding
+	keepLocal  key.Binding
+	keepRemote key.Binding
+	resolve    key.Binding
+	abort      key.Binding
+}
+
+// newConflictResolverKeyMap initializes and returns a new key map for the conflict resolver.
+func newConflictResolverKeyMap() *conflictResolverKeyMap {
+	return &conflictResolverKeyMap{
+		quit: key.NewBinding(
+			key.WithKeys("q", "esc"),
+			key.WithHelp("q/esc", "go back"),
+		),
+		up: key.NewBinding(
+			key.WithKeys("up", "k"),
+			key.WithHelp("↑/k", "up"),
+		),
+		down: key.NewBinding(
+			key.WithKeys("down", "j"),
+			key.WithHelp("↓/j", "down"),
+		),
+		keepLocal: key.NewBinding(
+			key.WithKeys("l"),
+			key.WithHelp("l", "keep local"),
+		),
+		keepRemote: key.NewBinding(
+			key.WithKeys("r"),
+			key.WithHelp("r", "keep remote"),
+		),
+		resolve: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "resolve and push"),
+		),
+		abort: key.NewBinding(
+			key.WithKeys("A"),
+			key.WithHelp("A", "abort pull"),
+		),
+	}
+}
+
+// conflictResolverModel represents the Bubble Tea model listing the
+// conflicting files of a stopped pull. For each file, the local or the
+// remote version is kept. Finishing or aborting the pull returns to the
+// view it was opened from, which receives the result.
+type conflictResolverModel struct {
+	config        *viper.Viper
+	back          tea.Model
+	keys          *conflictResolverKeyMap
+	help          help.Model
+	entries       []conflictEntry
+	cursor        int
+	busy          bool
+	notice        string
+	err           error
+	width, height int
+}
+
+// newConflictResolverModel creates a new conflictResolverModel
+// returning to back.
+func newConflictResolverModel(v *viper.Viper, back tea.Model) conflictResolverModel {
+	return conflictResolverModel{
+		config: v,
+		back:   back,
+		keys:   newConflictResolverKeyMap(),
+		help:   help.New(),
+		busy:   true,
+	}
+}
+
+// Init initializes the conflictResolverModel and loads the conflicts.
+func (m conflictResolverModel) Init() tea.Cmd {
+	return loadConflictsCmd(m.config)
+}
+
+// Update handles incoming messages and updates the conflictResolverModel accordingly.
+func (m conflictResolverModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case conflictsLoadedMsg:
+		m.busy = false
+		m.err = msg.err
+		m.cursor = 0
+		m.entries = nil
+		for _, conflict := range msg.conflicts {
+			m.entries = append(m.entries, conflictEntry{Conflict: conflict})
+		}
+		return m, nil
+
+	case conflictResolveErrorMsg:
+		m.busy = false
+		m.err = msg.err
+		return m, nil
+
+	case vcs.PullErrorMsg:
+		// The next local commit conflicts as well.
+		if vcs.ConflictInProgress(m.config) {
+			m.notice = "The next local change conflicts as well."
+			return m, loadConflictsCmd(m.config)
+		}
+		return m.back.Update(msg)
+
+	case vcs.PushDoneMsg, vcs.PushErrorMsg, vcs.ConflictAbortedMsg:
+		return m.back.Update(msg)
+
+	case tea.KeyMsg:
+		if msg.Type == tea.KeyCtrlC {
+			return m, tea.Quit
+		}
+
+		if m.busy {
+			return m, nil
+		}
+
+		switch {
+		case key.Matches(msg, m.keys.quit):
+			return m.back, tea.WindowSize()
+
+		case key.Matches(msg, m.keys.up):
+			if m.cursor > 0 {
+				m.cursor--
+			}
+
+		case key.Matches(msg, m.keys.down):
+			if m.cursor < len(m.entries)-1 {
+				m.cursor++
+			}
+
+		case key.Matches(msg, m.keys.keepLocal):
+			m.choose(conflictKeepLocal)
+
+		case key.Matches(msg, m.keys.keepRemote):
+			m.choose(conflictKeepRemote)
+
+		case key.Matches(msg, m.keys.resolve):
+			if m.unresolved() > 0 {
+				return m, nil
+			}
+
+			m.busy = true
+			m.notice = ""
+			m.err = nil
+			return m, resolveConflictsCmd(m.config, m.entries)
+
+		case key.Matches(msg, m.keys.abort):
+			m.busy = true
+			m.notice = ""
+			m.err = nil
+			return m, vcs.AbortConflictCmd(m.config)
+		}
+	}
+
+	return m, nil
+}
+
+// choose sets the version to keep of the selected file and moves
+// on to the next file.
+func (m *conflictResolverModel) choose(choice conflictChoice) {
+	if len(m.entries) == 0 {
+		return
+	}
+
+	m.entries[m.cursor].choice = choice
+	if m.cursor < len(m.entries)-1 {
+		m.cursor++
+	}
+}
+
+// unresolved returns the number of files without a chosen version.
+func (m conflictResolverModel) unresolved() int {
+	n := 0
+	for _, entry := range m.entries {
+		if entry.choice == conflictUnresolved {
+			n++
+		}
+	}
+
+	return n
+}
+
+// View renders the conflicting files, one per line, below a summary.
+func (m conflictResolverModel) View() string {
+	h, v := appStyle.GetFrameSize()
+	width, height := m.width-h, m.height-v
+
+	title := lipgloss.NewStyle().
+		Foreground(colors.BadgeText()).
+		Background(colors.Red()).
+		Padding(0, 1).
+		Render("Resolve conflicts")
+
+	helpView := m.help.ShortHelpView([]key.Binding{
+		m.keys.up,
+		m.keys.down,
+		m.keys.keepLocal,
+		m.keys.keepRemote,
+		m.keys.resolve,
+		m.keys.abort,
+		m.keys.quit,
+	})
+
+	var summary string
+	switch {
+	case m.busy:
+		summary = "Working…"
+	case m.err != nil:
+		summary = lipgloss.NewStyle().Foreground(colors.Red()).Render(m.err.Error())
+	case len(m.entries) == 0:
+		summary = "No conflicting files left. Press enter to finish the pull."
+	case m.unresolved() > 0:
+		summary = fmt.Sprintf("%d of %d file(s) changed both locally and on the remote. "+
+			"Choose the version to keep for each of them.", m.unresolved(), len(m.entries))
+	default:
+		summary = "All conflicts resolved. Press enter to finish the pull and push."
+	}
+	if m.notice != "" {
+		summary = m.notice + " " + summary
+	}
+
+	var lines []string
+	for i, entry := range m.entries {
+		lines = append(lines, m.entryView(entry, i == m.cursor, width))
+	}
+
+	// Scroll so that the cursor stays visible.
+	visible := max(height-lipgloss.Height(title)-lipgloss.Height(helpView)-6, 1)
+	offset := max(0, m.cursor-visible+1)
+	end := min(len(lines), offset+visible)
+
+	var b strings.Builder
+
+	b.WriteString(title)
+	b.WriteString("\n\n")
+	b.WriteString(lipgloss.NewStyle().
+		Width(width).
+		Render(summary))
+	b.WriteString("\n\n")
+	b.WriteString(lipgloss.NewStyle().
+		Height(visible).
+		Render(strings.Join(lines[offset:end], "\n")))
+	b.WriteString("\n")
+	b.WriteString(helpView)
+
+	return appStyle.Render(b.String())
+}
+
+// entryView renders a single conflicting file with the chosen version.
+func (m conflictResolverModel) entryView(entry conflictEntry, selected bool, width int) string {
+	titleStyle := lipgloss.NewStyle().
+		Width(max(width-16, 20)).
+		PaddingLeft(1)
+
+	if selected {
+		titleStyle = titleStyle.
+			Border(lipgloss.NormalBorder(), false, false, false, true).
+			BorderForeground(colors.Red()).
+			Bold(true)
+	} else {
+		titleStyle = titleStyle.MarginLeft(1)
+	}
+
+	title := cmp.Or(entry.Title, entry.Path)
+
+	var choice string
+	switch entry.choice {
+	case conflictKeepLocal:
+		choice = lipgloss.NewStyle().Foreground(colors.Green()).Render("keep local")
+	case conflictKeepRemote:
+		choice = lipgloss.NewStyle().Foreground(colors.Blue()).Render("keep remote")
+	default:
+		choice = lipgloss.NewStyle().Foreground(colors.Orange()).Render("unresolved")
+	}
+
+	return lipgloss.JoinHorizontal(lipgloss.Top,
+		titleStyle.Render(title),
+		lipgloss.NewStyle().Width(14).Render(choice),
+	)
+}
@@ -0,0 +1,428 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package models
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/handlebargh/yatto/internal/colors"
+	"github.com/handlebargh/yatto/internal/config"
+	"github.com/handlebargh/yatto/internal/helpers"
+	"github.com/handlebargh/yatto/internal/items"
+	"github.com/handlebargh/yatto/internal/storage"
+	"github.com/spf13/viper"
+)
+
+// dashboardSection groups the tasks shown in the dashboard.
+type dashboardSection int
+
+const (
+	// dashboardOverdue holds open tasks whose due date has passed.
+	dashboardOverdue dashboardSection = iota
+
+	// dashboardToday holds open tasks due later today.
+	dashboardToday
+
+	// dashboardInProgress holds the other tasks in progress.
+	dashboardInProgress
+)
+
+// String returns the display name of the dashboard section.
+func (s dashboardSection) String() string {
+	switch s {
+	case dashboardOverdue:
+		return "Overdue"
+	case dashboardToday:
+		return "Due today"
+	default:
+		return "In progress"
+	}
+}
+
+// color returns the color of the section's header and counter.
+func (s dashboardSection) color() lipgloss.AdaptiveColor {
+	switch s {
+	case dashboardOverdue:
+		return colors.VividRed()
+	case dashboardToday:
+		return colors.Orange()
+	default:
+		return colors.Blue()
+	}
+}
+
+// dashboardKeyMap defines the key bindings used in the dashboard.
+type dashboardKeyMap struct {
+	quit        key.Binding
+	up          key.Binding
+	down        key.Binding
+	openProject key.Binding
+}
+
+// newDashboardKeyMap initializes and returns a new key map for dashboard actions.
+func newDashboardKeyMap() *dashboardKeyMap {
+	return &dashboardKeyMap{
+		quit: key.NewBinding(
+			key.WithKeys("q", "esc", "h"),
+			key.WithHelp("q/esc", "show projects"),
+		),
+		up: key.NewBinding(
+			key.WithKeys("up", "k"),
+			key.WithHelp("↑/k", "up"),
+		),
+		down: key.NewBinding(
+			key.WithKeys("down", "j"),
+			key.WithHelp("↓/j", "down"),
+		),
+		openProject: key.NewBinding(
+			key.WithKeys("enter", "l"),
+			key.WithHelp("enter/l", "open in project"),
+		),
+	}
+}
+
+// dashboardEntry is a single task of the dashboard along with its project.
+type dashboardEntry struct {
+	project items.Project
+	task    items.Task
+	section dashboardSection
+}
+
+// dashboardModel represents the Bubble Tea model of the "Today" dashboard.
+// It summarizes the overdue tasks, the tasks due today and the tasks in
+// progress of all projects and lists them to jump into their projects.
+//
+// Shown on startup, it stands in for the project list, so all messages
+// it does not handle itself are passed on to the project list.
+type dashboardModel struct {
+	projectModel  *ProjectListModel
+	keys          *dashboardKeyMap
+	help          help.Model
+	entries       []dashboardEntry
+	warning       string
+	cursor        int
+	startup       bool
+	width, height int
+}
+
+// NewStartupModel returns the model shown when the TUI starts, either
+// the project list or the dashboard, depending on startup.view.
+// Its view honors the plain output mode, see plainModel, and a crash
+// saves the session, see recoveryModel.
+func NewStartupModel(v *viper.Viper) tea.Model {
+	projectModel := InitialProjectListModel(v)
+	if config.Get(v).StartupView != "dashboard" {
+		return plainModel{newRecoveryModel(v, projectModel)}
+	}
+
+	m := newDashboardModel(&projectModel, 0, 0)
+	m.startup = true
+
+	return plainModel{newRecoveryModel(v, m)}
+}
+
+// newDashboardModel creates a new dashboardModel from the tasks of all
+// projects found in storage.
+func newDashboardModel(projectModel *ProjectListModel, width, height int) dashboardModel {
+	h, v := appStyle.GetFrameSize()
+
+	m := dashboardModel{
+		projectModel: projectModel,
+		keys:         newDashboardKeyMap(),
+		help:         help.New(),
+		width:        width - h,
+		height:       height - v,
+	}
+	m.load()
+
+	return m
+}
+
+// load reads the tasks of the dashboard from storage, leaving out
+// completed and deferred tasks.
+func (m *dashboardModel) load() {
+	now := time.Now()
+
+	var entries []dashboardEntry
+	err := readAllTasks(m.projectModel.config, func(project items.Project, task items.Task) {
+		if task.Completed || task.Deferred(now) {
+			return
+		}
+
+		var section dashboardSection
+		switch {
+		case items.AgendaBucketOf(&task, now) == items.AgendaOverdue:
+			section = dashboardOverdue
+		case items.AgendaBucketOf(&task, now) == items.AgendaToday:
+			section = dashboardToday
+		case task.InProgress:
+			section = dashboardInProgress
+		default:
+			return
+		}
+
+		entries = append(entries, dashboardEntry{project: project, task: task, section: section})
+	})
+
+	slices.SortStableFunc(entries, func(x, y dashboardEntry) int {
+		if c := cmp.Compare(x.section, y.section); c != 0 {
+			return c
+		}
+
+		if x.task.DueDate != nil && y.task.DueDate != nil {
+			if c := x.task.DueDate.Compare(*y.task.DueDate); c != 0 {
+				return c
+			}
+		}
+
+		return cmp.Compare(y.task.PriorityValue(), x.task.PriorityValue())
+	})
+
+	m.entries = entries
+	m.warning = storageWarning(err)
+	m.cursor = min(m.cursor, max(len(entries)-1, 0))
+}
+
+// Init initializes the dashboard. On startup, the project list
+// is initialized as well.
+func (m dashboardModel) Init() tea.Cmd {
+	if m.startup {
+		return m.projectModel.Init()
+	}
+
+	return nil
+}
+
+// Update handles incoming messages and updates the dashboardModel accordingly.
+func (m dashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		h, v := appStyle.GetFrameSize()
+		m.width = msg.Width - h
+		m.height = msg.Height - v
+
+		return m, m.forward(msg)
+
+	case tea.KeyMsg:
+		if msg.Type == tea.KeyCtrlC {
+			return m, tea.Quit
+		}
+
+		switch {
+		case key.Matches(msg, m.keys.quit):
+			return m.projectModel, func() tea.Msg { return returnedToProjectListMsg{} }
+
+		case key.Matches(msg, m.keys.up):
+			if m.cursor > 0 {
+				m.cursor--
+			}
+
+		case key.Matches(msg, m.keys.down):
+			if m.cursor < len(m.entries)-1 {
+				m.cursor++
+			}
+
+		case key.Matches(msg, m.keys.openProject):
+			return m.openProject()
+		}
+
+		return m, nil
+
+	case tea.MouseMsg:
+		return m, nil
+
+	case storage.StorageChangedMsg, syncDoneMsg:
+		cmd := m.forward(msg)
+		m.load()
+		return m, cmd
+	}
+
+	return m, m.forward(msg)
+}
+
+// forward passes the message on to the project list, which keeps
+// running in the background, e.g. to sync or to reload the config.
+func (m dashboardModel) forward(msg tea.Msg) tea.Cmd {
+	updated, cmd := m.projectModel.Update(msg)
+	if projectModel, ok := updated.(ProjectListModel); ok {
+		*m.projectModel = projectModel
+	}
+
+	return cmd
+}
+
+// openProject switches to the task list of the project owning the
+// selected task and selects the task there.
+func (m dashboardModel) openProject() (tea.Model, tea.Cmd) {
+	if len(m.entries) == 0 {
+		return m, nil
+	}
+
+	entry := m.entries[m.cursor]
+
+	idx := entry.project.FindListIndexByID(m.projectModel.list.Items())
+	if idx < 0 {
+		return m, nil
+	}
+	m.projectModel.list.Select(idx)
+
+	project, ok := m.projectModel.list.Items()[idx].(*items.Project)
+	if !ok {
+		return m, nil
+	}
+
+	h, v := appStyle.GetFrameSize()
+	listModel := newTaskListModel(project, m.projectModel, m.width+h, m.height+v)
+	cmd := listModel.selectTask(entry.task)
+
+	return listModel, tea.Batch(cmd, tea.WindowSize())
+}
+
+// View returns the string representation of the dashboard.
+func (m dashboardModel) View() string {
+	title := lipgloss.NewStyle().
+		Foreground(colors.BadgeText()).
+		Background(colors.Green()).
+		Padding(0, 1).
+		Render("Today") +
+		"  " + time.Now().Format("Monday, 02 January")
+
+	if m.warning != "" {
+		title += "  " + m.warning
+	}
+
+	counts := make(map[dashboardSection]int)
+	for _, entry := range m.entries {
+		counts[entry.section]++
+	}
+
+	var summary []string
+	for _, section := range []dashboardSection{dashboardOverdue, dashboardToday, dashboardInProgress} {
+		summary = append(summary, lipgloss.NewStyle().
+			Foreground(colors.BadgeText()).
+			Background(section.color()).
+			Padding(0, 1).
+			Render(fmt.Sprintf("%d %s", counts[section], strings.ToLower(section.String()))))
+	}
+
+	helpView := m.help.ShortHelpView([]key.Binding{
+		m.keys.up,
+		m.keys.down,
+		m.keys.openProject,
+		m.keys.quit,
+	})
+
+	var lines []string
+	cursorLine := 0
+
+	if len(m.entries) == 0 {
+		lines = append(lines, lipgloss.NewStyle().
+			Foreground(colors.Green()).
+			Render("Nothing due today and no task in progress"))
+	}
+
+	for i, entry := range m.entries {
+		if i == 0 || entry.section != m.entries[i-1].section {
+			if i > 0 {
+				lines = append(lines, "")
+			}
+
+			lines = append(lines, lipgloss.NewStyle().
+				Bold(true).
+				Foreground(entry.section.color()).
+				Render(entry.section.String()))
+		}
+
+		if i == m.cursor {
+			cursorLine = len(lines)
+		}
+		lines = append(lines, m.entryView(entry, i == m.cursor))
+	}
+
+	header := title + "\n\n" + strings.Join(summary, " ")
+
+	// Scroll so that the cursor stays visible.
+	visible := max(m.height-lipgloss.Height(header)-lipgloss.Height(helpView)-2, 1)
+	offset := max(0, cursorLine-visible+1)
+	end := min(len(lines), offset+visible)
+
+	var b strings.Builder
+
+	b.WriteString(header)
+	b.WriteString("\n\n")
+	b.WriteString(lipgloss.NewStyle().
+		Height(visible).
+		Render(strings.Join(lines[offset:end], "\n")))
+	b.WriteString("\n")
+	b.WriteString(helpView)
+
+	return appStyle.Render(b.String())
+}
+
+// entryView renders a single dashboard entry with its project and due date.
+func (m dashboardModel) entryView(entry dashboardEntry, selected bool) string {
+	projectColor := helpers.GetColorCode(entry.project.Color)
+
+	titleStyle := lipgloss.NewStyle().
+		Width(max(m.width-50, 20)).
+		PaddingLeft(1)
+
+	if selected {
+		titleStyle = titleStyle.
+			Border(lipgloss.NormalBorder(), false, false, false, true).
+			BorderForeground(projectColor).
+			Bold(true)
+	} else {
+		titleStyle = titleStyle.MarginLeft(1)
+	}
+
+	due := ""
+	if entry.task.DueDate != nil {
+		due = entry.task.DueDate.Format("Mon, 02 Jan 15:04")
+	}
+
+	state := ""
+	if entry.task.InProgress && entry.section != dashboardInProgress {
+		state = "in progress"
+	}
+
+	return lipgloss.JoinHorizontal(lipgloss.Top,
+		titleStyle.Render(entry.task.CropTaskTitle(taskEntryLength)),
+		lipgloss.NewStyle().
+			Width(24).
+			Foreground(projectColor).
+			Render(entry.project.Title),
+		lipgloss.NewStyle().
+			Width(20).
+			Render(due),
+		lipgloss.NewStyle().
+			Foreground(colors.Blue()).
+			Render(state),
+	)
+}
@@ -24,8 +24,12 @@
 package models
 
 import (
+	"time"
+
 	"github.com/charmbracelet/lipgloss"
 	"github.com/handlebargh/yatto/internal/colors"
+	"github.com/handlebargh/yatto/internal/config"
+	"github.com/handlebargh/yatto/internal/items"
 )
 
 type (
@@ -35,8 +39,29 @@ type (
 	// doneWaitingMsg signals that the spinner has finished its post-completion delay.
 	doneWaitingMsg struct{}
 
+	// startDateReachedMsg signals that the start date of a hidden deferred task has arrived.
+	startDateReachedMsg struct {
+		at time.Time
+	}
+
+	// tasksLoadedMsg carries a page of the tasks of a project that were
+	// not read when its task list was created.
+	tasksLoadedMsg struct {
+		projectID string
+		files     []string
+		tasks     []items.Task
+		err       error
+	}
+
 	// returnedToProjectListMsg signals the return from another model to the project list.
 	returnedToProjectListMsg struct{}
+
+	// ConfigChangedMsg signals that the config file was changed while the program is running.
+	// Config is nil if the new config is invalid, Err holds the reason then.
+	ConfigChangedMsg struct {
+		Config *config.Config
+		Err    error
+	}
 )
 
 const (
@@ -46,8 +71,18 @@ const (
 	// modeConfirmDelete indicates the UI is prompting for delete confirmation.
 	modeConfirmDelete
 
+	// modeConfirmDeleteProtected indicates the UI is prompting to delete tasks
+	// of other users anyway, see "author.protect_delete".
+	modeConfirmDeleteProtected
+
 	// modeBackendError indicates a backend-related error has occurred and should be displayed.
 	modeBackendError
+
+	// modeStorageError indicates the storage directory could not be read completely.
+	modeStorageError
+
+	// modeYank indicates the UI is prompting for what to copy to the clipboard.
+	modeYank
 )
 
 // appStyle defines the base padding for the entire application.
@@ -0,0 +1,171 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package models
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/exp/golden"
+	"github.com/google/uuid"
+	"github.com/handlebargh/yatto/internal/items"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+// benchmarkTasks returns tasks covering the badges of the task list.
+func benchmarkTasks(n int) []items.Task {
+	tomorrow := time.Now().AddDate(0, 0, 1)
+	priorities := []string{"low", "medium", "high"}
+
+	tasks := make([]items.Task, n)
+	for i := range tasks {
+		tasks[i] = items.Task{
+			ID:         uuid.NewString(),
+			Title:      "Task with a title of average length",
+			Priority:   priorities[i%len(priorities)],
+			Labels:     items.Labels{"backend", "bug"},
+			Author:     "Jane Doe <jane@example.com>",
+			Assignee:   "John Doe <john@example.com>",
+			InProgress: i%2 == 0,
+			DueDate:    &tomorrow,
+			Estimate:   items.Estimate(90 * time.Minute),
+		}
+	}
+
+	return tasks
+}
+
+func TestTaskDelegateRender(t *testing.T) {
+	tomorrow := time.Now().AddDate(0, 0, 1)
+	tasks := []items.Task{
+		{
+			Title:      "Write the release notes for the upcoming version",
+			Priority:   "high",
+			Labels:     items.Labels{"docs", "release"},
+			Author:     "Jane Doe <jane@example.com>",
+			Assignee:   "John Doe <john@example.com>",
+			InProgress: true,
+			DueDate:    &tomorrow,
+			Estimate:   items.Estimate(90 * time.Minute),
+		},
+		{
+			Title:    "Fix login",
+			Priority: "low",
+			Author:   "Jane Doe <jane@example.com>",
+			Assignee: "John Doe <john@example.com>",
+		},
+		{
+			Title:     "Update dependencies",
+			Priority:  "medium",
+			Labels:    items.Labels{"chore"},
+			Author:    "Jane Doe <jane@example.com>",
+			Assignee:  "John Doe <john@example.com>",
+			Completed: true,
+		},
+	}
+
+	for _, width := range []int{40, 60, 80, 120} {
+		t.Run(fmt.Sprintf("width %d", width), func(t *testing.T) {
+			v := viper.New()
+			v.Set("storage.path", t.TempDir())
+			v.Set("vcs.backend", "none")
+			v.Set("author.show", true)
+			v.Set("assignee.show", true)
+
+			projectModel := InitialProjectListModel(v)
+			project := &items.Project{ID: uuid.NewString(), Title: "Test"}
+			m := newTaskListModel(project, &projectModel, width, 40)
+			for _, task := range tasks {
+				task.ID = uuid.NewString()
+				m.list.InsertItem(len(m.list.Items()), &task)
+			}
+			m.setListSize(width, 40)
+
+			delegate := m.newDelegate()
+			assert.Equal(t, width < minTitleWidth+badgeColumnWidth, delegate.stacked)
+
+			var out strings.Builder
+			for i, item := range m.list.Items() {
+				var b strings.Builder
+				delegate.Render(&b, m.list, i, item)
+
+				// Every item fits the width and the lines of the delegate.
+				assert.LessOrEqual(t, lipgloss.Width(b.String()), width)
+				assert.LessOrEqual(t, lipgloss.Height(b.String()), delegate.Height())
+
+				out.WriteString(b.String())
+				out.WriteString("\n\n")
+			}
+
+			golden.RequireEqual(t, out.String())
+		})
+	}
+}
+
+func BenchmarkTaskDelegateRender(b *testing.B) {
+	v := viper.New()
+	v.Set("storage.path", b.TempDir())
+	v.Set("vcs.backend", "git")
+
+	projectModel := InitialProjectListModel(v)
+	project := &items.Project{ID: uuid.NewString(), Title: "Benchmark"}
+	m := newTaskListModel(project, &projectModel, 120, 40)
+	for _, task := range benchmarkTasks(20) {
+		m.list.InsertItem(len(m.list.Items()), &task)
+	}
+
+	delegate := customTaskDelegate{parent: &m}
+	listItems := m.list.Items()
+
+	b.ReportAllocs()
+	for b.Loop() {
+		for i, item := range listItems {
+			delegate.Render(io.Discard, m.list, i, item)
+		}
+	}
+}
+
+func BenchmarkProjectDelegateRender(b *testing.B) {
+	v := viper.New()
+	v.Set("storage.path", b.TempDir())
+
+	m := InitialProjectListModel(v)
+	for i := range 20 {
+		project := &items.Project{ID: uuid.NewString(), Title: "Project", Description: "A project", Color: "blue"}
+		m.state.taskStats[project.ID] = items.TaskStats{Total: 10, Completed: i % 10, Due: i % 3}
+		m.list.InsertItem(len(m.list.Items()), project)
+	}
+
+	delegate := customProjectDelegate{parent: &m}
+	listItems := m.list.Items()
+
+	b.ReportAllocs()
+	for b.Loop() {
+		for i, item := range listItems {
+			delegate.Render(io.Discard, m.list, i, item)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package models
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/handlebargh/yatto/internal/colors"
+	"github.com/handlebargh/yatto/internal/items"
+)
+
+// dueFilterModel defines the Bubble Tea model for the menu used to
+// restrict the task list to a range of due dates.
+type dueFilterModel struct {
+	form          *huh.Form
+	listModel     *taskListModel
+	filter        *items.DueFilter
+	width, height int
+	lg            *lipgloss.Renderer
+	styles        *Styles
+}
+
+// newDueFilterModel returns a dueFilterModel with the current filter
+// of the task list preselected.
+func newDueFilterModel(listModel *taskListModel) dueFilterModel {
+	filter := listModel.filterDue
+
+	m := dueFilterModel{
+		listModel: listModel,
+		filter:    &filter,
+		lg:        lipgloss.DefaultRenderer(),
+	}
+	m.styles = NewStyles(m.lg)
+
+	options := make([]huh.Option[items.DueFilter], 0, len(items.DueFilters))
+	for _, f := range items.DueFilters {
+		options = append(options, huh.NewOption(f.String(), f))
+	}
+
+	m.form = huh.NewForm(
+		huh.NewGroup(
+			huh.NewSelect[items.DueFilter]().
+				Title("Show tasks:").
+				Options(options...).
+				Value(m.filter),
+		)).
+		WithWidth(80).
+		WithShowHelp(false).
+		WithShowErrors(false).
+		WithTheme(colors.FormTheme())
+
+	return m
+}
+
+// Init initializes the due filter model and returns the initial command to run.
+func (m dueFilterModel) Init() tea.Cmd {
+	return m.form.Init()
+}
+
+// Update processes incoming messages and updates the model state accordingly.
+// Once a range is chosen, the filter is applied to the task list.
+func (m dueFilterModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c":
+			return m, tea.Quit
+		case "esc":
+			return m.listModel, nil
+		}
+
+	case tea.WindowSizeMsg:
+		h, v := appStyle.GetFrameSize()
+		m.width = msg.Width - h
+		m.height = msg.Height - v
+	}
+
+	var cmds []tea.Cmd
+
+	form, cmd := m.form.Update(msg)
+	if f, ok := form.(*huh.Form); ok {
+		m.form = f
+		cmds = append(cmds, cmd)
+	}
+
+	if m.form.State == huh.StateCompleted {
+		m.listModel.filterDue = *m.filter
+		cmds = append(cmds,
+			m.listModel.reloadTasks(),
+			m.listModel.list.NewStatusMessage("Due date filter: "+m.filter.String()),
+		)
+
+		return m.listModel, tea.Batch(cmds...)
+	}
+
+	return m, tea.Batch(cmds...)
+}
+
+// View renders the due filter menu.
+func (m dueFilterModel) View() string {
+	v := strings.TrimSuffix(m.form.View(), "\n\n")
+	form := m.lg.NewStyle().Margin(1, 0).Render(v)
+
+	header := formBoundaryView(m.styles, m.width, "Filter "+m.listModel.project.Title+" by due date", colors.Blue())
+	footer := formBoundaryView(m.styles, m.width,
+		m.form.Help().ShortHelpView(m.form.KeyBinds())+" • esc cancel", colors.Blue())
+
+	return m.styles.Base.Render(header + "\n" + form + "\n\n" + footer)
+}
@@ -0,0 +1,79 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package models
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/handlebargh/yatto/internal/helpers"
+)
+
+// editorFinishedMsg holds the content of a task description or project
+// README after it was edited in the user's editor, or the error that occurred.
+type editorFinishedMsg struct {
+	content string
+	err     error
+}
+
+// editDescriptionCmd opens content in the editor set in EDITOR using a
+// temporary markdown file. The program is suspended until the editor exits.
+// The edited content is returned in an editorFinishedMsg.
+func editDescriptionCmd(content string) tea.Cmd {
+	editor, err := helpers.Editor()
+	if err != nil {
+		return func() tea.Msg { return editorFinishedMsg{err: err} }
+	}
+
+	f, err := os.CreateTemp("", "yatto-*.md")
+	if err != nil {
+		return func() tea.Msg { return editorFinishedMsg{err: err} }
+	}
+	path := f.Name()
+
+	_, writeErr := f.WriteString(content)
+	if err := errors.Join(writeErr, f.Close()); err != nil {
+		_ = os.Remove(path)
+		return func() tea.Msg { return editorFinishedMsg{err: err} }
+	}
+
+	cmd := exec.Command(editor, path) // #nosec G204 Command uses validated variables
+
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		defer os.Remove(path) //nolint:errcheck
+
+		if err != nil {
+			return editorFinishedMsg{err: fmt.Errorf("editor failed: %w", err)}
+		}
+
+		data, err := os.ReadFile(path) // #nosec G304 File was created above
+		if err != nil {
+			return editorFinishedMsg{err: err}
+		}
+
+		// Editors usually add a final newline.
+		return editorFinishedMsg{content: strings.TrimRight(string(data), "\n")}
+	})
+}
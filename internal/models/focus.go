@@ -0,0 +1,395 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package models
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/handlebargh/yatto/internal/colors"
+	"github.com/handlebargh/yatto/internal/helpers"
+	"github.com/handlebargh/yatto/internal/hooks"
+	"github.com/handlebargh/yatto/internal/items"
+	"github.com/handlebargh/yatto/internal/vcs"
+)
+
+// focusTickMsg advances the focus timer by one second. Ticks of an
+// outdated generation, e.g. from before a pause, are ignored.
+type focusTickMsg struct {
+	generation int
+}
+
+// focusKeyMap defines the key bindings used in focus mode.
+type focusKeyMap struct {
+	complete key.Binding
+	next     key.Binding
+	toggle   key.Binding
+	quit     key.Binding
+}
+
+// newFocusKeyMap initializes and returns a new key map for focus mode.
+func newFocusKeyMap() *focusKeyMap {
+	return &focusKeyMap{
+		complete: key.NewBinding(
+			key.WithKeys("C"),
+			key.WithHelp("C", "complete"),
+		),
+		next: key.NewBinding(
+			key.WithKeys("n", "tab"),
+			key.WithHelp("n", "next task"),
+		),
+		toggle: key.NewBinding(
+			key.WithKeys(" "),
+			key.WithHelp("space", "pause/resume"),
+		),
+		quit: key.NewBinding(
+			key.WithKeys("q", "esc"),
+			key.WithHelp("q/esc", "go back"),
+		),
+	}
+}
+
+// focusEntry is a task in progress along with its project.
+type focusEntry struct {
+	project items.Project
+	task    items.Task
+}
+
+// focusModel represents the Bubble Tea model of the distraction-free
+// focus mode. It shows a single task in progress at a time along with
+// a timer of the time spent on it and moves on to the next task in
+// progress once it is completed.
+type focusModel struct {
+	projectModel  *ProjectListModel
+	keys          *focusKeyMap
+	help          help.Model
+	entries       []focusEntry
+	tasks         map[string][]items.Task
+	current       int
+	completed     int
+	elapsed       time.Duration
+	running       bool
+	generation    int
+	status        string
+	warning       string
+	width, height int
+}
+
+// newFocusModel creates a new focusModel containing the tasks in
+// progress of all projects, most urgent first.
+func newFocusModel(projectModel *ProjectListModel, width, height int) focusModel {
+	var entries []focusEntry
+	tasks := make(map[string][]items.Task)
+	err := readAllTasks(projectModel.config, func(project items.Project, task items.Task) {
+		tasks[project.ID] = append(tasks[project.ID], task)
+
+		if task.InProgress && !task.Completed {
+			entries = append(entries, focusEntry{project: project, task: task})
+		}
+	})
+
+	slices.SortStableFunc(entries, func(x, y focusEntry) int {
+		if c := cmp.Compare(y.task.PriorityValue(), x.task.PriorityValue()); c != 0 {
+			return c
+		}
+
+		dx, dy := x.task.DueDate, y.task.DueDate
+		switch {
+		case dx == nil && dy != nil:
+			return 1
+		case dx != nil && dy == nil:
+			return -1
+		case dx != nil && dy != nil:
+			return dx.Compare(*dy)
+		}
+
+		return 0
+	})
+
+	h, v := appStyle.GetFrameSize()
+
+	return focusModel{
+		projectModel: projectModel,
+		keys:         newFocusKeyMap(),
+		help:         help.New(),
+		entries:      entries,
+		tasks:        tasks,
+		running:      len(entries) > 0,
+		warning:      storageWarning(err),
+		width:        width - h,
+		height:       height - v,
+	}
+}
+
+// Init starts the timer of the first task.
+func (m focusModel) Init() tea.Cmd {
+	if !m.running {
+		return nil
+	}
+
+	return m.tick()
+}
+
+// tick returns a command that sends the next tick of the current generation.
+func (m focusModel) tick() tea.Cmd {
+	generation := m.generation
+	return tea.Tick(time.Second, func(time.Time) tea.Msg {
+		return focusTickMsg{generation: generation}
+	})
+}
+
+// Update handles incoming messages and updates the focusModel accordingly.
+func (m focusModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		h, v := appStyle.GetFrameSize()
+		m.width = msg.Width - h
+		m.height = msg.Height - v
+
+	case focusTickMsg:
+		if !m.running || msg.generation != m.generation {
+			return m, nil
+		}
+
+		m.elapsed += time.Second
+		return m, m.tick()
+
+	case hooks.ErrorMsg:
+		m.status = errorStatus(msg.Err)
+
+	case vcs.CommitDoneMsg:
+		m.status = "🗘  Changes committed"
+
+	case vcs.PushQueuedMsg:
+		m.status = unpushedStatus(msg.Unpushed)
+
+	case items.WriteTaskJSONErrorMsg:
+		m.status = errorStatus(msg.Err)
+
+	case vcs.CommitErrorMsg:
+		m.status = errorStatus(msg.Err)
+
+	case tea.KeyMsg:
+		if msg.Type == tea.KeyCtrlC {
+			return m, tea.Quit
+		}
+
+		switch {
+		case key.Matches(msg, m.keys.quit):
+			return m.projectModel, func() tea.Msg { return returnedToProjectListMsg{} }
+
+		case len(m.entries) == 0:
+			return m, nil
+
+		case key.Matches(msg, m.keys.toggle):
+			m.running = !m.running
+			m.generation++
+			if m.running {
+				return m, m.tick()
+			}
+
+		case key.Matches(msg, m.keys.next):
+			m.current = (m.current + 1) % len(m.entries)
+			m.status = ""
+			return m, m.resetTimer()
+
+		case key.Matches(msg, m.keys.complete):
+			return m.complete()
+		}
+	}
+
+	return m, nil
+}
+
+// resetTimer restarts the timer for the current task.
+func (m *focusModel) resetTimer() tea.Cmd {
+	m.elapsed = 0
+	m.running = len(m.entries) > 0
+	m.generation++
+
+	if !m.running {
+		return nil
+	}
+
+	return m.tick()
+}
+
+// complete completes the current task unless it is blocked by open
+// tasks and continues with the next task in progress. Completing a
+// recurring task schedules its next occurrence.
+func (m focusModel) complete() (tea.Model, tea.Cmd) {
+	entry := m.entries[m.current]
+	config := m.projectModel.config
+	projectTasks := m.tasks[entry.project.ID]
+
+	if len(entry.task.OpenBlockers(projectTasks)) > 0 {
+		m.status = errorStatus(errors.New("cannot complete task blocked by open tasks"))
+		return m, nil
+	}
+
+	task := entry.task
+	task.Completed = true
+	task.InProgress = false
+	next := task.Recur()
+
+	if idx := slices.IndexFunc(projectTasks, func(t items.Task) bool { return t.ID == task.ID }); idx >= 0 {
+		projectTasks[idx] = task
+	}
+
+	writeCmds := []tea.Cmd{task.WriteTaskJSON(config, entry.project, "complete")}
+	paths := []string{task.Path(entry.project)}
+	hookCalls := task.HookCalls(entry.project, "complete")
+
+	var recurNames []string
+	if next != nil {
+		writeCmds = append(writeCmds, next.WriteTaskJSON(config, entry.project, "recur"))
+		paths = append(paths, next.Path(entry.project))
+		hookCalls = append(hookCalls, next.HookCalls(entry.project, "recur")...)
+		recurNames = append(recurNames, fmt.Sprintf("%s (due %s)", next.Title, next.DueDateToString()))
+	}
+
+	message := items.StateChangeCommitMessage("completion", []string{task.Title}, recurNames)
+
+	m.entries = slices.Delete(m.entries, m.current, m.current+1)
+	if m.current >= len(m.entries) {
+		m.current = 0
+	}
+	m.completed++
+	m.status = fmt.Sprintf("🗸  Completed %s in %s", task.Title, focusDuration(m.elapsed))
+
+	return m, tea.Batch(
+		tea.Sequence(append(writeCmds, vcs.QueueCommitThenCmd(config, message, hooks.RunCmd(config, hookCalls), paths...))...),
+		m.resetTimer(),
+	)
+}
+
+// focusDuration formats the time spent on a task, e.g. "05:42" or "1:05:42".
+func focusDuration(d time.Duration) string {
+	d = d.Round(time.Second)
+	if d >= time.Hour {
+		return fmt.Sprintf("%d:%02d:%02d", int(d.Hours()), int(d.Minutes())%60, int(d.Seconds())%60)
+	}
+
+	return fmt.Sprintf("%02d:%02d", int(d.Minutes()), int(d.Seconds())%60)
+}
+
+// View returns the string representation of focus mode.
+func (m focusModel) View() string {
+	helpView := m.help.ShortHelpView([]key.Binding{
+		m.keys.complete,
+		m.keys.next,
+		m.keys.toggle,
+		m.keys.quit,
+	})
+
+	var content string
+	if len(m.entries) == 0 {
+		text := "No task in progress ― press P in a task list to start one"
+		if m.completed > 0 {
+			text = fmt.Sprintf("All done: %d task(s) completed", m.completed)
+		}
+		content = lipgloss.NewStyle().Foreground(colors.Green()).Render(text)
+	} else {
+		content = m.taskView(m.entries[m.current])
+	}
+
+	footer := m.status
+	if m.warning != "" {
+		footer = m.warning + "  " + footer
+	}
+
+	// Long descriptions are cut off at the bottom.
+	height := max(m.height-lipgloss.Height(helpView)-2, 1)
+	if lines := strings.Split(content, "\n"); len(lines) > height {
+		content = strings.Join(lines[:height], "\n")
+	}
+
+	return appStyle.Render(
+		lipgloss.Place(m.width, height, lipgloss.Center, lipgloss.Center, content) +
+			"\n" + footer + "\n" + helpView,
+	)
+}
+
+// taskView renders the task in focus with its title, project,
+// timer and description.
+func (m focusModel) taskView(entry focusEntry) string {
+	task := entry.task
+	projectColor := helpers.GetColorCode(entry.project.Color)
+	width := min(max(m.width-4, 20), maxMarkdownWidth)
+
+	title := lipgloss.NewStyle().
+		Bold(true).
+		Width(width).
+		Align(lipgloss.Center).
+		Padding(1, 2).
+		Border(lipgloss.ThickBorder()).
+		BorderForeground(projectColor).
+		Render(strings.ToUpper(task.Title))
+
+	details := []string{
+		lipgloss.NewStyle().Foreground(projectColor).Render(entry.project.Title),
+		task.Priority + " priority",
+	}
+	if due := task.DueText(); due != "" {
+		details = append(details, due)
+	}
+	if done, total := task.ChecklistProgress(); total > 0 {
+		details = append(details, fmt.Sprintf("%d/%d subtasks", done, total))
+	}
+	if len(m.entries) > 1 {
+		details = append(details, fmt.Sprintf("%d of %d in progress", m.current+1, len(m.entries)))
+	}
+
+	timerColor := colors.Green()
+	timer := focusDuration(m.elapsed)
+	if !m.running {
+		timerColor = colors.Orange()
+		timer += " (paused)"
+	}
+
+	rows := []string{
+		title,
+		"",
+		strings.Join(details, " ・ "),
+		"",
+		lipgloss.NewStyle().Bold(true).Foreground(timerColor).Render(timer),
+	}
+
+	if description := strings.TrimSpace(task.Description); description != "" {
+		rendered := lipgloss.NewStyle().Width(width).Render(description)
+		if renderer := m.projectModel.state.markdownRenderer(width); renderer != nil {
+			if out, err := renderer.Render(description); err == nil {
+				rendered = strings.Trim(out, "\n")
+			}
+		}
+		rows = append(rows, "", rendered)
+	}
+
+	return lipgloss.JoinVertical(lipgloss.Center, rows...)
+}
@@ -20,7 +20,18 @@
 
 package models
 
-import "github.com/handlebargh/yatto/internal/items"
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/handlebargh/yatto/internal/colors"
+	"github.com/handlebargh/yatto/internal/helpers"
+	"github.com/handlebargh/yatto/internal/items"
+	"github.com/spf13/viper"
+)
 
 // completedString returns a string representation of the task completion state.
 // It returns "completed" if completed is true, otherwise "open".
@@ -43,3 +54,132 @@ func (m ProjectListModel) allProjects() []*items.Project {
 	}
 	return out
 }
+
+// readAllTasks calls fn for every task of every project found in storage,
+// archived tasks excluded. Corrupt files are skipped and reported in the
+// returned items.SkippedFilesError.
+func readAllTasks(v *viper.Viper, fn func(items.Project, items.Task)) error {
+	projects, err := helpers.ReadProjectsFromFS(v)
+	errs := []error{err}
+
+	for _, project := range projects {
+		tasks, err := project.ReadTasksFromFS(v)
+		errs = append(errs, err)
+
+		for _, task := range tasks {
+			fn(project, task)
+		}
+	}
+
+	return items.CollectSkipped(errs...)
+}
+
+// openTaskPager shows the given task in the pager on top of the task
+// list of its project, which is opened with the task selected. Leaving
+// the pager returns to that task list. ok is false if the project or the
+// task is not found or markdown cannot be rendered.
+func openTaskPager(projectModel *ProjectListModel, project items.Project, task items.Task, width, height int) (tea.Model, tea.Cmd, bool) {
+	if projectModel.state.renderer == nil {
+		return nil, nil, false
+	}
+
+	idx := project.FindListIndexByID(projectModel.list.Items())
+	if idx < 0 {
+		return nil, nil, false
+	}
+	projectModel.list.Select(idx)
+
+	p, ok := projectModel.list.Items()[idx].(*items.Project)
+	if !ok {
+		return nil, nil, false
+	}
+
+	listModel := newTaskListModel(p, projectModel, width, height)
+
+	cmd := listModel.selectTask(task)
+	if task.FindListIndexByID(listModel.list.Items()) < 0 {
+		return nil, nil, false
+	}
+
+	selected := listModel.list.SelectedItem().(*items.Task)
+	seenCmd := listModel.markSeen(selected)
+	pagerModel := newTaskPagerModel(selected.TaskToMarkdown(), &listModel)
+
+	return pagerModel, tea.Batch(cmd, seenCmd, tea.WindowSize()), true
+}
+
+// storageErrorView renders the screen shown when the storage directory
+// could not be read completely.
+func storageErrorView(err error) string {
+	var b strings.Builder
+
+	var skipped *items.SkippedFilesError
+	if errors.As(err, &skipped) {
+		b.WriteString("Some files could not be read and were skipped:")
+		b.WriteString("\n\n")
+		for _, f := range skipped.Files {
+			b.WriteString(f.Error())
+			b.WriteString("\n")
+		}
+	} else {
+		b.WriteString("The storage directory could not be read:")
+		b.WriteString("\n\n")
+		b.WriteString(err.Error())
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n")
+	b.WriteString("Run 'yatto doctor' for details. Press esc to continue.")
+
+	return b.String()
+}
+
+// storageWarning returns a one-line warning about files that could not
+// be read, or an empty string if err is nil.
+func storageWarning(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	text := err.Error()
+
+	var skipped *items.SkippedFilesError
+	if errors.As(err, &skipped) {
+		text = fmt.Sprintf("%d file(s) could not be read ― run 'yatto doctor' for details", len(skipped.Files))
+	}
+
+	return lipgloss.NewStyle().
+		Foreground(colors.Red()).
+		Render("⚠  " + text)
+}
+
+// unpushedStatus returns a status message about n commits that could
+// not be pushed to the remote yet.
+func unpushedStatus(n int) string {
+	return lipgloss.NewStyle().
+		Foreground(colors.Orange()).
+		Render(fmt.Sprintf("⇡  %d change(s) not yet pushed ― run 'yatto sync' to retry", n))
+}
+
+// errorStatus returns the status shown for a failed action,
+// reduced to the first line of the error.
+func errorStatus(err error) string {
+	reason, _, _ := strings.Cut(err.Error(), "\n")
+
+	return lipgloss.NewStyle().
+		Foreground(colors.Red()).
+		Render("✘ " + reason)
+}
+
+// configStatus returns a status message about a reloaded config file.
+func configStatus(msg ConfigChangedMsg) string {
+	if msg.Err != nil {
+		return lipgloss.NewStyle().
+			Foreground(colors.Red()).
+			Render(fmt.Sprintf("⚠  Config not reloaded: %s", msg.Err))
+	}
+
+	return lipgloss.NewStyle().
+		Foreground(colors.Green()).
+		Render("⚙  Configuration reloaded")
+}
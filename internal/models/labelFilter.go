@@ -0,0 +1,173 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package models
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/handlebargh/yatto/internal/colors"
+)
+
+// labelFilterVars holds the values chosen in the label filter form.
+type labelFilterVars struct {
+	labels []string
+	all    bool
+}
+
+// labelFilterModel defines the Bubble Tea model for the overlay used to
+// restrict the task list to tasks carrying certain labels.
+type labelFilterModel struct {
+	form          *huh.Form
+	listModel     *taskListModel
+	vars          *labelFilterVars
+	width, height int
+	lg            *lipgloss.Renderer
+	styles        *Styles
+}
+
+// newLabelFilterModel returns a labelFilterModel offering the given
+// labels, most frequently used first. The current filter of the task
+// list is preselected.
+func newLabelFilterModel(listModel *taskListModel, labels map[string]int) labelFilterModel {
+	m := labelFilterModel{
+		listModel: listModel,
+		vars: &labelFilterVars{
+			labels: slices.Clone(listModel.filterLabels),
+			all:    listModel.filterAll,
+		},
+		lg: lipgloss.DefaultRenderer(),
+	}
+	m.styles = NewStyles(m.lg)
+
+	names := make([]string, 0, len(labels))
+	for label := range labels {
+		if strings.TrimSpace(label) != "" {
+			names = append(names, label)
+		}
+	}
+
+	// Keep filtered labels selectable even if no task carries them anymore.
+	for _, label := range m.vars.labels {
+		if _, ok := labels[label]; !ok {
+			names = append(names, label)
+		}
+	}
+	slices.SortFunc(names, func(a, b string) int {
+		return cmp.Or(
+			cmp.Compare(labels[b], labels[a]),
+			strings.Compare(strings.ToLower(a), strings.ToLower(b)),
+		)
+	})
+
+	options := make([]huh.Option[string], 0, len(names))
+	for _, label := range names {
+		options = append(options, huh.NewOption(label, label))
+	}
+
+	m.form = huh.NewForm(
+		huh.NewGroup(
+			huh.NewMultiSelect[string]().
+				Title("Show tasks labeled:").
+				Description("Select none to show all tasks.").
+				Height(15).
+				Options(options...).
+				Value(&m.vars.labels),
+
+			huh.NewSelect[bool]().
+				Title("Match:").
+				Options(
+					huh.NewOption("any selected label (OR)", false),
+					huh.NewOption("all selected labels (AND)", true),
+				).
+				Value(&m.vars.all),
+		)).
+		WithWidth(80).
+		WithShowHelp(false).
+		WithShowErrors(false).
+		WithTheme(colors.FormTheme())
+
+	return m
+}
+
+// Init initializes the label filter model and returns the initial command to run.
+func (m labelFilterModel) Init() tea.Cmd {
+	return m.form.Init()
+}
+
+// Update processes incoming messages and updates the model state accordingly.
+// Once the form is completed, the filter is applied to the task list.
+func (m labelFilterModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c":
+			return m, tea.Quit
+		case "esc":
+			return m.listModel, nil
+		}
+
+	case tea.WindowSizeMsg:
+		h, v := appStyle.GetFrameSize()
+		m.width = msg.Width - h
+		m.height = msg.Height - v
+	}
+
+	var cmds []tea.Cmd
+
+	form, cmd := m.form.Update(msg)
+	if f, ok := form.(*huh.Form); ok {
+		m.form = f
+		cmds = append(cmds, cmd)
+	}
+
+	if m.form.State == huh.StateCompleted {
+		m.listModel.filterLabels = m.vars.labels
+		m.listModel.filterAll = m.vars.all
+		cmds = append(cmds, m.listModel.reloadTasks())
+
+		status := "Showing all tasks"
+		if len(m.vars.labels) > 0 {
+			status = "Filtered by " + m.listModel.labelFilterString()
+		}
+		cmds = append(cmds, m.listModel.list.NewStatusMessage(status))
+
+		return m.listModel, tea.Batch(cmds...)
+	}
+
+	return m, tea.Batch(cmds...)
+}
+
+// View renders the label filter form.
+func (m labelFilterModel) View() string {
+	v := strings.TrimSuffix(m.form.View(), "\n\n")
+	form := m.lg.NewStyle().Margin(1, 0).Render(v)
+
+	header := formBoundaryView(m.styles, m.width, "Filter "+m.listModel.project.Title+" by label", colors.Blue())
+	footer := formBoundaryView(m.styles, m.width,
+		m.form.Help().ShortHelpView(m.form.KeyBinds())+" • esc cancel", colors.Blue())
+
+	return m.styles.Base.Render(header + "\n" + form + "\n\n" + footer)
+}
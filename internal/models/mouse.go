@@ -0,0 +1,138 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package models
+
+import (
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/bubbles/paginator"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// doubleClickInterval is the maximum time between two clicks
+// on the same item to count as a double click.
+const doubleClickInterval = 400 * time.Millisecond
+
+// lastClick remembers the previous click on a list item
+// in order to detect double clicks.
+type lastClick struct {
+	index int
+	at    time.Time
+}
+
+// handleListMouse applies a mouse event to l, which is rendered with
+// delegate d inside appStyle. The scroll wheel moves the cursor, a click
+// on an item selects it and a click on a pagination dot shows that page.
+// It reports whether an item was double clicked and should be opened.
+func handleListMouse(l *list.Model, d list.ItemDelegate, last *lastClick, msg tea.MouseMsg) bool {
+	if l.FilterState() == list.Filtering {
+		return false
+	}
+
+	switch msg.Button {
+	case tea.MouseButtonWheelUp:
+		l.CursorUp()
+		return false
+	case tea.MouseButtonWheelDown:
+		l.CursorDown()
+		return false
+	case tea.MouseButtonLeft:
+		if msg.Action != tea.MouseActionPress {
+			return false
+		}
+	default:
+		return false
+	}
+
+	x := msg.X - appStyle.GetPaddingLeft()
+	y := msg.Y - appStyle.GetPaddingTop()
+
+	if page, ok := listPageAt(l, x, y); ok {
+		l.Select(page * l.Paginator.PerPage)
+		return false
+	}
+
+	index, ok := listItemAt(l, d, y)
+	if !ok {
+		return false
+	}
+
+	double := last.index == index && time.Since(last.at) < doubleClickInterval
+	*last = lastClick{index: index, at: time.Now()}
+	if double {
+		// A third click starts over.
+		last.at = time.Time{}
+	}
+
+	l.Select(index)
+
+	return double
+}
+
+// listItemAt returns the index of the item rendered
+// at row y of the view of l, if any.
+func listItemAt(l *list.Model, d list.ItemDelegate, y int) (int, bool) {
+	if l.ShowTitle() || (l.ShowFilter() && l.FilteringEnabled()) {
+		y -= lipgloss.Height(l.Styles.TitleBar.Render(" "))
+	}
+	if l.ShowStatusBar() {
+		y -= lipgloss.Height(l.Styles.StatusBar.Render(" "))
+	}
+
+	step := d.Height() + d.Spacing()
+	if y < 0 || step == 0 || y%step >= d.Height() {
+		return 0, false
+	}
+
+	start, end := l.Paginator.GetSliceBounds(len(l.VisibleItems()))
+	index := start + y/step
+	if index >= end {
+		return 0, false
+	}
+
+	return index, true
+}
+
+// listPageAt returns the page of the pagination dot rendered
+// at column x and row y of the view of l, if any.
+func listPageAt(l *list.Model, x, y int) (int, bool) {
+	if !l.ShowPagination() || l.Paginator.Type != paginator.Dots || l.Paginator.TotalPages < 2 {
+		return 0, false
+	}
+
+	row := l.Height() - 1
+	if l.ShowHelp() {
+		row -= lipgloss.Height(l.Styles.HelpStyle.Render(l.Help.View(l)))
+	}
+	if y != row {
+		return 0, false
+	}
+
+	x -= l.Styles.PaginationStyle.GetPaddingLeft()
+	dot := lipgloss.Width(l.Paginator.ActiveDot)
+	if x < 0 || dot == 0 || x/dot >= l.Paginator.TotalPages {
+		return 0, false
+	}
+
+	return x / dot, true
+}
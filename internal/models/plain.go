@@ -0,0 +1,45 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package models
+
+import (
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/handlebargh/yatto/internal/colors"
+)
+
+// plainModel wraps the model shown by the program, replacing the
+// Unicode glyphs of its view with ASCII if the plain output mode
+// is enabled, see colors.SetPlain. As the mode can be toggled in
+// the config file, the view is checked every time it is rendered.
+type plainModel struct {
+	tea.Model
+}
+
+// Update passes msg on to the wrapped model and wraps the model it returns.
+func (m plainModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	next, cmd := m.Model.Update(msg)
+	return plainModel{next}, cmd
+}
+
+// View renders the wrapped model, see colors.ASCII.
+func (m plainModel) View() string {
+	return colors.ASCII(m.Model.View())
+}
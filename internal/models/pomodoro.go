@@ -0,0 +1,333 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/progress"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/handlebargh/yatto/internal/colors"
+	"github.com/handlebargh/yatto/internal/config"
+	"github.com/handlebargh/yatto/internal/helpers"
+	"github.com/handlebargh/yatto/internal/items"
+	"github.com/handlebargh/yatto/internal/vcs"
+)
+
+// pomodoroPhase is the current phase of a pomodoro cycle.
+type pomodoroPhase int
+
+const (
+	// pomodoroReady waits for the next pomodoro to be started.
+	pomodoroReady pomodoroPhase = iota
+
+	// pomodoroWork is the focus phase of a pomodoro.
+	pomodoroWork
+
+	// pomodoroBreak is the break following a pomodoro.
+	pomodoroBreak
+)
+
+// pomodoroTickMsg advances the timer by one second. Ticks of an
+// outdated generation, e.g. from before a pause, are ignored.
+type pomodoroTickMsg struct {
+	generation int
+}
+
+// pomodoroKeyMap defines the key bindings used in the pomodoro view.
+type pomodoroKeyMap struct {
+	quit   key.Binding
+	toggle key.Binding
+	skip   key.Binding
+}
+
+// newPomodoroKeyMap initializes and returns a new key map for pomodoro actions.
+func newPomodoroKeyMap() *pomodoroKeyMap {
+	return &pomodoroKeyMap{
+		quit: key.NewBinding(
+			key.WithKeys("q", "esc"),
+			key.WithHelp("q/esc", "go back"),
+		),
+		toggle: key.NewBinding(
+			key.WithKeys(" ", "s"),
+			key.WithHelp("space/s", "start/pause"),
+		),
+		skip: key.NewBinding(
+			key.WithKeys("n"),
+			key.WithHelp("n", "skip phase"),
+		),
+	}
+}
+
+// pomodoroModel represents the Bubble Tea model of a pomodoro timer bound
+// to a task. Every finished pomodoro is logged in the task and committed.
+type pomodoroModel struct {
+	listModel     *taskListModel
+	task          *items.Task
+	keys          *pomodoroKeyMap
+	help          help.Model
+	progress      progress.Model
+	phase         pomodoroPhase
+	running       bool
+	remaining     time.Duration
+	generation    int
+	workLength    time.Duration
+	breakLength   time.Duration
+	notify        bool
+	status        string
+	width, height int
+}
+
+// newPomodoroModel creates a new pomodoroModel for the given task.
+// Durations are taken from the configuration.
+func newPomodoroModel(task *items.Task, listModel *taskListModel) pomodoroModel {
+	cfg := config.Get(listModel.projectModel.config)
+
+	return pomodoroModel{
+		listModel:   listModel,
+		task:        task,
+		keys:        newPomodoroKeyMap(),
+		help:        help.New(),
+		progress:    progress.New(progress.WithSolidFill(colors.Red().Dark), progress.WithoutPercentage()),
+		phase:       pomodoroReady,
+		remaining:   cfg.PomodoroWork,
+		workLength:  cfg.PomodoroWork,
+		breakLength: cfg.PomodoroBreak,
+		notify:      cfg.PomodoroNotify,
+	}
+}
+
+// Init initializes the pomodoroModel and returns an initial command.
+func (m pomodoroModel) Init() tea.Cmd {
+	return nil
+}
+
+// tick returns a command that sends the next tick of the current generation.
+func (m pomodoroModel) tick() tea.Cmd {
+	generation := m.generation
+	return tea.Tick(time.Second, func(time.Time) tea.Msg {
+		return pomodoroTickMsg{generation: generation}
+	})
+}
+
+// Update handles incoming messages and updates the pomodoroModel accordingly.
+func (m pomodoroModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		h, v := appStyle.GetFrameSize()
+		m.width = msg.Width - h
+		m.height = msg.Height - v
+		m.progress.Width = min(m.width, 60)
+
+	case pomodoroTickMsg:
+		if !m.running || msg.generation != m.generation {
+			return m, nil
+		}
+
+		m.remaining -= time.Second
+		if m.remaining > 0 {
+			return m, m.tick()
+		}
+
+		return m.finishPhase()
+
+	case items.WriteTaskJSONDoneMsg:
+		m.status = "🗸  Pomodoro logged ― committing changes"
+
+	case vcs.CommitDoneMsg:
+		m.status = "🗘  Changes committed"
+
+	case vcs.PushQueuedMsg:
+		m.status = unpushedStatus(msg.Unpushed)
+
+	case items.WriteTaskJSONErrorMsg, vcs.CommitErrorMsg, vcs.PullErrorMsg, vcs.PushErrorMsg:
+		m.status = lipgloss.NewStyle().
+			Foreground(colors.Red()).
+			Render(fmt.Sprintf("Could not log pomodoro: %s", msg.(error).Error()))
+
+	case tea.KeyMsg:
+		if msg.Type == tea.KeyCtrlC {
+			return m, tea.Quit
+		}
+
+		switch {
+		case key.Matches(msg, m.keys.quit):
+			return m.listModel, nil
+
+		case key.Matches(msg, m.keys.toggle):
+			if m.phase == pomodoroReady {
+				m.phase = pomodoroWork
+				m.remaining = m.workLength
+				m.status = ""
+			}
+
+			m.running = !m.running
+			m.generation++
+			if m.running {
+				return m, m.tick()
+			}
+
+		case key.Matches(msg, m.keys.skip):
+			if m.phase == pomodoroWork {
+				m.startPhase(pomodoroBreak)
+				return m, m.tick()
+			}
+			m.startPhase(pomodoroReady)
+		}
+	}
+
+	return m, nil
+}
+
+// startPhase switches to the given phase and resets the timer.
+// Only work and break phases run without user interaction.
+func (m *pomodoroModel) startPhase(phase pomodoroPhase) {
+	m.phase = phase
+	m.generation++
+
+	switch phase {
+	case pomodoroBreak:
+		m.remaining = m.breakLength
+		m.running = true
+	default:
+		m.remaining = m.workLength
+		m.running = false
+	}
+}
+
+// finishPhase ends the current phase. A finished pomodoro is logged
+// in the task and committed before the break starts. After the break,
+// the timer waits for the next pomodoro to be started.
+func (m pomodoroModel) finishPhase() (tea.Model, tea.Cmd) {
+	var cmds []tea.Cmd
+
+	if m.phase == pomodoroWork {
+		m.task.Pomodoros = append(m.task.Pomodoros, time.Now())
+		project := *m.listModel.project
+
+		cmds = append(cmds, tea.Sequence(
+			m.task.WriteTaskJSON(m.listModel.projectModel.config, project, "pomodoro"),
+			vcs.CommitCmd(
+				m.listModel.projectModel.config,
+				fmt.Sprintf("pomodoro: %s", m.task.Title),
+				m.task.Path(project),
+			),
+		))
+		cmds = append(cmds, m.notifyCmd("Pomodoro finished", "Time for a break"))
+
+		m.startPhase(pomodoroBreak)
+		cmds = append(cmds, m.tick())
+	} else {
+		cmds = append(cmds, m.notifyCmd("Break is over", "Ready for the next pomodoro"))
+		m.startPhase(pomodoroReady)
+	}
+
+	return m, tea.Batch(cmds...)
+}
+
+// notifyCmd sends a desktop notification if notifications are enabled.
+// Failing notifications are ignored since the timer is shown anyway.
+func (m pomodoroModel) notifyCmd(title, message string) tea.Cmd {
+	if !m.notify {
+		return nil
+	}
+
+	return func() tea.Msg {
+		_ = helpers.Notify(title, message)
+		return nil
+	}
+}
+
+// pomodorosToday returns the number of pomodoros logged in the task today.
+func (m pomodoroModel) pomodorosToday() int {
+	count := 0
+	for _, t := range m.task.Pomodoros {
+		if items.IsToday(&t) {
+			count++
+		}
+	}
+
+	return count
+}
+
+// View returns the string representation of the pomodoro view.
+func (m pomodoroModel) View() string {
+	title := lipgloss.NewStyle().
+		Foreground(colors.BadgeText()).
+		Background(colors.Red()).
+		Padding(0, 1).
+		Render("Pomodoro")
+
+	phase, length := "Ready", m.workLength
+	phaseColor := colors.Blue()
+	bar := m.progress
+
+	switch m.phase {
+	case pomodoroWork:
+		phase, phaseColor = "Focus", colors.Red()
+	case pomodoroBreak:
+		phase, length, phaseColor = "Break", m.breakLength, colors.Green()
+		bar.FullColor = colors.Green().Dark
+	}
+
+	if m.phase != pomodoroReady && !m.running {
+		phase += " (paused)"
+	}
+
+	var elapsed float64
+	if length > 0 {
+		elapsed = 1 - m.remaining.Seconds()/length.Seconds()
+	}
+
+	remaining := m.remaining.Round(time.Second)
+	timer := fmt.Sprintf("%02d:%02d", int(remaining.Minutes()), int(remaining.Seconds())%60)
+
+	var b strings.Builder
+
+	b.WriteString(title)
+	b.WriteString("\n\n")
+	b.WriteString(lipgloss.NewStyle().Bold(true).Render(m.task.CropTaskTitle(taskEntryLength)))
+	b.WriteString("\n\n")
+	b.WriteString(lipgloss.NewStyle().Foreground(phaseColor).Render(phase))
+	b.WriteString("  ")
+	b.WriteString(lipgloss.NewStyle().Bold(true).Render(timer))
+	b.WriteString("\n\n")
+	b.WriteString(bar.ViewAs(elapsed))
+	b.WriteString("\n\n")
+	fmt.Fprintf(&b, "%d pomodoro(s) today, %d in total", m.pomodorosToday(), len(m.task.Pomodoros))
+	b.WriteString("\n\n")
+	b.WriteString(m.status)
+
+	content := lipgloss.NewStyle().
+		Height(max(m.height-1, 1)).
+		Render(b.String())
+
+	return appStyle.Render(content + "\n" + m.help.ShortHelpView([]key.Binding{
+		m.keys.toggle,
+		m.keys.skip,
+		m.keys.quit,
+	}))
+}
@@ -0,0 +1,126 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package models
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/handlebargh/yatto/internal/colors"
+	"github.com/handlebargh/yatto/internal/items"
+)
+
+const (
+	// defaultPreviewPercent is the share of the width
+	// the preview pane takes when it is first shown.
+	defaultPreviewPercent = 50
+
+	// minPreviewPercent and maxPreviewPercent bound the width of
+	// the preview pane, previewPercentStep is the step it is resized by.
+	minPreviewPercent  = 30
+	maxPreviewPercent  = 70
+	previewPercentStep = 10
+)
+
+// previewStyle separates the preview pane from the task list.
+var previewStyle = lipgloss.NewStyle().
+	Border(lipgloss.NormalBorder(), false, false, false, true).
+	PaddingLeft(1)
+
+// taskPreview caches the rendered preview of the highlighted task.
+// Rendering markdown is expensive and View is called on every message,
+// so the preview is only rendered again if the task or width changed.
+type taskPreview struct {
+	key      string
+	rendered string
+}
+
+// setListSize lays out the list and, if shown, the preview pane
+// in the given area inside appStyle, excluding the status bar.
+func (m *taskListModel) setListSize(width, height int) {
+	m.areaWidth = width
+
+	if m.projectModel.state.showPreview {
+		width -= m.previewWidth()
+	}
+
+	m.list.SetSize(width, height)
+
+	// Whether the badges are stacked depends on the width.
+	m.list.SetDelegate(m.newDelegate())
+}
+
+// previewWidth returns the width of the preview pane including its border.
+func (m *taskListModel) previewWidth() int {
+	return m.areaWidth * m.projectModel.state.previewPercent / 100
+}
+
+// togglePreview shows or hides the preview pane.
+func (m *taskListModel) togglePreview() {
+	m.projectModel.state.showPreview = !m.projectModel.state.showPreview
+	m.setListSize(m.areaWidth, m.list.Height())
+}
+
+// resizePreview widens (positive steps) or narrows (negative steps) the
+// preview pane within its bounds. It shows the pane if it is hidden.
+func (m *taskListModel) resizePreview(steps int) {
+	state := m.projectModel.state
+	state.previewPercent = min(max(state.previewPercent+steps*previewPercentStep, minPreviewPercent), maxPreviewPercent)
+	state.showPreview = true
+	m.setListSize(m.areaWidth, m.list.Height())
+}
+
+// previewView renders the preview pane for the highlighted task.
+func (m taskListModel) previewView() string {
+	width := m.previewWidth()
+	height := m.list.Height()
+	contentWidth := width - previewStyle.GetHorizontalFrameSize()
+
+	style := previewStyle.
+		BorderForeground(colors.Indigo()).
+		Width(contentWidth).
+		Height(height).
+		MaxHeight(height)
+
+	t, ok := m.list.SelectedItem().(*items.Task)
+	if !ok || contentWidth <= 0 {
+		return style.Render("")
+	}
+
+	// Until the renderer is ready, the markdown is shown as is.
+	renderer := m.projectModel.state.markdownRenderer(contentWidth)
+	markdown := t.TaskToMarkdown()
+
+	key := strconv.Itoa(contentWidth) + "\x00" + strconv.FormatBool(renderer != nil) + "\x00" + markdown
+	if m.preview.key != key {
+		m.preview.key = key
+		m.preview.rendered = markdown
+
+		if renderer != nil {
+			if rendered, err := renderer.Render(markdown); err == nil {
+				m.preview.rendered = strings.Trim(rendered, "\n")
+			}
+		}
+	}
+
+	return style.Render(m.preview.rendered)
+}
@@ -21,15 +21,21 @@
 package models
 
 import (
+	"cmp"
 	"errors"
 	"fmt"
 	"path/filepath"
+	"slices"
 	"strings"
+	"unicode"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/huh"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/google/uuid"
 	"github.com/handlebargh/yatto/internal/colors"
+	"github.com/handlebargh/yatto/internal/helpers"
+	"github.com/handlebargh/yatto/internal/hooks"
 	"github.com/handlebargh/yatto/internal/items"
 	"github.com/handlebargh/yatto/internal/storage"
 	"github.com/handlebargh/yatto/internal/vcs"
@@ -48,6 +54,9 @@ type projectFormModel struct {
 	lg            *lipgloss.Renderer
 	styles        *Styles
 	vars          *projectFormVars
+
+	// source is the project a new project is copied from, if any.
+	source *items.Project
 }
 
 // projectFormVars holds the temporary values that are populated and modified
@@ -57,6 +66,88 @@ type projectFormVars struct {
 	projectTitle       string
 	projectDescription string
 	projectColor       string
+	projectHex         string
+	projectIcon        string
+	projectIconCustom  string
+	projectFields      string
+	copyTasks          bool
+}
+
+// customColor is the option of the color select that
+// uses the hex color entered in the form.
+const customColor = "custom"
+
+// customIcon is the option of the icon select that
+// uses the icon entered in the form.
+const customIcon = "custom"
+
+// projectIcon is an icon offered by the icon select of the project form.
+type projectIcon struct {
+	icon, name string
+}
+
+// projectIcons are the icons offered by the icon select of the project form.
+var projectIcons = []projectIcon{
+	{"📁", "folder"},
+	{"💼", "work"},
+	{"🏠", "home"},
+	{"🛒", "shopping"},
+	{"📚", "reading"},
+	{"🎯", "goals"},
+	{"🚀", "launch"},
+	{"🐛", "bugs"},
+	{"💡", "ideas"},
+	{"🎨", "design"},
+	{"🔧", "maintenance"},
+	{"🌱", "garden"},
+	{"💰", "finance"},
+	{"🌍", "travel"},
+}
+
+// color returns the project color chosen in the form.
+func (v *projectFormVars) color() string {
+	if v.projectColor == customColor {
+		return v.projectHex
+	}
+
+	return v.projectColor
+}
+
+// icon returns the project icon chosen in the form.
+func (v *projectFormVars) icon() string {
+	if v.projectIcon == customIcon {
+		return strings.TrimSpace(v.projectIconCustom)
+	}
+
+	return v.projectIcon
+}
+
+// colorPreview renders the project title with the chosen icon
+// in the chosen color like the title of the task list.
+func (v *projectFormVars) colorPreview() string {
+	color := v.color()
+	if v.projectColor == customColor && !helpers.IsHexColor(color) {
+		return "Preview: enter a color like #ff8800"
+	}
+
+	return "Preview: " + lipgloss.NewStyle().
+		Foreground(colors.BadgeText()).
+		Background(helpers.GetColorCode(color)).
+		Padding(0, 1).
+		Render(strings.TrimSpace(v.icon()+" "+cmp.Or(strings.TrimSpace(v.projectTitle), "Project")))
+}
+
+// fields returns the text fields of the form by name, see formInput.
+func (v *projectFormVars) fields() map[string]*string {
+	return map[string]*string{
+		"title":       &v.projectTitle,
+		"description": &v.projectDescription,
+		"color":       &v.projectColor,
+		"hex":         &v.projectHex,
+		"icon":        &v.projectIcon,
+		"iconCustom":  &v.projectIconCustom,
+		"fields":      &v.projectFields,
+	}
 }
 
 // newProjectFormModel initializes and returns a new projectFormModel instance,
@@ -71,6 +162,16 @@ func newProjectFormModel(
 		projectTitle:       p.Title,
 		projectDescription: p.Description,
 		projectColor:       p.Color,
+		projectFields:      p.Fields.String(),
+	}
+	if helpers.IsHexColor(p.Color) {
+		v.projectColor = customColor
+		v.projectHex = p.Color
+	}
+	v.projectIcon = p.Icon
+	if p.Icon != "" && !slices.ContainsFunc(projectIcons, func(i projectIcon) bool { return i.icon == p.Icon }) {
+		v.projectIcon = customIcon
+		v.projectIconCustom = p.Icon
 	}
 
 	m := projectFormModel{}
@@ -81,54 +182,165 @@ func newProjectFormModel(
 	m.lg = lipgloss.DefaultRenderer()
 	m.styles = NewStyles(m.lg)
 
+	m.form = m.newForm()
+
+	return m
+}
+
+// newCopyProjectFormModel returns a projectFormModel creating
+// a new project based on the source project.
+func newCopyProjectFormModel(source *items.Project, listModel *ProjectListModel) projectFormModel {
+	project := &items.Project{
+		ID:          uuid.NewString(),
+		Title:       runewidth.Truncate("Copy of "+source.Title, 32, ""),
+		Description: source.Description,
+		Color:       source.Color,
+		Icon:        source.Icon,
+		Fields:      slices.Clone(source.Fields),
+	}
+	if source.Settings != nil {
+		settings := *source.Settings
+		project.Settings = &settings
+	}
+
+	m := newProjectFormModel(project, listModel, false)
+	m.source = source
+	m.vars.copyTasks = true
+	m.form = m.newForm()
+
+	return m
+}
+
+// newForm builds the form of the model. Copies of a project
+// additionally ask whether to take over the open tasks.
+func (m projectFormModel) newForm() *huh.Form {
 	var confirmQuestion string
-	if edit {
+	if m.edit {
 		confirmQuestion = "Edit project?"
 	} else {
 		confirmQuestion = "Create new project?"
 	}
 
-	m.form = huh.NewForm(
-		huh.NewGroup(
-			huh.NewSelect[string]().
-				Key("color").
-				Options(huh.NewOptions("green", "orange", "red", "blue", "indigo")...).
-				Title("Select a color").
-				Value(&m.vars.projectColor),
-
-			huh.NewInput().
-				Key("title").
-				Title("Enter a title:").
-				Value(&m.vars.projectTitle).
-				Description("Give it a short but concise title."+"\n"+
-					"(max 64 characters)").
-				Validate(func(str string) error {
-					if len(strings.TrimSpace(str)) < 1 {
-						return errors.New("title must not be empty")
-					}
-					if runewidth.StringWidth(str) > 32 {
-						return errors.New("title is too long (max 32 terminal columns)")
-					}
-					return nil
-				}),
-
-			huh.NewText().
-				Key("description").
-				Title("Enter a description:").
-				Value(&m.vars.projectDescription),
-
-			huh.NewConfirm().
-				Title(confirmQuestion).
-				Affirmative("Yes").
-				Negative("No").
-				Value(&m.vars.confirm),
-		)).
+	colorOptions := make([]huh.Option[string], 0, len(helpers.ProjectColors)+1)
+	for _, color := range helpers.ProjectColors {
+		swatch := lipgloss.NewStyle().Foreground(helpers.GetColorCode(color)).Render("■")
+		colorOptions = append(colorOptions, huh.NewOption(swatch+" "+color, color))
+	}
+	colorOptions = append(colorOptions, huh.NewOption("  custom hex color", customColor))
+
+	iconOptions := []huh.Option[string]{huh.NewOption("  none", "")}
+	for _, i := range projectIcons {
+		iconOptions = append(iconOptions, huh.NewOption(i.icon+" "+i.name, i.icon))
+	}
+	iconOptions = append(iconOptions, huh.NewOption("  custom icon", customIcon))
+
+	// The preview follows the color, icon and title while they are changed.
+	preview := []*string{
+		&m.vars.projectColor, &m.vars.projectHex, &m.vars.projectTitle,
+		&m.vars.projectIcon, &m.vars.projectIconCustom,
+	}
+
+	fields := []huh.Field{
+		huh.NewSelect[string]().
+			Key("color").
+			Options(colorOptions...).
+			Title("Select a color").
+			DescriptionFunc(m.vars.colorPreview, preview).
+			Value(&m.vars.projectColor),
+
+		huh.NewInput().
+			Key("hex").
+			Title("Hex color:").
+			Placeholder("#ff8800").
+			DescriptionFunc(func() string {
+				return "Used if \"custom hex color\" is selected.\n" + m.vars.colorPreview()
+			}, preview).
+			Value(&m.vars.projectHex).
+			Validate(func(str string) error {
+				if (str != "" || m.vars.projectColor == customColor) && !helpers.IsHexColor(str) {
+					return errors.New("hex color must look like #ff8800 or #f80")
+				}
+				return nil
+			}),
+
+		huh.NewSelect[string]().
+			Key("icon").
+			Options(iconOptions...).
+			Title("Choose an icon").
+			DescriptionFunc(m.vars.colorPreview, preview).
+			Value(&m.vars.projectIcon),
+
+		huh.NewInput().
+			Key("iconCustom").
+			Title("Custom icon:").
+			Description("Any emoji or Nerd Font icon, used if \"custom icon\" is selected.").
+			Value(&m.vars.projectIconCustom).
+			Validate(func(str string) error {
+				str = strings.TrimSpace(str)
+				switch {
+				case str == "" && m.vars.projectIcon == customIcon:
+					return errors.New("icon must not be empty")
+				case strings.ContainsFunc(str, unicode.IsSpace):
+					return errors.New("icon must not contain spaces")
+				case runewidth.StringWidth(str) > 2:
+					return errors.New("icon is too wide (max 2 terminal columns)")
+				}
+				return nil
+			}),
+
+		huh.NewInput().
+			Key("title").
+			Title("Enter a title:").
+			Value(&m.vars.projectTitle).
+			Description("Give it a short but concise title." + "\n" +
+				"(max 64 characters)").
+			Validate(func(str string) error {
+				if len(strings.TrimSpace(str)) < 1 {
+					return errors.New("title must not be empty")
+				}
+				if runewidth.StringWidth(str) > 32 {
+					return errors.New("title is too long (max 32 terminal columns)")
+				}
+				return nil
+			}),
+
+		huh.NewText().
+			Key("description").
+			Title("Enter a description:").
+			Value(&m.vars.projectDescription),
+
+		huh.NewText().
+			Key("fields").
+			Title("Custom fields:").
+			Description("One per line as \"name: type\" with type text, number or date,\n" +
+				"or \"name: enum option, option, ...\".").
+			Value(&m.vars.projectFields).
+			Validate(func(str string) error {
+				_, err := items.ParseFieldDefs(str)
+				return err
+			}),
+	}
+
+	if m.source != nil {
+		fields = append(fields, huh.NewConfirm().
+			Title("Copy open tasks?").
+			Description(fmt.Sprintf("Takes over the open tasks of %q with their state reset.", m.source.Title)).
+			Affirmative("Yes").
+			Negative("No").
+			Value(&m.vars.copyTasks))
+	}
+
+	fields = append(fields, huh.NewConfirm().
+		Title(confirmQuestion).
+		Affirmative("Yes").
+		Negative("No").
+		Value(&m.vars.confirm))
+
+	return huh.NewForm(huh.NewGroup(fields...)).
 		WithWidth(80).
 		WithShowHelp(false).
 		WithShowErrors(false).
 		WithTheme(colors.FormTheme())
-
-	return m
 }
 
 // Init initializes the form model and returns the initial command to run.
@@ -179,7 +391,10 @@ func (m projectFormModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		m.project.Title = m.vars.projectTitle
 		m.project.Description = m.vars.projectDescription
-		m.project.Color = m.vars.projectColor
+		m.project.Color = m.vars.color()
+		m.project.Icon = m.vars.icon()
+		// Validated by the form.
+		m.project.Fields, _ = items.ParseFieldDefs(m.vars.projectFields)
 
 		json := m.project.MarshalProject()
 		action := "create"
@@ -188,16 +403,21 @@ func (m projectFormModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 
 		m.listModel.spinning = true
-		cmds = append(
-			cmds,
-			m.listModel.spinner.Tick,
-			m.project.WriteProjectJSON(m.listModel.config, json, action),
-			vcs.CommitCmd(
-				m.listModel.config,
-				fmt.Sprintf("%s: %s", action, m.project.Title),
-				filepath.Join(m.project.ID, "project.json"),
-			),
-		)
+		cmds = append(cmds, m.listModel.spinner.Tick)
+
+		if m.source != nil {
+			cmds = append(cmds, m.copyCmd(json))
+		} else {
+			cmds = append(
+				cmds,
+				m.project.WriteProjectJSON(m.listModel.config, json, action),
+				vcs.CommitCmd(
+					m.listModel.config,
+					fmt.Sprintf("%s: %s", action, m.project.Title),
+					filepath.Join(m.project.ID, "project.json"),
+				),
+			)
+		}
 
 		m.listModel.status = ""
 		cmds = append(cmds, func() tea.Msg { return returnedToProjectListMsg{} })
@@ -207,6 +427,39 @@ func (m projectFormModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, tea.Batch(cmds...)
 }
 
+// copyCmd writes the copy of the source project along with
+// the copies of its open tasks, if requested, and commits them.
+func (m projectFormModel) copyCmd(json []byte) tea.Cmd {
+	paths := []string{filepath.Join(m.project.ID, "project.json")}
+
+	var taskCmds []tea.Cmd
+	var hookCalls []hooks.Call
+	if m.vars.copyTasks {
+		// Unreadable tasks are reported when opening the source project.
+		tasks, _ := m.source.ReadTasksFromFS(m.listModel.config)
+		for _, task := range items.CopyOpenTasks(tasks) {
+			taskCmds = append(taskCmds, task.WriteTaskJSON(m.listModel.config, *m.project, "create"))
+			paths = append(paths, task.Path(*m.project))
+			hookCalls = append(hookCalls, task.HookCalls(*m.project, "create")...)
+		}
+	}
+
+	return tea.Sequence(
+		m.project.WriteProjectJSON(m.listModel.config, json, "create"),
+		tea.Batch(taskCmds...),
+		// The stats loaded after creating the project don't include the tasks yet.
+		items.LoadAllTaskStatsCmd(m.listModel.config, append(m.listModel.allProjects(), m.project)),
+		vcs.AfterCommitCmd(
+			vcs.CommitCmd(
+				m.listModel.config,
+				fmt.Sprintf("copy: %s (from %s, %d task(s))", m.project.Title, m.source.Title, len(paths)-1),
+				paths...,
+			),
+			hooks.RunCmd(m.listModel.config, hookCalls),
+		),
+	)
+}
+
 // View renders the project form UI.
 func (m projectFormModel) View() string {
 	if m.cancel {
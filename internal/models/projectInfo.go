@@ -0,0 +1,230 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package models
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/handlebargh/yatto/internal/items"
+	"github.com/handlebargh/yatto/internal/vcs"
+)
+
+// projectInfoKeyMap defines the key bindings used in the project info view.
+type projectInfoKeyMap struct {
+	quit       key.Binding
+	editReadme key.Binding
+}
+
+// newProjectInfoKeyMap initializes and returns a new key map for project info actions.
+func newProjectInfoKeyMap() *projectInfoKeyMap {
+	return &projectInfoKeyMap{
+		quit: key.NewBinding(
+			key.WithKeys("q", "esc", "h"),
+			key.WithHelp("q/esc", "go back"),
+		),
+		editReadme: key.NewBinding(
+			key.WithKeys("e"),
+			key.WithHelp("e", "edit README"),
+		),
+	}
+}
+
+// projectInfoModel represents the Bubble Tea model for the project info
+// view. It shows the project's description and task stats followed by
+// the README in the project directory, see items.ReadmeFile.
+type projectInfoModel struct {
+	projectModel *ProjectListModel
+	project      *items.Project
+	keys         *projectInfoKeyMap
+	readme       string
+	content      string
+	ready        bool
+	viewport     viewport.Model
+	status       string
+}
+
+// newProjectInfoModel creates a new projectInfoModel for the given project.
+func newProjectInfoModel(project *items.Project, projectModel *ProjectListModel) projectInfoModel {
+	m := projectInfoModel{
+		projectModel: projectModel,
+		project:      project,
+		keys:         newProjectInfoKeyMap(),
+	}
+
+	stats, err := project.CachedStats(projectModel.config)
+	if err != nil {
+		m.status = errorStatus(err)
+	}
+
+	m.readme, err = project.Readme(projectModel.config)
+	if err != nil {
+		m.status = errorStatus(err)
+	}
+
+	m.content = project.ProjectToMarkdown(stats, m.readme)
+
+	return m
+}
+
+// Init initializes the projectInfoModel and returns an initial command.
+func (m projectInfoModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles incoming messages and updates the projectInfoModel accordingly.
+func (m projectInfoModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if msg.Type == tea.KeyCtrlC {
+			return m, tea.Quit
+		}
+
+		switch {
+		case key.Matches(msg, m.keys.quit):
+			return m.projectModel, func() tea.Msg { return returnedToProjectListMsg{} }
+
+		case key.Matches(msg, m.keys.editReadme):
+			return m, editDescriptionCmd(m.readme)
+		}
+
+	case editorFinishedMsg:
+		return m.saveReadme(msg)
+
+	case items.WriteReadmeErrorMsg:
+		m.status = errorStatus(msg.Err)
+		return m, nil
+
+	case vcs.CommitDoneMsg:
+		m.status = "🗘  Changes committed"
+		return m, nil
+
+	case vcs.PushQueuedMsg:
+		m.status = unpushedStatus(msg.Unpushed)
+		return m, nil
+
+	case vcs.CommitErrorMsg:
+		m.status = errorStatus(msg.Err)
+		return m, nil
+
+	case tea.WindowSizeMsg:
+		footerHeight := lipgloss.Height(m.footerView())
+
+		if !m.ready {
+			m.viewport = viewport.New(msg.Width, msg.Height-footerHeight)
+			m.viewport.SetContent(m.render())
+			m.ready = true
+		} else {
+			resized := m.viewport.Width != msg.Width
+			m.viewport.Width = msg.Width
+			m.viewport.Height = msg.Height - footerHeight
+
+			// Rewrap the markdown for the new width.
+			if resized {
+				m.viewport.SetContent(m.render())
+			}
+		}
+	}
+
+	m.viewport, cmd = m.viewport.Update(msg)
+
+	return m, cmd
+}
+
+// saveReadme writes and commits the README edited in the user's editor
+// and shows the updated project info. An unchanged README is not saved.
+func (m projectInfoModel) saveReadme(msg editorFinishedMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.status = errorStatus(fmt.Errorf("could not edit README: %w", msg.err))
+		return m, nil
+	}
+
+	if msg.content == m.readme {
+		return m, nil
+	}
+
+	config := m.projectModel.config
+	cmd := tea.Sequence(
+		m.project.WriteReadme(config, msg.content),
+		vcs.CommitCmd(config, fmt.Sprintf("update README: %s", m.project.Title), m.project.ReadmePath()),
+	)
+
+	stats, _ := m.project.CachedStats(config)
+	m.readme = msg.content
+	if strings.TrimSpace(m.readme) == "" {
+		m.readme = ""
+	}
+	m.content = m.project.ProjectToMarkdown(stats, m.readme)
+	m.status = "🗸  README saved ― committing changes"
+	if m.ready {
+		m.viewport.SetContent(m.render())
+	}
+
+	return m, cmd
+}
+
+// render returns the project info rendered for the terminal. The
+// markdown is shown as is as long as the renderer is not ready.
+func (m projectInfoModel) render() string {
+	renderer := m.projectModel.state.markdownRenderer(m.viewport.Width)
+	if renderer == nil {
+		return m.content
+	}
+
+	rendered, err := renderer.Render(m.content)
+	if err != nil {
+		return "Error rendering markdown"
+	}
+
+	return rendered
+}
+
+// View returns the string representation of the project info view.
+func (m projectInfoModel) View() string {
+	if !m.ready {
+		return "\n  Initializing..."
+	}
+	return fmt.Sprintf("%s\n%s", m.viewport.View(), m.footerView())
+}
+
+// footerView returns the string representation of the project info
+// view's footer with the latest status message on the left.
+func (m projectInfoModel) footerView() string {
+	info := lipgloss.NewStyle().
+		Padding(0, 1).
+		Render(fmt.Sprintf("%s %s • %s %s  %3.f%%",
+			m.keys.editReadme.Help().Key,
+			m.keys.editReadme.Help().Desc,
+			m.keys.quit.Help().Key,
+			m.keys.quit.Help().Desc,
+			m.viewport.ScrollPercent()*100,
+		))
+
+	status := lipgloss.NewStyle().Padding(0, 1).Render(m.status)
+	line := strings.Repeat(" ", max(0, m.viewport.Width-lipgloss.Width(status)-lipgloss.Width(info)))
+	return lipgloss.JoinHorizontal(lipgloss.Center, status, line, info)
+}
@@ -21,8 +21,11 @@
 package models
 
 import (
+	"errors"
 	"fmt"
 	"io"
+	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
@@ -35,8 +38,11 @@ import (
 	"github.com/charmbracelet/lipgloss"
 	"github.com/google/uuid"
 	"github.com/handlebargh/yatto/internal/colors"
+	"github.com/handlebargh/yatto/internal/config"
 	"github.com/handlebargh/yatto/internal/helpers"
+	"github.com/handlebargh/yatto/internal/hooks"
 	"github.com/handlebargh/yatto/internal/items"
+	"github.com/handlebargh/yatto/internal/storage"
 	"github.com/handlebargh/yatto/internal/vcs"
 	"github.com/spf13/viper"
 )
@@ -55,6 +61,21 @@ type projectListKeyMap struct {
 	prevPage       key.Binding
 	nextPage       key.Binding
 	toggleSelect   key.Binding
+	undo           key.Binding
+	showAgenda     key.Binding
+	showDashboard  key.Binding
+	review         key.Binding
+	focus          key.Binding
+	showHistory    key.Binding
+	search         key.Binding
+	settings       key.Binding
+	showTemplates  key.Binding
+	copyProject    key.Binding
+	pinProject     key.Binding
+	moveUp         key.Binding
+	moveDown       key.Binding
+	showBurndown   key.Binding
+	showInfo       key.Binding
 }
 
 // newProjectListKeyMap returns a new set of key
@@ -81,13 +102,29 @@ func newProjectListKeyMap() *projectListKeyMap {
 			key.WithKeys("e"),
 			key.WithHelp("e", "edit project"),
 		),
+		copyProject: key.NewBinding(
+			key.WithKeys("c"),
+			key.WithHelp("c", "copy project"),
+		),
+		pinProject: key.NewBinding(
+			key.WithKeys("p"),
+			key.WithHelp("p", "pin/unpin project"),
+		),
+		moveUp: key.NewBinding(
+			key.WithKeys("K"),
+			key.WithHelp("K", "move project up"),
+		),
+		moveDown: key.NewBinding(
+			key.WithKeys("J"),
+			key.WithHelp("J", "move project down"),
+		),
 		toggleHelpMenu: key.NewBinding(
 			key.WithKeys("H"),
 			key.WithHelp("H", "toggle help"),
 		),
 		prevPage: key.NewBinding(
-			key.WithKeys("left", "pgup", "b", "u"),
-			key.WithHelp("←/pgup/b/u", "prev page"),
+			key.WithKeys("left", "pgup", "b"),
+			key.WithHelp("←/pgup/b", "prev page"),
 		),
 		nextPage: key.NewBinding(
 			key.WithKeys("right", "pgdown", "f", "d"),
@@ -97,6 +134,50 @@ func newProjectListKeyMap() *projectListKeyMap {
 			key.WithKeys(" "),
 			key.WithHelp("space", "select/deselect"),
 		),
+		undo: key.NewBinding(
+			key.WithKeys("u"),
+			key.WithHelp("u", "undo last change"),
+		),
+		showAgenda: key.NewBinding(
+			key.WithKeys("A"),
+			key.WithHelp("A", "show agenda"),
+		),
+		showDashboard: key.NewBinding(
+			key.WithKeys("t"),
+			key.WithHelp("t", "show today dashboard"),
+		),
+		review: key.NewBinding(
+			key.WithKeys("R"),
+			key.WithHelp("R", "review open tasks"),
+		),
+		focus: key.NewBinding(
+			key.WithKeys("F"),
+			key.WithHelp("F", "focus on tasks in progress"),
+		),
+		showHistory: key.NewBinding(
+			key.WithKeys("L"),
+			key.WithHelp("L", "show history"),
+		),
+		showBurndown: key.NewBinding(
+			key.WithKeys("B"),
+			key.WithHelp("B", "show burndown chart"),
+		),
+		showInfo: key.NewBinding(
+			key.WithKeys("i"),
+			key.WithHelp("i", "show project info and README"),
+		),
+		search: key.NewBinding(
+			key.WithKeys("s"),
+			key.WithHelp("s", "search all tasks"),
+		),
+		settings: key.NewBinding(
+			key.WithKeys(","),
+			key.WithHelp(",", "settings"),
+		),
+		showTemplates: key.NewBinding(
+			key.WithKeys("T"),
+			key.WithHelp("T", "task templates"),
+		),
 	}
 }
 
@@ -106,16 +187,12 @@ func newProjectListKeyMap() *projectListKeyMap {
 // is sent back to the update loop via a rendererReadyMsg.
 func initRendererCmd() tea.Cmd {
 	return func() tea.Msg {
-		isDark := lipgloss.HasDarkBackground()
-		style := "dark"
-		if !isDark {
-			style = "light"
-		}
-		renderer, err := glamour.NewTermRenderer(glamour.WithStylePath(style))
+		style := markdownStyle()
+		renderer, err := newMarkdownRenderer(style, defaultMarkdownWidth)
 		if err != nil {
 			panic(err)
 		}
-		return rendererReadyMsg{renderer: renderer}
+		return rendererReadyMsg{renderer: renderer, style: style}
 	}
 }
 
@@ -123,6 +200,83 @@ func initRendererCmd() tea.Cmd {
 // successfully initialized and is ready for use.
 type rendererReadyMsg struct {
 	renderer *glamour.TermRenderer
+	style    string
+}
+
+const (
+	// defaultMarkdownWidth is the width markdown is wrapped at
+	// until the width of the terminal is known.
+	defaultMarkdownWidth = 80
+
+	// maxMarkdownWidth is the maximum width markdown is wrapped at,
+	// so that text stays readable on wide terminals.
+	maxMarkdownWidth = 120
+)
+
+// markdownStyle returns the glamour style matching the background of
+// the terminal. If colors are disabled, see colors.NoColor, the ASCII
+// style without colors is returned.
+func markdownStyle() string {
+	switch {
+	case colors.NoColor():
+		return "ascii"
+	case lipgloss.HasDarkBackground():
+		return "dark"
+	default:
+		return "light"
+	}
+}
+
+// newMarkdownRenderer returns a glamour renderer using the given
+// style that wraps text at the given width.
+func newMarkdownRenderer(style string, width int) (*glamour.TermRenderer, error) {
+	return glamour.NewTermRenderer(glamour.WithStylePath(style), glamour.WithWordWrap(width))
+}
+
+// markdownRenderer returns the shared markdown renderer, wrapping text
+// at the given terminal width. Creating a renderer is expensive, so it
+// is only replaced when the width changed, e.g. after a resize.
+// It returns nil as long as the renderer is not ready.
+func (s *projectListState) markdownRenderer(width int) *glamour.TermRenderer {
+	width = min(width, maxMarkdownWidth)
+	if s.renderer == nil || width <= 0 || width == s.rendererWidth {
+		return s.renderer
+	}
+
+	renderer, err := newMarkdownRenderer(s.rendererStyle, width)
+	if err != nil {
+		return s.renderer
+	}
+
+	s.renderer = renderer
+	s.rendererWidth = width
+
+	return renderer
+}
+
+// currentUser returns the identity of the current user. The backends
+// run a command to look it up, so the result is kept for the session
+// and only resolved again when the storage path or backend changes.
+func (m *ProjectListModel) currentUser() string {
+	cfg := config.Get(m.config)
+
+	key := cfg.StoragePath + "\x00" + cfg.VCSBackend
+	if key == m.state.userKey {
+		return m.state.user
+	}
+
+	// A failed lookup is kept as well, it would fail again on every frame.
+	m.state.user, _ = vcs.User(m.config)
+	m.state.userKey = key
+
+	return m.state.user
+}
+
+// deleteProtected reports whether deleting the task needs to be confirmed
+// once more because "author.protect_delete" is set and the task was
+// neither created by nor assigned to the current user.
+func (m *ProjectListModel) deleteProtected(t *items.Task) bool {
+	return config.Get(m.config).AuthorProtectDelete && !t.OwnedBy(m.currentUser())
 }
 
 // projectListState holds shared mutable state that must remain consistent
@@ -132,6 +286,57 @@ type projectListState struct {
 	taskStats     map[string]items.TaskStats
 	selectedItems map[string]*items.Project
 	renderer      *glamour.TermRenderer
+	rendererStyle string
+	rendererWidth int
+
+	// The current user, see currentUser.
+	user    string
+	userKey string
+
+	// Background sync state, see backgroundSync.go.
+	syncing  bool
+	nextSync time.Time
+
+	// Sync information of the status bar, see loadSyncStatus.
+	unpushed int
+	lastSync time.Time
+
+	// Preview pane of the task lists, see preview.go.
+	showPreview    bool
+	previewPercent int
+
+	// Display settings that can change while running, see applyConfig.
+	showAuthor   bool
+	showAssignee bool
+	compact      bool
+	due          items.DueSettings
+}
+
+// projectItemStyles holds the styles of the items in the project list.
+// Like the progress bars, they are built by applyStyles rather than in
+// every call of customProjectDelegate.Render.
+type projectItemStyles struct {
+	marker    string
+	title     lipgloss.Style
+	desc      lipgloss.Style
+	info      lipgloss.Style
+	completed lipgloss.Style
+	due       lipgloss.Style
+}
+
+// newProjectItemStyles builds the styles of the project list items
+// from the current color palette.
+func newProjectItemStyles() projectItemStyles {
+	return projectItemStyles{
+		marker: lipgloss.NewStyle().
+			Foreground(colors.Red()).
+			Render("⟹  "),
+		title:     lipgloss.NewStyle().Padding(0, 1),
+		desc:      lipgloss.NewStyle().Padding(0, 1).Height(2),
+		info:      lipgloss.NewStyle().Width(40),
+		completed: lipgloss.NewStyle().Foreground(colors.Green()),
+		due:       lipgloss.NewStyle().Foreground(colors.Red()),
+	}
 }
 
 // customProjectDelegate implements a custom
@@ -166,29 +371,25 @@ func (d customProjectDelegate) Render(w io.Writer, m list.Model, index int, item
 	// Check if item is selected
 	_, selected := d.parent.state.selectedItems[projectItem.ID]
 
+	styles := &d.parent.itemStyles
+
 	marker := ""
 	indent := 0
 	if selected {
-		marker = lipgloss.NewStyle().
-			Foreground(colors.Red()).
-			Render("⟹  ")
+		marker = styles.marker
 		indent = 3
 	}
 
 	// Base styles.
-	listTitleStyle := lipgloss.NewStyle().
+	listTitleStyle := styles.title.
 		Foreground(color).
-		Padding(0, 1).
 		Width(leftWidth - indent)
 
-	listDescStyle := lipgloss.NewStyle().
-		Padding(0, 1).
+	listDescStyle := styles.desc.
 		MarginLeft(indent).
-		Width(leftWidth - indent).
-		Height(2)
+		Width(leftWidth - indent)
 
-	listItemInfoStyle := lipgloss.NewStyle().
-		Width(40)
+	listItemInfoStyle := styles.info
 
 	if index == m.GlobalIndex() {
 		listTitleStyle = listTitleStyle.
@@ -206,7 +407,11 @@ func (d customProjectDelegate) Render(w io.Writer, m list.Model, index int, item
 	var left strings.Builder
 
 	left.WriteString(marker)
-	left.WriteString(listTitleStyle.Render(projectItem.Title))
+	title := projectItem.DisplayTitle()
+	if projectItem.Pinned {
+		title = "★ " + title
+	}
+	left.WriteString(listTitleStyle.Render(title))
 	left.WriteString("\n")
 	left.WriteString(listDescStyle.Render(projectItem.CropDescription(projectDescLength)))
 
@@ -240,29 +445,25 @@ func (d customProjectDelegate) Render(w io.Writer, m list.Model, index int, item
 		taskTotalCompleteMessage = fmt.Sprintf("%d/%d tasks completed", numCompletedTasks, numTasks)
 		if numCompletedTasks == numTasks {
 			if numCompletedTasks == 1 {
-				taskTotalCompleteMessage = lipgloss.NewStyle().
-					Foreground(colors.Green()).
-					Render("1 task completed")
+				taskTotalCompleteMessage = styles.completed.Render("1 task completed")
 			} else {
-				taskTotalCompleteMessage = lipgloss.NewStyle().
-					Foreground(colors.Green()).
-					Render(fmt.Sprintf("%d tasks completed", numCompletedTasks))
+				taskTotalCompleteMessage = styles.completed.Render(fmt.Sprintf("%d tasks completed", numCompletedTasks))
 			}
 		}
 	} else {
 		taskTotalCompleteMessage = "Empty project"
 	}
 
+	if stats.Estimate > 0 {
+		taskTotalCompleteMessage += " • " + items.Estimate(stats.Estimate).String() + " left"
+	}
+
 	var taskDueMessage string
 	if numDueTasks > 0 {
 		if numDueTasks == 1 {
-			taskDueMessage = lipgloss.NewStyle().
-				Foreground(colors.Red()).
-				Render("1 task due today")
+			taskDueMessage = styles.due.Render("1 task due today")
 		} else {
-			taskDueMessage = lipgloss.NewStyle().
-				Foreground(colors.Red()).
-				Render(fmt.Sprintf("%d tasks due today", numDueTasks))
+			taskDueMessage = styles.due.Render(fmt.Sprintf("%d tasks due today", numDueTasks))
 		}
 	}
 
@@ -298,27 +499,31 @@ type ProjectListModel struct {
 	selected      bool
 	keys          *projectListKeyMap
 	mode          mode
-	cmdOutput     string
+	backendErr    *backendError
 	err           error
 	spinner       spinner.Model
 	spinning      bool
 	status        string
 	width, height int
 	state         *projectListState
+	lastClick     lastClick
 
 	progressRed    progress.Model
 	progressOrange progress.Model
 	progressYellow progress.Model
 	progressGreen  progress.Model
+	itemStyles     projectItemStyles
 }
 
 // InitialProjectListModel returns an initialized projectListModel
 // with all necessary state and UI settings.
 func InitialProjectListModel(v *viper.Viper) ProjectListModel {
+	cfg := config.Get(v)
+
 	listKeys := newProjectListKeyMap()
 
 	// Read all projects from FS to populate project list.
-	projects := helpers.ReadProjectsFromFS(v)
+	projects, readErr := helpers.ReadProjectsFromFS(v)
 	var listItems []list.Item
 
 	for _, project := range projects {
@@ -327,7 +532,6 @@ func InitialProjectListModel(v *viper.Viper) ProjectListModel {
 
 	sp := spinner.New()
 	sp.Spinner = spinner.Dot
-	sp.Style = lipgloss.NewStyle().Foreground(colors.Orange())
 
 	m := ProjectListModel{
 		config:   v,
@@ -335,27 +539,26 @@ func InitialProjectListModel(v *viper.Viper) ProjectListModel {
 		spinner:  sp,
 		spinning: false,
 		state: &projectListState{
-			taskStats:     make(map[string]items.TaskStats),
-			selectedItems: make(map[string]*items.Project),
+			taskStats:      make(map[string]items.TaskStats),
+			selectedItems:  make(map[string]*items.Project),
+			showAuthor:     cfg.AuthorShow,
+			showAssignee:   cfg.AssigneeShow,
+			compact:        cfg.ListCompact,
+			due:            items.DueSettingsFromConfig(v),
+			previewPercent: defaultPreviewPercent,
 		},
 	}
 
-	itemList := list.New(
-		listItems,
-		customProjectDelegate{DefaultDelegate: list.NewDefaultDelegate(), parent: &m},
-		0,
-		0,
-	)
+	itemList := list.New(listItems, list.NewDefaultDelegate(), 0, 0)
 	itemList.SetShowPagination(true)
 	itemList.SetShowTitle(true)
 	itemList.SetShowStatusBar(true)
 	itemList.SetStatusBarItemName("project", "projects")
 	itemList.StatusMessageLifetime = 3 * time.Second
 	itemList.Title = "Projects"
-	itemList.Styles.Title = lipgloss.NewStyle().
-		Foreground(colors.BadgeText()).
-		Background(colors.Green()).
-		Padding(0, 1)
+	if profile := v.GetString(config.ProfileKey); profile != "" {
+		itemList.Title += " · " + profile
+	}
 	// Disable the quit keybindings, so we can implement our own.
 	itemList.DisableQuitKeybindings()
 	// Set our own prev/next page keys.
@@ -372,19 +575,66 @@ func InitialProjectListModel(v *viper.Viper) ProjectListModel {
 			listKeys.chooseProject,
 			listKeys.addProject,
 			listKeys.editProject,
+			listKeys.copyProject,
 			listKeys.deleteProject,
+			listKeys.pinProject,
+			listKeys.moveUp,
+			listKeys.moveDown,
 			listKeys.toggleSelect,
+			listKeys.showAgenda,
+			listKeys.showDashboard,
+			listKeys.review,
+			listKeys.focus,
+			listKeys.showHistory,
+			listKeys.showBurndown,
+			listKeys.showInfo,
+			listKeys.search,
+			listKeys.showTemplates,
+			listKeys.settings,
+			listKeys.undo,
 		}
 	}
 
 	m.list = itemList
+	m.applyStyles()
+	m.state.loadSyncStatus(v)
+
+	if readErr != nil {
+		m.mode = modeStorageError
+		m.err = readErr
+	}
+
+	return m
+}
+
+// applyStyles (re)builds all colored styles of the model
+// from the current color palette.
+func (m *ProjectListModel) applyStyles() {
+	m.spinner.Style = lipgloss.NewStyle().Foreground(colors.Orange())
+	m.list.Styles.Title = lipgloss.NewStyle().
+		Foreground(colors.BadgeText()).
+		Background(colors.Green()).
+		Padding(0, 1)
 
 	m.progressRed = progress.New(progress.WithSolidFill(colors.Red().Dark), progress.WithWidth(30))
 	m.progressOrange = progress.New(progress.WithSolidFill(colors.Orange().Dark), progress.WithWidth(30))
 	m.progressYellow = progress.New(progress.WithSolidFill(colors.Yellow().Dark), progress.WithWidth(30))
 	m.progressGreen = progress.New(progress.WithSolidFill(colors.Green().Dark), progress.WithWidth(30))
+	m.itemStyles = newProjectItemStyles()
 
-	return m
+	// The delegate keeps its own copy of the model,
+	// so it has to be replaced to pick up the new progress bars.
+	m.list.SetDelegate(customProjectDelegate{DefaultDelegate: list.NewDefaultDelegate(), parent: m})
+}
+
+// applyConfig applies a reloaded configuration to the running model.
+// The color palette itself is swapped by the caller.
+func (m *ProjectListModel) applyConfig(cfg *config.Config) {
+	m.state.showAuthor = cfg.AuthorShow
+	m.state.showAssignee = cfg.AssigneeShow
+	m.state.compact = cfg.ListCompact
+	m.state.due = items.DueSettings{Soon: cfg.DueSoon, AllDay: cfg.DueAllDay}
+	m.applyStyles()
 }
 
 // Init initializes the Bubble Tea program
@@ -395,9 +645,59 @@ func (m ProjectListModel) Init() tea.Cmd {
 		vcs.InitCmd(m.config),
 		items.LoadAllTaskStatsCmd(m.config, projects),
 		initRendererCmd(),
+		m.scheduleSync(),
+		m.unpushedStatusCmd(),
+		m.conflictCmd(),
+		notifyOverdueCmd(m.config),
 	)
 }
 
+// conflictCmd returns a command that reports a pull of a previous run
+// that stopped because of conflicts, so that these can be resolved.
+func (m ProjectListModel) conflictCmd() tea.Cmd {
+	if !vcs.ConflictInProgress(m.config) {
+		return nil
+	}
+
+	return func() tea.Msg {
+		return vcs.PullErrorMsg{Err: vcs.ErrConflictInProgress}
+	}
+}
+
+// unpushedCommitsMsg reminds of commits that could not be pushed
+// during a previous run.
+type unpushedCommitsMsg struct {
+	n int
+}
+
+// unpushedStatusCmd returns a command that sends an unpushedCommitsMsg
+// if there are commits that have not been pushed yet.
+func (m ProjectListModel) unpushedStatusCmd() tea.Cmd {
+	if !vcs.RemoteEnabled(m.config) {
+		return nil
+	}
+
+	v := m.config
+
+	return func() tea.Msg {
+		if n := storage.UnpushedCommits(v); n > 0 {
+			return unpushedCommitsMsg{n: n}
+		}
+		return nil
+	}
+}
+
+// notifyOverdueCmd returns a command that runs the hooks of the
+// tasks that became overdue since the last check.
+func notifyOverdueCmd(v *viper.Viper) tea.Cmd {
+	return func() tea.Msg {
+		if err := items.NotifyOverdue(v, time.Now()); err != nil {
+			return hooks.ErrorMsg{Err: err}
+		}
+		return nil
+	}
+}
+
 // Update handles incoming messages and updates
 // the project list model state accordingly.
 func (m ProjectListModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
@@ -413,6 +713,8 @@ func (m ProjectListModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case rendererReadyMsg:
 		m.state.renderer = msg.renderer
+		m.state.rendererStyle = msg.style
+		m.state.rendererWidth = defaultMarkdownWidth
 		return m, nil
 
 	case doneWaitingMsg:
@@ -420,53 +722,161 @@ func (m ProjectListModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case returnedToProjectListMsg:
-		return m, items.LoadAllTaskStatsCmd(m.config, m.allProjects())
+		m.state.loadSyncStatus(m.config)
+		// The list has not been sized yet if a restored session
+		// started in a task list, see RestoreSession.
+		cmds := []tea.Cmd{items.LoadAllTaskStatsCmd(m.config, m.allProjects()), tea.WindowSize()}
+		// Merge the branch of the project that was left.
+		if vcs.ProjectBranches(m.config) {
+			cmds = append(cmds, tea.Sequence(vcs.FlushCmd(m.config), vcs.LeaveProjectBranchCmd(m.config)))
+		}
+		return m, tea.Batch(cmds...)
+
+	case unpushedCommitsMsg:
+		return m, m.list.NewStatusMessage(unpushedStatus(msg.n))
+
+	case hooks.ErrorMsg:
+		reason, _, _ := strings.Cut(msg.Err.Error(), "\n")
+		return m, m.list.NewStatusMessage(lipgloss.NewStyle().
+			Foreground(colors.Red()).
+			Render("✘ " + reason))
+
+	case syncTickMsg:
+		return m, m.handleSyncTick(m.spinning)
+
+	case syncDoneMsg:
+		// A conflict blocks all further syncs until it is resolved.
+		if result, ok := msg.result.(vcs.PullErrorMsg); ok && m.mode == modeNormal && vcs.ConflictInProgress(m.config) {
+			m.showBackendError(backendOpPull, result.CmdOutput, result.Err)
+		}
+
+		status, cmd := m.finishSync(msg)
+		if m.mode == modeNormal && !m.spinning && m.list.FilterState() != list.Filtering {
+			if feed := m.showChangeFeed(msg, &m); feed != nil {
+				return feed, tea.Batch(cmd, m.list.NewStatusMessage(status), tea.WindowSize())
+			}
+		}
+		return m, tea.Batch(cmd, m.list.NewStatusMessage(status))
+
+	case ConfigChangedMsg:
+		if msg.Err != nil {
+			return m, m.list.NewStatusMessage(configStatus(msg))
+		}
 
-	case vcs.InitDoneMsg:
+		m.applyConfig(msg.Config)
+		cmds := []tea.Cmd{m.list.NewStatusMessage(configStatus(msg))}
+		// The plain output mode may have been toggled.
+		if m.state.renderer != nil && (m.state.rendererStyle == "ascii") != colors.NoColor() {
+			cmds = append(cmds, initRendererCmd())
+		}
+		return m, tea.Batch(cmds...)
+
+	case storage.StorageChangedMsg:
+		if len(msg.Projects) > 0 {
+			items.InvalidateTaskStats(msg.Projects...)
+		}
+
+		// Don't change the selection while asking to delete it, and
+		// keep showing a failed pull, which may have left conflicts.
+		if m.mode == modeConfirmDelete || m.mode == modeBackendError {
+			return m, nil
+		}
+		return m, m.reloadProjects()
+
+	case vcs.InitDoneMsg, vcs.PullNoInitMsg:
+		m.spinning = false
 		return m, nil
 
 	case vcs.InitErrorMsg:
-		m.mode = 2
-		m.err = msg.Err
+		m.showBackendError(backendOpInit, "", msg.Err)
 		return m, nil
 
-	case vcs.CommitDoneMsg:
+	case vcs.PullDoneMsg, vcs.PushDoneMsg:
+		m.spinning = false
+		m.state.loadSyncStatus(m.config)
+		return m, tea.Batch(m.pulledChanges(), m.list.NewStatusMessage("⟳  Synced"))
+
+	case vcs.ConflictAbortedMsg:
+		m.state.loadSyncStatus(m.config)
+		return m, tea.Batch(m.pulledChanges(),
+			m.list.NewStatusMessage(unpushedStatus(storage.UnpushedCommits(m.config))))
+
+	case vcs.CommitDoneMsg, vcs.PushQueuedMsg:
+		m.state.loadSyncStatus(m.config)
+
+		status := "🗘  Changes committed"
+		if queued, ok := msg.(vcs.PushQueuedMsg); ok {
+			status = unpushedStatus(queued.Unpushed)
+		}
+
+		// Queued commits are made without the spinner
+		// and must not touch the current selection.
+		if !m.spinning {
+			return m, m.list.NewStatusMessage(status)
+		}
+
 		// Remove all map entries after successful commit.
 		for k := range m.state.selectedItems {
 			delete(m.state.selectedItems, k)
 		}
-		m.status = "🗘  Changes committed"
+		m.status = status
 
 		// Wait 1 second before fully stopping spinner
 		return m, tea.Tick(time.Second, func(time.Time) tea.Msg {
 			return doneWaitingMsg{}
 		})
 
+	case vcs.CommitQueuedMsg:
+		return m, nil
+
+	case vcs.BranchDoneMsg:
+		m.state.loadSyncStatus(m.config)
+		return m, nil
+
+	case vcs.BranchErrorMsg:
+		m.showBackendError(backendOpOther, msg.CmdOutput, msg.Err)
+		return m, nil
+
 	case vcs.CommitErrorMsg:
-		m.mode = modeBackendError
-		m.cmdOutput = msg.CmdOutput
-		m.err = msg.Err
+		m.showBackendError(backendOpOther, msg.CmdOutput, msg.Err)
 		m.spinning = false
 		return m, nil
 
 	case vcs.PullErrorMsg:
-		m.mode = modeBackendError
-		m.cmdOutput = msg.CmdOutput
-		m.err = msg.Err
+		m.showBackendError(backendOpPull, msg.CmdOutput, msg.Err)
 		m.spinning = false
 		return m, nil
 
 	case vcs.PushErrorMsg:
-		m.mode = modeBackendError
-		m.cmdOutput = msg.CmdOutput
-		m.err = msg.Err
+		m.showBackendError(backendOpPush, msg.CmdOutput, msg.Err)
+		m.spinning = false
+		return m, nil
+
+	case vcs.RevertDoneMsg:
+		m.status = "↶  Undone: " + msg.Subject
+		items.InvalidateTaskStats()
+
+		// Wait 1 second before fully stopping spinner
+		return m, tea.Batch(m.reloadProjects(), tea.Tick(time.Second, func(time.Time) tea.Msg {
+			return doneWaitingMsg{}
+		}))
+
+	case vcs.RevertErrorMsg:
 		m.spinning = false
+		if errors.Is(msg.Err, vcs.ErrorNothingToRevert) {
+			return m, m.list.NewStatusMessage(lipgloss.NewStyle().
+				Foreground(colors.Red()).
+				Render("Nothing to undo"))
+		}
+
+		m.showBackendError(backendOpOther, msg.CmdOutput, msg.Err)
 		return m, nil
 
 	case items.WriteProjectJSONDoneMsg:
 		switch msg.Kind {
 		case "create":
-			m.list.InsertItem(0, &msg.Project)
+			// New projects go right below the pinned ones.
+			m.list.InsertItem(m.pinnedCount(), &msg.Project)
 			m.status = "🗸  Project created ― committing changes"
 			return m, items.LoadAllTaskStatsCmd(m.config, m.allProjects())
 
@@ -477,23 +887,22 @@ func (m ProjectListModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m, nil
 
 	case items.WriteProjectJSONErrorMsg:
-		m.mode = 2
-		m.err = msg.Err
+		m.showBackendError(backendOpOther, "", msg.Err)
 		return m, nil
 
 	case items.ProjectDeleteDoneMsg:
-		for i, project := range m.state.selectedItems {
-			if idx := project.FindListIndexByID(m.list.Items()); idx >= 0 {
-				m.list.RemoveItem(idx)
-				delete(m.state.selectedItems, i)
-			}
+		// The selection may have been cleared meanwhile, e.g. by the
+		// commit of an earlier change, so remove the deleted project itself.
+		items.InvalidateTaskStats(msg.Project.ID)
+		if idx := msg.Project.FindListIndexByID(m.list.Items()); idx >= 0 {
+			m.list.RemoveItem(idx)
 		}
+		delete(m.state.selectedItems, msg.Project.ID)
 		m.status = "✘ Project(s) deleted ― committing changes"
 		return m, items.LoadAllTaskStatsCmd(m.config, m.allProjects())
 
 	case items.ProjectDeleteErrorMsg:
-		m.mode = 2
-		m.err = msg.Err
+		m.showBackendError(backendOpOther, "", msg.Err)
 		m.spinning = false
 		return m, nil
 
@@ -504,11 +913,37 @@ func (m ProjectListModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case items.TaskStatsErrorMsg:
 		return m, nil
 
+	case copiedToClipboardMsg:
+		status := fmt.Sprintf("🗸  Copied %s to clipboard", msg.what)
+		if msg.err != nil {
+			status = lipgloss.NewStyle().
+				Foreground(colors.Red()).
+				Render(fmt.Sprintf("Could not copy %s: %s", msg.what, msg.err))
+		}
+		if m.mode == modeBackendError {
+			m.backendErr.notice = status
+			return m, nil
+		}
+		return m, m.list.NewStatusMessage(status)
+
 	case tea.WindowSizeMsg:
 		h, v := appStyle.GetFrameSize()
-		m.list.SetSize(msg.Width-h, msg.Height-v)
+		m.list.SetSize(msg.Width-h, msg.Height-v-statusBarHeight)
 		m.width = msg.Width
 		m.height = msg.Height
+		cmds = append(cmds, m.resumeSync())
+
+	case tea.MouseMsg:
+		if m.mode != modeNormal || m.spinning {
+			return m, nil
+		}
+
+		delegate := customProjectDelegate{DefaultDelegate: list.NewDefaultDelegate(), parent: &m}
+		if handleListMouse(&m.list, delegate, &m.lastClick, msg) && m.list.SelectedItem() != nil {
+			listModel := newTaskListModel(m.list.SelectedItem().(*items.Project), &m, m.width, m.height)
+			return listModel, tea.WindowSize()
+		}
+		return m, nil
 
 	case tea.KeyMsg:
 		if msg.Type == tea.KeyCtrlC {
@@ -521,11 +956,14 @@ func (m ProjectListModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		switch m.mode {
 		case modeBackendError:
+			return m.handleBackendErrorKey(msg)
+
+		case modeStorageError:
 			switch msg.String() {
-			case "esc", "q":
+			case "esc", "q", "enter":
 				m.mode = modeNormal
-				return m, nil
 			}
+			return m, nil
 
 		case modeConfirmDelete:
 			switch msg.String() {
@@ -610,6 +1048,24 @@ func (m ProjectListModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					return formModel, tea.WindowSize()
 				}
 
+			case key.Matches(msg, m.keys.copyProject):
+				if m.list.SelectedItem() != nil {
+					formModel := newCopyProjectFormModel(m.list.SelectedItem().(*items.Project), &m)
+					return formModel, tea.WindowSize()
+				}
+
+			case key.Matches(msg, m.keys.pinProject):
+				m, cmds = m.togglePin()
+				return m, tea.Batch(cmds...)
+
+			case key.Matches(msg, m.keys.moveUp):
+				m, cmds = m.moveProject(-1)
+				return m, tea.Batch(cmds...)
+
+			case key.Matches(msg, m.keys.moveDown):
+				m, cmds = m.moveProject(1)
+				return m, tea.Batch(cmds...)
+
 			case key.Matches(msg, m.keys.addProject):
 				project := &items.Project{
 					ID:          uuid.NewString(),
@@ -619,6 +1075,55 @@ func (m ProjectListModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				formModel := newProjectFormModel(project, &m, false)
 				return formModel, tea.WindowSize()
 
+			case key.Matches(msg, m.keys.undo):
+				m.spinning = true
+				m.status = "↶  Undoing last change"
+				return m, tea.Batch(m.spinner.Tick, vcs.RevertLastCmd(m.config))
+
+			case key.Matches(msg, m.keys.showAgenda):
+				agendaModel := newAgendaModel(&m, m.width, m.height)
+				return agendaModel, tea.WindowSize()
+
+			case key.Matches(msg, m.keys.showDashboard):
+				dashboardModel := newDashboardModel(&m, m.width, m.height)
+				return dashboardModel, tea.WindowSize()
+
+			case key.Matches(msg, m.keys.review):
+				reviewModel := newReviewModel(&m, m.width, m.height)
+				return reviewModel, tea.WindowSize()
+
+			case key.Matches(msg, m.keys.focus):
+				focusModel := newFocusModel(&m, m.width, m.height)
+				return focusModel, tea.Batch(focusModel.Init(), tea.WindowSize())
+
+			case key.Matches(msg, m.keys.showHistory):
+				historyModel := newRepoHistoryModel(&m, m.width, m.height)
+				return historyModel, tea.Batch(historyModel.Init(), tea.WindowSize())
+
+			case key.Matches(msg, m.keys.showBurndown):
+				if m.list.SelectedItem() != nil {
+					burndownModel := newBurndownModel(m.list.SelectedItem().(*items.Project), &m, m.width, m.height)
+					return burndownModel, tea.Batch(burndownModel.Init(), tea.WindowSize())
+				}
+
+			case key.Matches(msg, m.keys.showInfo):
+				if m.list.SelectedItem() != nil {
+					infoModel := newProjectInfoModel(m.list.SelectedItem().(*items.Project), &m)
+					return infoModel, tea.WindowSize()
+				}
+
+			case key.Matches(msg, m.keys.search):
+				searchModel := newSearchModel(&m, m.width, m.height)
+				return searchModel, tea.Batch(searchModel.Init(), tea.WindowSize())
+
+			case key.Matches(msg, m.keys.showTemplates):
+				templatesModel := newTemplateListModel(&m, m.width, m.height)
+				return templatesModel, tea.WindowSize()
+
+			case key.Matches(msg, m.keys.settings):
+				settingsModel := newSettingsModel(&m)
+				return settingsModel, tea.Batch(settingsModel.Init(), tea.WindowSize())
+
 			case key.Matches(msg, m.keys.toggleSelect):
 				if m.list.SelectedItem() != nil {
 					p := m.list.SelectedItem().(*items.Project)
@@ -643,6 +1148,178 @@ func (m ProjectListModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, tea.Batch(cmds...)
 }
 
+// showBackendError switches to the error screen for a failed operation.
+func (m *ProjectListModel) showBackendError(op backendOp, output string, err error) {
+	m.mode = modeBackendError
+	m.backendErr = newBackendError(m.config, op, output, err)
+}
+
+// handleBackendErrorKey runs the action chosen in the error screen.
+func (m ProjectListModel) handleBackendErrorKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch m.backendErr.action(msg.String()) {
+	case backendActionClose:
+		m.mode = modeNormal
+
+	case backendActionRetry:
+		m.mode = modeNormal
+		m.spinning = true
+		m.status = "⟳  Retrying"
+		return m, tea.Batch(m.spinner.Tick, m.backendErr.retryCmd(m.config))
+
+	case backendActionSkip:
+		m.mode = modeNormal
+		m.state.loadSyncStatus(m.config)
+		return m, m.list.NewStatusMessage(m.backendErr.skip(m.config))
+
+	case backendActionResolve:
+		m.mode = modeNormal
+		resolverModel := newConflictResolverModel(m.config, m)
+		return resolverModel, tea.Batch(resolverModel.Init(), tea.WindowSize())
+
+	case backendActionCopy:
+		return m, copyToClipboardCmd("error", m.backendErr.report())
+	}
+
+	return m, nil
+}
+
+// reloadProjects replaces the list items with the projects currently
+// found in storage, e.g. after a change was undone, and refreshes
+// their task statistics.
+func (m *ProjectListModel) reloadProjects() tea.Cmd {
+	for k := range m.state.selectedItems {
+		delete(m.state.selectedItems, k)
+	}
+
+	projects, err := helpers.ReadProjectsFromFS(m.config)
+	if err != nil {
+		m.mode = modeStorageError
+		m.err = err
+	}
+
+	var listItems []list.Item
+	for _, project := range projects {
+		listItems = append(listItems, &project)
+	}
+
+	return tea.Batch(
+		m.list.SetItems(listItems),
+		items.LoadAllTaskStatsCmd(m.config, m.allProjects()),
+	)
+}
+
+// pinnedCount returns the number of pinned projects in the list.
+func (m ProjectListModel) pinnedCount() int {
+	n := 0
+	for _, p := range m.allProjects() {
+		if p.Pinned {
+			n++
+		}
+	}
+
+	return n
+}
+
+// moveProject moves the selected project up (negative offset) or down
+// (positive offset) in the list. Projects can't be moved past the pinned
+// ones or vice versa. The order currently shown becomes the manual order,
+// which is written to the projects and committed.
+func (m ProjectListModel) moveProject(offset int) (ProjectListModel, []tea.Cmd) {
+	if m.list.FilterState() != list.Unfiltered {
+		return m, []tea.Cmd{
+			m.list.NewStatusMessage(lipgloss.NewStyle().
+				Foreground(colors.Red()).
+				Render("Clear the filter to reorder projects")),
+		}
+	}
+
+	idx := m.list.Index()
+	target := idx + offset
+	projects := m.allProjects()
+	if m.list.SelectedItem() == nil || target < 0 || target >= len(projects) ||
+		projects[idx].Pinned != projects[target].Pinned {
+		return m, nil
+	}
+
+	projects[idx], projects[target] = projects[target], projects[idx]
+	moved := projects[target]
+
+	cmds := []tea.Cmd{m.setProjectOrder(projects)}
+	m.list.Select(target)
+
+	return m, append(cmds, m.writeOrderCmd(projects, nil, fmt.Sprintf("reorder: %s", moved.Title)))
+}
+
+// togglePin pins or unpins the selected project. A pinned project goes
+// to the end of the pinned projects, an unpinned one to the top of the
+// remaining projects.
+func (m ProjectListModel) togglePin() (ProjectListModel, []tea.Cmd) {
+	if m.list.FilterState() != list.Unfiltered {
+		return m, []tea.Cmd{
+			m.list.NewStatusMessage(lipgloss.NewStyle().
+				Foreground(colors.Red()).
+				Render("Clear the filter to pin projects")),
+		}
+	}
+
+	selected, ok := m.list.SelectedItem().(*items.Project)
+	if !ok {
+		return m, nil
+	}
+
+	projects := slices.DeleteFunc(m.allProjects(), func(p *items.Project) bool {
+		return p.ID == selected.ID
+	})
+	target := 0
+	for _, p := range projects {
+		if p.Pinned {
+			target++
+		}
+	}
+	projects = slices.Insert(projects, target, selected)
+
+	selected.Pinned = !selected.Pinned
+	message := fmt.Sprintf("pin: %s", selected.Title)
+	if !selected.Pinned {
+		message = fmt.Sprintf("unpin: %s", selected.Title)
+	}
+
+	cmds := []tea.Cmd{m.setProjectOrder(projects)}
+	m.list.Select(target)
+
+	return m, append(cmds, m.writeOrderCmd(projects, selected, message))
+}
+
+// setProjectOrder replaces the list items with the given projects.
+func (m *ProjectListModel) setProjectOrder(projects []*items.Project) tea.Cmd {
+	reordered := make([]list.Item, len(projects))
+	for i, p := range projects {
+		reordered[i] = p
+	}
+
+	return m.list.SetItems(reordered)
+}
+
+// writeOrderCmd renumbers the given projects and writes and commits
+// those whose order changed, plus the changed project if it isn't nil.
+func (m ProjectListModel) writeOrderCmd(projects []*items.Project, changed *items.Project, message string) tea.Cmd {
+	toWrite := items.RenumberProjects(projects)
+	if changed != nil && !slices.Contains(toWrite, changed) {
+		toWrite = append(toWrite, changed)
+	}
+
+	var writeCmds []tea.Cmd
+	var paths []string
+	for _, p := range toWrite {
+		writeCmds = append(writeCmds, p.WriteProjectJSON(m.config, p.MarshalProject(), "reorder"))
+		paths = append(paths, filepath.Join(p.ID, "project.json"))
+	}
+
+	writeCmds = append(writeCmds, vcs.QueueCommitCmd(m.config, message, paths...))
+
+	return tea.Sequence(writeCmds...)
+}
+
 // View renders the current UI state of the project list,
 // including list view, progress bar, and any status messages.
 func (m ProjectListModel) View() string {
@@ -670,19 +1347,16 @@ func (m ProjectListModel) View() string {
 		}
 	}
 
+	// Display storage error view
+	if m.mode == modeStorageError {
+		return centeredStyle.Render(storageErrorView(m.err))
+	}
+
 	// Display VCS error view
 	if m.mode == modeBackendError {
-		var e strings.Builder
-
-		e.WriteString("An error occurred during a backend operation:")
-		e.WriteString("\n\n")
-		e.WriteString(m.cmdOutput)
-		e.WriteString("\n\n")
-		e.WriteString("Please commit manually!")
-
-		return centeredStyle.Render(e.String())
+		return centeredStyle.Render(m.backendErr.view(m.width))
 	}
 
 	// Display list view.
-	return appStyle.Render(m.list.View())
+	return appStyle.Render(m.list.View() + "\n" + m.statusBar())
 }
@@ -0,0 +1,169 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package models
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/google/uuid"
+	"github.com/handlebargh/yatto/internal/colors"
+	"github.com/handlebargh/yatto/internal/helpers"
+	"github.com/handlebargh/yatto/internal/hooks"
+	"github.com/handlebargh/yatto/internal/items"
+	"github.com/handlebargh/yatto/internal/vcs"
+)
+
+// quickAddModel defines the Bubble Tea model for the one-line input
+// used to capture a task without going through the task form.
+type quickAddModel struct {
+	form          *huh.Form
+	listModel     *taskListModel
+	line          *string
+	width, height int
+	lg            *lipgloss.Renderer
+	styles        *Styles
+}
+
+// newQuickAddModel returns a quickAddModel creating tasks in the
+// project of the given task list. The list is shown again when the
+// input is left.
+func newQuickAddModel(listModel *taskListModel) quickAddModel {
+	line := ""
+
+	m := quickAddModel{
+		listModel: listModel,
+		line:      &line,
+		lg:        lipgloss.DefaultRenderer(),
+	}
+	m.styles = NewStyles(m.lg)
+
+	m.form = huh.NewForm(
+		huh.NewGroup(
+			huh.NewInput().
+				Title("New task:").
+				Description("!high/!medium/!low priority, @assignee, +label, due:fri").
+				Placeholder("Fix login bug !high @alice +auth due:fri").
+				Value(m.line).
+				Validate(func(str string) error {
+					_, err := helpers.ParseQuickAdd(str)
+					return err
+				}),
+		)).
+		WithWidth(80).
+		WithShowHelp(false).
+		WithShowErrors(false).
+		WithTheme(colors.FormTheme())
+
+	return m
+}
+
+// Init initializes the quick add model and returns the initial command to run.
+func (m quickAddModel) Init() tea.Cmd {
+	return m.form.Init()
+}
+
+// Update processes incoming messages and updates the model state accordingly.
+// Once a valid line is entered, the task is created and committed.
+func (m quickAddModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c":
+			return m, tea.Quit
+		case "esc":
+			return m.listModel, nil
+		}
+
+	case tea.WindowSizeMsg:
+		h, v := appStyle.GetFrameSize()
+		m.width = msg.Width - h
+		m.height = msg.Height - v
+	}
+
+	var cmds []tea.Cmd
+
+	form, cmd := m.form.Update(msg)
+	if f, ok := form.(*huh.Form); ok {
+		m.form = f
+		cmds = append(cmds, cmd)
+	}
+
+	if m.form.State == huh.StateCompleted {
+		// The line was validated by the form.
+		parsed, _ := helpers.ParseQuickAdd(*m.line)
+		config := m.listModel.projectModel.config
+		project := *m.listModel.project
+
+		task := &items.Task{
+			ID:       uuid.NewString(),
+			Title:    parsed.Title,
+			Priority: parsed.Priority,
+			Labels:   parsed.Labels,
+			DueDate:  parsed.DueDate,
+		}
+
+		task.Author = m.listModel.projectModel.currentUser()
+		if parsed.Assignee != "" {
+			contributors, _ := vcs.AllContributors(config)
+			task.Assignee = helpers.MatchContributor(parsed.Assignee, contributors)
+		}
+
+		write := task.WriteTaskJSON(config, project, "create")
+		hookCalls := task.HookCalls(project, "create")
+
+		m.listModel.spinning = true
+		m.listModel.status = ""
+		cmds = append(cmds,
+			m.listModel.spinner.Tick,
+			tea.Sequence(
+				write,
+				vcs.AfterCommitCmd(
+					vcs.CommitCmd(config, fmt.Sprintf("create: %s", task.Title), task.Path(project)),
+					hooks.RunCmd(config, hookCalls),
+				),
+			),
+		)
+
+		return m.listModel, tea.Batch(cmds...)
+	}
+
+	return m, tea.Batch(cmds...)
+}
+
+// View renders the quick add input.
+func (m quickAddModel) View() string {
+	v := strings.TrimSuffix(m.form.View(), "\n\n")
+	form := m.lg.NewStyle().Margin(1, 0).Render(v)
+
+	header := formBoundaryView(m.styles, m.width, "Quick add to "+m.listModel.project.Title, colors.Green())
+	if errs := m.form.Errors(); len(errs) > 0 {
+		header = formBoundaryView(m.styles, m.width, errs[0].Error(), colors.Red())
+	}
+
+	footer := formBoundaryView(m.styles, m.width,
+		m.form.Help().ShortHelpView(m.form.KeyBinds())+" • esc cancel", colors.Green())
+
+	return m.styles.Base.Render(header + "\n" + form + "\n\n" + footer)
+}
@@ -0,0 +1,361 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package models
+
+import (
+	"fmt"
+	"log/slog"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/cursor"
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/handlebargh/yatto/internal/colors"
+	"github.com/handlebargh/yatto/internal/items"
+	"github.com/handlebargh/yatto/internal/logging"
+	"github.com/handlebargh/yatto/internal/storage"
+	"github.com/spf13/viper"
+)
+
+// sessionModel is implemented by the models whose navigation state and
+// form input are saved if the TUI crashes, see recoveryModel.
+type sessionModel interface {
+	session() storage.Session
+}
+
+// recoveryState is shared by all copies of a recoveryModel, as View
+// cannot return a changed model.
+type recoveryState struct {
+	crashed       bool
+	reason        string
+	saveErr       error
+	width, height int
+}
+
+// recoveryModel wraps the model shown by the program. If updating or
+// rendering it panics, e.g. because of an unexpected task file, the
+// session is saved to the crash file, see storage.Session, and an error
+// screen is shown until a key is pressed. The session can be restored
+// on the next launch, see RestoreSession.
+type recoveryModel struct {
+	tea.Model
+	config *viper.Viper
+	state  *recoveryState
+}
+
+// newRecoveryModel wraps model in a recoveryModel.
+func newRecoveryModel(v *viper.Viper, model tea.Model) recoveryModel {
+	return recoveryModel{model, v, &recoveryState{}}
+}
+
+// Update passes msg on to the wrapped model and wraps the model it returns.
+// After a crash, the wrapped model is no longer updated.
+func (m recoveryModel) Update(msg tea.Msg) (next tea.Model, cmd tea.Cmd) {
+	logMsg(msg)
+
+	if msg, ok := msg.(tea.WindowSizeMsg); ok {
+		m.state.width = msg.Width
+		m.state.height = msg.Height
+	}
+
+	if m.state.crashed {
+		if _, ok := msg.(tea.KeyMsg); ok {
+			return m, tea.Quit
+		}
+		return m, nil
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			m.crash(r)
+			next, cmd = m, nil
+		}
+	}()
+
+	updated, cmd := m.Model.Update(msg)
+	m.Model = updated
+
+	return m, cmd
+}
+
+// View renders the wrapped model, or the error screen after a crash.
+func (m recoveryModel) View() (view string) {
+	if m.state.crashed {
+		return m.crashView()
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			m.crash(r)
+			view = m.crashView()
+		}
+	}()
+
+	return m.Model.View()
+}
+
+// crash saves the session of the wrapped model, the last one updated
+// without a panic, to the crash file.
+func (m recoveryModel) crash(r any) {
+	session := sessionOf(m.Model)
+	session.Time = time.Now()
+	session.Panic = fmt.Sprint(r)
+	session.Stack = string(debug.Stack())
+
+	m.state.crashed = true
+	m.state.reason = session.Panic
+	m.state.saveErr = storage.WriteCrash(m.config, session)
+
+	logging.Error("panic", "panic", session.Panic, "stack", session.Stack, "save_err", m.state.saveErr)
+}
+
+// logMsg logs the type of msg at the debug level to trace the message
+// flow. Frequent ticks and mouse motion are left out. Typed text is
+// never logged, only the names of special keys.
+func logMsg(msg tea.Msg) {
+	if !logging.Enabled(slog.LevelDebug) {
+		return
+	}
+
+	switch msg := msg.(type) {
+	case spinner.TickMsg, cursor.BlinkMsg, focusTickMsg, pomodoroTickMsg:
+		return
+	case tea.MouseMsg:
+		if msg.Action == tea.MouseActionMotion {
+			return
+		}
+	case tea.KeyMsg:
+		key := "text"
+		if msg.Type != tea.KeyRunes && msg.Type != tea.KeySpace {
+			key = msg.String()
+		}
+		logging.Debug("message", "type", fmt.Sprintf("%T", msg), "key", key)
+		return
+	case error:
+		logging.Debug("message", "type", fmt.Sprintf("%T", msg), "err", msg)
+		return
+	}
+
+	logging.Debug("message", "type", fmt.Sprintf("%T", msg))
+}
+
+// crashView renders the error screen shown after a crash.
+func (m recoveryModel) crashView() string {
+	var b strings.Builder
+
+	b.WriteString(lipgloss.NewStyle().
+		Bold(true).
+		Foreground(colors.Red()).
+		Render("yatto ran into an unexpected error and has to quit:"))
+	b.WriteString("\n\n")
+	b.WriteString(m.state.reason)
+	b.WriteString("\n\n")
+
+	if m.state.saveErr != nil {
+		b.WriteString("Your session could not be saved: " + m.state.saveErr.Error())
+	} else {
+		b.WriteString("Your session, including unsaved form input,\n" +
+			"was saved and can be restored on the next launch.")
+	}
+
+	b.WriteString("\n\n")
+	b.WriteString("Press any key to quit.")
+
+	return lipgloss.Place(m.state.width, m.state.height, lipgloss.Center, lipgloss.Center, b.String())
+}
+
+// sessionOf returns the session of model. As the model may be in
+// a broken state, a panic results in an empty session.
+func sessionOf(model tea.Model) (session storage.Session) {
+	defer func() {
+		if recover() != nil {
+			session = storage.Session{}
+		}
+	}()
+
+	if m, ok := model.(sessionModel); ok {
+		return m.session()
+	}
+
+	return session
+}
+
+// session returns the selected project.
+func (m ProjectListModel) session() storage.Session {
+	var session storage.Session
+	if project, ok := m.list.SelectedItem().(*items.Project); ok {
+		session.ProjectID = project.ID
+	}
+
+	return session
+}
+
+// session returns the project and the selected task.
+func (m taskListModel) session() storage.Session {
+	session := storage.Session{ProjectID: m.project.ID}
+	if task, ok := m.list.SelectedItem().(*items.Task); ok {
+		session.TaskID = task.ID
+	}
+
+	return session
+}
+
+// session returns the project and the task shown.
+func (m taskPagerModel) session() storage.Session {
+	return m.listModel.session()
+}
+
+// session returns the project and the selected task.
+func (m boardModel) session() storage.Session {
+	session := m.listModel.session()
+	if task := m.selectedTask(); task != nil {
+		session.TaskID = task.ID
+	}
+
+	return session
+}
+
+// session returns the project, the task and the input of the form.
+func (m taskFormModel) session() storage.Session {
+	return storage.Session{
+		ProjectID: m.listModel.project.ID,
+		TaskID:    m.task.ID,
+		Form:      "task",
+		Edit:      m.edit,
+		Input:     formInput(m.vars.fields()),
+	}
+}
+
+// session returns the project and the input of the form.
+func (m projectFormModel) session() storage.Session {
+	return storage.Session{
+		ProjectID: m.project.ID,
+		Form:      "project",
+		Edit:      m.edit,
+		Input:     formInput(m.vars.fields()),
+	}
+}
+
+// formInput returns the values of the given fields of a form.
+// Empty fields are left out.
+func formInput(fields map[string]*string) map[string]string {
+	input := make(map[string]string)
+	for name, value := range fields {
+		if *value != "" {
+			input[name] = *value
+		}
+	}
+
+	return input
+}
+
+// restoreFormInput sets the given fields of a form to the values of
+// input. Fields missing from input keep their value.
+func restoreFormInput(fields map[string]*string, input map[string]string) {
+	for name, value := range input {
+		if field, ok := fields[name]; ok {
+			*field = value
+		}
+	}
+}
+
+// restoredModel starts the program with a restored session. As the
+// project list may not be shown first, it is initialized as well.
+type restoredModel struct {
+	tea.Model
+	init tea.Cmd
+}
+
+// Init initializes the project list and the restored model.
+func (m restoredModel) Init() tea.Cmd {
+	return m.init
+}
+
+// Update passes msg on to the restored model, which replaces this one.
+func (m restoredModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	return m.Model.Update(msg)
+}
+
+// RestoreSession returns the model shown when the TUI starts with the
+// session saved by a crash: the project and the task open at that time,
+// and the form open at that time with its input. Projects and tasks that
+// no longer exist are left out. Its view honors the plain output mode,
+// see plainModel.
+func RestoreSession(v *viper.Viper, session storage.Session) tea.Model {
+	projectModel := InitialProjectListModel(v)
+	model, cmd := restoreSession(&projectModel, session)
+
+	return plainModel{newRecoveryModel(v, restoredModel{model, tea.Batch(projectModel.Init(), cmd)})}
+}
+
+// restoreSession opens the views of session on top of projectModel and
+// returns the view shown first and the command initializing the views.
+func restoreSession(projectModel *ProjectListModel, session storage.Session) (tea.Model, tea.Cmd) {
+	project := &items.Project{ID: session.ProjectID}
+	idx := project.FindListIndexByID(projectModel.list.Items())
+
+	if session.Form == "project" && (idx >= 0 || !session.Edit) {
+		if idx >= 0 {
+			project = projectModel.list.Items()[idx].(*items.Project)
+		}
+
+		formModel := newProjectFormModel(project, projectModel, session.Edit)
+		restoreFormInput(formModel.vars.fields(), session.Input)
+		formModel.form = formModel.newForm()
+
+		return formModel, formModel.Init()
+	}
+
+	if idx < 0 {
+		return projectModel, nil
+	}
+
+	projectModel.list.Select(idx)
+	project = projectModel.list.Items()[idx].(*items.Project)
+	listModel := newTaskListModel(project, projectModel, 0, 0)
+
+	task := &items.Task{ID: session.TaskID}
+	var selectCmd tea.Cmd
+	if session.TaskID != "" {
+		selectCmd = listModel.selectTask(*task)
+	}
+
+	if session.Form != "task" {
+		return listModel, selectCmd
+	}
+
+	if session.Edit {
+		selected, ok := listModel.list.SelectedItem().(*items.Task)
+		if !ok || selected.ID != session.TaskID {
+			return listModel, selectCmd
+		}
+		task = selected
+	}
+
+	vars := newTaskFormVars(task)
+	restoreFormInput(vars.fields(), session.Input)
+	formModel := newTaskFormModelWithVars(task, &listModel, session.Edit, vars)
+
+	return formModel, tea.Batch(selectCmd, formModel.Init())
+}
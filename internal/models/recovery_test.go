@@ -0,0 +1,123 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package models
+
+import (
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/handlebargh/yatto/internal/items"
+	"github.com/handlebargh/yatto/internal/storage"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// crashingModel panics on key presses, or on rendering if crashView is set.
+type crashingModel struct {
+	crashView bool
+}
+
+func (m crashingModel) Init() tea.Cmd { return nil }
+
+func (m crashingModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if _, ok := msg.(tea.KeyMsg); ok {
+		panic("unexpected task file")
+	}
+	return m, nil
+}
+
+func (m crashingModel) View() string {
+	if m.crashView {
+		panic("unexpected task file")
+	}
+	return "tasks"
+}
+
+func (m crashingModel) session() storage.Session {
+	return storage.Session{ProjectID: "project", Form: "task", Input: map[string]string{"title": "Draft"}}
+}
+
+func TestRecoveryModel(t *testing.T) {
+	v := viper.New()
+	v.Set("storage.path", t.TempDir())
+
+	m := newRecoveryModel(v, crashingModel{})
+	assert.Equal(t, "tasks", m.View())
+
+	next, cmd := m.Update(tea.WindowSizeMsg{Width: 80, Height: 24})
+	assert.Nil(t, cmd)
+
+	next, cmd = next.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Nil(t, cmd)
+	assert.Contains(t, next.View(), "unexpected task file")
+	assert.Contains(t, next.View(), "can be restored on the next launch")
+
+	session, err := storage.ReadCrash(v)
+	require.NoError(t, err)
+	require.NotNil(t, session)
+	assert.Equal(t, "unexpected task file", session.Panic)
+	assert.Equal(t, "project", session.ProjectID)
+	assert.Equal(t, map[string]string{"title": "Draft"}, session.Input)
+	assert.NotEmpty(t, session.Stack)
+
+	// Any key quits after a crash.
+	_, cmd = next.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.NotNil(t, cmd)
+	assert.IsType(t, tea.QuitMsg{}, cmd())
+}
+
+func TestRecoveryModelView(t *testing.T) {
+	v := viper.New()
+	v.Set("storage.path", t.TempDir())
+
+	m := newRecoveryModel(v, crashingModel{crashView: true})
+	assert.Contains(t, m.View(), "unexpected task file")
+
+	// The wrapped model is no longer updated.
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.NotNil(t, cmd)
+	assert.IsType(t, tea.QuitMsg{}, cmd())
+
+	session, err := storage.ReadCrash(v)
+	require.NoError(t, err)
+	assert.NotNil(t, session)
+}
+
+func TestFormInput(t *testing.T) {
+	vars := newTaskFormVars(&items.Task{Title: "Report", Priority: "high"})
+	vars.taskDescription = "Half-written"
+
+	input := formInput(vars.fields())
+	assert.Equal(t, map[string]string{
+		"title":       "Report",
+		"description": "Half-written",
+		"priority":    "high",
+	}, input)
+
+	restored := newTaskFormVars(&items.Task{Title: "Report"})
+	restoreFormInput(restored.fields(), map[string]string{
+		"description": "Half-written",
+		"unknown":     "ignored",
+	})
+	assert.Equal(t, "Report", restored.taskTitle)
+	assert.Equal(t, "Half-written", restored.taskDescription)
+}
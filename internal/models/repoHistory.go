@@ -0,0 +1,622 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package models
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/handlebargh/yatto/internal/colors"
+	"github.com/handlebargh/yatto/internal/helpers"
+	"github.com/handlebargh/yatto/internal/items"
+	"github.com/handlebargh/yatto/internal/storage"
+	"github.com/handlebargh/yatto/internal/vcs"
+	"github.com/spf13/viper"
+)
+
+// repoHistoryLimit is the maximum number of commits shown in the history view.
+const repoHistoryLimit = 200
+
+// repoHistoryMaxItems is the number of touched items listed per commit
+// before the remaining ones are summarized.
+const repoHistoryMaxItems = 5
+
+// repoHistoryKeyMap defines the key bindings used in the history view.
+type repoHistoryKeyMap struct {
+	quit         key.Binding
+	up           key.Binding
+	down         key.Binding
+	openSnapshot key.Binding
+}
+
+// newRepoHistoryKeyMap initializes and returns a new key map for history actions.
+func newRepoHistoryKeyMap() *repoHistoryKeyMap {
+	return &repoHistoryKeyMap{
+		quit: key.NewBinding(
+			key.WithKeys("q", "esc", "h"),
+			key.WithHelp("q/esc", "go back"),
+		),
+		up: key.NewBinding(
+			key.WithKeys("up", "k"),
+			key.WithHelp("↑/k", "up"),
+		),
+		down: key.NewBinding(
+			key.WithKeys("down", "j"),
+			key.WithHelp("↓/j", "down"),
+		),
+		openSnapshot: key.NewBinding(
+			key.WithKeys("enter", "l"),
+			key.WithHelp("enter/l", "view snapshot"),
+		),
+	}
+}
+
+// repoHistoryModel represents the Bubble Tea model for the history of
+// the storage repository. It lists the most recent commits along with
+// the projects and tasks each of them touched.
+type repoHistoryModel struct {
+	projectModel  *ProjectListModel
+	keys          *repoHistoryKeyMap
+	help          help.Model
+	entries       []vcs.LogEntry
+	titles        map[string]string
+	loading       bool
+	opening       bool
+	cmdOutput     string
+	err           error
+	warning       string
+	cursor        int
+	width, height int
+}
+
+// newRepoHistoryModel creates a new repoHistoryModel. The commits are
+// loaded asynchronously by the command returned from Init.
+func newRepoHistoryModel(projectModel *ProjectListModel, width, height int) repoHistoryModel {
+	h, v := appStyle.GetFrameSize()
+
+	return repoHistoryModel{
+		projectModel: projectModel,
+		keys:         newRepoHistoryKeyMap(),
+		help:         help.New(),
+		titles:       storageTitles(projectModel.config),
+		loading:      true,
+		width:        width - h,
+		height:       height - v,
+	}
+}
+
+// storageTitles maps the slash separated paths of all project, task and
+// template files currently in storage to their titles or template names.
+// Unreadable files are left out, as the titles are only used for display.
+func storageTitles(v *viper.Viper) map[string]string {
+	titles := make(map[string]string)
+
+	projects, _ := helpers.ReadProjectsFromFS(v)
+	for _, project := range projects {
+		titles[path.Join(project.ID, "project.json")] = project.Title
+
+		tasks, _ := project.ReadTasksFromFS(v)
+		archived, _ := project.ReadArchivedTasksFromFS(v)
+
+		for _, task := range append(tasks, archived...) {
+			titles[filepath.ToSlash(task.Path(project))] = task.Title
+		}
+	}
+
+	templates, _ := items.ReadTemplatesFromFS(v)
+	for _, template := range templates {
+		titles[filepath.ToSlash(template.Path())] = template.Name
+	}
+
+	return titles
+}
+
+// Init initializes the repoHistoryModel and starts loading the commits.
+func (m repoHistoryModel) Init() tea.Cmd {
+	return vcs.LogCmd(m.projectModel.config, repoHistoryLimit)
+}
+
+// Update handles incoming messages and updates the repoHistoryModel accordingly.
+func (m repoHistoryModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		h, v := appStyle.GetFrameSize()
+		m.width = msg.Width - h
+		m.height = msg.Height - v
+
+	case vcs.LogDoneMsg:
+		m.loading = false
+		m.entries = msg.Entries
+
+	case vcs.LogErrorMsg:
+		m.loading = false
+		m.cmdOutput = msg.CmdOutput
+		m.err = msg.Err
+
+	case vcs.SnapshotDoneMsg:
+		m.opening = false
+		if len(m.entries) == 0 || m.entries[m.cursor].Hash != msg.Hash {
+			return m, nil
+		}
+
+		snapshotModel := newRepoSnapshotModel(m, m.entries[m.cursor], msg.Files)
+		return snapshotModel, tea.WindowSize()
+
+	case vcs.SnapshotErrorMsg:
+		m.opening = false
+		m.warning = lipgloss.NewStyle().
+			Foreground(colors.Red()).
+			Render("⚠  Cannot read commit: " + msg.Error())
+
+	case tea.KeyMsg:
+		if msg.Type == tea.KeyCtrlC {
+			return m, tea.Quit
+		}
+
+		switch {
+		case key.Matches(msg, m.keys.quit):
+			return m.projectModel, func() tea.Msg { return returnedToProjectListMsg{} }
+
+		case key.Matches(msg, m.keys.up):
+			if m.cursor > 0 {
+				m.cursor--
+			}
+
+		case key.Matches(msg, m.keys.down):
+			if m.cursor < len(m.entries)-1 {
+				m.cursor++
+			}
+
+		case key.Matches(msg, m.keys.openSnapshot):
+			if len(m.entries) == 0 || m.opening {
+				return m, nil
+			}
+
+			m.opening = true
+			m.warning = ""
+			return m, vcs.SnapshotCmd(m.projectModel.config, m.entries[m.cursor].Hash)
+		}
+	}
+
+	return m, nil
+}
+
+// View returns the string representation of the history view.
+func (m repoHistoryModel) View() string {
+	title := lipgloss.NewStyle().
+		Foreground(colors.BadgeText()).
+		Background(colors.Green()).
+		Padding(0, 1).
+		Render("History")
+
+	switch {
+	case m.opening:
+		title += "  Loading snapshot..."
+	case m.warning != "":
+		title += "  " + m.warning
+	}
+
+	helpView := m.help.ShortHelpView([]key.Binding{
+		m.keys.up,
+		m.keys.down,
+		m.keys.openSnapshot,
+		m.keys.quit,
+	})
+
+	var (
+		lines                  []string
+		cursorStart, cursorEnd int
+	)
+
+	switch {
+	case m.loading:
+		lines = append(lines, "Loading history...")
+
+	case m.err != nil:
+		lines = append(lines, lipgloss.NewStyle().
+			Foreground(colors.Red()).
+			Render(fmt.Sprintf("Cannot read history: %s\n\n%s", m.err, m.cmdOutput)))
+
+	case len(m.entries) == 0:
+		lines = append(lines, "No changes recorded yet.")
+	}
+
+	for i, entry := range m.entries {
+		if i > 0 {
+			lines = append(lines, "")
+		}
+
+		if i == m.cursor {
+			cursorStart = len(lines)
+		}
+		lines = append(lines, strings.Split(m.entryView(entry, i == m.cursor), "\n")...)
+		if i == m.cursor {
+			cursorEnd = len(lines)
+		}
+	}
+
+	// Scroll so that the selected commit stays visible.
+	visible := max(m.height-lipgloss.Height(title)-lipgloss.Height(helpView)-2, 1)
+	offset := max(0, cursorEnd-visible)
+	if cursorStart < offset {
+		offset = cursorStart
+	}
+	end := min(len(lines), offset+visible)
+
+	var b strings.Builder
+
+	b.WriteString(title)
+	b.WriteString("\n\n")
+	b.WriteString(lipgloss.NewStyle().
+		Height(visible).
+		Render(strings.Join(lines[offset:end], "\n")))
+	b.WriteString("\n")
+	b.WriteString(helpView)
+
+	return appStyle.Render(b.String())
+}
+
+// entryView renders a single commit with its date, author, subject
+// and the projects and tasks it touched.
+func (m repoHistoryModel) entryView(entry vcs.LogEntry, selected bool) string {
+	hash := entry.Hash
+	if len(hash) > 7 {
+		hash = hash[:7]
+	}
+
+	lines := []string{
+		fmt.Sprintf("%s  %s  %s",
+			lipgloss.NewStyle().Bold(true).Render(entry.Date.Local().Format("Mon, 02 Jan 2006 15:04")),
+			lipgloss.NewStyle().Foreground(colors.Blue()).Render(entry.Author),
+			lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render(hash),
+		),
+		entry.Subject,
+	}
+
+	touched := m.touchedItems(entry)
+	for i, item := range touched {
+		if i == repoHistoryMaxItems {
+			lines = append(lines, fmt.Sprintf("  … and %d more", len(touched)-i))
+			break
+		}
+
+		lines = append(lines, "  "+item)
+	}
+
+	style := lipgloss.NewStyle().
+		Width(max(m.width-2, 20)).
+		PaddingLeft(1)
+
+	if selected {
+		style = style.
+			Border(lipgloss.NormalBorder(), false, false, false, true).
+			BorderForeground(colors.Green())
+	} else {
+		style = style.MarginLeft(1)
+	}
+
+	return style.Render(strings.Join(lines, "\n"))
+}
+
+// touchedItems describes the projects, tasks and templates changed by the
+// given commit using their current titles. Items that no longer exist are
+// shown as deleted. All other files are ignored.
+func (m repoHistoryModel) touchedItems(entry vcs.LogEntry) []string {
+	deleted := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+
+	var touched []string
+	for _, file := range entry.Files {
+		projectID, _, _ := strings.Cut(file, "/")
+		base := path.Base(file)
+
+		switch {
+		case projectID == storage.TemplatesDir:
+			title, ok := m.titles[file]
+			if !ok {
+				title = deleted.Render("deleted template")
+			}
+
+			touched = append(touched, "◇ "+title+deleted.Render(" (template)"))
+
+		case file == path.Join(projectID, "project.json"):
+			title, ok := m.titles[file]
+			if !ok {
+				title = deleted.Render("deleted project")
+			}
+
+			touched = append(touched, "◆ "+title)
+
+		case items.UUIDRegex.MatchString(base):
+			title, ok := m.titles[file]
+			if !ok {
+				title = deleted.Render("deleted task " + strings.TrimSuffix(base, ".json")[:8])
+			}
+
+			if path.Base(path.Dir(file)) == items.ArchiveDir {
+				title += deleted.Render(" (archived)")
+			}
+
+			if project, ok := m.titles[path.Join(projectID, "project.json")]; ok {
+				title += lipgloss.NewStyle().Foreground(colors.Blue()).Render("  " + project)
+			}
+
+			touched = append(touched, "• "+title)
+		}
+	}
+
+	return touched
+}
+
+// snapshotProject is a project along with its tasks as found in a snapshot.
+type snapshotProject struct {
+	project items.Project
+	tasks   []items.Task
+}
+
+// parseSnapshot reads the projects and tasks from the files of a snapshot.
+// Projects are sorted by title, their tasks by state and title. The number
+// of files that could not be parsed is returned along with the projects.
+func parseSnapshot(files map[string][]byte) ([]snapshotProject, int) {
+	projects := make(map[string]*snapshotProject)
+	get := func(id string) *snapshotProject {
+		if _, ok := projects[id]; !ok {
+			projects[id] = &snapshotProject{project: items.Project{ID: id, Title: id}}
+		}
+
+		return projects[id]
+	}
+
+	skipped := 0
+	for _, file := range slices.Sorted(maps.Keys(files)) {
+		parts := strings.Split(file, "/")
+
+		switch {
+		case parts[0] == storage.TemplatesDir:
+			continue
+
+		case len(parts) == 2 && parts[1] == "project.json":
+			var project items.Project
+			if err := json.Unmarshal(files[file], &project); err != nil {
+				skipped++
+				continue
+			}
+
+			project.ID = parts[0]
+			get(parts[0]).project = project
+
+		case len(parts) == 2 && items.UUIDRegex.MatchString(parts[1]),
+			len(parts) == 3 && parts[1] == items.ArchiveDir && items.UUIDRegex.MatchString(parts[2]):
+			var task items.Task
+			if err := json.Unmarshal(files[file], &task); err != nil {
+				skipped++
+				continue
+			}
+
+			task.Archived = len(parts) == 3
+			sp := get(parts[0])
+			sp.tasks = append(sp.tasks, task)
+		}
+	}
+
+	result := make([]snapshotProject, 0, len(projects))
+	for _, sp := range projects {
+		slices.SortFunc(sp.tasks, func(x, y items.Task) int {
+			if c := cmp.Compare(snapshotTaskRank(x), snapshotTaskRank(y)); c != 0 {
+				return c
+			}
+
+			return cmp.Compare(strings.ToLower(x.Title), strings.ToLower(y.Title))
+		})
+
+		result = append(result, *sp)
+	}
+
+	slices.SortFunc(result, func(x, y snapshotProject) int {
+		return cmp.Compare(strings.ToLower(x.project.Title), strings.ToLower(y.project.Title))
+	})
+
+	return result, skipped
+}
+
+// snapshotTaskRank orders open tasks before completed and archived ones.
+func snapshotTaskRank(t items.Task) int {
+	switch {
+	case t.Archived:
+		return 2
+	case t.Completed:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// repoSnapshotModel represents the Bubble Tea model for a read-only
+// view of all projects and tasks as they were at a given commit.
+type repoSnapshotModel struct {
+	historyModel repoHistoryModel
+	entry        vcs.LogEntry
+	projects     []snapshotProject
+	skipped      int
+	help         help.Model
+	ready        bool
+	viewport     viewport.Model
+}
+
+// newRepoSnapshotModel creates a new repoSnapshotModel for the given
+// commit and the files read from it.
+func newRepoSnapshotModel(
+	historyModel repoHistoryModel,
+	entry vcs.LogEntry,
+	files map[string][]byte,
+) repoSnapshotModel {
+	projects, skipped := parseSnapshot(files)
+
+	return repoSnapshotModel{
+		historyModel: historyModel,
+		entry:        entry,
+		projects:     projects,
+		skipped:      skipped,
+		help:         help.New(),
+	}
+}
+
+// Init initializes the repoSnapshotModel and returns an initial command.
+func (m repoSnapshotModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles incoming messages and updates the repoSnapshotModel accordingly.
+func (m repoSnapshotModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if msg.Type == tea.KeyCtrlC {
+			return m, tea.Quit
+		}
+
+		if key.Matches(msg, m.historyModel.keys.quit) {
+			return m.historyModel, tea.WindowSize()
+		}
+
+	case tea.WindowSizeMsg:
+		verticalMargins := lipgloss.Height(m.headerView()) + lipgloss.Height(m.footerView())
+
+		if !m.ready {
+			m.viewport = viewport.New(msg.Width, msg.Height-verticalMargins)
+			m.ready = true
+		} else {
+			m.viewport.Width = msg.Width
+			m.viewport.Height = msg.Height - verticalMargins
+		}
+
+		m.viewport.SetContent(m.snapshotView())
+	}
+
+	m.viewport, cmd = m.viewport.Update(msg)
+
+	return m, cmd
+}
+
+// View returns the string representation of the snapshot view.
+func (m repoSnapshotModel) View() string {
+	if !m.ready {
+		return "\n  Initializing..."
+	}
+
+	return fmt.Sprintf("%s\n%s\n%s", m.headerView(), m.viewport.View(), m.footerView())
+}
+
+// headerView returns the title of the snapshot view.
+func (m repoSnapshotModel) headerView() string {
+	hash := m.entry.Hash
+	if len(hash) > 7 {
+		hash = hash[:7]
+	}
+
+	return lipgloss.NewStyle().
+		Padding(0, 1).
+		Render(lipgloss.NewStyle().
+			Foreground(colors.BadgeText()).
+			Background(colors.Green()).
+			Padding(0, 1).
+			Render(fmt.Sprintf("Snapshot %s · %s (read-only)",
+				hash, m.entry.Date.Local().Format("Mon, 02 Jan 2006 15:04"))))
+}
+
+// footerView returns the key help and scroll position of the snapshot view.
+func (m repoSnapshotModel) footerView() string {
+	helpView := lipgloss.NewStyle().
+		Padding(0, 1).
+		Render(m.help.ShortHelpView([]key.Binding{m.historyModel.keys.quit}))
+
+	info := lipgloss.NewStyle().
+		Padding(0, 1).
+		Render(fmt.Sprintf("%3.f%%", m.viewport.ScrollPercent()*100))
+
+	line := strings.Repeat(" ", max(0, m.viewport.Width-lipgloss.Width(helpView)-lipgloss.Width(info)))
+	return lipgloss.JoinHorizontal(lipgloss.Center, helpView, line, info)
+}
+
+// snapshotView renders all projects of the snapshot, each followed
+// by its tasks with their state, priority and due date.
+func (m repoSnapshotModel) snapshotView() string {
+	padding := lipgloss.NewStyle().Padding(1, 2)
+	muted := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+
+	lines := []string{muted.Render(m.entry.Subject)}
+
+	if m.skipped > 0 {
+		lines = append(lines, lipgloss.NewStyle().
+			Foreground(colors.Red()).
+			Render(fmt.Sprintf("⚠  %d file(s) could not be read", m.skipped)))
+	}
+
+	if len(m.projects) == 0 {
+		lines = append(lines, "", "No projects at this point in time.")
+	}
+
+	for _, sp := range m.projects {
+		lines = append(lines, "", lipgloss.NewStyle().
+			Bold(true).
+			Foreground(helpers.GetColorCode(sp.project.Color)).
+			Render(fmt.Sprintf("%s (%d tasks)", sp.project.Title, len(sp.tasks))))
+
+		for _, task := range sp.tasks {
+			check := "[ ]"
+			if task.Completed {
+				check = "[x]"
+			}
+
+			line := fmt.Sprintf("  %s %s", check, task.CropTaskTitle(taskEntryLength))
+
+			var details []string
+			if task.Priority != "" {
+				details = append(details, task.Priority)
+			}
+			if task.DueDate != nil {
+				details = append(details, "due "+task.DueDate.Format("Mon, 02 Jan 15:04"))
+			}
+			if task.Archived {
+				details = append(details, "archived")
+			}
+
+			if len(details) > 0 {
+				line += "  " + muted.Render(strings.Join(details, " · "))
+			}
+
+			lines = append(lines, line)
+		}
+	}
+
+	return padding.Render(strings.Join(lines, "\n"))
+}
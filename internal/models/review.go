@@ -0,0 +1,662 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package models
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/handlebargh/yatto/internal/colors"
+	"github.com/handlebargh/yatto/internal/helpers"
+	"github.com/handlebargh/yatto/internal/hooks"
+	"github.com/handlebargh/yatto/internal/items"
+	"github.com/handlebargh/yatto/internal/vcs"
+)
+
+// reviewKeyMap defines the key bindings used in the review.
+type reviewKeyMap struct {
+	keep       key.Binding
+	snooze     key.Binding
+	snoozeWeek key.Binding
+	priority   key.Binding
+	complete   key.Binding
+	deleteTask key.Binding
+	move       key.Binding
+	quit       key.Binding
+}
+
+// newReviewKeyMap initializes and returns a new key map for review actions.
+func newReviewKeyMap() *reviewKeyMap {
+	return &reviewKeyMap{
+		keep: key.NewBinding(
+			key.WithKeys("enter", " ", "n"),
+			key.WithHelp("enter", "keep"),
+		),
+		snooze: key.NewBinding(
+			key.WithKeys("s"),
+			key.WithHelp("s", "snooze a day"),
+		),
+		snoozeWeek: key.NewBinding(
+			key.WithKeys("S"),
+			key.WithHelp("S", "snooze a week"),
+		),
+		priority: key.NewBinding(
+			key.WithKeys("p"),
+			key.WithHelp("p", "change priority"),
+		),
+		complete: key.NewBinding(
+			key.WithKeys("C"),
+			key.WithHelp("C", "complete"),
+		),
+		deleteTask: key.NewBinding(
+			key.WithKeys("D"),
+			key.WithHelp("D", "delete"),
+		),
+		move: key.NewBinding(
+			key.WithKeys("m"),
+			key.WithHelp("m", "move"),
+		),
+		quit: key.NewBinding(
+			key.WithKeys("q", "esc"),
+			key.WithHelp("q/esc", "go back"),
+		),
+	}
+}
+
+// reviewMode is the state of the review.
+type reviewMode int
+
+const (
+	reviewModeNormal reviewMode = iota
+	reviewModeConfirmDelete
+	reviewModeConfirmDeleteProtected
+	reviewModeMove
+)
+
+// reviewActions lists the actions counted in the summary of the review,
+// in the order they are shown.
+var reviewActions = []string{"kept", "snoozed", "reprioritized", "completed", "deleted", "moved"}
+
+// reviewEntry is a single open task of the review along with its project.
+type reviewEntry struct {
+	project       items.Project
+	task          items.Task
+	reprioritized bool
+}
+
+// reviewModel represents the Bubble Tea model for the guided review.
+// It walks through the open tasks of all projects one at a time, oldest
+// first, and offers a single key for every decision about a task.
+type reviewModel struct {
+	projectModel  *ProjectListModel
+	keys          *reviewKeyMap
+	help          help.Model
+	entries       []reviewEntry
+	tasks         map[string][]items.Task
+	current       int
+	mode          reviewMode
+	moveCursor    int
+	counts        map[string]int
+	status        string
+	warning       string
+	width, height int
+}
+
+// newReviewModel creates a new reviewModel containing the open tasks
+// of all projects found in storage, leaving out deferred tasks.
+func newReviewModel(projectModel *ProjectListModel, width, height int) reviewModel {
+	now := time.Now()
+
+	var entries []reviewEntry
+	tasks := make(map[string][]items.Task)
+	err := readAllTasks(projectModel.config, func(project items.Project, task items.Task) {
+		tasks[project.ID] = append(tasks[project.ID], task)
+
+		if task.Completed || task.Deferred(now) {
+			return
+		}
+
+		entries = append(entries, reviewEntry{project: project, task: task})
+	})
+
+	// Tasks without a creation date were created before it was
+	// recorded, so they are the oldest ones.
+	slices.SortStableFunc(entries, func(x, y reviewEntry) int {
+		cx, cy := x.task.CreatedAt, y.task.CreatedAt
+		switch {
+		case cx == nil && cy == nil:
+			return 0
+		case cx == nil:
+			return -1
+		case cy == nil:
+			return 1
+		}
+
+		return cx.Compare(*cy)
+	})
+
+	h, v := appStyle.GetFrameSize()
+
+	return reviewModel{
+		projectModel: projectModel,
+		keys:         newReviewKeyMap(),
+		help:         help.New(),
+		entries:      entries,
+		tasks:        tasks,
+		counts:       make(map[string]int),
+		warning:      storageWarning(err),
+		width:        width - h,
+		height:       height - v,
+	}
+}
+
+// Init initializes the reviewModel and returns an initial command.
+func (m reviewModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles incoming messages and updates the reviewModel accordingly.
+func (m reviewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		h, v := appStyle.GetFrameSize()
+		m.width = msg.Width - h
+		m.height = msg.Height - v
+
+	case hooks.ErrorMsg:
+		m.status = errorStatus(msg.Err)
+
+	case vcs.CommitDoneMsg:
+		m.status = "🗘  Changes committed"
+
+	case vcs.PushQueuedMsg:
+		m.status = unpushedStatus(msg.Unpushed)
+
+	case items.WriteTaskJSONErrorMsg:
+		m.status = errorStatus(msg.Err)
+
+	case items.TaskDeleteErrorMsg:
+		m.status = errorStatus(msg.Err)
+
+	case items.TaskMoveErrorMsg:
+		m.status = errorStatus(msg.Err)
+
+	case vcs.CommitErrorMsg:
+		m.status = errorStatus(msg.Err)
+
+	case tea.KeyMsg:
+		if msg.Type == tea.KeyCtrlC {
+			return m, tea.Quit
+		}
+
+		switch m.mode {
+		case reviewModeConfirmDelete, reviewModeConfirmDeleteProtected:
+			return m.updateConfirmDelete(msg)
+		case reviewModeMove:
+			return m.updateMove(msg)
+		}
+
+		if key.Matches(msg, m.keys.quit) {
+			return m.projectModel, func() tea.Msg { return returnedToProjectListMsg{} }
+		}
+
+		if m.current >= len(m.entries) {
+			return m, nil
+		}
+
+		m.status = ""
+
+		switch {
+		case key.Matches(msg, m.keys.keep):
+			m.next("kept")
+
+		case key.Matches(msg, m.keys.snooze):
+			return m.snooze(1)
+
+		case key.Matches(msg, m.keys.snoozeWeek):
+			return m.snooze(7)
+
+		case key.Matches(msg, m.keys.priority):
+			return m.cyclePriority()
+
+		case key.Matches(msg, m.keys.complete):
+			return m.complete()
+
+		case key.Matches(msg, m.keys.deleteTask):
+			m.mode = reviewModeConfirmDelete
+
+		case key.Matches(msg, m.keys.move):
+			if len(m.moveTargets()) == 0 {
+				m.status = errorStatus(errors.New("no other project to move the task to"))
+				return m, nil
+			}
+			m.mode = reviewModeMove
+			m.moveCursor = 0
+		}
+	}
+
+	return m, nil
+}
+
+// updateConfirmDelete handles the keys of the delete confirmation. Tasks
+// of other users need to be confirmed once more, see
+// ProjectListModel.deleteProtected.
+func (m reviewModel) updateConfirmDelete(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "y", "Y":
+		task := &m.entries[m.current].task
+		if m.mode == reviewModeConfirmDelete && m.projectModel.deleteProtected(task) {
+			m.mode = reviewModeConfirmDeleteProtected
+			return m, nil
+		}
+
+		m.mode = reviewModeNormal
+		return m.delete()
+
+	case "n", "N", "esc", "q":
+		m.mode = reviewModeNormal
+	}
+
+	return m, nil
+}
+
+// updateMove handles the keys of the project picker shown when moving a task.
+func (m reviewModel) updateMove(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	targets := m.moveTargets()
+
+	switch msg.String() {
+	case "up", "k":
+		if m.moveCursor > 0 {
+			m.moveCursor--
+		}
+
+	case "down", "j":
+		if m.moveCursor < len(targets)-1 {
+			m.moveCursor++
+		}
+
+	case "enter":
+		m.mode = reviewModeNormal
+		return m.moveTo(*targets[m.moveCursor])
+
+	case "esc", "q":
+		m.mode = reviewModeNormal
+	}
+
+	return m, nil
+}
+
+// next counts the action taken on the current task and continues
+// with the next one.
+func (m *reviewModel) next(action string) {
+	if m.entries[m.current].reprioritized && action != "deleted" {
+		m.counts["reprioritized"]++
+	}
+
+	m.counts[action]++
+	m.current++
+}
+
+// snooze hides the current task until the start of the day the
+// given number of days from now, see items.Task.Deferred.
+func (m reviewModel) snooze(days int) (tea.Model, tea.Cmd) {
+	entry := &m.entries[m.current]
+	config := m.projectModel.config
+
+	now := time.Now()
+	start := time.Date(now.Year(), now.Month(), now.Day()+days, 0, 0, 0, 0, now.Location())
+
+	task := entry.task
+	task.StartDate = &start
+
+	m.next("snoozed")
+
+	return m, tea.Sequence(
+		task.WriteTaskJSON(config, entry.project, "update"),
+		vcs.QueueCommitCmd(config,
+			fmt.Sprintf("snooze: %s (until %s)", task.Title, task.StartDateToString()),
+			task.Path(entry.project)),
+	)
+}
+
+// cyclePriority raises the priority of the current task, from high
+// back to low. The task stays selected, so that it can be changed again.
+func (m reviewModel) cyclePriority() (tea.Model, tea.Cmd) {
+	entry := &m.entries[m.current]
+	config := m.projectModel.config
+
+	switch entry.task.Priority {
+	case "low":
+		entry.task.Priority = "medium"
+	case "medium":
+		entry.task.Priority = "high"
+	default:
+		entry.task.Priority = "low"
+	}
+	entry.reprioritized = true
+
+	task := entry.task
+
+	return m, tea.Sequence(
+		task.WriteTaskJSON(config, entry.project, "update"),
+		vcs.QueueCommitCmd(config, fmt.Sprintf("update: %s", task.Title), task.Path(entry.project)),
+	)
+}
+
+// complete completes the current task unless it is blocked by open
+// tasks. Completing a recurring task schedules its next occurrence.
+func (m reviewModel) complete() (tea.Model, tea.Cmd) {
+	entry := &m.entries[m.current]
+	config := m.projectModel.config
+	projectTasks := m.tasks[entry.project.ID]
+
+	if len(entry.task.OpenBlockers(projectTasks)) > 0 {
+		m.status = errorStatus(errors.New("cannot complete task blocked by open tasks"))
+		return m, nil
+	}
+
+	task := entry.task
+	task.Completed = true
+	task.InProgress = false
+	next := task.Recur()
+	entry.task = task
+
+	// Later tasks blocked by this one can be completed now.
+	if idx := slices.IndexFunc(projectTasks, func(t items.Task) bool { return t.ID == task.ID }); idx >= 0 {
+		projectTasks[idx] = task
+	}
+
+	writeCmds := []tea.Cmd{task.WriteTaskJSON(config, entry.project, "complete")}
+	paths := []string{task.Path(entry.project)}
+	hookCalls := task.HookCalls(entry.project, "complete")
+
+	var recurNames []string
+	if next != nil {
+		writeCmds = append(writeCmds, next.WriteTaskJSON(config, entry.project, "recur"))
+		paths = append(paths, next.Path(entry.project))
+		hookCalls = append(hookCalls, next.HookCalls(entry.project, "recur")...)
+		recurNames = append(recurNames, fmt.Sprintf("%s (due %s)", next.Title, next.DueDateToString()))
+	}
+
+	m.next("completed")
+
+	message := items.StateChangeCommitMessage("completion", []string{task.Title}, recurNames)
+
+	return m, tea.Sequence(append(writeCmds, vcs.QueueCommitThenCmd(config, message, hooks.RunCmd(config, hookCalls), paths...))...)
+}
+
+// delete deletes the current task along with its attachments.
+func (m reviewModel) delete() (tea.Model, tea.Cmd) {
+	entry := &m.entries[m.current]
+	config := m.projectModel.config
+	task := entry.task
+
+	paths := []string{task.Path(entry.project)}
+	if attachments, _ := task.Attachments(config, entry.project); len(attachments) > 0 {
+		paths = append(paths, task.AttachmentsDir(entry.project))
+	}
+
+	m.next("deleted")
+
+	return m, tea.Sequence(
+		task.DeleteTaskFromFS(config, entry.project),
+		vcs.CommitCmd(config, fmt.Sprintf("delete: 1 task(s)\n\n- %s", task.Title), paths...),
+	)
+}
+
+// moveTo moves the current task along with its attachments into the
+// given project.
+func (m reviewModel) moveTo(target items.Project) (tea.Model, tea.Cmd) {
+	entry := &m.entries[m.current]
+	config := m.projectModel.config
+	task := entry.task
+
+	paths := []string{task.Path(entry.project), task.Path(target)}
+	if attachments, _ := task.Attachments(config, entry.project); len(attachments) > 0 {
+		paths = append(paths, task.AttachmentsDir(entry.project), task.AttachmentsDir(target))
+	}
+
+	m.tasks[target.ID] = append(m.tasks[target.ID], task)
+	m.next("moved")
+
+	return m, tea.Sequence(
+		task.MoveTaskOnFS(config, entry.project, target),
+		vcs.CommitCmd(config,
+			fmt.Sprintf("move: %s (%s → %s)", task.Title, entry.project.Title, target.Title),
+			paths...),
+	)
+}
+
+// moveTargets returns the projects the current task can be moved to.
+func (m reviewModel) moveTargets() []*items.Project {
+	if m.current >= len(m.entries) {
+		return nil
+	}
+
+	current := m.entries[m.current].project.ID
+
+	var targets []*items.Project
+	for _, p := range m.projectModel.allProjects() {
+		if p.ID != current {
+			targets = append(targets, p)
+		}
+	}
+
+	return targets
+}
+
+// View returns the string representation of the review.
+func (m reviewModel) View() string {
+	title := lipgloss.NewStyle().
+		Foreground(colors.BadgeText()).
+		Background(colors.Green()).
+		Padding(0, 1).
+		Render("Review")
+
+	if m.current < len(m.entries) {
+		title += fmt.Sprintf("  %d of %d", m.current+1, len(m.entries))
+	}
+
+	if m.warning != "" {
+		title += "  " + m.warning
+	}
+
+	var body, helpView string
+
+	switch {
+	case m.current >= len(m.entries):
+		body = m.summaryView()
+		helpView = m.help.ShortHelpView([]key.Binding{m.keys.quit})
+
+	case m.mode == reviewModeConfirmDelete:
+		body = m.entryView(m.entries[m.current]) + "\n\n" +
+			lipgloss.NewStyle().
+				Foreground(colors.Red()).
+				Render("Delete this task? (y/n)")
+
+	case m.mode == reviewModeConfirmDeleteProtected:
+		body = m.entryView(m.entries[m.current]) + "\n\n" +
+			lipgloss.NewStyle().
+				Foreground(colors.Red()).
+				Render("This task was neither created by nor assigned to you.\n"+
+					"Delete it anyway? (y/n)")
+
+	case m.mode == reviewModeMove:
+		body = m.entryView(m.entries[m.current]) + "\n\n" + m.moveView()
+		helpView = m.help.ShortHelpView([]key.Binding{
+			key.NewBinding(key.WithKeys("up"), key.WithHelp("↑/k", "up")),
+			key.NewBinding(key.WithKeys("down"), key.WithHelp("↓/j", "down")),
+			key.NewBinding(key.WithKeys("enter"), key.WithHelp("enter", "move here")),
+			key.NewBinding(key.WithKeys("esc"), key.WithHelp("esc", "cancel")),
+		})
+
+	default:
+		body = m.entryView(m.entries[m.current])
+		helpView = m.help.ShortHelpView([]key.Binding{
+			m.keys.keep,
+			m.keys.snooze,
+			m.keys.snoozeWeek,
+			m.keys.priority,
+			m.keys.complete,
+			m.keys.deleteTask,
+			m.keys.move,
+			m.keys.quit,
+		})
+	}
+
+	visible := max(m.height-lipgloss.Height(title)-lipgloss.Height(helpView)-4, 1)
+	lines := strings.Split(body, "\n")
+	lines = lines[:min(len(lines), visible)]
+
+	var b strings.Builder
+
+	b.WriteString(title)
+	b.WriteString("\n\n")
+	b.WriteString(lipgloss.NewStyle().
+		Height(visible).
+		Render(strings.Join(lines, "\n")))
+	b.WriteString("\n")
+	b.WriteString(m.status)
+	b.WriteString("\n")
+	b.WriteString(helpView)
+
+	return appStyle.Render(b.String())
+}
+
+// entryView renders the task under review with its project, priority,
+// dates, labels and description.
+func (m reviewModel) entryView(entry reviewEntry) string {
+	task := entry.task
+	projectColor := helpers.GetColorCode(entry.project.Color)
+
+	priorityStyle := lipgloss.NewStyle().
+		Foreground(colors.BadgeText()).
+		Padding(0, 1)
+
+	switch task.Priority {
+	case "low":
+		priorityStyle = priorityStyle.Background(colors.Indigo())
+	case "medium":
+		priorityStyle = priorityStyle.Background(colors.Orange())
+	case "high":
+		priorityStyle = priorityStyle.Background(colors.Red())
+	}
+
+	labelStyle := lipgloss.NewStyle().Width(10).Foreground(colors.Blue())
+
+	created := "unknown"
+	if task.CreatedAt != nil {
+		days := int(time.Since(*task.CreatedAt).Hours() / 24)
+		created = fmt.Sprintf("%s (%d day(s) ago)", task.CreatedAt.Format("Mon, 02 Jan 2006"), days)
+	}
+
+	due := "no due date"
+	if task.DueDate != nil {
+		due = task.DueDate.Format("Mon, 02 Jan 15:04") + " (" + task.DueText() + ")"
+	}
+
+	labels := "none"
+	if len(task.Labels) > 0 {
+		labels = strings.Join(task.LabelsList(), ", ")
+	}
+
+	rows := []string{
+		lipgloss.NewStyle().
+			Bold(true).
+			Border(lipgloss.NormalBorder(), false, false, false, true).
+			BorderForeground(projectColor).
+			PaddingLeft(1).
+			Render(task.Title),
+		"",
+		labelStyle.Render("Project") + lipgloss.NewStyle().Foreground(projectColor).Render(entry.project.Title),
+		labelStyle.Render("Priority") + priorityStyle.Render(task.Priority),
+		labelStyle.Render("Created") + created,
+		labelStyle.Render("Due") + due,
+		labelStyle.Render("Labels") + labels,
+	}
+
+	if task.InProgress {
+		rows = append(rows, labelStyle.Render("State")+"in progress")
+	}
+	if task.Assignee != "" {
+		rows = append(rows, labelStyle.Render("Assignee")+task.Assignee)
+	}
+
+	if description := strings.TrimSpace(task.Description); description != "" {
+		rows = append(rows, "", lipgloss.NewStyle().
+			Width(max(m.width-4, 20)).
+			Render(description))
+	}
+
+	return strings.Join(rows, "\n")
+}
+
+// moveView renders the projects the current task can be moved to.
+func (m reviewModel) moveView() string {
+	lines := []string{lipgloss.NewStyle().Bold(true).Render("Move to project:")}
+
+	for i, p := range m.moveTargets() {
+		style := lipgloss.NewStyle().
+			Foreground(helpers.GetColorCode(p.Color)).
+			PaddingLeft(2)
+		if i == m.moveCursor {
+			style = style.Bold(true).PaddingLeft(0)
+			lines = append(lines, style.Render("> "+p.Title))
+			continue
+		}
+		lines = append(lines, style.Render(p.Title))
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+// summaryView renders the number of tasks per action once all tasks
+// were reviewed.
+func (m reviewModel) summaryView() string {
+	if len(m.entries) == 0 {
+		return lipgloss.NewStyle().
+			Foreground(colors.Green()).
+			Render("No open tasks to review")
+	}
+
+	lines := []string{
+		lipgloss.NewStyle().
+			Bold(true).
+			Foreground(colors.Green()).
+			Render(fmt.Sprintf("Review finished: %d task(s) reviewed", len(m.entries))),
+		"",
+	}
+
+	for _, action := range reviewActions {
+		if n := m.counts[action]; n > 0 {
+			lines = append(lines, fmt.Sprintf("%-15s %d", action, n))
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
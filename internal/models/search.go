@@ -0,0 +1,316 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package models
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/handlebargh/yatto/internal/colors"
+	"github.com/handlebargh/yatto/internal/helpers"
+	"github.com/handlebargh/yatto/internal/items"
+)
+
+// searchKeyMap defines the key bindings used in the search view.
+// Letters are typed into the search input, so none are bound.
+type searchKeyMap struct {
+	quit     key.Binding
+	up       key.Binding
+	down     key.Binding
+	openTask key.Binding
+}
+
+// newSearchKeyMap initializes and returns a new key map for search actions.
+func newSearchKeyMap() *searchKeyMap {
+	return &searchKeyMap{
+		quit: key.NewBinding(
+			key.WithKeys("esc"),
+			key.WithHelp("esc", "go back"),
+		),
+		up: key.NewBinding(
+			key.WithKeys("up", "ctrl+p"),
+			key.WithHelp("↑/ctrl+p", "up"),
+		),
+		down: key.NewBinding(
+			key.WithKeys("down", "ctrl+n"),
+			key.WithHelp("↓/ctrl+n", "down"),
+		),
+		openTask: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "show task"),
+		),
+	}
+}
+
+// searchEntry is a single task of any project along with its project
+// and its score for the current search term.
+type searchEntry struct {
+	project items.Project
+	task    items.Task
+	score   int
+}
+
+// searchModel represents the Bubble Tea model for the full-text search
+// across the tasks of all projects.
+type searchModel struct {
+	projectModel  *ProjectListModel
+	keys          *searchKeyMap
+	help          help.Model
+	input         textinput.Model
+	tasks         []searchEntry
+	results       []searchEntry
+	warning       string
+	cursor        int
+	width, height int
+}
+
+// newSearchModel creates a new searchModel searching the tasks
+// of all projects found in storage. The tasks are taken from the
+// task index, so that they need not be parsed again.
+func newSearchModel(projectModel *ProjectListModel, width, height int) searchModel {
+	projects, err := helpers.ReadProjectsFromFS(projectModel.config)
+	indexed, indexErr := items.IndexedTasks(projectModel.config)
+	err = items.CollectSkipped(err, indexErr)
+
+	var tasks []searchEntry
+	for _, project := range projects {
+		for _, task := range indexed[project.ID] {
+			tasks = append(tasks, searchEntry{project: project, task: task})
+		}
+	}
+
+	ti := textinput.New()
+	ti.Placeholder = "Search titles, labels and descriptions"
+	ti.Prompt = "/ "
+	ti.Focus()
+
+	h, v := appStyle.GetFrameSize()
+
+	return searchModel{
+		projectModel: projectModel,
+		keys:         newSearchKeyMap(),
+		help:         help.New(),
+		input:        ti,
+		tasks:        tasks,
+		warning:      storageWarning(err),
+		width:        width - h,
+		height:       height - v,
+	}
+}
+
+// Init initializes the searchModel and returns an initial command.
+func (m searchModel) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+// Update handles incoming messages and updates the searchModel accordingly.
+func (m searchModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		h, v := appStyle.GetFrameSize()
+		m.width = msg.Width - h
+		m.height = msg.Height - v
+		return m, nil
+
+	case tea.KeyMsg:
+		if msg.Type == tea.KeyCtrlC {
+			return m, tea.Quit
+		}
+
+		switch {
+		case key.Matches(msg, m.keys.quit):
+			return m.projectModel, func() tea.Msg { return returnedToProjectListMsg{} }
+
+		case key.Matches(msg, m.keys.up):
+			if m.cursor > 0 {
+				m.cursor--
+			}
+			return m, nil
+
+		case key.Matches(msg, m.keys.down):
+			if m.cursor < len(m.results)-1 {
+				m.cursor++
+			}
+			return m, nil
+
+		case key.Matches(msg, m.keys.openTask):
+			return m.openTask()
+		}
+	}
+
+	term := m.input.Value()
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+
+	if m.input.Value() != term {
+		m.search()
+	}
+
+	return m, cmd
+}
+
+// search ranks all tasks against the current search term. Tasks with
+// higher scores come first, open tasks before completed ones.
+func (m *searchModel) search() {
+	term := m.input.Value()
+
+	m.results = nil
+	m.cursor = 0
+
+	for _, entry := range m.tasks {
+		if entry.score = items.SearchScore(&entry.task, term); entry.score > 0 {
+			m.results = append(m.results, entry)
+		}
+	}
+
+	slices.SortStableFunc(m.results, func(x, y searchEntry) int {
+		if c := cmp.Compare(y.score, x.score); c != 0 {
+			return c
+		}
+
+		switch {
+		case x.task.Completed && !y.task.Completed:
+			return 1
+		case !x.task.Completed && y.task.Completed:
+			return -1
+		}
+
+		return strings.Compare(strings.ToLower(x.task.Title), strings.ToLower(y.task.Title))
+	})
+}
+
+// openTask shows the selected task in the pager. Leaving the pager
+// returns to the task list of the task's project.
+func (m searchModel) openTask() (tea.Model, tea.Cmd) {
+	if len(m.results) == 0 {
+		return m, nil
+	}
+
+	entry := m.results[m.cursor]
+
+	h, v := appStyle.GetFrameSize()
+	if pagerModel, cmd, ok := openTaskPager(m.projectModel, entry.project, entry.task, m.width+h, m.height+v); ok {
+		return pagerModel, cmd
+	}
+
+	return m, nil
+}
+
+// View returns the string representation of the search view.
+func (m searchModel) View() string {
+	title := lipgloss.NewStyle().
+		Foreground(colors.BadgeText()).
+		Background(colors.Green()).
+		Padding(0, 1).
+		Render("Search")
+
+	if m.warning != "" {
+		title += "  " + m.warning
+	}
+
+	helpView := m.help.ShortHelpView([]key.Binding{
+		m.keys.up,
+		m.keys.down,
+		m.keys.openTask,
+		m.keys.quit,
+	})
+
+	var lines []string
+
+	switch {
+	case strings.TrimSpace(m.input.Value()) == "":
+		lines = append(lines, lipgloss.NewStyle().
+			Foreground(colors.Blue()).
+			Render("Type to search the tasks of all projects"))
+	case len(m.results) == 0:
+		lines = append(lines, lipgloss.NewStyle().
+			Foreground(colors.Red()).
+			Render("No matching tasks"))
+	}
+
+	for i, entry := range m.results {
+		lines = append(lines, m.entryView(entry, i == m.cursor))
+	}
+
+	// Scroll so that the cursor stays visible.
+	visible := max(m.height-lipgloss.Height(title)-lipgloss.Height(helpView)-4, 1)
+	offset := max(0, m.cursor-visible+1)
+	end := min(len(lines), offset+visible)
+
+	var b strings.Builder
+
+	b.WriteString(title)
+	b.WriteString("\n\n")
+	b.WriteString(m.input.View())
+	b.WriteString("\n\n")
+	b.WriteString(lipgloss.NewStyle().
+		Height(visible).
+		Render(strings.Join(lines[offset:end], "\n")))
+	b.WriteString("\n")
+	b.WriteString(helpView)
+
+	return appStyle.Render(b.String())
+}
+
+// entryView renders a single search result with its project and labels.
+func (m searchModel) entryView(entry searchEntry, selected bool) string {
+	projectColor := helpers.GetColorCode(entry.project.Color)
+
+	titleStyle := lipgloss.NewStyle().
+		Width(max(m.width-60, 20)).
+		PaddingLeft(1)
+
+	if selected {
+		titleStyle = titleStyle.
+			Border(lipgloss.NormalBorder(), false, false, false, true).
+			BorderForeground(projectColor).
+			Bold(true)
+	} else {
+		titleStyle = titleStyle.MarginLeft(1)
+	}
+
+	if entry.task.Completed {
+		titleStyle = titleStyle.Strikethrough(true)
+	}
+
+	labels := ""
+	if len(entry.task.Labels) > 0 {
+		labels = entry.task.CropTaskLabels(30)
+	}
+
+	return lipgloss.JoinHorizontal(lipgloss.Top,
+		titleStyle.Render(entry.task.CropTaskTitle(taskEntryLength)),
+		lipgloss.NewStyle().
+			Width(24).
+			Foreground(projectColor).
+			Render(entry.project.Title),
+		lipgloss.NewStyle().
+			Foreground(colors.Indigo()).
+			Render(labels),
+	)
+}
@@ -0,0 +1,434 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package models
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/handlebargh/yatto/internal/colors"
+	"github.com/handlebargh/yatto/internal/config"
+	"github.com/handlebargh/yatto/internal/vcs"
+)
+
+// settingsModel defines the Bubble Tea model for a form-based
+// interface used to change the settings in the config file.
+type settingsModel struct {
+	form          *huh.Form
+	listModel     *ProjectListModel
+	cancel        bool
+	saving        bool
+	err           error
+	width, height int
+	lg            *lipgloss.Renderer
+	styles        *Styles
+	vars          *settingsVars
+}
+
+// settingsVars holds the temporary values that are populated
+// and modified in the settings form UI.
+type settingsVars struct {
+	confirm       bool
+	formTheme     string
+	colorTheme    string
+	themes        []string
+	authorShow    bool
+	assigneeShow  bool
+	listCompact   bool
+	plain         bool
+	lightColors   []string
+	darkColors    []string
+	vcsSection    string
+	defaultBranch string
+	remoteEnable  bool
+	remoteName    string
+	remoteURL     string
+	testRemote    bool
+}
+
+// settingsSavedMsg is sent when the settings were written to the config file.
+type settingsSavedMsg struct {
+	cfg *config.Config
+}
+
+// settingsErrorMsg is sent when the settings could not be saved.
+type settingsErrorMsg struct {
+	err error
+}
+
+// newSettingsModel initializes and returns a new settingsModel
+// populated with the current configuration.
+func newSettingsModel(listModel *ProjectListModel) settingsModel {
+	c := listModel.config
+	cfg := config.Get(c)
+	remote := cfg.Remote()
+
+	v := settingsVars{
+		confirm:       true,
+		formTheme:     cfg.ColorsFormTheme,
+		colorTheme:    cfg.ColorsTheme,
+		themes:        colors.ThemeNames(colors.ThemeDir(c)),
+		authorShow:    cfg.AuthorShow,
+		assigneeShow:  cfg.AssigneeShow,
+		listCompact:   cfg.ListCompact,
+		plain:         cfg.UIPlain,
+		vcsSection:    vcs.RemoteSection(c),
+		defaultBranch: remote.DefaultBranch,
+		remoteEnable:  remote.Enable,
+		remoteName:    remote.Name,
+		remoteURL:     remote.URL,
+		testRemote:    true,
+	}
+
+	for _, name := range colors.Names {
+		v.lightColors = append(v.lightColors, cfg.Colors[name+"_light"])
+		v.darkColors = append(v.darkColors, cfg.Colors[name+"_dark"])
+	}
+
+	m := settingsModel{
+		listModel: listModel,
+		vars:      &v,
+		lg:        lipgloss.DefaultRenderer(),
+	}
+	m.styles = NewStyles(m.lg)
+	m.form = m.newForm()
+
+	return m
+}
+
+// newForm builds the settings form on top of the current values,
+// so that it can be shown again after saving failed.
+func (m settingsModel) newForm() *huh.Form {
+	colorInputs := func(values []string) []huh.Field {
+		var fields []huh.Field
+		for i, name := range colors.Names {
+			fields = append(fields, huh.NewInput().
+				Title(strings.ReplaceAll(name, "_", " ")).
+				Inline(true).
+				Value(&values[i]))
+		}
+		return fields
+	}
+
+	themeOptions := []huh.Option[string]{huh.NewOption("None, use the colors below", "")}
+	themeOptions = append(themeOptions, huh.NewOptions(m.vars.themes...)...)
+
+	return huh.NewForm(
+		huh.NewGroup(
+			huh.NewSelect[string]().
+				Title("Color theme").
+				Description("Themes set the colors and the form theme.").
+				Options(themeOptions...).
+				Value(&m.vars.colorTheme),
+
+			huh.NewSelect[string]().
+				Title("Form theme").
+				Options(huh.NewOptions("Charm", "Dracula", "Catppuccin", "Base16", "Base")...).
+				Value(&m.vars.formTheme),
+
+			huh.NewConfirm().
+				Title("Show task authors?").
+				Affirmative("Yes").
+				Negative("No").
+				Value(&m.vars.authorShow),
+
+			huh.NewConfirm().
+				Title("Show task assignees?").
+				Affirmative("Yes").
+				Negative("No").
+				Value(&m.vars.assigneeShow),
+
+			huh.NewConfirm().
+				Title("Show tasks in a single line each?").
+				Affirmative("Yes").
+				Negative("No").
+				Value(&m.vars.listCompact),
+
+			huh.NewConfirm().
+				Title("Use plain output without colors and Unicode symbols?").
+				Affirmative("Yes").
+				Negative("No").
+				Value(&m.vars.plain),
+		),
+
+		huh.NewGroup(colorInputs(m.vars.lightColors)...).
+			Title("Colors on light backgrounds").
+			Description("Hex color codes, e.g. #FE5F86"),
+
+		huh.NewGroup(colorInputs(m.vars.darkColors)...).
+			Title("Colors on dark backgrounds").
+			Description("Hex color codes, e.g. #FE5F86"),
+
+		huh.NewGroup(
+			huh.NewInput().
+				Title("Default branch").
+				Value(&m.vars.defaultBranch),
+
+			huh.NewConfirm().
+				Title("Sync with a remote repository?").
+				Affirmative("Yes").
+				Negative("No").
+				Value(&m.vars.remoteEnable),
+
+			huh.NewInput().
+				Title("Remote name").
+				Value(&m.vars.remoteName),
+
+			huh.NewInput().
+				Title("Remote repository URL").
+				Value(&m.vars.remoteURL),
+
+			huh.NewConfirm().
+				Title("Test the connection to the remote before saving?").
+				Affirmative("Yes").
+				Negative("No").
+				Value(&m.vars.testRemote),
+		).
+			Title("Version control").
+			Description("Changes take effect after a restart.").
+			WithHide(m.vars.vcsSection == ""),
+
+		huh.NewGroup(
+			huh.NewConfirm().
+				Title("Save settings?").
+				Affirmative("Yes").
+				Negative("No").
+				Value(&m.vars.confirm),
+		)).
+		WithWidth(80).
+		WithShowHelp(false).
+		WithShowErrors(false).
+		WithTheme(colors.FormTheme())
+}
+
+// values returns the settings of the form keyed by their config name.
+func (v *settingsVars) values() map[string]any {
+	values := map[string]any{
+		"colors.form.theme": v.formTheme,
+		"colors.theme":      v.colorTheme,
+		"author.show":       v.authorShow,
+		"assignee.show":     v.assigneeShow,
+		"list.compact":      v.listCompact,
+		"ui.plain":          v.plain,
+	}
+
+	for i, name := range colors.Names {
+		values["colors."+name+"_light"] = strings.TrimSpace(v.lightColors[i])
+		values["colors."+name+"_dark"] = strings.TrimSpace(v.darkColors[i])
+	}
+
+	if v.vcsSection != "" {
+		values[v.vcsSection+".default_branch"] = strings.TrimSpace(v.defaultBranch)
+		values[v.vcsSection+".remote.enable"] = v.remoteEnable
+		values[v.vcsSection+".remote.name"] = strings.TrimSpace(v.remoteName)
+		values[v.vcsSection+".remote.url"] = strings.TrimSpace(v.remoteURL)
+	}
+
+	return values
+}
+
+// remoteToTest returns the remote URL to test before saving, or an
+// empty string if the remote is not to be tested.
+func (v *settingsVars) remoteToTest() string {
+	if v.vcsSection == "" || !v.remoteEnable || !v.testRemote {
+		return ""
+	}
+
+	return strings.TrimSpace(v.remoteURL)
+}
+
+// saveCmd writes the settings to the config file. The new colors are
+// applied right away, the rest is applied by the project list. If asked
+// to, the remote is tested first and nothing is saved if it cannot be
+// reached.
+func (m settingsModel) saveCmd() tea.Cmd {
+	v := m.listModel.config
+	values := m.vars.values()
+	testURL := m.vars.remoteToTest()
+
+	// The repository keeps its own copy of the remote URL.
+	newURL := ""
+	if section := m.vars.vcsSection; section != "" {
+		if url := strings.TrimSpace(m.vars.remoteURL); url != "" && url != config.Get(v).Remote().URL {
+			newURL = url
+		}
+	}
+
+	return func() tea.Msg {
+		if testURL != "" {
+			if err := vcs.TestRemote(v, testURL); err != nil {
+				return settingsErrorMsg{err: err}
+			}
+		}
+
+		next, cfg, err := config.Update(v, values)
+		if err != nil {
+			return settingsErrorMsg{err: err}
+		}
+
+		if newURL != "" {
+			if err := vcs.SetRemoteURL(next, newURL); err != nil {
+				return settingsErrorMsg{err: fmt.Errorf("settings saved, but the repository remote could not be changed: %w", err)}
+			}
+		}
+
+		colors.Configure(next)
+		return settingsSavedMsg{cfg: cfg}
+	}
+}
+
+// Init initializes the settings model and returns the initial command to run.
+func (m settingsModel) Init() tea.Cmd {
+	return m.form.Init()
+}
+
+// Update processes incoming messages and updates the model state accordingly.
+func (m settingsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if m.saving {
+			return m, nil
+		}
+
+		if m.cancel {
+			switch msg.String() {
+			case "y", "Y":
+				return m.listModel, nil
+			case "n", "N":
+				m.cancel = false
+				return m, nil
+			}
+		}
+
+		switch msg.String() {
+		case "ctrl+c":
+			return m, tea.Quit
+		case "esc":
+			m.cancel = true
+			return m, nil
+		}
+
+	case tea.WindowSizeMsg:
+		h, v := appStyle.GetFrameSize()
+		m.width = msg.Width - h
+		m.height = msg.Height - v
+
+	case settingsSavedMsg:
+		return m.listModel, tea.Batch(
+			func() tea.Msg { return returnedToProjectListMsg{} },
+			func() tea.Msg { return ConfigChangedMsg{Config: msg.cfg} },
+		)
+
+	case settingsErrorMsg:
+		// Show the form again, so the invalid value can be fixed.
+		m.saving = false
+		m.err = msg.err
+		m.vars.confirm = true
+		m.form = m.newForm()
+		return m, m.form.Init()
+	}
+
+	var cmds []tea.Cmd
+
+	form, cmd := m.form.Update(msg)
+	if f, ok := form.(*huh.Form); ok {
+		m.form = f
+		cmds = append(cmds, cmd)
+	}
+
+	if m.form.State == huh.StateCompleted && !m.saving {
+		if !m.vars.confirm {
+			return m.listModel, nil
+		}
+
+		m.saving = true
+		m.err = nil
+		cmds = append(cmds, m.saveCmd())
+	}
+
+	return m, tea.Batch(cmds...)
+}
+
+// View renders the settings form UI.
+func (m settingsModel) View() string {
+	if m.cancel {
+		return lipgloss.NewStyle().
+			Width(m.width).
+			Height(m.height).
+			Align(lipgloss.Center).
+			AlignVertical(lipgloss.Center).
+			Render("Discard changes?\n\n[y] Yes   [n] No")
+	}
+
+	if m.saving {
+		if m.vars.remoteToTest() != "" {
+			return m.styles.Base.Render(m.appBoundaryView("Testing the remote and saving settings…"))
+		}
+		return m.styles.Base.Render(m.appBoundaryView("Saving settings…"))
+	}
+
+	// Form
+	v := strings.TrimSuffix(m.form.View(), "\n\n")
+	form := m.lg.NewStyle().Margin(1, 0).Render(v)
+
+	header := m.appBoundaryView("Settings")
+	if m.err != nil {
+		header = m.appErrorBoundaryView(m.err.Error())
+	}
+
+	footer := m.appBoundaryView(m.form.Help().ShortHelpView(m.form.KeyBinds()))
+
+	var b strings.Builder
+
+	b.WriteString(header)
+	b.WriteString("\n")
+	b.WriteString(form)
+	b.WriteString("\n\n")
+	b.WriteString(footer)
+
+	return m.styles.Base.Render(b.String())
+}
+
+// appBoundaryView returns a formatted header with colored boundaries,
+// used for visual separation in the UI.
+func (m settingsModel) appBoundaryView(text string) string {
+	return lipgloss.PlaceHorizontal(
+		m.width,
+		lipgloss.Left,
+		m.styles.HeaderText.Foreground(colors.Indigo()).Render(text),
+		lipgloss.WithWhitespaceChars("❯"),
+		lipgloss.WithWhitespaceForeground(colors.Indigo()),
+	)
+}
+
+// appErrorBoundaryView returns a styled horizontal boundary with error-specific colors.
+func (m settingsModel) appErrorBoundaryView(text string) string {
+	return lipgloss.PlaceHorizontal(
+		m.width,
+		lipgloss.Left,
+		m.styles.ErrorHeaderText.Render(text),
+		lipgloss.WithWhitespaceChars("❯"),
+		lipgloss.WithWhitespaceForeground(colors.Red()),
+	)
+}
@@ -0,0 +1,159 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/handlebargh/yatto/internal/storage"
+	"github.com/handlebargh/yatto/internal/vcs"
+	"github.com/spf13/viper"
+)
+
+// statusBarHeight is the number of lines the status bar
+// takes below the project and task lists.
+const statusBarHeight = 1
+
+// statusBarStyle matches the status bar of the list above.
+var statusBarStyle = lipgloss.NewStyle().
+	Foreground(lipgloss.AdaptiveColor{Light: "#A49FA5", Dark: "#777777"})
+
+// loadSyncStatus reads the number of unpushed commits and the time of the
+// last sync from the state file, which the vcs package keeps up to date.
+// The status bar is informational only, so errors are ignored.
+func (s *projectListState) loadSyncStatus(v *viper.Viper) {
+	state, err := storage.ReadState(v)
+	if err != nil {
+		return
+	}
+
+	s.unpushed = state.UnpushedCommits
+	s.lastSync = state.LastSync
+}
+
+// syncStatus returns the status bar parts describing queued commits,
+// unpushed commits and the last sync with the remote.
+func (m *ProjectListModel) syncStatus(now time.Time) []string {
+	var parts []string
+	if vcs.CommitPending() {
+		parts = append(parts, "⋯ committing")
+	}
+
+	if !vcs.RemoteEnabled(m.config) {
+		return parts
+	}
+
+	if m.state.unpushed > 0 {
+		parts = append(parts, fmt.Sprintf("⇡ %d unpushed", m.state.unpushed))
+	}
+
+	switch {
+	case m.state.syncing:
+		parts = append(parts, "⟳ syncing")
+	case m.state.lastSync.IsZero():
+		parts = append(parts, "never synced")
+	default:
+		parts = append(parts, "synced "+sinceString(now.Sub(m.state.lastSync)))
+	}
+
+	return parts
+}
+
+// listFilterStatus returns the status bar part describing the
+// filter typed into the list, or an empty string if there is none.
+func listFilterStatus(l list.Model) string {
+	if l.FilterState() == list.Unfiltered {
+		return ""
+	}
+
+	return fmt.Sprintf("filter: %q", l.FilterValue())
+}
+
+// renderStatusBar renders the parts on the left and right
+// in a single line of the given width. Without any parts,
+// the line is left empty.
+func renderStatusBar(width int, left, right []string) string {
+	if len(left) == 0 && len(right) == 0 {
+		return ""
+	}
+
+	l := strings.Join(left, " · ")
+	r := strings.Join(right, " · ")
+
+	gap := max(width-lipgloss.Width(l)-lipgloss.Width(r), 1)
+
+	return statusBarStyle.
+		MaxWidth(width).
+		Render(l + strings.Repeat(" ", gap) + r)
+}
+
+// sinceString describes a duration in the past, e.g. "5m ago".
+func sinceString(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd ago", int(d.Hours()/24))
+	}
+}
+
+// statusBar renders the status bar of the project list.
+func (m ProjectListModel) statusBar() string {
+	var left []string
+	if f := listFilterStatus(m.list); f != "" {
+		left = append(left, f)
+	}
+	if n := len(m.state.selectedItems); n > 0 {
+		left = append(left, fmt.Sprintf("%d selected", n))
+	}
+
+	return renderStatusBar(m.list.Width(), left, m.syncStatus(time.Now()))
+}
+
+// statusBar renders the status bar of the task list.
+func (m taskListModel) statusBar() string {
+	left := []string{m.project.Title}
+	if m.sortMode != "" {
+		left = append(left, "sort: "+m.sortMode)
+	}
+	if filters := m.filters(); len(filters) > 0 {
+		left = append(left, strings.Join(filters, ", "))
+	}
+	if f := listFilterStatus(m.list); f != "" {
+		left = append(left, f)
+	}
+	if m.showArchived {
+		left = append(left, "archived")
+	}
+	if n := len(m.selectedItems); n > 0 {
+		left = append(left, fmt.Sprintf("%d selected", n))
+	}
+
+	return renderStatusBar(m.areaWidth, left, m.projectModel.syncStatus(time.Now()))
+}
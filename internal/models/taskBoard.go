@@ -0,0 +1,449 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package models
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/handlebargh/yatto/internal/colors"
+	"github.com/handlebargh/yatto/internal/helpers"
+	"github.com/handlebargh/yatto/internal/items"
+)
+
+const (
+	// boardColumnTodo holds open tasks that are not in progress.
+	boardColumnTodo = iota
+
+	// boardColumnInProgress holds tasks that are in progress.
+	boardColumnInProgress
+
+	// boardColumnCompleted holds completed tasks.
+	boardColumnCompleted
+
+	// boardColumnCount is the number of columns on the board.
+	boardColumnCount
+)
+
+// boardColumnTitles defines the header of each board column.
+var boardColumnTitles = [boardColumnCount]string{"todo", "in progress", "completed"}
+
+// boardKeyMap defines the key bindings used in the board view.
+type boardKeyMap struct {
+	quit       key.Binding
+	up         key.Binding
+	down       key.Binding
+	prevColumn key.Binding
+	nextColumn key.Binding
+	moveLeft   key.Binding
+	moveRight  key.Binding
+	showTask   key.Binding
+}
+
+// newBoardKeyMap initializes and returns a new key map for board actions.
+func newBoardKeyMap() *boardKeyMap {
+	return &boardKeyMap{
+		quit: key.NewBinding(
+			key.WithKeys("q", "esc", "B"),
+			key.WithHelp("q/esc", "go back"),
+		),
+		up: key.NewBinding(
+			key.WithKeys("up", "k"),
+			key.WithHelp("↑/k", "up"),
+		),
+		down: key.NewBinding(
+			key.WithKeys("down", "j"),
+			key.WithHelp("↓/j", "down"),
+		),
+		prevColumn: key.NewBinding(
+			key.WithKeys("left", "shift+tab"),
+			key.WithHelp("←", "prev column"),
+		),
+		nextColumn: key.NewBinding(
+			key.WithKeys("right", "tab"),
+			key.WithHelp("→", "next column"),
+		),
+		moveLeft: key.NewBinding(
+			key.WithKeys("h"),
+			key.WithHelp("h", "move task left"),
+		),
+		moveRight: key.NewBinding(
+			key.WithKeys("l"),
+			key.WithHelp("l", "move task right"),
+		),
+		showTask: key.NewBinding(
+			key.WithKeys("enter"),
+			key.WithHelp("enter", "show task"),
+		),
+	}
+}
+
+// boardModel represents the Bubble Tea model for the kanban board view
+// of a project. It wraps the task list model, so all state changes run
+// through the same write and commit pipeline as the list.
+type boardModel struct {
+	listModel     *taskListModel
+	keys          *boardKeyMap
+	help          help.Model
+	column        int
+	cursors       [boardColumnCount]int
+	status        string
+	width, height int
+}
+
+// newBoardModel creates a new boardModel for the given task list.
+func newBoardModel(listModel *taskListModel) boardModel {
+	m := boardModel{
+		listModel: listModel,
+		keys:      newBoardKeyMap(),
+		help:      help.New(),
+	}
+
+	// Focus the column of the task selected in the list.
+	if t, ok := listModel.list.SelectedItem().(*items.Task); ok {
+		m.column = boardColumn(t)
+		for i, task := range m.columnTasks(m.column) {
+			if task.ID == t.ID {
+				m.cursors[m.column] = i
+			}
+		}
+	}
+
+	return m
+}
+
+// boardColumn returns the board column a task belongs to.
+func boardColumn(t *items.Task) int {
+	switch {
+	case t.Completed:
+		return boardColumnCompleted
+	case t.InProgress:
+		return boardColumnInProgress
+	default:
+		return boardColumnTodo
+	}
+}
+
+// columnTasks returns all tasks of the list that belong to the given column
+// in list order.
+func (m boardModel) columnTasks(column int) []*items.Task {
+	var tasks []*items.Task
+	for _, item := range m.listModel.list.Items() {
+		if t, ok := item.(*items.Task); ok && boardColumn(t) == column {
+			tasks = append(tasks, t)
+		}
+	}
+
+	return tasks
+}
+
+// selectedTask returns the task under the cursor of the focused column
+// or nil if the column is empty.
+func (m boardModel) selectedTask() *items.Task {
+	tasks := m.columnTasks(m.column)
+	if len(tasks) == 0 {
+		return nil
+	}
+
+	return tasks[min(m.cursors[m.column], len(tasks)-1)]
+}
+
+// Init initializes the boardModel and returns an initial command.
+func (m boardModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles incoming messages and updates the boardModel accordingly.
+// Messages that are not key presses are forwarded to the task list model,
+// which owns the spinner and handles write and commit results.
+func (m boardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if msg.Type == tea.KeyCtrlC {
+			return m, tea.Quit
+		}
+
+		if m.listModel.spinning {
+			return m, nil
+		}
+
+		if m.listModel.mode == modeBackendError {
+			updated, cmd := m.listModel.handleBackendErrorKey(msg)
+			lm, ok := updated.(taskListModel)
+			if !ok {
+				return updated, cmd
+			}
+			*m.listModel = lm
+			return m, cmd
+		}
+
+		m.status = ""
+
+		switch {
+		case key.Matches(msg, m.keys.quit):
+			return m.listModel, nil
+
+		case key.Matches(msg, m.keys.up):
+			if m.cursors[m.column] > 0 {
+				m.cursors[m.column]--
+			}
+
+		case key.Matches(msg, m.keys.down):
+			if m.cursors[m.column] < len(m.columnTasks(m.column))-1 {
+				m.cursors[m.column]++
+			}
+
+		case key.Matches(msg, m.keys.prevColumn):
+			m.column = (m.column - 1 + boardColumnCount) % boardColumnCount
+
+		case key.Matches(msg, m.keys.nextColumn):
+			m.column = (m.column + 1) % boardColumnCount
+
+		case key.Matches(msg, m.keys.moveLeft):
+			return m.moveSelectedTask(-1)
+
+		case key.Matches(msg, m.keys.moveRight):
+			return m.moveSelectedTask(1)
+
+		case key.Matches(msg, m.keys.showTask):
+			t := m.selectedTask()
+			if t == nil || m.listModel.projectModel.state.renderer == nil {
+				return m, nil
+			}
+
+			if idx := t.FindListIndexByID(m.listModel.list.Items()); idx >= 0 {
+				m.listModel.list.Select(idx)
+			}
+
+			return newTaskPagerModel(t.TaskToMarkdown(), m.listModel), tea.WindowSize()
+		}
+
+		return m, nil
+
+	case tea.WindowSizeMsg:
+		h, v := appStyle.GetFrameSize()
+		m.width = msg.Width - h
+		m.height = msg.Height - v
+	}
+
+	updated, cmd := m.listModel.Update(msg)
+	if lm, ok := updated.(taskListModel); ok {
+		*m.listModel = lm
+	}
+
+	return m, cmd
+}
+
+// moveSelectedTask moves the selected task to the adjacent column in the
+// given direction (-1 for left, 1 for right) by changing its state.
+// The change is written to disk and committed by the task list model.
+func (m boardModel) moveSelectedTask(direction int) (tea.Model, tea.Cmd) {
+	t := m.selectedTask()
+	if t == nil {
+		return m, nil
+	}
+
+	target := m.column + direction
+	if target < boardColumnTodo || target > boardColumnCompleted {
+		return m, nil
+	}
+
+	var (
+		toggleFunc   func(*items.Task)
+		precondition = func(_ *items.Task) (bool, string) { return true, "" }
+		kind         string
+		actionName   string
+	)
+
+	switch target {
+	case boardColumnTodo:
+		toggleFunc = func(t *items.Task) { t.InProgress = false }
+		kind, actionName = "stop", "progress"
+	case boardColumnInProgress:
+		if errMsg := m.listModel.wipLimitError([]*items.Task{t}); errMsg != "" {
+			m.status = errMsg
+			return m, nil
+		}
+
+		if m.column == boardColumnCompleted {
+			toggleFunc = func(t *items.Task) { t.Completed = false; t.InProgress = true }
+			kind, actionName = "reopen", "completion"
+		} else {
+			toggleFunc = func(t *items.Task) { t.InProgress = true }
+			kind, actionName = "start", "progress"
+		}
+	case boardColumnCompleted:
+		toggleFunc = func(t *items.Task) { t.Completed = true; t.InProgress = false }
+		precondition = m.listModel.completionPrecondition
+		kind, actionName = "complete", "completion"
+	}
+
+	if ok, msg := precondition(t); !ok {
+		m.status = msg
+		return m, nil
+	}
+
+	// Clear previous selections.
+	for k := range m.listModel.selectedItems {
+		delete(m.listModel.selectedItems, k)
+	}
+	m.listModel.selectedItems[t.ID] = t
+
+	lm, cmds := m.listModel.toggleTasks(
+		toggleFunc,
+		precondition,
+		func(_ *items.Task) string { return kind },
+		actionName,
+	)
+	delete(lm.selectedItems, t.ID)
+	*m.listModel = lm
+
+	// Follow the task into its new column.
+	m.column = target
+	for i, task := range m.columnTasks(target) {
+		if task.ID == t.ID {
+			m.cursors[target] = i
+		}
+	}
+
+	// Keep the cursor of the source column in bounds.
+	source := target - direction
+	m.cursors[source] = max(0, min(m.cursors[source], len(m.columnTasks(source))-1))
+
+	return m, tea.Batch(cmds...)
+}
+
+// View returns the string representation of the board view.
+func (m boardModel) View() string {
+	if m.listModel.spinning || m.listModel.mode == modeBackendError {
+		return m.listModel.View()
+	}
+
+	projectColor := helpers.GetColorCode(m.listModel.project.Color)
+
+	title := lipgloss.NewStyle().
+		Foreground(colors.BadgeText()).
+		Background(projectColor).
+		Padding(0, 1).
+		Render(m.listModel.project.DisplayTitle())
+
+	if m.status != "" {
+		title = lipgloss.JoinHorizontal(lipgloss.Top, title, lipgloss.NewStyle().
+			Foreground(colors.Red()).
+			PaddingLeft(2).
+			Render(m.status))
+	}
+
+	helpView := m.help.ShortHelpView([]key.Binding{
+		m.keys.up,
+		m.keys.down,
+		m.keys.prevColumn,
+		m.keys.nextColumn,
+		m.keys.moveLeft,
+		m.keys.moveRight,
+		m.keys.showTask,
+		m.keys.quit,
+	})
+
+	columnWidth := max(m.width/boardColumnCount, 20)
+	// Reserve space for the title, help line and column borders.
+	columnHeight := max(m.height-lipgloss.Height(title)-lipgloss.Height(helpView)-4, 3)
+
+	columns := make([]string, 0, boardColumnCount)
+	for column := range boardColumnCount {
+		columns = append(columns, m.columnView(column, columnWidth, columnHeight, projectColor))
+	}
+
+	var b strings.Builder
+
+	b.WriteString(title)
+	b.WriteString("\n\n")
+	b.WriteString(lipgloss.JoinHorizontal(lipgloss.Top, columns...))
+	b.WriteString("\n")
+	b.WriteString(helpView)
+
+	return appStyle.Render(b.String())
+}
+
+// columnView renders a single board column with its header and tasks.
+// Only as many tasks as fit into height are rendered, scrolled so that
+// the cursor stays visible.
+func (m boardModel) columnView(column, width, height int, color lipgloss.AdaptiveColor) string {
+	tasks := m.columnTasks(column)
+	focused := column == m.column
+
+	header := lipgloss.NewStyle().
+		Bold(true).
+		Render(fmt.Sprintf("%s (%d)", boardColumnTitles[column], len(tasks)))
+
+	innerWidth := max(width-4, 10)
+	visible := max(height-2, 1)
+	offset := max(0, m.cursors[column]-visible+1)
+
+	var b strings.Builder
+	b.WriteString(header)
+	b.WriteString("\n")
+
+	for i := offset; i < len(tasks) && i < offset+visible; i++ {
+		t := tasks[i]
+
+		style := lipgloss.NewStyle().
+			Width(innerWidth).
+			PaddingLeft(1)
+
+		switch t.Priority {
+		case "low":
+			style = style.BorderForeground(colors.Indigo())
+		case "medium":
+			style = style.BorderForeground(colors.Orange())
+		case "high":
+			style = style.BorderForeground(colors.Red())
+		}
+
+		if focused && i == m.cursors[column] {
+			style = style.
+				Border(lipgloss.NormalBorder(), false, false, false, true).
+				Bold(true)
+		} else {
+			style = style.MarginLeft(1)
+		}
+
+		b.WriteString("\n")
+		b.WriteString(style.Render(t.CropTaskTitle(innerWidth - 2)))
+	}
+
+	borderColor := lipgloss.AdaptiveColor{Light: "#BBBBBB", Dark: "#444444"}
+	if focused {
+		borderColor = color
+	}
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(borderColor).
+		Width(width-2).
+		Height(height).
+		Padding(0, 1).
+		Render(b.String())
+}
@@ -21,25 +21,28 @@
 package models
 
 import (
+	"cmp"
 	"errors"
 	"fmt"
-	"path/filepath"
-	"regexp"
+	"maps"
 	"slices"
-	"strconv"
 	"strings"
 	"time"
 
+	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/huh"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/handlebargh/yatto/internal/colors"
 	"github.com/handlebargh/yatto/internal/helpers"
+	"github.com/handlebargh/yatto/internal/hooks"
 	"github.com/handlebargh/yatto/internal/items"
+	"github.com/handlebargh/yatto/internal/logging"
 	"github.com/handlebargh/yatto/internal/storage"
 	"github.com/handlebargh/yatto/internal/vcs"
 	"github.com/muesli/reflow/wordwrap"
+	"github.com/spf13/viper"
 )
 
 const (
@@ -63,6 +66,8 @@ const (
 // used to create or edit a task.
 type taskFormModel struct {
 	form            *huh.Form
+	description     *huh.Text
+	editorErr       error
 	task            *items.Task
 	listModel       *taskListModel
 	taskLabels      map[string]int
@@ -84,52 +89,110 @@ type taskFormVars struct {
 	taskDescription    string
 	taskPriority       string
 	taskDueDate        string
+	taskStartDate      string
+	taskEstimate       string
+	taskReminders      string
+	taskFields         map[string]*string
+	taskSubtasks       string
+	taskRecurrence     string
+	taskBlockedBy      []string
 	taskLabels         string
 	taskLabelsSelected []string
 	taskAuthor         string
 	taskAssignee       string
 	taskAssigneeNew    string
+	taskWatchers       []string
+	taskWatchersNew    string
 	taskCompleted      bool
 }
 
+// fields returns the text fields of the form by name, see formInput.
+func (v *taskFormVars) fields() map[string]*string {
+	return map[string]*string{
+		"title":        &v.taskTitle,
+		"description":  &v.taskDescription,
+		"priority":     &v.taskPriority,
+		"due":          &v.taskDueDate,
+		"start":        &v.taskStartDate,
+		"estimate":     &v.taskEstimate,
+		"reminders":    &v.taskReminders,
+		"subtasks":     &v.taskSubtasks,
+		"recurrence":   &v.taskRecurrence,
+		"labels":       &v.taskLabels,
+		"assignee":     &v.taskAssignee,
+		"assignee_new": &v.taskAssigneeNew,
+		"watchers_new": &v.taskWatchersNew,
+	}
+}
+
 // newTaskFormModel initializes and returns a new taskFormModel instance,
 // optionally in edit mode.
 func newTaskFormModel(t *items.Task, listModel *taskListModel, edit bool) taskFormModel {
-	v := taskFormVars{
+	return newTaskFormModelWithVars(t, listModel, edit, newTaskFormVars(t))
+}
+
+// newTaskFormVars returns the form values of the task t.
+func newTaskFormVars(t *items.Task) *taskFormVars {
+	return &taskFormVars{
 		confirm:            true,
 		taskTitle:          t.Title,
 		taskDescription:    t.Description,
 		taskPriority:       t.Priority,
 		taskDueDate:        t.DueDateToString(),
+		taskStartDate:      t.StartDateToString(),
+		taskEstimate:       t.Estimate.String(),
+		taskReminders:      t.Reminders.String(),
+		taskSubtasks:       t.Subtasks.String(),
+		taskRecurrence:     t.Recurrence.String(),
+		taskBlockedBy:      slices.Clone(t.BlockedBy),
 		taskLabels:         "", // Clear labels as we have them already selected.
 		taskLabelsSelected: t.LabelsList(),
 		taskAuthor:         t.Author,
 		taskAssignee:       t.Assignee,
 		taskAssigneeNew:    "", // Clear this field
+		taskWatchers:       slices.Clone(t.Watchers),
 		taskCompleted:      t.Completed,
 	}
+}
 
+// newTaskFormModelWithVars returns a taskFormModel for the task t
+// whose fields start with the given values, e.g. restored after a crash.
+func newTaskFormModelWithVars(t *items.Task, listModel *taskListModel, edit bool, vars *taskFormVars) taskFormModel {
 	m := taskFormModel{}
 	m.edit = edit
-	m.vars = &v
+	m.vars = vars
 	m.task = t
 	m.listModel = listModel
-	m.taskLabels = helpers.AllLabels(m.listModel.projectModel.config)
+	// Without labels, the form just offers no suggestions.
+	labels, err := helpers.AllLabels(m.listModel.projectModel.config)
+	if err != nil {
+		logging.Warn("cannot read labels", "err", err)
+	}
+	m.taskLabels = labels
 	m.lg = lipgloss.DefaultRenderer()
 	m.styles = NewStyles(m.lg)
 
 	var confirmQuestion string
 	if edit {
 		if m.vars.taskAuthor == "" {
-			m.vars.taskAuthor, _ = vcs.User(m.listModel.projectModel.config)
+			m.vars.taskAuthor = m.listModel.projectModel.currentUser()
 		}
 		confirmQuestion = "Edit task?"
 	} else {
 		// Ignore error for now
-		m.vars.taskAuthor, _ = vcs.User(m.listModel.projectModel.config)
+		m.vars.taskAuthor = m.listModel.projectModel.currentUser()
 		confirmQuestion = "Create task?"
 	}
 
+	// The form opens the user's editor itself, see editDescriptionCmd,
+	// so that an invalid EDITOR is reported instead of ignored.
+	m.description = huh.NewText().
+		Key("description").
+		Title("Enter a description:\n" +
+			"(markdown is supported)").
+		ExternalEditor(false).
+		Value(&m.vars.taskDescription)
+
 	m.form = huh.NewForm(
 		huh.NewGroup(
 			huh.NewSelect[string]().
@@ -150,64 +213,97 @@ func newTaskFormModel(t *items.Task, listModel *taskListModel, edit bool) taskFo
 					return nil
 				}),
 
-			huh.NewText().
-				Key("description").
-				Title("Enter a description:\n"+
-					"(markdown is supported)").
-				Value(&m.vars.taskDescription),
+			m.description,
 		),
 		huh.NewGroup(
-			huh.NewInput().
+			newCalendarField(&m.vars.taskDueDate).
 				Key("dueDate").
-				Title(`Valid input formats:
-
-	tomorrow
-	next tuesday (or any other weekday)
-	in a week
-	in a month
-	in 3 days
-	in 6 weeks
-
-	15:04 (assume today)
-
-	2006-01-28
-	2006-01-28 15:04:05
+				Title("Select a due date:").
+				Validate(func(t time.Time) error {
+					if !m.edit && dueInPast(t, time.Now()) {
+						return errors.New("due date must be in the future")
+					}
 
-	28.01.2006
-	28.01.2006 15:04
+					return nil
+				}),
 
-	28/01/2006
-	28/01/2006 15:04
+			huh.NewInput().
+				Key("recurrence").
+				Title("Repeat:").
+				Description("daily, weekly, monthly, yearly or\n"+
+					"every <n> days|weeks|months|years\n"+
+					"Leave empty for a one-off task.").
+				Value(&m.vars.taskRecurrence).
+				Validate(func(str string) error {
+					if _, err := items.ParseRecurrence(str); err != nil {
+						return errors.New("invalid recurrence")
+					}
 
-	Or RFC3339
+					return nil
+				}),
 
-Date will be in your local timezone
-				`).
-				Value(&m.vars.taskDueDate).
+			huh.NewInput().
+				Key("estimate").
+				Title("Estimate:").
+				Description("Expected effort, e.g. 45m or 1h30m.\n"+
+					"Leave empty for no estimate.").
+				Value(&m.vars.taskEstimate).
 				Validate(func(str string) error {
-					if str == "" {
-						return nil
+					if _, err := items.ParseEstimate(str); err != nil {
+						return errors.New("invalid estimate")
 					}
 
-					t, err := parseShortcut(str)
-					if err == nil {
-						m.vars.taskDueDate = t.Format(time.DateTime)
-						return nil
-					}
+					return nil
+				}),
 
-					t, err = parseFlexibleDate(str)
-					if err != nil {
-						return fmt.Errorf("invalid format")
+			huh.NewInput().
+				Key("reminders").
+				Title("Remind me:").
+				Description("Lead times before the due date, e.g. 1d, 1h.\n"+
+					"Leave empty for no reminders.").
+				Value(&m.vars.taskReminders).
+				Validate(func(str string) error {
+					if _, err := items.ParseReminders(str); err != nil {
+						return errors.New("invalid reminders")
 					}
 
-					if !m.edit && t.Before(time.Now()) {
-						return errors.New("due date must be in the future")
+					return nil
+				}),
+		).Title("Due Date"),
+
+		huh.NewGroup(
+			newCalendarField(&m.vars.taskStartDate).
+				Key("startDate").
+				Title("Hide the task until:").
+				Validate(func(t time.Time) error {
+					due, err := time.ParseInLocation(time.DateTime, m.vars.taskDueDate, time.Local)
+					if err == nil && t.After(due) {
+						return errors.New("start date must not be after the due date")
 					}
 
-					m.vars.taskDueDate = t.Format(time.DateTime)
 					return nil
 				}),
-		).Title("Due Date"),
+		).Title("Start Date"),
+
+		huh.NewGroup(
+			huh.NewText().
+				Key("subtasks").
+				Title("Enter subtasks:").
+				Description("One subtask per line.\n"+
+					"Prefix a line with [x] to mark it as done.").
+				Value(&m.vars.taskSubtasks),
+		).Title("Subtasks"),
+
+		huh.NewGroup(
+			huh.NewMultiSelect[string]().
+				Key("blockedBy").
+				Title("Blocked by:").
+				Description("The task cannot be completed\n"+
+					"while any of these tasks are open.").
+				Height(15).
+				Options(m.blockedByOptions()...).
+				Value(&m.vars.taskBlockedBy),
+		).Title("Dependencies"),
 
 		huh.NewGroup(
 			huh.NewMultiSelect[string]().
@@ -222,17 +318,13 @@ Date will be in your local timezone
 				Value(&m.vars.taskLabels).
 				Description("Comma-separated list of labels."),
 		).Title("Labels"),
-		huh.NewGroup(
-			huh.NewInput().
-				Key("author").
-				Title("Enter the task author:").
-				Value(&m.vars.taskAuthor).
-				Description("This will set the task author."),
-		).Title("Author"),
+		m.customFieldsGroup(),
+		m.authorGroup(),
 		huh.NewGroup(
 			huh.NewSelect[string]().
 				Key("existingEmailAddresses").
 				Title("Choose an assignee:").
+				Description("Press / to search.").
 				Height(15).
 				OptionsFunc(m.sortEmailAddressesOptions, nil).
 				Value(&m.vars.taskAssignee),
@@ -241,8 +333,43 @@ Date will be in your local timezone
 				Key("newEmailAddress").
 				Title("Enter a new email address:").
 				Value(&m.vars.taskAssigneeNew).
-				Description("This will overwrite the selected assignee."),
+				Description("E.g. Jane Doe jane@example.com.\n"+
+					"This will overwrite the selected assignee.").
+				Validate(func(str string) error {
+					if strings.TrimSpace(str) == "" {
+						return nil
+					}
+
+					if _, err := helpers.NormalizeAssignee(str); err != nil {
+						return errors.New("invalid email address")
+					}
+
+					return nil
+				}),
 		).Title("Assignee"),
+		huh.NewGroup(
+			huh.NewMultiSelect[string]().
+				Key("watchers").
+				Title("Choose watchers:").
+				Description("Watchers find the task among their own tasks.").
+				Height(15).
+				OptionsFunc(m.watcherOptions, nil).
+				Value(&m.vars.taskWatchers),
+
+			huh.NewInput().
+				Key("newWatchers").
+				Title("Enter additional watchers:").
+				Value(&m.vars.taskWatchersNew).
+				Description("Comma-separated list, e.g.\n"+
+					"Jane Doe jane@example.com, john@example.com.").
+				Validate(func(str string) error {
+					if _, err := parseWatchers(str); err != nil {
+						return errors.New("invalid email address")
+					}
+
+					return nil
+				}),
+		).Title("Watchers"),
 		huh.NewGroup(
 			huh.NewConfirm().
 				Title(confirmQuestion).
@@ -288,6 +415,11 @@ func (m taskFormModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 
+		m.editorErr = nil
+		if key.Matches(msg, m.listModel.keys.editDescription) && m.descriptionFocused() {
+			return m, editDescriptionCmd(m.vars.taskDescription)
+		}
+
 		switch msg.Type {
 		case tea.KeyPgUp:
 			m.previewViewport.ScrollUp(previewLinesToScroll)
@@ -312,6 +444,17 @@ func (m taskFormModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.height = msg.Height - v
 
 		m.previewViewport = viewport.New(previewWidth, m.height-previewVerticalPadding)
+
+	case editorFinishedMsg:
+		if msg.err != nil {
+			m.editorErr = msg.err
+			return m, nil
+		}
+
+		m.vars.taskDescription = msg.content
+		m.description.Value(&m.vars.taskDescription)
+		m.previewViewport.SetContent(m.generatePreviewContent())
+		return m, nil
 	}
 
 	form, cmd := m.form.Update(msg)
@@ -331,35 +474,52 @@ func (m taskFormModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	if m.form.State == huh.StateCompleted {
 		if m.vars.confirm {
 			if m.vars.taskAssigneeNew != "" {
-				m.vars.taskAssignee = m.vars.taskAssigneeNew
+				// The address was validated by the form.
+				m.vars.taskAssignee, _ = helpers.NormalizeAssignee(m.vars.taskAssigneeNew)
 			}
 
+			// The addresses were validated by the form.
+			watchers, _ := parseWatchers(m.vars.taskWatchersNew)
+			m.vars.taskWatchers = append(m.vars.taskWatchers, watchers...)
+			m.vars.taskWatchersNew = ""
+
 			err := m.formVarsToTask()
 			if err != nil {
 				// TODO: we should probably return a message here.
 				return m, nil
 			}
 
-			json := m.task.MarshalTask()
-			taskPath := filepath.Join(m.listModel.project.ID, m.task.ID+".json")
+			taskPath := m.task.Path(*m.listModel.project)
 
 			action := "create"
 			if storage.FileExists(m.listModel.projectModel.config, taskPath) {
 				action = "update"
 			}
 
+			config := m.listModel.projectModel.config
+			write := m.task.WriteTaskJSON(config, *m.listModel.project, action)
+			hookCalls := m.task.HookCalls(*m.listModel.project, action)
+
 			m.listModel.spinning = true
 			cmds = append(
 				cmds,
 				m.listModel.spinner.Tick,
-				m.task.WriteTaskJSON(m.listModel.projectModel.config, json, *m.listModel.project, action),
-				vcs.CommitCmd(
-					m.listModel.projectModel.config,
-					fmt.Sprintf("%s: %s", action, m.task.Title),
-					taskPath,
+				tea.Sequence(
+					write,
+					vcs.AfterCommitCmd(
+						vcs.CommitCmd(config, fmt.Sprintf("%s: %s", action, m.task.Title), taskPath),
+						hooks.RunCmd(config, hookCalls),
+					),
 				),
 			)
 
+			if m.task.Assignee != "" {
+				cmds = append(cmds, rememberAssigneeCmd(m.listModel.projectModel.config, m.task.Assignee))
+			}
+			for _, watcher := range m.task.Watchers {
+				cmds = append(cmds, rememberAssigneeCmd(m.listModel.projectModel.config, watcher))
+			}
+
 			m.listModel.status = ""
 			return m.listModel, tea.Batch(cmds...)
 		}
@@ -422,13 +582,21 @@ func (m taskFormModel) View() string {
 		Render(m.previewViewport.View())
 
 	e := m.form.Errors()
+	if m.editorErr != nil {
+		e = append(e, m.editorErr)
+	}
 
 	if len(e) > 0 {
 		header = m.appErrorBoundaryView(m.errorView())
 	}
 	body := lipgloss.JoinHorizontal(lipgloss.Left, form, status)
 
-	footer := m.appBoundaryView(m.form.Help().ShortHelpView(m.form.KeyBinds()))
+	keyBinds := m.form.KeyBinds()
+	if m.descriptionFocused() {
+		keyBinds = append(keyBinds, m.listModel.keys.editDescription)
+	}
+
+	footer := m.appBoundaryView(m.form.Help().ShortHelpView(keyBinds))
 	if len(e) > 0 {
 		footer = m.appErrorBoundaryView("")
 	}
@@ -444,15 +612,24 @@ func (m taskFormModel) View() string {
 	return s.Base.Render(b.String())
 }
 
-// errorView returns a string representation of validation error messages.
+// errorView returns a string representation of validation error messages
+// and of the error that occurred in the user's editor.
 func (m taskFormModel) errorView() string {
 	var b strings.Builder
 	for _, err := range m.form.Errors() {
 		b.WriteString(err.Error())
 	}
+	if m.editorErr != nil {
+		b.WriteString(m.editorErr.Error())
+	}
 	return b.String()
 }
 
+// descriptionFocused reports whether the description field has focus.
+func (m taskFormModel) descriptionFocused() bool {
+	return m.form.GetFocusedField() == huh.Field(m.description)
+}
+
 // appBoundaryView returns a formatted header with colored boundaries,
 // used for visual separation in the UI.
 func (m taskFormModel) appBoundaryView(text string) string {
@@ -518,11 +695,54 @@ func (m taskFormModel) generatePreviewContent() string {
 	b.WriteString("\n\n")
 	b.WriteString(wordwrap.String(m.vars.taskDescription, previewWidth-previewContentPadding))
 
+	// Add recurrence if set
+	if r, err := items.ParseRecurrence(m.vars.taskRecurrence); err == nil && r != nil {
+		b.WriteString("\n\nRepeats:\n")
+		b.WriteString(r.String())
+	}
+
+	// Add estimate if set
+	if e, err := items.ParseEstimate(m.vars.taskEstimate); err == nil && e != 0 {
+		b.WriteString("\n\nEstimate:\n")
+		b.WriteString(e.String())
+	}
+
+	// Add reminders if set
+	if r, err := items.ParseReminders(m.vars.taskReminders); err == nil && len(r) > 0 {
+		b.WriteString("\n\nReminders:\n")
+		b.WriteString(r.String() + " before due")
+	}
+
+	// Add subtasks if set
+	if subtasks := items.ParseSubtasks(m.vars.taskSubtasks); len(subtasks) > 0 {
+		b.WriteString("\n\nSubtasks:\n")
+		b.WriteString(wordwrap.String(subtasks.String(), previewWidth-previewContentPadding))
+	}
+
+	// Add blockers if set
+	if len(m.vars.taskBlockedBy) > 0 {
+		var blockers []string
+		for _, t := range tasksFromItems(m.listModel.list.Items()) {
+			if slices.Contains(m.vars.taskBlockedBy, t.ID) {
+				blockers = append(blockers, "- "+t.Title)
+			}
+		}
+
+		b.WriteString("\n\nBlocked by:\n")
+		b.WriteString(wordwrap.String(strings.Join(blockers, "\n"), previewWidth-previewContentPadding))
+	}
+
+	// Add start date if set
+	if t, err := helpers.ParseFlexibleDate(m.vars.taskStartDate); err == nil {
+		b.WriteString("\n\nHidden until:\n")
+		b.WriteString(t.Format(time.RFC1123))
+	}
+
 	// Add due date if set
-	if t, err := parseShortcut(m.vars.taskDueDate); err == nil {
+	if t, err := helpers.ParseShortcut(m.vars.taskDueDate); err == nil {
 		b.WriteString("\n\nDue Date:\n")
 		b.WriteString(t.Format(time.RFC1123))
-	} else if t, err = parseFlexibleDate(m.vars.taskDueDate); err == nil {
+	} else if t, err = helpers.ParseFlexibleDate(m.vars.taskDueDate); err == nil {
 		b.WriteString("\n\nDue Date:\n")
 		b.WriteString(t.Format(time.RFC1123))
 	}
@@ -532,20 +752,21 @@ func (m taskFormModel) generatePreviewContent() string {
 
 // formVarsToTask updates the Task object with values from the form variables.
 //
-// It sets the task's title, description, priority, author, assignee, completion status,
-// and due date.
+// It sets the task's title, description, priority, author, assignee, watchers, completion status,
+// due date, start date, estimate, reminders and custom fields.
 // For labels, it merges labels selected via the multi-select widget with additional
 // labels entered as a comma-separated string, deduplicates them (case-insensitive),
 // trims whitespace, and stores them as a single comma-separated string on the task.
 //
-// Returns an error if the due date string cannot be parsed or the local time zone
-// cannot be loaded.
+// Returns an error if the due or start date, recurrence, estimate or reminders cannot be parsed or the
+// local time zone cannot be loaded.
 func (m taskFormModel) formVarsToTask() error {
 	m.task.Title = m.vars.taskTitle
 	m.task.Description = m.vars.taskDescription
 	m.task.Priority = m.vars.taskPriority
 	m.task.Author = m.vars.taskAuthor
 	m.task.Assignee = m.vars.taskAssignee
+	m.task.Watchers = watchersWithout(m.vars.taskWatchers, m.task.Assignee)
 
 	// Merge labels from MultiSelect (selected) and freeform input (typed)
 	typedLabels := helpers.LabelsStringToSlice(m.vars.taskLabels)
@@ -568,28 +789,163 @@ func (m taskFormModel) formVarsToTask() error {
 	}
 
 	m.task.Labels = uniqueLabels
+	m.task.Subtasks = items.ParseSubtasks(m.vars.taskSubtasks)
+	m.task.BlockedBy = slices.Clone(m.vars.taskBlockedBy)
+
+	recurrence, err := items.ParseRecurrence(m.vars.taskRecurrence)
+	if err != nil {
+		return err
+	}
+	m.task.Recurrence = recurrence
+
+	estimate, err := items.ParseEstimate(m.vars.taskEstimate)
+	if err != nil {
+		return err
+	}
+	m.task.Estimate = estimate
+
+	reminders, err := items.ParseReminders(m.vars.taskReminders)
+	if err != nil {
+		return err
+	}
+	m.task.Reminders = reminders
+
+	// Values of fields no longer defined by the project are kept.
+	fields := maps.Clone(m.task.Fields)
+	for name, value := range m.vars.taskFields {
+		if v := strings.TrimSpace(*value); v != "" {
+			if fields == nil {
+				fields = make(map[string]string)
+			}
+			fields[name] = v
+		} else {
+			delete(fields, name)
+		}
+	}
+	if len(fields) == 0 {
+		fields = nil
+	}
+	m.task.Fields = fields
 
 	m.task.Completed = m.vars.taskCompleted
 
+	location, err := time.LoadLocation("Local")
+	if err != nil {
+		return err
+	}
+
+	oldDueDate := m.task.DueDate
 	if m.vars.taskDueDate != "" {
-		location, err := time.LoadLocation("Local")
+		date, err := time.ParseInLocation(time.DateTime, m.vars.taskDueDate, location)
 		if err != nil {
 			return err
 		}
 
-		date, err := time.ParseInLocation(time.DateTime, m.vars.taskDueDate, location)
+		m.task.DueDate = &date
+	} else {
+		m.task.DueDate = nil
+	}
+
+	// Only changes of existing tasks are recorded.
+	if m.edit {
+		m.task.RecordDueChange(oldDueDate, time.Now())
+	}
+
+	if m.vars.taskStartDate != "" {
+		date, err := time.ParseInLocation(time.DateTime, m.vars.taskStartDate, location)
 		if err != nil {
 			return err
 		}
 
-		m.task.DueDate = &date
+		m.task.StartDate = &date
 	} else {
-		m.task.DueDate = nil
+		m.task.StartDate = nil
 	}
 
 	return nil
 }
 
+// authorGroup returns the form group showing the author of the task.
+// The author is set to the current user when the task is created and
+// cannot be changed in the form.
+func (m taskFormModel) authorGroup() *huh.Group {
+	return huh.NewGroup(
+		huh.NewNote().
+			Title("Task author:").
+			Description(cmp.Or(m.vars.taskAuthor, "Unknown") + "\n\n" +
+				"The author of a task cannot be changed."),
+	).Title("Author")
+}
+
+// customFieldsGroup returns the form group with an input for each custom
+// field of the project. Enum fields are offered as a selection. The group
+// is hidden if the project has no custom fields.
+func (m taskFormModel) customFieldsGroup() *huh.Group {
+	defs := m.listModel.project.Fields
+	m.vars.taskFields = make(map[string]*string, len(defs))
+
+	var fields []huh.Field
+	for _, def := range defs {
+		value := m.task.Fields[def.Name]
+		m.vars.taskFields[def.Name] = &value
+
+		if def.Type == items.FieldEnum {
+			options := []huh.Option[string]{huh.NewOption("(none)", "")}
+			for _, o := range def.Options {
+				options = append(options, huh.NewOption(o, o))
+			}
+
+			fields = append(fields, huh.NewSelect[string]().
+				Title(def.Name+":").
+				Options(options...).
+				Value(&value))
+			continue
+		}
+
+		input := huh.NewInput().
+			Title(def.Name + ":").
+			Value(&value).
+			Validate(func(str string) error {
+				return def.Validate(strings.TrimSpace(str))
+			})
+		if def.Type == items.FieldDate {
+			input.Placeholder(time.Now().Format(time.DateOnly))
+		}
+		fields = append(fields, input)
+	}
+
+	// A group needs at least one field, even if it is hidden.
+	if len(fields) == 0 {
+		fields = append(fields, huh.NewNote())
+	}
+
+	return huh.NewGroup(fields...).
+		Title("Custom Fields").
+		WithHideFunc(func() bool { return len(defs) == 0 })
+}
+
+// blockedByOptions returns the tasks of the current project that may block
+// the edited task. The task itself and tasks already blocked by it are left
+// out to prevent direct cycles.
+func (m taskFormModel) blockedByOptions() []huh.Option[string] {
+	var options []huh.Option[string]
+	for _, t := range tasksFromItems(m.listModel.list.Items()) {
+		if t.ID == m.task.ID || slices.Contains(t.BlockedBy, m.task.ID) {
+			continue
+		}
+
+		label := t.CropTaskTitle(taskEntryLength)
+		if t.Completed {
+			label += " (completed)"
+		}
+
+		options = append(options, huh.NewOption(label, t.ID).
+			Selected(slices.Contains(m.vars.taskBlockedBy, t.ID)))
+	}
+
+	return options
+}
+
 // sortLabelsOptions returns a slice of huh.Option[string] representing the task labels,
 // sorted with the following priority:
 //  1. Labels currently selected in the form appear first.
@@ -659,8 +1015,52 @@ func (m taskFormModel) sortLabelsOptions() []huh.Option[string] {
 	return opts
 }
 
+// rememberAssigneeCmd returns a command that adds the assignee to the
+// assignees offered by the task form, see storage.AddAssignee.
+// Remembering the assignee is a convenience only, so errors are ignored.
+func rememberAssigneeCmd(v *viper.Viper, assignee string) tea.Cmd {
+	return func() tea.Msg {
+		_ = storage.AddAssignee(v, assignee)
+		return nil
+	}
+}
+
+// assigneeCandidates returns the assignees offered by the task form:
+// the entries of the team roster, the contributors of the repository and
+// the assignees chosen before, see helpers.Roster, vcs.AllContributors
+// and storage.Assignees. Entries with the same email address as an
+// earlier one are left out.
+func assigneeCandidates(v *viper.Viper) []string {
+	roster, _ := helpers.Roster(v)
+	contributors, _ := vcs.AllContributors(v)
+
+	var candidates []string
+	seen := make(map[string]bool)
+	for _, list := range [][]string{roster, contributors, storage.Assignees(v)} {
+		for _, candidate := range list {
+			fields := strings.Fields(candidate)
+			if len(fields) == 0 {
+				continue
+			}
+
+			email := strings.ToLower(fields[len(fields)-1])
+			if !seen[email] {
+				seen[email] = true
+				candidates = append(candidates, candidate)
+			}
+		}
+	}
+
+	return candidates
+}
+
+// sortEmailAddressesOptions returns the options of the assignee select,
+// see assigneeCandidates, followed by an option to clear the assignee.
 func (m taskFormModel) sortEmailAddressesOptions() []huh.Option[string] {
-	emails, _ := vcs.AllContributors(m.listModel.projectModel.config)
+	emails := assigneeCandidates(m.listModel.projectModel.config)
+	if m.task.Assignee != "" && !slices.Contains(emails, m.task.Assignee) {
+		emails = append(emails, m.task.Assignee)
+	}
 
 	// Sort: selected first, then author's address, then alphabetical
 	slices.SortFunc(emails, func(a, b string) int {
@@ -685,7 +1085,7 @@ func (m taskFormModel) sortEmailAddressesOptions() []huh.Option[string] {
 	})
 
 	// Build sorted options
-	opts := make([]huh.Option[string], 0, len(emails))
+	opts := make([]huh.Option[string], 0, len(emails)+1)
 	for _, item := range emails {
 		opt := huh.NewOption(item, item)
 		if item == m.task.Assignee {
@@ -694,109 +1094,71 @@ func (m taskFormModel) sortEmailAddressesOptions() []huh.Option[string] {
 		opts = append(opts, opt)
 	}
 
+	return append(opts, huh.NewOption("No assignee", ""))
+}
+
+// watcherOptions returns the options of the watchers select, see
+// assigneeCandidates, including the current watchers of the task.
+func (m taskFormModel) watcherOptions() []huh.Option[string] {
+	candidates := assigneeCandidates(m.listModel.projectModel.config)
+	for _, watcher := range m.vars.taskWatchers {
+		if !slices.Contains(candidates, watcher) {
+			candidates = append(candidates, watcher)
+		}
+	}
+	slices.SortFunc(candidates, func(a, b string) int {
+		return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+	})
+
+	opts := make([]huh.Option[string], 0, len(candidates))
+	for _, c := range candidates {
+		opts = append(opts, huh.NewOption(c, c).
+			Selected(slices.Contains(m.vars.taskWatchers, c)))
+	}
+
 	return opts
 }
 
-// parseFlexibleDate parses a string into a time.Time value, supporting a variety of common date and time formats.
-// It handles ISO 8601, localized formats (e.g., "DD.MM.YYYY", "MM/DD/YYYY"), time-only inputs (assumed for today),
-// and RFC3339. If the input does not match any supported format, it returns an error.
-//
-// Examples of valid inputs:
-//   - "2026-02-14"
-//   - "14.02.2026 15:04"
-//   - "02/14/2026"
-//   - "15:04" (assumes today's date)
-//   - "2006-01-02T15:04:05Z07:00"
-func parseFlexibleDate(str string) (time.Time, error) {
-	now := time.Now()
-	layouts := []string{
-		time.DateTime,      // "2006-01-02 15:04:05"
-		"2006-01-02",       // "2006-02-14"
-		"02.01.2006 15:04", // "14.02.2026 15:04"
-		"02.01.2006",       // "14.02.2026"
-		"02/01/2006 15:04", // "02/14/2026 15:04"
-		"02/01/2006",       // "02/14/2026"
-		"15:04",            // "15:04" (assume today)
-		time.RFC3339,       // "2006-01-02T15:04:05Z07:00"
+// dueInPast reports whether the due date t lies before now. A due date
+// without a time of day, i.e. at midnight, lasts the whole day, so
+// only the calendar dates are compared then and today is not past.
+func dueInPast(t, now time.Time) bool {
+	if t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0 {
+		y, m, d := now.Date()
+		return t.Before(time.Date(y, m, d, 0, 0, 0, 0, t.Location()))
 	}
 
-	if len(str) <= 5 {
-		if t, err := time.Parse("15:04", str); err == nil {
-			return time.Date(now.Year(), now.Month(), now.Day(), t.Hour(), t.Minute(), 0, 0, now.Location()), nil
+	return t.Before(now)
+}
+
+// parseWatchers parses a comma-separated list of email addresses,
+// see helpers.NormalizeAssignee. Empty entries are skipped.
+func parseWatchers(s string) ([]string, error) {
+	var watchers []string
+	for _, entry := range strings.Split(s, ",") {
+		if strings.TrimSpace(entry) == "" {
+			continue
 		}
-	}
 
-	for _, layout := range layouts {
-		t, err := time.Parse(layout, str)
-		if err == nil {
-			return t, nil
+		watcher, err := helpers.NormalizeAssignee(entry)
+		if err != nil {
+			return nil, err
 		}
+		watchers = append(watchers, watcher)
 	}
 
-	return time.Time{}, fmt.Errorf("unsupported date format")
+	return watchers, nil
 }
 
-// parseShortcut parses natural language date shortcuts into a time.Time value.
-// It supports expressions like "tomorrow", "in 3 days", "in 2 weeks", "next monday", etc.
-// All returned times are set to midnight (00:00:00) in the local timezone.
-// If the input does not match any supported shortcut, it returns an error.
-//
-// Examples of valid inputs:
-//   - "tomorrow"
-//   - "in 3 days"
-//   - "in 2 weeks"
-//   - "next monday"
-func parseShortcut(str string) (time.Time, error) {
-	now := time.Now()
-	todayAtMidnight := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
-
-	re := regexp.MustCompile(`^in (\d+) (days|weeks)$`)
-	matches := re.FindStringSubmatch(strings.ToLower(str))
-	if len(matches) == 3 {
-		amount, _ := strconv.Atoi(matches[1])
-		unit := matches[2]
-		switch unit {
-		case "days":
-			return todayAtMidnight.AddDate(0, 0, amount), nil
-		case "weeks":
-			return todayAtMidnight.AddDate(0, 0, amount*7), nil
-		}
-	}
-
-	switch strings.ToLower(str) {
-	case "tomorrow":
-		return todayAtMidnight.AddDate(0, 0, 1), nil
-	case "in a week":
-		return todayAtMidnight.AddDate(0, 0, 7), nil
-	case "in a month":
-		return todayAtMidnight.AddDate(0, 1, 0), nil
-	case "next monday":
-		return nextWeekday(todayAtMidnight, time.Monday), nil
-	case "next tuesday":
-		return nextWeekday(todayAtMidnight, time.Tuesday), nil
-	case "next wednesday":
-		return nextWeekday(todayAtMidnight, time.Wednesday), nil
-	case "next thursday":
-		return nextWeekday(todayAtMidnight, time.Thursday), nil
-	case "next friday":
-		return nextWeekday(todayAtMidnight, time.Friday), nil
-	case "next saturday":
-		return nextWeekday(todayAtMidnight, time.Saturday), nil
-	case "next sunday":
-		return nextWeekday(todayAtMidnight, time.Sunday), nil
-	default:
-		return time.Time{}, fmt.Errorf("unknown shortcut")
+// watchersWithout returns the watchers without duplicates and without
+// the assignee, who follows the task anyway.
+func watchersWithout(watchers []string, assignee string) []string {
+	var result []string
+	for _, watcher := range watchers {
+		if watcher != assignee && !slices.Contains(result, watcher) {
+			result = append(result, watcher)
+		}
 	}
-}
 
-// nextWeekday returns the next occurrence of a specific weekday (e.g., time.Monday)
-// after the given time t. If t's weekday is already the target day,
-// it returns the time for the same day in the following week.
-//
-// Example:
-//
-//	nextWeekday(time.Now(), time.Monday) // Next Monday at the same time as t.
-func nextWeekday(t time.Time, day time.Weekday) time.Time {
-	diff := (day - t.Weekday() + 7) % 7
-	return t.AddDate(0, 0, int(diff))
+	return result
 }
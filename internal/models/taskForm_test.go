@@ -0,0 +1,39 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDueInPast(t *testing.T) {
+	now := time.Date(2026, time.March, 10, 14, 30, 0, 0, time.Local)
+	midnight := time.Date(2026, time.March, 10, 0, 0, 0, 0, time.Local)
+
+	assert.False(t, dueInPast(midnight, now), "today without a time of day")
+	assert.True(t, dueInPast(midnight.AddDate(0, 0, -1), now), "yesterday")
+	assert.False(t, dueInPast(midnight.AddDate(0, 0, 1), now), "tomorrow")
+	assert.True(t, dueInPast(now.Add(-time.Minute), now), "earlier today")
+	assert.False(t, dueInPast(now.Add(time.Minute), now), "later today")
+}
@@ -0,0 +1,401 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/help"
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/handlebargh/yatto/internal/colors"
+	"github.com/handlebargh/yatto/internal/items"
+	"github.com/handlebargh/yatto/internal/vcs"
+)
+
+// taskHistoryKeyMap defines the key bindings used in the task history view.
+type taskHistoryKeyMap struct {
+	prev    key.Binding
+	next    key.Binding
+	restore key.Binding
+}
+
+// newTaskHistoryKeyMap initializes and returns a new key map for task history actions.
+func newTaskHistoryKeyMap() *taskHistoryKeyMap {
+	return &taskHistoryKeyMap{
+		prev: key.NewBinding(
+			key.WithKeys("up", "k"),
+			key.WithHelp("↑/k", "newer"),
+		),
+		next: key.NewBinding(
+			key.WithKeys("down", "j"),
+			key.WithHelp("↓/j", "older"),
+		),
+		restore: key.NewBinding(
+			key.WithKeys("r"),
+			key.WithHelp("r", "restore this version"),
+		),
+	}
+}
+
+// taskHistoryModel represents the Bubble Tea model for the history
+// of a single task as recorded by the configured vcs backend.
+// Any earlier version of the task can be selected and restored.
+type taskHistoryModel struct {
+	pagerModel taskPagerModel
+	task       *items.Task
+	keys       *taskHistoryKeyMap
+	help       help.Model
+	entries    []vcs.HistoryEntry
+	loading    bool
+	cmdOutput  string
+	err        error
+	cursor     int
+	starts     []int
+	restoring  bool
+	notice     string
+	ready      bool
+	viewport   viewport.Model
+}
+
+// newTaskHistoryModel creates a new taskHistoryModel for the given task.
+// The history is loaded asynchronously by the command returned from Init.
+func newTaskHistoryModel(task *items.Task, pagerModel taskPagerModel) taskHistoryModel {
+	return taskHistoryModel{
+		pagerModel: pagerModel,
+		task:       task,
+		keys:       newTaskHistoryKeyMap(),
+		help:       help.New(),
+		loading:    true,
+	}
+}
+
+// Init initializes the taskHistoryModel and starts loading the task's history.
+func (m taskHistoryModel) Init() tea.Cmd {
+	return vcs.HistoryCmd(
+		m.pagerModel.listModel.projectModel.config,
+		m.task.Path(*m.pagerModel.listModel.project),
+	)
+}
+
+// Update handles incoming messages and updates the taskHistoryModel accordingly.
+func (m taskHistoryModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if msg.Type == tea.KeyCtrlC {
+			return m, tea.Quit
+		}
+
+		switch {
+		case key.Matches(msg, m.pagerModel.listModel.keys.quit) ||
+			key.Matches(msg, m.pagerModel.listModel.keys.goBackVim):
+			return m.pagerModel, nil
+
+		case key.Matches(msg, m.keys.prev):
+			if m.cursor > 0 {
+				m.selectEntry(m.cursor - 1)
+			}
+			return m, nil
+
+		case key.Matches(msg, m.keys.next):
+			if m.cursor < len(m.entries)-1 {
+				m.selectEntry(m.cursor + 1)
+			}
+			return m, nil
+
+		case key.Matches(msg, m.keys.restore):
+			return m.startRestore()
+		}
+
+	case vcs.HistoryDoneMsg:
+		m.loading = false
+		m.entries = msg.Entries
+		m.refresh()
+		return m, nil
+
+	case vcs.HistoryErrorMsg:
+		m.loading = false
+		m.cmdOutput = msg.CmdOutput
+		m.err = msg.Err
+		m.refresh()
+		return m, nil
+
+	case vcs.FileAtDoneMsg:
+		return m.restore(msg)
+
+	case vcs.FileAtErrorMsg:
+		m.restoring = false
+		m.notice = "Cannot read this version: " + msg.Error()
+		return m, nil
+
+	case tea.WindowSizeMsg:
+		verticalMargins := lipgloss.Height(m.headerView()) + lipgloss.Height(m.footerView())
+
+		if !m.ready {
+			m.viewport = viewport.New(msg.Width, msg.Height-verticalMargins)
+			m.ready = true
+		} else {
+			m.viewport.Width = msg.Width
+			m.viewport.Height = msg.Height - verticalMargins
+		}
+
+		m.refresh()
+	}
+
+	m.viewport, cmd = m.viewport.Update(msg)
+
+	return m, cmd
+}
+
+// refresh renders the timeline into the viewport.
+func (m *taskHistoryModel) refresh() {
+	content, starts := m.timelineView()
+	m.starts = starts
+	m.viewport.SetContent(content)
+}
+
+// selectEntry moves the cursor to the entry at index i and scrolls
+// the viewport so that the entry's header is visible.
+func (m *taskHistoryModel) selectEntry(i int) {
+	m.cursor = i
+	m.notice = ""
+	m.refresh()
+
+	if i >= len(m.starts) {
+		return
+	}
+
+	end := m.viewport.TotalLineCount()
+	if i+1 < len(m.starts) {
+		end = m.starts[i+1]
+	}
+
+	switch {
+	case m.starts[i] < m.viewport.YOffset:
+		m.viewport.SetYOffset(m.starts[i])
+	case end > m.viewport.YOffset+m.viewport.Height:
+		m.viewport.SetYOffset(min(m.starts[i], end-m.viewport.Height))
+	}
+}
+
+// startRestore reads the selected version of the task. The file is
+// looked up at both its regular and its archive location, as the task
+// may have been archived or restored from the archive since.
+func (m taskHistoryModel) startRestore() (tea.Model, tea.Cmd) {
+	if m.restoring || len(m.entries) == 0 {
+		return m, nil
+	}
+
+	if m.cursor == 0 {
+		m.notice = "This is the current version"
+		return m, nil
+	}
+
+	project := *m.pagerModel.listModel.project
+	moved := *m.task
+	moved.Archived = !moved.Archived
+
+	m.restoring = true
+	m.notice = "Restoring..."
+
+	return m, vcs.FileAtCmd(
+		m.pagerModel.listModel.projectModel.config,
+		m.entries[m.cursor].Hash,
+		m.task.Path(project),
+		moved.Path(project),
+	)
+}
+
+// restore overwrites the task with the version read from the vcs,
+// writes it back to its current location and commits the change.
+// The task's position in the list, its author, due date changes and
+// successor are kept, and a changed due date is recorded.
+func (m taskHistoryModel) restore(msg vcs.FileAtDoneMsg) (tea.Model, tea.Cmd) {
+	m.restoring = false
+
+	var restored items.Task
+	if err := json.Unmarshal(msg.Content, &restored); err != nil {
+		m.notice = "Cannot read this version: " + err.Error()
+		return m, nil
+	}
+
+	old := *m.task
+	restored.ID = old.ID
+	restored.Archived = old.Archived
+	restored.Order = old.Order
+	// Like the history of due dates, the author and the next occurrence
+	// of a recurring task belong to the task rather than to a version.
+	restored.Author = old.Author
+	restored.DueChanges = old.DueChanges
+	restored.Successor = old.Successor
+	restored.RecordDueChange(old.DueDate, time.Now())
+	*m.task = restored
+
+	hash := msg.Hash
+	if len(hash) > 7 {
+		hash = hash[:7]
+	}
+
+	listModel := m.pagerModel.listModel
+	config := listModel.projectModel.config
+	project := *listModel.project
+
+	listModel.spinning = true
+	listModel.status = ""
+
+	return listModel, tea.Batch(
+		listModel.spinner.Tick,
+		tea.Sequence(
+			m.task.WriteTaskJSON(config, project, "restore"),
+			vcs.CommitCmd(
+				config,
+				fmt.Sprintf("restore: %s (%s)", m.task.Title, hash),
+				m.task.Path(project),
+			),
+		),
+	)
+}
+
+// View returns the string representation of the task history view.
+func (m taskHistoryModel) View() string {
+	if !m.ready {
+		return "\n  Initializing..."
+	}
+
+	return fmt.Sprintf("%s\n%s\n%s", m.headerView(), m.viewport.View(), m.footerView())
+}
+
+// headerView returns the title of the task history view.
+func (m taskHistoryModel) headerView() string {
+	return lipgloss.NewStyle().
+		Padding(0, 1).
+		Render(lipgloss.NewStyle().
+			Foreground(colors.BadgeText()).
+			Background(colors.Green()).
+			Padding(0, 1).
+			Render("History: " + m.task.CropTaskTitle(taskEntryLength)))
+}
+
+// footerView returns the key help and scroll position of the task history view.
+func (m taskHistoryModel) footerView() string {
+	helpView := lipgloss.NewStyle().
+		Padding(0, 1).
+		Render(m.help.ShortHelpView([]key.Binding{
+			m.keys.prev,
+			m.keys.next,
+			m.keys.restore,
+			m.pagerModel.listModel.keys.quit,
+		}))
+
+	info := lipgloss.NewStyle().
+		Padding(0, 1).
+		Render(fmt.Sprintf("%3.f%%", m.viewport.ScrollPercent()*100))
+
+	if m.notice != "" {
+		info = lipgloss.NewStyle().
+			Foreground(colors.Orange()).
+			Render(m.notice) + info
+	}
+
+	line := strings.Repeat(" ", max(0, m.viewport.Width-lipgloss.Width(helpView)-lipgloss.Width(info)))
+	return lipgloss.JoinHorizontal(lipgloss.Center, helpView, line, info)
+}
+
+// timelineView renders all history entries from newest to oldest.
+// Each entry shows its date, author and commit subject followed by
+// the lines that were added or removed in that change. The selected
+// entry is highlighted. The line at which each entry starts is
+// returned along with the content.
+func (m taskHistoryModel) timelineView() (string, []int) {
+	padding := lipgloss.NewStyle().Padding(1, 2)
+
+	switch {
+	case m.loading:
+		return padding.Render("Loading history..."), nil
+
+	case m.err != nil:
+		return padding.Render(lipgloss.NewStyle().
+			Foreground(colors.Red()).
+			Render(fmt.Sprintf("Cannot read history: %s\n\n%s", m.err, m.cmdOutput))), nil
+
+	case len(m.entries) == 0:
+		return padding.Render("No history recorded for this task yet."), nil
+	}
+
+	bullet := lipgloss.NewStyle().Foreground(colors.Green()).Render("●")
+	selectedBullet := lipgloss.NewStyle().Foreground(colors.Orange()).Render("◆")
+	rail := lipgloss.NewStyle().Foreground(lipgloss.Color("240")).Render("│")
+	dateStyle := lipgloss.NewStyle().Bold(true)
+	authorStyle := lipgloss.NewStyle().Foreground(colors.Blue())
+	hashStyle := lipgloss.NewStyle().Foreground(lipgloss.Color("240"))
+	addedStyle := lipgloss.NewStyle().Foreground(colors.Green())
+	removedStyle := lipgloss.NewStyle().Foreground(colors.Red())
+
+	var (
+		lines  []string
+		starts []int
+	)
+	for i, entry := range m.entries {
+		if i > 0 {
+			lines = append(lines, rail)
+		}
+
+		hash := entry.Hash
+		if len(hash) > 7 {
+			hash = hash[:7]
+		}
+
+		marker := bullet
+		if i == m.cursor {
+			marker = selectedBullet
+		}
+
+		// The padding adds one line on top.
+		starts = append(starts, len(lines)+1)
+		lines = append(lines, fmt.Sprintf("%s %s  %s  %s",
+			marker,
+			dateStyle.Render(entry.Date.Local().Format("Mon, 02 Jan 2006 15:04")),
+			authorStyle.Render(entry.Author),
+			hashStyle.Render(hash),
+		))
+		lines = append(lines, fmt.Sprintf("%s %s", rail, entry.Subject))
+
+		for diffLine := range strings.SplitSeq(entry.Diff, "\n") {
+			switch {
+			case strings.HasPrefix(diffLine, "+++"), strings.HasPrefix(diffLine, "---"):
+				continue
+			case strings.HasPrefix(diffLine, "+"):
+				lines = append(lines, fmt.Sprintf("%s   %s", rail, addedStyle.Render(diffLine)))
+			case strings.HasPrefix(diffLine, "-"):
+				lines = append(lines, fmt.Sprintf("%s   %s", rail, removedStyle.Render(diffLine)))
+			}
+		}
+	}
+
+	return padding.Render(strings.Join(lines, "\n")), starts
+}
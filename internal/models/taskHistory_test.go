@@ -0,0 +1,86 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package models
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/handlebargh/yatto/internal/items"
+	"github.com/handlebargh/yatto/internal/vcs"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTaskHistoryRestore_CompletedRecurringTask(t *testing.T) {
+	v := viper.New()
+	v.Set("storage.path", t.TempDir())
+	v.Set("vcs.backend", "none")
+
+	projectModel := InitialProjectListModel(v)
+	project := &items.Project{ID: uuid.NewString(), Title: "Test"}
+	listModel := newTaskListModel(project, &projectModel, 120, 40)
+
+	earlier := time.Date(2026, time.March, 1, 9, 0, 0, 0, time.UTC)
+	later := earlier.AddDate(0, 0, 7)
+	changes := []items.DueChange{{Time: earlier, From: &earlier, To: &later}}
+
+	// The task was reopened after its completion created the next occurrence.
+	task := &items.Task{
+		ID:         uuid.NewString(),
+		Title:      "Water plants",
+		Author:     "Jane Doe <jane@example.com>",
+		DueDate:    &later,
+		DueChanges: changes,
+		Recurrence: &items.Recurrence{Frequency: "weekly", Interval: 1},
+		Successor:  uuid.NewString(),
+		Order:      3,
+	}
+	current := *task
+
+	// The completed version was written before the author, the due date
+	// changes and the successor were recorded.
+	content, err := json.Marshal(items.Task{
+		ID:         "other",
+		Title:      "Water plants",
+		DueDate:    &earlier,
+		Recurrence: &items.Recurrence{Frequency: "weekly", Interval: 1},
+		Completed:  true,
+	})
+	assert.NoError(t, err)
+
+	m := newTaskHistoryModel(task, newTaskPagerModel("", &listModel))
+	m.restore(vcs.FileAtDoneMsg{Hash: "0123456789abcdef", File: task.Path(*project), Content: content})
+
+	assert.True(t, task.Completed)
+	assert.Equal(t, current.ID, task.ID)
+	assert.Equal(t, current.Order, task.Order)
+	assert.Equal(t, current.Author, task.Author)
+	assert.Equal(t, current.Successor, task.Successor)
+	assert.Nil(t, task.Recur(), "the next occurrence must not be created again")
+
+	assert.Len(t, task.DueChanges, 2)
+	assert.Equal(t, changes[0], task.DueChanges[0])
+	assert.Equal(t, &later, task.DueChanges[1].From)
+	assert.Equal(t, &earlier, task.DueChanges[1].To)
+}
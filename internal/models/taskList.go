@@ -22,6 +22,7 @@ package models
 
 import (
 	"cmp"
+	"errors"
 	"fmt"
 	"io"
 	"path/filepath"
@@ -36,33 +37,86 @@ import (
 	"github.com/charmbracelet/lipgloss"
 	"github.com/google/uuid"
 	"github.com/handlebargh/yatto/internal/colors"
+	"github.com/handlebargh/yatto/internal/config"
 	"github.com/handlebargh/yatto/internal/helpers"
+	"github.com/handlebargh/yatto/internal/hooks"
 	"github.com/handlebargh/yatto/internal/items"
+	"github.com/handlebargh/yatto/internal/storage"
 	"github.com/handlebargh/yatto/internal/vcs"
-	"github.com/spf13/viper"
 )
 
 const taskEntryLength = 53
 
+// taskPageSize is the number of task files read when a task list is
+// opened. The remaining ones are read in the background in pages of
+// the same size, so that large projects open without delay.
+const taskPageSize = 200
+
+// sortManual is the sort mode of projects whose tasks are ordered manually.
+const sortManual = "manual"
+
+// sortModes maps the sort modes of the task list to the keys they sort by.
+var sortModes = map[string][]string{
+	"priority": {"completed", "priority"},
+	"dueDate":  {"completed", "dueDate"},
+	"author":   {"completed", "author", "dueDate", "priority"},
+	"assignee": {"completed", "assignee", "dueDate", "priority"},
+	"state":    {"completed", "inProgress", "dueDate", "priority"},
+	"updated":  {"updated"},
+	"estimate": {"completed", "estimate", "priority"},
+	sortManual: {"order"},
+}
+
 // taskListKeyMap defines the key bindings used in the task list view.
 type taskListKeyMap struct {
 	quit             key.Binding
 	toggleHelpMenu   key.Binding
 	addItem          key.Binding
+	duplicateItem    key.Binding
+	quickAdd         key.Binding
+	filterLabels     key.Binding
+	filterMine       key.Binding
+	filterAssignee   key.Binding
+	filterDue        key.Binding
+	yank             key.Binding
 	chooseItem       key.Binding
 	editItem         key.Binding
+	bulkEdit         key.Binding
 	deleteItem       key.Binding
 	sortByPriority   key.Binding
 	sortByDueDate    key.Binding
 	sortByState      key.Binding
 	sortByAuthor     key.Binding
 	sortByAssignee   key.Binding
+	sortByUpdated    key.Binding
+	sortByEstimate   key.Binding
+	sortManual       key.Binding
+	moveUp           key.Binding
+	moveDown         key.Binding
 	toggleInProgress key.Binding
 	toggleComplete   key.Binding
 	goBackVim        key.Binding
 	prevPage         key.Binding
 	nextPage         key.Binding
 	toggleSelect     key.Binding
+	undo             key.Binding
+	archive          key.Binding
+	toggleArchived   key.Binding
+	toggleDeferred   key.Binding
+	nextSubtask      key.Binding
+	prevSubtask      key.Binding
+	toggleSubtask    key.Binding
+	addComment       key.Binding
+	editDescription  key.Binding
+	addAttachment    key.Binding
+	openAttachment   key.Binding
+	showBoard        key.Binding
+	showHistory      key.Binding
+	pomodoro         key.Binding
+	togglePreview    key.Binding
+	widenPreview     key.Binding
+	narrowPreview    key.Binding
+	toggleCompact    key.Binding
 }
 
 // newTaskListKeyMap initializes and returns a new key map for task list actions.
@@ -100,6 +154,26 @@ func newTaskListKeyMap() *taskListKeyMap {
 			key.WithKeys("alt+A"),
 			key.WithHelp("alt+A", "sort by assignee"),
 		),
+		sortByEstimate: key.NewBinding(
+			key.WithKeys("alt+e"),
+			key.WithHelp("alt+e", "sort by estimate"),
+		),
+		sortByUpdated: key.NewBinding(
+			key.WithKeys("alt+u"),
+			key.WithHelp("alt+u", "sort by recently updated"),
+		),
+		sortManual: key.NewBinding(
+			key.WithKeys("alt+m"),
+			key.WithHelp("alt+m", "sort manually"),
+		),
+		moveUp: key.NewBinding(
+			key.WithKeys("K"),
+			key.WithHelp("K", "move task up"),
+		),
+		moveDown: key.NewBinding(
+			key.WithKeys("J"),
+			key.WithHelp("J", "move task down"),
+		),
 		deleteItem: key.NewBinding(
 			key.WithKeys("D"),
 			key.WithHelp("D", "delete selected tasks"),
@@ -108,6 +182,10 @@ func newTaskListKeyMap() *taskListKeyMap {
 			key.WithKeys("e"),
 			key.WithHelp("e", "edit task"),
 		),
+		bulkEdit: key.NewBinding(
+			key.WithKeys("E"),
+			key.WithHelp("E", "edit selected tasks"),
+		),
 		chooseItem: key.NewBinding(
 			key.WithKeys("enter", "l"),
 			key.WithHelp("enter/l", "show task"),
@@ -116,6 +194,34 @@ func newTaskListKeyMap() *taskListKeyMap {
 			key.WithKeys("a"),
 			key.WithHelp("a", "add task"),
 		),
+		quickAdd: key.NewBinding(
+			key.WithKeys("o"),
+			key.WithHelp("o", "quick add task"),
+		),
+		filterLabels: key.NewBinding(
+			key.WithKeys("f"),
+			key.WithHelp("f", "filter by labels"),
+		),
+		filterMine: key.NewBinding(
+			key.WithKeys("m"),
+			key.WithHelp("m", "toggle my tasks"),
+		),
+		filterAssignee: key.NewBinding(
+			key.WithKeys("@"),
+			key.WithHelp("@", "filter by assignee"),
+		),
+		filterDue: key.NewBinding(
+			key.WithKeys("w"),
+			key.WithHelp("w", "filter by due date"),
+		),
+		duplicateItem: key.NewBinding(
+			key.WithKeys("y"),
+			key.WithHelp("y", "duplicate task"),
+		),
+		yank: key.NewBinding(
+			key.WithKeys("Y"),
+			key.WithHelp("Y", "copy to clipboard"),
+		),
 		toggleHelpMenu: key.NewBinding(
 			key.WithKeys("H"),
 			key.WithHelp("H", "toggle help"),
@@ -125,43 +231,173 @@ func newTaskListKeyMap() *taskListKeyMap {
 			key.WithHelp("h", "go back"),
 		),
 		prevPage: key.NewBinding(
-			key.WithKeys("left", "pgup", "b", "u"),
-			key.WithHelp("←/pgup/b/u", "prev page"),
+			key.WithKeys("left", "pgup", "b"),
+			key.WithHelp("←/pgup/b", "prev page"),
 		),
 		nextPage: key.NewBinding(
-			key.WithKeys("right", "pgdown", "f", "d"),
-			key.WithHelp("→/pgdn/f/d", "next page"),
+			key.WithKeys("right", "pgdown", "d"),
+			key.WithHelp("→/pgdn/d", "next page"),
 		),
 		toggleSelect: key.NewBinding(
 			key.WithKeys(" "),
 			key.WithHelp("space", "select/deselect"),
 		),
+		undo: key.NewBinding(
+			key.WithKeys("u"),
+			key.WithHelp("u", "undo last change"),
+		),
+		archive: key.NewBinding(
+			key.WithKeys("z"),
+			key.WithHelp("z", "archive/restore tasks"),
+		),
+		toggleDeferred: key.NewBinding(
+			key.WithKeys("S"),
+			key.WithHelp("S", "show/hide deferred tasks"),
+		),
+		toggleArchived: key.NewBinding(
+			key.WithKeys("Z"),
+			key.WithHelp("Z", "show/hide archived"),
+		),
+		nextSubtask: key.NewBinding(
+			key.WithKeys("tab"),
+			key.WithHelp("tab", "next subtask"),
+		),
+		prevSubtask: key.NewBinding(
+			key.WithKeys("shift+tab"),
+			key.WithHelp("shift+tab", "previous subtask"),
+		),
+		toggleSubtask: key.NewBinding(
+			key.WithKeys("x"),
+			key.WithHelp("x", "toggle subtask"),
+		),
+		addComment: key.NewBinding(
+			key.WithKeys("c"),
+			key.WithHelp("c", "comment"),
+		),
+		editDescription: key.NewBinding(
+			key.WithKeys("ctrl+e"),
+			key.WithHelp("ctrl+e", "edit description in $EDITOR"),
+		),
+		addAttachment: key.NewBinding(
+			key.WithKeys("A"),
+			key.WithHelp("A", "attach"),
+		),
+		openAttachment: key.NewBinding(
+			key.WithKeys("o"),
+			key.WithHelp("o", "open attachment"),
+		),
+		showBoard: key.NewBinding(
+			key.WithKeys("B"),
+			key.WithHelp("B", "show board"),
+		),
+		showHistory: key.NewBinding(
+			key.WithKeys("g"),
+			key.WithHelp("g", "history"),
+		),
+		pomodoro: key.NewBinding(
+			key.WithKeys("p"),
+			key.WithHelp("p", "start pomodoro"),
+		),
+		togglePreview: key.NewBinding(
+			key.WithKeys("v"),
+			key.WithHelp("v", "toggle preview"),
+		),
+		widenPreview: key.NewBinding(
+			key.WithKeys(">"),
+			key.WithHelp(">", "widen preview"),
+		),
+		narrowPreview: key.NewBinding(
+			key.WithKeys("<"),
+			key.WithHelp("<", "narrow preview"),
+		),
+		toggleCompact: key.NewBinding(
+			key.WithKeys("V"),
+			key.WithHelp("V", "toggle compact view"),
+		),
 	}
 }
 
+const (
+	// badgeColumnWidth is the width reserved for the badges
+	// right of the task titles.
+	badgeColumnWidth = 40
+
+	// minTitleWidth is the narrowest the column of the task titles gets.
+	// Below, the badges are stacked under the titles.
+	minTitleWidth = 30
+)
+
 // customTaskDelegate is a custom list delegate for rendering task items.
 type customTaskDelegate struct {
 	list.DefaultDelegate
 	parent *taskListModel
+
+	// stacked is set if the list is too narrow to show the badges
+	// next to the titles, see badgesStacked.
+	stacked bool
+}
+
+// badgesStacked reports whether the badges of tasks are shown
+// under their titles in a list of the given width.
+func badgesStacked(width int) bool {
+	return width < minTitleWidth+badgeColumnWidth
+}
+
+// newDelegate returns the delegate rendering the tasks of m
+// at the current width of the list.
+func (m *taskListModel) newDelegate() customTaskDelegate {
+	return customTaskDelegate{
+		DefaultDelegate: list.NewDefaultDelegate(),
+		parent:          m,
+		stacked:         badgesStacked(m.list.Width()),
+	}
 }
 
 // Height returns the delegate's preferred height.
+// In the compact view, every task takes a single line.
 func (d customTaskDelegate) Height() int {
-	showAuthor := viper.GetBool("author.show")
-	showAssignee := viper.GetBool("assignee.show")
+	if d.parent.compact() {
+		return 1
+	}
 
-	if showAuthor && showAssignee {
-		return 4
+	showAuthor := d.parent.showAuthor()
+	showAssignee := d.parent.showAssignee()
+
+	height := 2
+	switch {
+	case d.stacked:
+		// The badges and assignee take a line of their own.
+		height = 3
+		if showAuthor {
+			height++
+		}
+	case showAuthor && showAssignee:
+		height = 4
+	case showAuthor || showAssignee:
+		height = 3
 	}
 
-	if showAuthor || showAssignee {
-		return 3
+	if s := d.parent.project.Settings; s != nil {
+		height = max(height, s.ItemHeight)
 	}
 
-	return 2
+	return height
+}
+
+// Spacing returns the number of lines between the items.
+// There are none in the compact view.
+func (d customTaskDelegate) Spacing() int {
+	if d.parent.compact() {
+		return 0
+	}
+
+	return d.DefaultDelegate.Spacing()
 }
 
 // Render draws a single task item within the task list.
+// The compact view leaves out the author, labels and assignee.
+// Titles are cropped to the width of their column, so that every
+// item takes the lines given by Height at any width of the list.
 func (d customTaskDelegate) Render(w io.Writer, m list.Model, index int, item list.Item) {
 	taskItem, ok := item.(*items.Task)
 	if !ok {
@@ -173,8 +409,9 @@ func (d customTaskDelegate) Render(w io.Writer, m list.Model, index int, item li
 		return
 	}
 
-	availableWidth := max(m.Width(), 40)
-	leftWidth := max(availableWidth-40, 20)
+	width := max(m.Width(), minTitleWidth)
+	styles := &d.parent.itemStyles
+	compact := d.parent.compact()
 
 	// Check if item is selected
 	_, selected := d.parent.selectedItems[taskItem.ID]
@@ -182,50 +419,31 @@ func (d customTaskDelegate) Render(w io.Writer, m list.Model, index int, item li
 	marker := ""
 	indent := 0
 	if selected {
-		marker = lipgloss.NewStyle().
-			Foreground(colors.Red()).
-			Render("⟹  ")
+		marker = styles.marker
 		indent = 3
 	}
 
-	// Base styles.
-	titleStyle := lipgloss.NewStyle().
-		Width(leftWidth-indent).
-		Padding(0, 1)
-
-	labelsStyle := lipgloss.NewStyle().
-		Foreground(colors.Blue()).
-		Width(leftWidth-indent).
-		Padding(0, 1).
-		MarginLeft(indent)
-
-	authorStyle := lipgloss.NewStyle().
-		Padding(0, 1).
-		MarginLeft(indent)
+	badges := d.badges(taskItem, tasksFromItems(m.Items()))
 
-	priorityValueStyle := lipgloss.NewStyle().
-		Foreground(colors.BadgeText()).
-		Padding(0, 1)
+	// The title column leaves room for the badges. It grows with the
+	// list, but shrinks for badges wider than their column.
+	leftWidth := width
+	if !d.stacked {
+		leftWidth = max(width-max(lipgloss.Width(badges), badgeColumnWidth), minTitleWidth)
+	}
 
-	switch taskItem.Priority {
-	case "low":
-		titleStyle = titleStyle.BorderForeground(colors.Indigo())
-		labelsStyle = labelsStyle.BorderForeground(colors.Indigo())
-		authorStyle = authorStyle.BorderForeground(colors.Indigo())
-		priorityValueStyle = priorityValueStyle.
-			BorderForeground(colors.Indigo()).Background(colors.Indigo())
-	case "medium":
-		titleStyle = titleStyle.BorderForeground(colors.Orange())
-		labelsStyle = labelsStyle.BorderForeground(colors.Orange())
-		authorStyle = authorStyle.BorderForeground(colors.Orange())
-		priorityValueStyle = priorityValueStyle.
-			BorderForeground(colors.Orange()).Background(colors.Orange())
-	case "high":
-		titleStyle = titleStyle.BorderForeground(colors.Red())
-		labelsStyle = labelsStyle.BorderForeground(colors.Red())
-		authorStyle = authorStyle.BorderForeground(colors.Red())
-		priorityValueStyle = priorityValueStyle.
-			BorderForeground(colors.Red()).Background(colors.Red())
+	// Base styles. Border or margin take a column, the padding two more.
+	textWidth := max(leftWidth-indent-3, 1)
+	titleStyle := styles.title.Width(leftWidth - indent)
+	labelsStyle := styles.labels.Width(leftWidth - indent).MarginLeft(indent).MaxHeight(1)
+	authorStyle := styles.author.MarginLeft(indent)
+	badgesStyle := lipgloss.NewStyle().MarginLeft(indent)
+
+	if color, ok := styles.priorityColors[taskItem.Priority]; ok {
+		titleStyle = titleStyle.BorderForeground(color)
+		labelsStyle = labelsStyle.BorderForeground(color)
+		authorStyle = authorStyle.BorderForeground(color)
+		badgesStyle = badgesStyle.BorderForeground(color)
 	}
 
 	if index == m.Index() {
@@ -235,20 +453,29 @@ func (d customTaskDelegate) Render(w io.Writer, m list.Model, index int, item li
 			Border(lipgloss.NormalBorder(), false, false, false, true)
 		authorStyle = authorStyle.
 			Border(lipgloss.NormalBorder(), false, false, false, true)
+		badgesStyle = badgesStyle.
+			Border(lipgloss.NormalBorder(), false, false, false, true)
 	} else if !selected {
 		titleStyle = titleStyle.MarginLeft(1)
 		labelsStyle = labelsStyle.MarginLeft(1)
 		authorStyle = authorStyle.MarginLeft(1)
+		badgesStyle = badgesStyle.MarginLeft(1)
 	}
 
 	var left strings.Builder
 
 	// Title
 	left.WriteString(marker)
-	left.WriteString(titleStyle.Render(taskItem.CropTaskTitle(taskEntryLength)))
+	left.WriteString(titleStyle.Render(taskItem.CropTaskTitle(min(taskEntryLength, textWidth))))
+
+	// Badges and assignee under the title
+	if d.stacked && !compact {
+		left.WriteString("\n")
+		left.WriteString(badgesStyle.Render(badges + d.assignee(taskItem)))
+	}
 
 	// Author
-	if viper.GetBool("author.show") {
+	if d.parent.showAuthor() && !compact {
 		// Strip email address in list view.
 		authorSlice := strings.Split(taskItem.Author, " ")
 		authorString := strings.Join(authorSlice[:len(authorSlice)-1], " ")
@@ -259,136 +486,280 @@ func (d customTaskDelegate) Render(w io.Writer, m list.Model, index int, item li
 	}
 
 	// Labels
-	left.WriteString("\n")
-	left.WriteString(labelsStyle.Render(taskItem.CropTaskLabels(taskEntryLength)))
+	if !compact {
+		left.WriteString("\n")
+		left.WriteString(labelsStyle.Render(taskItem.CropTaskLabels(taskEntryLength)))
+	}
 
 	var right strings.Builder
 
-	right.WriteString(priorityValueStyle.Render(taskItem.Priority))
+	if !d.stacked || compact {
+		right.WriteString(badges)
+
+		// Assignee
+		if d.parent.showAssignee() && !compact {
+			right.WriteString("\n")
+			right.WriteString(d.assignee(taskItem))
+		}
+	}
+
+	row := lipgloss.JoinHorizontal(lipgloss.Top,
+		lipgloss.NewStyle().Render(left.String()),
+		right.String(),
+	)
+
+	// Fill up the lines of a project specific item height.
+	if s := d.parent.project.Settings; s != nil && s.ItemHeight > 0 && !compact {
+		row = lipgloss.NewStyle().Height(d.Height()).Render(row)
+	}
+
+	// Cut off what is left too wide, so that the terminal does not wrap it.
+	if lipgloss.Width(row) > width {
+		row = lipgloss.NewStyle().MaxWidth(width).Render(row)
+	}
+
+	_, err := fmt.Fprint(w, row)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// badges renders the priority and the status badges of a task in a line.
+func (d customTaskDelegate) badges(taskItem *items.Task, tasks []items.Task) string {
+	styles := &d.parent.itemStyles
+
+	priorityValueStyle := styles.priority
+	if color, ok := styles.priorityColors[taskItem.Priority]; ok {
+		priorityValueStyle = priorityValueStyle.BorderForeground(color).Background(color)
+	}
+
+	var b strings.Builder
+
+	b.WriteString(priorityValueStyle.Render(taskItem.Priority))
 
 	now := time.Now()
-	dueDate := taskItem.DueDate
+	urgency := d.parent.projectModel.state.due.Urgency(taskItem, now)
+
+	switch urgency {
+	case items.UrgencyOverdue:
+		b.WriteString(styles.urgent.Render("overdue"))
+	case items.UrgencySoon:
+		b.WriteString(styles.urgent.Render(taskItem.DueText()))
+	}
 
-	if dueDate != nil &&
-		items.IsToday(dueDate) &&
-		dueDate.After(now) {
-		right.WriteString(lipgloss.NewStyle().
-			Padding(0, 1).
-			Background(colors.VividRed()).
-			Foreground(colors.BadgeText()).
-			Render("due today"))
+	if d.parent.seen.unseen(taskItem) {
+		b.WriteString(styles.unseen.Render("● updated"))
 	}
 
-	if dueDate != nil && dueDate.Before(now) {
-		right.WriteString(lipgloss.NewStyle().
-			Padding(0, 1).
-			Background(colors.VividRed()).
-			Foreground(colors.BadgeText()).
-			Render("overdue"))
+	if !d.parent.projectModel.state.due.Reminder(taskItem, now).IsZero() {
+		b.WriteString(styles.reminder.Render("reminder due"))
+	}
+
+	if taskItem.Deferred(now) {
+		b.WriteString(styles.deferred.Render("starts " + taskItem.StartDate.Format(time.DateOnly)))
 	}
 
 	if taskItem.InProgress {
-		right.WriteString(lipgloss.NewStyle().
-			Padding(0, 1).
-			Background(colors.Blue()).
-			Foreground(colors.BadgeText()).
-			Render("in progress"))
+		b.WriteString(styles.inProgress.Render("in progress"))
 	}
 
-	if dueDate != nil &&
-		!dueDate.Before(now) &&
-		!items.IsToday(dueDate) {
-		right.WriteString(lipgloss.NewStyle().
-			Padding(0, 1).
-			Background(colors.Yellow()).
-			Foreground(colors.BadgeText()).
-			Render("due in " + taskItem.DaysUntilToString() + " day(s)"))
+	if len(taskItem.OpenBlockers(tasks)) > 0 {
+		b.WriteString(styles.blocked.Render("blocked"))
 	}
 
-	if taskItem.Completed {
-		right.Reset()
-		right.WriteString(lipgloss.NewStyle().
-			Padding(0, 1).
-			Background(colors.Green()).
-			Foreground(colors.BadgeText()).
-			Render("completed"))
+	if urgency == items.UrgencyLater {
+		b.WriteString(styles.later.Render(taskItem.DueText()))
 	}
 
-	// Assignee
-	me, _ := vcs.User(d.parent.projectModel.config)
-	if viper.GetBool("assignee.show") {
-		// Strip email address in list view.
-		assigneeSlice := strings.Split(taskItem.Assignee, " ")
-		assigneeString := strings.Join(assigneeSlice[:len(assigneeSlice)-1], " ")
-
-		right.WriteString("\n")
-		if taskItem.Assignee == me {
-			right.WriteString(
-				lipgloss.NewStyle().
-					Foreground(colors.BadgeText()).
-					Background(colors.Red()).
-					Padding(0, 1).
-					Render(assigneeString),
-			)
-		} else {
-			right.WriteString(
-				lipgloss.NewStyle().
-					Foreground(colors.BadgeText()).
-					Background(colors.Green()).
-					Padding(0, 1).
-					Render(assigneeString),
-			)
+	if taskItem.Recurrence != nil {
+		b.WriteString(styles.info.Render("↻ " + taskItem.Recurrence.String()))
+	}
+
+	if taskItem.Estimate != 0 {
+		b.WriteString(styles.info.Render("⏱ " + taskItem.Estimate.String()))
+	}
+
+	if done, total := taskItem.ChecklistProgress(); total > 0 {
+		subtaskStyle := styles.info
+		if done == total {
+			subtaskStyle = styles.subtasksDone
 		}
+		b.WriteString(subtaskStyle.Render(fmt.Sprintf("%d/%d done", done, total)))
 	}
 
-	row := lipgloss.JoinHorizontal(lipgloss.Top,
-		lipgloss.NewStyle().Render(left.String()),
-		right.String(),
-	)
+	if taskItem.Completed {
+		b.Reset()
+		b.WriteString(styles.completed.Render("completed"))
+	}
 
-	_, err := fmt.Fprint(w, row)
-	if err != nil {
-		panic(err)
+	if taskItem.Archived {
+		b.WriteString(styles.archived.Render("archived"))
+	}
+
+	return b.String()
+}
+
+// assignee renders the assignee badge of a task, followed by a badge
+// if the current user watches it, or an empty string if assignees are
+// not shown.
+func (d customTaskDelegate) assignee(taskItem *items.Task) string {
+	if !d.parent.showAssignee() {
+		return ""
+	}
+
+	// Strip email address in list view.
+	assigneeSlice := strings.Split(taskItem.Assignee, " ")
+	assigneeString := strings.Join(assigneeSlice[:len(assigneeSlice)-1], " ")
+
+	me := d.parent.projectModel.currentUser()
+
+	var b strings.Builder
+	switch {
+	case taskItem.Assignee == "":
+	case taskItem.Assignee == me:
+		b.WriteString(d.parent.itemStyles.assignedToMe.Render(assigneeString))
+	default:
+		b.WriteString(d.parent.itemStyles.assignee.Render(assigneeString))
+	}
+
+	if taskItem.Watching(me) {
+		b.WriteString(d.parent.itemStyles.watching.Render("watching"))
+	}
+
+	return b.String()
+}
+
+// taskItemStyles holds the styles of the items in the task list. They are
+// built along with the other styles of the list by applyStyles rather than
+// in every call of customTaskDelegate.Render.
+type taskItemStyles struct {
+	marker         string
+	title          lipgloss.Style
+	labels         lipgloss.Style
+	author         lipgloss.Style
+	priority       lipgloss.Style
+	priorityColors map[string]lipgloss.AdaptiveColor
+	info           lipgloss.Style
+	urgent         lipgloss.Style
+	later          lipgloss.Style
+	reminder       lipgloss.Style
+	unseen         lipgloss.Style
+	deferred       lipgloss.Style
+	inProgress     lipgloss.Style
+	blocked        lipgloss.Style
+	subtasksDone   lipgloss.Style
+	completed      lipgloss.Style
+	archived       lipgloss.Style
+	assignee       lipgloss.Style
+	assignedToMe   lipgloss.Style
+	watching       lipgloss.Style
+}
+
+// newTaskItemStyles builds the styles of the task list items from the
+// current color palette. The labels and the informational badges, such
+// as the recurrence and the estimate, are drawn in the project's accent.
+func newTaskItemStyles(accent lipgloss.AdaptiveColor) taskItemStyles {
+	info := lipgloss.NewStyle().Padding(0, 1)
+	badge := info.Foreground(colors.BadgeText())
+
+	return taskItemStyles{
+		marker: lipgloss.NewStyle().
+			Foreground(colors.Red()).
+			Render("⟹  "),
+		title:    lipgloss.NewStyle().Padding(0, 1),
+		labels:   lipgloss.NewStyle().Foreground(accent).Padding(0, 1),
+		author:   lipgloss.NewStyle().Padding(0, 1),
+		priority: badge,
+		priorityColors: map[string]lipgloss.AdaptiveColor{
+			"low":    colors.Indigo(),
+			"medium": colors.Orange(),
+			"high":   colors.Red(),
+		},
+		info:         info.Foreground(accent),
+		urgent:       badge.Background(colors.VividRed()),
+		later:        badge.Background(colors.Yellow()),
+		reminder:     badge.Background(colors.Orange()),
+		unseen:       info.Foreground(colors.Orange()),
+		deferred:     badge.Background(colors.Indigo()),
+		inProgress:   badge.Background(colors.Blue()),
+		blocked:      badge.Background(colors.Orange()),
+		subtasksDone: info.Foreground(colors.Green()),
+		completed:    badge.Background(colors.Green()),
+		archived:     info.Foreground(lipgloss.Color("240")),
+		assignee:     badge.Background(colors.Green()),
+		assignedToMe: badge.Background(colors.Red()),
+		watching:     badge.Background(colors.Indigo()),
 	}
 }
 
 // taskListModel represents the Bubble Tea model for the task list view.
 type taskListModel struct {
-	list          list.Model
-	project       *items.Project
-	projectModel  *ProjectListModel
-	keys          *taskListKeyMap
-	mode          mode
-	cmdOutput     string
-	err           error
-	spinner       spinner.Model
-	spinning      bool
-	status        string
-	width, height int
-	selectedItems map[string]*items.Task
+	list           list.Model
+	project        *items.Project
+	projectModel   *ProjectListModel
+	keys           *taskListKeyMap
+	mode           mode
+	backendErr     *backendError
+	err            error
+	spinner        spinner.Model
+	spinning       bool
+	status         string
+	width, height  int
+	selectedItems  map[string]*items.Task
+	showArchived   bool
+	showDeferred   bool
+	nextStart      time.Time
+	pendingTasks   []string
+	itemStyles     taskItemStyles
+	filterLabels   []string
+	filterAll      bool
+	filterAssignee string
+	filterDue      items.DueFilter
+	sortMode       string
+	lastClick      lastClick
+	areaWidth      int
+	preview        *taskPreview
+	seen           *seenTasks
+}
+
+// seenTasks tells which changes of tasks the user has seen, see
+// storage.SeenTasks. It is shared by the copies of a taskListModel,
+// including the one kept by its delegate.
+type seenTasks struct {
+	times map[string]time.Time
+	since time.Time
+}
+
+// unseen reports whether the task was changed since the user last saw it.
+func (s *seenTasks) unseen(t *items.Task) bool {
+	return t.Unseen(s.times, s.since)
 }
 
 // newTaskListModel creates a new taskListModel for the given project.
 func newTaskListModel(project *items.Project, projectModel *ProjectListModel, width, height int) taskListModel {
 	listKeys := newTaskListKeyMap()
 
-	tasks := project.ReadTasksFromFS(projectModel.config)
+	// Only the first page of tasks is read here, the others are read
+	// by loadPendingTasks once the list is shown.
+	files, readErr := project.TaskFiles(projectModel.config)
+	page := files[:min(len(files), taskPageSize)]
+	tasks, err := items.ReadTaskFiles(projectModel.config, page)
+	if readErr == nil {
+		readErr = err
+	}
+
 	var listItems []list.Item
 
+	// Deferred tasks are hidden until their start date, see reloadTasks.
+	now := time.Now()
 	for _, task := range tasks {
-		listItems = append(listItems, &task)
+		if !task.Deferred(now) {
+			listItems = append(listItems, &task)
+		}
 	}
 
-	color := helpers.GetColorCode(project.Color)
-
-	titleStyleTasks := lipgloss.NewStyle().
-		Foreground(colors.BadgeText()).
-		Background(color).
-		Padding(0, 1)
-
 	sp := spinner.New()
 	sp.Spinner = spinner.Dot
-	sp.Style = lipgloss.NewStyle().Foreground(colors.Orange())
 
 	w, h := appStyle.GetFrameSize()
 
@@ -401,13 +772,23 @@ func newTaskListModel(project *items.Project, projectModel *ProjectListModel, wi
 		spinner:       sp,
 		spinning:      false,
 		selectedItems: make(map[string]*items.Task),
+		sortMode:      storage.ProjectSort(projectModel.config, project.ID),
+		nextStart:     earliestStart(tasks, now),
+		pendingTasks:  files[len(page):],
+		preview:       &taskPreview{},
+		seen:          &seenTasks{},
+	}
+	m.loadSeen()
+
+	if m.sortMode == "" && project.Settings != nil {
+		m.sortMode = project.Settings.Sort
 	}
 
 	itemList := list.New(
 		listItems,
-		customTaskDelegate{DefaultDelegate: list.NewDefaultDelegate(), parent: &m},
+		m.newDelegate(),
 		m.width,
-		m.height,
+		m.height-statusBarHeight,
 	)
 	itemList.SetShowPagination(true)
 	itemList.SetShowTitle(true)
@@ -415,8 +796,7 @@ func newTaskListModel(project *items.Project, projectModel *ProjectListModel, wi
 	itemList.SetStatusBarItemName("task", "tasks")
 	itemList.Filter = items.TaskFilterFunc
 	itemList.StatusMessageLifetime = 3 * time.Second
-	itemList.Title = project.Title
-	itemList.Styles.Title = titleStyleTasks
+	itemList.Title = m.title()
 	// Disable the quit keybindings, so we can implement our own.
 	itemList.DisableQuitKeybindings()
 	// Set our own prev/next page keys.
@@ -433,24 +813,441 @@ func newTaskListModel(project *items.Project, projectModel *ProjectListModel, wi
 			listKeys.chooseItem,
 			listKeys.goBackVim,
 			listKeys.addItem,
+			listKeys.quickAdd,
+			listKeys.duplicateItem,
+			listKeys.filterLabels,
+			listKeys.filterMine,
+			listKeys.filterAssignee,
+			listKeys.filterDue,
 			listKeys.editItem,
+			listKeys.bulkEdit,
 			listKeys.deleteItem,
 			listKeys.sortByPriority,
 			listKeys.sortByDueDate,
 			listKeys.sortByState,
 			listKeys.sortByAuthor,
 			listKeys.sortByAssignee,
+			listKeys.sortByUpdated,
+			listKeys.sortByEstimate,
+			listKeys.sortManual,
+			listKeys.moveUp,
+			listKeys.moveDown,
 			listKeys.toggleInProgress,
 			listKeys.toggleComplete,
 			listKeys.toggleSelect,
+			listKeys.showBoard,
+			listKeys.togglePreview,
+			listKeys.widenPreview,
+			listKeys.narrowPreview,
+			listKeys.toggleCompact,
+			listKeys.pomodoro,
+			listKeys.yank,
+			listKeys.archive,
+			listKeys.toggleArchived,
+			listKeys.toggleDeferred,
+			listKeys.undo,
 		}
 	}
 
 	m.list = itemList
+	m.setListSize(m.width, m.height-statusBarHeight)
+	m.applyStyles()
+
+	if keys, ok := sortModes[m.sortMode]; ok {
+		m.sortTasksByKeys(keys)
+	}
+
+	if readErr != nil {
+		m.mode = modeStorageError
+		m.err = readErr
+	}
 
 	return m
 }
 
+// showAuthor reports whether the author row of tasks is shown,
+// as set for the project or else in the global configuration.
+func (m *taskListModel) showAuthor() bool {
+	if s := m.project.Settings; s != nil && s.ShowAuthor != nil {
+		return *s.ShowAuthor
+	}
+
+	return m.projectModel.state.showAuthor
+}
+
+// showAssignee reports whether the assignee row of tasks is shown,
+// as set for the project or else in the global configuration.
+func (m *taskListModel) showAssignee() bool {
+	if s := m.project.Settings; s != nil && s.ShowAssignee != nil {
+		return *s.ShowAssignee
+	}
+
+	return m.projectModel.state.showAssignee
+}
+
+// compact reports whether the tasks are shown in a single line each.
+func (m *taskListModel) compact() bool {
+	return m.projectModel.state.compact
+}
+
+// wipLimitError returns a message if starting the given tasks would exceed
+// the project's limit of tasks in progress, or an empty string otherwise.
+// Tasks that are completed or in progress already are not counted twice.
+func (m *taskListModel) wipLimitError(tasks []*items.Task) string {
+	s := m.project.Settings
+	if s == nil || s.WIPLimit <= 0 {
+		return ""
+	}
+
+	inProgress := 0
+	for _, item := range m.list.Items() {
+		if t, ok := item.(*items.Task); ok && t.InProgress && !t.Completed && !t.Archived {
+			inProgress++
+		}
+	}
+
+	for _, t := range tasks {
+		if !t.InProgress {
+			inProgress++
+		}
+	}
+
+	if inProgress > s.WIPLimit {
+		return fmt.Sprintf("WIP limit of %d task(s) in progress reached", s.WIPLimit)
+	}
+
+	return ""
+}
+
+// applyStyles (re)builds all colored styles of the model
+// from the current color palette.
+func (m *taskListModel) applyStyles() {
+	accent := helpers.GetColorCode(m.project.Color)
+
+	m.spinner.Style = lipgloss.NewStyle().Foreground(colors.Orange())
+	m.list.Styles.Title = lipgloss.NewStyle().
+		Foreground(colors.BadgeText()).
+		Background(accent).
+		Padding(0, 1)
+	m.itemStyles = newTaskItemStyles(accent)
+
+	// The delegate keeps a pointer to its own copy of the model,
+	// so it has to be replaced to pick up the new item styles.
+	m.list.SetDelegate(m.newDelegate())
+}
+
+// showBackendError switches to the error screen for a failed operation.
+func (m *taskListModel) showBackendError(op backendOp, output string, err error) {
+	m.mode = modeBackendError
+	m.backendErr = newBackendError(m.projectModel.config, op, output, err)
+}
+
+// handleBackendErrorKey runs the action chosen in the error screen.
+func (m taskListModel) handleBackendErrorKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	v := m.projectModel.config
+
+	switch m.backendErr.action(msg.String()) {
+	case backendActionClose:
+		m.mode = modeNormal
+
+	case backendActionRetry:
+		m.mode = modeNormal
+		m.spinning = true
+		m.status = "⟳  Retrying"
+		return m, tea.Batch(m.spinner.Tick, m.backendErr.retryCmd(v))
+
+	case backendActionSkip:
+		m.mode = modeNormal
+		m.projectModel.state.loadSyncStatus(v)
+		return m, m.list.NewStatusMessage(m.backendErr.skip(v))
+
+	case backendActionResolve:
+		m.mode = modeNormal
+		resolverModel := newConflictResolverModel(v, m)
+		return resolverModel, tea.Batch(resolverModel.Init(), tea.WindowSize())
+
+	case backendActionCopy:
+		return m, copyToClipboardCmd("error", m.backendErr.report())
+	}
+
+	return m, nil
+}
+
+// reloadTasks replaces the list items with the tasks of the project
+// currently found in storage, e.g. after a change was undone.
+// Archived tasks are included if they are shown.
+func (m *taskListModel) reloadTasks() tea.Cmd {
+	for k := range m.selectedItems {
+		delete(m.selectedItems, k)
+	}
+
+	// All tasks are read, so pages still being loaded are obsolete.
+	m.pendingTasks = nil
+
+	tasks, err := m.project.ReadTasksFromFS(m.projectModel.config)
+	if m.showArchived {
+		archived, archivedErr := m.project.ReadArchivedTasksFromFS(m.projectModel.config)
+		tasks = append(tasks, archived...)
+		err = items.CollectSkipped(err, archivedErr)
+	}
+
+	if err != nil {
+		m.mode = modeStorageError
+		m.err = err
+	}
+
+	var listItems []list.Item
+	for _, task := range tasks {
+		if m.matchesFilter(&task) {
+			listItems = append(listItems, &task)
+		}
+	}
+
+	m.loadSeen()
+
+	m.list.Title = m.title()
+	cmd := m.list.SetItems(listItems)
+	if keys, ok := sortModes[m.sortMode]; ok {
+		m.sortTasksByKeys(keys)
+	}
+
+	return tea.Batch(cmd, m.scheduleNextStart(tasks))
+}
+
+// loadSeen reads which changes of tasks the user has seen, e.g. after
+// changes of others were pulled.
+func (m *taskListModel) loadSeen() {
+	m.seen.times, m.seen.since = storage.SeenTasks(m.projectModel.config)
+	if m.seen.times == nil {
+		m.seen.times = make(map[string]time.Time)
+	}
+}
+
+// markSeen returns a command that marks the current change of the task
+// as seen, or nil if it was seen already.
+func (m *taskListModel) markSeen(t *items.Task) tea.Cmd {
+	if !m.seen.unseen(t) {
+		return nil
+	}
+
+	m.seen.times[t.ID] = *t.UpdatedAt
+
+	config := m.projectModel.config
+	id, changed := t.ID, *t.UpdatedAt
+	return func() tea.Msg {
+		// Like the assignees, the seen tasks are a convenience only.
+		_ = storage.MarkSeen(config, id, changed)
+		return nil
+	}
+}
+
+// scheduleNextStart remembers the earliest start date of the given
+// hidden deferred tasks and returns a command that reports its arrival,
+// so that the task shows up without further interaction.
+func (m *taskListModel) scheduleNextStart(tasks []items.Task) tea.Cmd {
+	next := earliestStart(tasks, time.Now())
+	if next.Equal(m.nextStart) {
+		return nil
+	}
+
+	m.nextStart = next
+	return startDateTick(next)
+}
+
+// hideDeferredTask removes the deferred task from the list and makes
+// sure it shows up again once its start date arrives.
+func (m *taskListModel) hideDeferredTask(t items.Task) tea.Cmd {
+	if idx := t.FindListIndexByID(m.list.Items()); idx >= 0 {
+		m.list.RemoveItem(idx)
+		delete(m.selectedItems, t.ID)
+	}
+
+	if !m.nextStart.IsZero() && !t.StartDate.Before(m.nextStart) {
+		return nil
+	}
+
+	m.nextStart = *t.StartDate
+	return startDateTick(m.nextStart)
+}
+
+// loadPendingTasks returns a command reading the next page of the task
+// files that were not read when the list was created, or nil if all
+// tasks have been read.
+func (m *taskListModel) loadPendingTasks() tea.Cmd {
+	if len(m.pendingTasks) == 0 {
+		return nil
+	}
+
+	config := m.projectModel.config
+	projectID := m.project.ID
+	files := m.pendingTasks[:min(len(m.pendingTasks), taskPageSize)]
+
+	return func() tea.Msg {
+		tasks, err := items.ReadTaskFiles(config, files)
+		return tasksLoadedMsg{projectID: projectID, files: files, tasks: tasks, err: err}
+	}
+}
+
+// addLoadedTasks adds a page of tasks read by loadPendingTasks to the
+// list and requests the next page. The selected task stays selected.
+func (m *taskListModel) addLoadedTasks(msg tasksLoadedMsg) tea.Cmd {
+	// A page may be requested twice if the list is shown again while it is
+	// being read, so only the page that is due next is taken.
+	if msg.projectID != m.project.ID || len(m.pendingTasks) == 0 || m.pendingTasks[0] != msg.files[0] {
+		return nil
+	}
+	m.pendingTasks = m.pendingTasks[len(msg.files):]
+
+	if msg.err != nil {
+		m.mode = modeStorageError
+		m.err = msg.err
+	}
+
+	var selectedID string
+	if t, ok := m.list.SelectedItem().(*items.Task); ok {
+		selectedID = t.ID
+	}
+
+	listItems := slices.Clone(m.list.Items())
+	for _, task := range msg.tasks {
+		if m.matchesFilter(&task) {
+			listItems = append(listItems, &task)
+		}
+	}
+
+	cmds := []tea.Cmd{m.list.SetItems(listItems)}
+	if keys, ok := sortModes[m.sortMode]; ok {
+		m.sortTasksByKeys(keys)
+	}
+
+	if selectedID != "" {
+		if idx := (&items.Task{ID: selectedID}).FindListIndexByID(m.list.Items()); idx >= 0 {
+			m.list.Select(idx)
+		}
+	}
+
+	if next := earliestStart(msg.tasks, time.Now()); !next.IsZero() &&
+		(m.nextStart.IsZero() || next.Before(m.nextStart)) {
+		m.nextStart = next
+		cmds = append(cmds, startDateTick(next))
+	}
+
+	m.list.Title = m.title()
+	cmds = append(cmds, m.loadPendingTasks())
+
+	return tea.Batch(cmds...)
+}
+
+// selectTask selects the given task in the list. If it is not among the
+// tasks read so far, the remaining tasks are read first.
+func (m *taskListModel) selectTask(t items.Task) tea.Cmd {
+	var cmd tea.Cmd
+	idx := t.FindListIndexByID(m.list.Items())
+	if idx < 0 && len(m.pendingTasks) > 0 {
+		cmd = m.reloadTasks()
+		idx = t.FindListIndexByID(m.list.Items())
+	}
+
+	if idx >= 0 {
+		m.list.Select(idx)
+	}
+
+	return cmd
+}
+
+// earliestStart returns the earliest start date of the tasks deferred
+// at now, or the zero time if there is none.
+func earliestStart(tasks []items.Task, now time.Time) time.Time {
+	var next time.Time
+	for _, t := range tasks {
+		if t.Deferred(now) && (next.IsZero() || t.StartDate.Before(next)) {
+			next = *t.StartDate
+		}
+	}
+
+	return next
+}
+
+// startDateTick returns a command sending a startDateReachedMsg at the
+// given time. It returns nil for the zero time.
+func startDateTick(at time.Time) tea.Cmd {
+	if at.IsZero() {
+		return nil
+	}
+
+	return tea.Tick(time.Until(at), func(time.Time) tea.Msg {
+		return startDateReachedMsg{at: at}
+	})
+}
+
+// title returns the list title, naming the active filters if any.
+func (m *taskListModel) title() string {
+	title := m.project.DisplayTitle()
+	if filters := m.filters(); len(filters) > 0 {
+		title += " [" + strings.Join(filters, ", ") + "]"
+	}
+	if len(m.pendingTasks) > 0 {
+		title += " (loading…)"
+	}
+
+	return title
+}
+
+// filters describes the active label, assignee and due date filters.
+func (m *taskListModel) filters() []string {
+	var filters []string
+	if len(m.filterLabels) > 0 {
+		filters = append(filters, m.labelFilterString())
+	}
+	if m.filterAssignee != "" {
+		filters = append(filters, "@"+m.filterAssignee)
+	}
+	if m.filterDue != items.DueAny {
+		filters = append(filters, m.filterDue.String())
+	}
+
+	return filters
+}
+
+// protectedTasks returns the selected tasks that are protected from
+// deletion by "author.protect_delete", see ProjectListModel.deleteProtected.
+func (m *taskListModel) protectedTasks() []*items.Task {
+	var protected []*items.Task
+	for _, t := range m.selectedItems {
+		if m.projectModel.deleteProtected(t) {
+			protected = append(protected, t)
+		}
+	}
+
+	return protected
+}
+
+// matchesFilter reports whether the task passes the label, assignee
+// and due date filters of the list. The assignee filter also matches
+// the tasks watched by the assignee. Deferred tasks are only shown on
+// demand.
+func (m *taskListModel) matchesFilter(t *items.Task) bool {
+	if !m.showDeferred && t.Deferred(time.Now()) {
+		return false
+	}
+	if m.filterAssignee != "" && !t.Involves(m.filterAssignee) {
+		return false
+	}
+	if !m.filterDue.Match(t, time.Now()) {
+		return false
+	}
+	return t.HasLabels(m.filterLabels, m.filterAll)
+}
+
+// labelFilterString describes the active label filter, e.g. "bug | ui".
+func (m *taskListModel) labelFilterString() string {
+	sep := " | "
+	if m.filterAll {
+		sep = " & "
+	}
+	return strings.Join(m.filterLabels, sep)
+}
+
 // Init initializes the taskListModel and returns an initial command.
 func (m taskListModel) Init() tea.Cmd {
 	return nil
@@ -468,52 +1265,186 @@ func (m taskListModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, cmd
 		}
 
-	case doneWaitingMsg:
-		m.spinning = false
-		return m, nil
+	case doneWaitingMsg:
+		m.spinning = false
+		return m, nil
+
+	case tasksLoadedMsg:
+		return m, m.addLoadedTasks(msg)
+
+	case vcs.CommitDoneMsg, vcs.PushQueuedMsg:
+		m.projectModel.state.loadSyncStatus(m.projectModel.config)
+
+		status := "🗘  Changes committed"
+		if queued, ok := msg.(vcs.PushQueuedMsg); ok {
+			status = unpushedStatus(queued.Unpushed)
+		}
+
+		// Queued commits are made without the spinner
+		// and must not touch the current selection.
+		if !m.spinning {
+			return m, m.list.NewStatusMessage(status)
+		}
+
+		// Remove all map entries after successful commit.
+		for k := range m.selectedItems {
+			delete(m.selectedItems, k)
+		}
+		m.status = status
+
+		// Wait 1 second before fully stopping spinner
+		return m, tea.Tick(time.Second, func(time.Time) tea.Msg {
+			return doneWaitingMsg{}
+		})
+
+	case vcs.CommitQueuedMsg:
+		return m, nil
+
+	// The project list that was left may have merged its branch.
+	case vcs.BranchDoneMsg:
+		m.projectModel.state.loadSyncStatus(m.projectModel.config)
+		return m, nil
+
+	case vcs.BranchErrorMsg:
+		m.showBackendError(backendOpOther, msg.CmdOutput, msg.Err)
+		return m, nil
+
+	case vcs.PullDoneMsg, vcs.PushDoneMsg:
+		m.spinning = false
+		m.projectModel.state.loadSyncStatus(m.projectModel.config)
+		return m, tea.Batch(m.projectModel.pulledChanges(), m.list.NewStatusMessage("⟳  Synced"))
+
+	case vcs.ConflictAbortedMsg:
+		m.projectModel.state.loadSyncStatus(m.projectModel.config)
+		return m, tea.Batch(m.projectModel.pulledChanges(),
+			m.list.NewStatusMessage(unpushedStatus(storage.UnpushedCommits(m.projectModel.config))))
+
+	case vcs.CommitErrorMsg:
+		m.showBackendError(backendOpOther, msg.CmdOutput, msg.Err)
+		m.spinning = false
+		return m, nil
+
+	case vcs.PullErrorMsg:
+		m.showBackendError(backendOpPull, msg.CmdOutput, msg.Err)
+		m.spinning = false
+		return m, nil
+
+	case vcs.PushErrorMsg:
+		m.showBackendError(backendOpPush, msg.CmdOutput, msg.Err)
+		m.spinning = false
+		return m, nil
+
+	case vcs.RevertDoneMsg:
+		m.status = "↶  Undone: " + msg.Subject
+		items.InvalidateTaskStats()
+		cmds = append(cmds, m.projectModel.reloadProjects())
+
+		// The undone change may have removed this project.
+		idx := m.project.FindListIndexByID(m.projectModel.list.Items())
+		if idx < 0 {
+			m.spinning = false
+			cmds = append(cmds, func() tea.Msg { return returnedToProjectListMsg{} })
+			return m.projectModel, tea.Batch(cmds...)
+		}
+
+		m.project = m.projectModel.list.Items()[idx].(*items.Project)
+		m.list.Title = m.title()
+		cmds = append(cmds, m.reloadTasks())
+
+		// Wait 1 second before fully stopping spinner
+		cmds = append(cmds, tea.Tick(time.Second, func(time.Time) tea.Msg {
+			return doneWaitingMsg{}
+		}))
+		return m, tea.Batch(cmds...)
+
+	case ConfigChangedMsg:
+		if msg.Err == nil {
+			m.projectModel.applyConfig(msg.Config)
+			m.applyStyles()
+		}
+		return m, m.list.NewStatusMessage(configStatus(msg))
+
+	case storage.StorageChangedMsg:
+		if len(msg.Projects) > 0 {
+			items.InvalidateTaskStats(msg.Projects...)
+		}
+
+		// Don't change the selection while asking to delete it, and
+		// keep showing a failed pull, which may have left conflicts.
+		if m.mode == modeConfirmDelete || m.mode == modeConfirmDeleteProtected || m.mode == modeBackendError {
+			return m, nil
+		}
+
+		cmds = append(cmds, m.projectModel.reloadProjects())
 
-	case vcs.CommitDoneMsg:
-		// Remove all map entries after successful commit.
-		for k := range m.selectedItems {
-			delete(m.selectedItems, k)
+		// The change may have removed this project.
+		idx := m.project.FindListIndexByID(m.projectModel.list.Items())
+		if idx < 0 {
+			cmds = append(cmds, func() tea.Msg { return returnedToProjectListMsg{} })
+			return m.projectModel, tea.Batch(cmds...)
 		}
-		m.status = "🗘  Changes committed"
 
-		// Wait 1 second before fully stopping spinner
-		return m, tea.Tick(time.Second, func(time.Time) tea.Msg {
-			return doneWaitingMsg{}
-		})
+		m.project = m.projectModel.list.Items()[idx].(*items.Project)
+		m.list.Title = m.title()
+		if slices.Contains(msg.Projects, m.project.ID) {
+			cmds = append(cmds, m.reloadTasks())
+		}
+		return m, tea.Batch(cmds...)
 
-	case vcs.CommitErrorMsg:
-		m.mode = modeBackendError
-		m.cmdOutput = msg.CmdOutput
-		m.err = msg.Err
-		m.spinning = false
-		return m, nil
+	case syncTickMsg:
+		return m, m.projectModel.handleSyncTick(m.spinning)
 
-	case vcs.PullErrorMsg:
-		m.mode = modeBackendError
-		m.cmdOutput = msg.CmdOutput
-		m.err = msg.Err
-		m.spinning = false
-		return m, nil
+	case syncDoneMsg:
+		// A conflict blocks all further syncs until it is resolved.
+		if result, ok := msg.result.(vcs.PullErrorMsg); ok && m.mode == modeNormal && vcs.ConflictInProgress(m.projectModel.config) {
+			m.showBackendError(backendOpPull, result.CmdOutput, result.Err)
+		}
 
-	case vcs.PushErrorMsg:
-		m.mode = modeBackendError
-		m.cmdOutput = msg.CmdOutput
-		m.err = msg.Err
+		status, cmd := m.projectModel.finishSync(msg)
+		if m.mode == modeNormal && !m.spinning && m.list.FilterState() != list.Filtering {
+			if feed := m.projectModel.showChangeFeed(msg, m); feed != nil {
+				return feed, tea.Batch(cmd, m.list.NewStatusMessage(status), tea.WindowSize())
+			}
+		}
+		return m, tea.Batch(cmd, m.list.NewStatusMessage(status))
+
+	case vcs.RevertErrorMsg:
 		m.spinning = false
+		if errors.Is(msg.Err, vcs.ErrorNothingToRevert) {
+			return m, m.list.NewStatusMessage(lipgloss.NewStyle().
+				Foreground(colors.Red()).
+				Render("Nothing to undo"))
+		}
+
+		m.showBackendError(backendOpOther, msg.CmdOutput, msg.Err)
 		return m, nil
 
 	case items.WriteTaskJSONDoneMsg:
+		items.InvalidateTaskStats(m.project.ID)
+
+		// WriteTaskJSON marked the change as seen already.
+		if msg.Kind != "reorder" && msg.Task.UpdatedAt != nil {
+			m.seen.times[msg.Task.ID] = *msg.Task.UpdatedAt
+		}
+
+		hidden := !m.showDeferred && msg.Task.Deferred(time.Now())
+		if hidden {
+			cmds = append(cmds, m.hideDeferredTask(msg.Task))
+		}
+
 		switch msg.Kind {
 		case "create":
-			m.list.InsertItem(0, &msg.Task)
+			if !hidden {
+				m.list.InsertItem(0, &msg.Task)
+			}
 			m.status = "🗸  Task created ― committing changes"
 
 		case "update":
 			m.status = "🗸  Task updated ― committing changes"
 
+		case "reorder":
+			m.status = "🗸  Task(s) reordered ― committing changes"
+
 		case "start":
 			m.status = "🗸  Task(s) started ― committing changes"
 
@@ -526,37 +1457,136 @@ func (m taskListModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case "reopen":
 			m.status = "🗸  Task(s) reopened ― committing changes"
 
+		case "recur":
+			if !hidden {
+				m.list.InsertItem(0, &msg.Task)
+			}
+			m.status = "🗸  Next occurrence created ― committing changes"
+
+		case "restore":
+			m.status = "🗸  Task restored ― committing changes"
+
 		default:
 			return m, nil
 		}
-		return m, nil
+
+		if !m.spinning {
+			cmds = append(cmds, m.list.NewStatusMessage(m.status))
+		}
+		return m, tea.Batch(cmds...)
+
+	case hooks.ErrorMsg:
+		// A failed hook does not undo the change, so only report it.
+		reason, _, _ := strings.Cut(msg.Err.Error(), "\n")
+		return m, m.list.NewStatusMessage(lipgloss.NewStyle().
+			Foreground(colors.Red()).
+			Render("✘ " + reason))
 
 	case items.WriteTaskJSONErrorMsg:
-		m.mode = 2
-		m.err = msg.Err
+		m.showBackendError(backendOpOther, "", msg.Err)
 		return m, nil
 
+	case copiedToClipboardMsg:
+		status := fmt.Sprintf("🗸  Copied %s to clipboard", msg.what)
+		if msg.err != nil {
+			status = lipgloss.NewStyle().
+				Foreground(colors.Red()).
+				Render(fmt.Sprintf("Could not copy %s: %s", msg.what, msg.err))
+		}
+		if m.mode == modeBackendError {
+			m.backendErr.notice = status
+			return m, nil
+		}
+		return m, m.list.NewStatusMessage(status)
+
 	case items.TaskDeleteDoneMsg:
-		for i, task := range m.selectedItems {
-			if idx := task.FindListIndexByID(m.list.Items()); idx >= 0 {
-				m.list.RemoveItem(idx)
-				delete(m.selectedItems, i)
-			}
+		// The selection may have been cleared meanwhile, e.g. by the
+		// commit of an earlier change, so remove the deleted task itself.
+		items.InvalidateTaskStats(m.project.ID)
+		if idx := msg.Task.FindListIndexByID(m.list.Items()); idx >= 0 {
+			m.list.RemoveItem(idx)
 		}
+		delete(m.selectedItems, msg.Task.ID)
 		m.status = "✘ Task(s) deleted ― committing changes"
 		return m, nil
 
 	case items.TaskDeleteErrorMsg:
-		m.mode = 2
-		m.err = msg.Err
+		m.showBackendError(backendOpOther, "", msg.Err)
+		m.spinning = false
+		return m, nil
+
+	case items.TaskArchiveDoneMsg:
+		items.InvalidateTaskStats(m.project.ID)
+		if idx := msg.Task.FindListIndexByID(m.list.Items()); idx >= 0 {
+			if msg.Task.Archived && !m.showArchived {
+				m.list.RemoveItem(idx)
+			} else {
+				task := msg.Task
+				cmds = append(cmds, m.list.SetItem(idx, &task))
+			}
+		}
+		delete(m.selectedItems, msg.Task.ID)
+
+		if msg.Task.Archived {
+			m.status = "🗸  Task(s) archived ― committing changes"
+		} else {
+			m.status = "🗸  Task(s) restored ― committing changes"
+		}
+		return m, tea.Batch(cmds...)
+
+	case items.TaskArchiveErrorMsg:
+		m.showBackendError(backendOpOther, "", msg.Err)
 		m.spinning = false
 		return m, nil
 
+	case startDateReachedMsg:
+		// Don't drop a selection, the task is shown on the next reload then.
+		if msg.at.Equal(m.nextStart) && m.mode == modeNormal && len(m.selectedItems) == 0 {
+			return m, m.reloadTasks()
+		}
+		return m, nil
+
 	case tea.WindowSizeMsg:
 		h, v := appStyle.GetFrameSize()
-		m.list.SetSize(msg.Width-h, msg.Height-v)
+		m.setListSize(msg.Width-h, msg.Height-v-statusBarHeight)
 		m.width = msg.Width
 		m.height = msg.Height
+		cmds = append(cmds, m.projectModel.resumeSync())
+
+		// Pages of tasks requested while another view was shown are lost.
+		cmds = append(cmds, m.loadPendingTasks())
+
+		// The start date may have arrived while another view was shown,
+		// which also dropped the scheduled startDateReachedMsg.
+		if !m.nextStart.IsZero() {
+			if m.nextStart.After(time.Now()) {
+				cmds = append(cmds, startDateTick(m.nextStart))
+			} else if m.mode == modeNormal && len(m.selectedItems) == 0 {
+				cmds = append(cmds, m.reloadTasks())
+			}
+		}
+
+	case tea.MouseMsg:
+		if m.mode != modeNormal || m.spinning {
+			return m, nil
+		}
+
+		// Clicks into the preview pane are not meant for the list.
+		if m.projectModel.state.showPreview && msg.X-appStyle.GetPaddingLeft() >= m.list.Width() &&
+			msg.Button == tea.MouseButtonLeft {
+			return m, nil
+		}
+
+		delegate := m.newDelegate()
+		if handleListMouse(&m.list, delegate, &m.lastClick, msg) &&
+			m.list.SelectedItem() != nil && m.projectModel.state.renderer != nil {
+			task := m.list.SelectedItem().(*items.Task)
+			seenCmd := m.markSeen(task)
+			pagerModel := newTaskPagerModel(task.TaskToMarkdown(), &m)
+
+			return pagerModel, tea.Batch(tea.WindowSize(), seenCmd)
+		}
+		return m, nil
 
 	case tea.KeyMsg:
 		if msg.Type == tea.KeyCtrlC {
@@ -569,13 +1599,16 @@ func (m taskListModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		switch m.mode {
 		case modeBackendError:
+			return m.handleBackendErrorKey(msg)
+
+		case modeStorageError:
 			switch msg.String() {
-			case "esc", "q":
+			case "esc", "q", "enter":
 				m.mode = modeNormal
-				return m, nil
 			}
+			return m, nil
 
-		case modeConfirmDelete:
+		case modeConfirmDelete, modeConfirmDeleteProtected:
 			switch msg.String() {
 			case "y", "Y":
 				if len(m.selectedItems) == 0 {
@@ -584,11 +1617,19 @@ func (m taskListModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					return m, nil
 				}
 
+				if m.mode == modeConfirmDelete && len(m.protectedTasks()) > 0 {
+					m.mode = modeConfirmDeleteProtected
+					return m, nil
+				}
+
 				var taskNames, taskPaths []string
 				var deleteCmds []tea.Cmd
 				for _, item := range m.selectedItems {
 					taskNames = append(taskNames, item.Title)
-					taskPaths = append(taskPaths, filepath.Join(m.project.ID, item.ID+".json"))
+					taskPaths = append(taskPaths, item.Path(*m.project))
+					if attachments, _ := item.Attachments(m.projectModel.config, *m.project); len(attachments) > 0 {
+						taskPaths = append(taskPaths, item.AttachmentsDir(*m.project))
+					}
 					deleteCmds = append(deleteCmds, item.DeleteTaskFromFS(m.projectModel.config, *m.project))
 				}
 
@@ -609,6 +1650,25 @@ func (m taskListModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, nil
 			}
 
+		case modeYank:
+			m.mode = modeNormal
+
+			t, ok := m.list.SelectedItem().(*items.Task)
+			if !ok {
+				return m, nil
+			}
+
+			switch msg.String() {
+			case "t":
+				return m, copyToClipboardCmd("title", t.Title)
+			case "m":
+				return m, copyToClipboardCmd("markdown", t.TaskToMarkdown())
+			case "p":
+				path := filepath.Join(config.Get(m.projectModel.config).StoragePath, t.Path(*m.project))
+				return m, copyToClipboardCmd("file path", path)
+			}
+			return m, nil
+
 		case modeNormal:
 			// Don't match any of the keys below if we're actively filtering.
 			if m.list.FilterState() == list.Filtering {
@@ -627,30 +1687,128 @@ func (m taskListModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, nil
 
 			case key.Matches(msg, m.keys.sortByPriority):
-				m.sortTasksByKeys([]string{"completed", "priority"})
+				cmds = append(cmds, m.sortTasks("priority"))
 
 			case key.Matches(msg, m.keys.sortByDueDate):
-				m.sortTasksByKeys([]string{"completed", "dueDate"})
+				cmds = append(cmds, m.sortTasks("dueDate"))
 
 			case key.Matches(msg, m.keys.sortByAuthor):
-				m.sortTasksByKeys([]string{"completed", "author", "dueDate", "priority"})
+				cmds = append(cmds, m.sortTasks("author"))
 
 			case key.Matches(msg, m.keys.sortByAssignee):
-				m.sortTasksByKeys([]string{"completed", "assignee", "dueDate", "priority"})
+				cmds = append(cmds, m.sortTasks("assignee"))
 
 			case key.Matches(msg, m.keys.sortByState):
-				m.sortTasksByKeys([]string{"completed", "inProgress", "dueDate", "priority"})
+				cmds = append(cmds, m.sortTasks("state"))
+
+			case key.Matches(msg, m.keys.sortByUpdated):
+				cmds = append(cmds, m.sortTasks("updated"))
+
+			case key.Matches(msg, m.keys.sortByEstimate):
+				cmds = append(cmds, m.sortTasks("estimate"))
+
+			case key.Matches(msg, m.keys.sortManual):
+				return m, m.sortTasks(sortManual)
+
+			case key.Matches(msg, m.keys.moveUp):
+				m, cmds = m.moveTask(-1)
+				return m, tea.Batch(cmds...)
+
+			case key.Matches(msg, m.keys.moveDown):
+				m, cmds = m.moveTask(1)
+				return m, tea.Batch(cmds...)
 
 			case key.Matches(msg, m.keys.chooseItem):
 				if m.list.SelectedItem() != nil && m.projectModel.state.renderer != nil {
-					markdown := m.list.SelectedItem().(*items.Task).TaskToMarkdown()
-					pagerModel := newTaskPagerModel(markdown, &m)
+					task := m.list.SelectedItem().(*items.Task)
+					seenCmd := m.markSeen(task)
+					pagerModel := newTaskPagerModel(task.TaskToMarkdown(), &m)
+
+					return pagerModel, tea.Batch(tea.WindowSize(), seenCmd)
+				}
+				return m, nil
+
+			case key.Matches(msg, m.keys.archive):
+				m, cmds = m.archiveTasks()
+				return m, tea.Batch(cmds...)
+
+			case key.Matches(msg, m.keys.toggleDeferred):
+				m.showDeferred = !m.showDeferred
+				cmds = append(cmds, m.reloadTasks())
+
+				status := "Hiding deferred tasks"
+				if m.showDeferred {
+					status = "Showing deferred tasks"
+				}
+				cmds = append(cmds, m.list.NewStatusMessage(status))
+				return m, tea.Batch(cmds...)
+
+			case key.Matches(msg, m.keys.toggleArchived):
+				m.showArchived = !m.showArchived
+				cmds = append(cmds, m.reloadTasks())
+
+				status := "Hiding archived tasks"
+				if m.showArchived {
+					status = "Showing archived tasks"
+				}
+				cmds = append(cmds, m.list.NewStatusMessage(status))
+				return m, tea.Batch(cmds...)
+
+			case key.Matches(msg, m.keys.undo):
+				m.spinning = true
+				m.status = "↶  Undoing last change"
+				return m, tea.Batch(m.spinner.Tick, vcs.RevertLastCmd(m.projectModel.config))
+
+			case key.Matches(msg, m.keys.showBoard):
+				boardModel := newBoardModel(&m)
+				return boardModel, tea.WindowSize()
+
+			case key.Matches(msg, m.keys.togglePreview):
+				m.togglePreview()
+				return m, nil
 
-					return pagerModel, tea.WindowSize()
+			case key.Matches(msg, m.keys.widenPreview):
+				m.resizePreview(1)
+				return m, nil
+
+			case key.Matches(msg, m.keys.narrowPreview):
+				m.resizePreview(-1)
+				return m, nil
+
+			case key.Matches(msg, m.keys.toggleCompact):
+				m.projectModel.state.compact = !m.projectModel.state.compact
+
+				// Replacing the delegate updates the pagination
+				// for the new item height.
+				m.applyStyles()
+				return m, nil
+
+			case key.Matches(msg, m.keys.pomodoro):
+				if t, ok := m.list.SelectedItem().(*items.Task); ok {
+					if t.Completed {
+						return m, m.list.NewStatusMessage(lipgloss.NewStyle().
+							Foreground(colors.Red()).
+							Render("Cannot start pomodoro on completed task"))
+					}
+
+					pomodoroModel := newPomodoroModel(t, &m)
+					return pomodoroModel, tea.WindowSize()
 				}
 				return m, nil
 
 			case key.Matches(msg, m.keys.toggleInProgress):
+				var starting []*items.Task
+				for _, t := range m.selectedItems {
+					if !t.InProgress && !t.Completed {
+						starting = append(starting, t)
+					}
+				}
+				if errMsg := m.wipLimitError(starting); errMsg != "" {
+					return m, m.list.NewStatusMessage(lipgloss.NewStyle().
+						Foreground(colors.Red()).
+						Render(errMsg))
+				}
+
 				m, cmds = m.toggleTasks(
 					func(t *items.Task) { t.InProgress = !t.InProgress },
 					func(t *items.Task) (bool, string) {
@@ -673,7 +1831,7 @@ func (m taskListModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			case key.Matches(msg, m.keys.toggleComplete):
 				m, cmds = m.toggleTasks(
 					func(t *items.Task) { t.Completed = !t.Completed; t.InProgress = false },
-					func(_ *items.Task) (bool, string) { return true, "" },
+					m.completionPrecondition,
 					func(t *items.Task) string {
 						if t.Completed {
 							return "complete"
@@ -685,6 +1843,13 @@ func (m taskListModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 				return m, tea.Batch(cmds...)
 
+			case key.Matches(msg, m.keys.yank):
+				if m.list.SelectedItem() != nil {
+					m.mode = modeYank
+				}
+
+				return m, nil
+
 			case key.Matches(msg, m.keys.deleteItem):
 				if len(m.selectedItems) > 0 {
 					m.mode = modeConfirmDelete
@@ -706,6 +1871,12 @@ func (m taskListModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				return m, nil
 
 			case key.Matches(msg, m.keys.addItem):
+				// Templates are optional, so unreadable ones are left out.
+				if templates, _ := items.ReadTemplatesFromFS(m.projectModel.config); len(templates) > 0 {
+					pickerModel := newTemplatePickerModel(&m, templates)
+					return pickerModel, tea.Batch(pickerModel.Init(), tea.WindowSize())
+				}
+
 				task := &items.Task{
 					ID:          uuid.NewString(),
 					Title:       "",
@@ -714,6 +1885,63 @@ func (m taskListModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				formModel := newTaskFormModel(task, &m, false)
 				return formModel, tea.WindowSize()
 
+			case key.Matches(msg, m.keys.bulkEdit):
+				if len(m.selectedItems) == 0 {
+					return m, m.list.NewStatusMessage(lipgloss.NewStyle().
+						Foreground(colors.Red()).
+						Render("No task selected"))
+				}
+
+				bulkModel := newBulkEditModel(&m)
+				return bulkModel, tea.Batch(bulkModel.Init(), tea.WindowSize())
+
+			case key.Matches(msg, m.keys.filterLabels):
+				labels, err := helpers.ProjectLabels(m.projectModel.config, *m.project)
+				if err != nil {
+					return m, m.list.NewStatusMessage(lipgloss.NewStyle().
+						Foreground(colors.Red()).
+						Render(err.Error()))
+				}
+				if len(labels) == 0 && len(m.filterLabels) == 0 {
+					return m, m.list.NewStatusMessage("No labels in this project")
+				}
+				filterModel := newLabelFilterModel(&m, labels)
+				return filterModel, tea.Batch(filterModel.Init(), tea.WindowSize())
+
+			case key.Matches(msg, m.keys.filterMine):
+				status := "Showing tasks of all assignees"
+				if m.filterAssignee == "" {
+					me := m.projectModel.currentUser()
+					if me == "" {
+						return m, m.list.NewStatusMessage("Could not determine the current user")
+					}
+					m.filterAssignee = me
+					status = "Showing tasks assigned to or watched by you"
+				} else {
+					m.filterAssignee = ""
+				}
+				return m, tea.Batch(m.reloadTasks(), m.list.NewStatusMessage(status))
+
+			case key.Matches(msg, m.keys.filterAssignee):
+				filterModel := newAssigneeFilterModel(&m)
+				return filterModel, tea.Batch(filterModel.Init(), tea.WindowSize())
+
+			case key.Matches(msg, m.keys.filterDue):
+				filterModel := newDueFilterModel(&m)
+				return filterModel, tea.Batch(filterModel.Init(), tea.WindowSize())
+
+			case key.Matches(msg, m.keys.quickAdd):
+				quickAddModel := newQuickAddModel(&m)
+				return quickAddModel, tea.Batch(quickAddModel.Init(), tea.WindowSize())
+
+			case key.Matches(msg, m.keys.duplicateItem):
+				if m.list.SelectedItem() != nil {
+					task := m.list.SelectedItem().(*items.Task).Duplicate()
+					formModel := newTaskFormModel(task, &m, false)
+					return formModel, tea.WindowSize()
+				}
+				return m, nil
+
 			case key.Matches(msg, m.keys.toggleSelect):
 				if m.list.SelectedItem() != nil {
 					t := m.list.SelectedItem().(*items.Task)
@@ -765,25 +1993,51 @@ func (m taskListModel) View() string {
 		}
 	}
 
-	// Display VCS error view
-	if m.mode == modeBackendError {
-		var e strings.Builder
+	// Display the confirmation to delete tasks of other users.
+	if m.mode == modeConfirmDeleteProtected {
+		return centeredStyle.Render(
+			fmt.Sprintf("%d of the selected tasks were neither created by\n"+
+				"nor assigned to you. Delete them anyway?\n\n%s%s%s",
+				len(m.protectedTasks()),
+				lipgloss.NewStyle().Foreground(colors.Red()).Render("[y] Yes"),
+				"    ",
+				"[n] No",
+			))
+	}
 
-		e.WriteString("An error occurred during a backend operation:")
-		e.WriteString("\n\n")
-		e.WriteString(m.cmdOutput)
-		e.WriteString("\n\n")
-		e.WriteString("Please commit manually!")
+	// Display clipboard prompt.
+	if m.mode == modeYank {
+		return centeredStyle.Render("Copy to clipboard:\n\n[t] Title    [m] Markdown    [p] File path")
+	}
+
+	// Display storage error view
+	if m.mode == modeStorageError {
+		return centeredStyle.Render(storageErrorView(m.err))
+	}
 
-		return centeredStyle.Render(e.String())
+	// Display VCS error view
+	if m.mode == modeBackendError {
+		return centeredStyle.Render(m.backendErr.view(m.width))
 	}
 
 	// Display list view.
-	return appStyle.Render(m.list.View())
+	view := m.list.View()
+	if m.projectModel.state.showPreview {
+		// Cut off list lines that are too long, so that they don't
+		// push the preview aside.
+		width := m.list.Width()
+		view = lipgloss.NewStyle().MaxWidth(width).Render(view)
+		view = lipgloss.JoinHorizontal(lipgloss.Top,
+			lipgloss.NewStyle().Width(width).Render(view),
+			m.previewView(),
+		)
+	}
+
+	return appStyle.Render(view + "\n" + m.statusBar())
 }
 
 // sortTasksByKey sorts the tasks in the list model by a specified keys.
-// Valid keys include "priority", "dueDate", and "state".
+// Valid keys include "priority", "dueDate", "state" and "order".
 func (m *taskListModel) sortTasksByKeys(keys []string) {
 	selected := m.list.SelectedItem()
 	listItems := m.list.Items()
@@ -795,7 +2049,7 @@ func (m *taskListModel) sortTasksByKeys(keys []string) {
 		}
 	}
 
-	me, _ := vcs.User(m.projectModel.config)
+	me := m.projectModel.currentUser()
 
 	slices.SortStableFunc(tasks, func(x, y *items.Task) int {
 		for _, k := range keys {
@@ -847,6 +2101,29 @@ func (m *taskListModel) sortTasksByKeys(keys []string) {
 				default:
 					cmpResult = 0
 				}
+			case "updated":
+				// Most recently updated first, tasks never stamped last.
+				ux, uy := x.UpdatedAt, y.UpdatedAt
+				switch {
+				case ux == nil && uy != nil:
+					cmpResult = 1
+				case ux != nil && uy == nil:
+					cmpResult = -1
+				case ux != nil && uy != nil:
+					cmpResult = uy.Compare(*ux)
+				default:
+					cmpResult = 0
+				}
+			case "estimate":
+				// Smallest estimate first, tasks without estimate last.
+				switch {
+				case x.Estimate == 0 && y.Estimate != 0:
+					cmpResult = 1
+				case x.Estimate != 0 && y.Estimate == 0:
+					cmpResult = -1
+				default:
+					cmpResult = cmp.Compare(x.Estimate, y.Estimate)
+				}
 			case "priority":
 				if x.Completed != y.Completed {
 					if x.Completed {
@@ -857,6 +2134,16 @@ func (m *taskListModel) sortTasksByKeys(keys []string) {
 				} else {
 					cmpResult = cmp.Compare(y.PriorityValue(), x.PriorityValue())
 				}
+			case "order":
+				// Tasks that were never moved go last.
+				switch {
+				case x.Order == 0 && y.Order != 0:
+					cmpResult = 1
+				case x.Order != 0 && y.Order == 0:
+					cmpResult = -1
+				default:
+					cmpResult = cmp.Compare(x.Order, y.Order)
+				}
 			case "author":
 				switch {
 				case x.Author == "" && y.Author != "":
@@ -896,6 +2183,79 @@ func (m *taskListModel) sortTasksByKeys(keys []string) {
 	}
 }
 
+// sortTasks sorts the tasks by the given sort mode and remembers it
+// as the project's sort mode.
+func (m *taskListModel) sortTasks(mode string) tea.Cmd {
+	m.sortTasksByKeys(sortModes[mode])
+	return m.rememberSort(mode)
+}
+
+// rememberSort stores the given sort mode as the project's sort mode,
+// so that it is applied again the next time the project is opened.
+func (m *taskListModel) rememberSort(mode string) tea.Cmd {
+	if m.sortMode == mode {
+		return nil
+	}
+	m.sortMode = mode
+
+	if err := storage.SetProjectSort(m.projectModel.config, m.project.ID, mode); err != nil {
+		return m.list.NewStatusMessage(lipgloss.NewStyle().
+			Foreground(colors.Red()).
+			Render("Could not remember sort: " + err.Error()))
+	}
+
+	return nil
+}
+
+// moveTask moves the selected task up (negative offset) or down (positive
+// offset) in the list. The order currently shown becomes the project's
+// manual order, which is written to the tasks and committed.
+func (m taskListModel) moveTask(offset int) (taskListModel, []tea.Cmd) {
+	if m.list.FilterState() != list.Unfiltered {
+		return m, []tea.Cmd{
+			m.list.NewStatusMessage(lipgloss.NewStyle().
+				Foreground(colors.Red()).
+				Render("Clear the filter to reorder tasks")),
+		}
+	}
+
+	idx := m.list.Index()
+	target := idx + offset
+	listItems := m.list.Items()
+	if m.list.SelectedItem() == nil || target < 0 || target >= len(listItems) {
+		return m, nil
+	}
+
+	tasks := make([]*items.Task, 0, len(listItems))
+	for _, item := range listItems {
+		if t, ok := item.(*items.Task); ok {
+			tasks = append(tasks, t)
+		}
+	}
+	tasks[idx], tasks[target] = tasks[target], tasks[idx]
+	moved := tasks[target]
+
+	var writeCmds []tea.Cmd
+	var taskPaths []string
+	for _, t := range items.Renumber(tasks) {
+		writeCmds = append(writeCmds, t.WriteTaskJSON(m.projectModel.config, *m.project, "reorder"))
+		taskPaths = append(taskPaths, t.Path(*m.project))
+	}
+
+	reordered := make([]list.Item, len(tasks))
+	for i, t := range tasks {
+		reordered[i] = t
+	}
+
+	cmds := []tea.Cmd{m.list.SetItems(reordered), m.rememberSort(sortManual)}
+	m.list.Select(target)
+
+	writeCmds = append(writeCmds,
+		vcs.QueueCommitCmd(m.projectModel.config, fmt.Sprintf("reorder: %s", moved.Title), taskPaths...))
+
+	return m, append(cmds, tea.Sequence(writeCmds...))
+}
+
 // toggleTasks applies a toggle operation to all selected tasks in the task list.
 //
 // Parameters:
@@ -926,7 +2286,8 @@ func (m taskListModel) toggleTasks(
 	}
 
 	var cmds, writeCmds []tea.Cmd
-	var taskPaths, taskNames []string
+	var taskPaths, taskNames, recurNames []string
+	var hookCalls []hooks.Call
 
 	for _, t := range m.selectedItems {
 		ok, msg := precondition(t)
@@ -939,20 +2300,123 @@ func (m taskListModel) toggleTasks(
 		}
 
 		toggleFunc(t)
-		json := t.MarshalTask()
-		writeCmds = append(writeCmds, t.WriteTaskJSON(m.projectModel.config, json, *m.project, commitKind(t)))
-		taskPaths = append(taskPaths, filepath.Join(m.project.ID, t.ID+".json"))
+		kind := commitKind(t)
+
+		// Completing a recurring task schedules its next occurrence,
+		// unless it was scheduled by an earlier completion.
+		var next *items.Task
+		if kind == "complete" {
+			next = t.Recur()
+		}
+
+		writeCmds = append(writeCmds, t.WriteTaskJSON(m.projectModel.config, *m.project, kind))
+		taskPaths = append(taskPaths, t.Path(*m.project))
 		taskNames = append(taskNames, t.Title)
+		hookCalls = append(hookCalls, t.HookCalls(*m.project, kind)...)
+
+		if next != nil {
+			writeCmds = append(writeCmds,
+				next.WriteTaskJSON(m.projectModel.config, *m.project, "recur"))
+			taskPaths = append(taskPaths, next.Path(*m.project))
+			hookCalls = append(hookCalls, next.HookCalls(*m.project, "recur")...)
+			recurNames = append(recurNames, fmt.Sprintf("%s (due %s)", next.Title, next.DueDateToString()))
+		}
 	}
 
-	commitMsg := fmt.Sprintf("Change %s state of %d task(s)\n\n- %s",
-		actionName, len(taskNames), strings.Join(taskNames, "\n- "))
+	commitMsg := items.StateChangeCommitMessage(actionName, taskNames, recurNames)
 
-	m.spinning = true
+	// Toggles are committed in the background, so that several of them
+	// in quick succession end up in a single commit.
+	for k := range m.selectedItems {
+		delete(m.selectedItems, k)
+	}
 
-	cmds = append(cmds, m.spinner.Tick)
 	cmds = append(cmds, writeCmds...)
-	cmds = append(cmds, vcs.CommitCmd(m.projectModel.config, commitMsg, taskPaths...))
+	cmds = append(cmds, vcs.QueueCommitThenCmd(m.projectModel.config, commitMsg,
+		hooks.RunCmd(m.projectModel.config, hookCalls), taskPaths...))
 
 	return m, cmds
 }
+
+// archiveTasks moves the selected tasks into the project's archive, or
+// restores them if they are archived already. Without a selection, all
+// completed tasks in the list are archived. Open tasks cannot be archived.
+//
+// The files are moved before the changes are committed.
+func (m taskListModel) archiveTasks() (taskListModel, []tea.Cmd) {
+	var targets []*items.Task
+	for _, t := range m.selectedItems {
+		targets = append(targets, t)
+	}
+
+	if len(targets) == 0 {
+		for _, item := range m.list.Items() {
+			if t, ok := item.(*items.Task); ok && t.Completed && !t.Archived {
+				targets = append(targets, t)
+			}
+		}
+	}
+
+	if len(targets) == 0 {
+		return m, []tea.Cmd{
+			m.list.NewStatusMessage(lipgloss.NewStyle().
+				Foreground(colors.Red()).
+				Render("No completed tasks to archive")),
+		}
+	}
+
+	var archiveCmds []tea.Cmd
+	var taskPaths, archivedNames, restoredNames []string
+
+	for _, t := range targets {
+		if !t.Archived && !t.Completed {
+			return m, []tea.Cmd{
+				m.list.NewStatusMessage(lipgloss.NewStyle().
+					Foreground(colors.Red()).
+					Render("Cannot archive open task")),
+			}
+		}
+
+		moved := *t
+		moved.Archived = !t.Archived
+		taskPaths = append(taskPaths, t.Path(*m.project), moved.Path(*m.project))
+		archiveCmds = append(archiveCmds, t.ArchiveTaskOnFS(m.projectModel.config, *m.project, moved.Archived))
+
+		if moved.Archived {
+			archivedNames = append(archivedNames, t.Title)
+		} else {
+			restoredNames = append(restoredNames, t.Title)
+		}
+	}
+
+	commitMsg := items.ArchiveCommitMessage(archivedNames, restoredNames)
+
+	m.spinning = true
+
+	// Files must be moved before they can be committed.
+	archiveCmds = append(archiveCmds, vcs.CommitCmd(m.projectModel.config, commitMsg, taskPaths...))
+
+	return m, []tea.Cmd{m.spinner.Tick, tea.Sequence(archiveCmds...)}
+}
+
+// completionPrecondition prevents completing a task while any of
+// the tasks blocking it are still open. Reopening is always allowed.
+func (m taskListModel) completionPrecondition(t *items.Task) (bool, string) {
+	if !t.Completed && len(t.OpenBlockers(tasksFromItems(m.list.Items()))) > 0 {
+		return false, "Cannot complete task blocked by open tasks"
+	}
+
+	return true, ""
+}
+
+// tasksFromItems returns the tasks contained in the given list items.
+func tasksFromItems(listItems []list.Item) []items.Task {
+	tasks := make([]items.Task, 0, len(listItems))
+	for _, item := range listItems {
+		if t, ok := item.(*items.Task); ok {
+			tasks = append(tasks, *t)
+		}
+	}
+
+	return tasks
+}
@@ -22,30 +22,49 @@ package models
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/charmbracelet/bubbles/key"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/handlebargh/yatto/internal/colors"
 	"github.com/handlebargh/yatto/internal/items"
+	"github.com/handlebargh/yatto/internal/vcs"
 )
 
 // taskPagerModel represents the Bubble Tea model for the task detail view.
 type taskPagerModel struct {
-	listModel *taskListModel
-	content   string
-	ready     bool
-	viewport  viewport.Model
+	listModel     *taskListModel
+	content       string
+	ready         bool
+	viewport      viewport.Model
+	subtaskCursor int
+	attachments   []items.Attachment
+	status        string
 }
 
 // newTaskPagerModel creates a new taskPagerModel for the given task content.
+// The attachments of the selected task are listed below the content.
 func newTaskPagerModel(content string, listModel *taskListModel) taskPagerModel {
-	return taskPagerModel{
+	m := taskPagerModel{
 		listModel: listModel,
 		content:   content,
 		ready:     false,
 	}
+
+	if t := m.selectedTask(); t != nil {
+		attachments, err := t.Attachments(listModel.projectModel.config, *listModel.project)
+		if err != nil {
+			m.status = lipgloss.NewStyle().Foreground(colors.Red()).Render(err.Error())
+		}
+
+		m.attachments = attachments
+		m.content += items.AttachmentsToMarkdown(attachments)
+	}
+
+	return m
 }
 
 // Init initializes the taskPagerModel and returns an initial command.
@@ -79,6 +98,49 @@ func (m taskPagerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 			return m, nil
 
+		case key.Matches(msg, m.listModel.keys.showHistory):
+			if t := m.selectedTask(); t != nil {
+				historyModel := newTaskHistoryModel(t, m)
+				return historyModel, tea.Batch(historyModel.Init(), tea.WindowSize())
+			}
+
+			return m, nil
+
+		case key.Matches(msg, m.listModel.keys.addComment):
+			if t := m.selectedTask(); t != nil {
+				commentModel := newCommentFormModel(t, m)
+				return commentModel, tea.Batch(commentModel.Init(), tea.WindowSize())
+			}
+
+			return m, nil
+
+		case key.Matches(msg, m.listModel.keys.editDescription):
+			if t := m.selectedTask(); t != nil {
+				return m, editDescriptionCmd(t.Description)
+			}
+
+			return m, nil
+
+		case key.Matches(msg, m.listModel.keys.addAttachment):
+			if t := m.selectedTask(); t != nil {
+				attachModel := newAttachFormModel(t, m)
+				return attachModel, tea.Batch(attachModel.Init(), tea.WindowSize())
+			}
+
+			return m, nil
+
+		case key.Matches(msg, m.listModel.keys.openAttachment):
+			switch len(m.attachments) {
+			case 0:
+				m.status = lipgloss.NewStyle().Foreground(colors.Red()).Render("Task has no attachments")
+				return m, nil
+			case 1:
+				return m, m.openAttachmentCmd(m.attachments[0])
+			}
+
+			openModel := newOpenAttachmentFormModel(m)
+			return openModel, tea.Batch(openModel.Init(), tea.WindowSize())
+
 		case key.Matches(msg, m.listModel.keys.toggleInProgress):
 			return m.toggleSelectedTask(
 				func(t *items.Task) { t.InProgress = !t.InProgress },
@@ -100,7 +162,7 @@ func (m taskPagerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case key.Matches(msg, m.listModel.keys.toggleComplete):
 			return m.toggleSelectedTask(
 				func(t *items.Task) { t.Completed = !t.Completed; t.InProgress = false },
-				func(_ *items.Task) (bool, string) { return true, "" },
+				m.listModel.completionPrecondition,
 				func(t *items.Task) string {
 					if t.Completed {
 						return "complete"
@@ -109,23 +171,50 @@ func (m taskPagerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				},
 				"completion",
 			)
+
+		case key.Matches(msg, m.listModel.keys.nextSubtask):
+			if t := m.selectedTask(); t != nil {
+				if n := len(checklist(t)); n > 0 {
+					m.subtaskCursor = (m.subtaskCursor + 1) % n
+				}
+			}
+			return m, nil
+
+		case key.Matches(msg, m.listModel.keys.prevSubtask):
+			if t := m.selectedTask(); t != nil {
+				if n := len(checklist(t)); n > 0 {
+					m.subtaskCursor = (m.subtaskCursor - 1 + n) % n
+				}
+			}
+			return m, nil
+
+		case key.Matches(msg, m.listModel.keys.toggleSubtask):
+			return m.toggleChecklistItem()
 		}
+	case editorFinishedMsg:
+		return m.saveDescription(msg)
+
+	case openAttachmentErrorMsg:
+		m.status = lipgloss.NewStyle().Foreground(colors.Red()).Render(fmt.Sprintf("Could not open attachment: %s", msg.err))
+		return m, nil
+
 	case tea.WindowSizeMsg:
 		footerHeight := lipgloss.Height(m.footerView())
 
 		if !m.ready {
-			rendered, err := m.listModel.projectModel.state.renderer.Render(m.content)
-			if err != nil {
-				rendered = "Error rendering markdown"
-			}
-
 			m.viewport = viewport.New(msg.Width, msg.Height-footerHeight)
 			m.viewport.YPosition = 10
-			m.viewport.SetContent(rendered)
+			m.viewport.SetContent(m.render())
 			m.ready = true
 		} else {
+			resized := m.viewport.Width != msg.Width
 			m.viewport.Width = msg.Width
 			m.viewport.Height = msg.Height - footerHeight
+
+			// Rewrap the markdown for the new width.
+			if resized {
+				m.viewport.SetContent(m.render())
+			}
 		}
 	}
 
@@ -144,12 +233,162 @@ func (m taskPagerModel) View() string {
 }
 
 // footerView returns the string representation of the task detail view's footer.
+// If the task has subtasks, the currently focused subtask is shown on the left,
+// otherwise the latest status message.
 func (m taskPagerModel) footerView() string {
 	info := lipgloss.NewStyle().
 		Padding(0, 1).
-		Render(fmt.Sprintf("%3.f%%", m.viewport.ScrollPercent()*100))
-	line := strings.Repeat(" ", max(0, m.viewport.Width-lipgloss.Width(info)))
-	return lipgloss.JoinHorizontal(lipgloss.Center, line, info)
+		Render(fmt.Sprintf("%s %s • %s %s • %s %s • %s %s • %s %s  %3.f%%",
+			m.listModel.keys.editDescription.Help().Key,
+			"edit",
+			m.listModel.keys.addComment.Help().Key,
+			m.listModel.keys.addComment.Help().Desc,
+			m.listModel.keys.addAttachment.Help().Key,
+			m.listModel.keys.addAttachment.Help().Desc,
+			m.listModel.keys.openAttachment.Help().Key,
+			"open",
+			m.listModel.keys.showHistory.Help().Key,
+			m.listModel.keys.showHistory.Help().Desc,
+			m.viewport.ScrollPercent()*100,
+		))
+
+	subtask := m.subtaskView()
+	if subtask == "" {
+		subtask = lipgloss.NewStyle().Padding(0, 1).Render(m.status)
+	}
+	line := strings.Repeat(" ", max(0, m.viewport.Width-lipgloss.Width(subtask)-lipgloss.Width(info)))
+	return lipgloss.JoinHorizontal(lipgloss.Center, subtask, line, info)
+}
+
+// checklist returns the subtasks of t followed by the checkboxes
+// in its description. The pager's subtask cursor points into it.
+func checklist(t *items.Task) items.Subtasks {
+	return append(slices.Clone(t.Subtasks), t.DescriptionCheckboxes()...)
+}
+
+// subtaskView returns the focused subtask or description checkbox of the
+// selected task along with the keys to navigate and toggle them.
+// Returns an empty string if the task has neither.
+func (m taskPagerModel) subtaskView() string {
+	t := m.selectedTask()
+	if t == nil {
+		return ""
+	}
+
+	entries := checklist(t)
+	if len(entries) == 0 {
+		return ""
+	}
+
+	idx := min(m.subtaskCursor, len(entries)-1)
+	entry := entries[idx]
+
+	kind := "subtask"
+	if idx >= len(t.Subtasks) {
+		kind = "checkbox"
+	}
+
+	box := "[ ]"
+	if entry.Done {
+		box = "[x]"
+	}
+
+	keys := m.listModel.keys
+	help := lipgloss.NewStyle().
+		Foreground(lipgloss.Color("240")).
+		Render(fmt.Sprintf("%s/%s select • %s toggle",
+			keys.nextSubtask.Help().Key,
+			keys.prevSubtask.Help().Key,
+			keys.toggleSubtask.Help().Key,
+		))
+
+	return lipgloss.NewStyle().
+		Padding(0, 1).
+		Render(fmt.Sprintf("%s %d/%d %s %s  %s", kind, idx+1, len(entries), box, entry.Title, help))
+}
+
+// toggleChecklistItem toggles the subtask or description checkbox under
+// the cursor. The change is saved like any other toggle and the updated
+// task is shown again.
+func (m taskPagerModel) toggleChecklistItem() (tea.Model, tea.Cmd) {
+	t := m.selectedTask()
+	if t == nil || len(checklist(t)) == 0 {
+		return m, nil
+	}
+
+	idx := min(m.subtaskCursor, len(checklist(t))-1)
+	subtasks := len(t.Subtasks)
+
+	action := "subtask"
+	toggle := func(t *items.Task) { t.Subtasks[idx].Done = !t.Subtasks[idx].Done }
+	if idx >= subtasks {
+		action = "checkbox"
+		toggle = func(t *items.Task) { t.ToggleDescriptionCheckbox(idx - subtasks) }
+	}
+
+	_, cmd := m.toggleSelectedTask(
+		toggle,
+		func(_ *items.Task) (bool, string) { return true, "" },
+		func(_ *items.Task) string { return "update" },
+		action,
+	)
+
+	// Keep the scroll position while showing the updated task.
+	m.content = newTaskPagerModel(t.TaskToMarkdown(), m.listModel).content
+	m.viewport.SetContent(m.render())
+	m.subtaskCursor = idx
+
+	return m, cmd
+}
+
+// render returns the pager's markdown content rendered for the terminal.
+func (m taskPagerModel) render() string {
+	rendered, err := m.listModel.projectModel.state.markdownRenderer(m.viewport.Width).Render(m.content)
+	if err != nil {
+		return "Error rendering markdown"
+	}
+
+	return rendered
+}
+
+// selectedTask returns the task shown in the pager or nil
+// if no task is selected in the underlying list.
+func (m taskPagerModel) selectedTask() *items.Task {
+	if selected := m.listModel.list.SelectedItem(); selected != nil {
+		if t, ok := selected.(*items.Task); ok {
+			return t
+		}
+	}
+
+	return nil
+}
+
+// saveDescription writes and commits the description edited in the user's
+// editor and shows the updated task. Unchanged descriptions are not saved.
+func (m taskPagerModel) saveDescription(msg editorFinishedMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.status = lipgloss.NewStyle().
+			Foreground(colors.Red()).
+			Render(fmt.Sprintf("Could not edit description: %s", msg.err))
+		return m, nil
+	}
+
+	t := m.selectedTask()
+	if t == nil || t.Description == msg.content {
+		return m, nil
+	}
+
+	config := m.listModel.projectModel.config
+	project := *m.listModel.project
+	t.Description = msg.content
+
+	cmd := tea.Sequence(
+		t.WriteTaskJSON(config, project, "update"),
+		vcs.CommitCmd(config, fmt.Sprintf("update: %s", t.Title), t.Path(project)),
+	)
+
+	pagerModel := newTaskPagerModel(t.TaskToMarkdown(), m.listModel)
+	return pagerModel, tea.Batch(cmd, tea.WindowSize())
 }
 
 // toggleSelectedTask toggles the state of the currently selected task using
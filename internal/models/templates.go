@@ -0,0 +1,585 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/huh"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/google/uuid"
+	"github.com/handlebargh/yatto/internal/colors"
+	"github.com/handlebargh/yatto/internal/helpers"
+	"github.com/handlebargh/yatto/internal/items"
+	"github.com/handlebargh/yatto/internal/vcs"
+)
+
+// templateItem wraps a template to show it in a list.
+type templateItem struct {
+	template items.Template
+}
+
+// Title returns the name of the template.
+func (i templateItem) Title() string { return i.template.Name }
+
+// Description summarizes the values the template pre-fills.
+func (i templateItem) Description() string {
+	parts := []string{i.template.Priority}
+	if i.template.Title != "" {
+		parts = append(parts, fmt.Sprintf("%q", i.template.Title))
+	}
+	if len(i.template.Labels) > 0 {
+		parts = append(parts, i.template.Labels.String())
+	}
+
+	return strings.Join(parts, " · ")
+}
+
+// FilterValue returns the name of the template for list filtering.
+func (i templateItem) FilterValue() string { return i.template.Name }
+
+// templateListKeyMap defines the key bindings of the template list.
+type templateListKeyMap struct {
+	goBack         key.Binding
+	addTemplate    key.Binding
+	editTemplate   key.Binding
+	deleteTemplate key.Binding
+}
+
+// newTemplateListKeyMap returns a new set of key
+// bindings for template list operations.
+func newTemplateListKeyMap() *templateListKeyMap {
+	return &templateListKeyMap{
+		goBack: key.NewBinding(
+			key.WithKeys("q", "esc"),
+			key.WithHelp("q/esc", "go back"),
+		),
+		addTemplate: key.NewBinding(
+			key.WithKeys("a"),
+			key.WithHelp("a", "add template"),
+		),
+		editTemplate: key.NewBinding(
+			key.WithKeys("e", "enter"),
+			key.WithHelp("e/enter", "edit template"),
+		),
+		deleteTemplate: key.NewBinding(
+			key.WithKeys("D"),
+			key.WithHelp("D", "delete template"),
+		),
+	}
+}
+
+// templateListModel lists the task templates and
+// lets the user create, edit and delete them.
+type templateListModel struct {
+	list          list.Model
+	projectModel  *ProjectListModel
+	keys          *templateListKeyMap
+	confirmDelete bool
+	width, height int
+}
+
+// newTemplateListModel returns a templateListModel
+// showing all templates in storage.
+func newTemplateListModel(projectModel *ProjectListModel, width, height int) templateListModel {
+	keys := newTemplateListKeyMap()
+
+	delegate := list.NewDefaultDelegate()
+	delegate.Styles.SelectedTitle = delegate.Styles.SelectedTitle.
+		Foreground(colors.Indigo()).
+		BorderForeground(colors.Indigo())
+	delegate.Styles.SelectedDesc = delegate.Styles.SelectedTitle
+
+	templateList := list.New(nil, delegate, width, height)
+	templateList.Title = "Task templates"
+	templateList.Styles.Title = lipgloss.NewStyle().
+		Foreground(colors.BadgeText()).
+		Background(colors.Indigo()).
+		Padding(0, 1)
+	templateList.SetStatusBarItemName("template", "templates")
+	templateList.StatusMessageLifetime = 3 * time.Second
+	templateList.DisableQuitKeybindings()
+	templateList.AdditionalShortHelpKeys = func() []key.Binding {
+		return []key.Binding{keys.addTemplate, keys.editTemplate, keys.deleteTemplate, keys.goBack}
+	}
+	templateList.AdditionalFullHelpKeys = templateList.AdditionalShortHelpKeys
+
+	m := templateListModel{
+		list:         templateList,
+		projectModel: projectModel,
+		keys:         keys,
+		width:        width,
+		height:       height,
+	}
+	// The warning stays until the next status message.
+	if status := m.reload(); status != "" {
+		m.list.NewStatusMessage(status)
+	}
+
+	return m
+}
+
+// reload reads the templates from storage into the list. It returns a
+// status message if some templates could not be read, or an empty string.
+func (m *templateListModel) reload() string {
+	templates, err := items.ReadTemplatesFromFS(m.projectModel.config)
+
+	listItems := make([]list.Item, 0, len(templates))
+	for _, template := range templates {
+		listItems = append(listItems, templateItem{template: template})
+	}
+	m.list.SetItems(listItems)
+
+	if err != nil {
+		return lipgloss.NewStyle().Foreground(colors.Red()).Render("⚠  " + err.Error())
+	}
+
+	return ""
+}
+
+// saveTemplateCmd writes the template and commits it.
+func saveTemplateCmd(m *ProjectListModel, template *items.Template, action string) tea.Cmd {
+	return tea.Sequence(
+		template.WriteTemplateJSON(m.config, template.MarshalTemplate(), action),
+		vcs.CommitCmd(
+			m.config,
+			fmt.Sprintf("%s template: %s", action, template.Name),
+			template.Path(),
+		),
+	)
+}
+
+// Init initializes the template list model.
+func (m templateListModel) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles incoming messages and updates the template list accordingly.
+func (m templateListModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		h, v := appStyle.GetFrameSize()
+		m.width = msg.Width - h
+		m.height = msg.Height - v
+		m.list.SetSize(m.width, m.height)
+		return m, nil
+
+	case items.WriteTemplateJSONDoneMsg, items.TemplateDeleteDoneMsg:
+		return m, m.list.NewStatusMessage(m.reload())
+
+	case items.WriteTemplateJSONErrorMsg, items.TemplateDeleteErrorMsg:
+		return m, m.list.NewStatusMessage(lipgloss.NewStyle().
+			Foreground(colors.Red()).
+			Render(fmt.Sprintf("⚠  %s", msg)))
+
+	case vcs.CommitDoneMsg:
+		return m, m.list.NewStatusMessage(lipgloss.NewStyle().
+			Foreground(colors.Green()).
+			Render("🗘  Changes committed"))
+
+	case vcs.PushQueuedMsg:
+		return m, m.list.NewStatusMessage(unpushedStatus(msg.Unpushed))
+
+	case vcs.CommitErrorMsg, vcs.PushErrorMsg:
+		return m, m.list.NewStatusMessage(lipgloss.NewStyle().
+			Foreground(colors.Red()).
+			Render(fmt.Sprintf("⚠  %s", msg)))
+
+	case tea.KeyMsg:
+		if msg.Type == tea.KeyCtrlC {
+			return m, tea.Quit
+		}
+
+		if m.confirmDelete {
+			switch msg.String() {
+			case "y", "Y":
+				m.confirmDelete = false
+				if selected, ok := m.list.SelectedItem().(templateItem); ok {
+					template := selected.template
+					return m, tea.Sequence(
+						template.DeleteTemplateFromFS(m.projectModel.config),
+						vcs.CommitCmd(
+							m.projectModel.config,
+							"delete template: "+template.Name,
+							template.Path(),
+						),
+					)
+				}
+			case "n", "N", "esc", "q":
+				m.confirmDelete = false
+			}
+			return m, nil
+		}
+
+		if m.list.FilterState() == list.Filtering {
+			break
+		}
+
+		switch {
+		case key.Matches(msg, m.keys.goBack):
+			return m.projectModel, func() tea.Msg { return returnedToProjectListMsg{} }
+
+		case key.Matches(msg, m.keys.addTemplate):
+			template := &items.Template{ID: uuid.NewString(), Priority: "low"}
+			formModel := newTemplateFormModel(template, &m, false)
+			return formModel, tea.Batch(formModel.Init(), tea.WindowSize())
+
+		case key.Matches(msg, m.keys.editTemplate):
+			if selected, ok := m.list.SelectedItem().(templateItem); ok {
+				template := selected.template
+				formModel := newTemplateFormModel(&template, &m, true)
+				return formModel, tea.Batch(formModel.Init(), tea.WindowSize())
+			}
+			return m, nil
+
+		case key.Matches(msg, m.keys.deleteTemplate):
+			if m.list.SelectedItem() != nil {
+				m.confirmDelete = true
+			}
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	m.list, cmd = m.list.Update(msg)
+	return m, cmd
+}
+
+// View renders the template list.
+func (m templateListModel) View() string {
+	if m.confirmDelete {
+		if selected, ok := m.list.SelectedItem().(templateItem); ok {
+			return lipgloss.NewStyle().
+				Width(m.width).
+				Height(m.height).
+				Align(lipgloss.Center).
+				AlignVertical(lipgloss.Center).
+				Render(fmt.Sprintf("Delete template %q?\n\n[y] Yes    [n] No", selected.template.Name))
+		}
+	}
+
+	return appStyle.Render(m.list.View())
+}
+
+// templateFormModel defines the Bubble Tea model for
+// a form used to create or edit a task template.
+type templateFormModel struct {
+	form          *huh.Form
+	template      *items.Template
+	listModel     *templateListModel
+	edit          bool
+	cancel        bool
+	width, height int
+	lg            *lipgloss.Renderer
+	styles        *Styles
+	vars          *templateFormVars
+}
+
+// templateFormVars holds the temporary values that are
+// populated and modified in the template form UI.
+type templateFormVars struct {
+	confirm     bool
+	name        string
+	title       string
+	description string
+	priority    string
+	labels      string
+}
+
+// newTemplateFormModel initializes and returns a new
+// templateFormModel, optionally in edit mode.
+func newTemplateFormModel(t *items.Template, listModel *templateListModel, edit bool) templateFormModel {
+	v := templateFormVars{
+		confirm:     true,
+		name:        t.Name,
+		title:       t.Title,
+		description: t.Description,
+		priority:    t.Priority,
+		labels:      t.Labels.String(),
+	}
+
+	m := templateFormModel{
+		template:  t,
+		listModel: listModel,
+		edit:      edit,
+		vars:      &v,
+		lg:        lipgloss.DefaultRenderer(),
+	}
+	m.styles = NewStyles(m.lg)
+
+	confirmQuestion := "Create template?"
+	if edit {
+		confirmQuestion = "Edit template?"
+	}
+
+	m.form = huh.NewForm(
+		huh.NewGroup(
+			huh.NewInput().
+				Title("Enter a name:").
+				Description("Shown in the template picker when adding a task.").
+				Value(&m.vars.name).
+				Validate(func(str string) error {
+					if strings.TrimSpace(str) == "" {
+						return errors.New("name must not be empty")
+					}
+					return nil
+				}),
+
+			huh.NewInput().
+				Title("Task title:").
+				Description("{date} is replaced with the current date.").
+				Value(&m.vars.title),
+
+			huh.NewText().
+				Title("Task description:").
+				Value(&m.vars.description),
+
+			huh.NewSelect[string]().
+				Options(huh.NewOptions("low", "medium", "high")...).
+				Title("Select priority").
+				Value(&m.vars.priority),
+
+			huh.NewInput().
+				Title("Labels:").
+				Description("Comma-separated list of labels.").
+				Value(&m.vars.labels),
+
+			huh.NewConfirm().
+				Title(confirmQuestion).
+				Affirmative("Yes").
+				Negative("No").
+				Value(&m.vars.confirm),
+		)).
+		WithWidth(80).
+		WithShowHelp(false).
+		WithShowErrors(false).
+		WithTheme(colors.FormTheme())
+
+	return m
+}
+
+// Init initializes the form model and returns the initial command to run.
+func (m templateFormModel) Init() tea.Cmd {
+	return m.form.Init()
+}
+
+// Update processes incoming messages and updates the model state accordingly.
+func (m templateFormModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		if m.cancel {
+			switch msg.String() {
+			case "y", "Y":
+				return m.listModel, nil
+			case "n", "N":
+				m.cancel = false
+				return m, nil
+			}
+		}
+
+		switch msg.String() {
+		case "ctrl+c":
+			return m, tea.Quit
+		case "esc":
+			m.cancel = true
+			return m, nil
+		}
+
+	case tea.WindowSizeMsg:
+		h, v := appStyle.GetFrameSize()
+		m.width = msg.Width - h
+		m.height = msg.Height - v
+	}
+
+	var cmds []tea.Cmd
+
+	form, cmd := m.form.Update(msg)
+	if f, ok := form.(*huh.Form); ok {
+		m.form = f
+		cmds = append(cmds, cmd)
+	}
+
+	if m.form.State == huh.StateCompleted {
+		if !m.vars.confirm {
+			return m.listModel, nil
+		}
+
+		m.template.Name = strings.TrimSpace(m.vars.name)
+		m.template.Title = m.vars.title
+		m.template.Description = m.vars.description
+		m.template.Priority = m.vars.priority
+		m.template.Labels = helpers.LabelsStringToSlice(m.vars.labels)
+
+		action := "create"
+		if m.edit {
+			action = "update"
+		}
+
+		cmds = append(cmds, saveTemplateCmd(m.listModel.projectModel, m.template, action))
+		return m.listModel, tea.Batch(cmds...)
+	}
+
+	return m, tea.Batch(cmds...)
+}
+
+// View renders the template form UI.
+func (m templateFormModel) View() string {
+	if m.cancel {
+		centeredStyle := lipgloss.NewStyle().
+			Width(m.width).
+			Height(m.height).
+			Align(lipgloss.Center).
+			AlignVertical(lipgloss.Center)
+
+		if m.edit {
+			return centeredStyle.Render("Cancel edit?\n\n[y] Yes   [n] No")
+		}
+
+		return centeredStyle.Render("Cancel template creation?\n\n[y] Yes   [n] No")
+	}
+
+	v := strings.TrimSuffix(m.form.View(), "\n\n")
+	form := m.lg.NewStyle().Margin(1, 0).Render(v)
+
+	header := formBoundaryView(m.styles, m.width, "Create new template", colors.Indigo())
+	if m.edit {
+		header = formBoundaryView(m.styles, m.width, "Edit template", colors.Orange())
+	}
+
+	if errs := m.form.Errors(); len(errs) > 0 {
+		header = formBoundaryView(m.styles, m.width, errs[0].Error(), colors.Red())
+	}
+
+	footer := formBoundaryView(m.styles, m.width, m.form.Help().ShortHelpView(m.form.KeyBinds()), colors.Indigo())
+
+	return m.styles.Base.Render(header + "\n" + form + "\n\n" + footer)
+}
+
+// formBoundaryView returns text followed by a colored boundary
+// filling the given width, used to frame forms.
+func formBoundaryView(s *Styles, width int, text string, color lipgloss.AdaptiveColor) string {
+	return lipgloss.PlaceHorizontal(
+		width,
+		lipgloss.Left,
+		s.HeaderText.Foreground(color).Render(text),
+		lipgloss.WithWhitespaceChars("❯"),
+		lipgloss.WithWhitespaceForeground(color),
+	)
+}
+
+// templatePickerModel asks which template a new task is based on.
+type templatePickerModel struct {
+	form          *huh.Form
+	listModel     *taskListModel
+	templates     []items.Template
+	choice        *int
+	width, height int
+	lg            *lipgloss.Renderer
+	styles        *Styles
+}
+
+// newTemplatePickerModel returns a templatePickerModel
+// offering an empty task and the given templates.
+func newTemplatePickerModel(listModel *taskListModel, templates []items.Template) templatePickerModel {
+	m := templatePickerModel{
+		listModel: listModel,
+		templates: templates,
+		choice:    new(int),
+		lg:        lipgloss.DefaultRenderer(),
+	}
+	m.styles = NewStyles(m.lg)
+
+	*m.choice = -1
+	options := []huh.Option[int]{huh.NewOption("Empty task", -1)}
+	for i, template := range templates {
+		options = append(options, huh.NewOption(template.Name, i))
+	}
+
+	m.form = huh.NewForm(
+		huh.NewGroup(
+			huh.NewSelect[int]().
+				Title("Start from a template").
+				Options(options...).
+				Value(m.choice).
+				Height(min(len(options), 10) + 1),
+		)).
+		WithWidth(80).
+		WithShowHelp(false).
+		WithTheme(colors.FormTheme())
+
+	return m
+}
+
+// Init initializes the picker and returns the initial command to run.
+func (m templatePickerModel) Init() tea.Cmd {
+	return m.form.Init()
+}
+
+// Update processes incoming messages and opens the task
+// form once a template was chosen.
+func (m templatePickerModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c":
+			return m, tea.Quit
+		case "esc":
+			return m.listModel, nil
+		}
+
+	case tea.WindowSizeMsg:
+		h, v := appStyle.GetFrameSize()
+		m.width = msg.Width - h
+		m.height = msg.Height - v
+	}
+
+	form, cmd := m.form.Update(msg)
+	if f, ok := form.(*huh.Form); ok {
+		m.form = f
+	}
+
+	if m.form.State == huh.StateCompleted {
+		task := &items.Task{ID: uuid.NewString()}
+		if *m.choice >= 0 {
+			task = m.templates[*m.choice].Task(time.Now())
+		}
+
+		formModel := newTaskFormModel(task, m.listModel, false)
+		return formModel, tea.WindowSize()
+	}
+
+	return m, cmd
+}
+
+// View renders the template picker.
+func (m templatePickerModel) View() string {
+	header := formBoundaryView(m.styles, m.width, "Create new task", colors.Green())
+	form := m.lg.NewStyle().Margin(1, 0).Render(strings.TrimSuffix(m.form.View(), "\n\n"))
+	footer := formBoundaryView(m.styles, m.width, m.form.Help().ShortHelpView(m.form.KeyBinds()), colors.Green())
+
+	return m.styles.Base.Render(header + "\n" + form + "\n\n" + footer)
+}
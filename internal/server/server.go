@@ -0,0 +1,222 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package server implements the HTTP API of yatto serve. It exposes the
+// projects and tasks of the storage directory as JSON, reading and
+// writing them through the Go API in pkg/yatto.
+package server
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/handlebargh/yatto/pkg/yatto"
+)
+
+// maxBodySize limits the size of request bodies.
+const maxBodySize = 1 << 20
+
+// Server serves the HTTP API. All requests need the token as bearer token
+// in the Authorization header.
+type Server struct {
+	client *yatto.Client
+	token  string
+	mux    *http.ServeMux
+
+	// mu serializes the requests, as the client is not safe
+	// for concurrent use and changes are committed one by one.
+	mu sync.Mutex
+}
+
+// New returns a Server using client that accepts the given token.
+func New(client *yatto.Client, token string) *Server {
+	s := &Server{client: client, token: token, mux: http.NewServeMux()}
+
+	s.mux.HandleFunc("GET /projects", s.listProjects)
+	s.mux.HandleFunc("GET /tasks", s.listTasks)
+	s.mux.HandleFunc("POST /tasks", s.createTask)
+	s.mux.HandleFunc("PATCH /tasks/{id}", s.updateTask)
+	s.mux.HandleFunc("POST /sync", s.sync)
+
+	return s
+}
+
+// ServeHTTP checks the token and serves the request.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) != 1 {
+		writeError(w, http.StatusUnauthorized, errors.New("missing or invalid token"))
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.mux.ServeHTTP(w, r)
+}
+
+// listProjects responds with all projects.
+func (s *Server) listProjects(w http.ResponseWriter, _ *http.Request) {
+	projects, err := s.client.ListProjects()
+	if !skippedOnly(err) {
+		writeClientError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, nonNil(projects))
+}
+
+// listTasks responds with the tasks of the project given by the
+// project query parameter.
+func (s *Server) listTasks(w http.ResponseWriter, r *http.Request) {
+	tasks, err := s.client.ListTasks(r.URL.Query().Get("project"))
+	if !skippedOnly(err) {
+		writeClientError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, nonNil(tasks))
+}
+
+// createTask adds the task in the request body to the project given
+// by the project query parameter and responds with the created task.
+func (s *Server) createTask(w http.ResponseWriter, r *http.Request) {
+	var task yatto.Task
+	if err := readJSON(r, &task); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	created, err := s.client.CreateTask(r.URL.Query().Get("project"), task)
+	if err != nil {
+		writeClientError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, created)
+}
+
+// updateTask applies the fields in the request body to the task and
+// responds with the updated task. Fields missing in the body are kept.
+func (s *Server) updateTask(w http.ResponseWriter, r *http.Request) {
+	projectID := r.URL.Query().Get("project")
+
+	tasks, err := s.client.ListTasks(projectID)
+	if !skippedOnly(err) {
+		writeClientError(w, err)
+		return
+	}
+
+	var task *yatto.Task
+	for i := range tasks {
+		if tasks[i].ID == r.PathValue("id") {
+			task = &tasks[i]
+			break
+		}
+	}
+	if task == nil {
+		writeError(w, http.StatusNotFound, yatto.ErrNotFound)
+		return
+	}
+
+	id := task.ID
+	if err := readJSON(r, task); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	task.ID = id
+
+	updated, err := s.client.UpdateTask(projectID, *task)
+	if err != nil {
+		writeClientError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, updated)
+}
+
+// sync pulls from and pushes to the remote repository.
+func (s *Server) sync(w http.ResponseWriter, _ *http.Request) {
+	if err := s.client.Sync(); err != nil {
+		writeClientError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// skippedOnly reports whether err is nil or only reports skipped files,
+// in which case the items that could be read are served.
+func skippedOnly(err error) bool {
+	var skipped *yatto.SkippedFilesError
+	return err == nil || errors.As(err, &skipped)
+}
+
+// nonNil returns an empty slice instead of nil, so that it is encoded
+// as an empty JSON array.
+func nonNil[T any](s []T) []T {
+	if s == nil {
+		return []T{}
+	}
+
+	return s
+}
+
+// readJSON decodes the request body into v.
+func readJSON(r *http.Request, v any) error {
+	dec := json.NewDecoder(io.LimitReader(r.Body, maxBodySize))
+	dec.DisallowUnknownFields()
+
+	return dec.Decode(v)
+}
+
+// writeJSON responds with v encoded as JSON.
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// writeError responds with err as JSON object with an error field.
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, map[string]string{"error": err.Error()})
+}
+
+// writeClientError responds with an error returned by the client,
+// using a status code that matches its kind.
+func writeClientError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+
+	switch {
+	case errors.Is(err, yatto.ErrNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, yatto.ErrInvalid):
+		status = http.StatusBadRequest
+	case errors.Is(err, yatto.ErrBlocked), errors.Is(err, yatto.ErrNoRemote):
+		status = http.StatusConflict
+	}
+
+	writeError(w, status, err)
+}
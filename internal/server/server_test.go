@@ -0,0 +1,153 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/handlebargh/yatto/pkg/yatto"
+)
+
+const testToken = "secret"
+
+func newTestServer(t *testing.T) (*httptest.Server, yatto.Project) {
+	t.Helper()
+
+	tempDir := t.TempDir()
+	config := filepath.Join(tempDir, "config.toml")
+	data := fmt.Sprintf("[storage]\npath = %q\n\n[vcs]\nbackend = \"none\"\n", tempDir)
+	if err := os.WriteFile(config, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	project := yatto.Project{ID: "test-project", Title: "Test Project"}
+	if err := os.Mkdir(filepath.Join(tempDir, project.ID), 0o750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(tempDir, project.ID, "project.json"), project.MarshalProject(), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	client, err := yatto.Open(config)
+	if err != nil {
+		t.Fatalf("Open returned an error: %v", err)
+	}
+
+	ts := httptest.NewServer(New(client, testToken))
+	t.Cleanup(ts.Close)
+
+	return ts, project
+}
+
+func request(t *testing.T, ts *httptest.Server, method, path, body string, v any) int {
+	t.Helper()
+
+	req, err := http.NewRequest(method, ts.URL+path, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Bearer "+testToken)
+
+	resp, err := ts.Client().Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	if v != nil {
+		if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+			t.Fatalf("Could not decode the response: %v", err)
+		}
+	}
+
+	return resp.StatusCode
+}
+
+func TestServer(t *testing.T) {
+	ts, project := newTestServer(t)
+
+	t.Run("requires the token", func(t *testing.T) {
+		resp, err := ts.Client().Get(ts.URL + "/projects")
+		if err != nil {
+			t.Fatal(err)
+		}
+		_ = resp.Body.Close()
+		if resp.StatusCode != http.StatusUnauthorized {
+			t.Errorf("Expected status 401, but got %d", resp.StatusCode)
+		}
+	})
+
+	var projects []yatto.Project
+	if status := request(t, ts, "GET", "/projects", "", &projects); status != http.StatusOK {
+		t.Fatalf("Expected status 200, but got %d", status)
+	}
+	if len(projects) != 1 || projects[0].ID != project.ID {
+		t.Fatalf("Expected project %s, but got %v", project.ID, projects)
+	}
+
+	var created yatto.Task
+	status := request(t, ts, "POST", "/tasks?project="+project.ID, `{"title": "Write report"}`, &created)
+	if status != http.StatusCreated {
+		t.Fatalf("Expected status 201, but got %d", status)
+	}
+
+	var updated yatto.Task
+	status = request(t, ts, "PATCH", "/tasks/"+created.ID+"?project="+project.ID, `{"priority": "high"}`, &updated)
+	if status != http.StatusOK {
+		t.Fatalf("Expected status 200, but got %d", status)
+	}
+	if updated.Title != "Write report" || updated.Priority != "high" {
+		t.Errorf("Expected only the priority to change, but got %+v", updated)
+	}
+
+	var tasks []yatto.Task
+	request(t, ts, "GET", "/tasks?project="+project.ID, "", &tasks)
+	if len(tasks) != 1 || tasks[0].Priority != "high" {
+		t.Errorf("Expected the updated task, but got %v", tasks)
+	}
+
+	tests := []struct {
+		name, method, path, body string
+		status                   int
+	}{
+		{"unknown project", "GET", "/tasks?project=missing", "", http.StatusNotFound},
+		{"unknown task", "PATCH", "/tasks/missing?project=" + project.ID, `{}`, http.StatusNotFound},
+		{"empty title", "POST", "/tasks?project=" + project.ID, `{"title": ""}`, http.StatusBadRequest},
+		{"unknown field", "POST", "/tasks?project=" + project.ID, `{"name": "x"}`, http.StatusBadRequest},
+		{"no remote", "POST", "/sync", "", http.StatusConflict},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var resp map[string]string
+			if status := request(t, ts, tt.method, tt.path, tt.body, &resp); status != tt.status {
+				t.Errorf("Expected status %d, but got %d (%s)", tt.status, status, resp["error"])
+			}
+		})
+	}
+}
@@ -24,20 +24,37 @@ package staticprinter
 
 import (
 	"cmp"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"os"
 	"regexp"
 	"slices"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/handlebargh/yatto/internal/colors"
+	"github.com/handlebargh/yatto/internal/config"
 	"github.com/handlebargh/yatto/internal/helpers"
 	"github.com/handlebargh/yatto/internal/items"
 	"github.com/handlebargh/yatto/internal/vcs"
 	"github.com/spf13/viper"
 )
 
+// Output formats supported by PrintTasks.
+const (
+	FormatTable = "table"
+	FormatJSON  = "json"
+	FormatCSV   = "csv"
+)
+
+// Formats lists all output formats supported by PrintTasks.
+var Formats = []string{FormatTable, FormatJSON, FormatCSV}
+
 // projectTask represents a single task along with the project it belongs to.
 // It is used to keep project context when working with individual tasks.
 type projectTask struct {
@@ -50,11 +67,13 @@ type projectTask struct {
 // If no project IDs are provided, it returns tasks from all available projects.
 // For each task, the associated project is also returned via the projectTask type.
 //
-// It returns two values:
+// It returns three values:
 //   - A slice of projectTask, each containing a task and its corresponding project.
 //   - A slice of strings representing project IDs that were requested but not found.
-func getProjectTasks(v *viper.Viper, projectsIDs ...string) ([]projectTask, []string) {
-	projects := helpers.ReadProjectsFromFS(v)
+//   - An error, which is an items.SkippedFilesError if only corrupt files were skipped.
+func getProjectTasks(v *viper.Viper, projectsIDs ...string) ([]projectTask, []string, error) {
+	projects, err := helpers.ReadProjectsFromFS(v)
+	errs := []error{err}
 
 	foundIDs := make(map[string]bool)
 	var result []projectTask
@@ -63,7 +82,11 @@ func getProjectTasks(v *viper.Viper, projectsIDs ...string) ([]projectTask, []st
 		id := project.ID
 		if len(projectsIDs) == 0 || slices.Contains(projectsIDs, id) {
 			foundIDs[id] = true
-			for _, task := range project.ReadTasksFromFS(v) {
+
+			tasks, err := project.ReadTasksFromFS(v)
+			errs = append(errs, err)
+
+			for _, task := range tasks {
 				result = append(result, projectTask{
 					project: project,
 					task:    task,
@@ -81,7 +104,7 @@ func getProjectTasks(v *viper.Viper, projectsIDs ...string) ([]projectTask, []st
 		}
 	}
 
-	return result, missing
+	return result, missing, items.CollectSkipped(errs...)
 }
 
 // sortTasks sorts a slice of projectTask items in-place using a stable sort,
@@ -150,42 +173,54 @@ func sortTasks(v *viper.Viper, tasks []projectTask) {
 	})
 }
 
-// PrintTasks displays a styled list of all non-completed tasks for the given project IDs.
+// PrintTasks prints all non-completed tasks for the given project IDs in the given format.
 //
 // For each provided project ID, it attempts to retrieve associated tasks. If any project IDs
 // are not found, an error message is printed for each.
 //
-// The remaining tasks are filtered to exclude completed ones, then sorted by in-progress state,
-// due date, and priority using sortTasks. Each task is printed with:
-//   - A cropped task title
-//   - The project title, color-coded
-//   - Optional labels, color-coded
-//   - Priority, styled by level (low, medium, high)
-//   - Badges indicating task state, including:
-//   - "due today", "overdue", "in progress", or "due in N day(s)"
-func PrintTasks(v *viper.Viper, labelRegex string, author, assignee bool, projectsIDs ...string) {
-	projTask, missing := getProjectTasks(v, projectsIDs...)
+// The remaining tasks are filtered to exclude completed ones and, unless deferred
+// is true, tasks whose start date is in the future. If assignee is true, only the tasks
+// assigned to or watched by the current user are kept. They are then sorted by in-progress
+// state, due date, and priority using sortTasks. Depending on format, they are printed as a styled
+// table (see printTable), as a JSON array (see printJSON) or as CSV (see printCSV).
+// Returns an error if the format is unknown or the output cannot be written.
+func PrintTasks(v *viper.Viper, format, labelRegex string, author, assignee, deferred bool, projectsIDs ...string) error {
+	projTask, missing, err := getProjectTasks(v, projectsIDs...)
+
+	var skipped *items.SkippedFilesError
+	if errors.As(err, &skipped) {
+		_, _ = fmt.Fprintf(os.Stderr, "warning: %v\nRun 'yatto doctor' for details.\n", err)
+	} else if err != nil {
+		return err
+	}
 
-	if len(missing) > 0 {
-		for _, projectID := range missing {
+	for _, projectID := range missing {
+		if format == FormatTable {
 			fmt.Println(
 				lipgloss.NewStyle().
 					Foreground(colors.Red()).
-					Render(fmt.Sprintf("\nerror: project ID %s not found\n", projectID)),
+					Render(fmt.Sprintf("\nerror: project %s not found\n", projectID)),
 			)
+		} else {
+			_, _ = fmt.Fprintf(os.Stderr, "error: project %s not found\n", projectID)
 		}
 	}
 
 	me, _ := vcs.User(v)
 	regex := regexp.MustCompile(labelRegex)
+	now := time.Now()
 
 	var pendingTasks []projectTask
 	for _, pt := range projTask {
+		if !deferred && pt.task.Deferred(now) {
+			continue
+		}
+
 		if !pt.task.Completed && regex.MatchString(pt.task.Labels.String()) {
 			switch {
 			case author && pt.task.Author == me:
 				pendingTasks = append(pendingTasks, pt)
-			case assignee && pt.task.Assignee == me:
+			case assignee && pt.task.Involves(me):
 				pendingTasks = append(pendingTasks, pt)
 			case !author && !assignee:
 				pendingTasks = append(pendingTasks, pt)
@@ -197,6 +232,35 @@ func PrintTasks(v *viper.Viper, labelRegex string, author, assignee bool, projec
 
 	sortTasks(v, pendingTasks)
 
+	switch format {
+	case FormatTable:
+		printTable(v, pendingTasks)
+		return nil
+	case FormatJSON:
+		return printJSON(os.Stdout, pendingTasks)
+	case FormatCSV:
+		return printCSV(os.Stdout, pendingTasks)
+	default:
+		return fmt.Errorf("unknown format %q (valid: %s)", format, strings.Join(Formats, ", "))
+	}
+}
+
+// printTable displays a styled list of the given tasks.
+// Each task is printed with:
+//   - A cropped task title
+//   - The project title, color-coded
+//   - Optional labels, color-coded
+//   - Priority, styled by level (low, medium, high)
+//   - Badges indicating task state, including:
+//   - "due today", "overdue", "in progress", or "due in N day(s)", highlighted
+//     as set by the "due.soon" and "due.all_day" settings
+//   - "watching", if the current user watches the task
+//
+// In the plain output mode, the rows are printed without colors
+// and Unicode glyphs, see colors.SetPlain.
+func printTable(v *viper.Viper, pendingTasks []projectTask) {
+	cfg := config.Get(v)
+
 	if len(pendingTasks) == 0 {
 		fmt.Println(
 			lipgloss.NewStyle().
@@ -205,6 +269,9 @@ func PrintTasks(v *viper.Viper, labelRegex string, author, assignee bool, projec
 		)
 	}
 
+	due := items.DueSettingsFromConfig(v)
+	now := time.Now()
+
 	for _, pt := range pendingTasks {
 		taskTitle := pt.task.CropTaskTitle(40)
 		projectTitle := lipgloss.NewStyle().
@@ -221,14 +288,14 @@ func PrintTasks(v *viper.Viper, labelRegex string, author, assignee bool, projec
 		left.WriteString("\n")
 		left.WriteString(lipgloss.NewStyle().Width(50).Foreground(colors.Blue()).Render(pt.task.CropTaskLabels(40)))
 
-		if v.GetBool("author.show_printer") {
+		if cfg.AuthorShowPrinter {
 			left.WriteString("\n")
 			left.WriteString(lipgloss.NewStyle().Foreground(colors.Green()).Render("Author: "))
 			left.WriteString(pt.task.Author)
 		}
 
 		me, _ := vcs.User(v)
-		if v.GetBool("assignee.show_printer") {
+		if cfg.AssigneeShowPrinter {
 			left.WriteString("\n")
 			left.WriteString(lipgloss.NewStyle().Foreground(colors.Orange()).Render("Assignee: "))
 			if pt.task.Assignee == me {
@@ -256,25 +323,21 @@ func PrintTasks(v *viper.Viper, labelRegex string, author, assignee bool, projec
 		right.WriteString("\n")
 		right.WriteString(priorityValueStyle.Render(taskPriority))
 
-		now := time.Now()
-		dueDate := pt.task.DueDate
+		urgency := due.Urgency(&pt.task, now)
 
-		if dueDate != nil &&
-			items.IsToday(dueDate) &&
-			dueDate.After(now) {
+		switch urgency {
+		case items.UrgencyOverdue:
 			right.WriteString(lipgloss.NewStyle().
 				Padding(0, 1).
 				Background(colors.VividRed()).
 				Foreground(colors.BadgeText()).
-				Render("due today"))
-		}
-
-		if dueDate != nil && dueDate.Before(now) {
+				Render("overdue"))
+		case items.UrgencySoon:
 			right.WriteString(lipgloss.NewStyle().
 				Padding(0, 1).
 				Background(colors.VividRed()).
 				Foreground(colors.BadgeText()).
-				Render("overdue"))
+				Render(pt.task.DueText()))
 		}
 
 		if pt.task.InProgress {
@@ -285,18 +348,91 @@ func PrintTasks(v *viper.Viper, labelRegex string, author, assignee bool, projec
 				Render("in progress"))
 		}
 
-		if dueDate != nil &&
-			!dueDate.Before(now) &&
-			!items.IsToday(dueDate) {
+		if pt.task.Watching(me) {
+			right.WriteString(lipgloss.NewStyle().
+				Padding(0, 1).
+				Background(colors.Indigo()).
+				Foreground(colors.BadgeText()).
+				Render("watching"))
+		}
+
+		if urgency == items.UrgencyLater {
 			right.WriteString(lipgloss.NewStyle().
 				Padding(0, 1).
 				Background(colors.Yellow()).
 				Foreground(colors.BadgeText()).
-				Render("due in " + pt.task.DaysUntilToString() + " day(s)"))
+				Render(pt.task.DueText()))
 		}
 
 		row := lipgloss.JoinHorizontal(lipgloss.Top, left.String(), right.String())
 
-		fmt.Println(row)
+		fmt.Println(colors.ASCII(row))
+	}
+}
+
+// printedTask is the machine-readable representation of a task
+// including the project it belongs to.
+type printedTask struct {
+	ProjectID    string `json:"project_id"`
+	ProjectTitle string `json:"project_title"`
+	items.Task
+}
+
+// printJSON writes the given tasks as an indented JSON array to w.
+func printJSON(w io.Writer, pendingTasks []projectTask) error {
+	result := make([]printedTask, 0, len(pendingTasks))
+	for _, pt := range pendingTasks {
+		result = append(result, printedTask{
+			ProjectID:    pt.project.ID,
+			ProjectTitle: pt.project.Title,
+			Task:         pt.task,
+		})
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	encoder.SetEscapeHTML(false)
+
+	return encoder.Encode(result)
+}
+
+// printCSV writes the given tasks as CSV with a header row to w.
+// Labels and watchers are joined by commas and due dates are formatted as RFC3339.
+func printCSV(w io.Writer, pendingTasks []projectTask) error {
+	writer := csv.NewWriter(w)
+
+	if err := writer.Write([]string{
+		"project_id", "project_title", "id", "title", "priority", "labels",
+		"author", "assignee", "in_progress", "completed", "due_date", "watchers",
+	}); err != nil {
+		return err
 	}
+
+	for _, pt := range pendingTasks {
+		dueDate := ""
+		if pt.task.DueDate != nil {
+			dueDate = pt.task.DueDate.Format(time.RFC3339)
+		}
+
+		if err := writer.Write([]string{
+			pt.project.ID,
+			pt.project.Title,
+			pt.task.ID,
+			pt.task.Title,
+			pt.task.Priority,
+			pt.task.Labels.String(),
+			pt.task.Author,
+			pt.task.Assignee,
+			strconv.FormatBool(pt.task.InProgress),
+			strconv.FormatBool(pt.task.Completed),
+			dueDate,
+			strings.Join(pt.task.Watchers, ","),
+		}); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+
+	return writer.Error()
 }
@@ -0,0 +1,95 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package storage
+
+import (
+	"crypto/rand"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/handlebargh/yatto/internal/logging"
+)
+
+// tmpInfix separates the name of the target file from the random
+// suffix of the temporary files created by AtomicWrite.
+const tmpInfix = ".tmp-"
+
+// AtomicWrite writes data to the file name within root, so that the file
+// either keeps its old contents or has the new ones, even after a crash
+// or power loss. The data is written to a temporary file in the same
+// directory, which is flushed to disk and renamed to name afterwards.
+func AtomicWrite(root *os.Root, name string, data []byte, perm os.FileMode) (err error) {
+	dir := filepath.Dir(name)
+	tmpName := filepath.Join(dir, "."+filepath.Base(name)+tmpInfix+rand.Text())
+
+	defer func() {
+		if err != nil {
+			logging.Warn("write failed", "file", name, "err", err)
+			return
+		}
+		logging.Debug("wrote file", "file", name, "bytes", len(data))
+	}()
+
+	tmp, err := root.OpenFile(tmpName, os.O_WRONLY|os.O_CREATE|os.O_EXCL, perm)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if err != nil {
+			_ = root.Remove(tmpName)
+		}
+	}()
+
+	if _, err := tmp.Write(data); err != nil {
+		return errors.Join(err, tmp.Close())
+	}
+
+	if err := tmp.Sync(); err != nil {
+		return errors.Join(err, tmp.Close())
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	if err := root.Rename(tmpName, name); err != nil {
+		return err
+	}
+
+	// Persist the rename. Not all platforms support syncing
+	// directories, so this is done on a best-effort basis.
+	if d, err := root.Open(dir); err == nil {
+		_ = d.Sync()
+		_ = d.Close()
+	}
+
+	return nil
+}
+
+// IsTempFile reports whether the file name belongs to a temporary file
+// created by AtomicWrite. Such files only remain after an interrupted write.
+func IsTempFile(name string) bool {
+	base := filepath.Base(name)
+	return strings.HasPrefix(base, ".") && strings.Contains(base, tmpInfix)
+}
@@ -0,0 +1,119 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"time"
+
+	"github.com/handlebargh/yatto/internal/config"
+	"github.com/spf13/viper"
+)
+
+// CrashFile is the name of the file in the storage directory that keeps
+// the session of the TUI if it crashed, so that it can be restored on
+// the next launch. It is never committed.
+const CrashFile = ".yatto-crash.json"
+
+// Session is the navigation state of the TUI and the input of an open
+// form, saved if the TUI crashed.
+type Session struct {
+	// Time is the time of the crash.
+	Time time.Time `json:"time"`
+
+	// Panic is the value the TUI panicked with, Stack its stack trace.
+	Panic string `json:"panic"`
+	Stack string `json:"stack,omitempty"`
+
+	// ProjectID is the ID of the open or selected project, if any.
+	ProjectID string `json:"project_id,omitempty"`
+
+	// TaskID is the ID of the open or selected task, if any.
+	TaskID string `json:"task_id,omitempty"`
+
+	// Form is "task" or "project" if a form was open. Edit is true if it
+	// edited an existing task or project instead of creating a new one.
+	Form string `json:"form,omitempty"`
+	Edit bool   `json:"edit,omitempty"`
+
+	// Input maps the names of the text fields of the form to their values.
+	Input map[string]string `json:"input,omitempty"`
+}
+
+// ReadCrash reads the session saved by the last crash of the TUI.
+// It returns nil if there is none.
+func ReadCrash(v *viper.Viper) (*Session, error) {
+	root, err := os.OpenRoot(config.Get(v).StoragePath)
+	if err != nil {
+		return nil, fmt.Errorf("could not open storage directory: %w", err)
+	}
+	defer root.Close() //nolint:errcheck
+
+	data, err := root.ReadFile(CrashFile)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var session Session
+	if err := json.Unmarshal(data, &session); err != nil {
+		return nil, fmt.Errorf("invalid crash file: %w", err)
+	}
+
+	return &session, nil
+}
+
+// WriteCrash saves the session of the crashed TUI to the storage directory.
+func WriteCrash(v *viper.Viper, session Session) error {
+	data, err := json.MarshalIndent(session, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	root, err := os.OpenRoot(config.Get(v).StoragePath)
+	if err != nil {
+		return fmt.Errorf("could not open storage directory: %w", err)
+	}
+	defer root.Close() //nolint:errcheck
+
+	return AtomicWrite(root, CrashFile, data, 0o600)
+}
+
+// RemoveCrash removes the saved session, e.g. once it was restored.
+func RemoveCrash(v *viper.Viper) error {
+	root, err := os.OpenRoot(config.Get(v).StoragePath)
+	if err != nil {
+		return fmt.Errorf("could not open storage directory: %w", err)
+	}
+	defer root.Close() //nolint:errcheck
+
+	if err := root.Remove(CrashFile); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,84 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package storage
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/handlebargh/yatto/internal/config"
+	"github.com/spf13/viper"
+)
+
+// IgnoreFile is the name of the file in the storage directory that keeps
+// the local files of yatto out of the repository, see WriteIgnoreFile.
+const IgnoreFile = ".gitignore"
+
+// ignorePatterns match the files in the storage directory that are never
+// committed: the local state, the index, a saved session and the
+// temporary files of AtomicWrite that remain after an interrupted write.
+var ignorePatterns = []string{
+	"/" + StateFile,
+	"/" + IndexFile,
+	"/" + CrashFile,
+	".*" + tmpInfix + "*",
+}
+
+// WriteIgnoreFile adds the patterns of the files that are never committed
+// to the IgnoreFile in the storage directory, keeping the patterns it has.
+// git only commits the files yatto names, but jj snapshots the whole
+// working copy and would commit and push them otherwise.
+// Reports whether the file was changed.
+func WriteIgnoreFile(v *viper.Viper) (bool, error) {
+	root, err := os.OpenRoot(config.Get(v).StoragePath)
+	if err != nil {
+		return false, fmt.Errorf("could not open storage directory: %w", err)
+	}
+	defer root.Close() //nolint:errcheck
+
+	data, err := root.ReadFile(IgnoreFile)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return false, err
+	}
+
+	lines := strings.Split(strings.TrimRight(string(data), "\n"), "\n")
+
+	var missing []string
+	for _, pattern := range ignorePatterns {
+		if !slices.Contains(lines, pattern) {
+			missing = append(missing, pattern)
+		}
+	}
+	if len(missing) == 0 {
+		return false, nil
+	}
+
+	if len(data) > 0 && !strings.HasSuffix(string(data), "\n") {
+		data = append(data, '\n')
+	}
+	data = append(data, strings.Join(missing, "\n")+"\n"...)
+
+	return true, AtomicWrite(root, IgnoreFile, data, 0o600)
+}
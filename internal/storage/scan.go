@@ -0,0 +1,85 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package storage
+
+import (
+	"os"
+	"sync"
+)
+
+// scanWorkers is the maximum number of files read at the same time by
+// ScanFiles. Reading several files at once mostly pays off on network
+// filesystems, where each read waits for a round trip.
+const scanWorkers = 16
+
+// ScanResult is the outcome of reading and parsing a single file in ScanFiles.
+type ScanResult[T any] struct {
+	Path  string
+	Value T
+	Err   error
+}
+
+// ScanFiles reads the given files relative to root and parses their
+// content with parse, using a bounded pool of workers. The results are
+// returned in the order of paths. An error reading or parsing a file
+// is reported in its result and does not stop the scan.
+func ScanFiles[T any](root *os.Root, paths []string, parse func(data []byte) (T, error)) []ScanResult[T] {
+	type indexed struct {
+		i      int
+		result ScanResult[T]
+	}
+
+	jobs := make(chan int)
+	results := make(chan indexed)
+
+	var wg sync.WaitGroup
+	for range min(scanWorkers, len(paths)) {
+		wg.Go(func() {
+			for i := range jobs {
+				r := ScanResult[T]{Path: paths[i]}
+
+				data, err := root.ReadFile(paths[i])
+				if err == nil {
+					r.Value, err = parse(data)
+				}
+				r.Err = err
+
+				results <- indexed{i: i, result: r}
+			}
+		})
+	}
+
+	go func() {
+		for i := range paths {
+			jobs <- i
+		}
+		close(jobs)
+		wg.Wait()
+		close(results)
+	}()
+
+	scanned := make([]ScanResult[T], len(paths))
+	for r := range results {
+		scanned[r.i] = r.result
+	}
+
+	return scanned
+}
@@ -0,0 +1,378 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package storage
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/handlebargh/yatto/internal/config"
+	"github.com/spf13/viper"
+)
+
+// StateFile is the name of the file in the storage directory that keeps
+// local view state across launches. It is never committed.
+const StateFile = ".yatto-state.json"
+
+// IndexFile is the name of the file in the storage directory that keeps
+// a summary of all task files, so that they need not be parsed again on
+// every launch. It is rebuilt if missing and never committed.
+const IndexFile = ".yatto-index.json"
+
+// TemplatesDir is the name of the directory in the storage directory
+// that holds the task templates. It is committed like the projects.
+const TemplatesDir = ".templates"
+
+// State is the local view state kept in the StateFile.
+type State struct {
+	// ProjectSorts maps project IDs to the sort mode last used for their tasks.
+	ProjectSorts map[string]string `json:"project_sorts,omitempty"`
+
+	// UnpushedCommits is the number of commits that could not be pushed
+	// to the remote yet, e.g. because it was unreachable.
+	UnpushedCommits int `json:"unpushed_commits,omitempty"`
+
+	// OverdueNotified maps the IDs of overdue tasks the task_overdue hooks
+	// were run for to their due date at that time.
+	OverdueNotified map[string]time.Time `json:"overdue_notified,omitempty"`
+
+	// RemindersNotified maps the IDs of tasks with due reminders to the
+	// time the last reminder returned by items.DueReminders was due at.
+	RemindersNotified map[string]time.Time `json:"reminders_notified,omitempty"`
+
+	// LastSync is the time of the last successful pull from
+	// or push to the remote.
+	LastSync time.Time `json:"last_sync,omitzero"`
+
+	// Assignees lists the assignees last chosen in the task form,
+	// the most recent first.
+	Assignees []string `json:"assignees,omitempty"`
+
+	// Seen maps the IDs of tasks to the time of their last change the
+	// user has seen, see SeenTasks.
+	Seen map[string]time.Time `json:"seen,omitempty"`
+
+	// SeenSince is the time the tracking of seen tasks started at.
+	// Earlier changes count as seen.
+	SeenSince time.Time `json:"seen_since,omitzero"`
+
+	// DayStats maps project IDs to the numbers of tasks created and
+	// completed on this computer per day, keyed by date, see CountTasks.
+	DayStats map[string]map[string]DayStats `json:"day_stats,omitempty"`
+
+	// StatsSince is the time the counting of tasks started at.
+	StatsSince time.Time `json:"stats_since,omitzero"`
+}
+
+// DayStats holds the numbers of tasks created and completed
+// in a project on a day.
+type DayStats struct {
+	Created   int `json:"created,omitempty"`
+	Completed int `json:"completed,omitempty"`
+}
+
+// seenMu serializes the changes of the seen tasks in the state, which
+// are recorded concurrently along with the tasks written, see MarkSeen.
+var seenMu sync.Mutex
+
+// statsMu serializes the changes of the task counts in the state, which
+// are recorded concurrently along with the tasks written, see CountTasks.
+var statsMu sync.Mutex
+
+// maxAssignees is the number of assignees kept in the state.
+const maxAssignees = 50
+
+// ReadState reads the state from the storage directory.
+// A missing state file results in an empty state.
+func ReadState(v *viper.Viper) (State, error) {
+	var state State
+
+	root, err := os.OpenRoot(config.Get(v).StoragePath)
+	if err != nil {
+		return state, fmt.Errorf("could not open storage directory: %w", err)
+	}
+	defer root.Close() //nolint:errcheck
+
+	data, err := root.ReadFile(StateFile)
+	if errors.Is(err, fs.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return state, err
+	}
+
+	if err := json.Unmarshal(data, &state); err != nil {
+		return state, fmt.Errorf("invalid state file: %w", err)
+	}
+
+	return state, nil
+}
+
+// WriteState writes the state to the storage directory.
+func WriteState(v *viper.Viper, state State) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	root, err := os.OpenRoot(config.Get(v).StoragePath)
+	if err != nil {
+		return fmt.Errorf("could not open storage directory: %w", err)
+	}
+	defer root.Close() //nolint:errcheck
+
+	return AtomicWrite(root, StateFile, data, 0o600)
+}
+
+// ProjectSort returns the sort mode last used for the project with the
+// given ID, or an empty string if there is none or the state is unreadable.
+func ProjectSort(v *viper.Viper, projectID string) string {
+	state, err := ReadState(v)
+	if err != nil {
+		return ""
+	}
+
+	return state.ProjectSorts[projectID]
+}
+
+// SetProjectSort remembers the sort mode for the project with the given ID.
+// An empty mode forgets the project's sort mode.
+func SetProjectSort(v *viper.Viper, projectID, mode string) error {
+	state, err := ReadState(v)
+	if err != nil {
+		return err
+	}
+
+	if mode == "" {
+		delete(state.ProjectSorts, projectID)
+	} else {
+		if state.ProjectSorts == nil {
+			state.ProjectSorts = make(map[string]string)
+		}
+		state.ProjectSorts[projectID] = mode
+	}
+
+	return WriteState(v, state)
+}
+
+// UnpushedCommits returns the number of commits that have not been pushed
+// to the remote yet, or 0 if the state is unreadable.
+func UnpushedCommits(v *viper.Viper) int {
+	state, err := ReadState(v)
+	if err != nil {
+		return 0
+	}
+
+	return state.UnpushedCommits
+}
+
+// AddUnpushedCommit records a commit that could not be pushed and
+// returns the number of commits not pushed yet.
+func AddUnpushedCommit(v *viper.Viper) (int, error) {
+	state, err := ReadState(v)
+	if err != nil {
+		return 0, err
+	}
+
+	state.UnpushedCommits++
+
+	return state.UnpushedCommits, WriteState(v, state)
+}
+
+// ClearUnpushedCommits forgets about unpushed commits after a successful push.
+func ClearUnpushedCommits(v *viper.Viper) error {
+	state, err := ReadState(v)
+	if err != nil {
+		return err
+	}
+
+	if state.UnpushedCommits == 0 {
+		return nil
+	}
+
+	state.UnpushedCommits = 0
+
+	return WriteState(v, state)
+}
+
+// LastSync returns the time of the last successful pull from or push to
+// the remote, or the zero time if there is none or the state is unreadable.
+func LastSync(v *viper.Viper) time.Time {
+	state, err := ReadState(v)
+	if err != nil {
+		return time.Time{}
+	}
+
+	return state.LastSync
+}
+
+// SetLastSync records the time of a successful pull from or push to the remote.
+func SetLastSync(v *viper.Viper, t time.Time) error {
+	state, err := ReadState(v)
+	if err != nil {
+		return err
+	}
+
+	state.LastSync = t
+
+	return WriteState(v, state)
+}
+
+// Assignees returns the assignees last chosen, the most recent first,
+// or nil if there are none or the state is unreadable.
+func Assignees(v *viper.Viper) []string {
+	state, err := ReadState(v)
+	if err != nil {
+		return nil
+	}
+
+	return state.Assignees
+}
+
+// AddAssignee remembers the given assignee as the most recently chosen one.
+func AddAssignee(v *viper.Viper, assignee string) error {
+	state, err := ReadState(v)
+	if err != nil {
+		return err
+	}
+
+	if len(state.Assignees) > 0 && state.Assignees[0] == assignee {
+		return nil
+	}
+
+	assignees := []string{assignee}
+	for _, a := range state.Assignees {
+		if a != assignee && len(assignees) < maxAssignees {
+			assignees = append(assignees, a)
+		}
+	}
+	state.Assignees = assignees
+
+	return WriteState(v, state)
+}
+
+// SeenTasks returns the times of the last changes of tasks the user has
+// seen, keyed by task ID, and the time before which all changes count as
+// seen. The tracking starts with the first call, so that the tasks
+// existing by then are not reported as changed. If the state is
+// unreadable, all changes count as seen.
+func SeenTasks(v *viper.Viper) (map[string]time.Time, time.Time) {
+	seenMu.Lock()
+	defer seenMu.Unlock()
+
+	state, err := ReadState(v)
+	if err != nil {
+		return nil, time.Now()
+	}
+
+	if state.SeenSince.IsZero() {
+		state.SeenSince = time.Now()
+		if err := WriteState(v, state); err != nil {
+			return nil, state.SeenSince
+		}
+	}
+
+	return state.Seen, state.SeenSince
+}
+
+// MarkSeen records that the user has seen the task with the given ID
+// as changed at the given time. Earlier times than the one recorded
+// already are ignored.
+func MarkSeen(v *viper.Viper, taskID string, changed time.Time) error {
+	seenMu.Lock()
+	defer seenMu.Unlock()
+
+	state, err := ReadState(v)
+	if err != nil {
+		return err
+	}
+
+	if seen, ok := state.Seen[taskID]; ok && !changed.After(seen) {
+		return nil
+	}
+
+	if state.Seen == nil {
+		state.Seen = make(map[string]time.Time)
+	}
+	state.Seen[taskID] = changed
+
+	return WriteState(v, state)
+}
+
+// CountTasks adds the given numbers of tasks created and completed in the
+// project with the given ID to the counts of the day of t. The counts
+// are kept locally only and are never committed.
+func CountTasks(v *viper.Viper, projectID string, t time.Time, stats DayStats) error {
+	statsMu.Lock()
+	defer statsMu.Unlock()
+
+	state, err := ReadState(v)
+	if err != nil {
+		return err
+	}
+
+	if state.StatsSince.IsZero() {
+		state.StatsSince = t
+	}
+	if state.DayStats == nil {
+		state.DayStats = make(map[string]map[string]DayStats)
+	}
+	if state.DayStats[projectID] == nil {
+		state.DayStats[projectID] = make(map[string]DayStats)
+	}
+
+	day := t.Format(time.DateOnly)
+	counts := state.DayStats[projectID][day]
+	counts.Created += stats.Created
+	counts.Completed += stats.Completed
+	state.DayStats[projectID][day] = counts
+
+	return WriteState(v, state)
+}
+
+// ProjectDayStats returns the numbers of tasks created and completed in
+// the project with the given ID per day, keyed by date, and the time the
+// counting started at. Like SeenTasks, the counting starts with the first
+// call if no task was counted before, so that days without any tasks
+// created or completed are known to be counted.
+func ProjectDayStats(v *viper.Viper, projectID string) (map[string]DayStats, time.Time, error) {
+	statsMu.Lock()
+	defer statsMu.Unlock()
+
+	state, err := ReadState(v)
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	if state.StatsSince.IsZero() {
+		state.StatsSince = time.Now()
+		if err := WriteState(v, state); err != nil {
+			return nil, time.Time{}, err
+		}
+	}
+
+	return state.DayStats[projectID], state.StatsSince, nil
+}
@@ -25,17 +25,27 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"io/fs"
 	"os"
 	"os/exec"
 	"path/filepath"
 
 	"github.com/charmbracelet/huh"
+	"github.com/go-git/go-git/v5"
+	gitconfig "github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/handlebargh/yatto/internal/config"
 	"github.com/spf13/viper"
 )
 
 // ErrUserAborted is returned when a user cancels storage directory creation.
 var ErrUserAborted = errors.New("user aborted config creation")
 
+// ErrNotStorage is returned when the cloned remote repository is not a
+// yatto storage directory.
+var ErrNotStorage = errors.New("remote repository is not a yatto storage")
+
 // Settings defines settings used by CreateStorageDir.
 //
 // Fields:
@@ -44,26 +54,40 @@ var ErrUserAborted = errors.New("user aborted config creation")
 //   - Input:  Input stream used to read user responses (e.g., os.Stdin).
 //   - Output: Output stream used to print prompts and messages (e.g., os.Stdout).
 //   - Exit:   Function invoked to terminate the process (e.g., os.Exit).
+//   - Confirmed: Create the storage directory without asking, e.g. right after
+//     the user chose it while creating the config file.
 type Settings struct {
-	Viper  *viper.Viper
-	Input  io.Reader
-	Output io.Writer
-	Exit   func(int)
+	Viper     *viper.Viper
+	Input     io.Reader
+	Output    io.Writer
+	Exit      func(int)
+	Confirmed bool
 }
 
 // CreateStorageDir checks if the configured storage directory exists,
-// and prompts the user to create it if it does not. If the user confirms,
-// the directory is created with 0700 permissions. Exits the program if the
-// user declines or an error occurs during input.
+// and prompts the user to create it if it does not, unless settings.Confirmed
+// is set. If the user confirms, the directory is created with 0700
+// permissions or the configured remote repository is cloned into it.
+// An existing but empty storage directory is cloned into without asking,
+// so a second machine starts with the history of the remote instead of a
+// new one. Exits the program if the user declines or an error occurs
+// during input.
 func CreateStorageDir(settings Settings) error {
-	storageDir := settings.Viper.GetString("storage.path")
+	storageDir := config.Get(settings.Viper).StoragePath
 
-	_, err := os.Stat(storageDir)
-	if err != nil {
-		if !os.IsNotExist(err) {
-			return err
+	entries, err := os.ReadDir(storageDir)
+	if err == nil {
+		if len(entries) == 0 && remoteEnabled(settings.Viper) {
+			return cloneRemote(settings, storageDir)
 		}
+		return nil
+	}
 
+	if !os.IsNotExist(err) {
+		return err
+	}
+
+	if !settings.Confirmed {
 		var createStorage bool
 
 		form := huh.NewForm(
@@ -84,66 +108,210 @@ func CreateStorageDir(settings Settings) error {
 		if !createStorage {
 			return ErrUserAborted
 		}
+	}
 
-		backend := settings.Viper.GetString("vcs.backend")
-		if settings.Viper.GetBool(backend + ".remote.enable") {
-
-			jjCmd := []string{
-				"jj",
-				"git",
-				"clone",
-				"--remote",
-				settings.Viper.GetString("jj.remote.name"),
-				settings.Viper.GetString("jj.remote.url"),
-				storageDir,
-			}
+	if remoteEnabled(settings.Viper) {
+		return cloneRemote(settings, storageDir)
+	}
 
-			if settings.Viper.GetBool("jj.remote.colocate") {
-				jjCmd = append(jjCmd, "--colocate")
-			}
+	if err := os.MkdirAll(storageDir, 0o700); err != nil {
+		return fmt.Errorf("fatal error creating storage directory: %w", err)
+	}
 
-			cmds := map[string][]string{
-				"git": {"git", "clone", settings.Viper.GetString("git.remote.url"), storageDir},
-				"jj":  jjCmd,
-			}
+	return nil
+}
 
-			args, ok := cmds[backend]
-			if ok {
-				cmd := exec.Command(args[0], args[1:]...) // #nosec G204 Command uses validated config values
+// remoteEnabled reports whether a remote is configured for the vcs backend.
+// The gogit backend shares the git configuration section.
+func remoteEnabled(v *viper.Viper) bool {
+	cfg := config.Get(v)
 
-				cmd.Stdout = settings.Output
-				cmd.Stderr = settings.Output
+	switch cfg.VCSBackend {
+	case "git", "gogit":
+		return cfg.GitRemoteEnable
+	case "jj":
+		return cfg.JJRemoteEnable
+	default:
+		return false
+	}
+}
 
-				if err := cmd.Run(); err != nil {
-					return err
-				}
-			}
+// cloneRemote clones the configured remote into storageDir, which must
+// be missing or empty, and checks that the clone is a yatto storage
+// directory. If it is not, the clone is removed again.
+func cloneRemote(settings Settings, storageDir string) error {
+	_, _ = fmt.Fprintf(settings.Output, "Cloning remote repository into %s\n", storageDir)
 
-			// Rename branch if it's not our default.
-			if backend == "git" {
-				moveCmd := exec.Command("git", // #nosec G204 Command uses validated config value
-					"branch",
-					"--move", settings.Viper.GetString("git.default_branch"),
-				)
-				moveCmd.Dir = storageDir
-				if err := moveCmd.Run(); err != nil {
-					return err
-				}
-			}
-		} else {
-			if err := os.MkdirAll(storageDir, 0o700); err != nil {
-				return fmt.Errorf("fatal error creating storage directory: %w", err)
+	var err error
+	switch config.Get(settings.Viper).VCSBackend {
+	case "gogit":
+		err = gogitClone(settings, storageDir)
+	case "git":
+		err = gitClone(settings, storageDir)
+	case "jj":
+		err = jjClone(settings, storageDir)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := verifyClone(storageDir); err != nil {
+		if removeErr := removeContents(storageDir); removeErr != nil {
+			return errors.Join(err, removeErr)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// gitClone clones the configured git remote into storageDir and renames
+// the checked out branch to the configured default branch.
+func gitClone(settings Settings, storageDir string) error {
+	cfg := config.Get(settings.Viper)
+
+	cmd := exec.Command("git", // #nosec G204 Command uses validated config values
+		"clone",
+		"--origin", cfg.GitRemoteName,
+		cfg.GitRemoteURL,
+		storageDir,
+	)
+	cmd.Stdout = settings.Output
+	cmd.Stderr = settings.Output
+
+	if err := cmd.Run(); err != nil {
+		return err
+	}
+
+	// Rename branch if it's not our default.
+	moveCmd := exec.Command("git", // #nosec G204 Command uses validated config value
+		"branch",
+		"--move", cfg.GitDefaultBranch,
+	)
+	moveCmd.Dir = storageDir
+
+	return moveCmd.Run()
+}
+
+// jjClone clones the configured jj remote into storageDir.
+func jjClone(settings Settings, storageDir string) error {
+	cfg := config.Get(settings.Viper)
+
+	args := []string{
+		"git",
+		"clone",
+		"--remote",
+		cfg.JJRemoteName,
+		cfg.JJRemoteURL,
+		storageDir,
+	}
+
+	if cfg.JJRemoteColocate {
+		args = append(args, "--colocate")
+	}
+
+	cmd := exec.Command("jj", args...) // #nosec G204 Command uses validated config values
+	cmd.Stdout = settings.Output
+	cmd.Stderr = settings.Output
+
+	return cmd.Run()
+}
+
+// verifyClone returns ErrNotStorage if the cloned storageDir holds files
+// but neither the INIT marker nor a project directory. An empty clone is
+// fine, it is initialized like a new storage directory.
+func verifyClone(storageDir string) error {
+	entries, err := os.ReadDir(storageDir)
+	if err != nil {
+		return err
+	}
+
+	var files int
+	for _, entry := range entries {
+		switch {
+		case entry.Name() == ".git" || entry.Name() == ".jj":
+			continue
+		case entry.Name() == "INIT":
+			return nil
+		case entry.IsDir():
+			if _, err := os.Stat(filepath.Join(storageDir, entry.Name(), "project.json")); err == nil {
+				return nil
 			}
 		}
+		files++
+	}
+
+	if files == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("%w: no INIT file or project directories in the clone", ErrNotStorage)
+}
+
+// removeContents removes everything inside dir but keeps dir itself.
+func removeContents(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		if err := os.RemoveAll(filepath.Join(dir, entry.Name())); err != nil {
+			return err
+		}
 	}
 
 	return nil
 }
 
+// gogitClone clones the configured git remote into storageDir using the
+// built-in go-git library and checks out the configured default branch.
+// If the remote is still empty, a new repository pointing to it is
+// initialized instead.
+func gogitClone(settings Settings, storageDir string) error {
+	cfg := config.Get(settings.Viper)
+
+	_, err := git.PlainClone(storageDir, false, &git.CloneOptions{
+		URL:           cfg.GitRemoteURL,
+		RemoteName:    cfg.GitRemoteName,
+		ReferenceName: plumbing.NewBranchReferenceName(cfg.GitDefaultBranch),
+		SingleBranch:  true,
+		Progress:      settings.Output,
+	})
+	if err == nil {
+		return nil
+	}
+
+	if !errors.Is(err, transport.ErrEmptyRemoteRepository) {
+		return err
+	}
+
+	repo, err := git.PlainInitWithOptions(storageDir, &git.PlainInitOptions{
+		InitOptions: git.InitOptions{
+			DefaultBranch: plumbing.NewBranchReferenceName(cfg.GitDefaultBranch),
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.CreateRemote(&gitconfig.RemoteConfig{
+		Name: cfg.GitRemoteName,
+		URLs: []string{cfg.GitRemoteURL},
+	})
+
+	return err
+}
+
 // FileExists returns true if the specified file exists within the configured
-// storage directory. It uses os.Stat to check for existence and ignores other errors.
+// storage directory. It uses os.Root.Stat to check for existence and ignores other errors.
 func FileExists(v *viper.Viper, file string) bool {
-	fullPath := filepath.Join(v.GetString("storage.path"), file)
-	_, err := os.Stat(fullPath)
-	return !os.IsNotExist(err)
+	root, err := os.OpenRoot(config.Get(v).StoragePath)
+	if err != nil {
+		return false
+	}
+	defer root.Close() //nolint:errcheck
+
+	_, err = root.Stat(file)
+	return !errors.Is(err, fs.ErrNotExist)
 }
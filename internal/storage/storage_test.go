@@ -21,12 +21,19 @@
 package storage
 
 import (
+	"context"
+	"io"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"runtime"
+	"strconv"
 	"testing"
+	"time"
 
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestFileExists(t *testing.T) {
@@ -36,13 +43,436 @@ func TestFileExists(t *testing.T) {
 
 	t.Run("returns true when file exists", func(t *testing.T) {
 		filePath := filepath.Join(tempDir, "exists.txt")
-		_, err := os.Create(filePath) //nolint:gosec
+		f, err := os.Create(filePath) //nolint:gosec
 		assert.NoError(t, err)
+		assert.NoError(t, f.Close())
 
 		assert.True(t, FileExists(v, "exists.txt"))
 	})
 
+	t.Run("accepts slash separated paths", func(t *testing.T) {
+		assert.NoError(t, os.MkdirAll(filepath.Join(tempDir, "project", "archive"), 0o700))
+		assert.True(t, FileExists(v, "project/archive"))
+		assert.True(t, FileExists(v, filepath.Join("project", "archive")))
+	})
+
 	t.Run("returns false when file does not exist", func(t *testing.T) {
 		assert.False(t, FileExists(v, "nonexistent.txt"))
 	})
 }
+
+func TestCreateStorageDirConfirmed(t *testing.T) {
+	storageDir := filepath.Join(t.TempDir(), "tasks")
+	v := viper.New()
+	v.Set("storage.path", storageDir)
+	v.Set("vcs.backend", "none")
+
+	assert.NoError(t, CreateStorageDir(Settings{Viper: v, Confirmed: true}))
+
+	info, err := os.Stat(storageDir)
+	assert.NoError(t, err)
+	assert.True(t, info.IsDir())
+}
+
+// remoteRepo creates a repository to clone from, holding a commit of
+// the given file unless it is empty.
+func remoteRepo(t *testing.T, file string) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	git := func(args ...string) {
+		cmd := exec.Command("git", append([]string{
+			"-c", "user.name=Test User", "-c", "user.email=test@example.com", "-c", "commit.gpgSign=false",
+		}, args...)...)
+		cmd.Dir = dir
+		output, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(output))
+	}
+
+	git("init", "--initial-branch", "main")
+	if file != "" {
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, filepath.Dir(file)), 0o700))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, file), []byte("{}"), 0o600))
+		git("add", ".")
+		git("commit", "-m", "Initial commit")
+	}
+
+	return dir
+}
+
+func TestCreateStorageDirClone(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+
+	tests := []struct {
+		name    string
+		file    string
+		wantErr error
+		want    string
+	}{
+		{name: "yatto storage", file: "INIT", want: "INIT"},
+		{name: "project directories", file: "0c7a3b52-6f1e-4a8d-9b3e-2d5f8c1a4e67/project.json", want: "0c7a3b52-6f1e-4a8d-9b3e-2d5f8c1a4e67"},
+		{name: "empty remote", want: ".git"},
+		{name: "other repository", file: "README.md", wantErr: ErrNotStorage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storageDir := t.TempDir()
+			v := viper.New()
+			v.Set("storage.path", storageDir)
+			v.Set("vcs.backend", "git")
+			v.Set("git.remote.enable", true)
+			v.Set("git.remote.name", "origin")
+			v.Set("git.remote.url", remoteRepo(t, tt.file))
+			v.Set("git.default_branch", "main")
+
+			// The storage directory exists but is empty.
+			err := CreateStorageDir(Settings{Viper: v, Output: io.Discard})
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+
+				entries, err := os.ReadDir(storageDir)
+				require.NoError(t, err)
+				assert.Empty(t, entries, "the clone is removed")
+				return
+			}
+
+			require.NoError(t, err)
+			assert.FileExists(t, filepath.Join(storageDir, ".git", "HEAD"))
+			_, err = os.Stat(filepath.Join(storageDir, tt.want))
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestProjectSort(t *testing.T) {
+	tempDir := t.TempDir()
+	v := viper.New()
+	v.Set("storage.path", tempDir)
+
+	t.Run("returns empty mode without state file", func(t *testing.T) {
+		assert.Equal(t, "", ProjectSort(v, "project"))
+	})
+
+	t.Run("remembers modes per project", func(t *testing.T) {
+		assert.NoError(t, SetProjectSort(v, "project", "priority"))
+		assert.NoError(t, SetProjectSort(v, "other", "manual"))
+
+		assert.Equal(t, "priority", ProjectSort(v, "project"))
+		assert.Equal(t, "manual", ProjectSort(v, "other"))
+		assert.FileExists(t, filepath.Join(tempDir, StateFile))
+	})
+
+	t.Run("forgets empty modes", func(t *testing.T) {
+		assert.NoError(t, SetProjectSort(v, "project", ""))
+
+		state, err := ReadState(v)
+		assert.NoError(t, err)
+		assert.Equal(t, map[string]string{"other": "manual"}, state.ProjectSorts)
+	})
+
+	t.Run("ignores invalid state file", func(t *testing.T) {
+		assert.NoError(t, os.WriteFile(filepath.Join(tempDir, StateFile), []byte("{"), 0o600))
+
+		assert.Equal(t, "", ProjectSort(v, "other"))
+		assert.Error(t, SetProjectSort(v, "other", "manual"))
+	})
+}
+
+func TestUnpushedCommits(t *testing.T) {
+	tempDir := t.TempDir()
+	v := viper.New()
+	v.Set("storage.path", tempDir)
+
+	assert.Equal(t, 0, UnpushedCommits(v))
+	assert.NoError(t, ClearUnpushedCommits(v))
+	assert.NoFileExists(t, filepath.Join(tempDir, StateFile))
+
+	assert.NoError(t, SetProjectSort(v, "project", "priority"))
+
+	n, err := AddUnpushedCommit(v)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, n)
+
+	n, err = AddUnpushedCommit(v)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, 2, UnpushedCommits(v))
+
+	assert.NoError(t, ClearUnpushedCommits(v))
+	assert.Equal(t, 0, UnpushedCommits(v))
+
+	// Other state is kept.
+	assert.Equal(t, "priority", ProjectSort(v, "project"))
+}
+
+func TestLastSync(t *testing.T) {
+	v := viper.New()
+	v.Set("storage.path", t.TempDir())
+
+	assert.True(t, LastSync(v).IsZero())
+
+	assert.NoError(t, SetProjectSort(v, "project", "priority"))
+
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	assert.NoError(t, SetLastSync(v, now))
+	assert.True(t, now.Equal(LastSync(v)))
+
+	// Other state is kept.
+	assert.Equal(t, "priority", ProjectSort(v, "project"))
+}
+
+func TestAssignees(t *testing.T) {
+	v := viper.New()
+	v.Set("storage.path", t.TempDir())
+
+	assert.Empty(t, Assignees(v))
+
+	assert.NoError(t, AddAssignee(v, "Alice alice@example.com"))
+	assert.NoError(t, AddAssignee(v, "Bob bob@example.com"))
+	assert.NoError(t, AddAssignee(v, "Alice alice@example.com"))
+	assert.Equal(t, []string{"Alice alice@example.com", "Bob bob@example.com"}, Assignees(v))
+
+	for i := range maxAssignees {
+		assert.NoError(t, AddAssignee(v, "user"+strconv.Itoa(i)+"@example.com"))
+	}
+	assert.Len(t, Assignees(v), maxAssignees)
+	assert.NotContains(t, Assignees(v), "Bob bob@example.com")
+}
+
+func TestSeenTasks(t *testing.T) {
+	v := viper.New()
+	v.Set("storage.path", t.TempDir())
+
+	before := time.Now()
+	seen, since := SeenTasks(v)
+	assert.Empty(t, seen)
+	assert.False(t, since.Before(before))
+
+	// The start of the tracking is kept.
+	_, again := SeenTasks(v)
+	assert.True(t, since.Equal(again))
+
+	changed := time.Now().Add(time.Hour)
+	assert.NoError(t, MarkSeen(v, "task", changed))
+	assert.NoError(t, MarkSeen(v, "task", changed.Add(-time.Minute)))
+	seen, _ = SeenTasks(v)
+	assert.True(t, seen["task"].Equal(changed))
+}
+
+func TestDayStats(t *testing.T) {
+	v := viper.New()
+	v.Set("storage.path", t.TempDir())
+
+	before := time.Now()
+	stats, since, err := ProjectDayStats(v, "project")
+	require.NoError(t, err)
+	assert.Empty(t, stats)
+	assert.False(t, since.Before(before))
+
+	day := time.Date(2026, 3, 14, 12, 0, 0, 0, time.Local)
+	require.NoError(t, CountTasks(v, "project", day, DayStats{Created: 1}))
+	require.NoError(t, CountTasks(v, "project", day, DayStats{Created: 1, Completed: 1}))
+	require.NoError(t, CountTasks(v, "project", day.AddDate(0, 0, 1), DayStats{Completed: 1}))
+	require.NoError(t, CountTasks(v, "other", day, DayStats{Created: 1}))
+
+	stats, again, err := ProjectDayStats(v, "project")
+	require.NoError(t, err)
+	assert.True(t, since.Equal(again))
+	assert.Equal(t, map[string]DayStats{
+		"2026-03-14": {Created: 2, Completed: 1},
+		"2026-03-15": {Completed: 1},
+	}, stats)
+}
+
+func TestCrash(t *testing.T) {
+	tempDir := t.TempDir()
+	v := viper.New()
+	v.Set("storage.path", tempDir)
+
+	session, err := ReadCrash(v)
+	assert.NoError(t, err)
+	assert.Nil(t, session)
+	assert.NoError(t, RemoveCrash(v))
+
+	saved := Session{
+		Time:      time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC),
+		Panic:     "boom",
+		ProjectID: "project",
+		TaskID:    "task",
+		Form:      "task",
+		Edit:      true,
+		Input:     map[string]string{"description": "Half-written"},
+	}
+	require.NoError(t, WriteCrash(v, saved))
+
+	session, err = ReadCrash(v)
+	require.NoError(t, err)
+	assert.Equal(t, saved, *session)
+
+	assert.NoError(t, RemoveCrash(v))
+	assert.NoFileExists(t, filepath.Join(tempDir, CrashFile))
+
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, CrashFile), []byte("{"), 0o600))
+	_, err = ReadCrash(v)
+	assert.ErrorContains(t, err, "invalid crash file")
+}
+
+func TestWriteIgnoreFile(t *testing.T) {
+	tempDir := t.TempDir()
+	v := viper.New()
+	v.Set("storage.path", tempDir)
+
+	require.NoError(t, os.WriteFile(filepath.Join(tempDir, IgnoreFile), []byte("notes.txt"), 0o600))
+
+	changed, err := WriteIgnoreFile(v)
+	require.NoError(t, err)
+	assert.True(t, changed)
+
+	data, err := os.ReadFile(filepath.Join(tempDir, IgnoreFile))
+	require.NoError(t, err)
+	assert.Equal(t, "notes.txt\n/.yatto-state.json\n/.yatto-index.json\n/.yatto-crash.json\n.*.tmp-*\n", string(data))
+
+	changed, err = WriteIgnoreFile(v)
+	require.NoError(t, err)
+	assert.False(t, changed)
+}
+
+func TestAtomicWrite(t *testing.T) {
+	tempDir := t.TempDir()
+	assert.NoError(t, os.Mkdir(filepath.Join(tempDir, "project"), 0o700))
+
+	root, err := os.OpenRoot(tempDir)
+	assert.NoError(t, err)
+	defer root.Close() //nolint:errcheck
+
+	t.Run("creates and replaces files", func(t *testing.T) {
+		name := filepath.Join("project", "task.json")
+
+		assert.NoError(t, AtomicWrite(root, name, []byte("old"), 0o600))
+		assert.NoError(t, AtomicWrite(root, name, []byte("new"), 0o600))
+
+		data, err := os.ReadFile(filepath.Join(tempDir, name))
+		assert.NoError(t, err)
+		assert.Equal(t, "new", string(data))
+
+		// Windows only knows read-only and writable files.
+		if runtime.GOOS != "windows" {
+			info, err := os.Stat(filepath.Join(tempDir, name))
+			assert.NoError(t, err)
+			assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+		}
+	})
+
+	t.Run("leaves no temporary files", func(t *testing.T) {
+		entries, err := os.ReadDir(filepath.Join(tempDir, "project"))
+		assert.NoError(t, err)
+		assert.Len(t, entries, 1)
+	})
+
+	t.Run("fails outside of root", func(t *testing.T) {
+		assert.Error(t, AtomicWrite(root, filepath.Join("..", "escape.json"), []byte("x"), 0o600))
+	})
+
+	t.Run("fails for missing directories", func(t *testing.T) {
+		assert.Error(t, AtomicWrite(root, filepath.Join("missing", "task.json"), []byte("x"), 0o600))
+	})
+}
+
+func TestScanFiles(t *testing.T) {
+	tempDir := t.TempDir()
+
+	root, err := os.OpenRoot(tempDir)
+	assert.NoError(t, err)
+	defer root.Close() //nolint:errcheck
+
+	var paths []string
+	for i := range 50 {
+		name := strconv.Itoa(i)
+		assert.NoError(t, os.WriteFile(filepath.Join(tempDir, name), []byte(name), 0o600))
+		paths = append(paths, name)
+	}
+	paths = append(paths, "missing", "-1")
+	assert.NoError(t, os.WriteFile(filepath.Join(tempDir, "-1"), []byte("x"), 0o600))
+
+	results := ScanFiles(root, paths, func(data []byte) (int, error) {
+		return strconv.Atoi(string(data))
+	})
+
+	assert.Len(t, results, len(paths))
+	for i, r := range results[:50] {
+		assert.Equal(t, paths[i], r.Path)
+		assert.NoError(t, r.Err)
+		assert.Equal(t, i, r.Value)
+	}
+	assert.ErrorIs(t, results[50].Err, os.ErrNotExist)
+	assert.Error(t, results[51].Err)
+
+	assert.Empty(t, ScanFiles(root, nil, func([]byte) (int, error) { return 0, nil }))
+}
+
+func TestWatch(t *testing.T) {
+	tempDir := t.TempDir()
+	v := viper.New()
+	v.Set("storage.path", tempDir)
+
+	assert.NoError(t, os.MkdirAll(filepath.Join(tempDir, "project", "archive"), 0o700))
+	assert.NoError(t, os.Mkdir(filepath.Join(tempDir, ".git"), 0o700))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	msgs := make(chan StorageChangedMsg, 10)
+	done := make(chan error)
+	go func() {
+		done <- Watch(ctx, v, func(msg StorageChangedMsg) { msgs <- msg })
+	}()
+
+	// Give the watcher time to register its watches.
+	time.Sleep(100 * time.Millisecond)
+
+	receive := func(t *testing.T) StorageChangedMsg {
+		t.Helper()
+
+		select {
+		case msg := <-msgs:
+			return msg
+		case <-time.After(5 * time.Second):
+			t.Fatal("no StorageChangedMsg received")
+			return StorageChangedMsg{}
+		}
+	}
+
+	t.Run("reports changed projects", func(t *testing.T) {
+		assert.NoError(t, os.WriteFile(filepath.Join(tempDir, "project", "task.json"), []byte("{}"), 0o600))
+		assert.NoError(t, os.WriteFile(filepath.Join(tempDir, "project", "archive", "old.json"), []byte("{}"), 0o600))
+
+		assert.Equal(t, []string{"project"}, receive(t).Projects)
+	})
+
+	t.Run("watches new projects", func(t *testing.T) {
+		assert.NoError(t, os.Mkdir(filepath.Join(tempDir, "new"), 0o700))
+		assert.Equal(t, []string{"new"}, receive(t).Projects)
+
+		assert.NoError(t, os.WriteFile(filepath.Join(tempDir, "new", "project.json"), []byte("{}"), 0o600))
+		assert.Equal(t, []string{"new"}, receive(t).Projects)
+	})
+
+	t.Run("ignores repository, state and temporary files", func(t *testing.T) {
+		assert.NoError(t, os.WriteFile(filepath.Join(tempDir, ".git", "index"), []byte("x"), 0o600))
+		assert.NoError(t, os.WriteFile(filepath.Join(tempDir, StateFile), []byte("{}"), 0o600))
+		assert.NoError(t, os.WriteFile(filepath.Join(tempDir, "project", ".task.json.tmp-X"), []byte("{}"), 0o600))
+		assert.NoError(t, os.WriteFile(filepath.Join(tempDir, "INIT"), []byte(""), 0o600))
+
+		select {
+		case msg := <-msgs:
+			t.Errorf("unexpected StorageChangedMsg: %v", msg)
+		case <-time.After(4 * watchDebounce):
+		}
+	})
+
+	cancel()
+	assert.NoError(t, <-done)
+}
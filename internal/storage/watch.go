@@ -0,0 +1,155 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package storage
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/handlebargh/yatto/internal/config"
+	"github.com/spf13/viper"
+)
+
+// watchDebounce is the time Watch waits for further changes before
+// reporting them, so that a pull touching many files is reported once.
+const watchDebounce = 250 * time.Millisecond
+
+// StorageChangedMsg reports that files in the storage directory changed,
+// e.g. because another yatto instance wrote a task or a pull brought in
+// changes from another machine.
+type StorageChangedMsg struct {
+	// Projects holds the IDs of all projects whose directory, or any file
+	// in it, was created, changed or removed.
+	Projects []string
+}
+
+// Watch watches the storage directory and all project directories for
+// changes and calls send with a StorageChangedMsg for every batch of changes.
+// Changes to the repository directories, the state file and temporary
+// files are ignored. Watch blocks until ctx is canceled.
+func Watch(ctx context.Context, v *viper.Viper, send func(StorageChangedMsg)) error {
+	storagePath := config.Get(v).StoragePath
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer watcher.Close() //nolint:errcheck
+
+	if err := watcher.Add(storagePath); err != nil {
+		return err
+	}
+
+	entries, err := os.ReadDir(storagePath)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		if entry.IsDir() && !ignoredByWatch(entry.Name()) {
+			watchProjectDir(watcher, filepath.Join(storagePath, entry.Name()))
+		}
+	}
+
+	changed := make(map[string]bool)
+	timer := time.NewTimer(watchDebounce)
+	timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+
+			rel, err := filepath.Rel(storagePath, event.Name)
+			if err != nil || rel == "." {
+				continue
+			}
+
+			parts := strings.Split(rel, string(filepath.Separator))
+			if slices.ContainsFunc(parts, ignoredByWatch) {
+				continue
+			}
+
+			info, err := os.Stat(event.Name)
+			if err == nil && info.IsDir() && event.Has(fsnotify.Create) {
+				watchProjectDir(watcher, event.Name)
+			}
+
+			// Skip files directly in the storage directory,
+			// they don't belong to any project.
+			if len(parts) == 1 && err == nil && !info.IsDir() {
+				continue
+			}
+
+			changed[parts[0]] = true
+			timer.Reset(watchDebounce)
+
+		case _, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+
+		case <-timer.C:
+			projects := make([]string, 0, len(changed))
+			for id := range changed {
+				projects = append(projects, id)
+			}
+			slices.Sort(projects)
+			clear(changed)
+
+			send(StorageChangedMsg{Projects: projects})
+		}
+	}
+}
+
+// watchProjectDir adds dir and all directories below it to the watcher.
+// Directories that cannot be watched are skipped.
+func watchProjectDir(watcher *fsnotify.Watcher, dir string) {
+	_ = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil //nolint:nilerr
+		}
+
+		if d.IsDir() {
+			_ = watcher.Add(path)
+		}
+
+		return nil
+	})
+}
+
+// ignoredByWatch reports whether changes to a file or directory
+// of the given name are not reported by Watch.
+func ignoredByWatch(name string) bool {
+	return name == ".git" || name == ".jj" || name == StateFile || name == IndexFile ||
+		name == CrashFile || name == TemplatesDir || IsTempFile(name)
+}
@@ -0,0 +1,230 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package github implements a one-way sync of GitHub issues into yatto tasks.
+// Open issues are imported as tasks, and the issues of completed tasks can
+// be closed upstream. Nothing else is ever changed on GitHub.
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/handlebargh/yatto/internal/items"
+)
+
+// Source is the value of items.External.Source for tasks imported from GitHub.
+const Source = "github"
+
+// perPage is the number of issues requested per page.
+const perPage = 100
+
+// repoRegexp validates repositories in the form "owner/name".
+var repoRegexp = regexp.MustCompile(`^[A-Za-z0-9_.-]+/[A-Za-z0-9_.-]+$`)
+
+// Issue is the part of a GitHub issue used by the sync.
+type Issue struct {
+	Number  int    `json:"number"`
+	Title   string `json:"title"`
+	Body    string `json:"body"`
+	HTMLURL string `json:"html_url"`
+	Labels  []struct {
+		Name string `json:"name"`
+	} `json:"labels"`
+	Assignees []struct {
+		Login string `json:"login"`
+	} `json:"assignees"`
+	PullRequest *struct{} `json:"pull_request"`
+}
+
+// Client talks to the GitHub REST API.
+type Client struct {
+	BaseURL    string
+	Token      string
+	HTTPClient *http.Client
+}
+
+// NewClient returns a client for api.github.com authenticating with token.
+func NewClient(token string) *Client {
+	return &Client{
+		BaseURL:    "https://api.github.com",
+		Token:      token,
+		HTTPClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// ValidateRepo returns an error if repo is not in the form "owner/name".
+func ValidateRepo(repo string) error {
+	if !repoRegexp.MatchString(repo) {
+		return fmt.Errorf("invalid github repository %q (expected owner/name)", repo)
+	}
+
+	return nil
+}
+
+// OpenIssues returns all open issues of the repository.
+// Pull requests, which GitHub lists as issues too, are left out.
+func (c *Client) OpenIssues(ctx context.Context, repo string) ([]Issue, error) {
+	var issues []Issue
+
+	for page := 1; ; page++ {
+		path := fmt.Sprintf("/repos/%s/issues?state=open&per_page=%d&page=%d", repo, perPage, page)
+
+		var batch []Issue
+		if err := c.do(ctx, http.MethodGet, path, nil, &batch); err != nil {
+			return nil, err
+		}
+
+		for _, issue := range batch {
+			if issue.PullRequest == nil {
+				issues = append(issues, issue)
+			}
+		}
+
+		if len(batch) < perPage {
+			return issues, nil
+		}
+	}
+}
+
+// CloseIssue closes the issue with the given number.
+func (c *Client) CloseIssue(ctx context.Context, repo string, number int) error {
+	return c.do(ctx, http.MethodPatch, fmt.Sprintf("/repos/%s/issues/%d", repo, number),
+		map[string]string{"state": "closed"}, nil)
+}
+
+// do sends a request to the API and decodes the JSON response into out, if not nil.
+func (c *Client) do(ctx context.Context, method, path string, body, out any) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.BaseURL+path, reader)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	if c.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close() //nolint:errcheck
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		var apiErr struct {
+			Message string `json:"message"`
+		}
+		_ = json.NewDecoder(resp.Body).Decode(&apiErr)
+
+		return fmt.Errorf("github: %s %s: %s %s", method, path, resp.Status, apiErr.Message)
+	}
+
+	if out == nil {
+		return nil
+	}
+
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// IssueID returns the items.External ID of an issue, e.g. "owner/repo#12".
+func IssueID(repo string, number int) string {
+	return repo + "#" + strconv.Itoa(number)
+}
+
+// ToTask converts an issue into a new open task linked to the issue.
+// Labels are mapped to labels and the first assignee to the assignee.
+func (i Issue) ToTask(repo string) *items.Task {
+	task := &items.Task{
+		ID:          uuid.NewString(),
+		Title:       i.Title,
+		Description: i.Body,
+		Priority:    "low",
+		External: &items.External{
+			Source: Source,
+			ID:     IssueID(repo, i.Number),
+			URL:    i.HTMLURL,
+		},
+	}
+
+	for _, label := range i.Labels {
+		task.Labels = append(task.Labels, label.Name)
+	}
+
+	if len(i.Assignees) > 0 {
+		task.Assignee = i.Assignees[0].Login
+	}
+
+	return task
+}
+
+// Plan is the result of comparing the open issues of a repository
+// with the tasks of a project.
+type Plan struct {
+	// New holds tasks for issues that were not imported yet.
+	New []*items.Task
+	// Close holds the numbers of open issues whose tasks are completed.
+	Close []int
+}
+
+// PlanSync compares the open issues of repo with the tasks of the project.
+// Issues of completed tasks are only closed if closeCompleted is set.
+func PlanSync(repo string, issues []Issue, tasks []items.Task, closeCompleted bool) Plan {
+	imported := make(map[string]items.Task)
+	for _, task := range tasks {
+		if task.External != nil && task.External.Source == Source {
+			imported[task.External.ID] = task
+		}
+	}
+
+	var plan Plan
+	for _, issue := range issues {
+		task, ok := imported[IssueID(repo, issue.Number)]
+		switch {
+		case !ok:
+			plan.New = append(plan.New, issue.ToTask(repo))
+		case task.Completed && closeCompleted:
+			plan.Close = append(plan.Close, issue.Number)
+		}
+	}
+
+	return plan
+}
@@ -0,0 +1,122 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/handlebargh/yatto/internal/items"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client := NewClient("secret")
+	client.BaseURL = server.URL
+
+	return client
+}
+
+func TestClient_OpenIssues(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		assert.Equal(t, "/repos/owner/repo/issues", r.URL.Path)
+		assert.Equal(t, "open", r.URL.Query().Get("state"))
+
+		_, _ = w.Write([]byte(`[
+			{"number": 1, "title": "Bug", "body": "It breaks", "html_url": "https://github.com/owner/repo/issues/1",
+			 "labels": [{"name": "bug"}], "assignees": [{"login": "octocat"}]},
+			{"number": 2, "title": "Fix bug", "pull_request": {"url": "https://api.github.com/repos/owner/repo/pulls/2"}}
+		]`))
+	})
+
+	issues, err := client.OpenIssues(context.Background(), "owner/repo")
+	assert.NoError(t, err)
+	assert.Len(t, issues, 1)
+	assert.Equal(t, 1, issues[0].Number)
+	assert.Equal(t, "Bug", issues[0].Title)
+}
+
+func TestClient_OpenIssues_Error(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message": "Not Found"}`))
+	})
+
+	_, err := client.OpenIssues(context.Background(), "owner/repo")
+	assert.ErrorContains(t, err, "404 Not Found Not Found")
+}
+
+func TestClient_CloseIssue(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPatch, r.Method)
+		assert.Equal(t, "/repos/owner/repo/issues/7", r.URL.Path)
+
+		var body map[string]string
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		assert.Equal(t, "closed", body["state"])
+
+		_, _ = w.Write([]byte(`{}`))
+	})
+
+	assert.NoError(t, client.CloseIssue(context.Background(), "owner/repo", 7))
+}
+
+func TestValidateRepo(t *testing.T) {
+	assert.NoError(t, ValidateRepo("handlebargh/yatto"))
+	assert.Error(t, ValidateRepo("yatto"))
+	assert.Error(t, ValidateRepo("owner/repo/issues"))
+	assert.Error(t, ValidateRepo("owner/repo?x=1"))
+}
+
+func TestPlanSync(t *testing.T) {
+	var issues []Issue
+	assert.NoError(t, json.Unmarshal([]byte(`[
+		{"number": 1, "title": "New issue", "labels": [{"name": "bug"}, {"name": "ui"}], "assignees": [{"login": "octocat"}]},
+		{"number": 2, "title": "Imported issue"},
+		{"number": 3, "title": "Completed issue"}
+	]`), &issues))
+
+	tasks := []items.Task{
+		{ID: "a", External: &items.External{Source: Source, ID: "owner/repo#2"}},
+		{ID: "b", Completed: true, External: &items.External{Source: Source, ID: "owner/repo#3"}},
+		{ID: "c", Completed: true, External: &items.External{Source: Source, ID: "other/repo#1"}},
+	}
+
+	plan := PlanSync("owner/repo", issues, tasks, false)
+	assert.Len(t, plan.New, 1)
+	assert.Empty(t, plan.Close)
+
+	task := plan.New[0]
+	assert.Equal(t, "New issue", task.Title)
+	assert.Equal(t, []string{"bug", "ui"}, []string(task.Labels))
+	assert.Equal(t, "octocat", task.Assignee)
+	assert.Equal(t, "owner/repo#1", task.External.ID)
+
+	plan = PlanSync("owner/repo", issues, tasks, true)
+	assert.Equal(t, []int{3}, plan.Close)
+}
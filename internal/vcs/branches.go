@@ -0,0 +1,235 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package vcs
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/handlebargh/yatto/internal/config"
+	"github.com/spf13/viper"
+)
+
+// projectBranchPrefix prefixes the names of the project branches,
+// see ProjectBranches.
+const projectBranchPrefix = "project/"
+
+// branchMu serializes commits with checkouts of project branches, so
+// that no commit ends up on a branch that is being merged.
+var branchMu sync.Mutex
+
+// ProjectBranches reports whether the changes of the tasks of each project
+// are committed on a branch of its own (git.branch_per_project). The branch
+// is checked out by the first commit of the project's tasks and merged into
+// the default branch again by LeaveProjectBranchCmd or any other commit.
+// The working tree is the same on both branches, so nothing changes for
+// the reader of the storage directory. Only the git backend supports it.
+func ProjectBranches(v *viper.Viper) bool {
+	cfg := config.Get(v)
+
+	return cfg.VCSBackend == "git" && cfg.GitBranchPerProject
+}
+
+// ProjectBranch returns the name of the branch of the project with the given ID.
+func ProjectBranch(projectID string) string {
+	return projectBranchPrefix + projectID
+}
+
+// LeaveProjectBranchCmd returns a command that merges the branch of the
+// project that was committed to last into the default branch, which is
+// checked out again. The merge is pushed if a remote is enabled.
+// Returns a BranchDoneMsg or BranchErrorMsg, or the messages of a failed
+// push like CommitCmd. Returns nil if project branches are not enabled.
+func LeaveProjectBranchCmd(v *viper.Viper) tea.Cmd {
+	if !ProjectBranches(v) {
+		return nil
+	}
+
+	return func() tea.Msg {
+		branchMu.Lock()
+		merged, output, err := gitLeaveProjectBranch(v)
+		branchMu.Unlock()
+
+		if err != nil {
+			return BranchErrorMsg{string(output), err}
+		}
+
+		if merged && config.Get(v).GitRemoteEnable {
+			if msg := gitPublish(v); msg != nil {
+				return msg
+			}
+		}
+
+		return BranchDoneMsg{}
+	}
+}
+
+// gitCurrentBranch returns the name of the checked out branch.
+func gitCurrentBranch(v *viper.Viper) (string, error) {
+	output, err := gitOutput(v, "branch", "--show-current")
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", err, output)
+	}
+
+	return strings.TrimSpace(string(output)), nil
+}
+
+// gitOnProjectBranch reports whether the branch of a project is checked out.
+func gitOnProjectBranch(v *viper.Viper) bool {
+	branch, err := gitCurrentBranch(v)
+	return err == nil && strings.HasPrefix(branch, projectBranchPrefix)
+}
+
+// gitSwitchBranchFor checks out the branch the given files are committed
+// on: the branch of their project if they all belong to the tasks of the
+// same project, or else the default branch. The project branch is moved
+// to the default branch before, so that the working tree does not change.
+// Must be called with branchMu held.
+func gitSwitchBranchFor(v *viper.Viper, files []string) ([]byte, error) {
+	cfg := config.Get(v)
+
+	current, err := gitCurrentBranch(v)
+	if err != nil {
+		return nil, err
+	}
+
+	target := cfg.GitDefaultBranch
+	if id := taskProject(v, files); id != "" {
+		target = ProjectBranch(id)
+	}
+
+	if current == target {
+		return nil, nil
+	}
+
+	if strings.HasPrefix(current, projectBranchPrefix) {
+		if _, output, err := gitLeaveProjectBranch(v); err != nil {
+			return output, err
+		}
+	}
+
+	if !strings.HasPrefix(target, projectBranchPrefix) {
+		return nil, nil
+	}
+
+	// Commits that were not merged into the default branch, e.g. because
+	// the merge failed, must not be dropped by moving the branch.
+	if _, err := gitOutput(v, "rev-parse", "--verify", "--quiet", "refs/heads/"+target); err == nil {
+		if output, err := gitOutput(v, "merge-base", "--is-ancestor", target, "HEAD"); err != nil {
+			return output, fmt.Errorf("branch %s was not merged into %s", target, cfg.GitDefaultBranch)
+		}
+	}
+
+	return gitOutput(v, "switch", "--force-create", target)
+}
+
+// gitLeaveProjectBranch merges the checked out project branch into the
+// default branch and checks that out. The default branch cannot change
+// while the project branch is checked out, so the merge has the tree of
+// the project branch and is made without touching the working tree.
+// Reports whether a merge commit was made. Must be called with branchMu held.
+func gitLeaveProjectBranch(v *viper.Viper) (bool, []byte, error) {
+	current, err := gitCurrentBranch(v)
+	if err != nil || !strings.HasPrefix(current, projectBranchPrefix) {
+		return false, nil, err
+	}
+
+	defaultBranch := config.Get(v).GitDefaultBranch
+
+	// The branch holds no commits if it was only checked out.
+	if _, err := gitOutput(v, "merge-base", "--is-ancestor", current, defaultBranch); err == nil {
+		output, err := gitOutput(v, "switch", defaultBranch)
+		return false, output, err
+	}
+
+	if output, err := gitOutput(v, "merge-base", "--is-ancestor", defaultBranch, current); err != nil {
+		return false, output, fmt.Errorf("branch %s cannot be merged, %s has changed since it was checked out", current, defaultBranch)
+	}
+
+	output, err := gitOutput(v, "commit-tree", current+"^{tree}",
+		"-p", defaultBranch, "-p", current,
+		"-m", fmt.Sprintf("merge: %s", projectTitle(v, strings.TrimPrefix(current, projectBranchPrefix))))
+	if err != nil {
+		return false, output, err
+	}
+
+	if output, err := gitOutput(v, "update-ref", "refs/heads/"+defaultBranch, strings.TrimSpace(string(output))); err != nil {
+		return false, output, err
+	}
+	if output, err := gitOutput(v, "symbolic-ref", "HEAD", "refs/heads/"+defaultBranch); err != nil {
+		return false, output, err
+	}
+
+	return true, nil, nil
+}
+
+// taskProject returns the ID of the project whose tasks all files belong
+// to, or an empty string if they belong to several projects or include
+// other files, e.g. a project.json file or a file outside of a project.
+func taskProject(v *viper.Viper, files []string) string {
+	var id string
+
+	for _, file := range files {
+		parts := strings.Split(filepath.ToSlash(filepath.Clean(file)), "/")
+		if len(parts) < 2 || (len(parts) == 2 && parts[1] == "project.json") {
+			return ""
+		}
+		if id != "" && parts[0] != id {
+			return ""
+		}
+		id = parts[0]
+	}
+
+	if id == "" || strings.HasPrefix(id, ".") || !projectExists(v, id) {
+		return ""
+	}
+
+	return id
+}
+
+// projectExists reports whether the project with the given ID exists.
+func projectExists(v *viper.Viper, id string) bool {
+	_, err := os.Stat(filepath.Join(config.Get(v).StoragePath, id, "project.json"))
+	return err == nil
+}
+
+// projectTitle returns the title of the project with the given ID,
+// or the ID if the project cannot be read.
+func projectTitle(v *viper.Viper, id string) string {
+	data, err := os.ReadFile(filepath.Join(config.Get(v).StoragePath, id, "project.json")) // #nosec G304 The ID is part of a branch name yatto created
+	if err != nil {
+		return id
+	}
+
+	var project struct {
+		Title string `json:"title"`
+	}
+	if json.Unmarshal(data, &project) != nil || project.Title == "" {
+		return id
+	}
+
+	return project.Title
+}
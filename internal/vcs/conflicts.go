@@ -0,0 +1,194 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package vcs
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/handlebargh/yatto/internal/config"
+	"github.com/handlebargh/yatto/internal/storage"
+	"github.com/spf13/viper"
+)
+
+// ErrNoConflictResolution is returned by the conflict functions if the
+// vcs backend does not stop on conflicts. jj records conflicts in the
+// commits instead and go-git only fast-forwards.
+var ErrNoConflictResolution = errors.New("conflicts can only be resolved with the git backend")
+
+// ErrConflictInProgress is reported if a pull of a previous run stopped
+// because of conflicts that are not resolved yet.
+var ErrConflictInProgress = errors.New("a pull stopped because of conflicts")
+
+// Conflict is a file that was changed both locally and on the remote.
+// Path is relative to the storage path and slash separated, Title is
+// the title of the task or project as changed locally, if known.
+type Conflict struct {
+	Path  string
+	Title string
+}
+
+// ConflictAbortedMsg is returned when a stopped pull was aborted, so
+// that the storage repository is back at the state before the pull.
+type ConflictAbortedMsg struct{}
+
+// ConflictInProgress reports whether a pull stopped because of conflicts
+// that have to be resolved before the storage repository can be synced.
+func ConflictInProgress(v *viper.Viper) bool {
+	return config.Get(v).VCSBackend == "git" && gitRebaseInProgress(v)
+}
+
+// Conflicts returns the files with unresolved conflicts of a stopped pull.
+func Conflicts(v *viper.Viper) ([]Conflict, error) {
+	if config.Get(v).VCSBackend != "git" {
+		return nil, ErrNoConflictResolution
+	}
+
+	output, err := gitOutput(v, "diff", "--name-only", "--diff-filter=U", "-z")
+	if err != nil {
+		return nil, err
+	}
+
+	var conflicts []Conflict
+	for path := range strings.SplitSeq(strings.TrimRight(string(output), "\x00"), "\x00") {
+		if path == "" {
+			continue
+		}
+
+		conflict := Conflict{Path: path}
+
+		// While rebasing, "theirs" is the local commit being applied.
+		if content, err := gitOutput(v, "show", ":3:"+path); err == nil {
+			var item struct {
+				Title string `json:"title"`
+			}
+			if json.Unmarshal(content, &item) == nil {
+				conflict.Title = item.Title
+			}
+		}
+
+		conflicts = append(conflicts, conflict)
+	}
+
+	return conflicts, nil
+}
+
+// ResolveConflict resolves the conflict of the file at path by keeping
+// either the local or the remote version. A version that deleted the
+// file deletes it again.
+func ResolveConflict(v *viper.Viper, path string, keepLocal bool) error {
+	if config.Get(v).VCSBackend != "git" {
+		return ErrNoConflictResolution
+	}
+
+	// While rebasing, "ours" is the remote branch and "theirs" the
+	// local commit being applied on top of it.
+	side := "--ours"
+	if keepLocal {
+		side = "--theirs"
+	}
+
+	if _, err := gitOutput(v, "checkout", side, "--", path); err != nil {
+		if output, err := gitOutput(v, "rm", "--quiet", "--", path); err != nil {
+			return fmt.Errorf("%w\n%s", err, bytes.TrimSpace(output))
+		}
+		return nil
+	}
+
+	if output, err := gitOutput(v, "add", "--", path); err != nil {
+		return fmt.Errorf("%w\n%s", err, bytes.TrimSpace(output))
+	}
+
+	return nil
+}
+
+// ContinueConflictCmd finishes a stopped pull once all conflicts are
+// resolved and pushes the result. If the next local commit conflicts as
+// well, a PullErrorMsg is returned and ConflictInProgress stays true.
+// Otherwise, it returns like PushCmd.
+func ContinueConflictCmd(v *viper.Viper) tea.Cmd {
+	cfg := config.Get(v)
+
+	return func() tea.Msg {
+		if cfg.VCSBackend != "git" {
+			return PullErrorMsg{"", ErrNoConflictResolution}
+		}
+
+		cmd := exec.Command("git", "rebase", "--continue")
+		cmd.Dir = cfg.StoragePath
+		// Keep the messages of the local commits.
+		cmd.Env = append(os.Environ(), "GIT_EDITOR=true")
+
+		if output, err := runCombined(cmd); err != nil {
+			return PullErrorMsg{string(output), err}
+		}
+
+		if output, err := gitPush(v); err != nil {
+			return pushFailed(v, output, err)
+		}
+
+		pushed(v)
+
+		return PushDoneMsg{}
+	}
+}
+
+// AbortConflictCmd aborts a stopped pull. The local commits are kept
+// and pushed by the next sync, so they are counted as unpushed.
+func AbortConflictCmd(v *viper.Viper) tea.Cmd {
+	return func() tea.Msg {
+		if config.Get(v).VCSBackend != "git" {
+			return PullErrorMsg{"", ErrNoConflictResolution}
+		}
+
+		if output, err := gitOutput(v, "rebase", "--abort"); err != nil {
+			return PullErrorMsg{string(output), err}
+		}
+
+		// The count is informational only.
+		_, _ = storage.AddUnpushedCommit(v)
+
+		return ConflictAbortedMsg{}
+	}
+}
+
+// gitOutput runs git with the given arguments in the storage directory
+// and returns its standard output, or the combined output on failure.
+func gitOutput(v *viper.Viper, args ...string) ([]byte, error) {
+	cmd := exec.Command("git", args...) // #nosec G204 Arguments are paths reported by git
+	cmd.Dir = config.Get(v).StoragePath
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	output, err := runOutput(cmd)
+	if err != nil {
+		return append(output, stderr.Bytes()...), err
+	}
+
+	return output, nil
+}
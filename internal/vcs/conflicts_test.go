@@ -0,0 +1,134 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package vcs
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupConflict returns the config of a storage repository whose pull
+// stopped because task.json was changed both locally and on the remote.
+func setupConflict(t *testing.T) *viper.Viper {
+	t.Helper()
+
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+
+	dir := t.TempDir()
+	remote := filepath.Join(dir, "remote.git")
+	local := filepath.Join(dir, "local")
+	other := filepath.Join(dir, "other")
+
+	git := func(dir string, args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{
+			"-c", "user.name=Test User", "-c", "user.email=test@example.com", "-c", "commit.gpgSign=false",
+		}, args...)...)
+		cmd.Dir = dir
+		output, err := cmd.CombinedOutput()
+		require.NoError(t, err, string(output))
+	}
+	write := func(dir, title string) {
+		t.Helper()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "task.json"), []byte(`{"title": "`+title+`"}`), 0o600))
+	}
+
+	git(dir, "init", "--quiet", "--bare", "--initial-branch", "main", remote)
+	git(dir, "clone", "--quiet", remote, local)
+	write(local, "Initial")
+	git(local, "add", "task.json")
+	git(local, "commit", "--quiet", "-m", "create")
+	git(local, "push", "--quiet", "origin", "HEAD:main")
+
+	git(dir, "clone", "--quiet", remote, other)
+	write(other, "Remote")
+	git(other, "commit", "--quiet", "-am", "remote change")
+	git(other, "push", "--quiet")
+
+	git(local, "config", "user.name", "Test User")
+	git(local, "config", "user.email", "test@example.com")
+	git(local, "config", "commit.gpgSign", "false")
+	write(local, "Local")
+	git(local, "commit", "--quiet", "-am", "local change")
+
+	v := viper.New()
+	v.Set("storage.path", local)
+	v.Set("vcs.backend", "git")
+	v.Set("git.remote.enable", true)
+	v.Set("git.remote.name", "origin")
+	v.Set("git.default_branch", "main")
+
+	_, err := gitPull(v)
+	require.Error(t, err)
+
+	return v
+}
+
+func TestResolveConflict(t *testing.T) {
+	for _, keepLocal := range []bool{true, false} {
+		v := setupConflict(t)
+		require.True(t, ConflictInProgress(v))
+
+		conflicts, err := Conflicts(v)
+		require.NoError(t, err)
+		assert.Equal(t, []Conflict{{Path: "task.json", Title: "Local"}}, conflicts)
+
+		require.NoError(t, ResolveConflict(v, "task.json", keepLocal))
+
+		conflicts, err = Conflicts(v)
+		require.NoError(t, err)
+		assert.Empty(t, conflicts)
+
+		assert.IsType(t, PushDoneMsg{}, ContinueConflictCmd(v)())
+		assert.False(t, ConflictInProgress(v))
+
+		content, err := os.ReadFile(filepath.Join(v.GetString("storage.path"), "task.json"))
+		require.NoError(t, err)
+		want := "Remote"
+		if keepLocal {
+			want = "Local"
+		}
+		assert.Contains(t, string(content), want)
+	}
+}
+
+func TestAbortConflict(t *testing.T) {
+	v := setupConflict(t)
+
+	assert.IsType(t, ConflictAbortedMsg{}, AbortConflictCmd(v)())
+	assert.False(t, ConflictInProgress(v))
+
+	content, err := os.ReadFile(filepath.Join(v.GetString("storage.path"), "task.json"))
+	require.NoError(t, err)
+	assert.Contains(t, string(content), "Local")
+
+	v.Set("vcs.backend", "jj")
+	_, err = Conflicts(v)
+	assert.ErrorIs(t, err, ErrNoConflictResolution)
+}
@@ -28,6 +28,19 @@ var ErrorNoInit = errors.New(
 	"trying to pull but local repo is not initialized.\nPlease disable remote and try again",
 )
 
+// ErrorNothingToRevert is returned when undo is requested but the
+// repository holds no commit besides the initial one.
+var ErrorNothingToRevert = errors.New("nothing to undo")
+
+// ErrDiverged is returned by the gogit backend if both the local and
+// the remote branch have new commits. Unlike git and jj, it cannot
+// combine them, so they have to be rebased with git by hand.
+var ErrDiverged = errors.New("local and remote history have diverged")
+
+// initialCommitMessage is the message of the commit created on repository
+// initialization. This commit is never reverted.
+const initialCommitMessage = "Initial commit"
+
 type (
 	// InitDoneMsg is returned when repo initialization completes successfully.
 	InitDoneMsg struct{}
@@ -41,6 +54,10 @@ type (
 	// CommitDoneMsg is returned when a commit completes successfully.
 	CommitDoneMsg struct{}
 
+	// CommitQueuedMsg is returned by a queued commit that was combined
+	// with a later one, see QueueCommitCmd.
+	CommitQueuedMsg struct{}
+
 	// CommitErrorMsg is returned when a commit fails.
 	CommitErrorMsg struct {
 		CmdOutput string
@@ -60,11 +77,97 @@ type (
 	// because the repository's INIT file is missing.
 	PullNoInitMsg struct{}
 
+	// PushDoneMsg is returned when a push operation completes successfully.
+	PushDoneMsg struct{}
+
+	// PushQueuedMsg is returned when a commit was made but could not be
+	// pushed, e.g. because the remote is unreachable. Unpushed holds the
+	// number of commits waiting to be pushed by PushCmd.
+	PushQueuedMsg struct {
+		Unpushed  int
+		CmdOutput string
+		Err       error
+	}
+
 	// PushErrorMsg is returned when a push operation fails.
 	PushErrorMsg struct {
 		CmdOutput string
 		Err       error
 	}
+
+	// BranchDoneMsg is returned when a project branch was merged into
+	// the default branch, see LeaveProjectBranchCmd.
+	BranchDoneMsg struct{}
+
+	// BranchErrorMsg is returned when merging a project branch fails.
+	BranchErrorMsg struct {
+		CmdOutput string
+		Err       error
+	}
+
+	// RevertDoneMsg is returned when the most recent commit was reverted successfully.
+	// Subject holds the first line of the reverted commit's message.
+	RevertDoneMsg struct {
+		Subject string
+	}
+
+	// RevertErrorMsg is returned when reverting the most recent commit fails.
+	RevertErrorMsg struct {
+		CmdOutput string
+		Err       error
+	}
+
+	// HistoryDoneMsg is returned when the history of a file was read successfully.
+	// Entries are ordered from newest to oldest.
+	HistoryDoneMsg struct {
+		Entries []HistoryEntry
+	}
+
+	// HistoryErrorMsg is returned when reading the history of a file fails.
+	HistoryErrorMsg struct {
+		CmdOutput string
+		Err       error
+	}
+
+	// FileAtDoneMsg is returned when the content of a file at a given
+	// commit was read successfully. File is the path it was found at.
+	FileAtDoneMsg struct {
+		Hash    string
+		File    string
+		Content []byte
+	}
+
+	// FileAtErrorMsg is returned when reading a file at a given commit fails.
+	FileAtErrorMsg struct {
+		CmdOutput string
+		Err       error
+	}
+
+	// LogDoneMsg is returned when the commit log was read successfully.
+	// Entries are ordered from newest to oldest.
+	LogDoneMsg struct {
+		Entries []LogEntry
+	}
+
+	// LogErrorMsg is returned when reading the commit log fails.
+	LogErrorMsg struct {
+		CmdOutput string
+		Err       error
+	}
+
+	// SnapshotDoneMsg is returned when the files of a commit were read successfully.
+	// Files maps the slash separated paths of all JSON files, relative to the
+	// storage path, to their content at the commit.
+	SnapshotDoneMsg struct {
+		Hash  string
+		Files map[string][]byte
+	}
+
+	// SnapshotErrorMsg is returned when reading the files of a commit fails.
+	SnapshotErrorMsg struct {
+		CmdOutput string
+		Err       error
+	}
 )
 
 // Error implements the error interface for InitErrorMsg.
@@ -78,3 +181,21 @@ func (e PullErrorMsg) Error() string { return e.Err.Error() }
 
 // Error implements the error interface for PushErrorMsg.
 func (e PushErrorMsg) Error() string { return e.Err.Error() }
+
+// Error implements the error interface for BranchErrorMsg.
+func (e BranchErrorMsg) Error() string { return e.Err.Error() }
+
+// Error implements the error interface for RevertErrorMsg.
+func (e RevertErrorMsg) Error() string { return e.Err.Error() }
+
+// Error implements the error interface for HistoryErrorMsg.
+func (e HistoryErrorMsg) Error() string { return e.Err.Error() }
+
+// Error implements the error interface for FileAtErrorMsg.
+func (e FileAtErrorMsg) Error() string { return e.Err.Error() }
+
+// Error implements the error interface for LogErrorMsg.
+func (e LogErrorMsg) Error() string { return e.Err.Error() }
+
+// Error implements the error interface for SnapshotErrorMsg.
+func (e SnapshotErrorMsg) Error() string { return e.Err.Error() }
@@ -0,0 +1,80 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package vcs
+
+import (
+	"log/slog"
+	"os/exec"
+	"time"
+
+	"github.com/handlebargh/yatto/internal/logging"
+)
+
+// runCombined runs cmd like cmd.CombinedOutput and logs it.
+func runCombined(cmd *exec.Cmd) ([]byte, error) {
+	start := time.Now()
+	output, err := cmd.CombinedOutput()
+	logCommand(cmd, start, output, err)
+	return output, err
+}
+
+// runOutput runs cmd like cmd.Output and logs it.
+func runOutput(cmd *exec.Cmd) ([]byte, error) {
+	start := time.Now()
+	out, err := cmd.Output()
+	logCommand(cmd, start, out, err)
+	return out, err
+}
+
+// runCmd runs cmd like cmd.Run and logs it.
+func runCmd(cmd *exec.Cmd) error {
+	start := time.Now()
+	err := cmd.Run()
+	logCommand(cmd, start, nil, err)
+	return err
+}
+
+// logOperation logs an operation of the gogit backend, which works
+// on the repository without running commands, like logCommand.
+func logOperation(name string, start time.Time, output []byte, err error) {
+	if err != nil {
+		logging.Warn("vcs operation failed", "op", name, "duration", logging.Since(start), "err", err, "output", string(output))
+		return
+	}
+	logging.Info("vcs operation", "op", name, "duration", logging.Since(start))
+}
+
+// logCommand logs a finished command. Failed commands are logged as
+// warnings with their output, which is only logged for successful
+// ones at the debug level, since it may contain task contents.
+func logCommand(cmd *exec.Cmd, start time.Time, output []byte, err error) {
+	attrs := []any{"args", cmd.Args, "dir", cmd.Dir, "duration", logging.Since(start)}
+
+	if err != nil {
+		logging.Warn("vcs command failed", append(attrs, "err", err, "output", string(output))...)
+		return
+	}
+
+	if logging.Enabled(slog.LevelDebug) {
+		attrs = append(attrs, "output", string(output))
+	}
+	logging.Info("vcs command", attrs...)
+}
@@ -21,12 +21,16 @@
 package vcs
 
 import (
+	"bytes"
 	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"strconv"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/handlebargh/yatto/internal/config"
 	"github.com/handlebargh/yatto/internal/helpers"
 	"github.com/handlebargh/yatto/internal/storage"
 	"github.com/spf13/viper"
@@ -34,11 +38,14 @@ import (
 
 // gitInitCmd initializes a Git repository in the configured storage path.
 // It creates a Git repo with the default branch and makes an initial commit
-// with a file named "INIT". If "INIT" already exists InitCmd terminates immediately.
+// with a file named "INIT" and the ignore file of the local files of yatto.
+// If "INIT" already exists InitCmd terminates immediately.
 // Returns a InitDoneMsg or InitErrorMsg.
 func gitInitCmd(v *viper.Viper) tea.Cmd {
+	cfg := config.Get(v)
+
 	return func() tea.Msg {
-		storagePath := v.GetString("storage.path")
+		storagePath := cfg.StoragePath
 
 		root, err := os.OpenRoot(storagePath)
 		if err != nil {
@@ -53,25 +60,29 @@ func gitInitCmd(v *viper.Viper) tea.Cmd {
 		initCmd := exec.Command("git", // #nosec G204 Command uses validated config value
 			"init",
 			"--initial-branch",
-			v.GetString("git.default_branch"),
+			cfg.GitDefaultBranch,
 		)
 		initCmd.Dir = storagePath
 
-		if output, err := initCmd.CombinedOutput(); err != nil {
+		if output, err := runCombined(initCmd); err != nil {
 			return InitErrorMsg{string(output), err}
 		}
 
+		if _, err := storage.WriteIgnoreFile(v); err != nil {
+			return InitErrorMsg{"cannot write ignore file", err}
+		}
+
 		f, err := root.Create("INIT")
 		if err != nil {
 			return InitErrorMsg{"cannot create INIT file via root", err}
 		}
 		defer helpers.CloseWithErr(f, &err)
 
-		if output, err := gitCommit(v, "Initial commit", "INIT"); err != nil {
+		if output, err := gitCommit(v, initialCommitMessage, "INIT", storage.IgnoreFile); err != nil {
 			return InitErrorMsg{string(output), err}
 		}
 
-		if v.GetBool("git.remote.enable") {
+		if cfg.GitRemoteEnable {
 			if output, err := gitPush(v); err != nil {
 				return InitErrorMsg{string(output), err}
 			}
@@ -83,20 +94,19 @@ func gitInitCmd(v *viper.Viper) tea.Cmd {
 
 // gitCommitCmd stages and commits the specified files with the given message.
 // If Git remote support is enabled, it pulls from the remote and rebases before pushing.
-// Returns a CommitDoneMsg or CommitErrorMsg.
+// If the commit cannot be pushed, it is recorded for PushCmd and a PushQueuedMsg
+// is returned. Otherwise, returns a CommitDoneMsg or an error message.
 func gitCommitCmd(v *viper.Viper, message string, files ...string) tea.Cmd {
 	return func() tea.Msg {
 		if output, err := gitCommit(v, message, files...); err != nil {
 			return CommitErrorMsg{string(output), err}
 		}
 
-		if v.GetBool("git.remote.enable") {
-			if output, err := gitPull(v); err != nil {
-				return PullErrorMsg{string(output), err}
-			}
-
-			if output, err := gitPush(v); err != nil {
-				return PushErrorMsg{string(output), err}
+		// Commits on a project branch are pushed once it is merged,
+		// see LeaveProjectBranchCmd.
+		if config.Get(v).GitRemoteEnable && !(ProjectBranches(v) && gitOnProjectBranch(v)) {
+			if msg := gitPublish(v); msg != nil {
+				return msg
 			}
 		}
 
@@ -104,6 +114,53 @@ func gitCommitCmd(v *viper.Viper, message string, files ...string) tea.Cmd {
 	}
 }
 
+// gitPublish pulls from and pushes to the remote after a commit. If the
+// commit cannot be pushed, it is recorded for PushCmd and a PushQueuedMsg
+// is returned. Returns nil if the commit was pushed.
+func gitPublish(v *viper.Viper) tea.Msg {
+	if output, err := gitPull(v); err != nil {
+		// A conflicting rebase must be resolved by hand. Otherwise,
+		// the remote is most likely unreachable, so push later.
+		if gitRebaseInProgress(v) {
+			return PullErrorMsg{string(output), err}
+		}
+		return pushFailed(v, output, err)
+	}
+
+	if output, err := gitPush(v); err != nil {
+		return pushFailed(v, output, err)
+	}
+
+	pushed(v)
+
+	return nil
+}
+
+// gitPushCmd pulls from and pushes to the configured remote.
+// Returns a PushDoneMsg, PullErrorMsg or PushErrorMsg.
+func gitPushCmd(v *viper.Viper) tea.Cmd {
+	return func() tea.Msg {
+		if output, err := gitPull(v); err != nil {
+			return PullErrorMsg{string(output), err}
+		}
+
+		if output, err := gitPush(v); err != nil {
+			return pushErrorMsg(v, output, err)
+		}
+
+		pushed(v)
+
+		return PushDoneMsg{}
+	}
+}
+
+// gitRebaseInProgress reports whether a rebase was stopped
+// in the storage repository, e.g. because of a conflict.
+func gitRebaseInProgress(v *viper.Viper) bool {
+	return storage.FileExists(v, filepath.Join(".git", "rebase-merge")) ||
+		storage.FileExists(v, filepath.Join(".git", "rebase-apply"))
+}
+
 // gitPullCmd performs a Git pull with rebase in the configured storage path.
 // Returns a PullDoneMsg or PullErrorMsg.
 func gitPullCmd(v *viper.Viper) tea.Cmd {
@@ -118,17 +175,33 @@ func gitPullCmd(v *viper.Viper) tea.Cmd {
 			return PullErrorMsg{string(output), err}
 		}
 
+		synced(v)
+
 		return PullDoneMsg{}
 	}
 }
 
 // gitPull changes the working directory to the configured storage path
-// and performs a git pull --rebase. Returns an error if any step fails.
+// and performs a git pull --rebase. A checked out project branch is merged
+// before, as only the default branch is pulled, and the merges are kept.
+// Returns an error if any step fails.
 func gitPull(v *viper.Viper) ([]byte, error) {
-	pullCmd := exec.Command("git", "pull", "--rebase")
-	pullCmd.Dir = v.GetString("storage.path")
+	rebase := "--rebase"
+	if ProjectBranches(v) {
+		branchMu.Lock()
+		_, output, err := gitLeaveProjectBranch(v)
+		branchMu.Unlock()
+		if err != nil {
+			return output, err
+		}
+
+		rebase = "--rebase=merges"
+	}
+
+	pullCmd := exec.Command("git", "pull", rebase)
+	pullCmd.Dir = config.Get(v).StoragePath
 
-	output, err := pullCmd.CombinedOutput()
+	output, err := runCombined(pullCmd)
 	if err != nil {
 		return output, err
 	}
@@ -141,7 +214,18 @@ func gitPull(v *viper.Viper) ([]byte, error) {
 // it pushes the commit to the configured remote and branch.
 // Returns an error if any Git command fails.
 func gitCommit(v *viper.Viper, message string, files ...string) ([]byte, error) {
-	storagePath := v.GetString("storage.path")
+	storagePath := config.Get(v).StoragePath
+
+	if ProjectBranches(v) {
+		branchMu.Lock()
+		defer branchMu.Unlock()
+
+		// Committing on another branch would mix up the history
+		// of the projects.
+		if output, err := gitSwitchBranchFor(v, files); err != nil {
+			return output, err
+		}
+	}
 
 	root, err := os.OpenRoot(storagePath)
 	if err != nil {
@@ -152,7 +236,7 @@ func gitCommit(v *viper.Viper, message string, files ...string) ([]byte, error)
 	args := append([]string{"add"}, files...)
 	addCmd := exec.Command("git", args...) // #nosec G204 Command uses only UUIDs as filenames
 	addCmd.Dir = storagePath
-	output, err := addCmd.CombinedOutput()
+	output, err := runCombined(addCmd)
 	if err != nil {
 		return output, err
 	}
@@ -162,7 +246,7 @@ func gitCommit(v *viper.Viper, message string, files ...string) ([]byte, error)
 		"--cached",
 	)
 	diffCmd.Dir = storagePath
-	output, _ = diffCmd.CombinedOutput()
+	output, _ = runCombined(diffCmd)
 	if len(output) == 0 {
 		return output, nil
 	}
@@ -173,7 +257,7 @@ func gitCommit(v *viper.Viper, message string, files ...string) ([]byte, error)
 		message,
 	)
 	commitCmd.Dir = storagePath
-	output, err = commitCmd.CombinedOutput()
+	output, err = runCombined(commitCmd)
 	if err != nil {
 		return output, err
 	}
@@ -183,32 +267,44 @@ func gitCommit(v *viper.Viper, message string, files ...string) ([]byte, error)
 
 // gitPush changes the current working directory to the configured storage path
 // and executes a Git push command to the specified remote and branch.
+// Afterwards, the branch is pushed to all configured mirrors, see pushAll.
 // It returns an error if changing the directory or running the Git command fails.
 func gitPush(v *viper.Viper) ([]byte, error) {
-	pushCmd := exec.Command("git", // #nosec G204 Command uses validated config values
-		"push",
-		"--set-upstream",
-		v.GetString("git.remote.name"),
-		v.GetString("git.default_branch"),
+	cfg := config.Get(v)
+
+	storagePath := cfg.StoragePath
+	remote := cfg.GitRemoteName
+	branch := cfg.GitDefaultBranch
+
+	return pushAll(v, remote,
+		func() ([]byte, error) {
+			pushCmd := exec.Command("git", // #nosec G204 Command uses validated config values
+				"push",
+				"--set-upstream",
+				remote,
+				branch,
+			)
+			pushCmd.Dir = storagePath
+
+			return runCombined(pushCmd)
+		},
+		func(m Mirror) ([]byte, error) {
+			pushCmd := exec.Command("git", "push", m.URL, branch) // #nosec G204 Command uses validated config values
+			pushCmd.Dir = storagePath
+
+			return runCombined(pushCmd)
+		},
 	)
-	pushCmd.Dir = v.GetString("storage.path")
-
-	output, err := pushCmd.CombinedOutput()
-	if err != nil {
-		return output, err
-	}
-
-	return output, nil
 }
 
 // gitUser returns the name and email address that is returned by the
 // git config command.
 func gitUser(v *viper.Viper) (string, error) {
-	storagePath := v.GetString("storage.path")
+	storagePath := config.Get(v).StoragePath
 
 	nameCmd := exec.Command("git", "config", "user.name")
 	nameCmd.Dir = storagePath
-	nameOut, err := nameCmd.CombinedOutput()
+	nameOut, err := runCombined(nameCmd)
 	if err != nil {
 		return "", err
 	}
@@ -216,7 +312,7 @@ func gitUser(v *viper.Viper) (string, error) {
 	emailCmd := exec.Command("git", "config", "user.email")
 	emailCmd.Dir = storagePath
 
-	emailOut, err := emailCmd.CombinedOutput()
+	emailOut, err := runCombined(emailCmd)
 	if err != nil {
 		return "", err
 	}
@@ -229,13 +325,181 @@ func gitUser(v *viper.Viper) (string, error) {
 	return result.String(), nil
 }
 
+// gitRevertLastCmd reverts the most recent commit with git revert.
+// If Git remote support is enabled, it pulls before reverting so that
+// the latest change is undone, and pushes the revert afterwards.
+// Returns a RevertDoneMsg or RevertErrorMsg.
+func gitRevertLastCmd(v *viper.Viper) tea.Cmd {
+	cfg := config.Get(v)
+
+	return func() tea.Msg {
+		storagePath := cfg.StoragePath
+
+		if cfg.GitRemoteEnable {
+			if output, err := gitPull(v); err != nil {
+				return PullErrorMsg{string(output), err}
+			}
+		}
+
+		// Merges of project branches are undone by reverting the
+		// last commit of the project.
+		target := "HEAD"
+		if ProjectBranches(v) {
+			if _, err := gitOutput(v, "rev-parse", "--verify", "--quiet", "HEAD^2"); err == nil {
+				target = "HEAD^2"
+			}
+		}
+
+		subjectCmd := exec.Command("git", "log", "-1", "--format=%s", target) // #nosec G204 target is a fixed revision
+		subjectCmd.Dir = storagePath
+
+		output, err := runCombined(subjectCmd)
+		if err != nil {
+			return RevertErrorMsg{string(output), err}
+		}
+		subject := strings.TrimSpace(string(output))
+
+		parentCmd := exec.Command("git", "rev-parse", "--verify", "--quiet", target+"^") // #nosec G204 target is a fixed revision
+		parentCmd.Dir = storagePath
+
+		if err := runCmd(parentCmd); err != nil || subject == initialCommitMessage {
+			return RevertErrorMsg{"", ErrorNothingToRevert}
+		}
+
+		revertCmd := exec.Command("git", "revert", "--no-edit", target) // #nosec G204 target is a fixed revision
+		revertCmd.Dir = storagePath
+
+		if output, err := runCombined(revertCmd); err != nil {
+			return RevertErrorMsg{string(output), err}
+		}
+
+		if cfg.GitRemoteEnable {
+			if output, err := gitPush(v); err != nil {
+				return pushErrorMsg(v, output, err)
+			}
+		}
+
+		return RevertDoneMsg{Subject: subject}
+	}
+}
+
+// gitHistoryCmd reads the history of the given file including its diffs,
+// following renames.
+// Returns a HistoryDoneMsg or HistoryErrorMsg.
+func gitHistoryCmd(v *viper.Viper, file string) tea.Cmd {
+	return func() tea.Msg {
+		logCmd := exec.Command("git", // #nosec G204 File path is built from UUIDs
+			"log",
+			"--follow",
+			"--patch",
+			"--format="+historyMarker+"%H%x09%aN <%aE>%x09%aI%x09%s",
+			"--",
+			file,
+		)
+		logCmd.Dir = config.Get(v).StoragePath
+
+		output, err := runCombined(logCmd)
+		if err != nil {
+			return HistoryErrorMsg{string(output), err}
+		}
+
+		return HistoryDoneMsg{Entries: parseHistory(string(output))}
+	}
+}
+
+// gitLogCmd reads the most recent commits of the storage repository
+// along with the files changed in each of them.
+func gitLogCmd(v *viper.Viper, limit int) tea.Cmd {
+	return func() tea.Msg {
+		logCmd := exec.Command("git",
+			"log",
+			"--name-only",
+			"--max-count="+strconv.Itoa(limit),
+			"--format="+historyMarker+"%H%x09%aN <%aE>%x09%aI%x09%s",
+		)
+		logCmd.Dir = config.Get(v).StoragePath
+
+		output, err := runCombined(logCmd)
+		if err != nil {
+			return LogErrorMsg{string(output), err}
+		}
+
+		return LogDoneMsg{Entries: parseLog(string(output), func(line string) string {
+			return line
+		})}
+	}
+}
+
+// gitSnapshotCmd reads the JSON files of the storage repository at the
+// given commit by listing its tree and reading all blobs in one batch.
+func gitSnapshotCmd(v *viper.Viper, hash string) tea.Cmd {
+	cfg := config.Get(v)
+
+	return func() tea.Msg {
+		lsCmd := exec.Command("git", "ls-tree", "-r", "-z", "--name-only", hash) // #nosec G204 Hash is validated by SnapshotCmd
+		lsCmd.Dir = cfg.StoragePath
+
+		output, err := runCombined(lsCmd)
+		if err != nil {
+			return SnapshotErrorMsg{string(output), err}
+		}
+
+		var (
+			files []string
+			input strings.Builder
+		)
+		for file := range strings.SplitSeq(string(output), "\x00") {
+			if isSnapshotFile(file) {
+				files = append(files, file)
+				input.WriteString(hash + ":" + file + "\n")
+			}
+		}
+
+		catCmd := exec.Command("git", "cat-file", "--batch")
+		catCmd.Dir = cfg.StoragePath
+		catCmd.Stdin = strings.NewReader(input.String())
+
+		var stderr bytes.Buffer
+		catCmd.Stderr = &stderr
+
+		output, err = runOutput(catCmd)
+		if err != nil {
+			return SnapshotErrorMsg{stderr.String(), err}
+		}
+
+		contents, err := parseCatFileBatch(output, files)
+		if err != nil {
+			return SnapshotErrorMsg{"cannot read commit " + hash, err}
+		}
+
+		return SnapshotDoneMsg{Hash: hash, Files: contents}
+	}
+}
+
+// gitFileAtCmd reads the content of the first of the given files
+// that exists at the given commit.
+func gitFileAtCmd(v *viper.Viper, hash string, files []string) tea.Cmd {
+	return func() tea.Msg {
+		for _, file := range files {
+			showCmd := exec.Command("git", "show", hash+":"+file) // #nosec G204 Hash is validated by FileAtCmd
+			showCmd.Dir = config.Get(v).StoragePath
+
+			if output, err := runOutput(showCmd); err == nil {
+				return FileAtDoneMsg{Hash: hash, File: file, Content: output}
+			}
+		}
+
+		return FileAtErrorMsg{strings.Join(files, "\n"), errFileNotFound}
+	}
+}
+
 // gitContributorEmailAddresses returns all commit author email addresses
 // found by the git log command.
 func gitContributors(v *viper.Viper) ([]string, error) {
 	emailsCmd := exec.Command("git", "log", "--format=%aN %aE")
-	emailsCmd.Dir = v.GetString("storage.path")
+	emailsCmd.Dir = config.Get(v).StoragePath
 
-	output, err := emailsCmd.CombinedOutput()
+	output, err := runCombined(emailsCmd)
 	if err != nil {
 		return nil, err
 	}
@@ -244,3 +508,32 @@ func gitContributors(v *viper.Viper) ([]string, error) {
 
 	return helpers.UniqueNonEmptyStrings(authors), nil
 }
+
+// gitStatus returns the paths of all files with uncommitted changes,
+// including untracked files, as reported by git status.
+func gitStatus(v *viper.Viper) ([]string, error) {
+	statusCmd := exec.Command("git", "status", "--porcelain", "--untracked-files=all")
+	statusCmd.Dir = config.Get(v).StoragePath
+
+	output, err := runCombined(statusCmd)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", strings.TrimSpace(string(output)), err)
+	}
+
+	var files []string
+	for line := range strings.SplitSeq(string(output), "\n") {
+		if len(line) < 4 {
+			continue
+		}
+
+		// Renames are reported as "old -> new".
+		file := line[3:]
+		if _, after, found := strings.Cut(file, " -> "); found {
+			file = after
+		}
+
+		files = append(files, strings.Trim(file, `"`))
+	}
+
+	return files, nil
+}
@@ -24,8 +24,10 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/handlebargh/yatto/internal/storage"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 )
@@ -132,4 +134,242 @@ func TestGitInitCmd(t *testing.T) {
 
 	_, err := os.Stat(filepath.Join(tempDir, "INIT"))
 	assert.NoError(t, err, "INIT file should be created")
+
+	output, err := gitOutput(v, "ls-files")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{storage.IgnoreFile, "INIT"}, strings.Fields(string(output)))
+}
+
+func TestGitHistoryCmd(t *testing.T) {
+	v := setupTestRepo(t)
+	storagePath := v.GetString("storage.path")
+
+	err := os.MkdirAll(filepath.Join(storagePath, "project"), 0o700)
+	assert.NoError(t, err)
+
+	file := filepath.Join("project", "task.json")
+	for i, content := range []string{"{\"title\":\"a\"}\n", "{\"title\":\"b\"}\n"} {
+		err = os.WriteFile(filepath.Join(storagePath, file), []byte(content), 0o600)
+		assert.NoError(t, err)
+
+		_, err = gitCommit(v, []string{"create: a", "update: b"}[i], file)
+		assert.NoError(t, err)
+	}
+
+	msg := gitHistoryCmd(v, file)()
+
+	history, ok := msg.(HistoryDoneMsg)
+	assert.True(t, ok, "expected HistoryDoneMsg, got %T", msg)
+	assert.Len(t, history.Entries, 2)
+	assert.Equal(t, "update: b", history.Entries[0].Subject)
+	assert.Equal(t, "Test User <test@example.com>", history.Entries[0].Author)
+	assert.False(t, history.Entries[0].Date.IsZero())
+	assert.Contains(t, history.Entries[0].Diff, "+{\"title\":\"b\"}")
+	assert.Contains(t, history.Entries[0].Diff, "-{\"title\":\"a\"}")
+	assert.Equal(t, "create: a", history.Entries[1].Subject)
+}
+
+func TestGitLogAndSnapshotCmd(t *testing.T) {
+	v := setupTestRepo(t)
+	storagePath := v.GetString("storage.path")
+
+	err := os.MkdirAll(filepath.Join(storagePath, "project"), 0o700)
+	assert.NoError(t, err)
+
+	file := filepath.Join("project", "task.json")
+	for i, content := range []string{"{\"title\":\"a\"}\n", "{\"title\":\"b\"}\n"} {
+		err = os.WriteFile(filepath.Join(storagePath, file), []byte(content), 0o600)
+		assert.NoError(t, err)
+
+		_, err = gitCommit(v, []string{"create: a", "update: b"}[i], file)
+		assert.NoError(t, err)
+	}
+
+	msg := gitLogCmd(v, 10)()
+
+	log, ok := msg.(LogDoneMsg)
+	assert.True(t, ok, "expected LogDoneMsg, got %T", msg)
+	assert.Len(t, log.Entries, 2)
+	assert.Equal(t, "update: b", log.Entries[0].Subject)
+	assert.Equal(t, "Test User <test@example.com>", log.Entries[0].Author)
+	assert.Equal(t, []string{"project/task.json"}, log.Entries[0].Files)
+
+	msg = gitLogCmd(v, 1)()
+	assert.Len(t, msg.(LogDoneMsg).Entries, 1)
+
+	msg = gitSnapshotCmd(v, log.Entries[1].Hash)()
+
+	snapshot, ok := msg.(SnapshotDoneMsg)
+	assert.True(t, ok, "expected SnapshotDoneMsg, got %T", msg)
+	assert.Equal(t, map[string][]byte{"project/task.json": []byte("{\"title\":\"a\"}\n")}, snapshot.Files)
+}
+
+func TestGitFileAtCmd(t *testing.T) {
+	v := setupTestRepo(t)
+	storagePath := v.GetString("storage.path")
+
+	err := os.MkdirAll(filepath.Join(storagePath, "project"), 0o700)
+	assert.NoError(t, err)
+
+	file := filepath.Join("project", "task.json")
+	err = os.WriteFile(filepath.Join(storagePath, file), []byte("{\"title\":\"a\"}\n"), 0o600)
+	assert.NoError(t, err)
+	_, err = gitCommit(v, "create: a", file)
+	assert.NoError(t, err)
+
+	msg := gitFileAtCmd(v, "HEAD", []string{"project/archive/task.json", "project/task.json"})()
+
+	fileAt, ok := msg.(FileAtDoneMsg)
+	assert.True(t, ok, "expected FileAtDoneMsg, got %T", msg)
+	assert.Equal(t, "project/task.json", fileAt.File)
+	assert.Equal(t, "{\"title\":\"a\"}\n", string(fileAt.Content))
+
+	msg = gitFileAtCmd(v, "HEAD", []string{"project/missing.json"})()
+
+	_, ok = msg.(FileAtErrorMsg)
+	assert.True(t, ok, "expected FileAtErrorMsg, got %T", msg)
+}
+
+func TestGitRevertLastCmd(t *testing.T) {
+	v := setupTestRepo(t)
+	storagePath := v.GetString("storage.path")
+
+	err := os.WriteFile(filepath.Join(storagePath, "INIT"), nil, 0o600)
+	assert.NoError(t, err)
+	_, err = gitCommit(v, initialCommitMessage, "INIT")
+	assert.NoError(t, err)
+
+	// The initial commit is never reverted.
+	msg := gitRevertLastCmd(v)()
+	assert.Equal(t, RevertErrorMsg{"", ErrorNothingToRevert}, msg)
+
+	err = os.MkdirAll(filepath.Join(storagePath, "project"), 0o700)
+	assert.NoError(t, err)
+	file := filepath.Join("project", "task.json")
+	err = os.WriteFile(filepath.Join(storagePath, file), []byte("task"), 0o600)
+	assert.NoError(t, err)
+	_, err = gitCommit(v, "create: task", file)
+	assert.NoError(t, err)
+
+	msg = gitRevertLastCmd(v)()
+	assert.Equal(t, RevertDoneMsg{Subject: "create: task"}, msg)
+
+	_, err = os.Stat(filepath.Join(storagePath, file))
+	assert.True(t, os.IsNotExist(err), "reverted file should be removed")
+}
+
+// setupProjectBranchRepo creates a repository with project branches enabled
+// and a committed project with the given ID.
+func setupProjectBranchRepo(t *testing.T, projectID string) *viper.Viper {
+	t.Helper()
+
+	v := setupTestRepo(t)
+	v.Set("vcs.backend", "git")
+	v.Set("git.default_branch", "main")
+	v.Set("git.branch_per_project", true)
+	storagePath := v.GetString("storage.path")
+
+	_, err := gitOutput(v, "switch", "--orphan", "main")
+	assert.NoError(t, err)
+
+	err = os.Mkdir(filepath.Join(storagePath, projectID), 0o750)
+	assert.NoError(t, err)
+	err = os.WriteFile(filepath.Join(storagePath, projectID, "project.json"), []byte(`{"title":"Home"}`), 0o600)
+	assert.NoError(t, err)
+
+	_, err = gitCommit(v, "create: Home", filepath.Join(projectID, "project.json"))
+	assert.NoError(t, err)
+
+	return v
+}
+
+func TestTaskProject(t *testing.T) {
+	v := setupProjectBranchRepo(t, "p1")
+
+	assert.Equal(t, "p1", taskProject(v, []string{"p1/t1.json", "p1/t2.json"}))
+	assert.Empty(t, taskProject(v, []string{"p1/project.json"}))
+	assert.Empty(t, taskProject(v, []string{"p1/t1.json", "p1/project.json"}))
+	assert.Empty(t, taskProject(v, []string{"p1/t1.json", "p2/t1.json"}))
+	assert.Empty(t, taskProject(v, []string{"p2/t1.json"}))
+	assert.Empty(t, taskProject(v, []string{"INIT"}))
+	assert.Empty(t, taskProject(v, nil))
+}
+
+func TestGitCommit_ProjectBranches(t *testing.T) {
+	v := setupProjectBranchRepo(t, "p1")
+	storagePath := v.GetString("storage.path")
+
+	branch, err := gitCurrentBranch(v)
+	assert.NoError(t, err)
+	assert.Equal(t, "main", branch, "project.json is committed on the default branch")
+
+	err = os.WriteFile(filepath.Join(storagePath, "p1", "t1.json"), []byte(`{}`), 0o600)
+	assert.NoError(t, err)
+	_, err = gitCommit(v, "create: Task", filepath.Join("p1", "t1.json"))
+	assert.NoError(t, err)
+
+	branch, err = gitCurrentBranch(v)
+	assert.NoError(t, err)
+	assert.Equal(t, ProjectBranch("p1"), branch)
+
+	msg := LeaveProjectBranchCmd(v)()
+	assert.IsType(t, BranchDoneMsg{}, msg)
+
+	branch, err = gitCurrentBranch(v)
+	assert.NoError(t, err)
+	assert.Equal(t, "main", branch)
+
+	output, err := gitOutput(v, "log", "-1", "--format=%s", "HEAD^2")
+	assert.NoError(t, err, "HEAD should be a merge")
+	assert.Equal(t, "create: Task", strings.TrimSpace(string(output)))
+
+	output, err = gitOutput(v, "log", "-1", "--format=%s")
+	assert.NoError(t, err)
+	assert.Equal(t, "merge: Home", strings.TrimSpace(string(output)))
+
+	output, err = gitOutput(v, "status", "--porcelain")
+	assert.NoError(t, err)
+	assert.Empty(t, strings.TrimSpace(string(output)), "working tree should be clean")
+
+	_, err = os.Stat(filepath.Join(storagePath, "p1", "t1.json"))
+	assert.NoError(t, err)
+
+	// Leaving again does nothing.
+	msg = LeaveProjectBranchCmd(v)()
+	assert.IsType(t, BranchDoneMsg{}, msg)
+}
+
+func TestGitCommit_ProjectBranchNotMerged(t *testing.T) {
+	v := setupProjectBranchRepo(t, "p1")
+	storagePath := v.GetString("storage.path")
+
+	// The project branch holds a commit the default branch lacks.
+	_, err := gitOutput(v, "branch", ProjectBranch("p1"))
+	assert.NoError(t, err)
+	_, err = gitOutput(v, "commit", "--allow-empty", "--message", "lost")
+	assert.NoError(t, err)
+	_, err = gitOutput(v, "reset", "--hard", "HEAD^")
+	assert.NoError(t, err)
+	_, err = gitOutput(v, "branch", "--force", ProjectBranch("p1"), "HEAD@{1}")
+	assert.NoError(t, err)
+
+	err = os.WriteFile(filepath.Join(storagePath, "p1", "t1.json"), []byte(`{}`), 0o600)
+	assert.NoError(t, err)
+	_, err = gitCommit(v, "create: Task", filepath.Join("p1", "t1.json"))
+	assert.ErrorContains(t, err, "was not merged")
+
+	branch, err := gitCurrentBranch(v)
+	assert.NoError(t, err)
+	assert.Equal(t, "main", branch)
+
+	output, err := gitOutput(v, "log", "-1", "--format=%s")
+	assert.NoError(t, err)
+	assert.Equal(t, "create: Home", strings.TrimSpace(string(output)), "nothing should be committed")
+}
+
+func TestLeaveProjectBranchCmd_Disabled(t *testing.T) {
+	v := setupTestRepo(t)
+	v.Set("vcs.backend", "git")
+
+	assert.Nil(t, LeaveProjectBranchCmd(v))
 }
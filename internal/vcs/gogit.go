@@ -0,0 +1,821 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package vcs
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/go-git/go-billy/v5/util"
+	"github.com/go-git/go-git/v5"
+	gitconfig "github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/handlebargh/yatto/internal/config"
+	"github.com/handlebargh/yatto/internal/helpers"
+	"github.com/handlebargh/yatto/internal/storage"
+	"github.com/spf13/viper"
+)
+
+// gogitInitCmd initializes a Git repository in the configured storage path
+// using the built-in go-git library instead of the git binary.
+// It behaves like gitInitCmd and reuses the git.* configuration values.
+// Returns a InitDoneMsg or InitErrorMsg.
+func gogitInitCmd(v *viper.Viper) tea.Cmd {
+	cfg := config.Get(v)
+
+	return func() tea.Msg {
+		storagePath := cfg.StoragePath
+
+		root, err := os.OpenRoot(storagePath)
+		if err != nil {
+			return InitErrorMsg{"cannot change dir to configured storage path", err}
+		}
+		defer helpers.CloseWithErr(root, &err)
+
+		if _, err := root.Stat("INIT"); err == nil {
+			return InitDoneMsg{}
+		}
+
+		repo, err := git.PlainInitWithOptions(storagePath, &git.PlainInitOptions{
+			InitOptions: git.InitOptions{
+				DefaultBranch: plumbing.NewBranchReferenceName(cfg.GitDefaultBranch),
+			},
+		})
+		if err != nil && !errors.Is(err, git.ErrRepositoryAlreadyExists) {
+			return InitErrorMsg{"cannot initialize repository", err}
+		}
+
+		if cfg.GitRemoteEnable && repo != nil {
+			if _, err := repo.CreateRemote(&gitconfig.RemoteConfig{
+				Name: cfg.GitRemoteName,
+				URLs: []string{cfg.GitRemoteURL},
+			}); err != nil {
+				return InitErrorMsg{"cannot create remote", err}
+			}
+		}
+
+		if _, err := storage.WriteIgnoreFile(v); err != nil {
+			return InitErrorMsg{"cannot write ignore file", err}
+		}
+
+		f, err := root.Create("INIT")
+		if err != nil {
+			return InitErrorMsg{"cannot create INIT file via root", err}
+		}
+		defer helpers.CloseWithErr(f, &err)
+
+		if output, err := gogitCommit(v, initialCommitMessage, "INIT", storage.IgnoreFile); err != nil {
+			return InitErrorMsg{string(output), err}
+		}
+
+		if cfg.GitRemoteEnable {
+			if output, err := gogitPush(v); err != nil {
+				return InitErrorMsg{string(output), err}
+			}
+		}
+
+		return InitDoneMsg{}
+	}
+}
+
+// gogitCommitCmd stages and commits the specified files with the given message.
+// If Git remote support is enabled, it pulls from the remote before pushing.
+// If the commit cannot be pushed, it is recorded for PushCmd and a PushQueuedMsg
+// is returned. Otherwise, returns a CommitDoneMsg or an error message.
+func gogitCommitCmd(v *viper.Viper, message string, files ...string) tea.Cmd {
+	return func() tea.Msg {
+		if output, err := gogitCommit(v, message, files...); err != nil {
+			return CommitErrorMsg{string(output), err}
+		}
+
+		if config.Get(v).GitRemoteEnable {
+			if output, err := gogitPull(v); err != nil {
+				// Diverged histories must be resolved by hand. Otherwise,
+				// the remote is most likely unreachable, so push later.
+				if errors.Is(err, git.ErrNonFastForwardUpdate) {
+					return PullErrorMsg{string(output), err}
+				}
+				return pushFailed(v, output, err)
+			}
+
+			if output, err := gogitPush(v); err != nil {
+				return pushFailed(v, output, err)
+			}
+
+			pushed(v)
+		}
+
+		return CommitDoneMsg{}
+	}
+}
+
+// gogitPushCmd pulls from and pushes to the configured remote.
+// Returns a PushDoneMsg, PullErrorMsg or PushErrorMsg.
+func gogitPushCmd(v *viper.Viper) tea.Cmd {
+	return func() tea.Msg {
+		if output, err := gogitPull(v); err != nil {
+			return PullErrorMsg{string(output), err}
+		}
+
+		if output, err := gogitPush(v); err != nil {
+			return pushErrorMsg(v, output, err)
+		}
+
+		pushed(v)
+
+		return PushDoneMsg{}
+	}
+}
+
+// gogitPullCmd pulls the configured branch from the remote.
+// Returns a PullDoneMsg or PullErrorMsg.
+func gogitPullCmd(v *viper.Viper) tea.Cmd {
+	return func() tea.Msg {
+		// Don't try to pull if repo is not initialized.
+		if !storage.FileExists(v, "INIT") {
+			return PullNoInitMsg{}
+		}
+
+		output, err := gogitPull(v)
+		if err != nil {
+			return PullErrorMsg{string(output), err}
+		}
+
+		synced(v)
+
+		return PullDoneMsg{}
+	}
+}
+
+// gogitPull fetches the configured branch from the remote and merges it.
+// go-git can neither merge nor rebase, so only fast-forward updates
+// succeed and diverged histories return ErrDiverged.
+// An already up-to-date or still empty remote is not treated as an error.
+func gogitPull(v *viper.Viper) (output []byte, err error) {
+	cfg := config.Get(v)
+
+	defer func(start time.Time) { logOperation("gogit pull", start, output, err) }(time.Now())
+
+	repo, err := git.PlainOpen(cfg.StoragePath)
+	if err != nil {
+		return []byte("cannot open repository"), err
+	}
+
+	w, err := repo.Worktree()
+	if err != nil {
+		return []byte("cannot open worktree"), err
+	}
+
+	err = w.Pull(&git.PullOptions{
+		RemoteName:    cfg.GitRemoteName,
+		ReferenceName: plumbing.NewBranchReferenceName(cfg.GitDefaultBranch),
+		SingleBranch:  true,
+	})
+
+	switch {
+	case err == nil,
+		errors.Is(err, git.NoErrAlreadyUpToDate),
+		errors.Is(err, transport.ErrEmptyRemoteRepository),
+		errors.Is(err, plumbing.ErrReferenceNotFound):
+		return nil, nil
+	case errors.Is(err, git.ErrNonFastForwardUpdate):
+		return []byte("local and remote history have diverged and cannot be fast-forwarded"), fmt.Errorf("%w: %w", ErrDiverged, err)
+	default:
+		return []byte("pull failed"), err
+	}
+}
+
+// gogitCommit stages the specified files and commits them with the given message.
+// Paths that no longer exist in the worktree are removed from the index,
+// including all files below deleted directories. If there are no staged
+// changes, it returns nil.
+func gogitCommit(v *viper.Viper, message string, files ...string) (output []byte, err error) {
+	defer func(start time.Time) { logOperation("gogit commit", start, output, err) }(time.Now())
+
+	storagePath := config.Get(v).StoragePath
+
+	repo, err := git.PlainOpen(storagePath)
+	if err != nil {
+		return []byte("cannot open repository"), err
+	}
+
+	w, err := repo.Worktree()
+	if err != nil {
+		return []byte("cannot open worktree"), err
+	}
+
+	for _, file := range files {
+		file = filepath.ToSlash(filepath.Clean(file))
+
+		if _, err := w.Filesystem.Lstat(file); err == nil {
+			if _, err := w.Add(file); err != nil {
+				return fmt.Appendf(nil, "cannot add %s", file), err
+			}
+
+			continue
+		}
+
+		if err := gogitRemoveFromIndex(repo, w, file); err != nil {
+			return fmt.Appendf(nil, "cannot remove %s", file), err
+		}
+	}
+
+	status, err := w.Status()
+	if err != nil {
+		return []byte("cannot read worktree status"), err
+	}
+
+	staged := false
+	for _, s := range status {
+		if s.Staging != git.Unmodified && s.Staging != git.Untracked {
+			staged = true
+			break
+		}
+	}
+
+	if !staged {
+		return nil, nil
+	}
+
+	hash, err := w.Commit(message, &git.CommitOptions{})
+	if err != nil {
+		return []byte("commit failed"), err
+	}
+
+	return fmt.Appendf(nil, "[%s] %s", hash.String()[:7], message), nil
+}
+
+// gogitRemoveFromIndex removes the given path, or every tracked file below
+// it if the path was a directory, from the index.
+func gogitRemoveFromIndex(repo *git.Repository, w *git.Worktree, path string) error {
+	idx, err := repo.Storer.Index()
+	if err != nil {
+		return err
+	}
+
+	var names []string
+	for _, e := range idx.Entries {
+		if e.Name == path || strings.HasPrefix(e.Name, path+"/") {
+			names = append(names, e.Name)
+		}
+	}
+
+	for _, name := range names {
+		if _, err := w.Remove(name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// gogitPush pushes the configured branch to the configured remote
+// and afterwards to all configured mirrors, see pushAll.
+// SSH remotes are authenticated using the running ssh-agent.
+func gogitPush(v *viper.Viper) (output []byte, err error) {
+	cfg := config.Get(v)
+
+	defer func(start time.Time) { logOperation("gogit push", start, output, err) }(time.Now())
+
+	repo, err := git.PlainOpen(cfg.StoragePath)
+	if err != nil {
+		return []byte("cannot open repository"), err
+	}
+
+	remoteName := cfg.GitRemoteName
+	ref := plumbing.NewBranchReferenceName(cfg.GitDefaultBranch)
+	refSpecs := []gitconfig.RefSpec{gitconfig.RefSpec(ref + ":" + ref)}
+
+	return pushAll(v, remoteName,
+		func() ([]byte, error) {
+			err := repo.Push(&git.PushOptions{
+				RemoteName: remoteName,
+				RefSpecs:   refSpecs,
+			})
+			if err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+				return []byte("push failed"), err
+			}
+
+			return nil, nil
+		},
+		func(m Mirror) ([]byte, error) {
+			// Mirrors are not stored in the repository configuration.
+			remote := git.NewRemote(repo.Storer, &gitconfig.RemoteConfig{Name: m.Name, URLs: []string{m.URL}})
+
+			err := remote.Push(&git.PushOptions{
+				RemoteName: m.Name,
+				RefSpecs:   refSpecs,
+			})
+			if err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+				return nil, err
+			}
+
+			return nil, nil
+		},
+	)
+}
+
+// gogitUser returns the name and email address found in the
+// repository, global and system git configuration.
+func gogitUser(v *viper.Viper) (string, error) {
+	repo, err := git.PlainOpen(config.Get(v).StoragePath)
+	if err != nil {
+		return "", err
+	}
+
+	cfg, err := repo.ConfigScoped(gitconfig.SystemScope)
+	if err != nil {
+		return "", err
+	}
+
+	var result strings.Builder
+	result.WriteString(strings.TrimSpace(cfg.User.Name))
+	result.WriteString(" ")
+	result.WriteString(helpers.AddAngleBracketsToEmail(strings.TrimSpace(cfg.User.Email)))
+
+	return result.String(), nil
+}
+
+// gogitContributors returns all commit authors found in the repository history.
+func gogitContributors(v *viper.Viper) ([]string, error) {
+	repo, err := git.PlainOpen(config.Get(v).StoragePath)
+	if err != nil {
+		return nil, err
+	}
+
+	iter, err := repo.Log(&git.LogOptions{})
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close()
+
+	var authors []string
+	err = iter.ForEach(func(c *object.Commit) error {
+		authors = append(authors, c.Author.Name+" "+c.Author.Email)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return helpers.UniqueNonEmptyStrings(authors), nil
+}
+
+// gogitRevertLastCmd reverts the most recent commit.
+// If Git remote support is enabled, it pulls before reverting so that
+// the latest change is undone, and pushes the revert afterwards.
+// Returns a RevertDoneMsg or RevertErrorMsg.
+func gogitRevertLastCmd(v *viper.Viper) tea.Cmd {
+	cfg := config.Get(v)
+
+	return func() tea.Msg {
+		if cfg.GitRemoteEnable {
+			if output, err := gogitPull(v); err != nil {
+				return PullErrorMsg{string(output), err}
+			}
+		}
+
+		subject, output, err := gogitRevertLast(v)
+		if err != nil {
+			return RevertErrorMsg{string(output), err}
+		}
+
+		if cfg.GitRemoteEnable {
+			if output, err := gogitPush(v); err != nil {
+				return pushErrorMsg(v, output, err)
+			}
+		}
+
+		return RevertDoneMsg{Subject: subject}
+	}
+}
+
+// gogitRevertLast restores every file changed by the HEAD commit to the
+// state of its parent and commits the result. go-git has no revert, so
+// files added by HEAD are deleted along with directories left empty.
+// Returns the subject of the reverted commit.
+func gogitRevertLast(v *viper.Viper) (string, []byte, error) {
+	repo, err := git.PlainOpen(config.Get(v).StoragePath)
+	if err != nil {
+		return "", []byte("cannot open repository"), err
+	}
+
+	head, err := repo.Head()
+	if err != nil {
+		return "", []byte("cannot resolve HEAD"), err
+	}
+
+	commit, err := repo.CommitObject(head.Hash())
+	if err != nil {
+		return "", []byte("cannot read HEAD commit"), err
+	}
+
+	subject := strings.TrimSpace(strings.SplitN(commit.Message, "\n", 2)[0])
+	if commit.NumParents() == 0 || subject == initialCommitMessage {
+		return "", nil, ErrorNothingToRevert
+	}
+
+	parent, err := commit.Parent(0)
+	if err != nil {
+		return "", []byte("cannot read parent commit"), err
+	}
+
+	parentTree, err := parent.Tree()
+	if err != nil {
+		return "", []byte("cannot read parent tree"), err
+	}
+
+	tree, err := commit.Tree()
+	if err != nil {
+		return "", []byte("cannot read HEAD tree"), err
+	}
+
+	changes, err := object.DiffTree(parentTree, tree)
+	if err != nil {
+		return "", []byte("cannot diff HEAD against its parent"), err
+	}
+
+	w, err := repo.Worktree()
+	if err != nil {
+		return "", []byte("cannot open worktree"), err
+	}
+
+	var files []string
+	for _, change := range changes {
+		from, _, err := change.Files()
+		if err != nil {
+			return "", []byte("cannot read changed files"), err
+		}
+
+		// The file was added by HEAD.
+		if from == nil {
+			name := change.To.Name
+			if err := w.Filesystem.Remove(name); err != nil && !os.IsNotExist(err) {
+				return "", fmt.Appendf(nil, "cannot remove %s", name), err
+			}
+
+			// Only succeeds if the directory is empty.
+			if dir := path.Dir(name); dir != "." {
+				_ = w.Filesystem.Remove(dir)
+			}
+
+			files = append(files, name)
+			continue
+		}
+
+		name := change.From.Name
+
+		contents, err := from.Contents()
+		if err != nil {
+			return "", fmt.Appendf(nil, "cannot read %s", name), err
+		}
+
+		if err := w.Filesystem.MkdirAll(path.Dir(name), 0o700); err != nil {
+			return "", fmt.Appendf(nil, "cannot create directory for %s", name), err
+		}
+
+		if err := util.WriteFile(w.Filesystem, name, []byte(contents), 0o600); err != nil {
+			return "", fmt.Appendf(nil, "cannot restore %s", name), err
+		}
+
+		files = append(files, name)
+	}
+
+	output, err := gogitCommit(v, "Revert \""+subject+"\"", files...)
+	if err != nil {
+		return "", output, err
+	}
+
+	return subject, output, nil
+}
+
+// gogitHistoryCmd reads the history of the given file including its diffs.
+// Unlike gitHistoryCmd, renames are not followed.
+// Returns a HistoryDoneMsg or HistoryErrorMsg.
+func gogitHistoryCmd(v *viper.Viper, file string) tea.Cmd {
+	return func() tea.Msg {
+		entries, err := gogitHistory(v, filepath.ToSlash(filepath.Clean(file)))
+		if err != nil {
+			return HistoryErrorMsg{"cannot read history", err}
+		}
+
+		return HistoryDoneMsg{Entries: entries}
+	}
+}
+
+// gogitHistory returns all commits that changed the given file along
+// with the diff of that file in each commit.
+func gogitHistory(v *viper.Viper, file string) ([]HistoryEntry, error) {
+	repo, err := git.PlainOpen(config.Get(v).StoragePath)
+	if err != nil {
+		return nil, err
+	}
+
+	iter, err := repo.Log(&git.LogOptions{FileName: &file})
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close()
+
+	var entries []HistoryEntry
+	err = iter.ForEach(func(c *object.Commit) error {
+		diff, err := gogitFileDiff(c, file)
+		if err != nil {
+			return err
+		}
+
+		entries = append(entries, HistoryEntry{
+			Hash:    c.Hash.String(),
+			Author:  c.Author.Name + " " + helpers.AddAngleBracketsToEmail(c.Author.Email),
+			Date:    c.Author.When,
+			Subject: strings.TrimSpace(strings.SplitN(c.Message, "\n", 2)[0]),
+			Diff:    diff,
+		})
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// gogitCommitChanges returns the changes between the commit and its
+// first parent. Root commits are diffed against an empty tree.
+func gogitCommitChanges(c *object.Commit) (object.Changes, error) {
+	tree, err := c.Tree()
+	if err != nil {
+		return nil, err
+	}
+
+	var parentTree *object.Tree
+	if c.NumParents() > 0 {
+		parent, err := c.Parent(0)
+		if err != nil {
+			return nil, err
+		}
+
+		if parentTree, err = parent.Tree(); err != nil {
+			return nil, err
+		}
+	}
+
+	return object.DiffTree(parentTree, tree)
+}
+
+// gogitFileDiff returns the unified diff of the given file between the
+// commit and its first parent.
+func gogitFileDiff(c *object.Commit, file string) (string, error) {
+	changes, err := gogitCommitChanges(c)
+	if err != nil {
+		return "", err
+	}
+
+	var fileChanges object.Changes
+	for _, change := range changes {
+		if change.From.Name == file || change.To.Name == file {
+			fileChanges = append(fileChanges, change)
+		}
+	}
+
+	patch, err := fileChanges.Patch()
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimRight(patch.String(), "\n"), nil
+}
+
+// gogitLogCmd reads the most recent commits of the storage repository
+// along with the files changed in each of them.
+func gogitLogCmd(v *viper.Viper, limit int) tea.Cmd {
+	return func() tea.Msg {
+		entries, err := gogitLog(v, limit)
+		if err != nil {
+			return LogErrorMsg{"cannot read log", err}
+		}
+
+		return LogDoneMsg{Entries: entries}
+	}
+}
+
+// gogitLog returns at most limit commits reachable from HEAD,
+// newest first.
+func gogitLog(v *viper.Viper, limit int) ([]LogEntry, error) {
+	repo, err := git.PlainOpen(config.Get(v).StoragePath)
+	if err != nil {
+		return nil, err
+	}
+
+	iter, err := repo.Log(&git.LogOptions{})
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close()
+
+	var entries []LogEntry
+	err = iter.ForEach(func(c *object.Commit) error {
+		if len(entries) >= limit {
+			return storer.ErrStop
+		}
+
+		changes, err := gogitCommitChanges(c)
+		if err != nil {
+			return err
+		}
+
+		var files []string
+		for _, change := range changes {
+			name := change.To.Name
+			if name == "" {
+				name = change.From.Name
+			}
+
+			files = append(files, name)
+		}
+
+		entries = append(entries, LogEntry{
+			Hash:    c.Hash.String(),
+			Author:  c.Author.Name + " " + helpers.AddAngleBracketsToEmail(c.Author.Email),
+			Date:    c.Author.When,
+			Subject: strings.TrimSpace(strings.SplitN(c.Message, "\n", 2)[0]),
+			Files:   files,
+		})
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// gogitSnapshotCmd reads the JSON files of the storage repository
+// at the given commit.
+func gogitSnapshotCmd(v *viper.Viper, hash string) tea.Cmd {
+	return func() tea.Msg {
+		files, err := gogitSnapshot(v, hash)
+		if err != nil {
+			return SnapshotErrorMsg{"cannot read commit " + hash, err}
+		}
+
+		return SnapshotDoneMsg{Hash: hash, Files: files}
+	}
+}
+
+// gogitSnapshot returns the content of all JSON files in the tree
+// of the given commit, which may be abbreviated.
+func gogitSnapshot(v *viper.Viper, hash string) (map[string][]byte, error) {
+	repo, err := git.PlainOpen(config.Get(v).StoragePath)
+	if err != nil {
+		return nil, err
+	}
+
+	rev, err := repo.ResolveRevision(plumbing.Revision(hash))
+	if err != nil {
+		return nil, err
+	}
+
+	commit, err := repo.CommitObject(*rev)
+	if err != nil {
+		return nil, err
+	}
+
+	iter, err := commit.Files()
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close()
+
+	files := make(map[string][]byte)
+	err = iter.ForEach(func(f *object.File) error {
+		if !isSnapshotFile(f.Name) {
+			return nil
+		}
+
+		content, err := f.Contents()
+		if err != nil {
+			return err
+		}
+
+		files[f.Name] = []byte(content)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return files, nil
+}
+
+// gogitFileAtCmd reads the content of the first of the given files
+// that exists at the given commit.
+func gogitFileAtCmd(v *viper.Viper, hash string, files []string) tea.Cmd {
+	return func() tea.Msg {
+		file, content, err := gogitFileAt(v, hash, files)
+		if err != nil {
+			return FileAtErrorMsg{strings.Join(files, "\n"), err}
+		}
+
+		return FileAtDoneMsg{Hash: hash, File: file, Content: content}
+	}
+}
+
+// gogitFileAt returns the first of the given files that exists at the
+// given commit, which may be abbreviated, along with its content.
+func gogitFileAt(v *viper.Viper, hash string, files []string) (string, []byte, error) {
+	repo, err := git.PlainOpen(config.Get(v).StoragePath)
+	if err != nil {
+		return "", nil, err
+	}
+
+	rev, err := repo.ResolveRevision(plumbing.Revision(hash))
+	if err != nil {
+		return "", nil, err
+	}
+
+	commit, err := repo.CommitObject(*rev)
+	if err != nil {
+		return "", nil, err
+	}
+
+	for _, file := range files {
+		f, err := commit.File(file)
+		if errors.Is(err, object.ErrFileNotFound) {
+			continue
+		}
+		if err != nil {
+			return "", nil, err
+		}
+
+		content, err := f.Contents()
+		if err != nil {
+			return "", nil, err
+		}
+
+		return file, []byte(content), nil
+	}
+
+	return "", nil, errFileNotFound
+}
+
+// gogitStatus returns the paths of all files with uncommitted changes,
+// including untracked files, found in the worktree.
+func gogitStatus(v *viper.Viper) ([]string, error) {
+	repo, err := git.PlainOpen(config.Get(v).StoragePath)
+	if err != nil {
+		return nil, err
+	}
+
+	w, err := repo.Worktree()
+	if err != nil {
+		return nil, err
+	}
+
+	status, err := w.Status()
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]string, 0, len(status))
+	for file, s := range status {
+		if s.Staging == git.Unmodified && s.Worktree == git.Unmodified {
+			continue
+		}
+		files = append(files, file)
+	}
+	slices.Sort(files)
+
+	return files, nil
+}
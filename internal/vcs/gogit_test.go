@@ -0,0 +1,394 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package vcs
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/handlebargh/yatto/internal/storage"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+// setupGogitTestRepo creates a new temporary directory and initializes
+// a git repository without relying on the git binary.
+func setupGogitTestRepo(t *testing.T) *viper.Viper {
+	t.Helper()
+
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	tempDir := t.TempDir()
+	v := viper.New()
+	v.Set("storage.path", tempDir)
+	v.Set("git.default_branch", "main")
+	v.Set("git.remote.enable", false)
+
+	repo, err := git.PlainInit(tempDir, false)
+	assert.NoError(t, err)
+
+	cfg, err := repo.Config()
+	assert.NoError(t, err)
+
+	cfg.User.Name = "Test User"
+	cfg.User.Email = "test@example.com"
+	assert.NoError(t, repo.SetConfig(cfg))
+
+	return v
+}
+
+// gogitLastMessage returns the message of the HEAD commit.
+func gogitLastMessage(t *testing.T, v *viper.Viper) string {
+	t.Helper()
+
+	repo, err := git.PlainOpen(v.GetString("storage.path"))
+	assert.NoError(t, err)
+
+	head, err := repo.Head()
+	assert.NoError(t, err)
+
+	commit, err := repo.CommitObject(head.Hash())
+	assert.NoError(t, err)
+
+	return commit.Message
+}
+
+func TestGogitUser(t *testing.T) {
+	v := setupGogitTestRepo(t)
+
+	user, err := gogitUser(v)
+	assert.NoError(t, err)
+	assert.Equal(t, "Test User <test@example.com>", user)
+}
+
+func TestGogitCommit(t *testing.T) {
+	v := setupGogitTestRepo(t)
+	storagePath := v.GetString("storage.path")
+
+	err := os.MkdirAll(filepath.Join(storagePath, "project"), 0o700)
+	assert.NoError(t, err)
+	err = os.WriteFile(filepath.Join(storagePath, "project", "test.json"), []byte("hello"), 0o600)
+	assert.NoError(t, err)
+
+	output, err := gogitCommit(v, "feat: add test file", filepath.Join("project", "test.json"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(output), "feat: add test file")
+	assert.Equal(t, "feat: add test file", gogitLastMessage(t, v))
+
+	// Nothing changed, so no commit is created.
+	output, err = gogitCommit(v, "chore: nothing", filepath.Join("project", "test.json"))
+	assert.NoError(t, err)
+	assert.Empty(t, output)
+	assert.Equal(t, "feat: add test file", gogitLastMessage(t, v))
+
+	// Deleted directories are removed from the index.
+	err = os.RemoveAll(filepath.Join(storagePath, "project"))
+	assert.NoError(t, err)
+
+	_, err = gogitCommit(v, "delete: project", "project")
+	assert.NoError(t, err)
+	assert.Equal(t, "delete: project", gogitLastMessage(t, v))
+
+	repo, err := git.PlainOpen(storagePath)
+	assert.NoError(t, err)
+	idx, err := repo.Storer.Index()
+	assert.NoError(t, err)
+	assert.Empty(t, idx.Entries)
+}
+
+func TestGogitContributors(t *testing.T) {
+	v := setupGogitTestRepo(t)
+	storagePath := v.GetString("storage.path")
+
+	err := os.WriteFile(filepath.Join(storagePath, "file.txt"), []byte("content"), 0o600)
+	assert.NoError(t, err)
+
+	_, err = gogitCommit(v, "Initial commit", "file.txt")
+	assert.NoError(t, err)
+
+	contributors, err := gogitContributors(v)
+	assert.NoError(t, err)
+	assert.Contains(t, contributors, "Test User <test@example.com>")
+}
+
+func TestGogitInitCmd(t *testing.T) {
+	v := setupGogitTestRepo(t)
+
+	msg := gogitInitCmd(v)()
+
+	assert.IsType(t, InitDoneMsg{}, msg)
+
+	_, err := os.Stat(filepath.Join(v.GetString("storage.path"), "INIT"))
+	assert.NoError(t, err, "INIT file should be created")
+	assert.Equal(t, "Initial commit", gogitLastMessage(t, v))
+}
+
+func TestGogitHistory(t *testing.T) {
+	v := setupGogitTestRepo(t)
+	storagePath := v.GetString("storage.path")
+
+	err := os.MkdirAll(filepath.Join(storagePath, "project"), 0o700)
+	assert.NoError(t, err)
+
+	file := filepath.Join("project", "task.json")
+	for i, content := range []string{"{\"title\":\"a\"}\n", "{\"title\":\"b\"}\n"} {
+		err = os.WriteFile(filepath.Join(storagePath, file), []byte(content), 0o600)
+		assert.NoError(t, err)
+
+		_, err = gogitCommit(v, []string{"create: a", "update: b"}[i], file)
+		assert.NoError(t, err)
+	}
+
+	// Changes to other files are not part of the history.
+	err = os.WriteFile(filepath.Join(storagePath, "other.txt"), []byte("other"), 0o600)
+	assert.NoError(t, err)
+	_, err = gogitCommit(v, "other", "other.txt")
+	assert.NoError(t, err)
+
+	msg := gogitHistoryCmd(v, file)()
+
+	history, ok := msg.(HistoryDoneMsg)
+	assert.True(t, ok, "expected HistoryDoneMsg, got %T", msg)
+	assert.Len(t, history.Entries, 2)
+	assert.Equal(t, "update: b", history.Entries[0].Subject)
+	assert.Equal(t, "Test User <test@example.com>", history.Entries[0].Author)
+	assert.Contains(t, history.Entries[0].Diff, "+{\"title\":\"b\"}")
+	assert.Contains(t, history.Entries[0].Diff, "-{\"title\":\"a\"}")
+	assert.Equal(t, "create: a", history.Entries[1].Subject)
+	assert.Contains(t, history.Entries[1].Diff, "+{\"title\":\"a\"}")
+	assert.NotContains(t, history.Entries[1].Diff, "other")
+}
+
+func TestGogitLogAndSnapshot(t *testing.T) {
+	v := setupGogitTestRepo(t)
+	storagePath := v.GetString("storage.path")
+
+	err := os.MkdirAll(filepath.Join(storagePath, "project"), 0o700)
+	assert.NoError(t, err)
+
+	file := filepath.Join("project", "task.json")
+	for i, content := range []string{"{\"title\":\"a\"}\n", "{\"title\":\"b\"}\n"} {
+		err = os.WriteFile(filepath.Join(storagePath, file), []byte(content), 0o600)
+		assert.NoError(t, err)
+
+		_, err = gogitCommit(v, []string{"create: a", "update: b"}[i], file)
+		assert.NoError(t, err)
+	}
+
+	// Only JSON files are part of a snapshot.
+	err = os.WriteFile(filepath.Join(storagePath, "other.txt"), []byte("other"), 0o600)
+	assert.NoError(t, err)
+	_, err = gogitCommit(v, "other", "other.txt")
+	assert.NoError(t, err)
+
+	msg := gogitLogCmd(v, 2)()
+
+	log, ok := msg.(LogDoneMsg)
+	assert.True(t, ok, "expected LogDoneMsg, got %T", msg)
+	assert.Len(t, log.Entries, 2)
+	assert.Equal(t, "other", log.Entries[0].Subject)
+	assert.Equal(t, []string{"other.txt"}, log.Entries[0].Files)
+	assert.Equal(t, "update: b", log.Entries[1].Subject)
+	assert.Equal(t, "Test User <test@example.com>", log.Entries[1].Author)
+	assert.Equal(t, []string{"project/task.json"}, log.Entries[1].Files)
+
+	msg = gogitSnapshotCmd(v, log.Entries[0].Hash[:7])()
+
+	snapshot, ok := msg.(SnapshotDoneMsg)
+	assert.True(t, ok, "expected SnapshotDoneMsg, got %T", msg)
+	assert.Equal(t, map[string][]byte{"project/task.json": []byte("{\"title\":\"b\"}\n")}, snapshot.Files)
+}
+
+func TestGogitFileAtCmd(t *testing.T) {
+	v := setupGogitTestRepo(t)
+	storagePath := v.GetString("storage.path")
+
+	err := os.MkdirAll(filepath.Join(storagePath, "project"), 0o700)
+	assert.NoError(t, err)
+
+	file := filepath.Join("project", "task.json")
+	err = os.WriteFile(filepath.Join(storagePath, file), []byte("{\"title\":\"a\"}\n"), 0o600)
+	assert.NoError(t, err)
+	_, err = gogitCommit(v, "create: a", file)
+	assert.NoError(t, err)
+
+	msg := gogitFileAtCmd(v, "HEAD", []string{"project/archive/task.json", "project/task.json"})()
+
+	fileAt, ok := msg.(FileAtDoneMsg)
+	assert.True(t, ok, "expected FileAtDoneMsg, got %T", msg)
+	assert.Equal(t, "project/task.json", fileAt.File)
+	assert.Equal(t, "{\"title\":\"a\"}\n", string(fileAt.Content))
+
+	msg = gogitFileAtCmd(v, "HEAD", []string{"project/missing.json"})()
+
+	_, ok = msg.(FileAtErrorMsg)
+	assert.True(t, ok, "expected FileAtErrorMsg, got %T", msg)
+}
+
+func TestGogitRevertLastCmd(t *testing.T) {
+	v := setupGogitTestRepo(t)
+	storagePath := v.GetString("storage.path")
+
+	msg := gogitInitCmd(v)()
+	assert.IsType(t, InitDoneMsg{}, msg)
+
+	// The initial commit is never reverted.
+	msg = gogitRevertLastCmd(v)()
+	assert.Equal(t, RevertErrorMsg{"", ErrorNothingToRevert}, msg)
+
+	err := os.MkdirAll(filepath.Join(storagePath, "project"), 0o700)
+	assert.NoError(t, err)
+	file := filepath.Join("project", "task.json")
+	err = os.WriteFile(filepath.Join(storagePath, file), []byte("old"), 0o600)
+	assert.NoError(t, err)
+	_, err = gogitCommit(v, "create: task", file)
+	assert.NoError(t, err)
+
+	err = os.WriteFile(filepath.Join(storagePath, file), []byte("new"), 0o600)
+	assert.NoError(t, err)
+	_, err = gogitCommit(v, "update: task", file)
+	assert.NoError(t, err)
+
+	// Modified files are restored.
+	msg = gogitRevertLastCmd(v)()
+	assert.Equal(t, RevertDoneMsg{Subject: "update: task"}, msg)
+	assert.Equal(t, "Revert \"update: task\"", gogitLastMessage(t, v))
+
+	content, err := os.ReadFile(filepath.Join(storagePath, file))
+	assert.NoError(t, err)
+	assert.Equal(t, "old", string(content))
+
+	// Added files are removed along with their empty directory.
+	err = os.MkdirAll(filepath.Join(storagePath, "other"), 0o700)
+	assert.NoError(t, err)
+	err = os.WriteFile(filepath.Join(storagePath, "other", "project.json"), []byte("other"), 0o600)
+	assert.NoError(t, err)
+	_, err = gogitCommit(v, "create: other", "other")
+	assert.NoError(t, err)
+
+	msg = gogitRevertLastCmd(v)()
+	assert.Equal(t, RevertDoneMsg{Subject: "create: other"}, msg)
+
+	_, err = os.Stat(filepath.Join(storagePath, "other"))
+	assert.True(t, os.IsNotExist(err), "empty project directory should be removed")
+	_, err = os.Stat(filepath.Join(storagePath, file))
+	assert.NoError(t, err, "unrelated files should be kept")
+}
+
+func TestGogitCommitQueuesFailedPush(t *testing.T) {
+	v := setupGogitTestRepo(t)
+	storagePath := v.GetString("storage.path")
+	remotePath := filepath.Join(t.TempDir(), "remote.git")
+
+	assert.IsType(t, InitDoneMsg{}, gogitInitCmd(v)())
+
+	// setupGogitTestRepo does not set the default branch of the repository.
+	v.Set("git.default_branch", "master")
+	v.Set("git.remote.enable", true)
+	v.Set("git.remote.name", "origin")
+
+	repo, err := git.PlainOpen(storagePath)
+	assert.NoError(t, err)
+	_, err = repo.CreateRemote(&config.RemoteConfig{Name: "origin", URLs: []string{remotePath}})
+	assert.NoError(t, err)
+
+	// The remote does not exist yet, so the commit cannot be pushed.
+	for i, name := range []string{"a", "b"} {
+		err = os.WriteFile(filepath.Join(storagePath, name+".json"), []byte(name), 0o600)
+		assert.NoError(t, err)
+
+		msg := gogitCommitCmd(v, "create: "+name, name+".json")()
+		if assert.IsType(t, PushQueuedMsg{}, msg) {
+			assert.Equal(t, i+1, msg.(PushQueuedMsg).Unpushed)
+		}
+	}
+	assert.Equal(t, "create: b", gogitLastMessage(t, v))
+	assert.Equal(t, 2, storage.UnpushedCommits(v))
+
+	// Once the remote is reachable, the commits are pushed.
+	_, err = git.PlainInit(remotePath, true)
+	assert.NoError(t, err)
+
+	assert.Equal(t, PushDoneMsg{}, gogitPushCmd(v)())
+	assert.Equal(t, 0, storage.UnpushedCommits(v))
+
+	head, err := repo.Head()
+	assert.NoError(t, err)
+	remote, err := git.PlainOpen(remotePath)
+	assert.NoError(t, err)
+	ref, err := remote.Reference(head.Name(), true)
+	if assert.NoError(t, err) {
+		assert.Equal(t, head.Hash(), ref.Hash())
+	}
+}
+
+func TestGogitPullDiverged(t *testing.T) {
+	v := setupGogitTestRepo(t)
+	storagePath := v.GetString("storage.path")
+	remotePath := filepath.Join(t.TempDir(), "remote.git")
+
+	assert.IsType(t, InitDoneMsg{}, gogitInitCmd(v)())
+
+	// setupGogitTestRepo does not set the default branch of the repository.
+	v.Set("git.default_branch", "master")
+	v.Set("git.remote.enable", true)
+	v.Set("git.remote.name", "origin")
+
+	_, err := git.PlainInit(remotePath, true)
+	assert.NoError(t, err)
+	repo, err := git.PlainOpen(storagePath)
+	assert.NoError(t, err)
+	_, err = repo.CreateRemote(&config.RemoteConfig{Name: "origin", URLs: []string{remotePath}})
+	assert.NoError(t, err)
+	assert.Equal(t, PushDoneMsg{}, gogitPushCmd(v)())
+
+	// Another device pushes a change.
+	otherPath := t.TempDir()
+	other, err := git.PlainClone(otherPath, false, &git.CloneOptions{URL: remotePath})
+	assert.NoError(t, err)
+	assert.NoError(t, os.WriteFile(filepath.Join(otherPath, "remote.json"), []byte("remote"), 0o600))
+	w, err := other.Worktree()
+	assert.NoError(t, err)
+	_, err = w.Add("remote.json")
+	assert.NoError(t, err)
+	_, err = w.Commit("create: remote", &git.CommitOptions{
+		Author: &object.Signature{Name: "Other", Email: "other@example.com", When: time.Now()},
+	})
+	assert.NoError(t, err)
+	assert.NoError(t, other.Push(&git.PushOptions{}))
+
+	// The local change cannot be combined with it.
+	assert.NoError(t, os.WriteFile(filepath.Join(storagePath, "local.json"), []byte("local"), 0o600))
+	_, err = gogitCommit(v, "create: local", "local.json")
+	assert.NoError(t, err)
+
+	msg := gogitPullCmd(v)()
+	if assert.IsType(t, PullErrorMsg{}, msg) {
+		assert.ErrorIs(t, msg.(PullErrorMsg).Err, ErrDiverged)
+		assert.ErrorIs(t, msg.(PullErrorMsg).Err, git.ErrNonFastForwardUpdate)
+	}
+}
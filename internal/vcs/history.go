@@ -0,0 +1,88 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package vcs
+
+import (
+	"strings"
+	"time"
+)
+
+// historyMarker prefixes the header line of every commit in the log
+// output of the git and jj backends. The header holds the commit hash,
+// author, author date (RFC 3339) and subject separated by tabs.
+const historyMarker = "::yatto::"
+
+// HistoryEntry is a single change to a file as recorded by the vcs.
+type HistoryEntry struct {
+	Hash    string
+	Author  string
+	Date    time.Time
+	Subject string
+	Diff    string
+}
+
+// parseHistory parses log output consisting of historyMarker header
+// lines, each followed by the diff of that commit.
+// Lines before the first header are ignored.
+func parseHistory(output string) []HistoryEntry {
+	var (
+		entries []HistoryEntry
+		diff    []string
+	)
+
+	flush := func() {
+		if len(entries) > 0 {
+			entries[len(entries)-1].Diff = strings.Trim(strings.Join(diff, "\n"), "\n")
+		}
+		diff = nil
+	}
+
+	for line := range strings.SplitSeq(output, "\n") {
+		header, ok := strings.CutPrefix(line, historyMarker)
+		if !ok {
+			if len(entries) > 0 {
+				diff = append(diff, line)
+			}
+
+			continue
+		}
+
+		flush()
+
+		fields := strings.SplitN(header, "\t", 4)
+		for len(fields) < 4 {
+			fields = append(fields, "")
+		}
+
+		date, _ := time.Parse(time.RFC3339, strings.TrimSpace(fields[2]))
+
+		entries = append(entries, HistoryEntry{
+			Hash:    strings.TrimSpace(fields[0]),
+			Author:  strings.TrimSpace(fields[1]),
+			Date:    date,
+			Subject: strings.TrimSpace(fields[3]),
+		})
+	}
+
+	flush()
+
+	return entries
+}
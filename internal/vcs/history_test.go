@@ -0,0 +1,67 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package vcs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseHistory(t *testing.T) {
+	output := historyMarker + "abc123\tAlice <alice@example.com>\t2026-02-14T15:04:05+01:00\tupdate: Task\n" +
+		"\n" +
+		"diff --git a/p/t.json b/p/t.json\n" +
+		"-old\n" +
+		"+new\n" +
+		"\n" +
+		historyMarker + "def456\tBob <bob@example.com>\t2026-02-13T10:00:00Z\tcreate: Task\twith tab\n" +
+		"+new\n"
+
+	entries := parseHistory(output)
+
+	assert.Len(t, entries, 2)
+
+	assert.Equal(t, "abc123", entries[0].Hash)
+	assert.Equal(t, "Alice <alice@example.com>", entries[0].Author)
+	assert.True(t, entries[0].Date.Equal(time.Date(2026, 2, 14, 14, 4, 5, 0, time.UTC)))
+	assert.Equal(t, "update: Task", entries[0].Subject)
+	assert.Equal(t, "diff --git a/p/t.json b/p/t.json\n-old\n+new", entries[0].Diff)
+
+	assert.Equal(t, "def456", entries[1].Hash)
+	assert.Equal(t, "create: Task\twith tab", entries[1].Subject)
+	assert.Equal(t, "+new", entries[1].Diff)
+}
+
+func TestParseHistoryEmpty(t *testing.T) {
+	assert.Empty(t, parseHistory(""))
+	assert.Empty(t, parseHistory("warning: something\n"))
+}
+
+func TestParseHistoryMissingFields(t *testing.T) {
+	entries := parseHistory(historyMarker + "abc123\n")
+
+	assert.Len(t, entries, 1)
+	assert.Equal(t, "abc123", entries[0].Hash)
+	assert.True(t, entries[0].Date.IsZero())
+	assert.Empty(t, entries[0].Subject)
+}
@@ -24,9 +24,13 @@ import (
 	"fmt"
 	"os"
 	"os/exec"
+	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/handlebargh/yatto/internal/config"
 	"github.com/handlebargh/yatto/internal/helpers"
 	"github.com/handlebargh/yatto/internal/storage"
 	"github.com/spf13/viper"
@@ -34,11 +38,14 @@ import (
 
 // jjInitCmd initializes a jj (git compatible) repository in the configured storage path.
 // It creates a jj repo with the default branch and makes an initial commit
-// with a file named "INIT". If "INIT" already exists InitCmd terminates immediately.
+// with a file named "INIT" and the ignore file of the local files of yatto.
+// If "INIT" already exists, only the ignore file is brought up to date.
 // Returns a InitDoneMsg or InitErrorMsg.
 func jjInitCmd(v *viper.Viper) tea.Cmd {
+	cfg := config.Get(v)
+
 	return func() tea.Msg {
-		storagePath := v.GetString("storage.path")
+		storagePath := cfg.StoragePath
 
 		root, err := os.OpenRoot(storagePath)
 		if err != nil {
@@ -47,12 +54,17 @@ func jjInitCmd(v *viper.Viper) tea.Cmd {
 		defer helpers.CloseWithErr(root, &err)
 
 		if _, err := root.Stat("INIT"); err == nil {
+			// Storage directories initialized by older versions of
+			// yatto lack the ignore file.
+			if output, err := jjIgnoreLocalFiles(v); err != nil {
+				return InitErrorMsg{string(output), err}
+			}
 			return InitDoneMsg{}
 		}
 
-		if !v.GetBool("jj.remote.enable") {
+		if !cfg.JJRemoteEnable {
 			var cmd *exec.Cmd
-			if v.GetBool("jj.colocate") {
+			if cfg.JJColocate {
 				cmd = exec.Command("jj", "git", "init", "--colocate")
 			} else {
 				cmd = exec.Command("jj", "git", "init")
@@ -60,22 +72,26 @@ func jjInitCmd(v *viper.Viper) tea.Cmd {
 
 			cmd.Dir = storagePath
 
-			if output, err := cmd.CombinedOutput(); err != nil {
+			if output, err := runCombined(cmd); err != nil {
 				return InitErrorMsg{string(output), err}
 			}
 		}
 
+		if output, err := jjIgnoreLocalFiles(v); err != nil {
+			return InitErrorMsg{string(output), err}
+		}
+
 		f, err := root.Create("INIT")
 		if err != nil {
 			return InitErrorMsg{"cannot create INIT file via root", err}
 		}
 		defer helpers.CloseWithErr(f, &err)
 
-		if output, err := jjCommit(v, "Initial commit"); err != nil {
+		if output, err := jjCommit(v, initialCommitMessage); err != nil {
 			return InitErrorMsg{string(output), err}
 		}
 
-		if v.GetBool("jj.remote.enable") {
+		if cfg.JJRemoteEnable {
 			if output, err := jjPush(v); err != nil {
 				return InitErrorMsg{string(output), err}
 			}
@@ -87,16 +103,22 @@ func jjInitCmd(v *viper.Viper) tea.Cmd {
 
 // jjCommitCmd stages and commits the specified file with the given message.
 // If jj remote support is enabled, it fetches from the remote and rebases before committing.
-// Returns a CommitDoneMsg or CommitErrorMsg.
+// If the commit cannot be pushed, it is recorded for PushCmd and a PushQueuedMsg
+// is returned. Otherwise, returns a CommitDoneMsg or an error message.
 func jjCommitCmd(v *viper.Viper, message string) tea.Cmd {
-	return func() tea.Msg {
-		if v.GetBool("jj.remote.enable") {
-			if output, err := jjFetch(v); err != nil {
-				return PullErrorMsg{string(output), err}
-			}
+	cfg := config.Get(v)
 
-			if output, err := jjRebase(v); err != nil {
-				return PullErrorMsg{string(output), err}
+	return func() tea.Msg {
+		// If the remote is unreachable, commit anyway and push later.
+		var fetchOutput []byte
+		var fetchErr error
+
+		if cfg.JJRemoteEnable {
+			fetchOutput, fetchErr = jjFetch(v)
+			if fetchErr == nil {
+				if output, err := jjRebase(v); err != nil {
+					return PullErrorMsg{string(output), err}
+				}
 			}
 		}
 
@@ -104,16 +126,44 @@ func jjCommitCmd(v *viper.Viper, message string) tea.Cmd {
 			return CommitErrorMsg{string(output), err}
 		}
 
-		if v.GetBool("jj.remote.enable") {
+		if cfg.JJRemoteEnable {
+			if fetchErr != nil {
+				return pushFailed(v, fetchOutput, fetchErr)
+			}
+
 			if output, err := jjPush(v); err != nil {
-				return PushErrorMsg{string(output), err}
+				return pushFailed(v, output, err)
 			}
+
+			pushed(v)
 		}
 
 		return CommitDoneMsg{}
 	}
 }
 
+// jjPushCmd fetches from and pushes to the configured remote.
+// Returns a PushDoneMsg, PullErrorMsg or PushErrorMsg.
+func jjPushCmd(v *viper.Viper) tea.Cmd {
+	return func() tea.Msg {
+		if output, err := jjFetch(v); err != nil {
+			return PullErrorMsg{string(output), err}
+		}
+
+		if output, err := jjRebase(v); err != nil {
+			return PullErrorMsg{string(output), err}
+		}
+
+		if output, err := jjPush(v); err != nil {
+			return pushErrorMsg(v, output, err)
+		}
+
+		pushed(v)
+
+		return PushDoneMsg{}
+	}
+}
+
 // jjPullCmd performs a jj fetch and rebase in the configured storage path.
 // Returns a PullDoneMsg or PullErrorMsg.
 func jjPullCmd(v *viper.Viper) tea.Cmd {
@@ -131,6 +181,8 @@ func jjPullCmd(v *viper.Viper) tea.Cmd {
 			return PullErrorMsg{string(output), err}
 		}
 
+		synced(v)
+
 		return PullDoneMsg{}
 	}
 }
@@ -139,9 +191,9 @@ func jjPullCmd(v *viper.Viper) tea.Cmd {
 // and performs a jj git fetch. Returns an error if any step fails.
 func jjFetch(v *viper.Viper) ([]byte, error) {
 	fetchCmd := exec.Command("jj", "git", "fetch")
-	fetchCmd.Dir = v.GetString("storage.path")
+	fetchCmd.Dir = config.Get(v).StoragePath
 
-	output, err := fetchCmd.CombinedOutput()
+	output, err := runCombined(fetchCmd)
 	if err != nil {
 		return output, err
 	}
@@ -152,8 +204,10 @@ func jjFetch(v *viper.Viper) ([]byte, error) {
 // jjRebase changes the working directory to the configured storage path
 // and performs a jj rebase. Returns an error if any step fails.
 func jjRebase(v *viper.Viper) ([]byte, error) {
-	branch := v.GetString("jj.default_branch")
-	remote := v.GetString("jj.remote.name")
+	cfg := config.Get(v)
+
+	branch := cfg.JJDefaultBranch
+	remote := cfg.JJRemoteName
 
 	rebaseCmd := exec.Command("jj", // #nosec G204 Command use validated config values
 		"rebase",
@@ -162,8 +216,8 @@ func jjRebase(v *viper.Viper) ([]byte, error) {
 		"--destination", fmt.Sprintf("%s@%s", branch, remote),
 	)
 
-	rebaseCmd.Dir = v.GetString("storage.path")
-	output, err := rebaseCmd.CombinedOutput()
+	rebaseCmd.Dir = cfg.StoragePath
+	output, err := runCombined(rebaseCmd)
 	if err != nil {
 		return output, err
 	}
@@ -175,7 +229,7 @@ func jjRebase(v *viper.Viper) ([]byte, error) {
 // If remote is enabled, it pushes the commit to the configured remote and branch.
 // Returns an error if any command fails.
 func jjCommit(v *viper.Viper, message string) ([]byte, error) {
-	storagePath := v.GetString("storage.path")
+	storagePath := config.Get(v).StoragePath
 
 	cmd := exec.Command("jj",
 		"diff",
@@ -187,7 +241,7 @@ func jjCommit(v *viper.Viper, message string) ([]byte, error) {
 	)
 
 	cmd.Dir = storagePath
-	output, err := cmd.Output()
+	output, err := runOutput(cmd)
 	if err != nil {
 		return output, err
 	}
@@ -201,7 +255,7 @@ func jjCommit(v *viper.Viper, message string) ([]byte, error) {
 	)
 
 	commitCmd.Dir = storagePath
-	output, err = commitCmd.CombinedOutput()
+	output, err = runCombined(commitCmd)
 	if err != nil {
 		return output, err
 	}
@@ -209,6 +263,42 @@ func jjCommit(v *viper.Viper, message string) ([]byte, error) {
 	return output, nil
 }
 
+// jjIgnoreLocalFiles writes the ignore file of the storage directory,
+// see storage.WriteIgnoreFile, as jj snapshots all files of the working
+// copy. Local files that were tracked before are no longer tracked, so
+// that their removal is committed along with the next change.
+func jjIgnoreLocalFiles(v *viper.Viper) ([]byte, error) {
+	cfg := config.Get(v)
+
+	changed, err := storage.WriteIgnoreFile(v)
+	if err != nil || !changed {
+		return nil, err
+	}
+
+	listCmd := exec.Command("jj", "file", "list")
+	listCmd.Dir = cfg.StoragePath
+
+	output, err := runOutput(listCmd)
+	if err != nil {
+		return output, err
+	}
+
+	var tracked []string
+	for file := range strings.SplitSeq(string(output), "\n") {
+		if slices.Contains([]string{storage.StateFile, storage.IndexFile, storage.CrashFile}, file) {
+			tracked = append(tracked, file)
+		}
+	}
+	if len(tracked) == 0 {
+		return nil, nil
+	}
+
+	untrackCmd := exec.Command("jj", append([]string{"file", "untrack"}, tracked...)...) // #nosec G204 Arguments are fixed file names
+	untrackCmd.Dir = cfg.StoragePath
+
+	return runCombined(untrackCmd)
+}
+
 // jjPush updates the default branch bookmark in the local Jujutsu repository
 // and pushes it to the configured remote.
 //
@@ -217,11 +307,14 @@ func jjCommit(v *viper.Viper, message string) ([]byte, error) {
 //  2. Moves the default branch bookmark (from config key "jj.default_branch")
 //     to point to @-, i.e. the parent of the working copy commit.
 //  3. Pushes that bookmark to the Git remote specified in
-//     "jj.remote.name".
+//     "jj.remote.name" and afterwards to all configured mirrors,
+//     see pushAll.
 func jjPush(v *viper.Viper) ([]byte, error) {
-	storagePath := v.GetString("storage.path")
-	branch := v.GetString("jj.default_branch")
-	remote := v.GetString("jj.remote.name")
+	cfg := config.Get(v)
+
+	storagePath := cfg.StoragePath
+	branch := cfg.JJDefaultBranch
+	remote := cfg.JJRemoteName
 
 	bookmarkCmd := exec.Command("jj", // #nosec G204 Command uses validated config value
 		"bookmark", "set", branch,
@@ -229,34 +322,68 @@ func jjPush(v *viper.Viper) ([]byte, error) {
 	)
 
 	bookmarkCmd.Dir = storagePath
-	output, err := bookmarkCmd.CombinedOutput()
+	output, err := runCombined(bookmarkCmd)
 	if err != nil {
 		return output, err
 	}
 
-	pushCmd := exec.Command("jj", "git", "push", // #nosec G204 Command uses validated config values
-		"--allow-new",
-		"--remote", remote,
-		"--bookmark", branch,
+	push := func(remote string) ([]byte, error) {
+		pushCmd := exec.Command("jj", "git", "push", // #nosec G204 Command uses validated config values
+			"--allow-new",
+			"--remote", remote,
+			"--bookmark", branch,
+		)
+		pushCmd.Dir = storagePath
+
+		return runCombined(pushCmd)
+	}
+
+	return pushAll(v, remote,
+		func() ([]byte, error) { return push(remote) },
+		func(m Mirror) ([]byte, error) {
+			if output, err := jjEnsureRemote(v, m); err != nil {
+				return output, err
+			}
+
+			return push(m.Name)
+		},
 	)
+}
+
+// jjEnsureRemote adds the mirror as a git remote of the jj repository,
+// unless a remote of that name exists already. jj can only push to
+// remotes known to the repository.
+func jjEnsureRemote(v *viper.Viper, m Mirror) ([]byte, error) {
+	storagePath := config.Get(v).StoragePath
+
+	listCmd := exec.Command("jj", "git", "remote", "list")
+	listCmd.Dir = storagePath
 
-	pushCmd.Dir = storagePath
-	output, err = pushCmd.CombinedOutput()
+	output, err := runCombined(listCmd)
 	if err != nil {
 		return output, err
 	}
 
-	return output, nil
+	for line := range strings.Lines(string(output)) {
+		if name, _, _ := strings.Cut(line, " "); name == m.Name {
+			return nil, nil
+		}
+	}
+
+	addCmd := exec.Command("jj", "git", "remote", "add", m.Name, m.URL) // #nosec G204 Command uses validated config values
+	addCmd.Dir = storagePath
+
+	return runCombined(addCmd)
 }
 
 // jjUser returns the name and email address that is returned by the
 // jj config get command.
 func jjUser(v *viper.Viper) (string, error) {
-	storagePath := v.GetString("storage.path")
+	storagePath := config.Get(v).StoragePath
 
 	nameCmd := exec.Command("jj", "config", "get", "user.name")
 	nameCmd.Dir = storagePath
-	nameOut, err := nameCmd.CombinedOutput()
+	nameOut, err := runCombined(nameCmd)
 	if err != nil {
 		return "", err
 	}
@@ -264,7 +391,7 @@ func jjUser(v *viper.Viper) (string, error) {
 	emailCmd := exec.Command("jj", "config", "get", "user.email")
 	emailCmd.Dir = storagePath
 
-	emailOut, err := emailCmd.CombinedOutput()
+	emailOut, err := runCombined(emailCmd)
 	if err != nil {
 		return "", err
 	}
@@ -277,13 +404,196 @@ func jjUser(v *viper.Viper) (string, error) {
 	return result.String(), nil
 }
 
+// jjRevertLastCmd reverts the most recent commit, i.e. the parent of the
+// working copy commit, by restoring the files it changed to the state of
+// its own parent and committing the result.
+// If jj remote support is enabled, it fetches and rebases before reverting
+// and pushes the revert afterwards.
+// Returns a RevertDoneMsg or RevertErrorMsg.
+func jjRevertLastCmd(v *viper.Viper) tea.Cmd {
+	cfg := config.Get(v)
+
+	return func() tea.Msg {
+		storagePath := cfg.StoragePath
+
+		if cfg.JJRemoteEnable {
+			if output, err := jjFetch(v); err != nil {
+				return PullErrorMsg{string(output), err}
+			}
+
+			if output, err := jjRebase(v); err != nil {
+				return PullErrorMsg{string(output), err}
+			}
+		}
+
+		subjectCmd := exec.Command("jj",
+			"log",
+			"--no-graph",
+			"--revisions", "@-",
+			"--template", "description.first_line()",
+		)
+		subjectCmd.Dir = storagePath
+
+		output, err := runCombined(subjectCmd)
+		if err != nil {
+			return RevertErrorMsg{string(output), err}
+		}
+		subject := strings.TrimSpace(string(output))
+
+		if subject == "" || subject == initialCommitMessage {
+			return RevertErrorMsg{"", ErrorNothingToRevert}
+		}
+
+		filesCmd := exec.Command("jj", "diff", "--name-only", "--revisions", "@-")
+		filesCmd.Dir = storagePath
+
+		output, err = runCombined(filesCmd)
+		if err != nil {
+			return RevertErrorMsg{string(output), err}
+		}
+
+		args := []string{"restore", "--from", "@--", "--into", "@"}
+		args = append(args, strings.Fields(string(output))...)
+
+		restoreCmd := exec.Command("jj", args...) // #nosec G204 Paths are read from jj itself
+		restoreCmd.Dir = storagePath
+
+		if output, err := runCombined(restoreCmd); err != nil {
+			return RevertErrorMsg{string(output), err}
+		}
+
+		if output, err := jjCommit(v, "Revert \""+subject+"\""); err != nil {
+			return RevertErrorMsg{string(output), err}
+		}
+
+		if cfg.JJRemoteEnable {
+			if output, err := jjPush(v); err != nil {
+				return pushErrorMsg(v, output, err)
+			}
+		}
+
+		return RevertDoneMsg{Subject: subject}
+	}
+}
+
+// jjHistoryTemplate renders the historyMarker header line of a commit.
+const jjHistoryTemplate = `"` + historyMarker + `" ++ commit_id ++ "\t" ++ ` +
+	`author.name() ++ " <" ++ author.email() ++ ">\t" ++ ` +
+	`author.timestamp().format("%Y-%m-%dT%H:%M:%S%:z") ++ "\t" ++ ` +
+	`description.first_line() ++ "\n"`
+
+// jjHistoryCmd reads the history of the given file including its diffs
+// from all ancestors of the working copy commit.
+// Returns a HistoryDoneMsg or HistoryErrorMsg.
+func jjHistoryCmd(v *viper.Viper, file string) tea.Cmd {
+	return func() tea.Msg {
+		logCmd := exec.Command("jj", // #nosec G204 File path is built from UUIDs
+			"log",
+			"--no-graph",
+			"--git",
+			"--revisions", "::@",
+			"--template", jjHistoryTemplate,
+			file,
+		)
+		logCmd.Dir = config.Get(v).StoragePath
+
+		output, err := runCombined(logCmd)
+		if err != nil {
+			return HistoryErrorMsg{string(output), err}
+		}
+
+		return HistoryDoneMsg{Entries: parseHistory(string(output))}
+	}
+}
+
+// jjLogCmd reads the most recent commits of the storage repository
+// along with the files changed in each of them. The working copy
+// commit is not included.
+func jjLogCmd(v *viper.Viper, limit int) tea.Cmd {
+	return func() tea.Msg {
+		logCmd := exec.Command("jj",
+			"log",
+			"--no-graph",
+			"--summary",
+			"--limit", strconv.Itoa(limit),
+			"--revisions", "::@- ~ root()",
+			"--template", jjHistoryTemplate,
+		)
+		logCmd.Dir = config.Get(v).StoragePath
+
+		output, err := runCombined(logCmd)
+		if err != nil {
+			return LogErrorMsg{string(output), err}
+		}
+
+		// Summary lines look like "M path/to/file".
+		return LogDoneMsg{Entries: parseLog(string(output), func(line string) string {
+			_, file, _ := strings.Cut(line, " ")
+			return file
+		})}
+	}
+}
+
+// jjSnapshotCmd reads the JSON files of the storage repository
+// at the given commit.
+func jjSnapshotCmd(v *viper.Viper, hash string) tea.Cmd {
+	cfg := config.Get(v)
+
+	return func() tea.Msg {
+		listCmd := exec.Command("jj", "file", "list", "--revision", hash) // #nosec G204 Hash is validated by SnapshotCmd
+		listCmd.Dir = cfg.StoragePath
+
+		output, err := runCombined(listCmd)
+		if err != nil {
+			return SnapshotErrorMsg{string(output), err}
+		}
+
+		files := make(map[string][]byte)
+		for file := range strings.SplitSeq(strings.TrimSpace(string(output)), "\n") {
+			if !isSnapshotFile(file) {
+				continue
+			}
+
+			showCmd := exec.Command("jj", "file", "show", "--revision", hash, "--", file) // #nosec G204 Hash is validated by SnapshotCmd
+			showCmd.Dir = cfg.StoragePath
+
+			content, err := runOutput(showCmd)
+			if err != nil {
+				return SnapshotErrorMsg{"cannot read " + file, err}
+			}
+
+			files[filepath.ToSlash(file)] = content
+		}
+
+		return SnapshotDoneMsg{Hash: hash, Files: files}
+	}
+}
+
+// jjFileAtCmd reads the content of the first of the given files
+// that exists at the given commit.
+func jjFileAtCmd(v *viper.Viper, hash string, files []string) tea.Cmd {
+	return func() tea.Msg {
+		for _, file := range files {
+			showCmd := exec.Command("jj", "file", "show", "--revision", hash, "--", file) // #nosec G204 Hash is validated by FileAtCmd
+			showCmd.Dir = config.Get(v).StoragePath
+
+			// jj only warns about paths that do not exist.
+			if output, err := runOutput(showCmd); err == nil && len(output) > 0 {
+				return FileAtDoneMsg{Hash: hash, File: file, Content: output}
+			}
+		}
+
+		return FileAtErrorMsg{strings.Join(files, "\n"), errFileNotFound}
+	}
+}
+
 // jjContributorEmailAddresses returns all commit author email addresses
 // found by the jj log command.
 func jjContributors(v *viper.Viper) ([]string, error) {
 	emailsCmd := exec.Command("jj", "log", "--template=author")
-	emailsCmd.Dir = v.GetString("storage.path")
+	emailsCmd.Dir = config.Get(v).StoragePath
 
-	output, err := emailsCmd.CombinedOutput()
+	output, err := runCombined(emailsCmd)
 	if err != nil {
 		return nil, err
 	}
@@ -296,3 +606,24 @@ func jjContributors(v *viper.Viper) ([]string, error) {
 
 	return helpers.UniqueNonEmptyStrings(authors), nil
 }
+
+// jjStatus returns the paths of all files changed in the working-copy
+// commit as reported by jj diff.
+func jjStatus(v *viper.Viper) ([]string, error) {
+	diffCmd := exec.Command("jj", "diff", "--name-only")
+	diffCmd.Dir = config.Get(v).StoragePath
+
+	output, err := runCombined(diffCmd)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", strings.TrimSpace(string(output)), err)
+	}
+
+	var files []string
+	for file := range strings.SplitSeq(string(output), "\n") {
+		if file = strings.TrimSpace(file); file != "" {
+			files = append(files, file)
+		}
+	}
+
+	return files, nil
+}
@@ -24,8 +24,10 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/handlebargh/yatto/internal/storage"
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 )
@@ -126,3 +128,62 @@ func TestJjInitCmd(t *testing.T) {
 	_, err := os.Stat(filepath.Join(tempDir, "INIT"))
 	assert.NoError(t, err, "INIT file should be created")
 }
+
+// jjTrackedFiles returns the files tracked in the working copy.
+func jjTrackedFiles(t *testing.T, v *viper.Viper) []string {
+	t.Helper()
+
+	cmd := exec.Command("jj", "file", "list")
+	cmd.Dir = v.GetString("storage.path")
+	output, err := cmd.Output()
+	assert.NoError(t, err)
+
+	return strings.Fields(string(output))
+}
+
+func TestJjInitCmd_IgnoresLocalFiles(t *testing.T) {
+	t.Run("new storage", func(t *testing.T) {
+		storagePath := t.TempDir()
+		v := viper.New()
+		v.Set("storage.path", storagePath)
+		v.Set("jj.default_branch", "main")
+		v.Set("jj.remote.enable", false)
+
+		msg := jjInitCmd(v)()
+		assert.IsType(t, InitDoneMsg{}, msg)
+
+		for _, file := range []string{storage.StateFile, storage.IndexFile, storage.CrashFile, "task.json"} {
+			err := os.WriteFile(filepath.Join(storagePath, file), []byte("{}"), 0o600)
+			assert.NoError(t, err)
+		}
+
+		_, err := jjCommit(v, "create: Task")
+		assert.NoError(t, err)
+
+		tracked := jjTrackedFiles(t, v)
+		assert.Contains(t, tracked, "task.json")
+		assert.Contains(t, tracked, storage.IgnoreFile)
+		assert.NotContains(t, tracked, storage.StateFile)
+		assert.NotContains(t, tracked, storage.IndexFile)
+		assert.NotContains(t, tracked, storage.CrashFile)
+	})
+
+	t.Run("storage of an older version", func(t *testing.T) {
+		v := setupJjTestRepo(t)
+		storagePath := v.GetString("storage.path")
+
+		for _, file := range []string{"INIT", storage.StateFile} {
+			err := os.WriteFile(filepath.Join(storagePath, file), []byte("{}"), 0o600)
+			assert.NoError(t, err)
+		}
+		_, err := jjCommit(v, initialCommitMessage)
+		assert.NoError(t, err)
+		assert.Contains(t, jjTrackedFiles(t, v), storage.StateFile)
+
+		msg := jjInitCmd(v)()
+		assert.IsType(t, InitDoneMsg{}, msg)
+
+		assert.NotContains(t, jjTrackedFiles(t, v), storage.StateFile)
+		assert.FileExists(t, filepath.Join(storagePath, storage.StateFile))
+	})
+}
@@ -0,0 +1,119 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package vcs
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// commitHashRegexp validates commit hashes passed to SnapshotCmd.
+var commitHashRegexp = regexp.MustCompile(`^[0-9a-f]{7,64}$`)
+
+// LogEntry is a single commit of the storage repository.
+// Files holds the slash separated paths of the files changed
+// by the commit, relative to the storage path.
+type LogEntry struct {
+	Hash    string
+	Author  string
+	Date    time.Time
+	Subject string
+	Files   []string
+}
+
+// parseLog parses log output consisting of historyMarker header lines,
+// each followed by the files changed in that commit, one per line.
+// fileName extracts the path from such a line, e.g. by removing a
+// status prefix. Empty paths are ignored.
+func parseLog(output string, fileName func(line string) string) []LogEntry {
+	var entries []LogEntry
+
+	for _, h := range parseHistory(output) {
+		entry := LogEntry{
+			Hash:    h.Hash,
+			Author:  h.Author,
+			Date:    h.Date,
+			Subject: h.Subject,
+		}
+
+		for line := range strings.SplitSeq(h.Diff, "\n") {
+			if file := fileName(strings.TrimSpace(line)); file != "" {
+				entry.Files = append(entry.Files, file)
+			}
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries
+}
+
+// errFileNotFound is returned by FileAtCmd if none of the given
+// paths exists at the requested commit.
+var errFileNotFound = errors.New("file not found at this commit")
+
+// isSnapshotFile reports whether the file at the slash separated
+// path p is part of a snapshot, i.e. a project or task file.
+func isSnapshotFile(p string) bool {
+	return path.Ext(p) == ".json"
+}
+
+// parseCatFileBatch parses the output of git cat-file --batch for the
+// given files, which were requested in this order.
+func parseCatFileBatch(output []byte, files []string) (map[string][]byte, error) {
+	r := bufio.NewReader(bytes.NewReader(output))
+	contents := make(map[string][]byte, len(files))
+
+	for _, file := range files {
+		header, err := r.ReadString('\n')
+		if err != nil {
+			return nil, fmt.Errorf("cannot read %s: %w", file, err)
+		}
+
+		// The header is "<object> <type> <size>" or "<object> missing".
+		fields := strings.Fields(header)
+		if len(fields) != 3 {
+			return nil, fmt.Errorf("cannot read %s: %s", file, strings.TrimSpace(header))
+		}
+
+		size, err := strconv.Atoi(fields[2])
+		if err != nil {
+			return nil, fmt.Errorf("cannot read %s: %w", file, err)
+		}
+
+		content := make([]byte, size+1) // including the trailing newline
+		if _, err := io.ReadFull(r, content); err != nil {
+			return nil, fmt.Errorf("cannot read %s: %w", file, err)
+		}
+
+		contents[file] = content[:size]
+	}
+
+	return contents, nil
+}
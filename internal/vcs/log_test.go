@@ -0,0 +1,67 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package vcs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseLog(t *testing.T) {
+	output := historyMarker + "abc123\tJane <jane@example.com>\t2026-01-02T03:04:05Z\tupdate: b\n" +
+		"\n" +
+		"M project/task.json\n" +
+		"A project/other.json\n" +
+		historyMarker + "def456\tJane <jane@example.com>\t2026-01-01T03:04:05Z\tInitial commit\n"
+
+	entries := parseLog(output, func(line string) string {
+		return line[min(len(line), 2):]
+	})
+
+	assert.Len(t, entries, 2)
+	assert.Equal(t, "abc123", entries[0].Hash)
+	assert.Equal(t, "update: b", entries[0].Subject)
+	assert.Equal(t, []string{"project/task.json", "project/other.json"}, entries[0].Files)
+	assert.Equal(t, "def456", entries[1].Hash)
+	assert.Empty(t, entries[1].Files)
+}
+
+func TestParseCatFileBatch(t *testing.T) {
+	output := []byte("aaa blob 5\nhello\nbbb blob 0\n\n")
+
+	files, err := parseCatFileBatch(output, []string{"a.json", "b.json"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"a.json": []byte("hello"), "b.json": {}}, files)
+
+	_, err = parseCatFileBatch([]byte("HEAD:c.json missing\n"), []string{"c.json"})
+	assert.Error(t, err)
+
+	_, err = parseCatFileBatch([]byte("aaa blob 10\nshort\n"), []string{"a.json"})
+	assert.Error(t, err)
+}
+
+func TestSnapshotCmdInvalidHash(t *testing.T) {
+	msg := SnapshotCmd(nil, "--output=/tmp/x")()
+
+	_, ok := msg.(SnapshotErrorMsg)
+	assert.True(t, ok, "expected SnapshotErrorMsg, got %T", msg)
+}
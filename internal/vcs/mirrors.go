@@ -0,0 +1,113 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package vcs
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/handlebargh/yatto/internal/config"
+	"github.com/spf13/viper"
+)
+
+// Push policies of mirrors.
+const (
+	// MirrorRequired mirrors must be pushed to. A failed push is reported
+	// in the backend error view.
+	MirrorRequired = config.MirrorRequired
+
+	// MirrorOptional mirrors are pushed to on a best effort basis.
+	// A failed push is ignored.
+	MirrorOptional = config.MirrorOptional
+)
+
+// Mirror is an additional remote the storage repository is pushed to.
+type Mirror = config.Mirror
+
+// MirrorError is returned when the push to the remote succeeded,
+// but the push to one or more required mirrors failed.
+type MirrorError struct {
+	Failed []string
+}
+
+// Error implements the error interface.
+func (e *MirrorError) Error() string {
+	return fmt.Sprintf("push to mirror(s) failed: %s", strings.Join(e.Failed, ", "))
+}
+
+// Mirrors returns the mirrors configured for the vcs backend, sorted by name.
+// Mirrors without a policy are optional.
+func Mirrors(v *viper.Viper) []Mirror {
+	return config.Get(v).Mirrors
+}
+
+// pushAll pushes to the remote named remote and afterwards to all mirrors.
+// If the push to the remote fails, the mirrors are skipped and its output
+// and error are returned. Otherwise, the output lists the result for every
+// remote if mirrors are configured, and a *MirrorError is returned if a
+// required mirror could not be pushed to.
+func pushAll(
+	v *viper.Viper,
+	remote string,
+	pushRemote func() ([]byte, error),
+	pushMirror func(Mirror) ([]byte, error),
+) ([]byte, error) {
+	output, err := pushRemote()
+	if err != nil {
+		return output, err
+	}
+
+	mirrors := Mirrors(v)
+	if len(mirrors) == 0 {
+		return output, nil
+	}
+
+	var report strings.Builder
+	var failed []string
+
+	fmt.Fprintf(&report, "✓ %s\n", remote)
+
+	for _, m := range mirrors {
+		output, err := pushMirror(m)
+		if err == nil {
+			fmt.Fprintf(&report, "✓ %s\n", m.Name)
+			continue
+		}
+
+		fmt.Fprintf(&report, "✗ %s (%s): %v\n", m.Name, m.Policy, err)
+		for line := range strings.Lines(strings.TrimSpace(string(output))) {
+			fmt.Fprintf(&report, "    %s", line)
+		}
+		if !strings.HasSuffix(report.String(), "\n") {
+			report.WriteString("\n")
+		}
+
+		if m.Policy == MirrorRequired {
+			failed = append(failed, m.Name)
+		}
+	}
+
+	if len(failed) > 0 {
+		return []byte(report.String()), &MirrorError{Failed: failed}
+	}
+
+	return []byte(report.String()), nil
+}
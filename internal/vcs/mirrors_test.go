@@ -0,0 +1,129 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package vcs
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/handlebargh/yatto/internal/storage"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMirrors(t *testing.T) {
+	v := viper.New()
+	v.Set("vcs.backend", "gogit")
+	v.Set("git.mirrors.usb.url", "/media/usb/yatto.git")
+	v.Set("git.mirrors.backup.url", "git@example.com:me/backup.git")
+	v.Set("git.mirrors.backup.policy", MirrorRequired)
+	v.Set("jj.mirrors.other.url", "/other.git")
+
+	assert.Equal(t, []Mirror{
+		{Name: "backup", URL: "git@example.com:me/backup.git", Policy: MirrorRequired},
+		{Name: "usb", URL: "/media/usb/yatto.git", Policy: MirrorOptional},
+	}, Mirrors(v))
+
+	v.Set("vcs.backend", "jj")
+	assert.Equal(t, []Mirror{{Name: "other", URL: "/other.git", Policy: MirrorOptional}}, Mirrors(v))
+}
+
+// setupGogitRemote enables a bare remote named origin for the
+// repository created by setupGogitTestRepo.
+func setupGogitRemote(t *testing.T, v *viper.Viper) {
+	t.Helper()
+
+	assert.IsType(t, InitDoneMsg{}, gogitInitCmd(v)())
+
+	remotePath := filepath.Join(t.TempDir(), "origin.git")
+	_, err := git.PlainInit(remotePath, true)
+	assert.NoError(t, err)
+
+	repo, err := git.PlainOpen(v.GetString("storage.path"))
+	assert.NoError(t, err)
+	_, err = repo.CreateRemote(&config.RemoteConfig{Name: "origin", URLs: []string{remotePath}})
+	assert.NoError(t, err)
+
+	// setupGogitTestRepo does not set the default branch of the repository.
+	v.Set("git.default_branch", "master")
+	v.Set("git.remote.enable", true)
+	v.Set("git.remote.name", "origin")
+}
+
+func TestGogitPushMirrors(t *testing.T) {
+	v := setupGogitTestRepo(t)
+	v.Set("vcs.backend", "gogit")
+	setupGogitRemote(t, v)
+	storagePath := v.GetString("storage.path")
+
+	mirrorPath := filepath.Join(t.TempDir(), "backup.git")
+	_, err := git.PlainInit(mirrorPath, true)
+	assert.NoError(t, err)
+
+	missingPath := filepath.Join(t.TempDir(), "missing.git")
+
+	commit := func(name string) tea.Msg {
+		t.Helper()
+		err := os.WriteFile(filepath.Join(storagePath, name+".json"), []byte(name), 0o600)
+		assert.NoError(t, err)
+		return gogitCommitCmd(v, "create: "+name, name+".json")()
+	}
+
+	t.Run("pushes to all mirrors", func(t *testing.T) {
+		v.Set("git.mirrors.backup.url", mirrorPath)
+		v.Set("git.mirrors.backup.policy", MirrorRequired)
+
+		assert.Equal(t, CommitDoneMsg{}, commit("a"))
+
+		mirror, err := git.PlainOpen(mirrorPath)
+		assert.NoError(t, err)
+		ref, err := mirror.Reference("refs/heads/master", true)
+		if assert.NoError(t, err) {
+			commit, err := mirror.CommitObject(ref.Hash())
+			assert.NoError(t, err)
+			assert.Equal(t, "create: a", commit.Message)
+		}
+	})
+
+	t.Run("ignores failed optional mirrors", func(t *testing.T) {
+		v.Set("git.mirrors.usb.url", missingPath)
+
+		assert.Equal(t, CommitDoneMsg{}, commit("b"))
+	})
+
+	t.Run("reports failed required mirrors", func(t *testing.T) {
+		v.Set("git.mirrors.usb.policy", MirrorRequired)
+
+		msg := commit("c")
+		if assert.IsType(t, PushErrorMsg{}, msg) {
+			pushErr := msg.(PushErrorMsg)
+			assert.Equal(t, &MirrorError{Failed: []string{"usb"}}, pushErr.Err)
+			assert.Contains(t, pushErr.CmdOutput, "✓ origin\n✓ backup\n✗ usb (required)")
+		}
+
+		// The remote is up to date, so nothing is left to push later.
+		assert.Equal(t, 0, storage.UnpushedCommits(v))
+	})
+}
@@ -0,0 +1,99 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package vcs
+
+import (
+	"errors"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// ErrNoHistory is returned by the none backend for all operations
+// that need a recorded history.
+var ErrNoHistory = errors.New(`no history is recorded with vcs backend "none"`)
+
+// The none backend stores tasks as plain files without version control.
+// Commands that change the repository succeed without doing anything, so
+// that the UI flow is the same as with the other backends.
+
+// noneInitCmd does nothing, as the storage directory needs no setup.
+func noneInitCmd() tea.Cmd {
+	return func() tea.Msg {
+		return InitDoneMsg{}
+	}
+}
+
+// noneCommitCmd does nothing, as changes are only written to disk.
+func noneCommitCmd() tea.Cmd {
+	return func() tea.Msg {
+		return CommitDoneMsg{}
+	}
+}
+
+// nonePullCmd does nothing, as there is no remote to pull from.
+func nonePullCmd() tea.Cmd {
+	return func() tea.Msg {
+		return PullDoneMsg{}
+	}
+}
+
+// nonePushCmd does nothing, as there is no remote to push to.
+func nonePushCmd() tea.Cmd {
+	return func() tea.Msg {
+		return PushDoneMsg{}
+	}
+}
+
+// noneRevertLastCmd reports that there is nothing to undo.
+func noneRevertLastCmd() tea.Cmd {
+	return func() tea.Msg {
+		return RevertErrorMsg{"", fmt.Errorf("%w: %w", ErrorNothingToRevert, ErrNoHistory)}
+	}
+}
+
+// noneHistoryCmd reports that no history is available.
+func noneHistoryCmd() tea.Cmd {
+	return func() tea.Msg {
+		return HistoryErrorMsg{"", ErrNoHistory}
+	}
+}
+
+// noneLogCmd reports that no history is available.
+func noneLogCmd() tea.Cmd {
+	return func() tea.Msg {
+		return LogErrorMsg{"", ErrNoHistory}
+	}
+}
+
+// noneSnapshotCmd reports that no history is available.
+func noneSnapshotCmd() tea.Cmd {
+	return func() tea.Msg {
+		return SnapshotErrorMsg{"", ErrNoHistory}
+	}
+}
+
+// noneFileAtCmd reports that no history is available.
+func noneFileAtCmd() tea.Cmd {
+	return func() tea.Msg {
+		return FileAtErrorMsg{"", ErrNoHistory}
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package vcs
+
+import (
+	"errors"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/handlebargh/yatto/internal/storage"
+	"github.com/spf13/viper"
+)
+
+// pushFailed records that the commit just made could not be pushed and
+// returns a PushQueuedMsg, so that the push can be retried by PushCmd.
+// If the state file cannot be written, a PushErrorMsg is returned instead.
+func pushFailed(v *viper.Viper, output []byte, err error) tea.Msg {
+	var mirrorErr *MirrorError
+	if errors.As(err, &mirrorErr) {
+		return pushErrorMsg(v, output, err)
+	}
+
+	n, stateErr := storage.AddUnpushedCommit(v)
+	if stateErr != nil {
+		return PushErrorMsg{string(output), err}
+	}
+
+	return PushQueuedMsg{Unpushed: n, CmdOutput: string(output), Err: err}
+}
+
+// pushErrorMsg returns a PushErrorMsg for a failed push. If only mirrors
+// failed, the remote is up to date, so unpushed commits are forgotten.
+func pushErrorMsg(v *viper.Viper, output []byte, err error) tea.Msg {
+	var mirrorErr *MirrorError
+	if errors.As(err, &mirrorErr) {
+		pushed(v)
+	}
+
+	return PushErrorMsg{string(output), err}
+}
+
+// pushed forgets about unpushed commits after a successful push.
+// The count is informational only, so errors are ignored.
+func pushed(v *viper.Viper) {
+	_ = storage.ClearUnpushedCommits(v)
+	synced(v)
+}
+
+// synced records the time of a successful pull or push.
+// Like the unpushed commits, it is informational only.
+func synced(v *viper.Viper) {
+	_ = storage.SetLastSync(v, time.Now())
+}
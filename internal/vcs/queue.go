@@ -0,0 +1,203 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package vcs
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/viper"
+)
+
+// commitDelay is how long QueueCommitCmd waits for further commits
+// before committing everything that has been queued.
+var commitDelay = 1500 * time.Millisecond
+
+// commitQueue collects the messages and files of queued commits.
+type commitQueue struct {
+	mu       sync.Mutex
+	messages []string
+	files    []string
+	then     []tea.Cmd // run once the queued commits were made
+	gen      int       // incremented on every queued commit
+	flushing bool      // a queued commit is being made
+
+	flushMu sync.Mutex // serializes flushes
+}
+
+// queue is the commit queue shared by all models of the running program.
+var queue commitQueue
+
+// QueueCommitCmd queues a commit of the given files and returns a command
+// that makes it once no further commit has been queued for a short while.
+// Commits queued in quick succession, e.g. when toggling several tasks one
+// after another, are combined into a single commit with an aggregated message.
+//
+// The command of the last queued commit returns the result of the combined
+// commit, i.e. the message CommitCmd would return. All other commands
+// return a CommitQueuedMsg.
+func QueueCommitCmd(v *viper.Viper, message string, files ...string) tea.Cmd {
+	return QueueCommitThenCmd(v, message, nil, files...)
+}
+
+// QueueCommitThenCmd queues a commit like QueueCommitCmd and runs then in
+// the background once the combined commit was made. If it fails, then is
+// not run.
+func QueueCommitThenCmd(v *viper.Viper, message string, then tea.Cmd, files ...string) tea.Cmd {
+	queue.mu.Lock()
+	queue.messages = append(queue.messages, message)
+	queue.files = append(queue.files, files...)
+	if then != nil {
+		queue.then = append(queue.then, then)
+	}
+	queue.gen++
+	gen := queue.gen
+	queue.mu.Unlock()
+
+	return func() tea.Msg {
+		time.Sleep(commitDelay)
+
+		queue.mu.Lock()
+		latest := gen == queue.gen
+		queue.mu.Unlock()
+
+		if !latest {
+			return CommitQueuedMsg{}
+		}
+
+		return afterCommit(queue.flush(v))
+	}
+}
+
+// AfterCommitCmd returns a command that makes the commit of commit, as
+// returned by CommitCmd, and runs then in the background once it was
+// made. If the commit fails, then is not run.
+func AfterCommitCmd(commit, then tea.Cmd) tea.Cmd {
+	if commit == nil || then == nil {
+		return commit
+	}
+
+	return func() tea.Msg {
+		msg := commit()
+		if _, failed := msg.(CommitErrorMsg); failed {
+			return msg
+		}
+
+		return afterCommit(msg, []tea.Cmd{then})
+	}
+}
+
+// afterCommit returns msg, the result of a commit, together with the
+// commands to run once the commit was made. The commands run concurrently
+// with the handling of msg.
+func afterCommit(msg tea.Msg, then []tea.Cmd) tea.Msg {
+	if len(then) == 0 {
+		return msg
+	}
+
+	return tea.BatchMsg(append([]tea.Cmd{func() tea.Msg { return msg }}, then...))
+}
+
+// FlushCmd returns a command that commits all queued commits right away.
+// It returns a CommitQueuedMsg if nothing is queued.
+func FlushCmd(v *viper.Viper) tea.Cmd {
+	return func() tea.Msg {
+		return afterCommit(queue.flush(v))
+	}
+}
+
+// flushBefore returns a command that makes all queued commits and runs
+// cmd afterwards. If the queued commits fail, their error is returned
+// and cmd is not run.
+func flushBefore(v *viper.Viper, cmd tea.Cmd) tea.Cmd {
+	return func() tea.Msg {
+		msg, then := queue.flush(v)
+		switch msg.(type) {
+		case CommitErrorMsg, PullErrorMsg, PushErrorMsg:
+			return afterCommit(msg, then)
+		}
+
+		return afterCommit(cmd(), then)
+	}
+}
+
+// CommitPending reports whether queued commits have not been made yet.
+// Other backend operations such as pulling should wait until they are.
+func CommitPending() bool {
+	queue.mu.Lock()
+	defer queue.mu.Unlock()
+
+	return len(queue.messages) > 0 || queue.flushing
+}
+
+// flush makes a single commit of everything queued and returns its result
+// together with the commands to run afterwards, see QueueCommitThenCmd.
+// No commands are returned if the commit failed.
+func (q *commitQueue) flush(v *viper.Viper) (tea.Msg, []tea.Cmd) {
+	q.flushMu.Lock()
+	defer q.flushMu.Unlock()
+
+	q.mu.Lock()
+	messages, files, then := q.messages, q.files, q.then
+	q.messages, q.files, q.then = nil, nil, nil
+	q.flushing = len(messages) > 0
+	q.mu.Unlock()
+
+	if len(messages) == 0 {
+		return CommitQueuedMsg{}, nil
+	}
+
+	defer func() {
+		q.mu.Lock()
+		q.flushing = false
+		q.mu.Unlock()
+	}()
+
+	slices.Sort(files)
+
+	msg := CommitCmd(v, aggregateCommitMessages(messages), slices.Compact(files)...)()
+	if _, failed := msg.(CommitErrorMsg); failed {
+		return msg, nil
+	}
+
+	return msg, then
+}
+
+// aggregateCommitMessages combines the messages of queued commits.
+// A single message, or several identical ones, are returned unchanged.
+func aggregateCommitMessages(messages []string) string {
+	var unique []string
+	for _, m := range messages {
+		if !slices.Contains(unique, m) {
+			unique = append(unique, m)
+		}
+	}
+
+	if len(unique) == 1 {
+		return unique[0]
+	}
+
+	return fmt.Sprintf("Apply %d changes\n\n%s", len(unique), strings.Join(unique, "\n\n"))
+}
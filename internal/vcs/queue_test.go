@@ -0,0 +1,135 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package vcs
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAggregateCommitMessages(t *testing.T) {
+	assert.Equal(t, "reorder: a", aggregateCommitMessages([]string{"reorder: a"}))
+	assert.Equal(t, "reorder: a", aggregateCommitMessages([]string{"reorder: a", "reorder: a"}))
+	assert.Equal(t,
+		"Apply 2 changes\n\nreorder: a\n\nreorder: b",
+		aggregateCommitMessages([]string{"reorder: a", "reorder: b", "reorder: a"}),
+	)
+}
+
+func TestQueueCommitCmd(t *testing.T) {
+	v := setupGogitTestRepo(t)
+	v.Set("vcs.backend", "gogit")
+	storagePath := v.GetString("storage.path")
+
+	commitDelay = 50 * time.Millisecond
+	t.Cleanup(func() { commitDelay = 1500 * time.Millisecond })
+
+	assert.NoError(t, os.MkdirAll(filepath.Join(storagePath, "project"), 0o700))
+	for _, name := range []string{"a", "b"} {
+		err := os.WriteFile(filepath.Join(storagePath, "project", name+".json"), []byte(name), 0o600)
+		assert.NoError(t, err)
+	}
+
+	first := QueueCommitCmd(v, "complete: a", filepath.Join("project", "a.json"))
+	second := QueueCommitCmd(v, "complete: b", filepath.Join("project", "b.json"))
+	assert.True(t, CommitPending())
+
+	results := make(chan any, 2)
+	go func() { results <- first() }()
+	go func() { results <- second() }()
+
+	msgs := []any{<-results, <-results}
+	assert.ElementsMatch(t, []any{CommitQueuedMsg{}, CommitDoneMsg{}}, msgs)
+	assert.False(t, CommitPending())
+	assert.Equal(t, "Apply 2 changes\n\ncomplete: a\n\ncomplete: b", gogitLastMessage(t, v))
+
+	status, err := gogitStatus(v)
+	assert.NoError(t, err)
+	assert.Empty(t, status)
+
+	// Nothing is queued anymore.
+	assert.Equal(t, CommitQueuedMsg{}, FlushCmd(v)())
+}
+
+func TestFlushCmd(t *testing.T) {
+	v := setupGogitTestRepo(t)
+	v.Set("vcs.backend", "gogit")
+	storagePath := v.GetString("storage.path")
+
+	commitDelay = 50 * time.Millisecond
+	t.Cleanup(func() { commitDelay = 1500 * time.Millisecond })
+
+	err := os.WriteFile(filepath.Join(storagePath, "a.json"), []byte("a"), 0o600)
+	assert.NoError(t, err)
+
+	queued := QueueCommitCmd(v, "create: a", "a.json")
+	assert.Equal(t, CommitDoneMsg{}, FlushCmd(v)())
+	assert.Equal(t, "create: a", gogitLastMessage(t, v))
+
+	// The queued command finds its commit already made.
+	assert.Equal(t, CommitQueuedMsg{}, queued())
+}
+
+func TestQueueCommitThenCmd(t *testing.T) {
+	v := setupGogitTestRepo(t)
+	v.Set("vcs.backend", "gogit")
+	storagePath := v.GetString("storage.path")
+
+	err := os.WriteFile(filepath.Join(storagePath, "a.json"), []byte("a"), 0o600)
+	assert.NoError(t, err)
+
+	then := func() tea.Msg { return "hooks run" }
+	_ = QueueCommitThenCmd(v, "complete: a", then, "a.json")
+
+	batch, ok := FlushCmd(v)().(tea.BatchMsg)
+	if assert.True(t, ok) && assert.Len(t, batch, 2) {
+		assert.Equal(t, CommitDoneMsg{}, batch[0]())
+		assert.Equal(t, "hooks run", batch[1]())
+	}
+	assert.Equal(t, "complete: a", gogitLastMessage(t, v))
+}
+
+func TestAfterCommitCmd(t *testing.T) {
+	then := func() tea.Msg { return "hooks run" }
+
+	t.Run("runs then after a commit", func(t *testing.T) {
+		commit := func() tea.Msg { return CommitDoneMsg{} }
+
+		batch, ok := AfterCommitCmd(commit, then)().(tea.BatchMsg)
+		if assert.True(t, ok) && assert.Len(t, batch, 2) {
+			assert.Equal(t, CommitDoneMsg{}, batch[0]())
+			assert.Equal(t, "hooks run", batch[1]())
+		}
+	})
+
+	t.Run("does not run then after a failed commit", func(t *testing.T) {
+		failed := CommitErrorMsg{Err: errors.New("nothing to commit")}
+		commit := func() tea.Msg { return failed }
+
+		assert.Equal(t, failed, AfterCommitCmd(commit, then)())
+	})
+}
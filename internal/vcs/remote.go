@@ -0,0 +1,314 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package vcs
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	gitconfig "github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/handlebargh/yatto/internal/config"
+	"github.com/spf13/viper"
+)
+
+// ErrNoRemoteSection is returned by the remote functions if the vcs
+// backend has no remote, i.e. if it is "none".
+var ErrNoRemoteSection = errors.New("the configured vcs backend has no remote repository")
+
+// RemoteError explains a failed connection to a remote repository
+// and what the user can do about it. Network is set if the remote
+// could not be reached at all, as opposed to refusing access.
+type RemoteError struct {
+	Problem string
+	Hint    string
+	Network bool
+	Err     error
+}
+
+// Error implements the error interface.
+func (e *RemoteError) Error() string {
+	return e.Problem + ": " + e.Hint
+}
+
+// Unwrap returns the underlying error.
+func (e *RemoteError) Unwrap() error {
+	return e.Err
+}
+
+// remoteProblems maps parts of the messages of git, jj and go-git to
+// the problem they indicate. The first match wins.
+var remoteProblems = []struct {
+	patterns []string
+	problem  string
+	hint     string
+	network  bool
+}{
+	{
+		[]string{"ssh_auth_sock", "error creating ssh agent"},
+		"No ssh-agent running",
+		"start an ssh-agent and add your key with 'ssh-add'",
+		false,
+	},
+	{
+		[]string{"permission denied (publickey", "unable to authenticate", "no supported methods remain"},
+		"SSH authentication failed",
+		"add your key to the ssh-agent with 'ssh-add' and make sure it is registered with the Git host",
+		false,
+	},
+	{
+		[]string{"host key verification failed", "knownhosts: key is unknown", "key mismatch"},
+		"Unknown SSH host key",
+		"connect once with 'ssh -T <host>' to check and accept the host key",
+		false,
+	},
+	{
+		[]string{"authentication failed", "authentication required", "could not read username",
+			"could not read password", "terminal prompts disabled", "invalid username or password",
+			"authorization failed"},
+		"HTTPS authentication failed",
+		"put an access token into the URL (https://<user>:<token>@<host>/<repo>.git) or set up a git credential helper",
+		false,
+	},
+	{
+		[]string{"repository not found", "does not appear to be a git repository"},
+		"Repository not found",
+		"check the remote URL and that your account has access to the repository",
+		false,
+	},
+	{
+		[]string{"could not resolve host", "no such host", "name or service not known"},
+		"Host not found",
+		"check the host name of the remote URL and your network connection",
+		true,
+	},
+	{
+		[]string{"connection refused", "connection timed out", "operation timed out",
+			"network is unreachable", "i/o timeout", "no route to host"},
+		"Remote host unreachable",
+		"check your network connection, changes are pushed once the remote can be reached",
+		true,
+	},
+}
+
+// DiagnoseRemote returns a RemoteError if the output or error of a
+// pull, push or clone shows a known connection or authentication
+// problem, or nil otherwise.
+func DiagnoseRemote(output string, err error) *RemoteError {
+	if err == nil {
+		return nil
+	}
+
+	var remoteErr *RemoteError
+	if errors.As(err, &remoteErr) {
+		return remoteErr
+	}
+
+	text := strings.ToLower(output + "\n" + err.Error())
+	for _, p := range remoteProblems {
+		for _, pattern := range p.patterns {
+			if strings.Contains(text, pattern) {
+				return &RemoteError{Problem: p.problem, Hint: p.hint, Network: p.network, Err: err}
+			}
+		}
+	}
+
+	return nil
+}
+
+// RemoteSection returns the config section holding the remote of the
+// configured vcs backend, or an empty string if it has none. The gogit
+// backend shares the git configuration section.
+func RemoteSection(v *viper.Viper) string {
+	switch config.Get(v).VCSBackend {
+	case "git", "gogit":
+		return "git"
+	case "jj":
+		return "jj"
+	default:
+		return ""
+	}
+}
+
+// TestRemote checks that the repository at rawURL can be reached and
+// read with the credentials the vcs backend uses. The git backend runs
+// git ls-remote without prompting, so the SSH configuration and
+// credential helpers of git apply, but keys need to be in the ssh-agent
+// if they have a passphrase. The gogit and jj backends use the built-in client, which
+// authenticates SSH remotes with the ssh-agent. Known problems are
+// returned as RemoteError.
+func TestRemote(v *viper.Viper, rawURL string) error {
+	var output []byte
+	var err error
+
+	switch config.Get(v).VCSBackend {
+	case "git":
+		cmd := exec.Command("git", "ls-remote", "--heads", "--", rawURL) // #nosec G204 URL is passed after --
+		// Fail instead of waiting for a password nobody can enter.
+		cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+		if os.Getenv("GIT_SSH_COMMAND") == "" {
+			cmd.Env = append(cmd.Env, "GIT_SSH_COMMAND=ssh -o BatchMode=yes")
+		}
+		output, err = runCombined(cmd)
+	case "gogit", "jj":
+		remote := git.NewRemote(memory.NewStorage(), &gitconfig.RemoteConfig{
+			Name: "origin",
+			URLs: []string{rawURL},
+		})
+		_, err = remote.List(&git.ListOptions{})
+		if errors.Is(err, transport.ErrEmptyRemoteRepository) {
+			err = nil
+		}
+	default:
+		return ErrNoRemoteSection
+	}
+
+	if err == nil {
+		return nil
+	}
+
+	if remoteErr := DiagnoseRemote(string(output), err); remoteErr != nil {
+		return remoteErr
+	}
+
+	return fmt.Errorf("%w\n%s", err, strings.TrimSpace(string(output)))
+}
+
+// RemoteAuth describes how the vcs backend authenticates with the
+// repository at rawURL, e.g. for yatto remote show.
+func RemoteAuth(v *viper.Viper, rawURL string) string {
+	backend := config.Get(v).VCSBackend
+
+	u, err := url.Parse(rawURL)
+	switch {
+	case err == nil && (u.Scheme == "http" || u.Scheme == "https"):
+		if _, ok := u.User.Password(); ok {
+			return "access token in the URL"
+		}
+		if backend == "git" {
+			helper, _ := runOutput(exec.Command("git", "config", "credential.helper"))
+			if h := strings.TrimSpace(string(helper)); h != "" {
+				return fmt.Sprintf("git credential helper (%s)", h)
+			}
+		}
+		return "none, only public repositories can be read"
+	case err == nil && u.Scheme == "file", !strings.Contains(rawURL, ":"):
+		return "none, local repository"
+	}
+
+	agent := "ssh-agent"
+	if os.Getenv("SSH_AUTH_SOCK") == "" {
+		agent = "ssh-agent, but SSH_AUTH_SOCK is not set"
+	}
+	if backend == "git" {
+		return fmt.Sprintf("SSH keys of ~/.ssh or %s", agent)
+	}
+
+	return agent
+}
+
+// RedactURL returns rawURL with the password or token masked.
+func RedactURL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+
+	return u.Redacted()
+}
+
+// SetRemoteURL points the remote of the storage repository to rawURL,
+// adding the remote if the repository has none of the configured name.
+// Nothing is done if the storage repository does not exist yet.
+func SetRemoteURL(v *viper.Viper, rawURL string) error {
+	cfg := config.Get(v)
+
+	storagePath := cfg.StoragePath
+
+	switch cfg.VCSBackend {
+	case "git":
+		if _, err := os.Stat(filepath.Join(storagePath, ".git")); err != nil {
+			return nil
+		}
+
+		name := cfg.GitRemoteName
+		action := "set-url"
+		if err := runCmd(exec.Command("git", "-C", storagePath, "remote", "get-url", name)); err != nil { // #nosec G204 Command uses validated config values
+			action = "add"
+		}
+
+		cmd := exec.Command("git", "remote", action, name, rawURL) // #nosec G204 Command uses validated config values
+		cmd.Dir = storagePath
+		if output, err := runCombined(cmd); err != nil {
+			return fmt.Errorf("%w\n%s", err, strings.TrimSpace(string(output)))
+		}
+	case "gogit":
+		repo, err := git.PlainOpen(storagePath)
+		if errors.Is(err, git.ErrRepositoryNotExists) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		name := cfg.GitRemoteName
+		if err := repo.DeleteRemote(name); err != nil && !errors.Is(err, git.ErrRemoteNotFound) {
+			return err
+		}
+		if _, err := repo.CreateRemote(&gitconfig.RemoteConfig{Name: name, URLs: []string{rawURL}}); err != nil {
+			return err
+		}
+	case "jj":
+		if _, err := os.Stat(filepath.Join(storagePath, ".jj")); err != nil {
+			return nil
+		}
+
+		name := cfg.JJRemoteName
+		list, err := runOutput(exec.Command("jj", "-R", storagePath, "git", "remote", "list")) // #nosec G204 Command uses validated config values
+		if err != nil {
+			return err
+		}
+
+		action := "add"
+		for line := range strings.Lines(string(list)) {
+			if strings.HasPrefix(line, name+" ") {
+				action = "set-url"
+			}
+		}
+
+		cmd := exec.Command("jj", "git", "remote", action, name, rawURL) // #nosec G204 Command uses validated config values
+		cmd.Dir = storagePath
+		if output, err := runCombined(cmd); err != nil {
+			return fmt.Errorf("%w\n%s", err, strings.TrimSpace(string(output)))
+		}
+	default:
+		return ErrNoRemoteSection
+	}
+
+	return nil
+}
@@ -24,18 +24,26 @@
 package vcs
 
 import (
+	"fmt"
+	"path/filepath"
+
 	tea "github.com/charmbracelet/bubbletea"
+	"github.com/handlebargh/yatto/internal/config"
 	"github.com/spf13/viper"
 )
 
 // InitCmd returns the backend specific init command according
 // to configuration.
 func InitCmd(v *viper.Viper) tea.Cmd {
-	switch v.GetString("vcs.backend") {
+	switch config.Get(v).VCSBackend {
 	case "git":
 		return gitInitCmd(v)
+	case "gogit":
+		return gogitInitCmd(v)
 	case "jj":
 		return jjInitCmd(v)
+	case "none":
+		return noneInitCmd()
 	default:
 		return nil
 	}
@@ -44,11 +52,15 @@ func InitCmd(v *viper.Viper) tea.Cmd {
 // CommitCmd returns the backend specific commit command according
 // to configuration.
 func CommitCmd(v *viper.Viper, message string, files ...string) tea.Cmd {
-	switch v.GetString("vcs.backend") {
+	switch config.Get(v).VCSBackend {
 	case "git":
 		return gitCommitCmd(v, message, files...)
+	case "gogit":
+		return gogitCommitCmd(v, message, files...)
 	case "jj":
 		return jjCommitCmd(v, message)
+	case "none":
+		return noneCommitCmd()
 	default:
 		return nil
 	}
@@ -57,22 +69,133 @@ func CommitCmd(v *viper.Viper, message string, files ...string) tea.Cmd {
 // PullCmd returns the backend specific pull/fetch command according
 // to configuration.
 func PullCmd(v *viper.Viper) tea.Cmd {
-	switch v.GetString("vcs.backend") {
+	switch config.Get(v).VCSBackend {
 	case "git":
 		return gitPullCmd(v)
+	case "gogit":
+		return gogitPullCmd(v)
 	case "jj":
 		return jjPullCmd(v)
+	case "none":
+		return nonePullCmd()
+	default:
+		return nil
+	}
+}
+
+// PushCmd returns the backend specific command that pulls from and
+// pushes to the remote according to configuration. It is used to push
+// commits that could not be pushed when they were made.
+func PushCmd(v *viper.Viper) tea.Cmd {
+	switch config.Get(v).VCSBackend {
+	case "git":
+		return gitPushCmd(v)
+	case "gogit":
+		return gogitPushCmd(v)
+	case "jj":
+		return jjPushCmd(v)
+	case "none":
+		return nonePushCmd()
+	default:
+		return nil
+	}
+}
+
+// FileAtCmd returns the backend specific command that reads the content
+// of a file at the given commit according to configuration. The file is
+// looked up at each of the given paths in turn, which allows to find
+// files that were moved since. The working copy is left untouched.
+func FileAtCmd(v *viper.Viper, hash string, files ...string) tea.Cmd {
+	if !commitHashRegexp.MatchString(hash) {
+		return func() tea.Msg {
+			return FileAtErrorMsg{"", fmt.Errorf("invalid commit hash: %q", hash)}
+		}
+	}
+
+	paths := make([]string, len(files))
+	for i, file := range files {
+		paths[i] = filepath.ToSlash(filepath.Clean(file))
+	}
+
+	switch config.Get(v).VCSBackend {
+	case "git":
+		return gitFileAtCmd(v, hash, paths)
+	case "gogit":
+		return gogitFileAtCmd(v, hash, paths)
+	case "jj":
+		return jjFileAtCmd(v, hash, paths)
+	case "none":
+		return noneFileAtCmd()
+	default:
+		return nil
+	}
+}
+
+// LogCmd returns the backend specific command that reads the most
+// recent commits of the storage repository, at most limit of them,
+// according to configuration.
+func LogCmd(v *viper.Viper, limit int) tea.Cmd {
+	switch config.Get(v).VCSBackend {
+	case "git":
+		return gitLogCmd(v, limit)
+	case "gogit":
+		return gogitLogCmd(v, limit)
+	case "jj":
+		return jjLogCmd(v, limit)
+	case "none":
+		return noneLogCmd()
+	default:
+		return nil
+	}
+}
+
+// SnapshotCmd returns the backend specific command that reads the
+// JSON files of the storage repository at the given commit according
+// to configuration. The working copy is left untouched.
+func SnapshotCmd(v *viper.Viper, hash string) tea.Cmd {
+	if !commitHashRegexp.MatchString(hash) {
+		return func() tea.Msg {
+			return SnapshotErrorMsg{"", fmt.Errorf("invalid commit hash: %q", hash)}
+		}
+	}
+
+	switch config.Get(v).VCSBackend {
+	case "git":
+		return gitSnapshotCmd(v, hash)
+	case "gogit":
+		return gogitSnapshotCmd(v, hash)
+	case "jj":
+		return jjSnapshotCmd(v, hash)
+	case "none":
+		return noneSnapshotCmd()
 	default:
 		return nil
 	}
 }
 
+// RemoteEnabled reports whether the configured vcs backend
+// synchronizes with a remote repository.
+func RemoteEnabled(v *viper.Viper) bool {
+	cfg := config.Get(v)
+
+	switch cfg.VCSBackend {
+	case "git", "gogit":
+		return cfg.GitRemoteEnable
+	case "jj":
+		return cfg.JJRemoteEnable
+	default:
+		return false
+	}
+}
+
 // User returns the backend specific userEmail command according
 // to configuration.
 func User(v *viper.Viper) (string, error) {
-	switch v.GetString("vcs.backend") {
+	switch config.Get(v).VCSBackend {
 	case "git":
 		return gitUser(v)
+	case "gogit":
+		return gogitUser(v)
 	case "jj":
 		return jjUser(v)
 	default:
@@ -83,12 +206,65 @@ func User(v *viper.Viper) (string, error) {
 // AllContributors returns the backend specific
 // contributors command according to configuration.
 func AllContributors(v *viper.Viper) ([]string, error) {
-	switch v.GetString("vcs.backend") {
+	switch config.Get(v).VCSBackend {
 	case "git":
 		return gitContributors(v)
+	case "gogit":
+		return gogitContributors(v)
 	case "jj":
 		return jjContributors(v)
 	default:
 		return nil, nil
 	}
 }
+
+// RevertLastCmd returns the backend specific command that reverts
+// the most recent commit according to configuration.
+// Queued commits are made first, so that the last change is undone.
+func RevertLastCmd(v *viper.Viper) tea.Cmd {
+	switch config.Get(v).VCSBackend {
+	case "git":
+		return flushBefore(v, gitRevertLastCmd(v))
+	case "gogit":
+		return flushBefore(v, gogitRevertLastCmd(v))
+	case "jj":
+		return flushBefore(v, jjRevertLastCmd(v))
+	case "none":
+		return flushBefore(v, noneRevertLastCmd())
+	default:
+		return nil
+	}
+}
+
+// HistoryCmd returns the backend specific command that reads the
+// change history of the given file, relative to the storage path,
+// according to configuration.
+func HistoryCmd(v *viper.Viper, file string) tea.Cmd {
+	switch config.Get(v).VCSBackend {
+	case "git":
+		return gitHistoryCmd(v, file)
+	case "gogit":
+		return gogitHistoryCmd(v, file)
+	case "jj":
+		return jjHistoryCmd(v, file)
+	case "none":
+		return noneHistoryCmd()
+	default:
+		return nil
+	}
+}
+
+// Status returns the backend specific list of files with uncommitted
+// changes, relative to the storage path, according to configuration.
+func Status(v *viper.Viper) ([]string, error) {
+	switch config.Get(v).VCSBackend {
+	case "git":
+		return gitStatus(v)
+	case "gogit":
+		return gogitStatus(v)
+	case "jj":
+		return jjStatus(v)
+	default:
+		return nil, nil
+	}
+}
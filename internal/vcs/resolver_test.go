@@ -47,6 +47,32 @@ func TestResolver(t *testing.T) {
 		assert.NotNil(t, PullCmd(v))
 	})
 
+	t.Run("returns gogit commands when backend is gogit", func(t *testing.T) {
+		v := viper.New()
+		v.Set("vcs.backend", "gogit")
+		assert.NotNil(t, InitCmd(v))
+		assert.NotNil(t, CommitCmd(v, "test"))
+		assert.NotNil(t, PullCmd(v))
+	})
+
+	t.Run("returns no-op commands when backend is none", func(t *testing.T) {
+		v := viper.New()
+		v.Set("vcs.backend", "none")
+		assert.IsType(t, InitDoneMsg{}, InitCmd(v)())
+		assert.IsType(t, CommitDoneMsg{}, CommitCmd(v, "test", "file.json")())
+		assert.IsType(t, PullDoneMsg{}, PullCmd(v)())
+		assert.IsType(t, PushDoneMsg{}, PushCmd(v)())
+
+		msg, ok := RevertLastCmd(v)().(RevertErrorMsg)
+		assert.True(t, ok)
+		assert.ErrorIs(t, msg.Err, ErrorNothingToRevert)
+
+		history, ok := HistoryCmd(v, "file.json")().(HistoryErrorMsg)
+		assert.True(t, ok)
+		assert.ErrorIs(t, history.Err, ErrNoHistory)
+		assert.False(t, RemoteEnabled(v))
+	})
+
 	t.Run("returns nil for unknown backend", func(t *testing.T) {
 		v := viper.New()
 		v.Set("vcs.backend", "unknown")
@@ -0,0 +1,368 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+// Package yatto provides a Go API for the storage directory of yatto.
+// It lets other programs, e.g. bots or editor plugins, list and change
+// projects and tasks without running the TUI. Changes are committed
+// with the configured VCS backend just like the yatto commands do, and
+// the configured hooks are run afterwards. Failed hooks are reported by
+// errors wrapping ErrHook.
+package yatto
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/google/uuid"
+	"github.com/handlebargh/yatto/internal/config"
+	"github.com/handlebargh/yatto/internal/helpers"
+	"github.com/handlebargh/yatto/internal/hooks"
+	"github.com/handlebargh/yatto/internal/items"
+	"github.com/handlebargh/yatto/internal/vcs"
+	"github.com/spf13/viper"
+)
+
+type (
+	// Project is a project of the storage directory.
+	Project = items.Project
+
+	// Task is a task of a project.
+	Task = items.Task
+
+	// SkippedFilesError reports files that could not be read. It is
+	// returned along with the items that were read successfully.
+	SkippedFilesError = items.SkippedFilesError
+)
+
+var (
+	// ErrNotFound is returned if a project or task does not exist.
+	ErrNotFound = errors.New("not found")
+
+	// ErrInvalid is returned if a task is not valid, e.g. has no title.
+	ErrInvalid = errors.New("invalid task")
+
+	// ErrBlocked is returned if a task blocked by open tasks is completed.
+	ErrBlocked = errors.New("blocked by open task(s)")
+
+	// ErrNoRemote is returned by Sync if no remote repository is enabled.
+	ErrNoRemote = errors.New("no remote repository enabled, see the git.remote or jj.remote settings")
+
+	// ErrHook is wrapped by the errors of failed hooks. The change the
+	// hooks were run for was made nonetheless, so its result is returned
+	// along with the error.
+	ErrHook = errors.New("hook failed")
+)
+
+// Client reads and changes the storage directory of a configuration.
+// A Client must not be used by several goroutines at once.
+type Client struct {
+	v *viper.Viper
+}
+
+// Open reads the config file at configPath, or the default config file
+// if configPath is empty, and returns a Client for its storage directory.
+func Open(configPath string) (*Client, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return nil, err
+	}
+
+	v := viper.New()
+	config.InitConfig(v, home, &configPath)
+	if err := v.ReadInConfig(); err != nil {
+		return nil, fmt.Errorf("could not read config file: %w", err)
+	}
+
+	return New(v)
+}
+
+// New returns a Client for the configuration in v, which uses the same
+// keys as the config file. All settings must be set, see Open for
+// reading a config file with the default values. Unlike the yatto command, New does not create
+// a missing storage directory.
+func New(v *viper.Viper) (*Client, error) {
+	cfg := config.Get(v)
+
+	if _, err := config.Load(v); err != nil {
+		return nil, err
+	}
+
+	info, err := os.Stat(cfg.StoragePath)
+	if err != nil {
+		return nil, fmt.Errorf("could not open storage directory: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("storage path %s is not a directory", cfg.StoragePath)
+	}
+
+	if err := run(vcs.InitCmd(v)); err != nil {
+		return nil, err
+	}
+
+	return &Client{v: v}, nil
+}
+
+// ListProjects returns all projects sorted like in the project list.
+func (c *Client) ListProjects() ([]Project, error) {
+	projects, err := helpers.ReadProjectsFromFS(c.v)
+	items.SortProjects(projects)
+
+	return projects, err
+}
+
+// ListTasks returns the tasks of the project with the given ID.
+// Archived tasks are not included.
+func (c *Client) ListTasks(projectID string) ([]Task, error) {
+	project, err := c.project(projectID)
+	if err != nil {
+		return nil, err
+	}
+
+	return project.ReadTasksFromFS(c.v)
+}
+
+// CreateTask adds the task to the project with the given ID and commits
+// it. The task gets a new ID and, if it has none, the current user as
+// author. The created task is returned.
+func (c *Client) CreateTask(projectID string, task Task) (Task, error) {
+	if strings.TrimSpace(task.Title) == "" {
+		return Task{}, fmt.Errorf("%w: title must not be empty", ErrInvalid)
+	}
+
+	project, err := c.project(projectID)
+	if err != nil {
+		return Task{}, err
+	}
+
+	task.ID = uuid.NewString()
+	if task.Priority == "" {
+		task.Priority = "low"
+	}
+	if task.Author == "" {
+		// Ignore error just like the task form does.
+		task.Author, _ = vcs.User(c.v)
+	}
+
+	if err := run(task.WriteTaskJSON(c.v, *project, "create")); err != nil {
+		return Task{}, err
+	}
+
+	if err := run(vcs.CommitCmd(c.v, fmt.Sprintf("create: %s", task.Title), task.Path(*project))); err != nil {
+		return Task{}, err
+	}
+
+	return task, c.runHooks(task.HookCalls(*project, "create"))
+}
+
+// UpdateTask replaces the task with the same ID in the project with the
+// given ID and commits it. Completing a task this way works like
+// CompleteTask. The ID of the project, the author and archive state of the
+// task and the history of its due date cannot be changed; a new due date is
+// added to the history. The updated task is returned.
+func (c *Client) UpdateTask(projectID string, task Task) (Task, error) {
+	if strings.TrimSpace(task.Title) == "" {
+		return Task{}, fmt.Errorf("%w: title must not be empty", ErrInvalid)
+	}
+
+	project, tasks, old, err := c.task(projectID, task.ID)
+	if err != nil {
+		return Task{}, err
+	}
+
+	task.Author = old.Author
+	task.Archived = old.Archived
+	task.DueChanges = old.DueChanges
+	task.Successor = old.Successor
+	task.RecordDueChange(old.DueDate, time.Now())
+	// Failed hooks do not undo the change.
+	_, err = c.save(project, tasks, old, &task)
+	if err != nil && !errors.Is(err, ErrHook) {
+		return Task{}, err
+	}
+
+	return task, err
+}
+
+// CompleteTask marks the task with the given ID as completed and commits
+// it. Completing a recurring task creates its next occurrence, which is
+// returned. Tasks blocked by open tasks cannot be completed.
+func (c *Client) CompleteTask(projectID, taskID string) (*Task, error) {
+	project, tasks, old, err := c.task(projectID, taskID)
+	if err != nil {
+		return nil, err
+	}
+
+	if old.Completed {
+		return nil, nil
+	}
+
+	task := *old
+	task.Completed = true
+
+	return c.save(project, tasks, old, &task)
+}
+
+// save writes the changed task and commits it. If the task is completed
+// by the change, it is checked for open blockers and the next occurrence
+// of a recurring task is created and returned.
+func (c *Client) save(project *Project, tasks []Task, old, task *Task) (*Task, error) {
+	kind := "update"
+	var next *Task
+
+	if task.Completed && !old.Completed {
+		if blockers := task.OpenBlockers(tasks); len(blockers) > 0 {
+			var titles []string
+			for _, b := range blockers {
+				titles = append(titles, fmt.Sprintf("%q", b.Title))
+			}
+			return nil, fmt.Errorf("%s: %w %s", task.Title, ErrBlocked, strings.Join(titles, ", "))
+		}
+
+		kind = "complete"
+		task.InProgress = false
+		next = task.Recur()
+	}
+
+	writeCmds := []tea.Cmd{task.WriteTaskJSON(c.v, *project, kind)}
+	paths := []string{task.Path(*project)}
+	hookCalls := task.HookCalls(*project, kind)
+	var recurNames []string
+
+	if next != nil {
+		writeCmds = append(writeCmds, next.WriteTaskJSON(c.v, *project, "recur"))
+		paths = append(paths, next.Path(*project))
+		hookCalls = append(hookCalls, next.HookCalls(*project, "recur")...)
+		recurNames = append(recurNames, fmt.Sprintf("%s (due %s)", next.Title, next.DueDateToString()))
+	}
+
+	for _, cmd := range writeCmds {
+		if err := run(cmd); err != nil {
+			return nil, err
+		}
+	}
+
+	commitMsg := fmt.Sprintf("update: %s", task.Title)
+	if kind == "complete" {
+		commitMsg = items.StateChangeCommitMessage("completion", []string{task.Title}, recurNames)
+	}
+	if err := run(vcs.CommitCmd(c.v, commitMsg, paths...)); err != nil {
+		return nil, err
+	}
+
+	return next, c.runHooks(hookCalls)
+}
+
+// runHooks runs the hooks of a committed change, see Task.HookCalls.
+// The errors of failed hooks wrap ErrHook.
+func (c *Client) runHooks(calls []hooks.Call) error {
+	if err := hooks.RunAll(c.v, calls); err != nil {
+		return fmt.Errorf("%w: %w", ErrHook, err)
+	}
+
+	return nil
+}
+
+// Sync pulls from and pushes to the remote repository, including the
+// changes that could not be pushed when they were committed. Afterwards,
+// the sync_finished hooks are run.
+func (c *Client) Sync() error {
+	if !vcs.RemoteEnabled(c.v) {
+		return ErrNoRemote
+	}
+
+	if err := run(vcs.PushCmd(c.v)); err != nil {
+		return err
+	}
+
+	if err := hooks.RunSyncFinished(c.v, time.Now()); err != nil {
+		return fmt.Errorf("%w: %w", ErrHook, err)
+	}
+
+	return nil
+}
+
+// task returns the open task with the given ID together with its project
+// and all open tasks of the project.
+func (c *Client) task(projectID, taskID string) (*Project, []Task, *Task, error) {
+	project, err := c.project(projectID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	tasks, err := project.ReadTasksFromFS(c.v)
+	var skipped *SkippedFilesError
+	if err != nil && !errors.As(err, &skipped) {
+		return nil, nil, nil, err
+	}
+
+	for i := range tasks {
+		if tasks[i].ID == taskID {
+			return project, tasks, &tasks[i], nil
+		}
+	}
+
+	return nil, nil, nil, fmt.Errorf("task %s: %w", taskID, ErrNotFound)
+}
+
+// project returns the project with the given ID.
+func (c *Client) project(id string) (*Project, error) {
+	projects, err := helpers.ReadProjectsFromFS(c.v)
+	for _, project := range projects {
+		if project.ID == id {
+			return &project, nil
+		}
+	}
+
+	var skipped *SkippedFilesError
+	if err != nil && !errors.As(err, &skipped) {
+		return nil, err
+	}
+
+	return nil, fmt.Errorf("project %s: %w", id, ErrNotFound)
+}
+
+// run executes the given command synchronously and returns the error
+// carried by the resulting message, if any. The output of failed vcs
+// commands is appended to the error. A push that failed after the
+// commit was made is not an error, Sync pushes it later.
+func run(cmd tea.Cmd) error {
+	if cmd == nil {
+		return nil
+	}
+
+	switch msg := cmd().(type) {
+	case vcs.InitErrorMsg:
+		return fmt.Errorf("%w\n%s", msg.Err, msg.CmdOutput)
+	case vcs.CommitErrorMsg:
+		return fmt.Errorf("%w\n%s", msg.Err, msg.CmdOutput)
+	case vcs.PullErrorMsg:
+		return fmt.Errorf("%w\n%s", msg.Err, msg.CmdOutput)
+	case vcs.PushErrorMsg:
+		return fmt.Errorf("%w\n%s", msg.Err, msg.CmdOutput)
+	case error:
+		return msg
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,226 @@
+// Copyright 2025-2026 handlebargh and contributors
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in
+// all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE.
+
+package yatto
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/handlebargh/yatto/internal/items"
+)
+
+func newTestClient(t *testing.T) (*Client, Project) {
+	t.Helper()
+
+	tempDir := t.TempDir()
+	config := filepath.Join(tempDir, "config.toml")
+	data := fmt.Sprintf("[storage]\npath = %q\n\n[vcs]\nbackend = \"none\"\n", tempDir)
+	if err := os.WriteFile(config, []byte(data), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	project := Project{ID: "test-project", Title: "Test Project"}
+	if err := os.Mkdir(filepath.Join(tempDir, project.ID), 0o750); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(tempDir, project.ID, "project.json"), project.MarshalProject(), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := Open(config)
+	if err != nil {
+		t.Fatalf("Open returned an error: %v", err)
+	}
+
+	return c, project
+}
+
+func TestClient(t *testing.T) {
+	c, project := newTestClient(t)
+
+	projects, err := c.ListProjects()
+	if err != nil {
+		t.Fatalf("ListProjects returned an error: %v", err)
+	}
+	if len(projects) != 1 || projects[0].ID != project.ID {
+		t.Fatalf("Expected project %s, but got %v", project.ID, projects)
+	}
+
+	created, err := c.CreateTask(project.ID, Task{Title: "Write report"})
+	if err != nil {
+		t.Fatalf("CreateTask returned an error: %v", err)
+	}
+	if created.ID == "" || created.Priority != "low" {
+		t.Errorf("Expected an ID and the default priority, but got %+v", created)
+	}
+
+	tasks, err := c.ListTasks(project.ID)
+	if err != nil {
+		t.Fatalf("ListTasks returned an error: %v", err)
+	}
+	if len(tasks) != 1 || tasks[0].Title != "Write report" {
+		t.Fatalf("Expected the created task, but got %v", tasks)
+	}
+
+	t.Run("updates tasks", func(t *testing.T) {
+		author := created.Author
+		created.Priority = "high"
+		created.Author = "Jane Doe jane@example.com"
+		updated, err := c.UpdateTask(project.ID, created)
+		if err != nil {
+			t.Fatalf("UpdateTask returned an error: %v", err)
+		}
+		created = updated
+
+		tasks, _ := c.ListTasks(project.ID)
+		if tasks[0].Priority != "high" {
+			t.Errorf("Expected priority high, but got %q", tasks[0].Priority)
+		}
+		if tasks[0].Author != author {
+			t.Errorf("Expected author %q to be kept, but got %q", author, tasks[0].Author)
+		}
+
+		due := time.Now().AddDate(0, 0, 1)
+		created.DueDate = &due
+		created.DueChanges = nil
+		if created, err = c.UpdateTask(project.ID, created); err != nil {
+			t.Fatalf("UpdateTask returned an error: %v", err)
+		}
+		if len(created.DueChanges) != 1 || created.DueChanges[0].From != nil || !created.DueChanges[0].To.Equal(due) {
+			t.Errorf("Expected the new due date in the history, but got %+v", created.DueChanges)
+		}
+
+		created.Title = ""
+		if _, err := c.UpdateTask(project.ID, created); !errors.Is(err, ErrInvalid) {
+			t.Errorf("Expected ErrInvalid, but got %v", err)
+		}
+		created.Title = "Write report"
+	})
+
+	t.Run("completes tasks", func(t *testing.T) {
+		next, err := c.CompleteTask(project.ID, created.ID)
+		if err != nil {
+			t.Fatalf("CompleteTask returned an error: %v", err)
+		}
+		if next != nil {
+			t.Errorf("Expected no next occurrence, but got %v", next)
+		}
+
+		tasks, _ := c.ListTasks(project.ID)
+		if !tasks[0].Completed || tasks[0].CompletedAt == nil {
+			t.Errorf("Expected the task to be completed, but got %+v", tasks[0])
+		}
+	})
+
+	t.Run("creates the next occurrence of recurring tasks", func(t *testing.T) {
+		recurring, err := c.CreateTask(project.ID, Task{
+			Title:      "Water plants",
+			Recurrence: &items.Recurrence{Frequency: "weekly", Interval: 1},
+		})
+		if err != nil {
+			t.Fatalf("CreateTask returned an error: %v", err)
+		}
+
+		next, err := c.CompleteTask(project.ID, recurring.ID)
+		if err != nil {
+			t.Fatalf("CompleteTask returned an error: %v", err)
+		}
+		if next == nil || next.ID == recurring.ID || next.Completed {
+			t.Errorf("Expected a new open occurrence, but got %v", next)
+		}
+
+		// Reopening and completing the task again must not create
+		// another occurrence.
+		_, _, done, err := c.task(project.ID, recurring.ID)
+		if err != nil {
+			t.Fatalf("Reading the completed task returned an error: %v", err)
+		}
+		done.Completed = false
+		if _, err := c.UpdateTask(project.ID, *done); err != nil {
+			t.Fatalf("UpdateTask returned an error: %v", err)
+		}
+
+		again, err := c.CompleteTask(project.ID, recurring.ID)
+		if err != nil {
+			t.Fatalf("CompleteTask returned an error: %v", err)
+		}
+		if again != nil {
+			t.Errorf("Expected no further occurrence, but got %v", again)
+		}
+
+		tasks, err := c.ListTasks(project.ID)
+		if err != nil {
+			t.Fatalf("ListTasks returned an error: %v", err)
+		}
+		var occurrences int
+		for _, task := range tasks {
+			if task.Title == "Water plants" {
+				occurrences++
+			}
+		}
+		if occurrences != 2 {
+			t.Errorf("Expected the task and one next occurrence, but got %d tasks", occurrences)
+		}
+	})
+
+	t.Run("reports missing projects and tasks", func(t *testing.T) {
+		if _, err := c.ListTasks("missing"); !errors.Is(err, ErrNotFound) {
+			t.Errorf("Expected ErrNotFound, but got %v", err)
+		}
+		if _, err := c.CompleteTask(project.ID, "missing"); !errors.Is(err, ErrNotFound) {
+			t.Errorf("Expected ErrNotFound, but got %v", err)
+		}
+	})
+
+	t.Run("requires a remote to sync", func(t *testing.T) {
+		if err := c.Sync(); !errors.Is(err, ErrNoRemote) {
+			t.Errorf("Expected ErrNoRemote, but got %v", err)
+		}
+	})
+
+	t.Run("reports failed hooks", func(t *testing.T) {
+		if runtime.GOOS == "windows" {
+			t.Skip("the hooks use sh")
+		}
+
+		c.v.Set("hooks.task_created", []string{"echo broken >&2; exit 1"})
+		t.Cleanup(func() { c.v.Set("hooks.task_created", nil) })
+
+		hooked, err := c.CreateTask(project.ID, Task{Title: "Call back"})
+		if !errors.Is(err, ErrHook) {
+			t.Fatalf("Expected ErrHook, but got %v", err)
+		}
+		if hooked.ID == "" {
+			t.Errorf("Expected the created task along with the error, but got %+v", hooked)
+		}
+
+		tasks, _ := c.ListTasks(project.ID)
+		if !slices.ContainsFunc(tasks, func(task Task) bool { return task.ID == hooked.ID }) {
+			t.Errorf("Expected the task to be created despite the failed hook")
+		}
+	})
+}
@@ -145,8 +145,12 @@ func (e *e2e) addProject(title, desc string, present []string) {
 	e.tm.Send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'a'}})
 
 	e.confirmField("Select a color", "")
+	e.confirmField("Hex color", "")
+	e.confirmField("Choose an icon", "")
+	e.confirmField("Custom icon", "")
 	e.confirmField("Enter a title", title)
 	e.confirmField("Enter a description", desc)
+	e.confirmField("Custom fields", "")
 	e.confirmField("Create new project?", "y")
 
 	e.waitForMessagesPresent(present)
@@ -160,8 +164,12 @@ func (e *e2e) editProject(title, appendText string, present []string) {
 	e.tm.Send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'e'}})
 
 	e.confirmField("Select a color", "")
+	e.confirmField("Hex color", "")
+	e.confirmField("Choose an icon", "")
+	e.confirmField("Custom icon", "")
 	e.confirmField("Enter a title", appendText)
 	e.confirmField("Enter a description", "")
+	e.confirmField("Custom fields", "")
 	e.confirmField("Edit project?", "y")
 
 	e.waitForMessagesPresent(present)
@@ -176,11 +184,19 @@ func (e *e2e) addTask(title, desc string, present []string) {
 	e.confirmField("Enter a title", title)
 	e.confirmField("Enter a description", desc)
 	e.confirmField("Due Date", "")
+	e.confirmField("Repeat", "")
+	e.confirmField("Estimate", "")
+	e.confirmField("Remind me", "")
+	e.confirmField("Hide the task until", "")
+	e.confirmField("Enter subtasks", "")
+	e.confirmField("Blocked by", "")
 	e.confirmField("Choose existing labels", "")
 	e.confirmField("Enter additional labels", "")
-	e.confirmField("Enter the task author", "")
+	e.confirmField("Task author", "")
 	e.confirmField("Choose an assignee", "")
 	e.confirmField("Enter a new email address", "")
+	e.confirmField("Choose watchers", "")
+	e.confirmField("Enter additional watchers", "")
 	e.confirmField("Create task?", "y")
 
 	e.waitForMessagesPresent(present)
@@ -197,11 +213,19 @@ func (e *e2e) editTask(title, appendTitle, appendDesc string, present []string)
 	e.confirmField("Enter a title", appendTitle)
 	e.confirmField("Enter a description", appendDesc)
 	e.confirmField("Due Date", "")
+	e.confirmField("Repeat", "")
+	e.confirmField("Estimate", "")
+	e.confirmField("Remind me", "")
+	e.confirmField("Hide the task until", "")
+	e.confirmField("Enter subtasks", "")
+	e.confirmField("Blocked by", "")
 	e.confirmField("Choose existing labels", "")
 	e.confirmField("Enter additional labels", "")
-	e.confirmField("Enter the task author", "")
+	e.confirmField("Task author", "")
 	e.confirmField("Choose an assignee", "")
 	e.confirmField("Enter a new email address", "")
+	e.confirmField("Choose watchers", "")
+	e.confirmField("Enter additional watchers", "")
 	e.confirmField("Edit task?", "y")
 
 	e.waitForMessagesPresent(present)